@@ -0,0 +1,201 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (a *API) registerAccessTokensRoutes(r *mux.Router) {
+	// Personal access token APIs. These are not needed in plugin mode.
+	r.HandleFunc("/users/me/access-tokens", a.sessionRequired(model.AccessTokenScopeRead, a.handleGetAccessTokens)).Methods("GET")
+	r.HandleFunc("/users/me/access-tokens", a.sessionRequired(model.AccessTokenScopeAdmin, a.handleCreateAccessToken)).Methods("POST")
+	r.HandleFunc("/users/me/access-tokens/{tokenID}", a.sessionRequired(model.AccessTokenScopeAdmin, a.handleDeleteAccessToken)).Methods("DELETE")
+}
+
+// checkAccessTokensAllowed makes sure that access tokens are managed by the
+// server, and returns the ID of the current user.
+func (a *API) checkAccessTokensAllowed(w http.ResponseWriter, r *http.Request) (string, bool) {
+	if a.MattermostAuth {
+		a.errorResponse(w, r, model.NewErrNotImplemented("not permitted in plugin mode"))
+		return "", false
+	}
+
+	userID := getUserID(r)
+	if userID == model.SingleUser {
+		a.errorResponse(w, r, model.NewErrNotImplemented("not permitted in single-user mode"))
+		return "", false
+	}
+	return userID, true
+}
+
+func (a *API) handleGetAccessTokens(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /users/me/access-tokens getAccessTokens
+	//
+	// Returns the personal access tokens of the current user, without the
+	// tokens themselves.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/AccessToken"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID, ok := a.checkAccessTokensAllowed(w, r)
+	if !ok {
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getAccessTokens", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+
+	tokens, err := a.app.GetAccessTokensForUser(userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(tokens)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleCreateAccessToken(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /users/me/access-tokens createAccessToken
+	//
+	// Creates a personal access token for the current user, with a name,
+	// scopes and an optional expiry time. The token is only returned in
+	// this response, and is used as a bearer token.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: Body
+	//   in: body
+	//   description: the access token to create
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/AccessToken"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/AccessToken"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID, ok := a.checkAccessTokensAllowed(w, r)
+	if !ok {
+		return
+	}
+
+	token, err := model.AccessTokenFromJSON(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "createAccessToken", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
+	auditRec.AddMeta("scopes", strings.Join(token.Scopes, ","))
+	auditRec.AddMeta("expireAt", token.ExpireAt)
+
+	token, err = a.app.CreateAccessToken(token, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("CreateAccessToken",
+		mlog.String("userID", userID),
+		mlog.String("tokenID", token.ID),
+	)
+
+	data, err := json.Marshal(token)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec.AddMeta("tokenID", token.ID)
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteAccessToken(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /users/me/access-tokens/{tokenID} deleteAccessToken
+	//
+	// Revokes a personal access token of the current user.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: tokenID
+	//   in: path
+	//   description: Access token ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID, ok := a.checkAccessTokensAllowed(w, r)
+	if !ok {
+		return
+	}
+	tokenID := mux.Vars(r)["tokenID"]
+
+	auditRec := a.makeAuditRecord(r, "deleteAccessToken", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
+	auditRec.AddMeta("tokenID", tokenID)
+
+	if err := a.app.DeleteAccessToken(userID, tokenID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DeleteAccessToken",
+		mlog.String("userID", userID),
+		mlog.String("tokenID", tokenID),
+	)
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
@@ -2,8 +2,11 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gorilla/mux"
@@ -13,6 +16,231 @@ import (
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 )
 
+func (a *API) handleAdminGetUsers(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	strPage := query.Get("page")
+	strPerPage := query.Get("per_page")
+	if strPage == "" {
+		strPage = defaultPage
+	}
+	if strPerPage == "" {
+		strPerPage = defaultPerPage
+	}
+
+	page, err := strconv.Atoi(strPage)
+	if err != nil || page < 0 {
+		a.errorResponse(w, r, model.NewErrBadRequest(fmt.Sprintf("invalid `page` parameter: %s", strPage)))
+		return
+	}
+	perPage, err := strconv.Atoi(strPerPage)
+	if err != nil || perPage <= 0 {
+		a.errorResponse(w, r, model.NewErrBadRequest(fmt.Sprintf("invalid `per_page` parameter: %s", strPerPage)))
+		return
+	}
+
+	opts := model.QueryUsersOptions{
+		Term:           strings.TrimSpace(query.Get("q")),
+		IncludeDeleted: query.Get("include_deleted") == "true",
+		Page:           page,
+		PerPage:        perPage,
+	}
+
+	auditRec := a.makeAuditRecord(r, "adminGetUsers", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("term", opts.Term)
+	auditRec.AddMeta("includeDeleted", opts.IncludeDeleted)
+	auditRec.AddMeta("page", page)
+	auditRec.AddMeta("per_page", perPage)
+
+	users, err := a.app.GetUsersForAdmin(opts)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(users)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("AdminGetUsers", mlog.Int("userCount", len(users)))
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("userCount", len(users))
+	auditRec.Success()
+}
+
+func (a *API) handleAdminGetUser(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	username := vars["username"]
+
+	auditRec := a.makeAuditRecord(r, "adminGetUser", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("username", username)
+
+	user, err := a.app.GetUserByUsernameForAdmin(username)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(model.NewAdminUser(user))
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("AdminGetUser", mlog.String("userID", user.ID))
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleAdminGetUserBoards(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	username := vars["username"]
+
+	auditRec := a.makeAuditRecord(r, "adminGetUserBoards", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("username", username)
+
+	user, err := a.app.GetUserByUsernameForAdmin(username)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	memberships, err := a.app.GetBoardMembershipsForUser(user.ID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(memberships)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("AdminGetUserBoards",
+		mlog.String("userID", user.ID),
+		mlog.Int("boardCount", len(memberships)),
+	)
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("boardCount", len(memberships))
+	auditRec.Success()
+}
+
+func (a *API) handleAdminDeactivateUser(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	username := vars["username"]
+
+	auditRec := a.makeAuditRecord(r, "adminDeactivateUser", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
+	auditRec.AddMeta("username", username)
+
+	user, err := a.app.GetUserByUsernameForAdmin(username)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// the system admins can't lock themselves out over HTTP
+	if user.ID == getUserID(r) {
+		a.errorResponse(w, r, model.NewErrBadRequest("cannot deactivate yourself"))
+		return
+	}
+
+	if err = a.app.DeactivateUser(user.ID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("AdminDeactivateUser", mlog.String("userID", user.ID))
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) handleAdminReactivateUser(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	username := vars["username"]
+
+	auditRec := a.makeAuditRecord(r, "adminReactivateUser", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
+	auditRec.AddMeta("username", username)
+
+	user, err := a.app.GetUserByUsernameForAdmin(username)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if err = a.app.ReactivateUser(user.ID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("AdminReactivateUser", mlog.String("userID", user.ID))
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+type AdminSetSystemAdminData struct {
+	IsSystemAdmin bool `json:"isSystemAdmin"`
+}
+
+func (a *API) handleAdminSetSystemAdmin(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	username := vars["username"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var requestData AdminSetSystemAdminData
+	err = json.Unmarshal(requestBody, &requestData)
+	if err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "adminSetSystemAdmin", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
+	auditRec.AddMeta("username", username)
+	auditRec.AddMeta("isSystemAdmin", requestData.IsSystemAdmin)
+
+	user, err := a.app.GetUserByUsernameForAdmin(username)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// the system admins can't lock themselves out over HTTP
+	if !requestData.IsSystemAdmin && user.ID == getUserID(r) {
+		a.errorResponse(w, r, model.NewErrBadRequest("cannot remove your own system admin role"))
+		return
+	}
+
+	if err = a.app.UpdateUserSystemAdmin(user.ID, requestData.IsSystemAdmin); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("AdminSetSystemAdmin",
+		mlog.String("userID", user.ID),
+		mlog.Bool("isSystemAdmin", requestData.IsSystemAdmin),
+	)
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
 type AdminSetPasswordData struct {
 	Password string `json:"password"`
 }
@@ -54,3 +282,241 @@ func (a *API) handleAdminSetPassword(w http.ResponseWriter, r *http.Request) {
 	jsonStringResponse(w, http.StatusOK, "{}")
 	auditRec.Success()
 }
+
+type AdminSetGuestData struct {
+	IsGuest       bool  `json:"isGuest"`
+	GuestExpireAt int64 `json:"guestExpireAt"`
+}
+
+func (a *API) handleAdminSetGuest(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	username := vars["username"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var requestData AdminSetGuestData
+	err = json.Unmarshal(requestBody, &requestData)
+	if err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "adminSetGuest", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
+	auditRec.AddMeta("username", username)
+	auditRec.AddMeta("isGuest", requestData.IsGuest)
+	auditRec.AddMeta("guestExpireAt", requestData.GuestExpireAt)
+
+	user, err := a.app.GetUserByUsername(username)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	err = a.app.UpdateUserGuest(user.ID, requestData.IsGuest, requestData.GuestExpireAt)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("AdminSetGuest",
+		mlog.String("userID", user.ID),
+		mlog.Bool("isGuest", requestData.IsGuest),
+	)
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) handleAdminResetMfa(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	username := vars["username"]
+
+	auditRec := a.makeAuditRecord(r, "adminResetMfa", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
+	auditRec.AddMeta("username", username)
+
+	user, err := a.app.GetUserByUsername(username)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if err = a.app.ResetUserMfa(user.ID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("AdminResetMfa", mlog.String("userID", user.ID))
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) handleAdminRevokeSessions(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	username := vars["username"]
+
+	auditRec := a.makeAuditRecord(r, "adminRevokeSessions", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
+	auditRec.AddMeta("username", username)
+
+	user, err := a.app.GetUserByUsername(username)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if err = a.app.RevokeSessionsForUser(user.ID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("AdminRevokeSessions", mlog.String("userID", user.ID))
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) handleAdminUnlockUser(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	username := vars["username"]
+
+	auditRec := a.makeAuditRecord(r, "adminUnlockUser", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
+	auditRec.AddMeta("username", username)
+
+	user, err := a.app.GetUserByUsername(username)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if err = a.app.UnlockUserLogin(user.ID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("AdminUnlockUser", mlog.String("userID", user.ID))
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) handleAdminUnlockIPAddress(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	ipAddress := vars["ipAddress"]
+
+	auditRec := a.makeAuditRecord(r, "adminUnlockIPAddress", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
+	auditRec.AddMeta("ipAddress", ipAddress)
+
+	if net.ParseIP(ipAddress) == nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid IP address"))
+		return
+	}
+
+	if err := a.app.UnlockIPAddressLogin(ipAddress); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("AdminUnlockIPAddress", mlog.String("ipAddress", ipAddress))
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) handleAdminSyncLdap(w http.ResponseWriter, r *http.Request) {
+	auditRec := a.makeAuditRecord(r, "adminSyncLdap", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
+
+	result, err := a.app.SyncLdap()
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("AdminSyncLdap",
+		mlog.Int("deactivated", result.Deactivated),
+		mlog.Int("reactivated", result.Reactivated),
+	)
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+type AdminTransferBoardsData struct {
+	ToUsername string `json:"toUsername"`
+}
+
+func (a *API) handleAdminTransferBoards(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	username := vars["username"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var requestData AdminTransferBoardsData
+	err = json.Unmarshal(requestBody, &requestData)
+	if err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "adminTransferBoards", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("username", username)
+	auditRec.AddMeta("toUsername", requestData.ToUsername)
+
+	if requestData.ToUsername == "" {
+		a.errorResponse(w, r, model.NewErrBadRequest("toUsername is required"))
+		return
+	}
+
+	fromUser, err := a.app.GetUserByUsername(username)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	toUser, err := a.app.GetUserByUsername(requestData.ToUsername)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	boards, err := a.app.TransferBoardOwnership(fromUser.ID, toUser.ID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("AdminTransferBoards",
+		mlog.String("fromUserID", fromUser.ID),
+		mlog.String("toUserID", toUser.ID),
+		mlog.Int("boards", len(boards)),
+	)
+
+	data, err := json.Marshal(boards)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec.AddMeta("boardCount", len(boards))
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"runtime/debug"
+	"strconv"
 	"sync"
 
 	"github.com/gorilla/mux"
@@ -90,9 +91,11 @@ func (a *API) RegisterRoutes(r *mux.Router) {
 	// V2 routes (ToDo: migrate these to V3 when ready to ship V3)
 	a.registerUsersRoutes(apiv2)
 	a.registerAuthRoutes(apiv2)
+	a.registerMfaRoutes(apiv2)
 	a.registerMembersRoutes(apiv2)
 	a.registerCategoriesRoutes(apiv2)
 	a.registerSharingRoutes(apiv2)
+	a.registerShareLinksRoutes(apiv2)
 	a.registerTeamsRoutes(apiv2)
 	a.registerAchivesRoutes(apiv2)
 	a.registerSubscriptionsRoutes(apiv2)
@@ -112,13 +115,48 @@ func (a *API) RegisterRoutes(r *mux.Router) {
 
 	// V3 routes
 	a.registerCardsRoutes(apiv2)
+	a.registerRecurrencesRoutes(apiv2)
+	a.registerBoardSnapshotsRoutes(apiv2)
+	a.registerAutomationsRoutes(apiv2)
+	a.registerLocksRoutes(apiv2)
+	a.registerCardAccessRoutes(apiv2)
+	a.registerCustomBoardRolesRoutes(apiv2)
+	a.registerPermissionsRoutes(apiv2)
+	a.registerTeamManagementRoutes(apiv2)
+	a.registerAccessTokensRoutes(apiv2)
+	a.registerSessionsRoutes(apiv2)
+	a.registerPasswordResetRoutes(apiv2)
+	a.registerEmailVerificationRoutes(apiv2)
+	a.registerAdminRoutes(apiv2)
 
 	// System routes are outside the /api/v2 path
 	a.registerSystemRoutes(r)
+	a.registerOidcRoutes(r)
+	a.registerScimRoutes(r)
 }
 
+// RegisterAdminRoutes registers the admin APIs on the router of the local
+// admin socket. They are also available to the system admins on the main
+// router.
 func (a *API) RegisterAdminRoutes(r *mux.Router) {
-	r.HandleFunc("/api/v2/admin/users/{username}/password", a.adminRequired(a.handleAdminSetPassword)).Methods("POST")
+	a.registerAdminRoutes(r.PathPrefix("/api/v2").Subrouter())
+}
+
+func (a *API) registerAdminRoutes(r *mux.Router) {
+	r.HandleFunc("/admin/users", a.adminRequired(a.handleAdminGetUsers)).Methods("GET")
+	r.HandleFunc("/admin/users/{username}", a.adminRequired(a.handleAdminGetUser)).Methods("GET")
+	r.HandleFunc("/admin/users/{username}/boards", a.adminRequired(a.handleAdminGetUserBoards)).Methods("GET")
+	r.HandleFunc("/admin/users/{username}/deactivate", a.adminRequired(a.handleAdminDeactivateUser)).Methods("POST")
+	r.HandleFunc("/admin/users/{username}/reactivate", a.adminRequired(a.handleAdminReactivateUser)).Methods("POST")
+	r.HandleFunc("/admin/users/{username}/system-admin", a.adminRequired(a.handleAdminSetSystemAdmin)).Methods("POST")
+	r.HandleFunc("/admin/users/{username}/password", a.adminRequired(a.handleAdminSetPassword)).Methods("POST")
+	r.HandleFunc("/admin/users/{username}/transfer-boards", a.adminRequired(a.handleAdminTransferBoards)).Methods("POST")
+	r.HandleFunc("/admin/users/{username}/guest", a.adminRequired(a.handleAdminSetGuest)).Methods("POST")
+	r.HandleFunc("/admin/users/{username}/mfa/reset", a.adminRequired(a.handleAdminResetMfa)).Methods("POST")
+	r.HandleFunc("/admin/users/{username}/sessions/revoke", a.adminRequired(a.handleAdminRevokeSessions)).Methods("POST")
+	r.HandleFunc("/admin/users/{username}/unlock", a.adminRequired(a.handleAdminUnlockUser)).Methods("POST")
+	r.HandleFunc("/admin/ip-addresses/{ipAddress}/unlock", a.adminRequired(a.handleAdminUnlockIPAddress)).Methods("POST")
+	r.HandleFunc("/admin/ldap/sync", a.adminRequired(a.handleAdminSyncLdap)).Methods("POST")
 }
 
 func getUserID(r *http.Request) string {
@@ -172,7 +210,10 @@ func (a *API) hasValidReadTokenForBoard(r *http.Request, boardID string) bool {
 		return false
 	}
 
-	isValid, err := a.app.IsValidReadToken(boardID, readToken)
+	isValid, err := a.app.IsValidReadToken(boardID, readToken, model.ShareLinkCredentials{
+		Password: r.Header.Get(model.HeaderReadTokenPassword),
+		ViewID:   r.Header.Get(model.HeaderReadTokenViewID),
+	})
 	if err != nil {
 		a.logger.Error("IsValidReadTokenForBoard ERROR", mlog.Err(err))
 		return false
@@ -203,10 +244,18 @@ func (a *API) errorResponse(w http.ResponseWriter, r *http.Request, err error) {
 		errorResponse.ErrorCode = http.StatusForbidden
 	case model.IsErrNotFound(err):
 		errorResponse.ErrorCode = http.StatusNotFound
+	case model.IsErrConflict(err):
+		errorResponse.ErrorCode = http.StatusConflict
 	case model.IsErrRequestEntityTooLarge(err):
 		errorResponse.ErrorCode = http.StatusRequestEntityTooLarge
 	case model.IsErrNotImplemented(err):
 		errorResponse.ErrorCode = http.StatusNotImplemented
+	case model.IsErrTooManyRequests(err):
+		errorResponse.ErrorCode = http.StatusTooManyRequests
+		var tmr *model.ErrTooManyRequests
+		if errors.As(err, &tmr) && tmr.RetryAfter > 0 {
+			setResponseHeader(w, "Retry-After", strconv.FormatInt(tmr.RetryAfter, 10))
+		}
 	default:
 		a.logger.Error("API ERROR",
 			mlog.Int("code", http.StatusInternalServerError),
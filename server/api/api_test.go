@@ -39,6 +39,8 @@ func TestErrorResponse(t *testing.T) {
 		{"ErrForbidden", model.NewErrForbidden("not enough permissions"), http.StatusForbidden, "not enough permissions"},
 		{"ErrPermission", model.NewErrPermission("not enough permissions"), http.StatusForbidden, "not enough permissions"},
 		{"ErrPatchUpdatesLimitedCards", model.ErrPatchUpdatesLimitedCards, http.StatusForbidden, "cards that are limited"},
+		{"ErrBoardIsLocked", model.ErrBoardIsLocked, http.StatusForbidden, "board is locked"},
+		{"ErrCardIsLocked", model.ErrCardIsLocked, http.StatusForbidden, "card is locked"},
 		{"ErrCategoryPermissionDenied", model.ErrCategoryPermissionDenied, http.StatusForbidden, "doesn't belong to user"},
 
 		// not found
@@ -19,9 +19,9 @@ const (
 
 func (a *API) registerAchivesRoutes(r *mux.Router) {
 	// Archive APIs
-	r.HandleFunc("/boards/{boardID}/archive/export", a.sessionRequired(a.handleArchiveExportBoard)).Methods("GET")
-	r.HandleFunc("/teams/{teamID}/archive/import", a.sessionRequired(a.handleArchiveImport)).Methods("POST")
-	r.HandleFunc("/teams/{teamID}/archive/export", a.sessionRequired(a.handleArchiveExportTeam)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/archive/export", a.sessionRequired(model.AccessTokenScopeRead, a.handleArchiveExportBoard)).Methods("GET")
+	r.HandleFunc("/teams/{teamID}/archive/import", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleArchiveImport)).Methods("POST")
+	r.HandleFunc("/teams/{teamID}/archive/export", a.sessionRequired(model.AccessTokenScopeRead, a.handleArchiveExportTeam)).Methods("GET")
 }
 
 func (a *API) handleArchiveExportBoard(w http.ResponseWriter, r *http.Request) {
@@ -38,6 +38,11 @@ func (a *API) handleArchiveExportBoard(w http.ResponseWriter, r *http.Request) {
 	//   description: Id of board to export
 	//   required: true
 	//   type: string
+	// - name: archived_cards
+	//   in: query
+	//   description: Which cards to export by archived state, one of include, exclude or only (default=include)
+	//   required: false
+	//   type: string
 	// security:
 	// - BearerAuth: []
 	// responses:
@@ -57,7 +62,8 @@ func (a *API) handleArchiveExportBoard(w http.ResponseWriter, r *http.Request) {
 	userID := getUserID(r)
 
 	// check user has permission to board
-	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+	canViewBoard := a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard)
+	if !canViewBoard {
 		// if this user has `manage_system` permission and there is a license with the compliance
 		// feature enabled, then we will allow the export.
 		license := a.app.GetLicense()
@@ -77,9 +83,20 @@ func (a *API) handleArchiveExportBoard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	archivedCards, err := model.ArchivedCardsFilterFromString(r.URL.Query().Get("archived_cards"))
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
 	opts := model.ExportArchiveOptions{
-		TeamID:   board.TeamID,
-		BoardIDs: []string{board.ID},
+		TeamID:        board.TeamID,
+		BoardIDs:      []string{board.ID},
+		ArchivedCards: archivedCards,
+	}
+	if canViewBoard {
+		// compliance exports include the restricted cards
+		opts.UserID = userID
 	}
 
 	filename := fmt.Sprintf("archive-%s%s", time.Now().Format("2006-01-02"), archiveExtension)
@@ -191,6 +208,11 @@ func (a *API) handleArchiveExportTeam(w http.ResponseWriter, r *http.Request) {
 	//   description: Id of team
 	//   required: true
 	//   type: string
+	// - name: archived_cards
+	//   in: query
+	//   description: Which cards to export by archived state, one of include, exclude or only (default=include)
+	//   required: false
+	//   type: string
 	// security:
 	// - BearerAuth: []
 	// responses:
@@ -236,9 +258,17 @@ func (a *API) handleArchiveExportTeam(w http.ResponseWriter, r *http.Request) {
 		ids = append(ids, board.ID)
 	}
 
+	archivedCards, err := model.ArchivedCardsFilterFromString(r.URL.Query().Get("archived_cards"))
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
 	opts := model.ExportArchiveOptions{
-		TeamID:   teamID,
-		BoardIDs: ids,
+		TeamID:        teamID,
+		BoardIDs:      ids,
+		ArchivedCards: archivedCards,
+		UserID:        userID,
 	}
 
 	filename := fmt.Sprintf("archive-%s%s", time.Now().Format("2006-01-02"), archiveExtension)
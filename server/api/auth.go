@@ -3,12 +3,14 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net"
 	"net/http"
 	"strings"
 
 	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/app"
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/audit"
 	"github.com/mattermost/focalboard/server/services/auth"
@@ -20,10 +22,10 @@ import (
 func (a *API) registerAuthRoutes(r *mux.Router) {
 	// personal-server specific routes. These are not needed in plugin mode.
 	r.HandleFunc("/login", a.handleLogin).Methods("POST")
-	r.HandleFunc("/logout", a.sessionRequired(a.handleLogout)).Methods("POST")
+	r.HandleFunc("/logout", a.mfaSetupSessionRequired(model.AccessTokenScopeBoardsWrite, a.handleLogout)).Methods("POST")
 	r.HandleFunc("/register", a.handleRegister).Methods("POST")
-	r.HandleFunc("/teams/{teamID}/regenerate_signup_token", a.sessionRequired(a.handlePostTeamRegenerateSignupToken)).Methods("POST")
-	r.HandleFunc("/users/{userID}/changepassword", a.sessionRequired(a.handleChangePassword)).Methods("POST")
+	r.HandleFunc("/teams/{teamID}/regenerate_signup_token", a.sessionRequired(model.AccessTokenScopeAdmin, a.handlePostTeamRegenerateSignupToken)).Methods("POST")
+	r.HandleFunc("/users/{userID}/changepassword", a.sessionRequired(model.AccessTokenScopeAdmin, a.handleChangePassword)).Methods("POST")
 }
 
 func (a *API) handleLogin(w http.ResponseWriter, r *http.Request) {
@@ -50,6 +52,10 @@ func (a *API) handleLogin(w http.ResponseWriter, r *http.Request) {
 	//     description: invalid login
 	//     schema:
 	//       "$ref": "#/definitions/ErrorResponse"
+	//   '429':
+	//     description: too many failed logins, retry after the Retry-After header seconds
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
 	//   '500':
 	//     description: internal error
 	//     schema:
@@ -84,11 +90,32 @@ func (a *API) handleLogin(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("type", loginData.Type)
 
 	if loginData.Type == "normal" {
-		token, err := a.app.Login(loginData.Username, loginData.Email, loginData.Password, loginData.MfaToken)
+		login := loginData.Username
+		if login == "" {
+			login = loginData.Email
+		}
+		ipAddress := a.clientIPAddress(r)
+
+		if err = a.app.CheckLoginAllowed(login, ipAddress); err != nil {
+			auditRec.AddMeta("throttled", true)
+			a.errorResponse(w, r, err)
+			return
+		}
+
+		token, err := a.app.Login(loginData.Username, loginData.Email, loginData.Password, loginData.MfaToken, a.newSessionProps(r))
+		if errors.Is(err, app.ErrMfaTokenRequired) || errors.Is(err, app.ErrEmailNotVerified) {
+			a.errorResponse(w, r, model.NewErrUnauthorized(err.Error()))
+			return
+		}
 		if err != nil {
+			a.recordLoginFailure(r, login, ipAddress)
 			a.errorResponse(w, r, model.NewErrUnauthorized("incorrect login"))
 			return
 		}
+
+		if err = a.app.ResetLoginAttempts(login); err != nil {
+			a.logger.Warn("Unable to reset the failed logins", mlog.Err(err))
+		}
 		json, err := json.Marshal(model.LoginResponse{Token: token})
 		if err != nil {
 			a.errorResponse(w, r, err)
@@ -146,6 +173,11 @@ func (a *API) handleLogout(w http.ResponseWriter, r *http.Request) {
 
 	auditRec.AddMeta("sessionID", session.ID)
 
+	// the single sign-on logins keep the session in a cookie
+	if _, err := r.Cookie(auth.SessionCookieToken); err == nil {
+		http.SetCookie(w, &http.Cookie{Name: auth.SessionCookieToken, Path: "/", MaxAge: -1, HttpOnly: true})
+	}
+
 	jsonStringResponse(w, http.StatusOK, "{}")
 	auditRec.Success()
 }
@@ -170,6 +202,8 @@ func (a *API) handleRegister(w http.ResponseWriter, r *http.Request) {
 	//     description: success
 	//   '401':
 	//     description: invalid registration token
+	//   '403':
+	//     description: the email domain is not allowed to sign up
 	//   '500':
 	//     description: internal error
 	//     schema:
@@ -200,19 +234,9 @@ func (a *API) handleRegister(w http.ResponseWriter, r *http.Request) {
 	registerData.Email = strings.TrimSpace(registerData.Email)
 	registerData.Username = strings.TrimSpace(registerData.Username)
 
-	// Validate token
-	if len(registerData.Token) > 0 {
-		team, err2 := a.app.GetRootTeam()
-		if err2 != nil {
-			a.errorResponse(w, r, err2)
-			return
-		}
-
-		if registerData.Token != team.SignupToken {
-			a.errorResponse(w, r, model.NewErrUnauthorized("invalid token"))
-			return
-		}
-	} else {
+	// The signup token is validated while registering the user, as it
+	// can be the token of a team or of a team invitation
+	if len(registerData.Token) == 0 {
 		// No signup token, check if no active users
 		userCount, err2 := a.app.GetRegisteredUserCount()
 		if err2 != nil {
@@ -234,7 +258,15 @@ func (a *API) handleRegister(w http.ResponseWriter, r *http.Request) {
 	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
 	auditRec.AddMeta("username", registerData.Username)
 
-	err = a.app.RegisterUser(registerData.Username, registerData.Email, registerData.Password)
+	if len(registerData.Token) > 0 {
+		err = a.app.RegisterUserWithToken(registerData.Username, registerData.Email, registerData.Password, registerData.Token)
+	} else {
+		err = a.app.RegisterUser(registerData.Username, registerData.Email, registerData.Password)
+	}
+	if model.IsErrUnauthorized(err) || model.IsErrForbidden(err) || model.IsErrNotImplemented(err) {
+		a.errorResponse(w, r, err)
+		return
+	}
 	if err != nil {
 		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
 		return
@@ -320,11 +352,25 @@ func (a *API) handleChangePassword(w http.ResponseWriter, r *http.Request) {
 	auditRec.Success()
 }
 
-func (a *API) sessionRequired(handler func(w http.ResponseWriter, r *http.Request)) func(w http.ResponseWriter, r *http.Request) {
-	return a.attachSession(handler, true)
+// sessionRequired requires a session. The scope is the one the personal
+// access tokens need for the route, every route states it when it's
+// registered.
+func (a *API) sessionRequired(scope string, handler func(w http.ResponseWriter, r *http.Request)) func(w http.ResponseWriter, r *http.Request) {
+	return a.attachSession(scope, handler, true)
+}
+
+// mfaSetupSessionRequired is like sessionRequired, but it lets through the
+// users that still need to activate multi-factor authentication when the
+// server requires it.
+func (a *API) mfaSetupSessionRequired(scope string, handler func(w http.ResponseWriter, r *http.Request)) func(w http.ResponseWriter, r *http.Request) {
+	return a.attachSessionWithOptions(scope, handler, true, true)
 }
 
-func (a *API) attachSession(handler func(w http.ResponseWriter, r *http.Request), required bool) func(w http.ResponseWriter, r *http.Request) {
+func (a *API) attachSession(scope string, handler func(w http.ResponseWriter, r *http.Request), required bool) func(w http.ResponseWriter, r *http.Request) {
+	return a.attachSessionWithOptions(scope, handler, required, false)
+}
+
+func (a *API) attachSessionWithOptions(scope string, handler func(w http.ResponseWriter, r *http.Request), required bool, allowMfaSetup bool) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		token, _ := auth.ParseAuthTokenFromRequest(r)
 
@@ -391,20 +437,99 @@ func (a *API) attachSession(handler func(w http.ResponseWriter, r *http.Request)
 			return
 		}
 
+		if !session.HasAccessTokenScope(scope) {
+			a.errorResponse(w, r, model.NewErrPermission("the access token scopes don't allow this request"))
+			return
+		}
+
+		if !allowMfaSetup {
+			mfaSetupRequired, err := a.app.IsMfaSetupRequired(session.UserID)
+			if err != nil {
+				a.errorResponse(w, r, err)
+				return
+			}
+			if mfaSetupRequired {
+				if required {
+					a.errorResponse(w, r, model.NewErrPermission("multi-factor authentication setup required"))
+					return
+				}
+
+				handler(w, r)
+				return
+			}
+		}
+
 		ctx := context.WithValue(r.Context(), sessionContextKey, session)
 		handler(w, r.WithContext(ctx))
 	}
 }
 
+// recordLoginFailure counts a failed login, and records an audit event
+// when it locks the account or the IP address out.
+func (a *API) recordLoginFailure(r *http.Request, login, ipAddress string) {
+	locked, err := a.app.RecordLoginFailure(login, ipAddress)
+	if err != nil {
+		a.logger.Warn("Unable to record the failed login", mlog.Err(err))
+		return
+	}
+	if !locked {
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "loginLockout", audit.Fail)
+	auditRec.AddMeta("login", login)
+	auditRec.AddMeta("ipAddress", ipAddress)
+	auditRec.Success()
+	a.audit.LogRecord(audit.LevelAuth, auditRec)
+}
+
+// clientIPAddress returns the IP address of the client, without the port.
+// Behind a reverse proxy every request comes from the proxy address, so the
+// per IP login limits would apply to all the users at once: the proxy must
+// then add the client address to the header set in TrustedProxyIPHeader.
+// The last address of the header is used, as it is the one added by the
+// proxy, the previous ones being set by the client.
+func (a *API) clientIPAddress(r *http.Request) string {
+	if header := a.app.GetConfig().TrustedProxyIPHeader; header != "" {
+		if values := r.Header.Values(header); len(values) > 0 {
+			addresses := strings.Split(values[len(values)-1], ",")
+			if address := strings.TrimSpace(addresses[len(addresses)-1]); address != "" {
+				return address
+			}
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// adminRequired lets through the local unix connections of the admin socket,
+// and the authenticated system admins of standalone servers.
 func (a *API) adminRequired(handler func(w http.ResponseWriter, r *http.Request)) func(w http.ResponseWriter, r *http.Request) {
+	systemAdminHandler := a.sessionRequired(model.AccessTokenScopeAdmin, func(w http.ResponseWriter, r *http.Request) {
+		if !a.permissions.HasPermissionTo(getUserID(r), model.PermissionManageSystem) {
+			a.errorResponse(w, r, model.NewErrPermission("access denied to system admin API"))
+			return
+		}
+
+		handler(w, r)
+	})
+
 	return func(w http.ResponseWriter, r *http.Request) {
-		// Currently, admin APIs require local unix connections
 		conn := GetContextConn(r)
-		if _, isUnix := conn.(*net.UnixConn); !isUnix {
-			a.errorResponse(w, r, model.NewErrUnauthorized("not a local unix connection"))
+		if _, isUnix := conn.(*net.UnixConn); isUnix {
+			handler(w, r)
 			return
 		}
 
-		handler(w, r)
+		if a.MattermostAuth {
+			a.errorResponse(w, r, model.NewErrNotImplemented("not permitted in plugin mode"))
+			return
+		}
+
+		systemAdminHandler(w, r)
 	}
 }
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (a *API) registerAutomationsRoutes(r *mux.Router) {
+	// Board automation APIs
+	r.HandleFunc("/boards/{boardID}/automations", a.sessionRequired(model.AccessTokenScopeRead, a.handleGetBoardAutomations)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/automations", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleSetBoardAutomations)).Methods("PUT")
+}
+
+func (a *API) handleGetBoardAutomations(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/automations getBoardAutomations
+	//
+	// Returns the automation rules of a board.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/AutomationRule"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board automations"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBoardAutomations", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	rules, err := a.app.GetBoardAutomations(boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(rules)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleSetBoardAutomations(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /boards/{boardID}/automations setBoardAutomations
+	//
+	// Replaces the automation rules of a board. Rules run when a card is
+	// created, a card property changes, a comment is added or a due date is
+	// reached. Their actions are made on behalf of the system user and don't
+	// trigger other rules, and only run while the user who saved the rules
+	// can still manage them and make the changes of the actions. Webhooks
+	// can't be sent to internal addresses.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the automation rules
+	//   required: true
+	//   schema:
+	//     type: array
+	//     items:
+	//       "$ref": "#/definitions/AutomationRule"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/AutomationRule"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	rules, err := model.AutomationRulesFromJSON(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to set board automations"))
+		return
+	}
+
+	// cards can only be moved to boards the user can manage
+	for _, rule := range rules {
+		if rule == nil {
+			continue
+		}
+		for _, action := range rule.Actions {
+			if action.Type != model.AutomationActionMoveToBoard || action.Move == nil {
+				continue
+			}
+			if !a.permissions.HasPermissionToBoard(userID, action.Move.TargetBoardID, model.PermissionManageBoardCards) {
+				a.errorResponse(w, r, model.NewErrPermission("access denied to automation target board"))
+				return
+			}
+		}
+	}
+
+	auditRec := a.makeAuditRecord(r, "setBoardAutomations", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("ruleCount", len(rules))
+
+	rules, err = a.app.SetBoardAutomations(boardID, rules, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("SetBoardAutomations",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+		mlog.Int("ruleCount", len(rules)),
+	)
+
+	data, err := json.Marshal(rules)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
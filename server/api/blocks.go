@@ -16,13 +16,13 @@ import (
 
 func (a *API) registerBlocksRoutes(r *mux.Router) {
 	// Blocks APIs
-	r.HandleFunc("/boards/{boardID}/blocks", a.attachSession(a.handleGetBlocks, false)).Methods("GET")
-	r.HandleFunc("/boards/{boardID}/blocks", a.sessionRequired(a.handlePostBlocks)).Methods("POST")
-	r.HandleFunc("/boards/{boardID}/blocks", a.sessionRequired(a.handlePatchBlocks)).Methods("PATCH")
-	r.HandleFunc("/boards/{boardID}/blocks/{blockID}", a.sessionRequired(a.handleDeleteBlock)).Methods("DELETE")
-	r.HandleFunc("/boards/{boardID}/blocks/{blockID}", a.sessionRequired(a.handlePatchBlock)).Methods("PATCH")
-	r.HandleFunc("/boards/{boardID}/blocks/{blockID}/undelete", a.sessionRequired(a.handleUndeleteBlock)).Methods("POST")
-	r.HandleFunc("/boards/{boardID}/blocks/{blockID}/duplicate", a.sessionRequired(a.handleDuplicateBlock)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/blocks", a.attachSession(model.AccessTokenScopeRead, a.handleGetBlocks, false)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/blocks", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handlePostBlocks)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/blocks", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handlePatchBlocks)).Methods("PATCH")
+	r.HandleFunc("/boards/{boardID}/blocks/{blockID}", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleDeleteBlock)).Methods("DELETE")
+	r.HandleFunc("/boards/{boardID}/blocks/{blockID}", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handlePatchBlock)).Methods("PATCH")
+	r.HandleFunc("/boards/{boardID}/blocks/{blockID}/undelete", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleUndeleteBlock)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/blocks/{blockID}/duplicate", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleDuplicateBlock)).Methods("POST")
 }
 
 func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
@@ -49,6 +49,11 @@ func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
 	//   description: Type of blocks to return, omit to specify all types
 	//   required: false
 	//   type: string
+	// - name: include_archived
+	//   in: query
+	//   description: Include archived cards and their content (default=false)
+	//   required: false
+	//   type: boolean
 	// security:
 	// - BearerAuth: []
 	// responses:
@@ -70,6 +75,7 @@ func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
 	blockType := query.Get("type")
 	all := query.Get("all")
 	blockID := query.Get("block_id")
+	includeArchived := query.Get("include_archived") == "true"
 	boardID := mux.Vars(r)["boardID"]
 
 	userID := getUserID(r)
@@ -120,6 +126,7 @@ func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("blockType", blockType)
 	auditRec.AddMeta("all", all)
 	auditRec.AddMeta("blockID", blockID)
+	auditRec.AddMeta("includeArchived", includeArchived)
 
 	var blocks []*model.Block
 	var block *model.Block
@@ -151,6 +158,26 @@ func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// a single block is always returned so that archived cards stay readable
+	if blockID == "" && !includeArchived {
+		blocks, err = a.app.FilterArchivedBlocks(boardID, blocks)
+		if err != nil {
+			a.errorResponse(w, r, err)
+			return
+		}
+	}
+
+	blocks, err = a.app.FilterRestrictedBlocks(userID, board, blocks)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	if blockID != "" && len(blocks) == 0 {
+		message := fmt.Sprintf("block ID=%s on BoardID=%s", blockID, boardID)
+		a.errorResponse(w, r, model.NewErrNotFound(message))
+		return
+	}
+
 	a.logger.Debug("GetBlocks",
 		mlog.String("boardID", boardID),
 		mlog.String("parentID", parentID),
@@ -282,6 +309,18 @@ func (a *API) handlePostBlocks(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// the content and comments can't be added to the cards the user can't see
+	contentBlocks := make([]*model.Block, 0, len(blocks))
+	for _, block := range blocks {
+		if block.Type != model.TypeCard {
+			contentBlocks = append(contentBlocks, block)
+		}
+	}
+	if err = a.app.CheckCanSeeBlocks(userID, contentBlocks...); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
 	blocks = model.GenerateBlockIDs(blocks, a.logger)
 
 	auditRec := a.makeAuditRecord(r, "postBlocks", audit.Fail)
@@ -384,6 +423,10 @@ func (a *API) handleDeleteBlock(w http.ResponseWriter, r *http.Request) {
 		a.errorResponse(w, r, model.NewErrNotFound(message))
 		return
 	}
+	if err = a.app.CheckCanSeeBlocks(userID, block); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
 
 	auditRec := a.makeAuditRecord(r, "deleteBlock", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelModify, auditRec)
@@ -460,6 +503,10 @@ func (a *API) handleUndeleteBlock(w http.ResponseWriter, r *http.Request) {
 		a.errorResponse(w, r, model.NewErrNotFound(message))
 		return
 	}
+	if err = a.app.CheckCanSeeBlocks(userID, block); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
 
 	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
 		a.errorResponse(w, r, model.NewErrPermission("access denied to modify board members"))
@@ -553,6 +600,10 @@ func (a *API) handlePatchBlock(w http.ResponseWriter, r *http.Request) {
 		a.errorResponse(w, r, model.NewErrNotFound(message))
 		return
 	}
+	if err = a.app.CheckCanSeeBlocks(userID, block); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
 
 	requestBody, err := io.ReadAll(r.Body)
 	if err != nil {
@@ -647,6 +698,7 @@ func (a *API) handlePatchBlocks(w http.ResponseWriter, r *http.Request) {
 		auditRec.AddMeta("block_"+strconv.FormatInt(int64(i), 10), patches.BlockIDs[i])
 	}
 
+	blocks := make([]*model.Block, 0, len(patches.BlockIDs))
 	for _, blockID := range patches.BlockIDs {
 		var block *model.Block
 		block, err = a.app.GetBlockByID(blockID)
@@ -658,6 +710,11 @@ func (a *API) handlePatchBlocks(w http.ResponseWriter, r *http.Request) {
 			a.errorResponse(w, r, model.NewErrPermission("access denied to make board changesa"))
 			return
 		}
+		blocks = append(blocks, block)
+	}
+	if err = a.app.CheckCanSeeBlocks(userID, blocks...); err != nil {
+		a.errorResponse(w, r, err)
+		return
 	}
 
 	err = a.app.PatchBlocksAndNotify(teamID, patches, userID, disableNotify)
@@ -735,6 +792,10 @@ func (a *API) handleDuplicateBlock(w http.ResponseWriter, r *http.Request) {
 		a.errorResponse(w, r, model.NewErrNotFound(message))
 		return
 	}
+	if err = a.app.CheckCanSeeBlocks(userID, block); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
 
 	if block.Type == model.TypeComment {
 		if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionCommentBoardCards) {
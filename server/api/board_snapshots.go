@@ -0,0 +1,294 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (a *API) registerBoardSnapshotsRoutes(r *mux.Router) {
+	// Board snapshot APIs
+	r.HandleFunc("/boards/{boardID}/snapshots", a.sessionRequired(model.AccessTokenScopeRead, a.handleGetBoardSnapshots)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/snapshots", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleCreateBoardSnapshot)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/snapshots/{snapshotID}", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleDeleteBoardSnapshot)).Methods("DELETE")
+	r.HandleFunc("/boards/{boardID}/restore", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleRestoreBoard)).Methods("POST")
+}
+
+func (a *API) handleGetBoardSnapshots(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/snapshots getBoardSnapshots
+	//
+	// Returns the snapshots of a board, newest first.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/BoardSnapshot"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board snapshots"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBoardSnapshots", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	snapshots, err := a.app.GetBoardSnapshots(boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(snapshots)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleCreateBoardSnapshot(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/snapshots createBoardSnapshot
+	//
+	// Takes a named snapshot of a board that the board can later be restored to.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the snapshot to take, only the name is used
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BoardSnapshot"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardSnapshot"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	snapshot, err := model.BoardSnapshotFromJSON(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+	snapshot.ID = ""
+	snapshot.BoardID = boardID
+	snapshot.Type = model.BoardSnapshotManual
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to create board snapshot"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "createBoardSnapshot", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("name", snapshot.Name)
+
+	snapshot, err = a.app.CreateBoardSnapshot(snapshot, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("CreateBoardSnapshot",
+		mlog.String("boardID", boardID),
+		mlog.String("snapshotID", snapshot.ID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec.AddMeta("snapshotID", snapshot.ID)
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteBoardSnapshot(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/snapshots/{snapshotID} deleteBoardSnapshot
+	//
+	// Deletes a snapshot of a board. The board itself is not changed.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: snapshotID
+	//   in: path
+	//   description: Snapshot ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	snapshotID := vars["snapshotID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to delete board snapshot"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteBoardSnapshot", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("snapshotID", snapshotID)
+
+	if err := a.app.DeleteBoardSnapshot(boardID, snapshotID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DeleteBoardSnapshot",
+		mlog.String("boardID", boardID),
+		mlog.String("snapshotID", snapshotID),
+		mlog.String("userID", userID),
+	)
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) handleRestoreBoard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/restore restoreBoard
+	//
+	// Restores the board, its views, cards and content to a snapshot or to a
+	// point in time. A snapshot of the current state is taken before restoring.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the snapshot or time to restore to
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BoardRestoreRequest"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Board"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	request, err := model.BoardRestoreRequestFromJSON(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	if err = request.IsValid(); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) ||
+		!a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to restore board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "restoreBoard", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("snapshotID", request.SnapshotID)
+	auditRec.AddMeta("timestamp", request.Timestamp)
+
+	board, err := a.app.RestoreBoard(boardID, request, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("RestoreBoard",
+		mlog.String("boardID", boardID),
+		mlog.String("snapshotID", request.SnapshotID),
+		mlog.Int("timestamp", request.Timestamp),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(board)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
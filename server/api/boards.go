@@ -13,14 +13,14 @@ import (
 )
 
 func (a *API) registerBoardsRoutes(r *mux.Router) {
-	r.HandleFunc("/teams/{teamID}/boards", a.sessionRequired(a.handleGetBoards)).Methods("GET")
-	r.HandleFunc("/boards", a.sessionRequired(a.handleCreateBoard)).Methods("POST")
-	r.HandleFunc("/boards/{boardID}", a.attachSession(a.handleGetBoard, false)).Methods("GET")
-	r.HandleFunc("/boards/{boardID}", a.sessionRequired(a.handlePatchBoard)).Methods("PATCH")
-	r.HandleFunc("/boards/{boardID}", a.sessionRequired(a.handleDeleteBoard)).Methods("DELETE")
-	r.HandleFunc("/boards/{boardID}/duplicate", a.sessionRequired(a.handleDuplicateBoard)).Methods("POST")
-	r.HandleFunc("/boards/{boardID}/undelete", a.sessionRequired(a.handleUndeleteBoard)).Methods("POST")
-	r.HandleFunc("/boards/{boardID}/metadata", a.sessionRequired(a.handleGetBoardMetadata)).Methods("GET")
+	r.HandleFunc("/teams/{teamID}/boards", a.sessionRequired(model.AccessTokenScopeRead, a.handleGetBoards)).Methods("GET")
+	r.HandleFunc("/boards", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleCreateBoard)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}", a.attachSession(model.AccessTokenScopeRead, a.handleGetBoard, false)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handlePatchBoard)).Methods("PATCH")
+	r.HandleFunc("/boards/{boardID}", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleDeleteBoard)).Methods("DELETE")
+	r.HandleFunc("/boards/{boardID}/duplicate", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleDuplicateBoard)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/undelete", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleUndeleteBoard)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/metadata", a.sessionRequired(model.AccessTokenScopeRead, a.handleGetBoardMetadata)).Methods("GET")
 }
 
 func (a *API) handleGetBoards(w http.ResponseWriter, r *http.Request) {
@@ -515,6 +515,17 @@ func (a *API) handleDuplicateBoard(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// the copy would reveal the restricted cards the user can't see
+	hiddenCardIDs, err := a.app.GetHiddenCardIDs(userID, board)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	if len(hiddenCardIDs) > 0 {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to the restricted cards of the board"))
+		return
+	}
+
 	isGuest, err := a.userIsGuest(userID)
 	if err != nil {
 		a.errorResponse(w, r, err)
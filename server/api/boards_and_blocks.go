@@ -15,9 +15,9 @@ import (
 
 func (a *API) registerBoardsAndBlocksRoutes(r *mux.Router) {
 	// BoardsAndBlocks APIs
-	r.HandleFunc("/boards-and-blocks", a.sessionRequired(a.handleCreateBoardsAndBlocks)).Methods("POST")
-	r.HandleFunc("/boards-and-blocks", a.sessionRequired(a.handlePatchBoardsAndBlocks)).Methods("PATCH")
-	r.HandleFunc("/boards-and-blocks", a.sessionRequired(a.handleDeleteBoardsAndBlocks)).Methods("DELETE")
+	r.HandleFunc("/boards-and-blocks", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleCreateBoardsAndBlocks)).Methods("POST")
+	r.HandleFunc("/boards-and-blocks", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handlePatchBoardsAndBlocks)).Methods("PATCH")
+	r.HandleFunc("/boards-and-blocks", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleDeleteBoardsAndBlocks)).Methods("DELETE")
 }
 
 func (a *API) handleCreateBoardsAndBlocks(w http.ResponseWriter, r *http.Request) {
@@ -254,6 +254,7 @@ func (a *API) handlePatchBoardsAndBlocks(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
+	blocks := make([]*model.Block, 0, len(pbab.BlockIDs))
 	for _, blockID := range pbab.BlockIDs {
 		block, err2 := a.app.GetBlockByID(blockID)
 		if err2 != nil {
@@ -270,6 +271,11 @@ func (a *API) handlePatchBoardsAndBlocks(w http.ResponseWriter, r *http.Request)
 			a.errorResponse(w, r, model.NewErrPermission("access denied to modifying cards"))
 			return
 		}
+		blocks = append(blocks, block)
+	}
+	if err := a.app.CheckCanSeeBlocks(userID, blocks...); err != nil {
+		a.errorResponse(w, r, err)
+		return
 	}
 
 	auditRec := a.makeAuditRecord(r, "patchBoardsAndBlocks", audit.Fail)
@@ -366,6 +372,7 @@ func (a *API) handleDeleteBoardsAndBlocks(w http.ResponseWriter, r *http.Request
 		}
 	}
 
+	blocks := make([]*model.Block, 0, len(dbab.Blocks))
 	for _, blockID := range dbab.Blocks {
 		block, err2 := a.app.GetBlockByID(blockID)
 		if err2 != nil {
@@ -382,6 +389,11 @@ func (a *API) handleDeleteBoardsAndBlocks(w http.ResponseWriter, r *http.Request
 			a.errorResponse(w, r, model.NewErrPermission("access denied to modifying cards"))
 			return
 		}
+		blocks = append(blocks, block)
+	}
+	if err := a.app.CheckCanSeeBlocks(userID, blocks...); err != nil {
+		a.errorResponse(w, r, err)
+		return
 	}
 
 	if err := dbab.IsValid(); err != nil {
@@ -0,0 +1,113 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (a *API) registerCardAccessRoutes(r *mux.Router) {
+	// Card access APIs
+	r.HandleFunc("/cards/{cardID}/access", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleSetCardAccess)).Methods("PUT")
+}
+
+func (a *API) handleSetCardAccess(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /cards/{cardID}/access setCardAccess
+	//
+	// Restricts the specified card to its creator, its assignees, a list of
+	// allowed users and the board admins, or makes it visible to all the
+	// board members again.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the card access
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CardAccess"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/Card'
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	access, err := model.CardAccessFromJSON(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	cardBlock, err := a.app.GetBlockByID(cardID)
+	if err != nil {
+		message := fmt.Sprintf("could not fetch card %s: %s", cardID, err)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, cardBlock.BoardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to set card access"))
+		return
+	}
+
+	if err = a.app.CheckCanSeeBlocks(userID, cardBlock); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "setCardAccess", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", cardBlock.BoardID)
+	auditRec.AddMeta("cardID", cardID)
+	auditRec.AddMeta("restricted", access.Restricted)
+	auditRec.AddMeta("allowedUserCount", len(access.AllowedUserIDs))
+
+	card, err := a.app.SetCardAccess(cardID, access, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("SetCardAccess",
+		mlog.String("boardID", card.BoardID),
+		mlog.String("cardID", card.ID),
+		mlog.String("userID", userID),
+		mlog.Bool("restricted", card.IsRestricted),
+	)
+
+	data, err := json.Marshal(card)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
@@ -21,10 +21,16 @@ const (
 
 func (a *API) registerCardsRoutes(r *mux.Router) {
 	// Cards APIs
-	r.HandleFunc("/boards/{boardID}/cards", a.sessionRequired(a.handleCreateCard)).Methods("POST")
-	r.HandleFunc("/boards/{boardID}/cards", a.sessionRequired(a.handleGetCards)).Methods("GET")
-	r.HandleFunc("/cards/{cardID}", a.sessionRequired(a.handlePatchCard)).Methods("PATCH")
-	r.HandleFunc("/cards/{cardID}", a.sessionRequired(a.handleGetCard)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/cards", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleCreateCard)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/cards", a.sessionRequired(model.AccessTokenScopeRead, a.handleGetCards)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/cards/bulk", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleBulkUpdateCards)).Methods("POST")
+	r.HandleFunc("/cards/{cardID}", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handlePatchCard)).Methods("PATCH")
+	r.HandleFunc("/cards/{cardID}", a.sessionRequired(model.AccessTokenScopeRead, a.handleGetCard)).Methods("GET")
+	r.HandleFunc("/cards/{cardID}/move", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleMoveCard)).Methods("POST")
+	r.HandleFunc("/cards/{cardID}/archive", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleArchiveCard)).Methods("POST")
+	r.HandleFunc("/cards/{cardID}/unarchive", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleUnarchiveCard)).Methods("POST")
+	r.HandleFunc("/cards/{cardID}/history", a.sessionRequired(model.AccessTokenScopeRead, a.handleGetCardHistory)).Methods("GET")
+	r.HandleFunc("/cards/{cardID}/revert", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleRevertCard)).Methods("POST")
 }
 
 func (a *API) handleCreateCard(w http.ResponseWriter, r *http.Request) {
@@ -151,6 +157,11 @@ func (a *API) handleGetCards(w http.ResponseWriter, r *http.Request) {
 	//   description: Number of cards to return per page(default=100)
 	//   required: false
 	//   type: integer
+	// - name: include_archived
+	//   in: query
+	//   description: Include archived cards (default=false)
+	//   required: false
+	//   type: boolean
 	// security:
 	// - BearerAuth: []
 	// responses:
@@ -170,6 +181,7 @@ func (a *API) handleGetCards(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query()
 	strPage := query.Get("page")
 	strPerPage := query.Get("per_page")
+	includeArchived := query.Get("include_archived") == "true"
 
 	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
 		a.errorResponse(w, r, model.NewErrPermission("access denied to fetch cards"))
@@ -200,12 +212,20 @@ func (a *API) handleGetCards(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("boardID", boardID)
 	auditRec.AddMeta("page", page)
 	auditRec.AddMeta("per_page", perPage)
+	auditRec.AddMeta("include_archived", includeArchived)
+
+	cards, err := a.app.GetCardsForBoard(boardID, page, perPage, includeArchived)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
 
-	cards, err := a.app.GetCardsForBoard(boardID, page, perPage)
+	board, err := a.app.GetBoard(boardID)
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
 	}
+	cards = a.app.FilterRestrictedCards(userID, board, cards)
 
 	a.logger.Debug("GetCards",
 		mlog.String("boardID", boardID),
@@ -288,6 +308,11 @@ func (a *API) handlePatchCard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err = a.app.CheckCanSeeBlocks(userID, model.Card2Block(card)); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
 	var patch *model.CardPatch
 	if err = json.Unmarshal(requestBody, &patch); err != nil {
 		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
@@ -360,11 +385,16 @@ func (a *API) handleGetCard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionManageBoardCards) {
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionViewBoard) {
 		a.errorResponse(w, r, model.NewErrPermission("access denied to fetch card"))
 		return
 	}
 
+	if err = a.app.CheckCanSeeBlocks(userID, model.Card2Block(card)); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
 	auditRec := a.makeAuditRecord(r, "getCard", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelRead, auditRec)
 	auditRec.AddMeta("boardID", card.BoardID)
@@ -387,3 +417,515 @@ func (a *API) handleGetCard(w http.ResponseWriter, r *http.Request) {
 
 	auditRec.Success()
 }
+
+func (a *API) handleMoveCard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /cards/{cardID}/move moveCard
+	//
+	// Moves the specified card, with its content, comments and attachments, to another board.
+	// Property values are remapped to the target board's properties by name and option value.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the target board and property mapping
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/MoveCardRequest"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/Card'
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	request, err := model.MoveCardRequestFromJSON(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+	if err = request.IsValid(); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	card, err := a.app.GetCardByID(cardID)
+	if err != nil {
+		message := fmt.Sprintf("could not fetch card %s: %s", cardID, err)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to move card from board"))
+		return
+	}
+
+	if err = a.app.CheckCanSeeBlocks(userID, model.Card2Block(card)); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, request.TargetBoardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to move card to board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "moveCard", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", card.ID)
+	auditRec.AddMeta("targetBoardID", request.TargetBoardID)
+
+	movedCard, err := a.app.MoveCard(card.ID, request, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("MoveCard",
+		mlog.String("boardID", card.BoardID),
+		mlog.String("targetBoardID", movedCard.BoardID),
+		mlog.String("cardID", movedCard.ID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(movedCard)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleArchiveCard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /cards/{cardID}/archive archiveCard
+	//
+	// Archives the specified card. Archived cards are hidden from card
+	// listings but are kept until they are deleted.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/Card'
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	a.setCardArchived(w, r, true)
+}
+
+func (a *API) handleUnarchiveCard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /cards/{cardID}/unarchive unarchiveCard
+	//
+	// Restores the specified archived card.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/Card'
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	a.setCardArchived(w, r, false)
+}
+
+func (a *API) setCardArchived(w http.ResponseWriter, r *http.Request, archived bool) {
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	card, err := a.app.GetCardByID(cardID)
+	if err != nil {
+		message := fmt.Sprintf("could not fetch card %s: %s", cardID, err)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to archive card"))
+		return
+	}
+
+	if err = a.app.CheckCanSeeBlocks(userID, model.Card2Block(card)); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	action := "unarchiveCard"
+	if archived {
+		action = "archiveCard"
+	}
+
+	auditRec := a.makeAuditRecord(r, action, audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", card.ID)
+
+	if archived {
+		card, err = a.app.ArchiveCard(cardID, userID)
+	} else {
+		card, err = a.app.UnarchiveCard(cardID, userID)
+	}
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug(action,
+		mlog.String("boardID", card.BoardID),
+		mlog.String("cardID", card.ID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(card)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleBulkUpdateCards(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/cards/bulk bulkUpdateCards
+	//
+	// Applies one operation to a list of cards, or to the cards matching a filter.
+	// The cards are changed in a single transaction and a result is returned for each card.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the operation and the cards to apply it to
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BulkCardRequest"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/BulkCardResult"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	request, err := model.BulkCardRequestFromJSON(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+	if err = request.IsValid(); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to change cards"))
+		return
+	}
+
+	if request.Operation == model.BulkCardOperationMove &&
+		!a.permissions.HasPermissionToBoard(userID, request.Move.TargetBoardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to move cards to board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "bulkUpdateCards", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("operation", request.Operation)
+
+	results, err := a.app.BulkUpdateCards(boardID, request, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("BulkUpdateCards",
+		mlog.String("boardID", boardID),
+		mlog.String("operation", string(request.Operation)),
+		mlog.String("userID", userID),
+		mlog.Int("count", len(results)),
+	)
+
+	data, err := json.Marshal(results)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("count", len(results))
+	auditRec.Success()
+}
+
+func (a *API) handleGetCardHistory(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /cards/{cardID}/history getCardHistory
+	//
+	// Returns the versions of the specified card, newest first, with the
+	// changes made to the card and its content in each version.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: before
+	//   in: query
+	//   description: Only return versions older than this update time
+	//   required: false
+	//   type: integer
+	// - name: per_page
+	//   in: query
+	//   description: The number of versions to return (default 20)
+	//   required: false
+	//   type: integer
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/CardVersion"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	query := r.URL.Query()
+	opts := model.QueryCardHistoryOptions{}
+	if strBefore := query.Get("before"); strBefore != "" {
+		before, err := strconv.ParseInt(strBefore, 10, 64)
+		if err != nil {
+			message := fmt.Sprintf("invalid `before` parameter: %s", err)
+			a.errorResponse(w, r, model.NewErrBadRequest(message))
+			return
+		}
+		opts.BeforeUpdateAt = before
+	}
+	if strPerPage := query.Get("per_page"); strPerPage != "" {
+		perPage, err := strconv.Atoi(strPerPage)
+		if err != nil {
+			message := fmt.Sprintf("invalid `per_page` parameter: %s", err)
+			a.errorResponse(w, r, model.NewErrBadRequest(message))
+			return
+		}
+		opts.PerPage = perPage
+	}
+
+	card, err := a.app.GetCardByID(cardID)
+	if err != nil {
+		message := fmt.Sprintf("could not fetch card %s: %s", cardID, err)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to fetch card history"))
+		return
+	}
+
+	if err = a.app.CheckCanSeeBlocks(userID, model.Card2Block(card)); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getCardHistory", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", card.ID)
+
+	versions, err := a.app.GetCardHistory(cardID, opts)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetCardHistory",
+		mlog.String("boardID", card.BoardID),
+		mlog.String("cardID", card.ID),
+		mlog.String("userID", userID),
+		mlog.Int("versionCount", len(versions)),
+	)
+
+	data, err := json.Marshal(versions)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleRevertCard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /cards/{cardID}/revert revertCard
+	//
+	// Reverts the title, properties and content of the specified card to
+	// the version at the given update time. Comments are not changed.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: to
+	//   in: query
+	//   description: The update time of the version to revert to
+	//   required: true
+	//   type: integer
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/Card'
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	strTo := r.URL.Query().Get("to")
+	if strTo == "" {
+		a.errorResponse(w, r, model.NewErrBadRequest("missing `to` parameter"))
+		return
+	}
+	to, err := strconv.ParseInt(strTo, 10, 64)
+	if err != nil {
+		message := fmt.Sprintf("invalid `to` parameter: %s", err)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	card, err := a.app.GetCardByID(cardID)
+	if err != nil {
+		message := fmt.Sprintf("could not fetch card %s: %s", cardID, err)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to revert card"))
+		return
+	}
+
+	if err = a.app.CheckCanSeeBlocks(userID, model.Card2Block(card)); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "revertCard", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", card.ID)
+	auditRec.AddMeta("to", to)
+
+	card, err = a.app.RevertCard(cardID, to, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("RevertCard",
+		mlog.String("boardID", card.BoardID),
+		mlog.String("cardID", card.ID),
+		mlog.String("userID", userID),
+		mlog.Int("to", to),
+	)
+
+	data, err := json.Marshal(card)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
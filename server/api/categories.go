@@ -13,15 +13,15 @@ import (
 
 func (a *API) registerCategoriesRoutes(r *mux.Router) {
 	// Category APIs
-	r.HandleFunc("/teams/{teamID}/categories", a.sessionRequired(a.handleCreateCategory)).Methods(http.MethodPost)
-	r.HandleFunc("/teams/{teamID}/categories/reorder", a.sessionRequired(a.handleReorderCategories)).Methods(http.MethodPut)
-	r.HandleFunc("/teams/{teamID}/categories/{categoryID}", a.sessionRequired(a.handleUpdateCategory)).Methods(http.MethodPut)
-	r.HandleFunc("/teams/{teamID}/categories/{categoryID}", a.sessionRequired(a.handleDeleteCategory)).Methods(http.MethodDelete)
-	r.HandleFunc("/teams/{teamID}/categories", a.sessionRequired(a.handleGetUserCategoryBoards)).Methods(http.MethodGet)
-	r.HandleFunc("/teams/{teamID}/categories/{categoryID}/boards/reorder", a.sessionRequired(a.handleReorderCategoryBoards)).Methods(http.MethodPut)
-	r.HandleFunc("/teams/{teamID}/categories/{categoryID}/boards/{boardID}", a.sessionRequired(a.handleUpdateCategoryBoard)).Methods(http.MethodPost)
-	r.HandleFunc("/teams/{teamID}/categories/{categoryID}/boards/{boardID}/hide", a.sessionRequired(a.handleHideBoard)).Methods(http.MethodPut)
-	r.HandleFunc("/teams/{teamID}/categories/{categoryID}/boards/{boardID}/unhide", a.sessionRequired(a.handleUnhideBoard)).Methods(http.MethodPut)
+	r.HandleFunc("/teams/{teamID}/categories", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleCreateCategory)).Methods(http.MethodPost)
+	r.HandleFunc("/teams/{teamID}/categories/reorder", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleReorderCategories)).Methods(http.MethodPut)
+	r.HandleFunc("/teams/{teamID}/categories/{categoryID}", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleUpdateCategory)).Methods(http.MethodPut)
+	r.HandleFunc("/teams/{teamID}/categories/{categoryID}", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleDeleteCategory)).Methods(http.MethodDelete)
+	r.HandleFunc("/teams/{teamID}/categories", a.sessionRequired(model.AccessTokenScopeRead, a.handleGetUserCategoryBoards)).Methods(http.MethodGet)
+	r.HandleFunc("/teams/{teamID}/categories/{categoryID}/boards/reorder", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleReorderCategoryBoards)).Methods(http.MethodPut)
+	r.HandleFunc("/teams/{teamID}/categories/{categoryID}/boards/{boardID}", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleUpdateCategoryBoard)).Methods(http.MethodPost)
+	r.HandleFunc("/teams/{teamID}/categories/{categoryID}/boards/{boardID}/hide", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleHideBoard)).Methods(http.MethodPut)
+	r.HandleFunc("/teams/{teamID}/categories/{categoryID}/boards/{boardID}/unhide", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleUnhideBoard)).Methods(http.MethodPut)
 }
 
 func (a *API) handleCreateCategory(w http.ResponseWriter, r *http.Request) {
@@ -14,7 +14,7 @@ import (
 )
 
 func (a *API) registerChannelsRoutes(r *mux.Router) {
-	r.HandleFunc("/teams/{teamID}/channels/{channelID}", a.sessionRequired(a.handleGetChannel)).Methods("GET")
+	r.HandleFunc("/teams/{teamID}/channels/{channelID}", a.sessionRequired(model.AccessTokenScopeRead, a.handleGetChannel)).Methods("GET")
 }
 
 func (a *API) handleGetChannel(w http.ResponseWriter, r *http.Request) {
@@ -10,7 +10,7 @@ import (
 
 func (a *API) registerContentBlocksRoutes(r *mux.Router) {
 	// Blocks APIs
-	r.HandleFunc("/content-blocks/{blockID}/moveto/{where}/{dstBlockID}", a.sessionRequired(a.handleMoveBlockTo)).Methods("POST")
+	r.HandleFunc("/content-blocks/{blockID}/moveto/{where}/{dstBlockID}", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleMoveBlockTo)).Methods("POST")
 }
 
 func (a *API) handleMoveBlockTo(w http.ResponseWriter, r *http.Request) {
@@ -84,6 +84,10 @@ func (a *API) handleMoveBlockTo(w http.ResponseWriter, r *http.Request) {
 		a.errorResponse(w, r, model.NewErrPermission("access denied to modify board cards"))
 		return
 	}
+	if err = a.app.CheckCanSeeBlocks(userID, block, dstBlock); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
 
 	auditRec := a.makeAuditRecord(r, "moveBlockTo", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelModify, auditRec)
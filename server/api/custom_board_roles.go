@@ -0,0 +1,300 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (a *API) registerCustomBoardRolesRoutes(r *mux.Router) {
+	// Custom board role APIs
+	r.HandleFunc("/teams/{teamID}/board-roles", a.sessionRequired(model.AccessTokenScopeRead, a.handleGetCustomBoardRoles)).Methods("GET")
+	r.HandleFunc("/teams/{teamID}/board-roles", a.sessionRequired(model.AccessTokenScopeAdmin, a.handleCreateCustomBoardRole)).Methods("POST")
+	r.HandleFunc("/teams/{teamID}/board-roles/{roleID}", a.sessionRequired(model.AccessTokenScopeAdmin, a.handlePatchCustomBoardRole)).Methods("PATCH")
+	r.HandleFunc("/teams/{teamID}/board-roles/{roleID}", a.sessionRequired(model.AccessTokenScopeAdmin, a.handleDeleteCustomBoardRole)).Methods("DELETE")
+}
+
+func (a *API) handleGetCustomBoardRoles(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /teams/{teamID}/board-roles getCustomBoardRoles
+	//
+	// Returns the custom board roles of a team, sorted by name.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/CustomBoardRole"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	teamID := mux.Vars(r)["teamID"]
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to team"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getCustomBoardRoles", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+
+	roles, err := a.app.GetCustomBoardRolesForTeam(teamID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(roles)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleCreateCustomBoardRole(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /teams/{teamID}/board-roles createCustomBoardRole
+	//
+	// Creates a custom board role for a team. Custom roles are named sets of
+	// board permissions that can be assigned to board members on top of
+	// their scheme role. Only team admins can create them. The permissions
+	// to manage the members, the type and the deletion of a board are
+	// reserved to the board admins and can't be part of a custom role.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the role to create
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CustomBoardRole"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/CustomBoardRole"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	teamID := mux.Vars(r)["teamID"]
+
+	role, err := model.CustomBoardRoleFromJSON(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+	role.TeamID = teamID
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionManageTeam) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to create custom board role"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "createCustomBoardRole", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+	auditRec.AddMeta("name", role.Name)
+	auditRec.AddMeta("permissions", role.Permissions)
+
+	role, err = a.app.CreateCustomBoardRole(role, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("CreateCustomBoardRole",
+		mlog.String("teamID", teamID),
+		mlog.String("roleID", role.ID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(role)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec.AddMeta("roleID", role.ID)
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handlePatchCustomBoardRole(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PATCH /teams/{teamID}/board-roles/{roleID} patchCustomBoardRole
+	//
+	// Partially updates a custom board role. The changes apply to all the
+	// board members that have the role.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: roleID
+	//   in: path
+	//   description: Custom board role ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the role patch
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CustomBoardRolePatch"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/CustomBoardRole"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	teamID := vars["teamID"]
+	roleID := vars["roleID"]
+
+	patch, err := model.CustomBoardRolePatchFromJSON(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionManageTeam) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to modify custom board role"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "patchCustomBoardRole", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+	auditRec.AddMeta("roleID", roleID)
+
+	role, err := a.app.PatchCustomBoardRole(teamID, roleID, patch)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("PatchCustomBoardRole",
+		mlog.String("teamID", teamID),
+		mlog.String("roleID", roleID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(role)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteCustomBoardRole(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /teams/{teamID}/board-roles/{roleID} deleteCustomBoardRole
+	//
+	// Deletes a custom board role. The board members that had the role keep
+	// their scheme role only.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: roleID
+	//   in: path
+	//   description: Custom board role ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	teamID := vars["teamID"]
+	roleID := vars["roleID"]
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionManageTeam) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to delete custom board role"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteCustomBoardRole", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+	auditRec.AddMeta("roleID", roleID)
+
+	if err := a.app.DeleteCustomBoardRole(teamID, roleID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DeleteCustomBoardRole",
+		mlog.String("teamID", teamID),
+		mlog.String("roleID", roleID),
+		mlog.String("userID", userID),
+	)
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+)
+
+func (a *API) registerEmailVerificationRoutes(r *mux.Router) {
+	// Email verification APIs. These are not needed in plugin mode.
+	r.HandleFunc("/email/verify/send", a.handleSendEmailVerification).Methods("POST")
+	r.HandleFunc("/email/verify", a.handleVerifyEmail).Methods("POST")
+}
+
+func (a *API) handleSendEmailVerification(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /email/verify/send sendEmailVerification
+	//
+	// Sends a new email verification email to an unverified account. The
+	// response is the same whether the email address is registered or not.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   description: Email verification email request
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/SendEmailVerificationRequest"
+	// responses:
+	//   '200':
+	//     description: success
+	//   '501':
+	//     description: the SMTP server is not configured
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	if !a.checkLocalAccountsAllowed(w, r) {
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var requestData model.SendEmailVerificationRequest
+	if err = json.Unmarshal(requestBody, &requestData); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+	requestData.Email = strings.TrimSpace(requestData.Email)
+
+	if err = requestData.IsValid(); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "sendEmailVerification", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
+	auditRec.AddMeta("email", requestData.Email)
+
+	if err = a.app.SendEmailVerification(requestData.Email); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) handleVerifyEmail(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /email/verify verifyEmail
+	//
+	// Verifies the email address of a user with the token of an email
+	// verification email, the user can then log in.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   description: Email verification request
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/VerifyEmailRequest"
+	// responses:
+	//   '200':
+	//     description: success
+	//   '401':
+	//     description: invalid or expired token
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	if !a.checkLocalAccountsAllowed(w, r) {
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var requestData model.VerifyEmailRequest
+	if err = json.Unmarshal(requestBody, &requestData); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	if err = requestData.IsValid(); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "verifyEmail", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
+
+	if err = a.app.VerifyEmail(requestData.Token); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
@@ -75,9 +75,9 @@ func FileInfoResponseFromJSON(data io.Reader) (*mmModel.FileInfo, error) {
 
 func (a *API) registerFilesRoutes(r *mux.Router) {
 	// Files API
-	r.HandleFunc("/files/teams/{teamID}/{boardID}/{filename}", a.attachSession(a.handleServeFile, false)).Methods("GET")
-	r.HandleFunc("/files/teams/{teamID}/{boardID}/{filename}/info", a.attachSession(a.getFileInfo, false)).Methods("GET")
-	r.HandleFunc("/teams/{teamID}/{boardID}/files", a.sessionRequired(a.handleUploadFile)).Methods("POST")
+	r.HandleFunc("/files/teams/{teamID}/{boardID}/{filename}", a.attachSession(model.AccessTokenScopeRead, a.handleServeFile, false)).Methods("GET")
+	r.HandleFunc("/files/teams/{teamID}/{boardID}/{filename}/info", a.attachSession(model.AccessTokenScopeRead, a.getFileInfo, false)).Methods("GET")
+	r.HandleFunc("/teams/{teamID}/{boardID}/files", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleUploadFile)).Methods("POST")
 }
 
 func (a *API) handleServeFile(w http.ResponseWriter, r *http.Request) {
@@ -371,6 +371,10 @@ func (a *API) handleUploadFile(w http.ResponseWriter, r *http.Request) {
 		a.errorResponse(w, r, err)
 		return
 	}
+	if board.IsLocked {
+		a.errorResponse(w, r, model.ErrBoardIsLocked)
+		return
+	}
 
 	if a.app.GetConfig().MaxFileSize > 0 {
 		r.Body = http.MaxBytesReader(w, r.Body, a.app.GetConfig().MaxFileSize)
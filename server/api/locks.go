@@ -0,0 +1,238 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (a *API) registerLocksRoutes(r *mux.Router) {
+	// Locks APIs
+	r.HandleFunc("/boards/{boardID}/lock", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleLockBoard)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/unlock", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleUnlockBoard)).Methods("POST")
+	r.HandleFunc("/cards/{cardID}/lock", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleLockCard)).Methods("POST")
+	r.HandleFunc("/cards/{cardID}/unlock", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleUnlockCard)).Methods("POST")
+}
+
+func (a *API) handleLockBoard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/lock lockBoard
+	//
+	// Locks the specified board. The cards, views and properties of a
+	// locked board can't be changed until the board is unlocked.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/Board'
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	a.setBoardLocked(w, r, true)
+}
+
+func (a *API) handleUnlockBoard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/unlock unlockBoard
+	//
+	// Unlocks the specified board.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/Board'
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	a.setBoardLocked(w, r, false)
+}
+
+func (a *API) setBoardLocked(w http.ResponseWriter, r *http.Request, locked bool) {
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionLockBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to lock board"))
+		return
+	}
+
+	action := "unlockBoard"
+	if locked {
+		action = "lockBoard"
+	}
+
+	auditRec := a.makeAuditRecord(r, action, audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	board, err := a.app.SetBoardLocked(boardID, locked, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug(action,
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(board)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleLockCard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /cards/{cardID}/lock lockCard
+	//
+	// Locks the specified card. A locked card, its content, comments and
+	// attachments can't be changed until the card is unlocked.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/Card'
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	a.setCardLocked(w, r, true)
+}
+
+func (a *API) handleUnlockCard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /cards/{cardID}/unlock unlockCard
+	//
+	// Unlocks the specified card.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/Card'
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	a.setCardLocked(w, r, false)
+}
+
+func (a *API) setCardLocked(w http.ResponseWriter, r *http.Request, locked bool) {
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	card, err := a.app.GetCardByID(cardID)
+	if err != nil {
+		message := fmt.Sprintf("could not fetch card %s: %s", cardID, err)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionLockBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to lock card"))
+		return
+	}
+
+	if err = a.app.CheckCanSeeBlocks(userID, model.Card2Block(card)); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	action := "unlockCard"
+	if locked {
+		action = "lockCard"
+	}
+
+	auditRec := a.makeAuditRecord(r, action, audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", card.ID)
+
+	card, err = a.app.SetCardLocked(cardID, locked, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug(action,
+		mlog.String("boardID", card.BoardID),
+		mlog.String("cardID", card.ID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(card)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
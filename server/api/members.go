@@ -13,12 +13,12 @@ import (
 
 func (a *API) registerMembersRoutes(r *mux.Router) {
 	// Member APIs
-	r.HandleFunc("/boards/{boardID}/members", a.sessionRequired(a.handleGetMembersForBoard)).Methods("GET")
-	r.HandleFunc("/boards/{boardID}/members", a.sessionRequired(a.handleAddMember)).Methods("POST")
-	r.HandleFunc("/boards/{boardID}/members/{userID}", a.sessionRequired(a.handleUpdateMember)).Methods("PUT")
-	r.HandleFunc("/boards/{boardID}/members/{userID}", a.sessionRequired(a.handleDeleteMember)).Methods("DELETE")
-	r.HandleFunc("/boards/{boardID}/join", a.sessionRequired(a.handleJoinBoard)).Methods("POST")
-	r.HandleFunc("/boards/{boardID}/leave", a.sessionRequired(a.handleLeaveBoard)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/members", a.sessionRequired(model.AccessTokenScopeRead, a.handleGetMembersForBoard)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/members", a.sessionRequired(model.AccessTokenScopeAdmin, a.handleAddMember)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/members/{userID}", a.sessionRequired(model.AccessTokenScopeAdmin, a.handleUpdateMember)).Methods("PUT")
+	r.HandleFunc("/boards/{boardID}/members/{userID}", a.sessionRequired(model.AccessTokenScopeAdmin, a.handleDeleteMember)).Methods("DELETE")
+	r.HandleFunc("/boards/{boardID}/join", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleJoinBoard)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/leave", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleLeaveBoard)).Methods("POST")
 }
 
 func (a *API) handleGetMembersForBoard(w http.ResponseWriter, r *http.Request) {
@@ -162,6 +162,7 @@ func (a *API) handleAddMember(w http.ResponseWriter, r *http.Request) {
 		SchemeAdmin:     reqBoardMember.SchemeAdmin,
 		SchemeViewer:    reqBoardMember.SchemeViewer,
 		SchemeCommenter: reqBoardMember.SchemeCommenter,
+		CustomRoleID:    reqBoardMember.CustomRoleID,
 	}
 
 	auditRec := a.makeAuditRecord(r, "addMember", audit.Fail)
@@ -432,6 +433,7 @@ func (a *API) handleUpdateMember(w http.ResponseWriter, r *http.Request) {
 		SchemeEditor:    reqBoardMember.SchemeEditor,
 		SchemeCommenter: reqBoardMember.SchemeCommenter,
 		SchemeViewer:    reqBoardMember.SchemeViewer,
+		CustomRoleID:    reqBoardMember.CustomRoleID,
 	}
 
 	isGuest, err := a.userIsGuest(paramsUserID)
@@ -0,0 +1,257 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (a *API) registerMfaRoutes(r *mux.Router) {
+	// Multi-factor authentication APIs. These are not needed in plugin mode.
+	r.HandleFunc("/users/me/mfa/generate", a.mfaSetupSessionRequired(model.AccessTokenScopeAdmin, a.handleGenerateMfaSecret)).Methods("POST")
+	r.HandleFunc("/users/me/mfa/activate", a.mfaSetupSessionRequired(model.AccessTokenScopeAdmin, a.handleActivateMfa)).Methods("POST")
+	r.HandleFunc("/users/me/mfa/deactivate", a.sessionRequired(model.AccessTokenScopeAdmin, a.handleDeactivateMfa)).Methods("POST")
+	r.HandleFunc("/users/me/mfa/recovery-codes", a.sessionRequired(model.AccessTokenScopeAdmin, a.handleRegenerateMfaRecoveryCodes)).Methods("POST")
+}
+
+// checkMfaAllowed makes sure that multi-factor authentication is managed by
+// the server, and returns the ID of the current user.
+func (a *API) checkMfaAllowed(w http.ResponseWriter, r *http.Request) (string, bool) {
+	if a.MattermostAuth {
+		a.errorResponse(w, r, model.NewErrNotImplemented("not permitted in plugin mode"))
+		return "", false
+	}
+
+	userID := getUserID(r)
+	if userID == model.SingleUser {
+		a.errorResponse(w, r, model.NewErrNotImplemented("not permitted in single-user mode"))
+		return "", false
+	}
+	return userID, true
+}
+
+func (a *API) handleGenerateMfaSecret(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /users/me/mfa/generate generateMfaSecret
+	//
+	// Generates a new TOTP secret for the current user. Multi-factor
+	// authentication is not active until the secret is verified.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/MfaSecret"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID, ok := a.checkMfaAllowed(w, r)
+	if !ok {
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "generateMfaSecret", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
+
+	secret, err := a.app.GenerateMfaSecret(userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GenerateMfaSecret", mlog.String("userID", userID))
+
+	data, err := json.Marshal(secret)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleActivateMfa(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /users/me/mfa/activate activateMfa
+	//
+	// Activates multi-factor authentication for the current user, verifying
+	// a code of the generated secret. Returns the recovery codes of the user,
+	// that won't be shown again.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: Body
+	//   in: body
+	//   description: a code of the generated secret
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/MfaCodeRequest"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/MfaRecoveryCodes"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID, ok := a.checkMfaAllowed(w, r)
+	if !ok {
+		return
+	}
+
+	var request model.MfaCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "activateMfa", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
+
+	recoveryCodes, err := a.app.ActivateMfa(userID, request.Code)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("ActivateMfa", mlog.String("userID", userID))
+
+	data, err := json.Marshal(model.MfaRecoveryCodes{RecoveryCodes: recoveryCodes})
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleDeactivateMfa(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /users/me/mfa/deactivate deactivateMfa
+	//
+	// Deactivates multi-factor authentication for the current user. Not
+	// allowed if the server requires it.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: Body
+	//   in: body
+	//   description: a TOTP code or a recovery code
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/MfaCodeRequest"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID, ok := a.checkMfaAllowed(w, r)
+	if !ok {
+		return
+	}
+
+	var request model.MfaCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deactivateMfa", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
+
+	if err := a.app.DeactivateMfa(userID, request.Code); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DeactivateMfa", mlog.String("userID", userID))
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) handleRegenerateMfaRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /users/me/mfa/recovery-codes regenerateMfaRecoveryCodes
+	//
+	// Replaces the recovery codes of the current user.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: Body
+	//   in: body
+	//   description: a TOTP code or a recovery code
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/MfaCodeRequest"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/MfaRecoveryCodes"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID, ok := a.checkMfaAllowed(w, r)
+	if !ok {
+		return
+	}
+
+	var request model.MfaCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "regenerateMfaRecoveryCodes", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
+
+	recoveryCodes, err := a.app.RegenerateMfaRecoveryCodes(userID, request.Code)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("RegenerateMfaRecoveryCodes", mlog.String("userID", userID))
+
+	data, err := json.Marshal(model.MfaRecoveryCodes{RecoveryCodes: recoveryCodes})
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
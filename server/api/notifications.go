@@ -14,13 +14,13 @@ import (
 )
 
 func (a *API) registerNotificationsRoutes(r *mux.Router) {
-	r.HandleFunc("/notifications", a.sessionRequired(a.handleGetNotifications)).Methods("GET")
-	r.HandleFunc("/notifications", a.sessionRequired(a.handleCreateNotification)).Methods("POST")
-	r.HandleFunc("/notifications/unread_count", a.sessionRequired(a.handleGetUnreadNotificationsCount)).Methods("GET")
-	r.HandleFunc("/notifications/{notificationID}", a.sessionRequired(a.handleGetNotification)).Methods("GET")
-	r.HandleFunc("/notifications/{notificationID}/read", a.sessionRequired(a.handleMarkNotificationAsRead)).Methods("PUT")
-	r.HandleFunc("/notifications/mark_all_as_read", a.sessionRequired(a.handleMarkAllNotificationsAsRead)).Methods("PUT")
-	r.HandleFunc("/notifications/{notificationID}", a.sessionRequired(a.handleDeleteNotification)).Methods("DELETE")
+	r.HandleFunc("/notifications", a.sessionRequired(model.AccessTokenScopeRead, a.handleGetNotifications)).Methods("GET")
+	r.HandleFunc("/notifications", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleCreateNotification)).Methods("POST")
+	r.HandleFunc("/notifications/unread_count", a.sessionRequired(model.AccessTokenScopeRead, a.handleGetUnreadNotificationsCount)).Methods("GET")
+	r.HandleFunc("/notifications/{notificationID}", a.sessionRequired(model.AccessTokenScopeRead, a.handleGetNotification)).Methods("GET")
+	r.HandleFunc("/notifications/{notificationID}/read", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleMarkNotificationAsRead)).Methods("PUT")
+	r.HandleFunc("/notifications/mark_all_as_read", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleMarkAllNotificationsAsRead)).Methods("PUT")
+	r.HandleFunc("/notifications/{notificationID}", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleDeleteNotification)).Methods("DELETE")
 }
 
 // handleGetNotifications kullanıcının bildirimlerini getirir
@@ -0,0 +1,199 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/app"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+	"github.com/mattermost/focalboard/server/services/auth"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const (
+	oidcRequestCookie       = "FOCALBOARDOIDCREQUEST"
+	oidcRequestCookiePath   = "/oauth/oidc"
+	oidcRequestCookieMaxAge = 10 * 60
+)
+
+func (a *API) registerOidcRoutes(r *mux.Router) {
+	// OpenID Connect single sign-on. These are browser redirects, so they
+	// are outside the /api/v2 path and its CSRF header check, the state
+	// parameter protects the callback instead.
+	r.HandleFunc("/oauth/oidc/login", a.handleOidcLogin).Methods("GET")
+	r.HandleFunc(app.OidcCallbackPath, a.handleOidcCallback).Methods("GET")
+}
+
+func (a *API) handleOidcLogin(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /oauth/oidc/login oidcLogin
+	//
+	// Starts an OpenID Connect login, redirecting to the identity provider
+	//
+	// ---
+	// parameters:
+	// - name: redirect_to
+	//   in: query
+	//   description: Path to redirect to after the login
+	//   required: false
+	//   type: string
+	// responses:
+	//   '302':
+	//     description: redirect to the identity provider
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	if a.MattermostAuth {
+		a.errorResponse(w, r, model.NewErrNotImplemented("not permitted in plugin mode"))
+		return
+	}
+
+	request, err := a.app.StartOidcLogin(r.Context(), safeRedirectPath(r.URL.Query().Get("redirect_to")))
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(request)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcRequestCookie,
+		Value:    base64.RawURLEncoding.EncodeToString(data),
+		Path:     oidcRequestCookiePath,
+		MaxAge:   oidcRequestCookieMaxAge,
+		HttpOnly: true,
+		Secure:   a.app.GetConfig().SecureCookie,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	http.Redirect(w, r, request.URL, http.StatusFound)
+}
+
+func (a *API) handleOidcCallback(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /oauth/oidc/callback oidcCallback
+	//
+	// Completes an OpenID Connect login, creating the user on its first
+	// login, and redirects to the requested page with a session cookie
+	//
+	// ---
+	// parameters:
+	// - name: code
+	//   in: query
+	//   description: Authorization code
+	//   required: true
+	//   type: string
+	// - name: state
+	//   in: query
+	//   description: State of the authorization request
+	//   required: true
+	//   type: string
+	// responses:
+	//   '302':
+	//     description: success
+	//   '401':
+	//     description: invalid login
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	if a.MattermostAuth {
+		a.errorResponse(w, r, model.NewErrNotImplemented("not permitted in plugin mode"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "oidcLogin", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
+
+	request, err := readOidcRequestCookie(r)
+	// the request can only be used once
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcRequestCookie,
+		Path:     oidcRequestCookiePath,
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   a.app.GetConfig().SecureCookie,
+		SameSite: http.SameSiteLaxMode,
+	})
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	query := r.URL.Query()
+	if providerErr := query.Get("error"); providerErr != "" {
+		a.errorResponse(w, r, model.NewErrUnauthorized("identity provider error: "+providerErr))
+		return
+	}
+	if query.Get("state") == "" || query.Get("state") != request.State {
+		a.errorResponse(w, r, model.NewErrUnauthorized("invalid OpenID Connect state"))
+		return
+	}
+
+	token, err := a.app.LoginWithOidc(r.Context(), request, query.Get("code"), a.newSessionProps(r))
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     auth.SessionCookieToken,
+		Value:    token,
+		Path:     "/",
+		MaxAge:   int(a.app.GetConfig().SessionExpireTime),
+		HttpOnly: true,
+		Secure:   a.app.GetConfig().SecureCookie,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	redirectTo := request.RedirectTo
+	if redirectTo == "" {
+		redirectTo = "/"
+	}
+
+	a.logger.Debug("OidcLogin", mlog.String("redirectTo", redirectTo))
+	http.Redirect(w, r, redirectTo, http.StatusFound)
+	auditRec.Success()
+}
+
+func readOidcRequestCookie(r *http.Request) (*app.OidcAuthRequest, error) {
+	cookie, err := r.Cookie(oidcRequestCookie)
+	if err != nil {
+		return nil, model.NewErrUnauthorized("missing OpenID Connect request, the login may have expired")
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(cookie.Value)
+	if err != nil {
+		return nil, model.NewErrUnauthorized("invalid OpenID Connect request")
+	}
+
+	var request app.OidcAuthRequest
+	if err := json.Unmarshal(data, &request); err != nil {
+		return nil, model.NewErrUnauthorized("invalid OpenID Connect request")
+	}
+	return &request, nil
+}
+
+// safeRedirectPath only keeps local paths, so the login can't be used to
+// redirect to another site.
+func safeRedirectPath(path string) string {
+	if !strings.HasPrefix(path, "/") || strings.HasPrefix(path, "//") || strings.HasPrefix(path, "/\\") {
+		return ""
+	}
+	return path
+}
@@ -10,7 +10,7 @@ import (
 
 func (a *API) registerOnboardingRoutes(r *mux.Router) {
 	// Onboarding tour endpoints APIs
-	r.HandleFunc("/teams/{teamID}/onboard", a.sessionRequired(a.handleOnboard)).Methods(http.MethodPost)
+	r.HandleFunc("/teams/{teamID}/onboard", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleOnboard)).Methods(http.MethodPost)
 }
 
 func (a *API) handleOnboard(w http.ResponseWriter, r *http.Request) {
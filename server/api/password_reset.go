@@ -0,0 +1,157 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+)
+
+func (a *API) registerPasswordResetRoutes(r *mux.Router) {
+	// Password reset APIs. These are not needed in plugin mode.
+	r.HandleFunc("/password/reset/send", a.handleSendPasswordReset).Methods("POST")
+	r.HandleFunc("/password/reset", a.handleResetPassword).Methods("POST")
+}
+
+// checkLocalAccountsAllowed makes sure that the accounts are managed by
+// the server.
+func (a *API) checkLocalAccountsAllowed(w http.ResponseWriter, r *http.Request) bool {
+	if a.MattermostAuth {
+		a.errorResponse(w, r, model.NewErrNotImplemented("not permitted in plugin mode"))
+		return false
+	}
+
+	if len(a.singleUserToken) > 0 {
+		// Not permitted in single-user mode
+		a.errorResponse(w, r, model.NewErrUnauthorized("not permitted in single-user mode"))
+		return false
+	}
+	return true
+}
+
+func (a *API) handleSendPasswordReset(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /password/reset/send sendPasswordReset
+	//
+	// Sends a password reset email. The response is the same whether the
+	// email address is registered or not.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   description: Password reset email request
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/SendPasswordResetRequest"
+	// responses:
+	//   '200':
+	//     description: success
+	//   '501':
+	//     description: the SMTP server is not configured
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	if !a.checkLocalAccountsAllowed(w, r) {
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var requestData model.SendPasswordResetRequest
+	if err = json.Unmarshal(requestBody, &requestData); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+	requestData.Email = strings.TrimSpace(requestData.Email)
+
+	if err = requestData.IsValid(); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "sendPasswordReset", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
+	auditRec.AddMeta("email", requestData.Email)
+
+	if err = a.app.SendPasswordReset(requestData.Email); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) handleResetPassword(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /password/reset resetPassword
+	//
+	// Sets a new password with the token of a password reset email, and
+	// revokes all the sessions of the user.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   description: Password reset request
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/ResetPasswordRequest"
+	// responses:
+	//   '200':
+	//     description: success
+	//   '400':
+	//     description: the password doesn't match the password policy
+	//   '401':
+	//     description: invalid or expired token
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	if !a.checkLocalAccountsAllowed(w, r) {
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var requestData model.ResetPasswordRequest
+	if err = json.Unmarshal(requestBody, &requestData); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	if err = requestData.IsValid(); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "resetPassword", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
+
+	if err = a.app.ResetPassword(requestData.Token, requestData.NewPassword); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (a *API) registerPermissionsRoutes(r *mux.Router) {
+	// Permissions APIs
+	r.HandleFunc("/boards/{boardID}/permissions/explain", a.sessionRequired(model.AccessTokenScopeRead, a.handleExplainBoardPermissions)).Methods("GET")
+}
+
+func (a *API) handleExplainBoardPermissions(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/permissions/explain explainBoardPermissions
+	//
+	// Returns every board permission of a user and the rule that granted or
+	// denied it: the member role, a synthetic membership, the minimum role of
+	// the board, a custom board role, the team admin elevation, a locked board
+	// or the lack of membership. Users can explain their own permissions,
+	// board, team and system admins can explain the permissions of anyone.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: userId
+	//   in: query
+	//   description: The user to explain the permissions of, defaults to the current user
+	//   required: false
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardPermissionsExplanation"
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+	explainedUserID := r.URL.Query().Get("userId")
+	if explainedUserID == "" {
+		explainedUserID = userID
+	}
+
+	board, err := a.app.GetBoard(boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if explainedUserID != userID &&
+		!a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) &&
+		!a.permissions.HasPermissionToTeam(userID, board.TeamID, model.PermissionManageTeam) &&
+		!a.permissions.HasPermissionTo(userID, model.PermissionManageSystem) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to explain the permissions of another user"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "explainBoardPermissions", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("explainedUserID", explainedUserID)
+
+	explanation := a.app.ExplainBoardPermissions(explainedUserID, boardID)
+
+	a.logger.Debug("ExplainBoardPermissions",
+		mlog.String("boardID", boardID),
+		mlog.String("explainedUserID", explainedUserID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(explanation)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
@@ -0,0 +1,241 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (a *API) registerRecurrencesRoutes(r *mux.Router) {
+	// Card recurrence APIs
+	r.HandleFunc("/cards/{cardID}/recurrence", a.sessionRequired(model.AccessTokenScopeRead, a.handleGetCardRecurrence)).Methods("GET")
+	r.HandleFunc("/cards/{cardID}/recurrence", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleSetCardRecurrence)).Methods("PUT")
+	r.HandleFunc("/cards/{cardID}/recurrence", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleDeleteCardRecurrence)).Methods("DELETE")
+}
+
+func (a *API) handleGetCardRecurrence(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /cards/{cardID}/recurrence getCardRecurrence
+	//
+	// Returns the recurrence schedule of a card.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/CardRecurrence"
+	//   '404':
+	//     description: card has no recurrence
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	card, err := a.app.GetCardByID(cardID)
+	if err != nil {
+		message := fmt.Sprintf("could not fetch card %s: %s", cardID, err)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to card recurrence"))
+		return
+	}
+
+	if err = a.app.CheckCanSeeBlocks(userID, model.Card2Block(card)); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getCardRecurrence", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", card.ID)
+
+	recurrence, err := a.app.GetCardRecurrence(cardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(recurrence)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleSetCardRecurrence(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /cards/{cardID}/recurrence setCardRecurrence
+	//
+	// Creates or replaces the recurrence schedule of a card. A copy of the card, with its
+	// due date set to the occurrence, is created each time the schedule is due.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the recurrence schedule
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CardRecurrence"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/CardRecurrence"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	recurrence, err := model.CardRecurrenceFromJSON(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	card, err := a.app.GetCardByID(cardID)
+	if err != nil {
+		message := fmt.Sprintf("could not fetch card %s: %s", cardID, err)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to set card recurrence"))
+		return
+	}
+
+	if err = a.app.CheckCanSeeBlocks(userID, model.Card2Block(card)); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "setCardRecurrence", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", card.ID)
+	auditRec.AddMeta("rule", recurrence.Rule)
+
+	recurrence.CardID = card.ID
+	recurrence, err = a.app.SetCardRecurrence(recurrence, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("SetCardRecurrence",
+		mlog.String("boardID", card.BoardID),
+		mlog.String("cardID", card.ID),
+		mlog.String("rule", recurrence.Rule),
+		mlog.Int("nextRunAt", recurrence.NextRunAt),
+	)
+
+	data, err := json.Marshal(recurrence)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteCardRecurrence(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /cards/{cardID}/recurrence deleteCardRecurrence
+	//
+	// Stops a card from recurring. Copies already created are kept.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	card, err := a.app.GetCardByID(cardID)
+	if err != nil {
+		message := fmt.Sprintf("could not fetch card %s: %s", cardID, err)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to delete card recurrence"))
+		return
+	}
+
+	if err = a.app.CheckCanSeeBlocks(userID, model.Card2Block(card)); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteCardRecurrence", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", card.ID)
+
+	if err = a.app.DeleteCardRecurrence(cardID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DeleteCardRecurrence",
+		mlog.String("boardID", card.BoardID),
+		mlog.String("cardID", card.ID),
+	)
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
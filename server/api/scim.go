@@ -0,0 +1,682 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/app"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const scimContentType = "application/scim+json"
+
+func (a *API) registerScimRoutes(r *mux.Router) {
+	// SCIM 2.0 provisioning APIs. The identity providers authenticate with
+	// the SCIM bearer token and don't send the CSRF header, so these are
+	// outside the /api/v2 path.
+	scim := r.PathPrefix(app.ScimPath).Subrouter()
+	scim.Use(a.panicHandler)
+
+	scim.HandleFunc("/Users", a.scimRequired(a.handleScimGetUsers)).Methods("GET")
+	scim.HandleFunc("/Users", a.scimRequired(a.handleScimCreateUser)).Methods("POST")
+	scim.HandleFunc("/Users/{userID}", a.scimRequired(a.handleScimGetUser)).Methods("GET")
+	scim.HandleFunc("/Users/{userID}", a.scimRequired(a.handleScimReplaceUser)).Methods("PUT")
+	scim.HandleFunc("/Users/{userID}", a.scimRequired(a.handleScimPatchUser)).Methods("PATCH")
+	scim.HandleFunc("/Users/{userID}", a.scimRequired(a.handleScimDeleteUser)).Methods("DELETE")
+
+	scim.HandleFunc("/Groups", a.scimRequired(a.handleScimGetGroups)).Methods("GET")
+	scim.HandleFunc("/Groups", a.scimRequired(a.handleScimCreateGroup)).Methods("POST")
+	scim.HandleFunc("/Groups/{groupID}", a.scimRequired(a.handleScimGetGroup)).Methods("GET")
+	scim.HandleFunc("/Groups/{groupID}", a.scimRequired(a.handleScimReplaceGroup)).Methods("PUT")
+	scim.HandleFunc("/Groups/{groupID}", a.scimRequired(a.handleScimPatchGroup)).Methods("PATCH")
+	scim.HandleFunc("/Groups/{groupID}", a.scimRequired(a.handleScimDeleteGroup)).Methods("DELETE")
+}
+
+// scimRequired checks that the SCIM API is enabled and that the request
+// has the SCIM bearer token.
+func (a *API) scimRequired(handler func(w http.ResponseWriter, r *http.Request)) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if a.MattermostAuth {
+			a.scimErrorResponse(w, r, model.NewErrNotImplemented("not permitted in plugin mode"))
+			return
+		}
+		if !a.app.IsScimEnabled() {
+			a.scimErrorResponse(w, r, model.NewErrNotImplemented("SCIM provisioning is not enabled"))
+			return
+		}
+
+		token := ""
+		if header := r.Header.Get("Authorization"); len(header) > 7 && strings.EqualFold(header[:7], "Bearer ") {
+			token = strings.TrimSpace(header[7:])
+		}
+		if !a.app.IsValidScimToken(token) {
+			a.scimErrorResponse(w, r, model.NewErrUnauthorized("invalid SCIM token"))
+			return
+		}
+
+		handler(w, r)
+	}
+}
+
+// scimErrorResponse writes an error in the SCIM format.
+func (a *API) scimErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	scimError := model.ScimError{
+		Schemas: []string{model.ScimSchemaError},
+		Detail:  err.Error(),
+	}
+
+	code := http.StatusInternalServerError
+	switch {
+	case model.IsErrBadRequest(err):
+		code = http.StatusBadRequest
+		scimError.ScimType = "invalidValue"
+	case model.IsErrUnauthorized(err):
+		code = http.StatusUnauthorized
+	case model.IsErrForbidden(err):
+		code = http.StatusForbidden
+	case model.IsErrNotFound(err):
+		code = http.StatusNotFound
+	case model.IsErrConflict(err):
+		code = http.StatusConflict
+		scimError.ScimType = "uniqueness"
+	case model.IsErrNotImplemented(err):
+		code = http.StatusNotImplemented
+	default:
+		a.logger.Error("SCIM API ERROR",
+			mlog.Int("code", code),
+			mlog.Err(err),
+			mlog.String("api", r.URL.Path),
+		)
+		scimError.Detail = "internal server error"
+	}
+	scimError.Status = strconv.Itoa(code)
+
+	data, err := json.Marshal(scimError)
+	if err != nil {
+		data = []byte("{}")
+	}
+	scimBytesResponse(w, code, data)
+}
+
+func scimBytesResponse(w http.ResponseWriter, code int, data []byte) {
+	setResponseHeader(w, "Content-Type", scimContentType)
+	w.WriteHeader(code)
+	_, _ = w.Write(data)
+}
+
+func (a *API) scimJSONResponse(w http.ResponseWriter, r *http.Request, code int, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		a.scimErrorResponse(w, r, err)
+		return
+	}
+	scimBytesResponse(w, code, data)
+}
+
+// readScimBody decodes the body of a SCIM request.
+func readScimBody(r *http.Request, v interface{}) error {
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	if err = json.Unmarshal(requestBody, v); err != nil {
+		return model.NewErrBadRequest(err.Error())
+	}
+	return nil
+}
+
+// scimListParams returns the filter, the 1-based start index and the page
+// size of a SCIM list request.
+func scimListParams(r *http.Request) (string, int, int, error) {
+	query := r.URL.Query()
+
+	startIndex := 1
+	if value := query.Get("startIndex"); value != "" {
+		var err error
+		if startIndex, err = strconv.Atoi(value); err != nil {
+			return "", 0, 0, model.NewErrBadRequest("invalid startIndex")
+		}
+		if startIndex < 1 {
+			startIndex = 1
+		}
+	}
+
+	count := model.ScimMaxResults
+	if value := query.Get("count"); value != "" {
+		var err error
+		if count, err = strconv.Atoi(value); err != nil {
+			return "", 0, 0, model.NewErrBadRequest("invalid count")
+		}
+		if count < 0 {
+			count = 0
+		}
+		if count > model.ScimMaxResults {
+			count = model.ScimMaxResults
+		}
+	}
+
+	return query.Get("filter"), startIndex, count, nil
+}
+
+func scimListResponse(resources interface{}, total, startIndex, itemsPerPage int) *model.ScimListResponse {
+	return &model.ScimListResponse{
+		Schemas:      []string{model.ScimSchemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: itemsPerPage,
+		Resources:    resources,
+	}
+}
+
+// readScimPatch decodes a SCIM patch request.
+func readScimPatch(r *http.Request) ([]model.ScimPatchOperation, error) {
+	var patch model.ScimPatchRequest
+	if err := readScimBody(r, &patch); err != nil {
+		return nil, err
+	}
+	if len(patch.Operations) == 0 {
+		return nil, model.NewErrBadRequest("the patch request has no operations")
+	}
+	return patch.Operations, nil
+}
+
+func (a *API) handleScimGetUsers(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /scim/v2/Users scimGetUsers
+	//
+	// Lists the users. The deactivated users are listed as inactive, the
+	// deleted users aren't listed. The supported filters are the equality
+	// of the userName, the email address or the id
+	//
+	// ---
+	// produces:
+	// - application/scim+json
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+
+	filter, startIndex, count, err := scimListParams(r)
+	if err != nil {
+		a.scimErrorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "scimGetUsers", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("filter", filter)
+
+	users, total, err := a.app.GetScimUsers(filter, startIndex, count)
+	if err != nil {
+		a.scimErrorResponse(w, r, err)
+		return
+	}
+
+	a.scimJSONResponse(w, r, http.StatusOK, scimListResponse(users, total, startIndex, len(users)))
+	auditRec.Success()
+}
+
+func (a *API) handleScimGetUser(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /scim/v2/Users/{userID} scimGetUser
+	//
+	// Returns a user
+	//
+	// ---
+	// produces:
+	// - application/scim+json
+	// parameters:
+	// - name: userID
+	//   in: path
+	//   description: User ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+
+	userID := mux.Vars(r)["userID"]
+
+	auditRec := a.makeAuditRecord(r, "scimGetUser", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("userID", userID)
+
+	user, err := a.app.GetScimUser(userID)
+	if err != nil {
+		a.scimErrorResponse(w, r, err)
+		return
+	}
+
+	a.scimJSONResponse(w, r, http.StatusOK, user)
+	auditRec.Success()
+}
+
+func (a *API) handleScimCreateUser(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /scim/v2/Users scimCreateUser
+	//
+	// Provisions a user
+	//
+	// ---
+	// produces:
+	// - application/scim+json
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '201':
+	//     description: success
+	//   default:
+	//     description: internal error
+
+	var scimUser model.ScimUser
+	if err := readScimBody(r, &scimUser); err != nil {
+		a.scimErrorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "scimCreateUser", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
+	auditRec.AddMeta("username", scimUser.UserName)
+
+	user, err := a.app.CreateScimUser(&scimUser)
+	if err != nil {
+		a.scimErrorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("ScimCreateUser", mlog.String("userID", user.ID))
+
+	auditRec.AddMeta("userID", user.ID)
+	a.scimJSONResponse(w, r, http.StatusCreated, user)
+	auditRec.Success()
+}
+
+func (a *API) handleScimReplaceUser(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /scim/v2/Users/{userID} scimReplaceUser
+	//
+	// Replaces the attributes of a user. The active attribute deactivates
+	// or reactivates the user
+	//
+	// ---
+	// produces:
+	// - application/scim+json
+	// parameters:
+	// - name: userID
+	//   in: path
+	//   description: User ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+
+	userID := mux.Vars(r)["userID"]
+
+	var scimUser model.ScimUser
+	if err := readScimBody(r, &scimUser); err != nil {
+		a.scimErrorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "scimReplaceUser", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
+	auditRec.AddMeta("userID", userID)
+
+	user, err := a.app.ReplaceScimUser(userID, &scimUser)
+	if err != nil {
+		a.scimErrorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("ScimReplaceUser", mlog.String("userID", userID))
+
+	a.scimJSONResponse(w, r, http.StatusOK, user)
+	auditRec.Success()
+}
+
+func (a *API) handleScimPatchUser(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PATCH /scim/v2/Users/{userID} scimPatchUser
+	//
+	// Applies a SCIM patch request to a user. Setting active to false
+	// deactivates the user, and setting it to true reactivates it
+	//
+	// ---
+	// produces:
+	// - application/scim+json
+	// parameters:
+	// - name: userID
+	//   in: path
+	//   description: User ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+
+	userID := mux.Vars(r)["userID"]
+
+	operations, err := readScimPatch(r)
+	if err != nil {
+		a.scimErrorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "scimPatchUser", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
+	auditRec.AddMeta("userID", userID)
+
+	user, err := a.app.PatchScimUser(userID, operations)
+	if err != nil {
+		a.scimErrorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("ScimPatchUser", mlog.String("userID", userID))
+
+	a.scimJSONResponse(w, r, http.StatusOK, user)
+	auditRec.Success()
+}
+
+func (a *API) handleScimDeleteUser(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /scim/v2/Users/{userID} scimDeleteUser
+	//
+	// Deprovisions a user: it is deactivated, its sessions are revoked and
+	// it is removed from the teams, except the ones it is the last admin
+	// of. The user isn't found afterwards
+	//
+	// ---
+	// parameters:
+	// - name: userID
+	//   in: path
+	//   description: User ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '204':
+	//     description: success
+	//   default:
+	//     description: internal error
+
+	userID := mux.Vars(r)["userID"]
+
+	auditRec := a.makeAuditRecord(r, "scimDeleteUser", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
+	auditRec.AddMeta("userID", userID)
+
+	if err := a.app.DeleteScimUser(userID); err != nil {
+		a.scimErrorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("ScimDeleteUser", mlog.String("userID", userID))
+
+	w.WriteHeader(http.StatusNoContent)
+	auditRec.Success()
+}
+
+func (a *API) handleScimGetGroups(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /scim/v2/Groups scimGetGroups
+	//
+	// Lists the groups, which are the active managed teams. The supported
+	// filters are the equality of the displayName or the id
+	//
+	// ---
+	// produces:
+	// - application/scim+json
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+
+	filter, startIndex, count, err := scimListParams(r)
+	if err != nil {
+		a.scimErrorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "scimGetGroups", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("filter", filter)
+
+	groups, total, err := a.app.GetScimGroups(filter, startIndex, count)
+	if err != nil {
+		a.scimErrorResponse(w, r, err)
+		return
+	}
+
+	a.scimJSONResponse(w, r, http.StatusOK, scimListResponse(groups, total, startIndex, len(groups)))
+	auditRec.Success()
+}
+
+func (a *API) handleScimGetGroup(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /scim/v2/Groups/{groupID} scimGetGroup
+	//
+	// Returns a group
+	//
+	// ---
+	// produces:
+	// - application/scim+json
+	// parameters:
+	// - name: groupID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+
+	groupID := mux.Vars(r)["groupID"]
+
+	auditRec := a.makeAuditRecord(r, "scimGetGroup", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("teamID", groupID)
+
+	group, err := a.app.GetScimGroup(groupID)
+	if err != nil {
+		a.scimErrorResponse(w, r, err)
+		return
+	}
+
+	a.scimJSONResponse(w, r, http.StatusOK, group)
+	auditRec.Success()
+}
+
+func (a *API) handleScimCreateGroup(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /scim/v2/Groups scimCreateGroup
+	//
+	// Creates a team with the members of the group
+	//
+	// ---
+	// produces:
+	// - application/scim+json
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '201':
+	//     description: success
+	//   default:
+	//     description: internal error
+
+	var scimGroup model.ScimGroup
+	if err := readScimBody(r, &scimGroup); err != nil {
+		a.scimErrorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "scimCreateGroup", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("displayName", scimGroup.DisplayName)
+
+	group, err := a.app.CreateScimGroup(&scimGroup)
+	if err != nil {
+		a.scimErrorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("ScimCreateGroup", mlog.String("teamID", group.ID))
+
+	auditRec.AddMeta("teamID", group.ID)
+	a.scimJSONResponse(w, r, http.StatusCreated, group)
+	auditRec.Success()
+}
+
+func (a *API) handleScimReplaceGroup(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /scim/v2/Groups/{groupID} scimReplaceGroup
+	//
+	// Replaces the title and the members of a team
+	//
+	// ---
+	// produces:
+	// - application/scim+json
+	// parameters:
+	// - name: groupID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+
+	groupID := mux.Vars(r)["groupID"]
+
+	var scimGroup model.ScimGroup
+	if err := readScimBody(r, &scimGroup); err != nil {
+		a.scimErrorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "scimReplaceGroup", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("teamID", groupID)
+
+	group, err := a.app.ReplaceScimGroup(groupID, &scimGroup)
+	if err != nil {
+		a.scimErrorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("ScimReplaceGroup", mlog.String("teamID", groupID))
+
+	a.scimJSONResponse(w, r, http.StatusOK, group)
+	auditRec.Success()
+}
+
+func (a *API) handleScimPatchGroup(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PATCH /scim/v2/Groups/{groupID} scimPatchGroup
+	//
+	// Applies a SCIM patch request to a team, adding or removing members
+	//
+	// ---
+	// produces:
+	// - application/scim+json
+	// parameters:
+	// - name: groupID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+
+	groupID := mux.Vars(r)["groupID"]
+
+	operations, err := readScimPatch(r)
+	if err != nil {
+		a.scimErrorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "scimPatchGroup", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("teamID", groupID)
+
+	group, err := a.app.PatchScimGroup(groupID, operations)
+	if err != nil {
+		a.scimErrorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("ScimPatchGroup", mlog.String("teamID", groupID))
+
+	a.scimJSONResponse(w, r, http.StatusOK, group)
+	auditRec.Success()
+}
+
+func (a *API) handleScimDeleteGroup(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /scim/v2/Groups/{groupID} scimDeleteGroup
+	//
+	// Archives a team
+	//
+	// ---
+	// parameters:
+	// - name: groupID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '204':
+	//     description: success
+	//   default:
+	//     description: internal error
+
+	groupID := mux.Vars(r)["groupID"]
+
+	auditRec := a.makeAuditRecord(r, "scimDeleteGroup", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("teamID", groupID)
+
+	if err := a.app.DeleteScimGroup(groupID); err != nil {
+		a.scimErrorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("ScimDeleteGroup", mlog.String("teamID", groupID))
+
+	w.WriteHeader(http.StatusNoContent)
+	auditRec.Success()
+}
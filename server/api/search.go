@@ -12,10 +12,10 @@ import (
 )
 
 func (a *API) registerSearchRoutes(r *mux.Router) {
-	r.HandleFunc("/teams/{teamID}/channels", a.sessionRequired(a.handleSearchMyChannels)).Methods("GET")
-	r.HandleFunc("/teams/{teamID}/boards/search", a.sessionRequired(a.handleSearchBoards)).Methods("GET")
-	r.HandleFunc("/teams/{teamID}/boards/search/linkable", a.sessionRequired(a.handleSearchLinkableBoards)).Methods("GET")
-	r.HandleFunc("/boards/search", a.sessionRequired(a.handleSearchAllBoards)).Methods("GET")
+	r.HandleFunc("/teams/{teamID}/channels", a.sessionRequired(model.AccessTokenScopeRead, a.handleSearchMyChannels)).Methods("GET")
+	r.HandleFunc("/teams/{teamID}/boards/search", a.sessionRequired(model.AccessTokenScopeRead, a.handleSearchBoards)).Methods("GET")
+	r.HandleFunc("/teams/{teamID}/boards/search/linkable", a.sessionRequired(model.AccessTokenScopeRead, a.handleSearchLinkableBoards)).Methods("GET")
+	r.HandleFunc("/boards/search", a.sessionRequired(model.AccessTokenScopeRead, a.handleSearchAllBoards)).Methods("GET")
 }
 
 func (a *API) handleSearchMyChannels(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,190 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (a *API) registerSessionsRoutes(r *mux.Router) {
+	// Session management APIs. These are not needed in plugin mode.
+	r.HandleFunc("/users/me/sessions", a.sessionRequired(model.AccessTokenScopeRead, a.handleGetSessions)).Methods("GET")
+	r.HandleFunc("/users/me/sessions", a.sessionRequired(model.AccessTokenScopeAdmin, a.handleRevokeOtherSessions)).Methods("DELETE")
+	r.HandleFunc("/users/me/sessions/{sessionID}", a.sessionRequired(model.AccessTokenScopeAdmin, a.handleRevokeSession)).Methods("DELETE")
+}
+
+// newSessionProps returns the props that record the client of a login
+// request in its session.
+func (a *API) newSessionProps(r *http.Request) map[string]interface{} {
+	return map[string]interface{}{
+		model.SessionPropUserAgent: r.UserAgent(),
+		model.SessionPropIPAddress: a.clientIPAddress(r),
+	}
+}
+
+// checkSessionsAllowed makes sure that the sessions are managed by the
+// server, and returns the current session.
+func (a *API) checkSessionsAllowed(w http.ResponseWriter, r *http.Request) (*model.Session, bool) {
+	if a.MattermostAuth {
+		a.errorResponse(w, r, model.NewErrNotImplemented("not permitted in plugin mode"))
+		return nil, false
+	}
+
+	session := r.Context().Value(sessionContextKey).(*model.Session)
+	if session.UserID == model.SingleUser {
+		a.errorResponse(w, r, model.NewErrNotImplemented("not permitted in single-user mode"))
+		return nil, false
+	}
+	return session, true
+}
+
+func (a *API) handleGetSessions(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /users/me/sessions getSessions
+	//
+	// Returns the active sessions of the current user.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/SessionInfo"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	session, ok := a.checkSessionsAllowed(w, r)
+	if !ok {
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getSessions", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+
+	sessions, err := a.app.GetSessionsForUser(session.UserID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	infos := make([]*model.SessionInfo, 0, len(sessions))
+	for _, s := range sessions {
+		infos = append(infos, model.NewSessionInfo(s, session.ID))
+	}
+
+	data, err := json.Marshal(infos)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleRevokeSession(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /users/me/sessions/{sessionID} revokeSession
+	//
+	// Revokes a session of the current user, and closes its websocket
+	// connections.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: sessionID
+	//   in: path
+	//   description: Session ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	session, ok := a.checkSessionsAllowed(w, r)
+	if !ok {
+		return
+	}
+	sessionID := mux.Vars(r)["sessionID"]
+
+	auditRec := a.makeAuditRecord(r, "revokeSession", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
+	auditRec.AddMeta("sessionID", sessionID)
+
+	if err := a.app.RevokeSession(session.UserID, sessionID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("RevokeSession",
+		mlog.String("userID", session.UserID),
+		mlog.String("sessionID", sessionID),
+	)
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) handleRevokeOtherSessions(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /users/me/sessions revokeOtherSessions
+	//
+	// Revokes every session of the current user but the session of the
+	// request, and closes their websocket connections.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	session, ok := a.checkSessionsAllowed(w, r)
+	if !ok {
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "revokeOtherSessions", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
+
+	count, err := a.app.RevokeOtherSessions(session.UserID, session.ID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("RevokeOtherSessions",
+		mlog.String("userID", session.UserID),
+		mlog.Int("revoked", count),
+	)
+
+	auditRec.AddMeta("revoked", count)
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
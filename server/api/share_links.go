@@ -0,0 +1,223 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (a *API) registerShareLinksRoutes(r *mux.Router) {
+	// Share link APIs
+	r.HandleFunc("/boards/{boardID}/sharelinks", a.sessionRequired(model.AccessTokenScopeRead, a.handleGetShareLinks)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/sharelinks", a.sessionRequired(model.AccessTokenScopeAdmin, a.handleCreateShareLink)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/sharelinks/{linkID}", a.sessionRequired(model.AccessTokenScopeAdmin, a.handleDeleteShareLink)).Methods("DELETE")
+}
+
+func (a *API) handleGetShareLinks(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/sharelinks getShareLinks
+	//
+	// Returns the share links of a board, with their usage information.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/ShareLink"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionShareBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to sharing the board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getShareLinks", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	links, err := a.app.GetShareLinksForBoard(boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	for _, link := range links {
+		link.Sanitize()
+	}
+
+	data, err := json.Marshal(links)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleCreateShareLink(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/sharelinks createShareLink
+	//
+	// Creates a share link for a board. The link can have an expiry time, a
+	// password, that must be sent along with the read token in the
+	// X-Read-Token-Password header, and can be restricted to a single view,
+	// identified by the X-Read-Token-View-Id header sent with every request
+	// of the view.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the share link to create
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/ShareLink"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/ShareLink"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	link, err := model.ShareLinkFromJSON(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+	link.BoardID = boardID
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionShareBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to sharing the board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "createShareLink", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("expireAt", link.ExpireAt)
+	auditRec.AddMeta("singleView", link.SingleView)
+
+	link, err = a.app.CreateShareLink(link, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	link.Sanitize()
+
+	a.logger.Debug("CreateShareLink",
+		mlog.String("boardID", boardID),
+		mlog.String("linkID", link.ID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(link)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec.AddMeta("linkID", link.ID)
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteShareLink(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/sharelinks/{linkID} deleteShareLink
+	//
+	// Revokes a share link. The rest of the share links of the board keep
+	// working.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: linkID
+	//   in: path
+	//   description: Share link ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	linkID := vars["linkID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionShareBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to sharing the board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteShareLink", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("linkID", linkID)
+
+	if err := a.app.DeleteShareLink(boardID, linkID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DeleteShareLink",
+		mlog.String("boardID", boardID),
+		mlog.String("linkID", linkID),
+		mlog.String("userID", userID),
+	)
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
@@ -17,8 +17,8 @@ var ErrTurningOnSharing = errors.New("turning on sharing for board failed, see l
 
 func (a *API) registerSharingRoutes(r *mux.Router) {
 	// Sharing APIs
-	r.HandleFunc("/boards/{boardID}/sharing", a.sessionRequired(a.handlePostSharing)).Methods("POST")
-	r.HandleFunc("/boards/{boardID}/sharing", a.sessionRequired(a.handleGetSharing)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/sharing", a.sessionRequired(model.AccessTokenScopeAdmin, a.handlePostSharing)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/sharing", a.sessionRequired(model.AccessTokenScopeRead, a.handleGetSharing)).Methods("GET")
 }
 
 func (a *API) handleGetSharing(w http.ResponseWriter, r *http.Request) {
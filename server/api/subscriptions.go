@@ -15,9 +15,9 @@ import (
 
 func (a *API) registerSubscriptionsRoutes(r *mux.Router) {
 	// Subscription APIs
-	r.HandleFunc("/subscriptions", a.sessionRequired(a.handleCreateSubscription)).Methods("POST")
-	r.HandleFunc("/subscriptions/{blockID}/{subscriberID}", a.sessionRequired(a.handleDeleteSubscription)).Methods("DELETE")
-	r.HandleFunc("/subscriptions/{subscriberID}", a.sessionRequired(a.handleGetSubscriptions)).Methods("GET")
+	r.HandleFunc("/subscriptions", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleCreateSubscription)).Methods("POST")
+	r.HandleFunc("/subscriptions/{blockID}/{subscriberID}", a.sessionRequired(model.AccessTokenScopeBoardsWrite, a.handleDeleteSubscription)).Methods("DELETE")
+	r.HandleFunc("/subscriptions/{subscriberID}", a.sessionRequired(model.AccessTokenScopeRead, a.handleGetSubscriptions)).Methods("GET")
 }
 
 // subscriptions
@@ -82,12 +82,16 @@ func (a *API) handleCreateSubscription(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// check for valid block
-	_, bErr := a.app.GetBlockByID(sub.BlockID)
+	block, bErr := a.app.GetBlockByID(sub.BlockID)
 	if bErr != nil {
 		message := fmt.Sprintf("invalid blockID: %s", bErr)
 		a.errorResponse(w, r, model.NewErrBadRequest(message))
 		return
 	}
+	if bErr = a.app.CheckCanSeeBlocks(session.UserID, block); bErr != nil {
+		a.errorResponse(w, r, bErr)
+		return
+	}
 
 	subNew, err := a.app.CreateSubscription(&sub)
 	if err != nil {
@@ -0,0 +1,820 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (a *API) registerTeamManagementRoutes(r *mux.Router) {
+	// Team management APIs, only available in standalone mode
+	r.HandleFunc("/teams", a.sessionRequired(model.AccessTokenScopeAdmin, a.handleCreateTeam)).Methods("POST")
+	r.HandleFunc("/teams/{teamID}", a.sessionRequired(model.AccessTokenScopeAdmin, a.handlePatchTeam)).Methods("PATCH")
+	r.HandleFunc("/teams/{teamID}/archive", a.sessionRequired(model.AccessTokenScopeAdmin, a.handleArchiveTeam)).Methods("POST")
+	r.HandleFunc("/teams/{teamID}/members", a.sessionRequired(model.AccessTokenScopeRead, a.handleGetTeamMembers)).Methods("GET")
+	r.HandleFunc("/teams/{teamID}/members", a.sessionRequired(model.AccessTokenScopeAdmin, a.handleAddTeamMember)).Methods("POST")
+	r.HandleFunc("/teams/{teamID}/members/{userID}", a.sessionRequired(model.AccessTokenScopeAdmin, a.handleUpdateTeamMember)).Methods("PUT")
+	r.HandleFunc("/teams/{teamID}/members/{userID}", a.sessionRequired(model.AccessTokenScopeAdmin, a.handleDeleteTeamMember)).Methods("DELETE")
+	r.HandleFunc("/teams/{teamID}/invites", a.sessionRequired(model.AccessTokenScopeRead, a.handleGetTeamInvites)).Methods("GET")
+	r.HandleFunc("/teams/{teamID}/invites", a.sessionRequired(model.AccessTokenScopeAdmin, a.handleCreateTeamInvite)).Methods("POST")
+	r.HandleFunc("/teams/{teamID}/invites/{inviteID}", a.sessionRequired(model.AccessTokenScopeAdmin, a.handleDeleteTeamInvite)).Methods("DELETE")
+	r.HandleFunc("/invites/{inviteID}/accept", a.sessionRequired(model.AccessTokenScopeAdmin, a.handleAcceptTeamInvite)).Methods("POST")
+}
+
+func (a *API) handleCreateTeam(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /teams createTeam
+	//
+	// Creates a team, the current user becomes its admin
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: Body
+	//   in: body
+	//   description: the team to create, only the title is used
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/Team"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Team"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	if a.MattermostAuth {
+		a.errorResponse(w, r, model.NewErrNotImplemented("not permitted in plugin mode"))
+		return
+	}
+
+	userID := getUserID(r)
+
+	var team model.Team
+	if err := json.NewDecoder(r.Body).Decode(&team); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	isGuest, err := a.userIsGuest(userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	if isGuest {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to create team"))
+		return
+	}
+
+	if err = team.IsValid(); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "createTeam", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("title", team.Title)
+
+	newTeam, err := a.app.CreateTeam(&team, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("CreateTeam",
+		mlog.String("teamID", newTeam.ID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(newTeam)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec.AddMeta("teamID", newTeam.ID)
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handlePatchTeam(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PATCH /teams/{teamID} patchTeam
+	//
+	// Renames a team
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the team patch
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/TeamPatch"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Team"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	if a.MattermostAuth {
+		a.errorResponse(w, r, model.NewErrNotImplemented("not permitted in plugin mode"))
+		return
+	}
+
+	userID := getUserID(r)
+	teamID := mux.Vars(r)["teamID"]
+
+	patch, err := model.TeamPatchFromJSON(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionManageTeam) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to modify team"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "patchTeam", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+
+	team, err := a.app.PatchTeam(teamID, patch, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("PatchTeam",
+		mlog.String("teamID", teamID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(team)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleArchiveTeam(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /teams/{teamID}/archive archiveTeam
+	//
+	// Archives a team. Nobody can access the team and its boards afterwards
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	if a.MattermostAuth {
+		a.errorResponse(w, r, model.NewErrNotImplemented("not permitted in plugin mode"))
+		return
+	}
+
+	userID := getUserID(r)
+	teamID := mux.Vars(r)["teamID"]
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionManageTeam) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to archive team"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "archiveTeam", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+
+	if err := a.app.ArchiveTeam(teamID, userID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("ArchiveTeam",
+		mlog.String("teamID", teamID),
+		mlog.String("userID", userID),
+	)
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) handleGetTeamMembers(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /teams/{teamID}/members getTeamMembers
+	//
+	// Returns the members of a team
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/TeamMember"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	if a.MattermostAuth {
+		a.errorResponse(w, r, model.NewErrNotImplemented("not permitted in plugin mode"))
+		return
+	}
+
+	userID := getUserID(r)
+	teamID := mux.Vars(r)["teamID"]
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to team members"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getTeamMembers", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+
+	members, err := a.app.GetTeamMembers(teamID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(members)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec.AddMeta("memberCount", len(members))
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleAddTeamMember(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /teams/{teamID}/members addTeamMember
+	//
+	// Adds a user to a team
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the team member to add
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/TeamMember"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/TeamMember"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	if a.MattermostAuth {
+		a.errorResponse(w, r, model.NewErrNotImplemented("not permitted in plugin mode"))
+		return
+	}
+
+	userID := getUserID(r)
+	teamID := mux.Vars(r)["teamID"]
+
+	reqMember, err := model.TeamMemberFromJSON(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	if reqMember.UserID == "" {
+		a.errorResponse(w, r, model.NewErrBadRequest("missing user ID"))
+		return
+	}
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionManageTeam) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to modify team members"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "addTeamMember", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+	auditRec.AddMeta("addedUserID", reqMember.UserID)
+
+	member, err := a.app.SaveTeamMember(&model.TeamMember{
+		TeamID:      teamID,
+		UserID:      reqMember.UserID,
+		SchemeAdmin: reqMember.SchemeAdmin,
+	})
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("AddTeamMember",
+		mlog.String("teamID", teamID),
+		mlog.String("addedUserID", reqMember.UserID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(member)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleUpdateTeamMember(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /teams/{teamID}/members/{userID} updateTeamMember
+	//
+	// Changes the role of a team member
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: userID
+	//   in: path
+	//   description: User ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the team member with its new role
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/TeamMember"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/TeamMember"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	if a.MattermostAuth {
+		a.errorResponse(w, r, model.NewErrNotImplemented("not permitted in plugin mode"))
+		return
+	}
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	teamID := vars["teamID"]
+	paramsUserID := vars["userID"]
+
+	reqMember, err := model.TeamMemberFromJSON(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionManageTeam) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to modify team members"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "updateTeamMember", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+	auditRec.AddMeta("updatedUserID", paramsUserID)
+
+	if _, err = a.app.GetTeamMember(teamID, paramsUserID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	member, err := a.app.SaveTeamMember(&model.TeamMember{
+		TeamID:      teamID,
+		UserID:      paramsUserID,
+		SchemeAdmin: reqMember.SchemeAdmin,
+	})
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("UpdateTeamMember",
+		mlog.String("teamID", teamID),
+		mlog.String("updatedUserID", paramsUserID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(member)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteTeamMember(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /teams/{teamID}/members/{userID} deleteTeamMember
+	//
+	// Removes a user from a team. Users can leave teams unless they are the
+	// last admin of the team
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: userID
+	//   in: path
+	//   description: User ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	if a.MattermostAuth {
+		a.errorResponse(w, r, model.NewErrNotImplemented("not permitted in plugin mode"))
+		return
+	}
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	teamID := vars["teamID"]
+	paramsUserID := vars["userID"]
+
+	if userID != paramsUserID && !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionManageTeam) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to modify team members"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteTeamMember", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+	auditRec.AddMeta("deletedUserID", paramsUserID)
+
+	if err := a.app.DeleteTeamMember(teamID, paramsUserID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DeleteTeamMember",
+		mlog.String("teamID", teamID),
+		mlog.String("deletedUserID", paramsUserID),
+		mlog.String("userID", userID),
+	)
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) handleGetTeamInvites(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /teams/{teamID}/invites getTeamInvites
+	//
+	// Returns the pending invitations of a team
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/TeamInvite"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	if a.MattermostAuth {
+		a.errorResponse(w, r, model.NewErrNotImplemented("not permitted in plugin mode"))
+		return
+	}
+
+	userID := getUserID(r)
+	teamID := mux.Vars(r)["teamID"]
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionManageTeam) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to team invitations"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getTeamInvites", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+
+	invites, err := a.app.GetTeamInvites(teamID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(invites)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleCreateTeamInvite(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /teams/{teamID}/invites createTeamInvite
+	//
+	// Invites a user to a team. The id of the invitation can be used as the
+	// signup token or accepted by existing users. The invited user is added
+	// to the boards of the invitation
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the invitation to create, only the email, the roles, the expiry and the boards are used
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/TeamInvite"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/TeamInvite"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	if a.MattermostAuth {
+		a.errorResponse(w, r, model.NewErrNotImplemented("not permitted in plugin mode"))
+		return
+	}
+
+	userID := getUserID(r)
+	teamID := mux.Vars(r)["teamID"]
+
+	invite, err := model.TeamInviteFromJSON(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+	invite.TeamID = teamID
+
+	if err = invite.IsValid(); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionManageTeam) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to invite to team"))
+		return
+	}
+	for _, boardID := range invite.BoardIDs {
+		if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+			a.errorResponse(w, r, model.NewErrPermission("access denied to invite to board"))
+			return
+		}
+	}
+
+	auditRec := a.makeAuditRecord(r, "createTeamInvite", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+	auditRec.AddMeta("email", invite.Email)
+
+	invite, err = a.app.CreateTeamInvite(invite, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("CreateTeamInvite",
+		mlog.String("teamID", teamID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(invite)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteTeamInvite(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /teams/{teamID}/invites/{inviteID} deleteTeamInvite
+	//
+	// Revokes a team invitation
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: inviteID
+	//   in: path
+	//   description: Invitation ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	if a.MattermostAuth {
+		a.errorResponse(w, r, model.NewErrNotImplemented("not permitted in plugin mode"))
+		return
+	}
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	teamID := vars["teamID"]
+	inviteID := vars["inviteID"]
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionManageTeam) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to revoke team invitation"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteTeamInvite", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+
+	if err := a.app.DeleteTeamInvite(teamID, inviteID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DeleteTeamInvite",
+		mlog.String("teamID", teamID),
+		mlog.String("userID", userID),
+	)
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) handleAcceptTeamInvite(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /invites/{inviteID}/accept acceptTeamInvite
+	//
+	// Accepts a team invitation, adding the current user to the team
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: inviteID
+	//   in: path
+	//   description: Invitation ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/TeamMember"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	if a.MattermostAuth {
+		a.errorResponse(w, r, model.NewErrNotImplemented("not permitted in plugin mode"))
+		return
+	}
+
+	userID := getUserID(r)
+	inviteID := mux.Vars(r)["inviteID"]
+
+	auditRec := a.makeAuditRecord(r, "acceptTeamInvite", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+
+	member, err := a.app.AcceptTeamInvite(inviteID, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("AcceptTeamInvite",
+		mlog.String("teamID", member.TeamID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(member)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec.AddMeta("teamID", member.TeamID)
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
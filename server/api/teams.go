@@ -13,11 +13,11 @@ import (
 
 func (a *API) registerTeamsRoutes(r *mux.Router) {
 	// Team APIs
-	r.HandleFunc("/teams", a.sessionRequired(a.handleGetTeams)).Methods("GET")
-	r.HandleFunc("/teams/{teamID}", a.sessionRequired(a.handleGetTeam)).Methods("GET")
-	r.HandleFunc("/teams/{teamID}/users", a.sessionRequired(a.handleGetTeamUsers)).Methods("GET")
-	r.HandleFunc("/teams/{teamID}/users", a.sessionRequired(a.handleGetTeamUsersByID)).Methods("POST")
-	r.HandleFunc("/teams/{teamID}/archive/export", a.sessionRequired(a.handleArchiveExportTeam)).Methods("GET")
+	r.HandleFunc("/teams", a.sessionRequired(model.AccessTokenScopeRead, a.handleGetTeams)).Methods("GET")
+	r.HandleFunc("/teams/{teamID}", a.sessionRequired(model.AccessTokenScopeRead, a.handleGetTeam)).Methods("GET")
+	r.HandleFunc("/teams/{teamID}/users", a.sessionRequired(model.AccessTokenScopeRead, a.handleGetTeamUsers)).Methods("GET")
+	r.HandleFunc("/teams/{teamID}/users", a.sessionRequired(model.AccessTokenScopeRead, a.handleGetTeamUsersByID)).Methods("POST")
+	r.HandleFunc("/teams/{teamID}/archive/export", a.sessionRequired(model.AccessTokenScopeRead, a.handleArchiveExportTeam)).Methods("GET")
 }
 
 func (a *API) handleGetTeams(w http.ResponseWriter, r *http.Request) {
@@ -53,6 +53,15 @@ func (a *API) handleGetTeams(w http.ResponseWriter, r *http.Request) {
 	defer a.audit.LogRecord(audit.LevelRead, auditRec)
 	auditRec.AddMeta("teamCount", len(teams))
 
+	// only team admins can see the signup tokens of managed teams
+	if !a.MattermostAuth {
+		for _, team := range teams {
+			if team.IsManaged() && !a.permissions.HasPermissionToTeam(userID, team.ID, model.PermissionManageTeam) {
+				team.Sanitize()
+			}
+		}
+	}
+
 	data, err := json.Marshal(teams)
 	if err != nil {
 		a.errorResponse(w, r, err)
@@ -110,11 +119,20 @@ func (a *API) handleGetTeam(w http.ResponseWriter, r *http.Request) {
 			a.errorResponse(w, r, err)
 		}
 	} else {
-		team, err = a.app.GetRootTeam()
+		team, err = a.app.GetTeam(teamID)
 		if err != nil {
 			a.errorResponse(w, r, err)
 			return
 		}
+		if team == nil || !team.IsManaged() {
+			team, err = a.app.GetRootTeam()
+			if err != nil {
+				a.errorResponse(w, r, err)
+				return
+			}
+		} else if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionManageTeam) {
+			team.Sanitize()
+		}
 	}
 
 	auditRec := a.makeAuditRecord(r, "getTeam", audit.Fail)
@@ -134,7 +152,8 @@ func (a *API) handleGetTeam(w http.ResponseWriter, r *http.Request) {
 func (a *API) handlePostTeamRegenerateSignupToken(w http.ResponseWriter, r *http.Request) {
 	// swagger:operation POST /teams/{teamID}/regenerate_signup_token regenerateSignupToken
 	//
-	// Regenerates the signup token for the root team
+	// Regenerates the signup token for the team. Only team admins can
+	// regenerate the signup token of managed teams
 	//
 	// ---
 	// produces:
@@ -159,7 +178,36 @@ func (a *API) handlePostTeamRegenerateSignupToken(w http.ResponseWriter, r *http
 		return
 	}
 
-	team, err := a.app.GetRootTeam()
+	teamID := mux.Vars(r)["teamID"]
+	userID := getUserID(r)
+
+	team, err := a.app.GetTeam(teamID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if team != nil && team.IsManaged() {
+		if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionManageTeam) {
+			a.errorResponse(w, r, model.NewErrPermission("access denied to regenerate signup token"))
+			return
+		}
+
+		auditRec := a.makeAuditRecord(r, "regenerateSignupToken", audit.Fail)
+		defer a.audit.LogRecord(audit.LevelModify, auditRec)
+		auditRec.AddMeta("teamID", teamID)
+
+		if err = a.app.RegenerateTeamSignupToken(teamID, userID); err != nil {
+			a.errorResponse(w, r, err)
+			return
+		}
+
+		jsonStringResponse(w, http.StatusOK, "{}")
+		auditRec.Success()
+		return
+	}
+
+	team, err = a.app.GetRootTeam()
 	if err != nil {
 		a.errorResponse(w, r, err)
 		return
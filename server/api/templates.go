@@ -12,7 +12,7 @@ import (
 )
 
 func (a *API) registerTemplatesRoutes(r *mux.Router) {
-	r.HandleFunc("/teams/{teamID}/templates", a.sessionRequired(a.handleGetTemplates)).Methods("GET")
+	r.HandleFunc("/teams/{teamID}/templates", a.sessionRequired(model.AccessTokenScopeRead, a.handleGetTemplates)).Methods("GET")
 }
 
 func (a *API) handleGetTemplates(w http.ResponseWriter, r *http.Request) {
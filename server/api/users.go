@@ -13,12 +13,12 @@ import (
 
 func (a *API) registerUsersRoutes(r *mux.Router) {
 	// Users APIs
-	r.HandleFunc("/users", a.sessionRequired(a.handleGetUsersList)).Methods("POST")
-	r.HandleFunc("/users/me", a.sessionRequired(a.handleGetMe)).Methods("GET")
-	r.HandleFunc("/users/me/memberships", a.sessionRequired(a.handleGetMyMemberships)).Methods("GET")
-	r.HandleFunc("/users/{userID}", a.sessionRequired(a.handleGetUser)).Methods("GET")
-	r.HandleFunc("/users/{userID}/config", a.sessionRequired(a.handleUpdateUserConfig)).Methods(http.MethodPut)
-	r.HandleFunc("/users/me/config", a.sessionRequired(a.handleGetUserPreferences)).Methods(http.MethodGet)
+	r.HandleFunc("/users", a.sessionRequired(model.AccessTokenScopeRead, a.handleGetUsersList)).Methods("POST")
+	r.HandleFunc("/users/me", a.mfaSetupSessionRequired(model.AccessTokenScopeRead, a.handleGetMe)).Methods("GET")
+	r.HandleFunc("/users/me/memberships", a.sessionRequired(model.AccessTokenScopeRead, a.handleGetMyMemberships)).Methods("GET")
+	r.HandleFunc("/users/{userID}", a.sessionRequired(model.AccessTokenScopeRead, a.handleGetUser)).Methods("GET")
+	r.HandleFunc("/users/{userID}/config", a.sessionRequired(model.AccessTokenScopeAdmin, a.handleUpdateUserConfig)).Methods(http.MethodPut)
+	r.HandleFunc("/users/me/config", a.sessionRequired(model.AccessTokenScopeRead, a.handleGetUserPreferences)).Methods(http.MethodGet)
 }
 
 func (a *API) handleGetUsersList(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,51 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func (a *App) GetAccessTokensForUser(userID string) ([]*model.AccessToken, error) {
+	return a.store.GetAccessTokensForUser(userID)
+}
+
+// CreateAccessToken creates a new personal access token for the user. The
+// returned token is the only copy of it, only its hash is stored.
+func (a *App) CreateAccessToken(token *model.AccessToken, userID string) (*model.AccessToken, error) {
+	if token.ExpireAt != 0 && token.ExpireAt <= utils.GetMillis() {
+		return nil, model.NewErrBadRequest("the access token expiry time is in the past")
+	}
+
+	rawToken := model.AccessTokenPrefix + utils.NewID(utils.IDTypeToken)
+
+	token.ID = ""
+	token.UserID = userID
+	token.Token = ""
+	token.TokenHash = model.HashToken(rawToken)
+
+	created, err := a.store.CreateAccessToken(token)
+	if err != nil {
+		return nil, err
+	}
+	created.Token = rawToken
+	return created, nil
+}
+
+// DeleteAccessToken revokes one of the access tokens of the user.
+func (a *App) DeleteAccessToken(userID, tokenID string) error {
+	token, err := a.store.GetAccessToken(tokenID)
+	if err != nil {
+		return err
+	}
+	if token.UserID != userID {
+		return model.NewErrNotFound("access token ID=" + tokenID)
+	}
+	if err := a.store.DeleteAccessToken(tokenID); err != nil {
+		return err
+	}
+	a.wsAdapter.CloseSessionConnections([]string{tokenID})
+	return nil
+}
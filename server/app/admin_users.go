@@ -0,0 +1,142 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"strings"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// getUserIncludingDeleted returns a user, deactivated or not.
+func (a *App) getUserIncludingDeleted(userID string) (*model.User, error) {
+	users, err := a.store.GetUsers(model.QueryUsersOptions{UserID: userID, IncludeDeleted: true})
+	if err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, model.NewErrNotFound("user ID=" + userID)
+	}
+	return users[0], nil
+}
+
+// GetUsersForAdmin lists and searches the users for the system admins.
+func (a *App) GetUsersForAdmin(opts model.QueryUsersOptions) ([]*model.AdminUser, error) {
+	users, err := a.store.GetUsers(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	adminUsers := make([]*model.AdminUser, 0, len(users))
+	for _, user := range users {
+		adminUsers = append(adminUsers, model.NewAdminUser(user))
+	}
+	return adminUsers, nil
+}
+
+// GetUserByUsernameForAdmin returns a user by its username, deactivated or
+// not.
+func (a *App) GetUserByUsernameForAdmin(username string) (*model.User, error) {
+	users, err := a.store.GetUsers(model.QueryUsersOptions{Username: username, IncludeDeleted: true})
+	if err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, model.NewErrNotFound("username=" + username)
+	}
+	return users[0], nil
+}
+
+// DeactivateUser deactivates a user, revoking its sessions. The user can be
+// reactivated with ReactivateUser.
+func (a *App) DeactivateUser(userID string) error {
+	user, err := a.getUserIncludingDeleted(userID)
+	if err != nil {
+		return err
+	}
+	if user.DeleteAt != 0 {
+		return model.NewErrBadRequest("user is already deactivated")
+	}
+
+	if err := a.deactivateUser(userID); err != nil {
+		return err
+	}
+
+	a.logger.Info("Deactivated user", mlog.String("userID", userID))
+	return nil
+}
+
+// ReactivateUser reactivates a deactivated user.
+func (a *App) ReactivateUser(userID string) error {
+	user, err := a.getUserIncludingDeleted(userID)
+	if err != nil {
+		return err
+	}
+	if user.DeleteAt == 0 {
+		return model.NewErrBadRequest("user is not deactivated")
+	}
+
+	if err := a.store.UpdateUserDeleteAt(userID, 0); err != nil {
+		return err
+	}
+
+	a.logger.Info("Reactivated user", mlog.String("userID", userID))
+	return nil
+}
+
+// UpdateUserSystemAdmin grants or removes the system admin role of a user,
+// keeping its other roles.
+func (a *App) UpdateUserSystemAdmin(userID string, isSystemAdmin bool) error {
+	user, err := a.getUserIncludingDeleted(userID)
+	if err != nil {
+		return err
+	}
+	if user.IsSystemAdmin() == isSystemAdmin {
+		return nil
+	}
+
+	roles := []string{}
+	for _, role := range strings.Fields(user.Roles) {
+		if role != model.SystemAdminRole {
+			roles = append(roles, role)
+		}
+	}
+	if isSystemAdmin {
+		roles = append(roles, model.SystemAdminRole)
+	}
+
+	if err := a.store.UpdateUserRoles(userID, strings.Join(roles, " ")); err != nil {
+		return err
+	}
+
+	a.logger.Info("Updated the system admin role of the user",
+		mlog.String("userID", userID),
+		mlog.Bool("isSystemAdmin", isSystemAdmin),
+	)
+	return nil
+}
+
+// GetBoardMembershipsForUser returns the board memberships of a user with
+// their boards.
+func (a *App) GetBoardMembershipsForUser(userID string) ([]*model.UserBoardMembership, error) {
+	members, err := a.store.GetMembersForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	memberships := make([]*model.UserBoardMembership, 0, len(members))
+	for _, member := range members {
+		board, err := a.store.GetBoard(member.BoardID)
+		if model.IsErrNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		memberships = append(memberships, &model.UserBoardMembership{Board: board, Member: member})
+	}
+	return memberships, nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/mattermost/focalboard/server/services/config"
 	"github.com/mattermost/focalboard/server/services/metrics"
 	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/services/oidc"
 	"github.com/mattermost/focalboard/server/services/permissions"
 	"github.com/mattermost/focalboard/server/services/store"
 	"github.com/mattermost/focalboard/server/services/webhook"
@@ -70,6 +71,10 @@ type App struct {
 
 	cardLimitMux sync.RWMutex
 	cardLimit    int
+
+	oidcMux      sync.Mutex
+	oidcProvider *oidc.Provider
+	oidcIssuer   string
 }
 
 func (a *App) SetConfig(config *config.Configuration) {
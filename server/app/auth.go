@@ -3,6 +3,7 @@ package app
 import (
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/auth"
+	"github.com/mattermost/focalboard/server/services/ldap"
 	"github.com/mattermost/focalboard/server/utils"
 
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
@@ -24,8 +25,8 @@ func (a *App) GetSession(token string) (*model.Session, error) {
 }
 
 // IsValidReadToken validates the read token for a block.
-func (a *App) IsValidReadToken(boardID string, readToken string) (bool, error) {
-	return a.auth.IsValidReadToken(boardID, readToken)
+func (a *App) IsValidReadToken(boardID string, readToken string, credentials model.ShareLinkCredentials) (bool, error) {
+	return a.auth.IsValidReadToken(boardID, readToken, credentials)
 }
 
 // GetRegisteredUserCount returns the number of registered users.
@@ -77,9 +78,27 @@ func (a *App) GetUsersList(userIDs []string) ([]*model.User, error) {
 }
 
 // Login create a new user session if the authentication data is valid.
-func (a *App) Login(username, email, password, mfaToken string) (string, error) {
+// The props record the client that logged in.
+func (a *App) Login(username, email, password, mfaToken string, props map[string]interface{}) (string, error) {
 	var user *model.User
-	if username != "" {
+	if username != "" && a.config.Ldap.Enable {
+		// the directory users are tried first, the local accounts remain
+		// available when the login isn't a directory user
+		ldapUser, err := a.loginWithLdap(username, password)
+		switch {
+		case err == nil:
+			user = ldapUser
+		case errors.Is(err, ldap.ErrUserNotFound), errors.Is(err, ldap.ErrInvalidCredentials):
+		case model.IsErrUnauthorized(err):
+			a.metrics.IncrementLoginFailCount(1)
+			return "", err
+		default:
+			a.logger.Warn("Unable to authenticate with LDAP", mlog.Err(err))
+		}
+	}
+
+	authenticated := user != nil
+	if user == nil && username != "" {
 		var err error
 		user, err = a.store.GetUserByUsername(username)
 		if err != nil && !model.IsErrNotFound(err) {
@@ -102,38 +121,63 @@ func (a *App) Login(username, email, password, mfaToken string) (string, error)
 		return "", errors.New("invalid username or password")
 	}
 
-	if !auth.ComparePassword(user.Password, password) {
+	if !authenticated && !auth.ComparePassword(user.Password, password) {
 		a.metrics.IncrementLoginFailCount(1)
 		a.logger.Debug("Invalid password for user", mlog.String("userID", user.ID))
 		return "", errors.New("invalid username or password")
 	}
 
+	if user.IsExpiredGuest(utils.GetMillis()) {
+		a.metrics.IncrementLoginFailCount(1)
+		a.logger.Debug("Expired guest account", mlog.String("userID", user.ID))
+		return "", errors.New("the guest account has expired")
+	}
+
+	if a.config.RequireEmailVerification && !user.EmailVerified {
+		a.logger.Debug("Unverified email address for user", mlog.String("userID", user.ID))
+		return "", ErrEmailNotVerified
+	}
+
+	if user.MfaActive {
+		if mfaToken == "" {
+			return "", ErrMfaTokenRequired
+		}
+
+		valid, err := a.checkMfaToken(user, mfaToken)
+		if err != nil {
+			a.metrics.IncrementLoginFailCount(1)
+			return "", errors.Wrap(err, "unable to check the multi-factor authentication token")
+		}
+		if !valid {
+			a.metrics.IncrementLoginFailCount(1)
+			a.logger.Debug("Invalid multi-factor authentication token for user", mlog.String("userID", user.ID))
+			return "", errors.New("invalid multi-factor authentication token")
+		}
+	}
+
 	authService := user.AuthService
-	if authService == "" {
+	switch authService {
+	case "":
 		authService = "native"
+	case model.LdapAuthService:
+		// like the OpenID Connect users, the directory users get the
+		// sessions of the server auth mode
+		authService = a.config.AuthMode
 	}
 
-	session := model.Session{
-		ID:          utils.NewID(utils.IDTypeSession),
-		Token:       utils.NewID(utils.IDTypeToken),
-		UserID:      user.ID,
-		AuthService: authService,
-		Props:       map[string]interface{}{},
-	}
-	err := a.store.CreateSession(&session)
+	session, err := a.createSession(user.ID, authService, props)
 	if err != nil {
 		return "", errors.Wrap(err, "unable to create session")
 	}
 
 	a.metrics.IncrementLoginCount(1)
 
-	// TODO: MFA verification
 	return session.Token, nil
 }
 
 // Logout invalidates the user session.
 func (a *App) Logout(sessionID string) error {
-	err := a.store.DeleteSession(sessionID)
+	err := a.revokeSessions([]string{sessionID})
 	if err != nil {
 		return errors.Wrap(err, "unable to delete the session")
 	}
@@ -145,15 +189,78 @@ func (a *App) Logout(sessionID string) error {
 
 // RegisterUser creates a new user if the provided data is valid.
 func (a *App) RegisterUser(username, email, password string) error {
+	_, err := a.registerUser(username, email, password, nil)
+	return err
+}
+
+// RegisterUserWithToken registers a user with a signup token. The signup
+// token of the root team only registers the user, the signup tokens of
+// managed teams and the team invitations also add the user to the team.
+func (a *App) RegisterUserWithToken(username, email, password, token string) error {
+	rootTeam, err := a.GetRootTeam()
+	if err != nil {
+		return err
+	}
+	if token == rootTeam.SignupToken {
+		_, err = a.registerUser(username, email, password, nil)
+		return err
+	}
+
+	team, err := a.store.GetTeamBySignupToken(token)
+	if err != nil && !model.IsErrNotFound(err) {
+		return err
+	}
+	if team != nil {
+		user, err := a.registerUser(username, email, password, nil)
+		if err != nil {
+			return err
+		}
+		_, err = a.store.SaveTeamMember(&model.TeamMember{TeamID: team.ID, UserID: user.ID})
+		return err
+	}
+
+	invite, err := a.getAcceptableTeamInvite(token, email)
+	if model.IsErrNotFound(err) {
+		return model.NewErrUnauthorized("invalid token")
+	}
+	if err != nil {
+		return model.NewErrUnauthorized(err.Error())
+	}
+
+	user, err := a.registerUser(username, email, password, invite)
+	if err != nil {
+		return err
+	}
+	if _, err = a.store.AcceptTeamInvite(invite.ID, user.ID); err != nil {
+		return err
+	}
+	return a.addTeamInviteBoardMembers(invite, user.ID)
+}
+
+// registerUser creates a local account. The invitations restricted to an
+// email address are trusted with the address: it doesn't need to be
+// verified nor to belong to the allowed signup domains.
+func (a *App) registerUser(username, email, password string, invite *model.TeamInvite) (*model.User, error) {
+	emailVerified := invite != nil && invite.Email != ""
+	if !emailVerified {
+		if err := a.checkSignupEmailDomain(email); err != nil {
+			return nil, err
+		}
+		if a.config.RequireEmailVerification && !a.isEmailConfigured() {
+			return nil, model.NewErrNotImplemented("the email addresses can't be verified without an SMTP server")
+		}
+		emailVerified = !a.config.RequireEmailVerification
+	}
+
 	var user *model.User
 	if username != "" {
 		var err error
 		user, err = a.store.GetUserByUsername(username)
 		if err != nil && !model.IsErrNotFound(err) {
-			return err
+			return nil, err
 		}
 		if user != nil {
-			return errors.New("The username already exists")
+			return nil, errors.New("The username already exists")
 		}
 	}
 
@@ -161,37 +268,39 @@ func (a *App) RegisterUser(username, email, password string) error {
 		var err error
 		user, err = a.store.GetUserByEmail(email)
 		if err != nil && !model.IsErrNotFound(err) {
-			return err
+			return nil, err
 		}
 		if user != nil {
-			return errors.New("The email already exists")
+			return nil, errors.New("The email already exists")
 		}
 	}
 
-	// TODO: Move this into the config
-	passwordSettings := auth.PasswordSettings{
-		MinimumLength: 6,
-	}
-
-	err := auth.IsPasswordValid(password, passwordSettings)
+	err := auth.IsPasswordValid(password, a.passwordSettings())
 	if err != nil {
-		return errors.Wrap(err, "Invalid password")
+		return nil, errors.Wrap(err, "Invalid password")
 	}
 
-	_, err = a.store.CreateUser(&model.User{
-		ID:          utils.NewID(utils.IDTypeUser),
-		Username:    username,
-		Email:       email,
-		Password:    auth.HashPassword(password),
-		MfaSecret:   "",
-		AuthService: a.config.AuthMode,
-		AuthData:    "",
+	user, err = a.store.CreateUser(&model.User{
+		ID:            utils.NewID(utils.IDTypeUser),
+		Username:      username,
+		Email:         email,
+		Password:      auth.HashPassword(password),
+		MfaSecret:     "",
+		AuthService:   a.config.AuthMode,
+		AuthData:      "",
+		EmailVerified: emailVerified,
 	})
 	if err != nil {
-		return errors.Wrap(err, "Unable to create the new user")
+		return nil, errors.Wrap(err, "Unable to create the new user")
 	}
 
-	return nil
+	if !emailVerified {
+		if err = a.sendEmailVerification(user); err != nil {
+			a.logger.Error("Unable to send the email verification", mlog.String("userID", user.ID), mlog.Err(err))
+		}
+	}
+
+	return user, nil
 }
 
 func (a *App) UpdateUserPassword(username, password string) error {
@@ -222,10 +331,30 @@ func (a *App) ChangePassword(userID, oldPassword, newPassword string) error {
 		return errors.New("invalid username or password")
 	}
 
-	err := a.store.UpdateUserPasswordByID(userID, auth.HashPassword(newPassword))
+	err := auth.IsPasswordValid(newPassword, a.passwordSettings())
+	if err != nil {
+		return model.NewErrBadRequest(err.Error())
+	}
+
+	err = a.store.UpdateUserPasswordByID(userID, auth.HashPassword(newPassword))
 	if err != nil {
 		return errors.Wrap(err, "unable to update password")
 	}
 
 	return nil
 }
+
+// passwordSettings returns the password policy of the local accounts.
+func (a *App) passwordSettings() auth.PasswordSettings {
+	settings := auth.PasswordSettings{
+		MinimumLength: a.config.Password.MinimumLength,
+		Lowercase:     a.config.Password.Lowercase,
+		Number:        a.config.Password.Number,
+		Uppercase:     a.config.Password.Uppercase,
+		Symbol:        a.config.Password.Symbol,
+	}
+	if settings.MinimumLength <= 0 {
+		settings.MinimumLength = model.MinimumPasswordLength
+	}
+	return settings
+}
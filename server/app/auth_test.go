@@ -46,7 +46,7 @@ func TestLogin(t *testing.T) {
 
 	for _, test := range testcases {
 		t.Run(test.title, func(t *testing.T) {
-			token, err := th.App.Login(test.userName, test.email, test.password, test.mfa)
+			token, err := th.App.Login(test.userName, test.email, test.password, test.mfa, nil)
 			if test.isError {
 				require.Error(t, err)
 			} else {
@@ -57,6 +57,52 @@ func TestLogin(t *testing.T) {
 	}
 }
 
+func TestLoginGuest(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	guest := &model.User{
+		ID:       utils.NewID(utils.IDTypeUser),
+		Username: "guestUsername",
+		Password: auth.HashPassword("testPassword"),
+		IsGuest:  true,
+	}
+
+	t.Run("success, guest account without expiry", func(t *testing.T) {
+		th.Store.EXPECT().GetUserByUsername("guestUsername").Return(guest, nil)
+		th.Store.EXPECT().CreateSession(gomock.Any()).Return(nil)
+
+		token, err := th.App.Login("guestUsername", "", "testPassword", "", nil)
+		require.NoError(t, err)
+		require.NotEmpty(t, token)
+	})
+
+	t.Run("fail, expired guest account", func(t *testing.T) {
+		expiredGuest := *guest
+		expiredGuest.GuestExpireAt = utils.GetMillis() - 1000
+		th.Store.EXPECT().GetUserByUsername("guestUsername").Return(&expiredGuest, nil)
+
+		token, err := th.App.Login("guestUsername", "", "testPassword", "", nil)
+		require.Error(t, err)
+		require.Empty(t, token)
+	})
+}
+
+func TestUpdateUserGuest(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("success", func(t *testing.T) {
+		th.Store.EXPECT().UpdateUserGuest("user-id", true, int64(1000)).Return(nil)
+		require.NoError(t, th.App.UpdateUserGuest("user-id", true, 1000))
+	})
+
+	t.Run("fail, negative expiry", func(t *testing.T) {
+		err := th.App.UpdateUserGuest("user-id", true, -1)
+		require.True(t, model.IsErrBadRequest(err))
+	})
+}
+
 func TestGetUser(t *testing.T) {
 	th, tearDown := SetupTestHelper(t)
 	defer tearDown()
@@ -186,3 +232,76 @@ func TestChangePassword(t *testing.T) {
 		})
 	}
 }
+
+func TestRegisterUserWithToken(t *testing.T) {
+	rootTeam := &model.Team{ID: model.GlobalTeamID, SignupToken: "root-token"}
+	managedTeam := &model.Team{ID: "managed-team-id", Title: "Managed", SignupToken: "team-token"}
+	newUser := &model.User{ID: "new-user-id", Email: "someone@example.com"}
+
+	expectNewUser := func(th *TestHelper) {
+		th.Store.EXPECT().GetUserByUsername("newUsername").Return(nil, model.NewErrNotFound("user"))
+		th.Store.EXPECT().CreateUser(gomock.Any()).Return(newUser, nil)
+	}
+
+	t.Run("root team token", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		th.Store.EXPECT().GetTeam(model.GlobalTeamID).Return(rootTeam, nil)
+		expectNewUser(th)
+
+		require.NoError(t, th.App.RegisterUserWithToken("newUsername", "", "testPassword", "root-token"))
+	})
+
+	t.Run("team signup token", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		th.Store.EXPECT().GetTeam(model.GlobalTeamID).Return(rootTeam, nil)
+		th.Store.EXPECT().GetTeamBySignupToken("team-token").Return(managedTeam, nil)
+		expectNewUser(th)
+		th.Store.EXPECT().
+			SaveTeamMember(&model.TeamMember{TeamID: managedTeam.ID, UserID: newUser.ID}).
+			Return(&model.TeamMember{TeamID: managedTeam.ID, UserID: newUser.ID}, nil)
+
+		require.NoError(t, th.App.RegisterUserWithToken("newUsername", "", "testPassword", "team-token"))
+	})
+
+	t.Run("team invitation", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		invite := &model.TeamInvite{
+			ID:       "invite-token",
+			TeamID:   managedTeam.ID,
+			Email:    "someone@example.com",
+			ExpireAt: utils.GetMillis() + 1000,
+		}
+		th.Store.EXPECT().GetTeam(model.GlobalTeamID).Return(rootTeam, nil).Times(2)
+		th.Store.EXPECT().GetTeamBySignupToken("invite-token").Return(nil, model.NewErrNotFound("team")).Times(2)
+		th.Store.EXPECT().GetTeamInvite("invite-token").Return(invite, nil).Times(2)
+		th.Store.EXPECT().GetTeam(managedTeam.ID).Return(managedTeam, nil)
+		th.Store.EXPECT().GetUserByEmail("someone@example.com").Return(nil, model.NewErrNotFound("user"))
+		expectNewUser(th)
+		th.Store.EXPECT().
+			AcceptTeamInvite("invite-token", newUser.ID).
+			Return(&model.TeamMember{TeamID: managedTeam.ID, UserID: newUser.ID}, nil)
+
+		err := th.App.RegisterUserWithToken("otherUsername", "other@example.com", "testPassword", "invite-token")
+		require.True(t, model.IsErrUnauthorized(err))
+
+		require.NoError(t, th.App.RegisterUserWithToken("newUsername", "someone@example.com", "testPassword", "invite-token"))
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		th.Store.EXPECT().GetTeam(model.GlobalTeamID).Return(rootTeam, nil)
+		th.Store.EXPECT().GetTeamBySignupToken("bad-token").Return(nil, model.NewErrNotFound("team"))
+		th.Store.EXPECT().GetTeamInvite("bad-token").Return(nil, model.NewErrNotFound("team invite"))
+
+		err := th.App.RegisterUserWithToken("newUsername", "", "testPassword", "bad-token")
+		require.True(t, model.IsErrUnauthorized(err))
+	})
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// automationsDueDateCheckAtKey is the system setting holding the time of the
+// last due date check of the automation rules.
+const automationsDueDateCheckAtKey = "AutomationsDueDateCheckAt"
+
+func (a *App) GetBoardsWithAutomations() ([]*model.Board, error) {
+	return a.store.GetBoardsWithAutomations()
+}
+
+// GetAutomationsDueDateCheckAt returns the time of the last due date check,
+// or zero if the due dates were never checked.
+func (a *App) GetAutomationsDueDateCheckAt() (int64, error) {
+	value, err := a.store.GetSystemSetting(automationsDueDateCheckAtKey)
+	if err != nil {
+		return 0, err
+	}
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
+
+// ClaimAutomationsDueDateCheck moves the time of the last due date check
+// forward. It returns false if another server checked the due dates since
+// prevCheckAt, so that each due date runs its rules only once.
+func (a *App) ClaimAutomationsDueDateCheck(prevCheckAt, checkAt int64) (bool, error) {
+	prevValue := ""
+	if prevCheckAt != 0 {
+		prevValue = strconv.FormatInt(prevCheckAt, 10)
+	}
+	return a.store.ClaimSystemSetting(automationsDueDateCheckAtKey, prevValue, strconv.FormatInt(checkAt, 10))
+}
+
+func (a *App) GetBoardAutomations(boardID string) ([]*model.AutomationRule, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	return model.AutomationRulesFromBoard(board)
+}
+
+// SetBoardAutomations validates and replaces the automation rules of a
+// board. Rules without an id get a new one.
+func (a *App) SetBoardAutomations(boardID string, rules []*model.AutomationRule, userID string) ([]*model.AutomationRule, error) {
+	if len(rules) > model.MaxAutomationRules {
+		return nil, model.NewErrBadRequest(fmt.Sprintf("a board cannot have more than %d automation rules", model.MaxAutomationRules))
+	}
+
+	seen := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		if err := rule.IsValid(); err != nil {
+			return nil, err
+		}
+		if rule.ID == "" || seen[rule.ID] {
+			rule.ID = utils.NewID(utils.IDTypeNone)
+		}
+		seen[rule.ID] = true
+		rule.CreatedBy = userID
+		if rule.Conditions == nil {
+			rule.Conditions = []model.AutomationCondition{}
+		}
+	}
+
+	patch := &model.BoardPatch{
+		UpdatedProperties: map[string]interface{}{model.BoardPropertyAutomations: rules},
+	}
+	if _, err := a.PatchBoard(patch, boardID, userID); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
@@ -37,6 +37,14 @@ func (a *App) DuplicateBlock(boardID string, blockID string, userID string, asTe
 		return nil, fmt.Errorf("cannot fetch board %s for DuplicateBlock: %w", boardID, err)
 	}
 
+	block, err := a.store.GetBlock(blockID)
+	if err != nil {
+		return nil, err
+	}
+	if err = a.checkParentsNotLocked(board, block); err != nil {
+		return nil, err
+	}
+
 	blocks, err := a.store.DuplicateBlock(boardID, blockID, userID, asTemplate)
 	if err != nil {
 		return nil, err
@@ -72,6 +80,13 @@ func (a *App) PatchBlockAndNotify(blockID string, blockPatch *model.BlockPatch,
 		return nil, err
 	}
 
+	if err = checkProtectedFields(blockPatch); err != nil {
+		return nil, err
+	}
+	if err = a.checkBlocksNotLocked(board, oldBlock); err != nil {
+		return nil, err
+	}
+
 	err = a.store.PatchBlock(blockID, blockPatch, modifiedByID)
 	if err != nil {
 		return nil, err
@@ -193,6 +208,15 @@ func (a *App) PatchBlocksAndNotify(teamID string, blockPatches *model.BlockPatch
 		return err
 	}
 
+	for i := range blockPatches.BlockPatches {
+		if err = checkProtectedFields(&blockPatches.BlockPatches[i]); err != nil {
+			return err
+		}
+	}
+	if err = a.checkBoardBlocksNotLocked(oldBlocks); err != nil {
+		return err
+	}
+
 	if err := a.store.PatchBlocks(blockPatches, modifiedByID); err != nil {
 		return err
 	}
@@ -225,6 +249,10 @@ func (a *App) InsertBlockAndNotify(block *model.Block, modifiedByID string, disa
 		return bErr
 	}
 
+	if bErr = a.checkParentsNotLocked(board, block); bErr != nil {
+		return bErr
+	}
+
 	err := a.store.InsertBlock(block, modifiedByID)
 	if err == nil {
 		a.blockChangeNotifier.Enqueue(func() error {
@@ -263,6 +291,10 @@ func (a *App) InsertBlocksAndNotify(blocks []*model.Block, modifiedByID string,
 		return nil, err
 	}
 
+	if err = a.checkParentsNotLocked(board, blocks...); err != nil {
+		return nil, err
+	}
+
 	needsNotify := make([]*model.Block, 0, len(blocks))
 	for i := range blocks {
 		err := a.store.InsertBlock(blocks[i], modifiedByID)
@@ -313,6 +345,10 @@ func (a *App) DeleteBlockAndNotify(blockID string, modifiedBy string, disableNot
 		return nil
 	}
 
+	if err = a.checkBlocksNotLocked(board, block); err != nil {
+		return err
+	}
+
 	err = a.store.DeleteBlock(blockID, modifiedBy)
 	if err != nil {
 		return err
@@ -352,6 +388,14 @@ func (a *App) UndeleteBlock(blockID string, modifiedBy string) (*model.Block, er
 		return nil, nil
 	}
 
+	board, err := a.store.GetBoard(blocks[0].BoardID)
+	if err != nil {
+		return nil, err
+	}
+	if err = a.checkParentsNotLocked(board, blocks[0]); err != nil {
+		return nil, err
+	}
+
 	err = a.store.UndeleteBlock(blockID, modifiedBy)
 	if err != nil {
 		return nil, err
@@ -366,11 +410,6 @@ func (a *App) UndeleteBlock(blockID string, modifiedBy string) (*model.Block, er
 		return nil, err
 	}
 
-	board, err := a.store.GetBoard(block.BoardID)
-	if err != nil {
-		return nil, err
-	}
-
 	a.blockChangeNotifier.Enqueue(func() error {
 		a.wsAdapter.BroadcastBlockChange(board.TeamID, block)
 		a.metrics.IncrementBlocksInserted(1)
@@ -58,8 +58,9 @@ func TestPatchBlocks(t *testing.T) {
 			},
 		}
 
-		block1 := &model.Block{ID: "block1"}
+		block1 := &model.Block{ID: "block1", BoardID: "board-id"}
 		th.Store.EXPECT().GetBlocksByIDs([]string{"block1"}).Return([]*model.Block{block1}, nil)
+		th.Store.EXPECT().GetBoard("board-id").Return(&model.Board{ID: "board-id"}, nil)
 		th.Store.EXPECT().PatchBlocks(gomock.Eq(&blockPatches), gomock.Eq("user-id-1")).Return(nil)
 		th.Store.EXPECT().GetBlock("block1").Return(block1, nil)
 		// this call comes from the WS server notification
@@ -172,16 +173,32 @@ func TestUndeleteBlock(t *testing.T) {
 
 	t.Run("error scenario", func(t *testing.T) {
 		block := &model.Block{
-			ID: "block-id",
+			ID:      "block-id",
+			BoardID: testBoardID,
 		}
 		th.Store.EXPECT().GetBlockHistory(
 			gomock.Eq("block-id"),
 			gomock.Eq(model.QueryBlockHistoryOptions{Limit: 1, Descending: true}),
 		).Return([]*model.Block{block}, nil)
+		th.Store.EXPECT().GetBoard(testBoardID).Return(&model.Board{ID: testBoardID}, nil)
 		th.Store.EXPECT().UndeleteBlock(gomock.Eq("block-id"), gomock.Eq("user-id-1")).Return(blockError{"error"})
 		_, err := th.App.UndeleteBlock("block-id", "user-id-1")
 		require.Error(t, err, "error")
 	})
+
+	t.Run("locked board", func(t *testing.T) {
+		block := &model.Block{
+			ID:      "block-id",
+			BoardID: testBoardID,
+		}
+		th.Store.EXPECT().GetBlockHistory(
+			gomock.Eq("block-id"),
+			gomock.Eq(model.QueryBlockHistoryOptions{Limit: 1, Descending: true}),
+		).Return([]*model.Block{block}, nil)
+		th.Store.EXPECT().GetBoard(testBoardID).Return(&model.Board{ID: testBoardID, IsLocked: true}, nil)
+		_, err := th.App.UndeleteBlock("block-id", "user-id-1")
+		require.ErrorIs(t, err, model.ErrBoardIsLocked)
+	})
 }
 
 func TestInsertBlocks(t *testing.T) {
@@ -0,0 +1,215 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// TransferBoardOwnership makes toUserID the owner and an admin of every
+// board and template that fromUserID created or administers. fromUserID
+// keeps editing the boards but is no longer an admin of them.
+func (a *App) TransferBoardOwnership(fromUserID, toUserID string) ([]*model.Board, error) {
+	if fromUserID == toUserID {
+		return nil, model.NewErrBadRequest("cannot transfer boards to the same user")
+	}
+
+	if _, err := a.store.GetUserByID(fromUserID); err != nil {
+		return nil, err
+	}
+	toUser, err := a.store.GetUserByID(toUserID)
+	if err != nil {
+		return nil, err
+	}
+	if toUser.DeleteAt != 0 {
+		return nil, model.NewErrBadRequest("cannot transfer boards to a deactivated user")
+	}
+
+	boards, err := a.store.GetBoardsAdministeredByUser(fromUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, board := range boards {
+		if err := a.transferBoardAdmin(board, fromUserID, toUserID); err != nil {
+			return nil, err
+		}
+	}
+	return boards, nil
+}
+
+// deactivateUser marks the user as deleted, revokes its sessions and its
+// access tokens, and hands over the boards it was the last admin of.
+func (a *App) deactivateUser(userID string) error {
+	if err := a.store.UpdateUserDeleteAt(userID, utils.GetMillis()); err != nil {
+		return err
+	}
+	if err := a.RevokeSessionsForUser(userID); err != nil {
+		return err
+	}
+	if err := a.store.DeleteAccessTokensForUser(userID); err != nil {
+		return err
+	}
+	return a.OnUserDeactivated(userID)
+}
+
+// OnUserDeactivated keeps the boards of a deactivated user manageable: on
+// every board the user was the last admin of, a team admin is made admin
+// instead. The root team and the teams that aren't managed have no admins,
+// so a system admin, or else another member of the board, takes over their
+// boards.
+func (a *App) OnUserDeactivated(userID string) error {
+	members, err := a.store.GetMembersForUser(userID)
+	if err != nil {
+		return err
+	}
+
+	teamAdmins := map[string]*model.User{}
+	for _, member := range members {
+		if !member.SchemeAdmin || member.Synthetic {
+			continue
+		}
+
+		isLastAdmin, err := a.isLastAdmin(userID, member.BoardID)
+		if err != nil {
+			return err
+		}
+		if !isLastAdmin {
+			continue
+		}
+
+		board, err := a.store.GetBoard(member.BoardID)
+		if model.IsErrNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		newAdmin, ok := teamAdmins[board.TeamID]
+		if !ok {
+			if newAdmin, err = a.findTeamAdmin(board.TeamID, userID); err != nil {
+				return err
+			}
+			if newAdmin == nil {
+				if newAdmin, err = a.findSystemAdmin(userID); err != nil {
+					return err
+				}
+			}
+			teamAdmins[board.TeamID] = newAdmin
+		}
+		if newAdmin == nil {
+			if newAdmin, err = a.findBoardMember(board.ID, userID); err != nil {
+				return err
+			}
+		}
+
+		if newAdmin == nil {
+			a.logger.Warn("No admin to take over board of deactivated user",
+				mlog.String("board_id", board.ID),
+				mlog.String("team_id", board.TeamID),
+				mlog.String("user_id", userID),
+			)
+			continue
+		}
+
+		if err := a.transferBoardAdmin(board, userID, newAdmin.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findTeamAdmin returns an active admin of the team other than the given
+// user, or nil if there is none.
+func (a *App) findTeamAdmin(teamID, excludeUserID string) (*model.User, error) {
+	if teamID == model.GlobalTeamID {
+		return nil, nil
+	}
+
+	users, err := a.store.GetUsersByTeam(teamID, "", false, false)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range users {
+		if user.ID == excludeUserID || user.DeleteAt != 0 || user.IsBot {
+			continue
+		}
+		if a.permissions.HasPermissionToTeam(user.ID, teamID, model.PermissionManageTeam) {
+			return user, nil
+		}
+	}
+	return nil, nil
+}
+
+// findSystemAdmin returns an active system admin other than the given user,
+// or nil if there is none.
+func (a *App) findSystemAdmin(excludeUserID string) (*model.User, error) {
+	users, err := a.store.GetUsers(model.QueryUsersOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range users {
+		if user.ID == excludeUserID || user.IsBot {
+			continue
+		}
+		if user.IsSystemAdmin() {
+			return user, nil
+		}
+	}
+	return nil, nil
+}
+
+// findBoardMember returns the first active member of the board other than
+// the given user, or nil if there is none.
+func (a *App) findBoardMember(boardID, excludeUserID string) (*model.User, error) {
+	members, err := a.store.GetMembersForBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, member := range members {
+		if member.UserID == excludeUserID || member.Synthetic {
+			continue
+		}
+		user, err := a.store.GetUserByID(member.UserID)
+		if model.IsErrNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if user.DeleteAt != 0 || user.IsBot {
+			continue
+		}
+		return user, nil
+	}
+	return nil, nil
+}
+
+func (a *App) transferBoardAdmin(board *model.Board, fromUserID, toUserID string) error {
+	newAdmin, err := a.store.TransferBoardOwnership(board.ID, fromUserID, toUserID)
+	if err != nil {
+		return err
+	}
+
+	oldAdmin, err := a.store.GetMemberForBoard(board.ID, fromUserID)
+	if err != nil && !model.IsErrNotFound(err) {
+		return err
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.wsAdapter.BroadcastMemberChange(board.TeamID, board.ID, newAdmin)
+		if oldAdmin != nil {
+			a.wsAdapter.BroadcastMemberChange(board.TeamID, board.ID, oldAdmin)
+		}
+		return nil
+	})
+	return nil
+}
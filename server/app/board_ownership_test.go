@@ -0,0 +1,163 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferBoardOwnership(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	const fromUserID = "from_user_id"
+	const toUserID = "to_user_id"
+
+	t.Run("same user", func(t *testing.T) {
+		boards, err := th.App.TransferBoardOwnership(fromUserID, fromUserID)
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, boards)
+	})
+
+	t.Run("deactivated target user", func(t *testing.T) {
+		th.Store.EXPECT().GetUserByID(fromUserID).Return(&model.User{ID: fromUserID}, nil)
+		th.Store.EXPECT().GetUserByID(toUserID).Return(&model.User{ID: toUserID, DeleteAt: 1}, nil)
+
+		boards, err := th.App.TransferBoardOwnership(fromUserID, toUserID)
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, boards)
+	})
+
+	t.Run("base case", func(t *testing.T) {
+		board := &model.Board{ID: "board_id", TeamID: "team_id"}
+		template := &model.Board{ID: "template_id", TeamID: "team_id", IsTemplate: true}
+
+		th.Store.EXPECT().GetUserByID(fromUserID).Return(&model.User{ID: fromUserID}, nil)
+		th.Store.EXPECT().GetUserByID(toUserID).Return(&model.User{ID: toUserID}, nil)
+		th.Store.EXPECT().GetBoardsAdministeredByUser(fromUserID).Return([]*model.Board{board, template}, nil)
+		for _, b := range []*model.Board{board, template} {
+			th.Store.EXPECT().TransferBoardOwnership(b.ID, fromUserID, toUserID).
+				Return(&model.BoardMember{BoardID: b.ID, UserID: toUserID, SchemeAdmin: true}, nil)
+			th.Store.EXPECT().GetMemberForBoard(b.ID, fromUserID).
+				Return(&model.BoardMember{BoardID: b.ID, UserID: fromUserID, SchemeEditor: true}, nil)
+			// for WS change broadcast
+			th.Store.EXPECT().GetMembersForBoard(b.ID).Return([]*model.BoardMember{}, nil).AnyTimes()
+		}
+
+		boards, err := th.App.TransferBoardOwnership(fromUserID, toUserID)
+		require.NoError(t, err)
+		require.Equal(t, []*model.Board{board, template}, boards)
+	})
+}
+
+func TestOnUserDeactivated(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	const userID = "deactivated_user_id"
+	const teamID = "team_id"
+
+	t.Run("last admin is replaced by a team admin", func(t *testing.T) {
+		th.Store.EXPECT().GetMembersForUser(userID).Return([]*model.BoardMember{
+			{BoardID: "orphaned_board", UserID: userID, SchemeAdmin: true},
+			{BoardID: "shared_board", UserID: userID, SchemeAdmin: true},
+			{BoardID: "edited_board", UserID: userID, SchemeEditor: true},
+		}, nil)
+
+		th.Store.EXPECT().GetMembersForBoard("orphaned_board").Return([]*model.BoardMember{
+			{BoardID: "orphaned_board", UserID: userID, SchemeAdmin: true},
+			{BoardID: "orphaned_board", UserID: "editor_id", SchemeEditor: true},
+		}, nil)
+		th.Store.EXPECT().GetMembersForBoard("shared_board").Return([]*model.BoardMember{
+			{BoardID: "shared_board", UserID: userID, SchemeAdmin: true},
+			{BoardID: "shared_board", UserID: "other_admin_id", SchemeAdmin: true},
+		}, nil)
+
+		th.Store.EXPECT().GetBoard("orphaned_board").Return(&model.Board{ID: "orphaned_board", TeamID: teamID}, nil)
+		th.Store.EXPECT().GetUsersByTeam(teamID, "", false, false).Return([]*model.User{
+			{ID: userID},
+			{ID: "deactivated_admin_id", DeleteAt: 1},
+			{ID: "member_id"},
+			{ID: "team_admin_id"},
+		}, nil)
+		th.API.EXPECT().HasPermissionToTeam("member_id", teamID, model.PermissionManageTeam).Return(false)
+		th.API.EXPECT().HasPermissionToTeam("team_admin_id", teamID, model.PermissionManageTeam).Return(true)
+
+		th.Store.EXPECT().TransferBoardOwnership("orphaned_board", userID, "team_admin_id").
+			Return(&model.BoardMember{BoardID: "orphaned_board", UserID: "team_admin_id", SchemeAdmin: true}, nil)
+		th.Store.EXPECT().GetMemberForBoard("orphaned_board", userID).
+			Return(&model.BoardMember{BoardID: "orphaned_board", UserID: userID, SchemeEditor: true}, nil)
+		// for WS change broadcast
+		th.Store.EXPECT().GetMembersForBoard("orphaned_board").Return([]*model.BoardMember{}, nil).AnyTimes()
+
+		require.NoError(t, th.App.OnUserDeactivated(userID))
+	})
+
+	t.Run("no admin to take over", func(t *testing.T) {
+		th.Store.EXPECT().GetMembersForUser(userID).Return([]*model.BoardMember{
+			{BoardID: "other_board", UserID: userID, SchemeAdmin: true},
+		}, nil)
+		th.Store.EXPECT().GetMembersForBoard("other_board").Return([]*model.BoardMember{
+			{BoardID: "other_board", UserID: userID, SchemeAdmin: true},
+		}, nil).Times(2)
+		th.Store.EXPECT().GetBoard("other_board").Return(&model.Board{ID: "other_board", TeamID: teamID}, nil)
+		th.Store.EXPECT().GetUsersByTeam(teamID, "", false, false).Return([]*model.User{{ID: userID}}, nil)
+		th.Store.EXPECT().GetUsers(model.QueryUsersOptions{}).Return([]*model.User{{ID: userID, Roles: model.SystemAdminRole}}, nil)
+
+		require.NoError(t, th.App.OnUserDeactivated(userID))
+	})
+
+	t.Run("root team boards are taken over by a system admin", func(t *testing.T) {
+		th.Store.EXPECT().GetMembersForUser(userID).Return([]*model.BoardMember{
+			{BoardID: "root_board", UserID: userID, SchemeAdmin: true},
+		}, nil)
+		th.Store.EXPECT().GetMembersForBoard("root_board").Return([]*model.BoardMember{
+			{BoardID: "root_board", UserID: userID, SchemeAdmin: true},
+		}, nil)
+		th.Store.EXPECT().GetBoard("root_board").Return(&model.Board{ID: "root_board", TeamID: model.GlobalTeamID}, nil)
+		th.Store.EXPECT().GetUsers(model.QueryUsersOptions{}).Return([]*model.User{
+			{ID: userID, Roles: model.SystemAdminRole},
+			{ID: "bot_id", Roles: model.SystemAdminRole, IsBot: true},
+			{ID: "member_id", Roles: "system_user"},
+			{ID: "system_admin_id", Roles: "system_user " + model.SystemAdminRole},
+		}, nil)
+
+		th.Store.EXPECT().TransferBoardOwnership("root_board", userID, "system_admin_id").
+			Return(&model.BoardMember{BoardID: "root_board", UserID: "system_admin_id", SchemeAdmin: true}, nil)
+		th.Store.EXPECT().GetMemberForBoard("root_board", userID).
+			Return(&model.BoardMember{BoardID: "root_board", UserID: userID, SchemeEditor: true}, nil)
+		// for WS change broadcast
+		th.Store.EXPECT().GetMembersForBoard("root_board").Return([]*model.BoardMember{}, nil).AnyTimes()
+
+		require.NoError(t, th.App.OnUserDeactivated(userID))
+	})
+
+	t.Run("without a system admin another board member takes over", func(t *testing.T) {
+		th.Store.EXPECT().GetMembersForUser(userID).Return([]*model.BoardMember{
+			{BoardID: "member_board", UserID: userID, SchemeAdmin: true},
+		}, nil)
+		th.Store.EXPECT().GetMembersForBoard("member_board").Return([]*model.BoardMember{
+			{BoardID: "member_board", UserID: userID, SchemeAdmin: true},
+			{BoardID: "member_board", UserID: "synthetic_id", Synthetic: true},
+			{BoardID: "member_board", UserID: "deactivated_id", SchemeEditor: true},
+			{BoardID: "member_board", UserID: "editor_id", SchemeEditor: true},
+		}, nil).Times(2)
+		th.Store.EXPECT().GetBoard("member_board").Return(&model.Board{ID: "member_board", TeamID: model.GlobalTeamID}, nil)
+		th.Store.EXPECT().GetUsers(model.QueryUsersOptions{}).Return([]*model.User{{ID: userID}, {ID: "editor_id"}}, nil)
+		th.Store.EXPECT().GetUserByID("deactivated_id").Return(&model.User{ID: "deactivated_id", DeleteAt: 1}, nil)
+		th.Store.EXPECT().GetUserByID("editor_id").Return(&model.User{ID: "editor_id"}, nil)
+
+		th.Store.EXPECT().TransferBoardOwnership("member_board", userID, "editor_id").
+			Return(&model.BoardMember{BoardID: "member_board", UserID: "editor_id", SchemeAdmin: true}, nil)
+		th.Store.EXPECT().GetMemberForBoard("member_board", userID).
+			Return(&model.BoardMember{BoardID: "member_board", UserID: userID, SchemeEditor: true}, nil)
+		// for WS change broadcast
+		th.Store.EXPECT().GetMembersForBoard("member_board").Return([]*model.BoardMember{}, nil).AnyTimes()
+
+		require.NoError(t, th.App.OnUserDeactivated(userID))
+	})
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// CreateBoardSnapshot records a named snapshot of a board at the current time.
+func (a *App) CreateBoardSnapshot(snapshot *model.BoardSnapshot, userID string) (*model.BoardSnapshot, error) {
+	if _, err := a.store.GetBoard(snapshot.BoardID); err != nil {
+		return nil, err
+	}
+
+	snapshot.CreatedBy = userID
+	return a.store.CreateBoardSnapshot(snapshot)
+}
+
+func (a *App) GetBoardSnapshots(boardID string) ([]*model.BoardSnapshot, error) {
+	return a.store.GetBoardSnapshots(boardID)
+}
+
+func (a *App) DeleteBoardSnapshot(boardID, snapshotID string) error {
+	snapshot, err := a.store.GetBoardSnapshot(snapshotID)
+	if err != nil {
+		return err
+	}
+	if snapshot.BoardID != boardID {
+		return model.NewErrNotFound("board snapshot ID=" + snapshotID)
+	}
+	return a.store.DeleteBoardSnapshot(snapshotID)
+}
+
+// createAutomaticBoardSnapshot takes the snapshot of a board before an
+// operation that changes many of its blocks at once.
+func (a *App) createAutomaticBoardSnapshot(boardID, name, userID string) error {
+	_, err := a.store.CreateBoardSnapshot(&model.BoardSnapshot{
+		BoardID:   boardID,
+		Name:      name,
+		Type:      model.BoardSnapshotAutomatic,
+		CreatedBy: userID,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot create snapshot of board %s: %w", boardID, err)
+	}
+	return nil
+}
+
+// RestoreBoard rolls the board, its views, cards and content back to how
+// they were at the time of a snapshot or at a timestamp. Blocks that were
+// moved to another board since then are not brought back. A snapshot of the
+// current state is taken first, so the restore itself can be undone.
+func (a *App) RestoreBoard(boardID string, request *model.BoardRestoreRequest, userID string) (*model.Board, error) {
+	if err := request.IsValid(); err != nil {
+		return nil, err
+	}
+
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if board.IsLocked {
+		return nil, model.ErrBoardIsLocked
+	}
+
+	restoreAt := request.Timestamp
+	if request.SnapshotID != "" {
+		snapshot, snapshotErr := a.store.GetBoardSnapshot(request.SnapshotID)
+		if snapshotErr != nil {
+			return nil, snapshotErr
+		}
+		if snapshot.BoardID != boardID {
+			return nil, model.NewErrNotFound("board snapshot ID=" + request.SnapshotID)
+		}
+		restoreAt = snapshot.CreateAt
+	}
+
+	boardHistory, err := a.store.GetBoardHistory(boardID, model.QueryBoardHistoryOptions{
+		BeforeUpdateAt: restoreAt + 1,
+		Descending:     true,
+		Limit:          1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(boardHistory) == 0 || boardHistory[0].DeleteAt != 0 {
+		return nil, model.NewErrBadRequest(fmt.Sprintf("board %s has no version at %d", boardID, restoreAt))
+	}
+	oldBoard := boardHistory[0]
+
+	blockHistory, err := a.store.GetBlockHistoryDescendants(boardID, model.QueryBlockHistoryOptions{
+		BeforeUpdateAt: restoreAt + 1,
+		Descending:     true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	currentBlocks, err := a.store.GetBlocksForBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the newest version of each block as of the restored time
+	oldBlocks := make(map[string]*model.Block)
+	for _, block := range blockHistory {
+		if _, ok := oldBlocks[block.ID]; !ok {
+			oldBlocks[block.ID] = block
+		}
+	}
+
+	var blocks []*model.Block
+	var deletedBlocks []*model.Block
+	var deletedBlockIDs []string
+	for _, block := range currentBlocks {
+		oldBlock, ok := oldBlocks[block.ID]
+		delete(oldBlocks, block.ID)
+		if !ok || oldBlock.DeleteAt != 0 {
+			deletedBlocks = append(deletedBlocks, block)
+			deletedBlockIDs = append(deletedBlockIDs, block.ID)
+			continue
+		}
+		if block.Title != oldBlock.Title || block.ParentID != oldBlock.ParentID ||
+			!reflect.DeepEqual(block.Fields, oldBlock.Fields) {
+			restored := *block
+			restored.ParentID = oldBlock.ParentID
+			restored.Title = oldBlock.Title
+			restored.Fields = oldBlock.Fields
+			blocks = append(blocks, &restored)
+		}
+	}
+	for _, oldBlock := range oldBlocks {
+		if oldBlock.DeleteAt != 0 {
+			continue
+		}
+		if _, err = a.store.GetBlock(oldBlock.ID); err == nil {
+			// the block has been moved to another board
+			continue
+		} else if !model.IsErrNotFound(err) {
+			return nil, err
+		}
+		restored := *oldBlock
+		blocks = append(blocks, &restored)
+	}
+
+	restoredBoard := *board
+	restoredBoard.Title = oldBoard.Title
+	restoredBoard.Description = oldBoard.Description
+	restoredBoard.Icon = oldBoard.Icon
+	restoredBoard.ShowDescription = oldBoard.ShowDescription
+	restoredBoard.Properties = oldBoard.Properties
+	restoredBoard.CardProperties = oldBoard.CardProperties
+
+	if err = a.createAutomaticBoardSnapshot(boardID, "Before restore", userID); err != nil {
+		return nil, err
+	}
+
+	if err = a.store.RestoreBoard(&restoredBoard, blocks, deletedBlockIDs, userID); err != nil {
+		return nil, fmt.Errorf("cannot restore board %s: %w", boardID, err)
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.wsAdapter.BroadcastBoardChange(restoredBoard.TeamID, &restoredBoard)
+		for _, block := range blocks {
+			a.wsAdapter.BroadcastBlockChange(restoredBoard.TeamID, block)
+			a.webhook.NotifyUpdate(block)
+		}
+		for _, block := range deletedBlocks {
+			a.wsAdapter.BroadcastBlockDelete(restoredBoard.TeamID, block.ID, block.BoardID)
+		}
+		return nil
+	})
+
+	return &restoredBoard, nil
+}
@@ -310,10 +310,20 @@ func (a *App) PatchBoard(patch *model.BoardPatch, boardID, userID string) (*mode
 	var isTemplate bool
 	var oldMembers []*model.BoardMember
 
+	board, err := a.store.GetBoard(boardID)
+	if model.IsErrNotFound(err) {
+		return nil, model.NewErrNotFound("board ID=" + boardID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if board.IsLocked {
+		return nil, model.ErrBoardIsLocked
+	}
+
 	if patch.Type != nil || patch.ChannelID != nil {
 		testChannel := ""
 		if patch.ChannelID != nil && *patch.ChannelID == "" {
-			var err error
 			oldMembers, err = a.GetMembersForBoard(boardID)
 			if err != nil {
 				a.logger.Error("Unable to get the board members", mlog.Err(err))
@@ -322,13 +332,6 @@ func (a *App) PatchBoard(patch *model.BoardPatch, boardID, userID string) (*mode
 			testChannel = *patch.ChannelID
 		}
 
-		board, err := a.store.GetBoard(boardID)
-		if model.IsErrNotFound(err) {
-			return nil, model.NewErrNotFound("board ID=" + boardID)
-		}
-		if err != nil {
-			return nil, err
-		}
 		oldChannelID = board.ChannelID
 		isTemplate = board.IsTemplate
 		if testChannel == "" {
@@ -528,6 +531,10 @@ func (a *App) AddMemberToBoard(member *model.BoardMember) (*model.BoardMember, e
 		return existingMembership, nil
 	}
 
+	if err = a.checkMemberCustomRole(board, member); err != nil {
+		return nil, err
+	}
+
 	newMember, err := a.store.SaveMember(member)
 	if err != nil {
 		return nil, err
@@ -597,6 +604,10 @@ func (a *App) UpdateBoardMember(member *model.BoardMember) (*model.BoardMember,
 		}
 	}
 
+	if err = a.checkMemberCustomRole(board, member); err != nil {
+		return nil, err
+	}
+
 	newMember, err := a.store.SaveMember(member)
 	if err != nil {
 		return nil, err
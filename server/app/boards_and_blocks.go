@@ -61,6 +61,24 @@ func (a *App) PatchBoardsAndBlocks(pbab *model.PatchBoardsAndBlocks, userID stri
 		return nil, err
 	}
 
+	for _, blockPatch := range pbab.BlockPatches {
+		if err = checkProtectedFields(blockPatch); err != nil {
+			return nil, err
+		}
+	}
+	if err = a.checkBoardBlocksNotLocked(oldBlocks); err != nil {
+		return nil, err
+	}
+	for _, boardID := range pbab.BoardIDs {
+		board, bErr := a.store.GetBoard(boardID)
+		if bErr != nil {
+			return nil, bErr
+		}
+		if board.IsLocked {
+			return nil, model.ErrBoardIsLocked
+		}
+	}
+
 	oldBlocksMap := map[string]*model.Block{}
 	for _, block := range oldBlocks {
 		oldBlocksMap[block.ID] = block
@@ -113,6 +131,9 @@ func (a *App) DeleteBoardsAndBlocks(dbab *model.DeleteBoardsAndBlocks, userID st
 		}
 		blocks = append(blocks, block)
 	}
+	if err = a.checkBoardBlocksNotLocked(blocks); err != nil {
+		return err
+	}
 
 	if err := a.store.DeleteBoardsAndBlocks(dbab, userID); err != nil {
 		return err
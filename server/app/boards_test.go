@@ -149,6 +149,7 @@ func TestPatchBoard(t *testing.T) {
 			Title: &patchTitle,
 		}
 
+		th.Store.EXPECT().GetBoard(boardID).Return(&model.Board{ID: boardID, TeamID: teamID}, nil)
 		th.Store.EXPECT().PatchBoard(boardID, patch, userID).Return(
 			&model.Board{
 				ID:     boardID,
@@ -165,6 +166,21 @@ func TestPatchBoard(t *testing.T) {
 		require.Equal(t, patchTitle, patchedBoard.Title)
 	})
 
+	t.Run("locked board", func(t *testing.T) {
+		const boardID = "board_id_1"
+		const userID = "user_id_1"
+
+		patchTitle := "Patched Title"
+		patch := &model.BoardPatch{
+			Title: &patchTitle,
+		}
+
+		th.Store.EXPECT().GetBoard(boardID).Return(&model.Board{ID: boardID, IsLocked: true}, nil)
+
+		_, err := th.App.PatchBoard(patch, boardID, userID)
+		require.ErrorIs(t, err, model.ErrBoardIsLocked)
+	})
+
 	t.Run("patch type open, no users", func(t *testing.T) {
 		const boardID = "board_id_1"
 		const userID = "user_id_2"
@@ -0,0 +1,160 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/permissions"
+)
+
+// SetCardAccess restricts a card to its creator, its assignees, the allowed
+// users and the board admins, or makes it visible to the whole board again.
+func (a *App) SetCardAccess(cardID string, access *model.CardAccess, userID string) (*model.Card, error) {
+	if err := access.IsValid(); err != nil {
+		return nil, err
+	}
+
+	cardBlock, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+	if cardBlock.Type != model.TypeCard {
+		return nil, model.NewErrBadRequest("block is not a card")
+	}
+
+	board, err := a.store.GetBoard(cardBlock.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	if err = a.checkBlocksNotLocked(board, cardBlock); err != nil {
+		return nil, err
+	}
+
+	blockPatch := &model.BlockPatch{
+		DeletedFields: []string{model.CardRestrictedField, model.CardAllowedUsersField},
+	}
+	if access.Restricted {
+		allowedUserIDs := access.AllowedUserIDs
+		if allowedUserIDs == nil {
+			allowedUserIDs = []string{}
+		}
+		blockPatch = &model.BlockPatch{
+			UpdatedFields: map[string]any{
+				model.CardRestrictedField:   true,
+				model.CardAllowedUsersField: allowedUserIDs,
+			},
+		}
+	}
+	if err = a.store.PatchBlock(cardID, blockPatch, userID); err != nil {
+		return nil, err
+	}
+
+	block, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.wsAdapter.BroadcastBlockChange(board.TeamID, block)
+		a.webhook.NotifyUpdate(block)
+		return nil
+	})
+	return model.Block2Card(block)
+}
+
+// CanSeeCard returns true if the user can see the card.
+func (a *App) CanSeeCard(userID string, board *model.Board, card *model.Block) bool {
+	return permissions.CanSeeCard(a.permissions, userID, board, card)
+}
+
+// CheckCanSeeBlocks returns a not found error if any of the blocks is a
+// restricted card the user can't see, or belongs to one. Hidden cards are
+// reported as not found so that their existence isn't leaked. Every API that
+// reads or changes a card or its content goes through this check.
+func (a *App) CheckCanSeeBlocks(userID string, blocks ...*model.Block) error {
+	boards := map[string]*model.Board{}
+	cards := map[string]*model.Block{}
+	for _, block := range blocks {
+		card := block
+		if block.Type != model.TypeCard {
+			if block.ParentID == "" || block.ParentID == block.BoardID {
+				continue
+			}
+			parent, ok := cards[block.ParentID]
+			if !ok {
+				var err error
+				parent, err = a.store.GetBlock(block.ParentID)
+				if err != nil && !model.IsErrNotFound(err) {
+					return err
+				}
+				cards[block.ParentID] = parent
+			}
+			if parent == nil || parent.Type != model.TypeCard {
+				continue
+			}
+			card = parent
+		}
+
+		board, ok := boards[card.BoardID]
+		if !ok {
+			var err error
+			board, err = a.store.GetBoard(card.BoardID)
+			if err != nil {
+				return err
+			}
+			boards[card.BoardID] = board
+		}
+		if !a.CanSeeCard(userID, board, card) {
+			return model.NewErrNotFound("card ID=" + card.ID)
+		}
+	}
+	return nil
+}
+
+// GetHiddenCardIDs returns the IDs of the restricted cards of a board that
+// the user can't see.
+func (a *App) GetHiddenCardIDs(userID string, board *model.Board) (map[string]bool, error) {
+	cards, err := a.store.GetBlocks(model.QueryBlocksOptions{
+		BoardID:   board.ID,
+		BlockType: model.TypeCard,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	hiddenCardIDs := map[string]bool{}
+	for _, card := range cards {
+		if !a.CanSeeCard(userID, board, card) {
+			hiddenCardIDs[card.ID] = true
+		}
+	}
+	return hiddenCardIDs, nil
+}
+
+// FilterRestrictedBlocks removes the restricted cards the user can't see
+// from a list of blocks of a board, along with the blocks that belong to
+// them.
+func (a *App) FilterRestrictedBlocks(userID string, board *model.Board, blocks []*model.Block) ([]*model.Block, error) {
+	if len(blocks) == 0 {
+		return blocks, nil
+	}
+
+	hiddenCardIDs, err := a.GetHiddenCardIDs(userID, board)
+	if err != nil {
+		return nil, err
+	}
+	return model.FilterHiddenCardBlocks(blocks, hiddenCardIDs), nil
+}
+
+// FilterRestrictedCards removes the restricted cards the user can't see
+// from a list of cards of a board.
+func (a *App) FilterRestrictedCards(userID string, board *model.Board, cards []*model.Card) []*model.Card {
+	filtered := make([]*model.Card, 0, len(cards))
+	for _, card := range cards {
+		if a.CanSeeCard(userID, board, model.Card2Block(card)) {
+			filtered = append(filtered, card)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetCardAccess(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("invalid access", func(t *testing.T) {
+		access := &model.CardAccess{AllowedUserIDs: []string{"user_id"}}
+
+		card, err := th.App.SetCardAccess("card_id", access, "user_id")
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, card)
+	})
+
+	t.Run("restrict card", func(t *testing.T) {
+		cardBlock := &model.Block{ID: "card_id", BoardID: "board_id", Type: model.TypeCard}
+		restrictedBlock := &model.Block{
+			ID:      "card_id",
+			BoardID: "board_id",
+			Type:    model.TypeCard,
+			Fields: map[string]interface{}{
+				model.CardRestrictedField:   true,
+				model.CardAllowedUsersField: []interface{}{"allowed_id"},
+			},
+		}
+		gomock.InOrder(
+			th.Store.EXPECT().GetBlock(cardBlock.ID).Return(cardBlock, nil),
+			th.Store.EXPECT().GetBlock(cardBlock.ID).Return(restrictedBlock, nil),
+		)
+		th.Store.EXPECT().GetBoard("board_id").Return(&model.Board{ID: "board_id", TeamID: "team_id"}, nil)
+		th.Store.EXPECT().PatchBlock(cardBlock.ID, &model.BlockPatch{
+			UpdatedFields: map[string]any{
+				model.CardRestrictedField:   true,
+				model.CardAllowedUsersField: []string{"allowed_id"},
+			},
+		}, "user_id").Return(nil)
+		// for WS change broadcast
+		th.Store.EXPECT().GetBoard("board_id").Return(&model.Board{ID: "board_id", TeamID: "team_id"}, nil).AnyTimes()
+		th.Store.EXPECT().GetMembersForBoard("board_id").Return([]*model.BoardMember{}, nil).AnyTimes()
+
+		access := &model.CardAccess{Restricted: true, AllowedUserIDs: []string{"allowed_id"}}
+		card, err := th.App.SetCardAccess(cardBlock.ID, access, "user_id")
+		require.NoError(t, err)
+		require.True(t, card.IsRestricted)
+		require.Equal(t, []string{"allowed_id"}, card.AllowedUserIDs)
+	})
+}
+
+func TestFilterRestrictedBlocks(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{ID: "board_id", TeamID: "team_id"}
+	restrictedCard := &model.Block{
+		ID:        "restricted_card_id",
+		BoardID:   board.ID,
+		ParentID:  board.ID,
+		Type:      model.TypeCard,
+		CreatedBy: "creator_id",
+		Fields:    map[string]interface{}{model.CardRestrictedField: true},
+	}
+	card := &model.Block{ID: "card_id", BoardID: board.ID, ParentID: board.ID, Type: model.TypeCard}
+	blocks := []*model.Block{
+		restrictedCard,
+		{ID: "restricted_text_id", BoardID: board.ID, ParentID: restrictedCard.ID, Type: model.TypeText},
+		card,
+		{ID: "text_id", BoardID: board.ID, ParentID: card.ID, Type: model.TypeText},
+	}
+
+	th.Store.EXPECT().GetBlocks(model.QueryBlocksOptions{BoardID: board.ID, BlockType: model.TypeCard}).
+		Return([]*model.Block{restrictedCard, card}, nil).Times(2)
+
+	t.Run("creator", func(t *testing.T) {
+		filtered, err := th.App.FilterRestrictedBlocks("creator_id", board, blocks)
+		require.NoError(t, err)
+		require.Equal(t, blocks, filtered)
+	})
+
+	t.Run("other board member", func(t *testing.T) {
+		th.PermissionsStore.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.API.EXPECT().HasPermissionToTeam("member_id", board.TeamID, model.PermissionViewTeam).Return(false)
+
+		filtered, err := th.App.FilterRestrictedBlocks("member_id", board, blocks)
+		require.NoError(t, err)
+		require.Equal(t, blocks[2:], filtered)
+	})
+}
+
+func TestCheckCanSeeBlocks(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{ID: "board_id", TeamID: "team_id"}
+	restrictedCard := &model.Block{
+		ID:        "restricted_card_id",
+		BoardID:   board.ID,
+		ParentID:  board.ID,
+		Type:      model.TypeCard,
+		CreatedBy: "creator_id",
+		Fields:    map[string]interface{}{model.CardRestrictedField: true},
+	}
+	text := &model.Block{ID: "text_id", BoardID: board.ID, ParentID: restrictedCard.ID, Type: model.TypeText}
+	view := &model.Block{ID: "view_id", BoardID: board.ID, ParentID: board.ID, Type: model.TypeView}
+
+	t.Run("creator", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(restrictedCard.ID).Return(restrictedCard, nil)
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+
+		require.NoError(t, th.App.CheckCanSeeBlocks("creator_id", view, text, restrictedCard))
+	})
+
+	t.Run("other board member", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(restrictedCard.ID).Return(restrictedCard, nil)
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.PermissionsStore.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.API.EXPECT().HasPermissionToTeam("member_id", board.TeamID, model.PermissionViewTeam).Return(false)
+
+		err := th.App.CheckCanSeeBlocks("member_id", view, text)
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
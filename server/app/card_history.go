@@ -0,0 +1,275 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/services/notify/notifysubscriptions"
+)
+
+const defaultCardHistoryPerPage = 20
+
+// GetCardHistory returns the versions of a card, newest first. A version is
+// recorded every time the card or one of its content blocks changes.
+func (a *App) GetCardHistory(cardID string, opts model.QueryCardHistoryOptions) ([]*model.CardVersion, error) {
+	card, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+	if card.Type != model.TypeCard {
+		return nil, model.NewErrBadRequest("block is not a card")
+	}
+
+	board, err := a.store.GetBoard(card.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.PerPage <= 0 {
+		opts.PerPage = defaultCardHistoryPerPage
+	}
+
+	history, err := a.store.GetBlockHistoryWithChildren(cardID, model.QueryBlockHistoryOptions{
+		BeforeUpdateAt: opts.BeforeUpdateAt,
+		Descending:     true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// one version per distinct update time, plus the one before the last
+	// returned version, which its changes are computed against
+	updateTimes := distinctUpdateTimes(history)
+	if len(updateTimes) > opts.PerPage+1 {
+		updateTimes = updateTimes[:opts.PerPage+1]
+	}
+
+	versions := make([]*model.CardVersion, 0, opts.PerPage)
+	for i, updateAt := range updateTimes {
+		if i == opts.PerPage {
+			break
+		}
+		var sinceUpdateAt int64
+		if i+1 < len(updateTimes) {
+			sinceUpdateAt = updateTimes[i+1]
+		}
+
+		cardVersion := blockVersionAt(history, cardID, updateAt)
+		if cardVersion == nil {
+			continue
+		}
+
+		diff, err := notifysubscriptions.GenerateCardVersionDiff(a.store, board, cardVersion, sinceUpdateAt, updateAt, a.logger)
+		if err != nil {
+			return nil, fmt.Errorf("cannot generate diff for card %s: %w", cardID, err)
+		}
+
+		version, err := cardVersionFromDiff(diff, updateAt)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, version)
+	}
+	return versions, nil
+}
+
+// RevertCard restores the title, properties and content blocks of a card to
+// how they were at the given time. Comments are not changed.
+func (a *App) RevertCard(cardID string, toUpdateAt int64, userID string) (*model.Card, error) {
+	card, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+	if card.Type != model.TypeCard {
+		return nil, model.NewErrBadRequest("block is not a card")
+	}
+
+	board, err := a.store.GetBoard(card.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	if err = a.checkBlocksNotLocked(board, card); err != nil {
+		return nil, err
+	}
+
+	history, err := a.store.GetBlockHistoryWithChildren(cardID, model.QueryBlockHistoryOptions{
+		BeforeUpdateAt: toUpdateAt + 1,
+		Descending:     true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	oldCard := blockVersionAt(history, cardID, toUpdateAt)
+	if oldCard == nil || oldCard.DeleteAt != 0 {
+		return nil, model.NewErrBadRequest(fmt.Sprintf("card %s has no version at %d", cardID, toUpdateAt))
+	}
+
+	revertedCard := *card
+	revertedCard.Title = oldCard.Title
+	revertedCard.Fields = make(map[string]interface{}, len(card.Fields))
+	for k, v := range card.Fields {
+		revertedCard.Fields[k] = v
+	}
+	for _, key := range []string{"properties", "contentOrder", "icon"} {
+		if value, ok := oldCard.Fields[key]; ok {
+			revertedCard.Fields[key] = value
+		} else {
+			delete(revertedCard.Fields, key)
+		}
+	}
+
+	children, err := a.store.GetBlocksWithParent(card.BoardID, cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	oldChildren := make(map[string]*model.Block)
+	for _, block := range history {
+		if block.ID == cardID || block.Type == model.TypeComment || block.ParentID != cardID {
+			continue
+		}
+		if _, ok := oldChildren[block.ID]; !ok {
+			oldChildren[block.ID] = block
+		}
+	}
+
+	blocks := []*model.Block{&revertedCard}
+	var deletedBlocks []*model.Block
+	var deletedBlockIDs []string
+	for _, child := range children {
+		if child.Type == model.TypeComment {
+			continue
+		}
+		oldChild, ok := oldChildren[child.ID]
+		delete(oldChildren, child.ID)
+		if !ok || oldChild.DeleteAt != 0 {
+			deletedBlocks = append(deletedBlocks, child)
+			deletedBlockIDs = append(deletedBlockIDs, child.ID)
+			continue
+		}
+		if child.Title != oldChild.Title || !reflect.DeepEqual(child.Fields, oldChild.Fields) {
+			restored := *child
+			restored.Title = oldChild.Title
+			restored.Fields = oldChild.Fields
+			blocks = append(blocks, &restored)
+		}
+	}
+	for _, oldChild := range oldChildren {
+		if oldChild.DeleteAt != 0 {
+			continue
+		}
+		restored := *oldChild
+		restored.BoardID = card.BoardID
+		restored.DeleteAt = 0
+		blocks = append(blocks, &restored)
+	}
+
+	if err = a.store.RevertBlocks(blocks, deletedBlockIDs, userID); err != nil {
+		return nil, fmt.Errorf("cannot revert card %s: %w", cardID, err)
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		for _, block := range blocks {
+			a.wsAdapter.BroadcastBlockChange(board.TeamID, block)
+			a.webhook.NotifyUpdate(block)
+		}
+		for _, block := range deletedBlocks {
+			a.wsAdapter.BroadcastBlockDelete(board.TeamID, block.ID, block.BoardID)
+		}
+		a.notifyBlockChanged(notify.Update, &revertedCard, card, userID)
+		return nil
+	})
+
+	return model.Block2Card(&revertedCard)
+}
+
+// distinctUpdateTimes returns the distinct update times of a block history,
+// newest first.
+func distinctUpdateTimes(history []*model.Block) []int64 {
+	seen := make(map[int64]bool)
+	updateTimes := make([]int64, 0, len(history))
+	for _, block := range history {
+		if !seen[block.UpdateAt] {
+			seen[block.UpdateAt] = true
+			updateTimes = append(updateTimes, block.UpdateAt)
+		}
+	}
+	sort.Slice(updateTimes, func(i, j int) bool { return updateTimes[i] > updateTimes[j] })
+	return updateTimes
+}
+
+// blockVersionAt returns the version of a block as of the given time from a
+// history sorted newest first.
+func blockVersionAt(history []*model.Block, blockID string, updateAt int64) *model.Block {
+	for _, block := range history {
+		if block.ID == blockID && block.UpdateAt <= updateAt {
+			return block
+		}
+	}
+	return nil
+}
+
+func cardVersionFromDiff(diff *notifysubscriptions.Diff, updateAt int64) (*model.CardVersion, error) {
+	card, err := model.Block2Card(diff.NewBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	modifiedBy := diff.Authors.Keys()
+	sort.Strings(modifiedBy)
+
+	version := &model.CardVersion{
+		UpdateAt:        updateAt,
+		ModifiedBy:      modifiedBy,
+		Card:            card,
+		PropertyChanges: make([]model.CardPropertyChange, 0, len(diff.PropDiffs)),
+		ContentChanges:  make([]model.CardContentChange, 0, len(diff.Diffs)),
+	}
+
+	if diff.OldBlock == nil {
+		version.TitleChange = &model.CardValueChange{NewValue: diff.NewBlock.Title}
+	} else if diff.OldBlock.Title != diff.NewBlock.Title {
+		version.TitleChange = &model.CardValueChange{OldValue: diff.OldBlock.Title, NewValue: diff.NewBlock.Title}
+	}
+
+	for _, propDiff := range diff.PropDiffs {
+		version.PropertyChanges = append(version.PropertyChanges, model.CardPropertyChange{
+			PropertyID: propDiff.ID,
+			Name:       propDiff.Name,
+			OldValue:   propDiff.OldValue,
+			NewValue:   propDiff.NewValue,
+		})
+	}
+
+	for _, childDiff := range diff.Diffs {
+		change := model.CardContentChange{
+			BlockID:  childDiff.NewBlock.ID,
+			Type:     childDiff.NewBlock.Type,
+			NewTitle: childDiff.NewBlock.Title,
+		}
+		switch {
+		case childDiff.OldBlock == nil:
+			change.Action = model.CardContentAdded
+		case childDiff.NewBlock.DeleteAt != 0:
+			change.Action = model.CardContentDeleted
+			change.OldTitle = childDiff.OldBlock.Title
+			change.NewTitle = ""
+		case childDiff.OldBlock.Title != childDiff.NewBlock.Title ||
+			!reflect.DeepEqual(childDiff.OldBlock.Fields, childDiff.NewBlock.Fields):
+			change.Action = model.CardContentUpdated
+			change.OldTitle = childDiff.OldBlock.Title
+		default:
+			continue
+		}
+		version.ContentChanges = append(version.ContentChanges, change)
+	}
+
+	return version, nil
+}
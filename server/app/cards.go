@@ -7,7 +7,10 @@ import (
 	"fmt"
 
 	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
 	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
 )
 
 func (a *App) CreateCard(card *model.Card, boardID string, userID string, disableNotify bool) (*model.Card, error) {
@@ -37,12 +40,13 @@ func (a *App) CreateCard(card *model.Card, boardID string, userID string, disabl
 	return newCard, nil
 }
 
-func (a *App) GetCardsForBoard(boardID string, page int, perPage int) ([]*model.Card, error) {
+func (a *App) GetCardsForBoard(boardID string, page int, perPage int, includeArchived bool) ([]*model.Card, error) {
 	opts := model.QueryBlocksOptions{
-		BoardID:   boardID,
-		BlockType: model.TypeCard,
-		Page:      page,
-		PerPage:   perPage,
+		BoardID:         boardID,
+		BlockType:       model.TypeCard,
+		Page:            page,
+		PerPage:         perPage,
+		ExcludeArchived: !includeArchived,
 	}
 
 	blocks, err := a.store.GetBlocks(opts)
@@ -94,3 +98,207 @@ func (a *App) GetCardByID(cardID string) (*model.Card, error) {
 
 	return card, nil
 }
+
+// ArchiveCard archives a card. Archived cards are hidden from card listings
+// but, unlike deleted cards, are never purged.
+func (a *App) ArchiveCard(cardID string, userID string) (*model.Card, error) {
+	blockPatch := &model.BlockPatch{
+		UpdatedFields: map[string]any{"archivedAt": utils.GetMillis()},
+	}
+	return a.setCardArchived(cardID, blockPatch, true, userID)
+}
+
+// UnarchiveCard restores an archived card.
+func (a *App) UnarchiveCard(cardID string, userID string) (*model.Card, error) {
+	blockPatch := &model.BlockPatch{
+		DeletedFields: []string{"archivedAt"},
+	}
+	return a.setCardArchived(cardID, blockPatch, false, userID)
+}
+
+func (a *App) setCardArchived(cardID string, blockPatch *model.BlockPatch, archived bool, userID string) (*model.Card, error) {
+	cardBlock, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	card, err := model.Block2Card(cardBlock)
+	if err != nil {
+		return nil, model.NewErrBadRequest("block is not a card")
+	}
+
+	if (card.ArchivedAt != 0) == archived {
+		return card, nil
+	}
+
+	newBlock, err := a.PatchBlockAndNotify(cardID, blockPatch, userID, false)
+	if err != nil {
+		return nil, fmt.Errorf("cannot update archived state of card %s: %w", cardID, err)
+	}
+
+	return model.Block2Card(newBlock)
+}
+
+// FilterArchivedBlocks removes the archived cards of a board from a list of
+// its blocks, along with the blocks that belong to them.
+func (a *App) FilterArchivedBlocks(boardID string, blocks []*model.Block) ([]*model.Block, error) {
+	if len(blocks) == 0 {
+		return blocks, nil
+	}
+
+	cards, err := a.store.GetBlocks(model.QueryBlocksOptions{
+		BoardID:   boardID,
+		BlockType: model.TypeCard,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	archivedCardIDs := map[string]bool{}
+	for _, card := range cards {
+		if archivedAt, _ := model.BlockArchivedAt(card); archivedAt != 0 {
+			archivedCardIDs[card.ID] = true
+		}
+	}
+	return model.FilterHiddenCardBlocks(blocks, archivedCardIDs), nil
+}
+
+// MoveCard moves a card with its content blocks, comments and attachments to
+// another board. Property values are remapped to the target board's property
+// schema and attachment files are copied to the target board.
+func (a *App) MoveCard(cardID string, request *model.MoveCardRequest, userID string) (*model.Card, error) {
+	if err := request.IsValid(); err != nil {
+		return nil, err
+	}
+
+	cardBlock, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+	if cardBlock.Type != model.TypeCard {
+		return nil, model.NewErrBadRequest("block is not a card")
+	}
+	if cardBlock.BoardID == request.TargetBoardID {
+		return nil, model.NewErrBadRequest("card already belongs to the target board")
+	}
+
+	sourceBoard, err := a.store.GetBoard(cardBlock.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	targetBoard, err := a.store.GetBoard(request.TargetBoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = a.checkBlocksNotLocked(sourceBoard, cardBlock); err != nil {
+		return nil, err
+	}
+	if targetBoard.IsLocked {
+		return nil, model.ErrBoardIsLocked
+	}
+
+	sourceSchema, err := model.ParsePropertySchema(sourceBoard)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse properties of board %s: %w", sourceBoard.ID, err)
+	}
+	targetSchema, err := model.ParsePropertySchema(targetBoard)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse properties of board %s: %w", targetBoard.ID, err)
+	}
+
+	blocks, movedCard, err := a.prepareCardMove(cardBlock, sourceBoard, targetBoard, sourceSchema, targetSchema, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = a.store.MoveBlocks(blocks, userID); err != nil {
+		return nil, fmt.Errorf("cannot move card %s: %w", cardID, err)
+	}
+
+	a.updateMovedCardRecurrence(cardID, targetBoard.ID)
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		for _, block := range blocks {
+			a.wsAdapter.BroadcastBlockDelete(sourceBoard.TeamID, block.ID, sourceBoard.ID)
+			a.wsAdapter.BroadcastBlockChange(targetBoard.TeamID, block)
+		}
+		a.webhook.NotifyUpdate(movedCard)
+		a.notifyBlockChanged(notify.Update, movedCard, cardBlock, userID)
+		return nil
+	})
+
+	return model.Block2Card(movedCard)
+}
+
+// prepareCardMove loads a card's subtree and updates it in memory for the
+// target board. The returned blocks are ready to be saved with MoveBlocks.
+func (a *App) prepareCardMove(cardBlock *model.Block, sourceBoard, targetBoard *model.Board, sourceSchema, targetSchema model.PropSchema, request *model.MoveCardRequest) ([]*model.Block, *model.Block, error) {
+	blocks, err := a.store.GetSubTree2(sourceBoard.ID, cardBlock.ID, model.QuerySubtreeOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var movedCard *model.Block
+	childIDs := make(map[string]bool)
+	for _, block := range blocks {
+		block.BoardID = targetBoard.ID
+		if block.ID == cardBlock.ID {
+			movedCard = block
+		} else {
+			childIDs[block.ID] = true
+		}
+	}
+	if movedCard == nil {
+		return nil, nil, model.NewErrNotFound("card ID=" + cardBlock.ID)
+	}
+
+	movedCard.ParentID = targetBoard.ID
+	if movedCard.Fields == nil {
+		movedCard.Fields = make(map[string]interface{})
+	}
+	properties, _ := movedCard.Fields["properties"].(map[string]interface{})
+	movedCard.Fields["properties"] = model.RemapCardProperties(properties, sourceSchema, targetSchema, request)
+	if contentOrder, ok := movedCard.Fields["contentOrder"].([]interface{}); ok {
+		movedCard.Fields["contentOrder"] = model.FilterContentOrder(contentOrder, childIDs)
+	}
+
+	// the moved card can't point to files that only exist on the source
+	// board, so the move is aborted if they can't be copied
+	newFileNames, err := a.CopyCardFiles(sourceBoard.ID, blocks, targetBoard.IsTemplate)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not copy the files of card %s: %w", cardBlock.ID, err)
+	}
+	replaceCopiedFileIDs(blocks, newFileNames)
+
+	return blocks, movedCard, nil
+}
+
+// replaceCopiedFileIDs points the image and attachment blocks to the file
+// copies returned by CopyCardFiles.
+func replaceCopiedFileIDs(blocks []*model.Block, newFileNames map[string]string) {
+	for _, block := range blocks {
+		if block.Type != model.TypeImage && block.Type != model.TypeAttachment {
+			continue
+		}
+		fileID, ok := block.Fields["fileId"].(string)
+		if !ok {
+			fileID, _ = block.Fields["attachmentId"].(string)
+		}
+		if newFileName, ok := newFileNames[fileID]; ok {
+			block.Fields["fileId"] = newFileName
+			delete(block.Fields, "attachmentId")
+		}
+	}
+}
+
+func (a *App) updateMovedCardRecurrence(cardID string, boardID string) {
+	recurrence, err := a.store.GetCardRecurrence(cardID)
+	if err != nil {
+		return
+	}
+	recurrence.BoardID = boardID
+	if _, err = a.store.UpsertCardRecurrence(recurrence); err != nil {
+		a.logger.Error("Could not update recurrence of moved card", mlog.String("cardID", cardID), mlog.Err(err))
+	}
+}
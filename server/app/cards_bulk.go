@@ -0,0 +1,449 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// BulkUpdateCards applies one operation to a list of cards of a board, or to
+// the cards of the board matching a filter. Cards that can't be changed are
+// reported in the results. The others are changed in a single transaction,
+// so either all of them are changed or an error is returned.
+func (a *App) BulkUpdateCards(boardID string, request *model.BulkCardRequest, userID string) ([]*model.BulkCardResult, error) {
+	if err := request.IsValid(); err != nil {
+		return nil, err
+	}
+
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if board.IsLocked {
+		return nil, model.ErrBoardIsLocked
+	}
+
+	cards, results, err := a.getCardsForBulkRequest(board, request, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(cards) == 0 {
+		return results, nil
+	}
+
+	snapshotName := fmt.Sprintf("Before bulk %s of %d cards", request.Operation, len(cards))
+	if err = a.createAutomaticBoardSnapshot(board.ID, snapshotName, userID); err != nil {
+		return nil, err
+	}
+
+	resultsByID := make(map[string]*model.BulkCardResult, len(results))
+	for _, result := range results {
+		resultsByID[result.CardID] = result
+	}
+
+	switch request.Operation {
+	case model.BulkCardOperationSetProperty, model.BulkCardOperationAddPerson,
+		model.BulkCardOperationRemovePerson, model.BulkCardOperationArchive:
+		err = a.bulkPatchCards(board, cards, request, userID)
+	case model.BulkCardOperationDelete:
+		err = a.bulkDeleteCards(board, cards, userID)
+	case model.BulkCardOperationMove:
+		err = a.bulkMoveCards(board, cards, request.Move, userID)
+	case model.BulkCardOperationDuplicate:
+		err = a.bulkDuplicateCards(board, cards, resultsByID, userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, card := range cards {
+		resultsByID[card.ID].Success = true
+	}
+	return results, nil
+}
+
+// getCardsForBulkRequest returns the cards a bulk request applies to, along
+// with a result for each of them. Requested cards that can't be changed get
+// a failed result and are not returned. The restricted cards the user can't
+// see are never matched by a filter, and are reported as not found when
+// requested by ID.
+func (a *App) getCardsForBulkRequest(board *model.Board, request *model.BulkCardRequest, userID string) ([]*model.Block, []*model.BulkCardResult, error) {
+	if request.Filter != nil {
+		opts := model.QueryBlocksOptions{
+			BoardID:         board.ID,
+			BlockType:       model.TypeCard,
+			ExcludeArchived: !request.Filter.IncludeArchived,
+		}
+		blocks, err := a.store.GetBlocks(opts)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		cards := make([]*model.Block, 0, len(blocks))
+		results := make([]*model.BulkCardResult, 0, len(blocks))
+		for _, block := range blocks {
+			if !request.Filter.Matches(block) || !a.CanSeeCard(userID, board, block) {
+				continue
+			}
+			result := &model.BulkCardResult{CardID: block.ID}
+			results = append(results, result)
+			if isLockedForBulkRequest(block, request) {
+				result.Error = "card is locked"
+				continue
+			}
+			cards = append(cards, block)
+		}
+		if len(cards) > model.MaxBulkCards {
+			return nil, nil, model.NewErrBadRequest(fmt.Sprintf("filter matches more than %d cards", model.MaxBulkCards))
+		}
+		return cards, results, nil
+	}
+
+	cardIDs := make([]string, 0, len(request.CardIDs))
+	seen := make(map[string]bool, len(request.CardIDs))
+	for _, cardID := range request.CardIDs {
+		if !seen[cardID] {
+			seen[cardID] = true
+			cardIDs = append(cardIDs, cardID)
+		}
+	}
+	blocks, err := a.store.GetBlocksByIDs(cardIDs)
+	if err != nil && !model.IsErrNotFound(err) {
+		return nil, nil, err
+	}
+
+	blocksByID := make(map[string]*model.Block, len(blocks))
+	for _, block := range blocks {
+		blocksByID[block.ID] = block
+	}
+
+	cards := make([]*model.Block, 0, len(blocks))
+	results := make([]*model.BulkCardResult, 0, len(cardIDs))
+	for _, cardID := range cardIDs {
+		result := &model.BulkCardResult{CardID: cardID}
+		results = append(results, result)
+
+		block, ok := blocksByID[cardID]
+		switch {
+		case !ok:
+			result.Error = "card not found"
+		case block.BoardID != board.ID:
+			result.Error = "card does not belong to the board"
+		case block.Type != model.TypeCard:
+			result.Error = "block is not a card"
+		case !a.CanSeeCard(userID, board, block):
+			result.Error = "card not found"
+		case isLockedForBulkRequest(block, request):
+			result.Error = "card is locked"
+		default:
+			cards = append(cards, block)
+		}
+	}
+	return cards, results, nil
+}
+
+// isLockedForBulkRequest returns true if the card is locked and the bulk
+// operation would change it. Locked cards can still be duplicated.
+func isLockedForBulkRequest(card *model.Block, request *model.BulkCardRequest) bool {
+	return request.Operation != model.BulkCardOperationDuplicate && model.BlockIsLocked(card)
+}
+
+func (a *App) bulkPatchCards(board *model.Board, cards []*model.Block, request *model.BulkCardRequest, userID string) error {
+	var propType string
+	if request.Operation != model.BulkCardOperationArchive {
+		schema, err := model.ParsePropertySchema(board)
+		if err != nil {
+			return fmt.Errorf("cannot parse properties of board %s: %w", board.ID, err)
+		}
+		propDef, ok := schema[request.PropertyID]
+		if !ok {
+			return model.NewErrBadRequest("property not found: " + request.PropertyID)
+		}
+		if request.Operation != model.BulkCardOperationSetProperty && propDef.Type != "person" && propDef.Type != "multiPerson" {
+			return model.NewErrBadRequest("property is not a person property: " + request.PropertyID)
+		}
+		propType = propDef.Type
+	}
+
+	now := utils.GetMillis()
+	oldBlocks := make(map[string]*model.Block)
+	assignedCards := make([]*model.Block, 0)
+	patches := &model.BlockPatchBatch{}
+	for _, card := range cards {
+		var patch *model.BlockPatch
+		if request.Operation == model.BulkCardOperationArchive {
+			if archivedAt, _ := model.BlockArchivedAt(card); archivedAt == 0 {
+				patch = &model.BlockPatch{UpdatedFields: map[string]any{"archivedAt": now}}
+			}
+		} else {
+			properties, changed, assigned := bulkPatchProperties(card, propType, request)
+			if changed {
+				patch = &model.BlockPatch{UpdatedFields: map[string]any{"properties": properties}}
+			}
+			if assigned {
+				assignedCards = append(assignedCards, card)
+			}
+		}
+
+		if patch != nil {
+			oldBlocks[card.ID] = card
+			patches.BlockIDs = append(patches.BlockIDs, card.ID)
+			patches.BlockPatches = append(patches.BlockPatches, *patch)
+		}
+	}
+
+	if len(patches.BlockIDs) == 0 {
+		return nil
+	}
+
+	if err := a.store.PatchBlocks(patches, userID); err != nil {
+		return err
+	}
+
+	newBlocks, err := a.store.GetBlocksByIDs(patches.BlockIDs)
+	if err != nil {
+		return err
+	}
+
+	if len(assignedCards) != 0 && request.UserID != userID {
+		if assignedBy, err := a.store.GetUserByID(userID); err == nil {
+			for _, card := range assignedCards {
+				cardTitle := card.Title
+				if cardTitle == "" {
+					cardTitle = "Untitled"
+				}
+				if err := a.CreateCardAssignmentNotification(assignedBy, request.UserID, board.ID, card.ID, cardTitle); err != nil {
+					a.logger.Error("Could not create assignment notification", mlog.String("cardID", card.ID), mlog.Err(err))
+				}
+			}
+		}
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.metrics.IncrementBlocksPatched(len(newBlocks))
+		a.wsAdapter.BroadcastBlockChanges(board.TeamID, newBlocks)
+		for _, block := range newBlocks {
+			a.webhook.NotifyUpdate(block)
+			a.notifyBlockChanged(notify.Update, block, oldBlocks[block.ID], userID)
+		}
+		return nil
+	})
+	return nil
+}
+
+// bulkPatchProperties returns the card's properties changed by a property or
+// person operation, whether they changed, and whether the user of a person
+// operation was newly assigned to the card.
+func bulkPatchProperties(card *model.Block, propType string, request *model.BulkCardRequest) (map[string]any, bool, bool) {
+	properties := make(map[string]any)
+	if current, ok := card.Fields["properties"].(map[string]any); ok {
+		for k, v := range current {
+			properties[k] = v
+		}
+	}
+
+	current, hasValue := properties[request.PropertyID]
+
+	switch request.Operation {
+	case model.BulkCardOperationSetProperty:
+		if request.Value == nil {
+			delete(properties, request.PropertyID)
+			return properties, hasValue, false
+		}
+		properties[request.PropertyID] = request.Value
+		return properties, true, false
+
+	case model.BulkCardOperationAddPerson:
+		if values, ok := current.([]any); ok {
+			for _, v := range values {
+				if v == request.UserID {
+					return properties, false, false
+				}
+			}
+			properties[request.PropertyID] = append(values, request.UserID)
+			return properties, true, true
+		}
+		if current == request.UserID {
+			return properties, false, false
+		}
+		if propType == "multiPerson" {
+			properties[request.PropertyID] = []any{request.UserID}
+		} else {
+			properties[request.PropertyID] = request.UserID
+		}
+		return properties, true, true
+
+	case model.BulkCardOperationRemovePerson:
+		if values, ok := current.([]any); ok {
+			remaining := make([]any, 0, len(values))
+			for _, v := range values {
+				if v != request.UserID {
+					remaining = append(remaining, v)
+				}
+			}
+			properties[request.PropertyID] = remaining
+			return properties, len(remaining) != len(values), false
+		}
+		if current != request.UserID {
+			return properties, false, false
+		}
+		delete(properties, request.PropertyID)
+		return properties, true, false
+	}
+
+	return properties, false, false
+}
+
+func (a *App) bulkDeleteCards(board *model.Board, cards []*model.Block, userID string) error {
+	cardIDs := make([]string, 0, len(cards))
+	for _, card := range cards {
+		cardIDs = append(cardIDs, card.ID)
+	}
+
+	if err := a.store.DeleteBlocks(cardIDs, userID); err != nil {
+		return err
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.metrics.IncrementBlocksDeleted(len(cards))
+		a.wsAdapter.BroadcastBlockChanges(board.TeamID, deletedBlocks(cards))
+		for _, card := range cards {
+			a.notifyBlockChanged(notify.Delete, card, card, userID)
+		}
+		return nil
+	})
+	return nil
+}
+
+func (a *App) bulkMoveCards(sourceBoard *model.Board, cards []*model.Block, request *model.MoveCardRequest, userID string) error {
+	if request.TargetBoardID == sourceBoard.ID {
+		return model.NewErrBadRequest("cards already belong to the target board")
+	}
+
+	targetBoard, err := a.store.GetBoard(request.TargetBoardID)
+	if err != nil {
+		return err
+	}
+	if targetBoard.IsLocked {
+		return model.ErrBoardIsLocked
+	}
+
+	sourceSchema, err := model.ParsePropertySchema(sourceBoard)
+	if err != nil {
+		return fmt.Errorf("cannot parse properties of board %s: %w", sourceBoard.ID, err)
+	}
+	targetSchema, err := model.ParsePropertySchema(targetBoard)
+	if err != nil {
+		return fmt.Errorf("cannot parse properties of board %s: %w", targetBoard.ID, err)
+	}
+
+	allBlocks := make([]*model.Block, 0, len(cards))
+	movedCards := make([]*model.Block, 0, len(cards))
+	for _, card := range cards {
+		blocks, movedCard, err := a.prepareCardMove(card, sourceBoard, targetBoard, sourceSchema, targetSchema, request)
+		if err != nil {
+			return err
+		}
+		allBlocks = append(allBlocks, blocks...)
+		movedCards = append(movedCards, movedCard)
+	}
+
+	if err := a.store.MoveBlocks(allBlocks, userID); err != nil {
+		return err
+	}
+
+	for _, card := range cards {
+		a.updateMovedCardRecurrence(card.ID, targetBoard.ID)
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		sourceBlocks := make([]*model.Block, 0, len(allBlocks))
+		for _, block := range allBlocks {
+			sourceBlocks = append(sourceBlocks, &model.Block{ID: block.ID, BoardID: sourceBoard.ID})
+		}
+		a.wsAdapter.BroadcastBlockChanges(sourceBoard.TeamID, deletedBlocks(sourceBlocks))
+		a.wsAdapter.BroadcastBlockChanges(targetBoard.TeamID, allBlocks)
+		for i, movedCard := range movedCards {
+			a.webhook.NotifyUpdate(movedCard)
+			a.notifyBlockChanged(notify.Update, movedCard, cards[i], userID)
+		}
+		return nil
+	})
+	return nil
+}
+
+func (a *App) bulkDuplicateCards(board *model.Board, cards []*model.Block, results map[string]*model.BulkCardResult, userID string) error {
+	allBlocks := make([]*model.Block, 0, len(cards))
+	for _, card := range cards {
+		subtree, err := a.store.GetSubTree2(board.ID, card.ID, model.QuerySubtreeOptions{})
+		if err != nil {
+			return err
+		}
+
+		// the card goes first so that it can be found after the ids are
+		// regenerated, comments are not duplicated
+		var root *model.Block
+		children := make([]*model.Block, 0, len(subtree))
+		for _, block := range subtree {
+			switch {
+			case block.ID == card.ID:
+				root = block
+			case block.Type != model.TypeComment:
+				children = append(children, block)
+			}
+		}
+		if root == nil {
+			return model.NewErrNotFound("card ID=" + card.ID)
+		}
+		blocks := append([]*model.Block{root}, children...)
+
+		blocks = model.GenerateBlockIDs(blocks, a.logger)
+
+		newFileNames, err := a.CopyCardFiles(board.ID, blocks, board.IsTemplate)
+		if err != nil {
+			a.logger.Error("Could not copy files while duplicating card", mlog.String("cardID", card.ID), mlog.Err(err))
+		}
+		replaceCopiedFileIDs(blocks, newFileNames)
+
+		results[card.ID].NewCardID = blocks[0].ID
+		allBlocks = append(allBlocks, blocks...)
+	}
+
+	if err := a.store.InsertBlocks(allBlocks, userID); err != nil {
+		return err
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.metrics.IncrementBlocksInserted(len(allBlocks))
+		a.wsAdapter.BroadcastBlockChanges(board.TeamID, allBlocks)
+		for _, block := range allBlocks {
+			a.webhook.NotifyUpdate(block)
+			a.notifyBlockChanged(notify.Add, block, nil, userID)
+		}
+		return nil
+	})
+	return nil
+}
+
+// deletedBlocks returns the deletion of the blocks as it is broadcast to the
+// clients, with only their IDs, boards and deletion time.
+func deletedBlocks(blocks []*model.Block) []*model.Block {
+	now := utils.GetMillis()
+	deleted := make([]*model.Block, 0, len(blocks))
+	for _, block := range blocks {
+		deleted = append(deleted, &model.Block{
+			ID:       block.ID,
+			BoardID:  block.BoardID,
+			UpdateAt: now,
+			DeleteAt: now,
+		})
+	}
+	return deleted
+}
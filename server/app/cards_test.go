@@ -83,6 +83,20 @@ func TestGetCards(t *testing.T) {
 	}
 
 	t.Run("success scenario", func(t *testing.T) {
+		opts := model.QueryBlocksOptions{
+			BoardID:         board.ID,
+			BlockType:       model.TypeCard,
+			ExcludeArchived: true,
+		}
+
+		th.Store.EXPECT().GetBlocks(opts).Return(blocks, nil)
+
+		cards, err := th.App.GetCardsForBoard(board.ID, 0, 0, false)
+		require.NoError(t, err)
+		assert.Len(t, cards, cardCount)
+	})
+
+	t.Run("include archived", func(t *testing.T) {
 		opts := model.QueryBlocksOptions{
 			BoardID:   board.ID,
 			BlockType: model.TypeCard,
@@ -90,20 +104,21 @@ func TestGetCards(t *testing.T) {
 
 		th.Store.EXPECT().GetBlocks(opts).Return(blocks, nil)
 
-		cards, err := th.App.GetCardsForBoard(board.ID, 0, 0)
+		cards, err := th.App.GetCardsForBoard(board.ID, 0, 0, true)
 		require.NoError(t, err)
 		assert.Len(t, cards, cardCount)
 	})
 
 	t.Run("error scenario", func(t *testing.T) {
 		opts := model.QueryBlocksOptions{
-			BoardID:   board.ID,
-			BlockType: model.TypeCard,
+			BoardID:         board.ID,
+			BlockType:       model.TypeCard,
+			ExcludeArchived: true,
 		}
 
 		th.Store.EXPECT().GetBlocks(opts).Return(nil, blockError{"error"})
 
-		cards, err := th.App.GetCardsForBoard(board.ID, 0, 0)
+		cards, err := th.App.GetCardsForBoard(board.ID, 0, 0, false)
 		require.Error(t, err)
 		require.Nil(t, cards)
 	})
@@ -264,3 +279,38 @@ func modifyProps(m map[string]any) map[string]any {
 	}
 	return out
 }
+
+func TestMoveCard(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	userID := utils.NewID(utils.IDTypeUser)
+	sourceBoard := &model.Board{ID: utils.NewID(utils.IDTypeBoard), TeamID: "team-id"}
+	targetBoard := &model.Board{ID: utils.NewID(utils.IDTypeBoard), TeamID: "team-id"}
+	card := &model.Block{
+		ID:       utils.NewID(utils.IDTypeCard),
+		BoardID:  sourceBoard.ID,
+		ParentID: sourceBoard.ID,
+		Type:     model.TypeCard,
+		Fields:   map[string]interface{}{},
+	}
+	image := &model.Block{
+		ID:       utils.NewID(utils.IDTypeBlock),
+		BoardID:  sourceBoard.ID,
+		ParentID: card.ID,
+		Type:     model.TypeImage,
+		Fields:   map[string]interface{}{"fileId": "7file.png"},
+	}
+
+	t.Run("the move is aborted if the files can't be copied", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(card.ID).Return(card, nil)
+		th.Store.EXPECT().GetBoard(sourceBoard.ID).Return(sourceBoard, nil)
+		th.Store.EXPECT().GetBoard(targetBoard.ID).Return(targetBoard, nil)
+		th.Store.EXPECT().GetSubTree2(sourceBoard.ID, card.ID, gomock.Any()).Return([]*model.Block{card, image}, nil)
+		th.Store.EXPECT().GetBoard(sourceBoard.ID).Return(nil, model.NewErrNotFound("board"))
+
+		movedCard, err := th.App.MoveCard(card.ID, &model.MoveCardRequest{TargetBoardID: targetBoard.ID}, userID)
+		require.Error(t, err)
+		require.Nil(t, movedCard)
+	})
+}
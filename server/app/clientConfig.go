@@ -12,5 +12,8 @@ func (a *App) GetClientConfig() *model.ClientConfig {
 		TeammateNameDisplay:      a.config.TeammateNameDisplay,
 		FeatureFlags:             a.config.FeatureFlags,
 		MaxFileSize:              a.config.MaxFileSize,
+		RequireMfa:               a.config.RequireMfa,
+		EnableOidc:               a.config.Oidc.Enable,
+		OidcDisplayName:          oidcDisplayName(a.config),
 	}
 }
@@ -0,0 +1,67 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func (a *App) GetCustomBoardRolesForTeam(teamID string) ([]*model.CustomBoardRole, error) {
+	return a.store.GetCustomBoardRolesForTeam(teamID)
+}
+
+// GetCustomBoardRole fetches a custom board role, making sure that it
+// belongs to the team.
+func (a *App) GetCustomBoardRole(teamID, roleID string) (*model.CustomBoardRole, error) {
+	role, err := a.store.GetCustomBoardRole(roleID)
+	if err != nil {
+		return nil, err
+	}
+	if role.TeamID != teamID {
+		return nil, model.NewErrNotFound("custom board role ID=" + roleID)
+	}
+	return role, nil
+}
+
+func (a *App) CreateCustomBoardRole(role *model.CustomBoardRole, userID string) (*model.CustomBoardRole, error) {
+	role.ID = ""
+	role.CreatedBy = userID
+	return a.store.CreateCustomBoardRole(role)
+}
+
+func (a *App) PatchCustomBoardRole(teamID, roleID string, patch *model.CustomBoardRolePatch) (*model.CustomBoardRole, error) {
+	role, err := a.GetCustomBoardRole(teamID, roleID)
+	if err != nil {
+		return nil, err
+	}
+	return a.store.UpdateCustomBoardRole(patch.Patch(role))
+}
+
+// DeleteCustomBoardRole deletes a custom board role. The board members that
+// had the role keep their scheme role only.
+func (a *App) DeleteCustomBoardRole(teamID, roleID string) error {
+	if _, err := a.GetCustomBoardRole(teamID, roleID); err != nil {
+		return err
+	}
+	return a.store.DeleteCustomBoardRole(roleID)
+}
+
+// checkMemberCustomRole makes sure that the custom role assigned to a board
+// member, if any, is a role of the board team.
+func (a *App) checkMemberCustomRole(board *model.Board, member *model.BoardMember) error {
+	if member.CustomRoleID == "" {
+		return nil
+	}
+	role, err := a.store.GetCustomBoardRole(member.CustomRoleID)
+	if model.IsErrNotFound(err) {
+		return model.NewErrBadRequest("invalid custom board role: " + member.CustomRoleID)
+	}
+	if err != nil {
+		return err
+	}
+	if role.TeamID != board.TeamID {
+		return model.NewErrBadRequest("the custom board role doesn't belong to the board team")
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCustomBoardRole(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	role := &model.CustomBoardRole{ID: "role_id", TeamID: "team_id"}
+
+	t.Run("role of the team", func(t *testing.T) {
+		th.Store.EXPECT().GetCustomBoardRole(role.ID).Return(role, nil)
+
+		fetched, err := th.App.GetCustomBoardRole("team_id", role.ID)
+		require.NoError(t, err)
+		require.Equal(t, role, fetched)
+	})
+
+	t.Run("role of another team", func(t *testing.T) {
+		th.Store.EXPECT().GetCustomBoardRole(role.ID).Return(role, nil)
+
+		fetched, err := th.App.GetCustomBoardRole("other_team_id", role.ID)
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, fetched)
+	})
+}
+
+func TestPatchCustomBoardRole(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	role := &model.CustomBoardRole{
+		ID:          "role_id",
+		TeamID:      "team_id",
+		Name:        "card editor",
+		Permissions: []string{model.PermissionManageBoardCards.Id},
+	}
+	th.Store.EXPECT().GetCustomBoardRole(role.ID).Return(role, nil)
+	th.Store.EXPECT().UpdateCustomBoardRole(&model.CustomBoardRole{
+		ID:          "role_id",
+		TeamID:      "team_id",
+		Name:        "card editor",
+		Permissions: []string{model.PermissionCommentBoardCards.Id},
+	}).Return(role, nil)
+
+	patch := &model.CustomBoardRolePatch{Permissions: []string{model.PermissionCommentBoardCards.Id}}
+	_, err := th.App.PatchCustomBoardRole("team_id", role.ID, patch)
+	require.NoError(t, err)
+}
+
+func TestDeleteCustomBoardRole(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	role := &model.CustomBoardRole{ID: "role_id", TeamID: "team_id"}
+
+	t.Run("role of the team", func(t *testing.T) {
+		th.Store.EXPECT().GetCustomBoardRole(role.ID).Return(role, nil)
+		th.Store.EXPECT().DeleteCustomBoardRole(role.ID).Return(nil)
+
+		require.NoError(t, th.App.DeleteCustomBoardRole("team_id", role.ID))
+	})
+
+	t.Run("role of another team", func(t *testing.T) {
+		th.Store.EXPECT().GetCustomBoardRole(role.ID).Return(role, nil)
+
+		require.True(t, model.IsErrNotFound(th.App.DeleteCustomBoardRole("other_team_id", role.ID)))
+	})
+}
+
+func TestUpdateBoardMemberCustomRole(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{ID: "board_id", TeamID: "team_id"}
+	oldMember := &model.BoardMember{BoardID: board.ID, UserID: "user_id", SchemeCommenter: true}
+
+	t.Run("role of another team", func(t *testing.T) {
+		member := &model.BoardMember{BoardID: board.ID, UserID: "user_id", SchemeCommenter: true, CustomRoleID: "role_id"}
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().GetMemberForBoard(board.ID, "user_id").Return(oldMember, nil)
+		th.Store.EXPECT().GetCustomBoardRole("role_id").Return(&model.CustomBoardRole{ID: "role_id", TeamID: "other_team_id"}, nil)
+
+		updated, err := th.App.UpdateBoardMember(member)
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, updated)
+	})
+
+	t.Run("not existing role", func(t *testing.T) {
+		member := &model.BoardMember{BoardID: board.ID, UserID: "user_id", SchemeCommenter: true, CustomRoleID: "role_id"}
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().GetMemberForBoard(board.ID, "user_id").Return(oldMember, nil)
+		th.Store.EXPECT().GetCustomBoardRole("role_id").Return(nil, model.NewErrNotFound("role_id"))
+
+		updated, err := th.App.UpdateBoardMember(member)
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, updated)
+	})
+
+	t.Run("role of the board team", func(t *testing.T) {
+		member := &model.BoardMember{BoardID: board.ID, UserID: "user_id", SchemeCommenter: true, CustomRoleID: "role_id"}
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().GetMemberForBoard(board.ID, "user_id").Return(oldMember, nil)
+		th.Store.EXPECT().GetCustomBoardRole("role_id").Return(&model.CustomBoardRole{ID: "role_id", TeamID: board.TeamID}, nil)
+		th.Store.EXPECT().SaveMember(member).Return(member, nil)
+		// for WS change broadcast
+		th.Store.EXPECT().GetMembersForBoard(board.ID).Return([]*model.BoardMember{}, nil).AnyTimes()
+
+		updated, err := th.App.UpdateBoardMember(member)
+		require.NoError(t, err)
+		require.Equal(t, "role_id", updated.CustomRoleID)
+	})
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"strings"
+
+	"github.com/mattermost/focalboard/server/services/email"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// isEmailConfigured tells if the server can send emails.
+func (a *App) isEmailConfigured() bool {
+	return email.IsConfigured(a.config.SMTP)
+}
+
+// serverLink returns the absolute URL of a path of the server.
+func (a *App) serverLink(path string) string {
+	return strings.TrimSuffix(a.config.ServerRoot, "/") + path
+}
+
+// sendEmailInBackground sends an email without waiting for the SMTP
+// server, so that the response time of the requests doesn't tell if an
+// address is registered. Failures are only logged.
+func (a *App) sendEmailInBackground(msg email.Message, userID string) {
+	sender := email.New(a.config.SMTP)
+	go func() {
+		if err := sender.Send(msg); err != nil {
+			a.logger.Error("Unable to send an email",
+				mlog.String("subject", msg.Subject),
+				mlog.String("userID", userID),
+				mlog.Err(err),
+			)
+		}
+	}()
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/email"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+
+	"github.com/pkg/errors"
+)
+
+const EmailVerificationPath = "/verify_email"
+
+var ErrEmailNotVerified = errors.New("the email address is not verified")
+
+// checkSignupEmailDomain makes sure that the email address belongs to one
+// of the domains allowed to sign up, when the signups are restricted.
+func (a *App) checkSignupEmailDomain(emailAddress string) error {
+	if len(a.config.AllowedSignupDomains) == 0 {
+		return nil
+	}
+
+	at := strings.LastIndex(emailAddress, "@")
+	if at < 0 {
+		return model.NewErrBadRequest("invalid email format")
+	}
+	domain := strings.ToLower(emailAddress[at+1:])
+	for _, allowed := range a.config.AllowedSignupDomains {
+		allowed = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(allowed), "@"))
+		if domain == allowed {
+			return nil
+		}
+	}
+	return model.NewErrPermission("the email domain is not allowed to sign up")
+}
+
+// SendEmailVerification emails a new verification link to the account with
+// the email address. Like for the password reset, no error tells if the
+// address is registered.
+func (a *App) SendEmailVerification(emailAddress string) error {
+	if !a.isEmailConfigured() {
+		return model.NewErrNotImplemented("the SMTP server is not configured")
+	}
+
+	user, err := a.store.GetUserByEmail(emailAddress)
+	if model.IsErrNotFound(err) {
+		a.logger.Debug("Email verification requested for an unknown email address")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if user.EmailVerified {
+		return nil
+	}
+	return a.sendEmailVerification(user)
+}
+
+// sendEmailVerification replaces the pending verification links of the
+// user with a new one, and emails it.
+func (a *App) sendEmailVerification(user *model.User) error {
+	if err := a.store.DeleteEmailVerificationTokensForUser(user.ID); err != nil {
+		return err
+	}
+
+	rawToken := utils.NewID(utils.IDTypeToken)
+	_, err := a.store.CreateEmailVerificationToken(&model.EmailVerificationToken{
+		UserID:    user.ID,
+		Email:     user.Email,
+		TokenHash: model.HashToken(rawToken),
+		ExpireAt:  utils.GetMillis() + model.EmailVerificationExpiry.Milliseconds(),
+	})
+	if err != nil {
+		return err
+	}
+
+	link := a.serverLink(EmailVerificationPath + "?token=" + url.QueryEscape(rawToken))
+	a.sendEmailInBackground(email.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Welcome %s!\n\n"+
+			"Open the link below to verify your email address and activate your account, it expires in %d hours:\n\n%s\n",
+			user.Username, int(model.EmailVerificationExpiry.Hours()), link),
+	}, user.ID)
+	return nil
+}
+
+// VerifyEmail marks the email address of a user as verified with the token
+// of a verification email. The token can only be used once.
+func (a *App) VerifyEmail(token string) error {
+	verificationToken, err := a.store.ConsumeEmailVerificationToken(model.HashToken(token))
+	if model.IsErrNotFound(err) {
+		return model.NewErrUnauthorized("invalid or expired email verification token")
+	}
+	if err != nil {
+		return err
+	}
+	if verificationToken.IsExpired(utils.GetMillis()) {
+		return model.NewErrUnauthorized("invalid or expired email verification token")
+	}
+
+	user, err := a.store.GetUserByID(verificationToken.UserID)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(user.Email, verificationToken.Email) {
+		// the email address changed after the email was sent
+		return model.NewErrUnauthorized("invalid or expired email verification token")
+	}
+
+	if err = a.store.UpdateUserEmailVerified(user.ID, true); err != nil {
+		return err
+	}
+	if err = a.store.DeleteEmailVerificationTokensForUser(user.ID); err != nil {
+		a.logger.Warn("Unable to delete the email verification tokens", mlog.String("userID", user.ID), mlog.Err(err))
+	}
+	return nil
+}
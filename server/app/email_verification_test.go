@@ -0,0 +1,30 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckSignupEmailDomain(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("no restriction", func(t *testing.T) {
+		th.App.config.AllowedSignupDomains = nil
+		require.NoError(t, th.App.checkSignupEmailDomain("someone@anywhere.com"))
+	})
+
+	t.Run("allowed domains", func(t *testing.T) {
+		th.App.config.AllowedSignupDomains = []string{"example.com", " @Example.org "}
+		require.NoError(t, th.App.checkSignupEmailDomain("someone@example.com"))
+		require.NoError(t, th.App.checkSignupEmailDomain("someone@EXAMPLE.org"))
+		require.True(t, model.IsErrForbidden(th.App.checkSignupEmailDomain("someone@sub.example.com")))
+		require.True(t, model.IsErrForbidden(th.App.checkSignupEmailDomain("someone@example.com.evil.net")))
+		require.True(t, model.IsErrBadRequest(th.App.checkSignupEmailDomain("someone")))
+	})
+}
@@ -87,6 +87,18 @@ func (a *App) writeArchiveBoard(zw *zip.Writer, board model.Board, opt model.Exp
 		return err
 	}
 
+	blocks, err = filterArchivedCards(blocks, opt.ArchivedCards)
+	if err != nil {
+		return err
+	}
+
+	if opt.UserID != "" {
+		blocks, err = a.FilterRestrictedBlocks(opt.UserID, &board, blocks)
+		if err != nil {
+			return err
+		}
+	}
+
 	for _, block := range blocks {
 		if err = a.writeArchiveBlockLine(w, block); err != nil {
 			return err
@@ -120,6 +132,37 @@ func (a *App) writeArchiveBoard(zw *zip.Writer, board model.Board, opt model.Exp
 	return nil
 }
 
+// filterArchivedCards removes the cards that don't pass the archived cards
+// filter, along with the blocks that belong to them.
+func filterArchivedCards(blocks []*model.Block, filter model.ArchivedCardsFilter) ([]*model.Block, error) {
+	skipped := make(map[string]bool)
+	for _, block := range blocks {
+		if block.Type != model.TypeCard {
+			continue
+		}
+		archivedAt, err := model.BlockArchivedAt(block)
+		if err != nil {
+			return nil, err
+		}
+		if !filter.Matches(archivedAt != 0) {
+			skipped[block.ID] = true
+		}
+	}
+
+	if len(skipped) == 0 {
+		return blocks, nil
+	}
+
+	filtered := make([]*model.Block, 0, len(blocks)-len(skipped))
+	for _, block := range blocks {
+		if skipped[block.ID] || skipped[block.ParentID] {
+			continue
+		}
+		filtered = append(filtered, block)
+	}
+	return filtered, nil
+}
+
 // writeArchiveBoardMemberLine writes a single boardMember to the archive.
 func (a *App) writeArchiveBoardMemberLine(w io.Writer, boardMember *model.BoardMember) error {
 	bm, err := json.Marshal(&boardMember)
@@ -198,6 +198,9 @@ func TestApp_ImportArchive(t *testing.T) {
 		}
 		th.Store.EXPECT().GetBlocks(opts).Return([]*model.Block{imageBlock, attachmentBlock}, nil)
 		th.Store.EXPECT().GetBlocksByIDs(blockIDs).Return([]*model.Block{imageBlock, attachmentBlock}, nil)
+		th.Store.EXPECT().GetBoard("board-id").Return(&model.Board{ID: "board-id"}, nil)
+		// the card is fetched for the lock check and for the WS change broadcast
+		th.Store.EXPECT().GetBlock("c3zqnh6fsu3f4mr6hzq9hizwske").Return(&model.Block{ID: "c3zqnh6fsu3f4mr6hzq9hizwske", Type: model.TypeCard}, nil).AnyTimes()
 		th.Store.EXPECT().GetBlock(blockIDs[0]).Return(imageBlock, nil)
 		th.Store.EXPECT().GetBlock(blockIDs[1]).Return(attachmentBlock, nil)
 		th.Store.EXPECT().GetMembersForBoard("board-id").AnyTimes().Return([]*model.BoardMember{}, nil)
@@ -0,0 +1,416 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"strings"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/services/ldap"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/pkg/errors"
+)
+
+// LdapSyncResult counts the changes of an LDAP synchronization.
+type LdapSyncResult struct {
+	Updated            int `json:"updated"`
+	Deactivated        int `json:"deactivated"`
+	Reactivated        int `json:"reactivated"`
+	MembershipsAdded   int `json:"membershipsAdded"`
+	MembershipsRemoved int `json:"membershipsRemoved"`
+}
+
+// ldapUser is a user provisioned from the directory with its entry.
+type ldapUser struct {
+	user  *model.User
+	entry *ldap.Entry
+}
+
+// ldapTarget is the team or the board of a group mapping.
+type ldapTarget struct {
+	teamID  string
+	boardID string
+}
+
+// loginWithLdap authenticates the user against the directory, and returns
+// the user linked to its entry, provisioning it on its first login.
+func (a *App) loginWithLdap(login, password string) (*model.User, error) {
+	client := ldap.New(a.config.Ldap)
+
+	entry, err := client.Authenticate(login, password)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := a.provisionLdapUser(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(a.config.Ldap.GroupMappings) > 0 {
+		groups, err := client.GetGroups()
+		if err != nil {
+			// the memberships are fixed by the next synchronization
+			a.logger.Warn("Unable to read the LDAP groups on login", mlog.String("userID", user.ID), mlog.Err(err))
+			return user, nil
+		}
+		a.syncLdapMemberships([]ldapUser{{user: user, entry: entry}}, groups, &LdapSyncResult{})
+	}
+
+	return user, nil
+}
+
+// provisionLdapUser returns the user linked to the directory entry,
+// creating it on its first login and updating its attributes afterwards.
+func (a *App) provisionLdapUser(entry *ldap.Entry) (*model.User, error) {
+	user, err := a.store.GetUserByAuthData(model.LdapAuthService, entry.ID)
+	if err != nil && !model.IsErrNotFound(err) {
+		return nil, err
+	}
+
+	if user != nil {
+		if user.DeleteAt != 0 {
+			return nil, model.NewErrUnauthorized("the account is deactivated")
+		}
+		user, _, err = a.updateLdapUser(user, entry)
+		return user, err
+	}
+
+	email := strings.ToLower(strings.TrimSpace(entry.Email))
+	if email == "" {
+		return nil, model.NewErrUnauthorized("the directory entry has no email address")
+	}
+
+	existing, err := a.store.GetUserByEmail(email)
+	if err != nil && !model.IsErrNotFound(err) {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, model.NewErrUnauthorized("an account with this email address already exists")
+	}
+
+	username, err := a.getAvailableUsername(sanitizeExternalUsername(entry.Username, email), "")
+	if err != nil {
+		return nil, err
+	}
+
+	user, err = a.store.CreateUser(&model.User{
+		ID:          utils.NewID(utils.IDTypeUser),
+		Username:    username,
+		Email:       email,
+		Nickname:    entry.Nickname,
+		FirstName:   entry.FirstName,
+		LastName:    entry.LastName,
+		AuthService: model.LdapAuthService,
+		AuthData:    entry.ID,
+		// the directory is trusted with the email address
+		EmailVerified: true,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create the new user")
+	}
+
+	a.logger.Info("Provisioned LDAP user", mlog.String("userID", user.ID))
+	return user, nil
+}
+
+// updateLdapUser copies the attributes of the directory entry to the user,
+// and tells if the user changed.
+func (a *App) updateLdapUser(user *model.User, entry *ldap.Entry) (*model.User, bool, error) {
+	updated := *user
+	updated.Nickname = entry.Nickname
+	updated.FirstName = entry.FirstName
+	updated.LastName = entry.LastName
+
+	if email := strings.ToLower(strings.TrimSpace(entry.Email)); email != "" && email != user.Email {
+		existing, err := a.store.GetUserByEmail(email)
+		if err != nil && !model.IsErrNotFound(err) {
+			return nil, false, err
+		}
+		if existing != nil && existing.ID != user.ID {
+			return nil, false, model.NewErrUnauthorized("an account with this email address already exists")
+		}
+		updated.Email = email
+	}
+
+	// the username may have a numeric suffix added when it was taken
+	if username := sanitizeExternalUsername(entry.Username, updated.Email); !hasUsernameBase(user.Username, username) {
+		var err error
+		if updated.Username, err = a.getAvailableUsername(username, user.ID); err != nil {
+			return nil, false, err
+		}
+	}
+
+	if updated.Email == user.Email && updated.Username == user.Username && updated.Nickname == user.Nickname &&
+		updated.FirstName == user.FirstName && updated.LastName == user.LastName {
+		return user, false, nil
+	}
+
+	result, err := a.store.UpdateUser(&updated)
+	if err != nil {
+		return nil, false, err
+	}
+	return result, true, nil
+}
+
+// SyncLdap updates the LDAP users from the directory. The users removed
+// from the directory are deactivated and their sessions revoked, the users
+// back in the directory are reactivated, and the memberships of the mapped
+// teams and boards follow the LDAP groups. New users are only provisioned
+// on their first login. The synchronization is aborted when the directory
+// returns no users, or more removed users than the configured maximum, so
+// a misconfiguration or a truncated search doesn't deactivate everyone.
+func (a *App) SyncLdap() (*LdapSyncResult, error) {
+	if !a.config.Ldap.Enable {
+		return nil, model.NewErrNotImplemented("LDAP authentication is not enabled")
+	}
+
+	client := ldap.New(a.config.Ldap)
+	entries, err := client.GetUsers()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read the LDAP users")
+	}
+	entriesByID := make(map[string]*ldap.Entry, len(entries))
+	for _, entry := range entries {
+		entriesByID[entry.ID] = entry
+	}
+
+	users, err := a.store.GetUsersByAuthService(model.LdapAuthService)
+	if err != nil {
+		return nil, err
+	}
+
+	removed := 0
+	for _, user := range users {
+		if _, ok := entriesByID[user.AuthData]; !ok && user.DeleteAt == 0 {
+			removed++
+		}
+	}
+	if removed > 0 && len(entries) == 0 {
+		return nil, errors.New("the LDAP directory returned no users, the synchronization is aborted")
+	}
+	if maxRemoved := a.config.Ldap.MaxSyncDeactivations; maxRemoved > 0 && removed > maxRemoved {
+		return nil, errors.Errorf("%d users were removed from the LDAP directory, more than the maximum of %d, the synchronization is aborted", removed, maxRemoved)
+	}
+
+	result := &LdapSyncResult{}
+	activeUsers := make([]ldapUser, 0, len(users))
+	for _, user := range users {
+		entry, ok := entriesByID[user.AuthData]
+		if !ok {
+			if user.DeleteAt != 0 {
+				continue
+			}
+			if err := a.deactivateUser(user.ID); err != nil {
+				return nil, err
+			}
+			a.logger.Info("Deactivated user removed from the LDAP directory", mlog.String("userID", user.ID))
+			result.Deactivated++
+			continue
+		}
+
+		if user.DeleteAt != 0 {
+			if err := a.store.UpdateUserDeleteAt(user.ID, 0); err != nil {
+				return nil, err
+			}
+			a.logger.Info("Reactivated user back in the LDAP directory", mlog.String("userID", user.ID))
+			user.DeleteAt = 0
+			result.Reactivated++
+		}
+
+		updated, changed, err := a.updateLdapUser(user, entry)
+		if err != nil {
+			a.logger.Warn("Unable to update LDAP user", mlog.String("userID", user.ID), mlog.Err(err))
+			updated = user
+		}
+		if changed {
+			result.Updated++
+		}
+		activeUsers = append(activeUsers, ldapUser{user: updated, entry: entry})
+	}
+
+	if len(a.config.Ldap.GroupMappings) > 0 {
+		groups, err := client.GetGroups()
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to read the LDAP groups")
+		}
+		a.syncLdapMemberships(activeUsers, groups, result)
+	}
+
+	return result, nil
+}
+
+// syncLdapMemberships adds the users to the teams and boards mapped to
+// their groups, with the highest role of their mappings, and removes them
+// from the mapped teams and boards of the groups they aren't members of.
+// Groups missing from the directory are skipped, so a misconfiguration
+// doesn't remove every member.
+func (a *App) syncLdapMemberships(users []ldapUser, groups []*ldap.Group, result *LdapSyncResult) {
+	wanted := map[ldapTarget]map[string]model.BoardRole{}
+	for _, mapping := range a.config.Ldap.GroupMappings {
+		group := findLdapGroup(groups, mapping.Group)
+		if group == nil {
+			a.logger.Warn("LDAP group of a mapping not found", mlog.String("group", mapping.Group))
+			continue
+		}
+
+		target := ldapTarget{teamID: mapping.TeamID, boardID: mapping.BoardID}
+		if wanted[target] == nil {
+			wanted[target] = map[string]model.BoardRole{}
+		}
+		role := ldapMappingRole(mapping)
+		for _, user := range users {
+			if group.HasMember(user.entry) && boardRoleRank(role) > boardRoleRank(wanted[target][user.user.ID]) {
+				wanted[target][user.user.ID] = role
+			}
+		}
+	}
+
+	for target, roles := range wanted {
+		for _, user := range users {
+			var err error
+			if target.boardID != "" {
+				err = a.syncLdapBoardMember(target.boardID, user.user.ID, roles[user.user.ID], result)
+			} else {
+				err = a.syncLdapTeamMember(target.teamID, user.user.ID, roles[user.user.ID], result)
+			}
+			if err != nil {
+				a.logger.Warn("Unable to sync LDAP membership",
+					mlog.String("teamID", target.teamID),
+					mlog.String("boardID", target.boardID),
+					mlog.String("userID", user.user.ID),
+					mlog.Err(err),
+				)
+			}
+		}
+	}
+}
+
+func (a *App) syncLdapTeamMember(teamID, userID string, role model.BoardRole, result *LdapSyncResult) error {
+	existing, err := a.store.GetTeamMember(teamID, userID)
+	if err != nil && !model.IsErrNotFound(err) {
+		return err
+	}
+
+	if role == model.BoardRoleNone {
+		if existing == nil {
+			return nil
+		}
+		if err := a.DeleteTeamMember(teamID, userID); err != nil {
+			return err
+		}
+		result.MembershipsRemoved++
+		return nil
+	}
+
+	isAdmin := role == model.BoardRoleAdmin
+	if existing != nil && existing.SchemeAdmin == isAdmin {
+		return nil
+	}
+	if _, err := a.SaveTeamMember(&model.TeamMember{TeamID: teamID, UserID: userID, SchemeAdmin: isAdmin}); err != nil {
+		return err
+	}
+	if existing == nil {
+		result.MembershipsAdded++
+	}
+	return nil
+}
+
+func (a *App) syncLdapBoardMember(boardID, userID string, role model.BoardRole, result *LdapSyncResult) error {
+	existing, err := a.store.GetMemberForBoard(boardID, userID)
+	if err != nil && !model.IsErrNotFound(err) {
+		return err
+	}
+	if existing != nil && existing.Synthetic {
+		existing = nil
+	}
+
+	if role == model.BoardRoleNone {
+		if existing == nil {
+			return nil
+		}
+		if err := a.DeleteBoardMember(boardID, userID); err != nil {
+			return err
+		}
+		result.MembershipsRemoved++
+		return nil
+	}
+
+	if existing == nil {
+		member := &model.BoardMember{BoardID: boardID, UserID: userID}
+		setBoardMemberRole(member, role)
+		if _, err := a.AddMemberToBoard(member); err != nil {
+			return err
+		}
+		result.MembershipsAdded++
+		return nil
+	}
+
+	member := *existing
+	setBoardMemberRole(&member, role)
+	if member == *existing {
+		return nil
+	}
+	_, err = a.UpdateBoardMember(&member)
+	return err
+}
+
+// hasUsernameBase tells if the username is the base username, with or
+// without the numeric suffix of getAvailableUsername.
+func hasUsernameBase(username, base string) bool {
+	if !strings.HasPrefix(username, base) {
+		return false
+	}
+	return strings.Trim(username[len(base):], "0123456789") == ""
+}
+
+func findLdapGroup(groups []*ldap.Group, name string) *ldap.Group {
+	for _, group := range groups {
+		if strings.EqualFold(group.ID, name) || strings.EqualFold(group.DN, name) {
+			return group
+		}
+	}
+	return nil
+}
+
+// ldapMappingRole returns the role of a mapping. Team mappings only
+// distinguish admins from members, and board mappings default to editors.
+func ldapMappingRole(mapping config.LdapGroupMapping) model.BoardRole {
+	role := model.BoardRole(strings.ToLower(mapping.Role))
+	if mapping.BoardID == "" {
+		if role == model.BoardRoleAdmin {
+			return model.BoardRoleAdmin
+		}
+		return model.BoardRoleViewer
+	}
+	if role == model.BoardRoleNone || !model.IsBoardMinimumRoleValid(role) {
+		return model.BoardRoleEditor
+	}
+	return role
+}
+
+func boardRoleRank(role model.BoardRole) int {
+	switch role {
+	case model.BoardRoleAdmin:
+		return 4
+	case model.BoardRoleEditor:
+		return 3
+	case model.BoardRoleCommenter:
+		return 2
+	case model.BoardRoleViewer:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func setBoardMemberRole(member *model.BoardMember, role model.BoardRole) {
+	member.SchemeAdmin = role == model.BoardRoleAdmin
+	member.SchemeEditor = role == model.BoardRoleAdmin || role == model.BoardRoleEditor
+	member.SchemeCommenter = role == model.BoardRoleCommenter
+	member.SchemeViewer = role == model.BoardRoleViewer
+}
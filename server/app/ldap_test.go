@@ -0,0 +1,37 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLdapMappingRole(t *testing.T) {
+	testCases := []struct {
+		mapping  config.LdapGroupMapping
+		expected model.BoardRole
+	}{
+		{config.LdapGroupMapping{TeamID: "team", Role: "Admin"}, model.BoardRoleAdmin},
+		{config.LdapGroupMapping{TeamID: "team", Role: "editor"}, model.BoardRoleViewer},
+		{config.LdapGroupMapping{TeamID: "team"}, model.BoardRoleViewer},
+		{config.LdapGroupMapping{BoardID: "board", Role: "commenter"}, model.BoardRoleCommenter},
+		{config.LdapGroupMapping{BoardID: "board", Role: "unknown"}, model.BoardRoleEditor},
+		{config.LdapGroupMapping{BoardID: "board"}, model.BoardRoleEditor},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.expected, ldapMappingRole(tc.mapping), tc.mapping)
+	}
+}
+
+func TestHasUsernameBase(t *testing.T) {
+	require.True(t, hasUsernameBase("jdoe", "jdoe"))
+	require.True(t, hasUsernameBase("jdoe12", "jdoe"))
+	require.False(t, hasUsernameBase("jdoe.smith", "jdoe"))
+	require.False(t, hasUsernameBase("john", "jdoe"))
+}
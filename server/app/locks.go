@@ -0,0 +1,175 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+)
+
+const lockedField = "isLocked"
+
+// SetBoardLocked locks or unlocks a board. Nobody can change the cards,
+// views or properties of a locked board.
+func (a *App) SetBoardLocked(boardID string, locked bool, userID string) (*model.Board, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	if board.IsLocked == locked {
+		return board, nil
+	}
+
+	board.IsLocked = locked
+	updatedBoard, err := a.store.InsertBoard(board, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.wsAdapter.BroadcastBoardChange(updatedBoard.TeamID, updatedBoard)
+		return nil
+	})
+	return updatedBoard, nil
+}
+
+// SetCardLocked locks or unlocks a card. Nobody can change a locked card or
+// its content, comments and attachments.
+func (a *App) SetCardLocked(cardID string, locked bool, userID string) (*model.Card, error) {
+	cardBlock, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+	if cardBlock.Type != model.TypeCard {
+		return nil, model.NewErrBadRequest("block is not a card")
+	}
+	if model.BlockIsLocked(cardBlock) == locked {
+		return model.Block2Card(cardBlock)
+	}
+
+	board, err := a.store.GetBoard(cardBlock.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	if board.IsLocked {
+		return nil, model.ErrBoardIsLocked
+	}
+
+	blockPatch := &model.BlockPatch{DeletedFields: []string{lockedField}}
+	if locked {
+		blockPatch = &model.BlockPatch{UpdatedFields: map[string]any{lockedField: true}}
+	}
+	if err = a.store.PatchBlock(cardID, blockPatch, userID); err != nil {
+		return nil, err
+	}
+
+	block, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.wsAdapter.BroadcastBlockChange(board.TeamID, block)
+		a.webhook.NotifyUpdate(block)
+		return nil
+	})
+	return model.Block2Card(block)
+}
+
+// checkBlocksNotLocked returns an error if the board is locked, or if any of
+// the blocks is a locked card or belongs to one.
+func (a *App) checkBlocksNotLocked(board *model.Board, blocks ...*model.Block) error {
+	for _, block := range blocks {
+		if model.BlockIsLocked(block) {
+			return model.ErrCardIsLocked
+		}
+	}
+	return a.checkParentsNotLocked(board, blocks...)
+}
+
+// checkParentsNotLocked returns an error if the board is locked, or if any
+// of the blocks belongs to a locked card. The blocks themselves may be
+// locked, which allows inserting locked cards.
+func (a *App) checkParentsNotLocked(board *model.Board, blocks ...*model.Block) error {
+	if board.IsLocked {
+		return model.ErrBoardIsLocked
+	}
+
+	lockedParents := map[string]bool{}
+	for _, block := range blocks {
+		if block.Type == model.TypeCard || block.ParentID == "" || block.ParentID == block.BoardID {
+			continue
+		}
+
+		locked, ok := lockedParents[block.ParentID]
+		if !ok {
+			parent, err := a.store.GetBlock(block.ParentID)
+			if err != nil && !model.IsErrNotFound(err) {
+				return err
+			}
+			locked = model.BlockIsLocked(parent)
+			lockedParents[block.ParentID] = locked
+		}
+		if locked {
+			return model.ErrCardIsLocked
+		}
+	}
+	return nil
+}
+
+// checkCardNotLocked returns an error if the card or its board is locked.
+func (a *App) checkCardNotLocked(cardID string) error {
+	cardBlock, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return err
+	}
+	board, err := a.store.GetBoard(cardBlock.BoardID)
+	if err != nil {
+		return err
+	}
+	return a.checkBlocksNotLocked(board, cardBlock)
+}
+
+// checkBoardBlocksNotLocked is checkBlocksNotLocked for blocks that may
+// belong to different boards.
+func (a *App) checkBoardBlocksNotLocked(blocks []*model.Block) error {
+	blocksByBoard := map[string][]*model.Block{}
+	for _, block := range blocks {
+		blocksByBoard[block.BoardID] = append(blocksByBoard[block.BoardID], block)
+	}
+
+	for boardID, boardBlocks := range blocksByBoard {
+		board, err := a.store.GetBoard(boardID)
+		if err != nil {
+			return err
+		}
+		if err = a.checkBlocksNotLocked(board, boardBlocks...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkProtectedFields returns an error if the patch changes a card field
+// that can only be changed with a dedicated API.
+func checkProtectedFields(blockPatch *model.BlockPatch) error {
+	if patchesField(blockPatch, lockedField) {
+		return model.NewErrBadRequest("cards can only be locked and unlocked with the lock API")
+	}
+	if patchesField(blockPatch, model.CardRestrictedField) || patchesField(blockPatch, model.CardAllowedUsersField) {
+		return model.NewErrBadRequest("card access can only be changed with the card access API")
+	}
+	return nil
+}
+
+func patchesField(blockPatch *model.BlockPatch, field string) bool {
+	if _, ok := blockPatch.UpdatedFields[field]; ok {
+		return true
+	}
+	for _, deletedField := range blockPatch.DeletedFields {
+		if deletedField == field {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetBoardLocked(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("lock board", func(t *testing.T) {
+		board := &model.Board{ID: "board_id_1", TeamID: "team_id"}
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().InsertBoard(gomock.Any(), "user_id").DoAndReturn(
+			func(b *model.Board, _ string) (*model.Board, error) {
+				require.True(t, b.IsLocked)
+				return b, nil
+			})
+		// for WS change broadcast
+		th.Store.EXPECT().GetMembersForBoard(board.ID).Return([]*model.BoardMember{}, nil).AnyTimes()
+
+		locked, err := th.App.SetBoardLocked(board.ID, true, "user_id")
+		require.NoError(t, err)
+		require.True(t, locked.IsLocked)
+	})
+
+	t.Run("already unlocked board", func(t *testing.T) {
+		board := &model.Board{ID: "board_id_2", TeamID: "team_id"}
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+
+		unlocked, err := th.App.SetBoardLocked(board.ID, false, "user_id")
+		require.NoError(t, err)
+		require.False(t, unlocked.IsLocked)
+	})
+}
+
+func TestSetCardLocked(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("not a card", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("view_id").Return(&model.Block{ID: "view_id", Type: model.TypeView}, nil)
+
+		card, err := th.App.SetCardLocked("view_id", true, "user_id")
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, card)
+	})
+
+	t.Run("locked board", func(t *testing.T) {
+		cardBlock := &model.Block{ID: "card_id_1", BoardID: "board_id", Type: model.TypeCard}
+		th.Store.EXPECT().GetBlock(cardBlock.ID).Return(cardBlock, nil)
+		th.Store.EXPECT().GetBoard("board_id").Return(&model.Board{ID: "board_id", IsLocked: true}, nil)
+
+		card, err := th.App.SetCardLocked(cardBlock.ID, true, "user_id")
+		require.ErrorIs(t, err, model.ErrBoardIsLocked)
+		require.Nil(t, card)
+	})
+
+	t.Run("unlock card", func(t *testing.T) {
+		cardBlock := &model.Block{
+			ID:      "card_id_2",
+			BoardID: "board_id",
+			Type:    model.TypeCard,
+			Fields:  map[string]interface{}{"isLocked": true},
+		}
+		unlockedBlock := &model.Block{ID: "card_id_2", BoardID: "board_id", Type: model.TypeCard}
+		gomock.InOrder(
+			th.Store.EXPECT().GetBlock(cardBlock.ID).Return(cardBlock, nil),
+			th.Store.EXPECT().GetBlock(cardBlock.ID).Return(unlockedBlock, nil),
+		)
+		th.Store.EXPECT().GetBoard("board_id").Return(&model.Board{ID: "board_id", TeamID: "team_id"}, nil)
+		th.Store.EXPECT().PatchBlock(cardBlock.ID, &model.BlockPatch{DeletedFields: []string{"isLocked"}}, "user_id").Return(nil)
+		// for WS change broadcast
+		th.Store.EXPECT().GetMembersForBoard("board_id").Return([]*model.BoardMember{}, nil).AnyTimes()
+
+		card, err := th.App.SetCardLocked(cardBlock.ID, false, "user_id")
+		require.NoError(t, err)
+		require.False(t, card.IsLocked)
+	})
+}
+
+func TestPatchLockedBlock(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	lockedCard := &model.Block{
+		ID:      "card_id",
+		BoardID: "board_id",
+		Type:    model.TypeCard,
+		Fields:  map[string]interface{}{"isLocked": true},
+	}
+	text := &model.Block{ID: "text_id", BoardID: "board_id", ParentID: lockedCard.ID, Type: model.TypeText}
+
+	th.Store.EXPECT().GetBlock(text.ID).Return(text, nil)
+	th.Store.EXPECT().GetBoard("board_id").Return(&model.Board{ID: "board_id"}, nil)
+	th.Store.EXPECT().GetBlock(lockedCard.ID).Return(lockedCard, nil)
+
+	title := "new title"
+	block, err := th.App.PatchBlock(text.ID, &model.BlockPatch{Title: &title}, "user_id")
+	require.ErrorIs(t, err, model.ErrCardIsLocked)
+	require.Nil(t, block)
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// loginAccountKey returns the counter key of the account of a login. The
+// logins with the username and with the email address of a user share the
+// counter of the user.
+func (a *App) loginAccountKey(login string) (string, error) {
+	user, err := a.store.GetUserByUsername(login)
+	if model.IsErrNotFound(err) {
+		user, err = a.store.GetUserByEmail(login)
+	}
+	if model.IsErrNotFound(err) {
+		return model.LoginAttemptsAccountKey(login), nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return model.LoginAttemptsUserKey(user.ID), nil
+}
+
+// loginAttemptsKeys returns the counter keys of a login, with the number
+// of failures that locks each of them out.
+func (a *App) loginAttemptsKeys(login, ipAddress string) (map[string]int, error) {
+	keys := map[string]int{}
+	if login != "" {
+		accountKey, err := a.loginAccountKey(login)
+		if err != nil {
+			return nil, err
+		}
+		keys[accountKey] = a.config.LoginLockout.MaxAccountFailures
+	}
+	if ipAddress != "" {
+		keys[model.LoginAttemptsIPKey(ipAddress)] = a.config.LoginLockout.MaxIPFailures
+	}
+	return keys, nil
+}
+
+func (a *App) loginLockoutDuration() time.Duration {
+	return time.Duration(a.config.LoginLockout.LockoutMinutes) * time.Minute
+}
+
+// loginBlockedUntil returns the time until which the logins of a counter
+// are rejected: the counters are locked out once they reach maxFailures,
+// and the failures past the free ones double the delay before the next
+// login, up to the maximum delay.
+func (a *App) loginBlockedUntil(attempts *model.LoginAttempts, maxFailures int) int64 {
+	cfg := a.config.LoginLockout
+	if maxFailures > 0 && attempts.FailedCount >= maxFailures {
+		return attempts.LastFailureAt + a.loginLockoutDuration().Milliseconds()
+	}
+
+	extra := attempts.FailedCount - cfg.FreeFailures
+	if extra <= 0 {
+		return 0
+	}
+
+	maxDelay := time.Duration(cfg.MaxDelaySeconds) * time.Second
+	delay := maxDelay
+	if extra <= 30 && time.Second<<(extra-1) < maxDelay {
+		delay = time.Second << (extra - 1)
+	}
+	return attempts.LastFailureAt + delay.Milliseconds()
+}
+
+// CheckLoginAllowed returns a model.ErrTooManyRequests error if the
+// account or the IP address have to wait before trying to log in again.
+func (a *App) CheckLoginAllowed(login, ipAddress string) error {
+	if !a.config.LoginLockout.Enable {
+		return nil
+	}
+
+	keys, err := a.loginAttemptsKeys(login, ipAddress)
+	if err != nil {
+		return err
+	}
+
+	now := utils.GetMillis()
+	var retryAt int64
+	for key, maxFailures := range keys {
+		attempts, err := a.store.GetLoginAttempts(key)
+		if model.IsErrNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if blockedUntil := a.loginBlockedUntil(attempts, maxFailures); blockedUntil > retryAt {
+			retryAt = blockedUntil
+		}
+	}
+
+	if retryAt <= now {
+		return nil
+	}
+	retryAfter := (retryAt - now + 999) / 1000
+	return model.NewErrTooManyRequests("too many failed login attempts, try again later", retryAfter)
+}
+
+// RecordLoginFailure counts a failed login of the account and of the IP
+// address, and returns true if the failure locks one of them out.
+func (a *App) RecordLoginFailure(login, ipAddress string) (bool, error) {
+	if !a.config.LoginLockout.Enable {
+		return false, nil
+	}
+
+	keys, err := a.loginAttemptsKeys(login, ipAddress)
+	if err != nil {
+		return false, err
+	}
+
+	windowStart := utils.GetMillis() - a.loginLockoutDuration().Milliseconds()
+	locked := false
+	for key, maxFailures := range keys {
+		attempts, err := a.store.IncrementLoginAttempts(key, windowStart)
+		if err != nil {
+			return false, err
+		}
+
+		if maxFailures > 0 && attempts.FailedCount == maxFailures {
+			a.logger.Warn("Too many failed logins, locking out",
+				mlog.String("key", key),
+				mlog.Int("failures", attempts.FailedCount),
+			)
+			locked = true
+		}
+	}
+	return locked, nil
+}
+
+// ResetLoginAttempts resets the failed logins of an account after it
+// logged in. The IP address counters aren't reset, so an attacker can't
+// reset them by logging into its own account.
+func (a *App) ResetLoginAttempts(login string) error {
+	if !a.config.LoginLockout.Enable || login == "" {
+		return nil
+	}
+
+	key, err := a.loginAccountKey(login)
+	if err != nil {
+		return err
+	}
+	return a.store.DeleteLoginAttempts(key)
+}
+
+// UnlockUserLogin resets the failed logins of the user, including the ones
+// recorded with its username or its email address before it existed.
+func (a *App) UnlockUserLogin(userID string) error {
+	user, err := a.store.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	keys := []string{model.LoginAttemptsUserKey(user.ID)}
+	for _, login := range []string{user.Username, user.Email} {
+		if login != "" {
+			keys = append(keys, model.LoginAttemptsAccountKey(login))
+		}
+	}
+	for _, key := range keys {
+		if err := a.store.DeleteLoginAttempts(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnlockIPAddressLogin resets the failed logins of an IP address.
+func (a *App) UnlockIPAddressLogin(ipAddress string) error {
+	return a.store.DeleteLoginAttempts(model.LoginAttemptsIPKey(ipAddress))
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginBlockedUntil(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	th.App.config.LoginLockout = config.LockoutConfig{
+		Enable:          true,
+		FreeFailures:    3,
+		MaxDelaySeconds: 30,
+		LockoutMinutes:  15,
+	}
+
+	testCases := []struct {
+		name        string
+		failures    int
+		maxFailures int
+		expected    int64
+	}{
+		{"free failures", 3, 10, 0},
+		{"first delayed failure", 4, 10, 1000},
+		{"doubled delay", 6, 10, 4000},
+		{"maximum delay", 9, 10, 30000},
+		{"no lockout", 100, 0, 30000},
+		{"locked out", 10, 10, 15 * 60 * 1000},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			attempts := &model.LoginAttempts{FailedCount: tc.failures, LastFailureAt: 1000}
+			blockedUntil := th.App.loginBlockedUntil(attempts, tc.maxFailures)
+			if tc.expected == 0 {
+				require.Zero(t, blockedUntil)
+				return
+			}
+			require.Equal(t, 1000+tc.expected, blockedUntil)
+		})
+	}
+}
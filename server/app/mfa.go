@@ -0,0 +1,200 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/auth"
+
+	"github.com/pkg/errors"
+)
+
+// MfaIssuer is the issuer shown by authenticator apps.
+const MfaIssuer = "Focalboard"
+
+var ErrMfaTokenRequired = errors.New("multi-factor authentication token required")
+
+// GenerateMfaSecret generates a new TOTP secret for the user. Multi-factor
+// authentication is not active until the secret is verified with
+// ActivateMfa.
+func (a *App) GenerateMfaSecret(userID string) (*model.MfaSecret, error) {
+	user, err := a.store.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.MfaActive {
+		return nil, model.NewErrBadRequest("multi-factor authentication is already active")
+	}
+	if user.AuthService == model.OidcAuthService {
+		return nil, model.NewErrBadRequest("multi-factor authentication is managed by the identity provider")
+	}
+
+	secret, err := auth.GenerateMfaSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.store.UpdateUserMfa(user.ID, secret, false, nil); err != nil {
+		return nil, err
+	}
+
+	accountName := user.Email
+	if accountName == "" {
+		accountName = user.Username
+	}
+
+	return &model.MfaSecret{
+		Secret:          secret,
+		ProvisioningURI: auth.MfaProvisioningURI(MfaIssuer, accountName, secret),
+	}, nil
+}
+
+// ActivateMfa activates multi-factor authentication for the user if the
+// code is valid for the generated secret, and returns its recovery codes.
+func (a *App) ActivateMfa(userID, code string) ([]string, error) {
+	user, err := a.store.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.MfaActive {
+		return nil, model.NewErrBadRequest("multi-factor authentication is already active")
+	}
+	if user.MfaSecret == "" {
+		return nil, model.NewErrBadRequest("no multi-factor authentication secret generated")
+	}
+	valid, err := a.checkMfaCode(user, code)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, model.NewErrBadRequest("invalid multi-factor authentication code")
+	}
+
+	return a.saveMfaRecoveryCodes(user)
+}
+
+// DeactivateMfa deactivates multi-factor authentication for the user, which
+// is not allowed if the server requires it.
+func (a *App) DeactivateMfa(userID, code string) error {
+	if a.config.RequireMfa {
+		return model.NewErrPermission("multi-factor authentication is required")
+	}
+
+	user, err := a.getMfaUser(userID, code)
+	if err != nil {
+		return err
+	}
+	return a.store.UpdateUserMfa(user.ID, "", false, nil)
+}
+
+// RegenerateMfaRecoveryCodes replaces the recovery codes of the user.
+func (a *App) RegenerateMfaRecoveryCodes(userID, code string) ([]string, error) {
+	user, err := a.getMfaUser(userID, code)
+	if err != nil {
+		return nil, err
+	}
+	return a.saveMfaRecoveryCodes(user)
+}
+
+// ResetUserMfa deactivates multi-factor authentication for a user that
+// lost access to their authenticator and recovery codes.
+func (a *App) ResetUserMfa(userID string) error {
+	return a.store.UpdateUserMfa(userID, "", false, nil)
+}
+
+// IsMfaSetupRequired returns true if the server requires multi-factor
+// authentication and the user hasn't activated it yet. The users of
+// OpenID Connect log in with the identity provider, which is responsible
+// for their multi-factor authentication.
+func (a *App) IsMfaSetupRequired(userID string) (bool, error) {
+	if !a.config.RequireMfa {
+		return false, nil
+	}
+
+	user, err := a.store.GetUserByID(userID)
+	if err != nil {
+		return false, err
+	}
+	if user.AuthService == model.OidcAuthService {
+		return false, nil
+	}
+	return !user.MfaActive, nil
+}
+
+// getMfaUser fetches a user with multi-factor authentication active,
+// making sure that the code is valid.
+func (a *App) getMfaUser(userID, code string) (*model.User, error) {
+	user, err := a.store.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.MfaActive {
+		return nil, model.NewErrBadRequest("multi-factor authentication is not active")
+	}
+
+	valid, err := a.checkMfaToken(user, code)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, model.NewErrBadRequest("invalid multi-factor authentication code")
+	}
+	return user, nil
+}
+
+func (a *App) saveMfaRecoveryCodes(user *model.User) ([]string, error) {
+	recoveryCodes, err := auth.GenerateMfaRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]string, len(recoveryCodes))
+	for i, code := range recoveryCodes {
+		hashes[i] = auth.HashMfaRecoveryCode(code)
+	}
+
+	if err := a.store.UpdateUserMfa(user.ID, user.MfaSecret, true, hashes); err != nil {
+		return nil, err
+	}
+	return recoveryCodes, nil
+}
+
+// checkMfaCode validates a TOTP code of the user. A code is only accepted
+// once, and neither are the codes of the earlier time steps, so that an
+// intercepted code can't be replayed while it is still valid.
+func (a *App) checkMfaCode(user *model.User, code string) (bool, error) {
+	timeStep, ok := auth.ValidateMfaCode(user.MfaSecret, code, time.Now())
+	if !ok {
+		return false, nil
+	}
+	return a.store.ClaimUserMfaTimeStep(user.ID, timeStep)
+}
+
+// checkMfaToken validates a TOTP code or a recovery code of the user.
+// Recovery codes can only be used once.
+func (a *App) checkMfaToken(user *model.User, token string) (bool, error) {
+	if valid, err := a.checkMfaCode(user, token); err != nil || valid {
+		return valid, err
+	}
+
+	hashes, err := a.store.GetUserMfaRecoveryCodes(user.ID)
+	if err != nil {
+		return false, err
+	}
+
+	hash := auth.HashMfaRecoveryCode(token)
+	for i, h := range hashes {
+		if h != hash {
+			continue
+		}
+		remaining := append(hashes[:i:i], hashes[i+1:]...)
+		if err := a.store.UpdateUserMfa(user.ID, user.MfaSecret, true, remaining); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	return false, nil
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/auth"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateMfaSecret(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("success", func(t *testing.T) {
+		user := &model.User{ID: "user-id", Email: "user@example.com"}
+		th.Store.EXPECT().GetUserByID("user-id").Return(user, nil)
+		th.Store.EXPECT().UpdateUserMfa("user-id", gomock.Any(), false, nil).Return(nil)
+
+		secret, err := th.App.GenerateMfaSecret("user-id")
+		require.NoError(t, err)
+		require.NotEmpty(t, secret.Secret)
+		require.Contains(t, secret.ProvisioningURI, "user@example.com")
+	})
+
+	t.Run("fail, MFA already active", func(t *testing.T) {
+		user := &model.User{ID: "user-id", MfaActive: true}
+		th.Store.EXPECT().GetUserByID("user-id").Return(user, nil)
+
+		secret, err := th.App.GenerateMfaSecret("user-id")
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, secret)
+	})
+
+	t.Run("fail, OpenID Connect user", func(t *testing.T) {
+		user := &model.User{ID: "user-id", AuthService: model.OidcAuthService}
+		th.Store.EXPECT().GetUserByID("user-id").Return(user, nil)
+
+		secret, err := th.App.GenerateMfaSecret("user-id")
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, secret)
+	})
+}
+
+func TestLoginMfa(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	secret, err := auth.GenerateMfaSecret()
+	require.NoError(t, err)
+
+	user := *mockUser
+	user.MfaSecret = secret
+	user.MfaActive = true
+
+	t.Run("fail, missing token", func(t *testing.T) {
+		th.Store.EXPECT().GetUserByUsername("testUsername").Return(&user, nil)
+
+		_, err := th.App.Login("testUsername", "", "testPassword", "", nil)
+		require.ErrorIs(t, err, ErrMfaTokenRequired)
+	})
+
+	t.Run("fail, invalid token", func(t *testing.T) {
+		th.Store.EXPECT().GetUserByUsername("testUsername").Return(&user, nil)
+		th.Store.EXPECT().GetUserMfaRecoveryCodes(user.ID).Return([]string{}, nil)
+
+		_, err := th.App.Login("testUsername", "", "testPassword", "000000", nil)
+		require.Error(t, err)
+	})
+
+	t.Run("success, TOTP code", func(t *testing.T) {
+		code, err := auth.GenerateMfaCode(secret, time.Now())
+		require.NoError(t, err)
+
+		th.Store.EXPECT().GetUserByUsername("testUsername").Return(&user, nil)
+		th.Store.EXPECT().ClaimUserMfaTimeStep(user.ID, gomock.Any()).Return(true, nil)
+		th.Store.EXPECT().CreateSession(gomock.Any()).Return(nil)
+
+		token, err := th.App.Login("testUsername", "", "testPassword", code, nil)
+		require.NoError(t, err)
+		require.NotEmpty(t, token)
+	})
+
+	t.Run("fail, replayed TOTP code", func(t *testing.T) {
+		code, err := auth.GenerateMfaCode(secret, time.Now())
+		require.NoError(t, err)
+
+		th.Store.EXPECT().GetUserByUsername("testUsername").Return(&user, nil)
+		th.Store.EXPECT().ClaimUserMfaTimeStep(user.ID, gomock.Any()).Return(false, nil)
+		th.Store.EXPECT().GetUserMfaRecoveryCodes(user.ID).Return([]string{}, nil)
+
+		_, err = th.App.Login("testUsername", "", "testPassword", code, nil)
+		require.Error(t, err)
+	})
+
+	t.Run("success, recovery code", func(t *testing.T) {
+		hashes := []string{auth.HashMfaRecoveryCode("aaaaa-bbbbb"), auth.HashMfaRecoveryCode("ccccc-ddddd")}
+		th.Store.EXPECT().GetUserByUsername("testUsername").Return(&user, nil)
+		th.Store.EXPECT().GetUserMfaRecoveryCodes(user.ID).Return(hashes, nil)
+		th.Store.EXPECT().UpdateUserMfa(user.ID, secret, true, []string{hashes[1]}).Return(nil)
+		th.Store.EXPECT().CreateSession(gomock.Any()).Return(nil)
+
+		token, err := th.App.Login("testUsername", "", "testPassword", "aaaaa-bbbbb", nil)
+		require.NoError(t, err)
+		require.NotEmpty(t, token)
+	})
+}
+
+func TestIsMfaSetupRequired(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	required, err := th.App.IsMfaSetupRequired("user-id")
+	require.NoError(t, err)
+	require.False(t, required)
+
+	th.App.config.RequireMfa = true
+	defer func() { th.App.config.RequireMfa = false }()
+
+	th.Store.EXPECT().GetUserByID("user-id").Return(&model.User{ID: "user-id"}, nil)
+	required, err = th.App.IsMfaSetupRequired("user-id")
+	require.NoError(t, err)
+	require.True(t, required)
+
+	th.Store.EXPECT().GetUserByID("user-id").Return(&model.User{ID: "user-id", MfaActive: true}, nil)
+	required, err = th.App.IsMfaSetupRequired("user-id")
+	require.NoError(t, err)
+	require.False(t, required)
+
+	// the identity provider handles the MFA of the OpenID Connect users
+	th.Store.EXPECT().GetUserByID("user-id").Return(&model.User{ID: "user-id", AuthService: model.OidcAuthService}, nil)
+	required, err = th.App.IsMfaSetupRequired("user-id")
+	require.NoError(t, err)
+	require.False(t, required)
+}
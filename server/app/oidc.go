@@ -0,0 +1,316 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/services/oidc"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/pkg/errors"
+)
+
+const (
+	// OidcCallbackPath is the path of the OpenID Connect redirect URI,
+	// relative to the server root.
+	OidcCallbackPath = "/oauth/oidc/callback"
+
+	oidcDefaultUsernameClaim = "preferred_username"
+	oidcDefaultEmailClaim    = "email"
+	oidcHTTPTimeout          = 30 * time.Second
+	oidcMaxUsernameAttempts  = 100
+)
+
+var externalUsernameInvalidChars = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+// OidcAuthRequest is the state of an authorization request, that the
+// client keeps until the provider redirects the user back.
+type OidcAuthRequest struct {
+	State        string `json:"state"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"codeVerifier"`
+	RedirectTo   string `json:"redirectTo,omitempty"`
+
+	// URL is the provider authorization URL to redirect the user to.
+	URL string `json:"-"`
+}
+
+// StartOidcLogin creates a new authorization request for the configured
+// OpenID Connect provider.
+func (a *App) StartOidcLogin(ctx context.Context, redirectTo string) (*OidcAuthRequest, error) {
+	provider, cfg, err := a.getOidcProvider(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &OidcAuthRequest{RedirectTo: redirectTo}
+	for _, value := range []*string{&request.State, &request.Nonce, &request.CodeVerifier} {
+		if *value, err = oidc.NewCodeVerifier(); err != nil {
+			return nil, err
+		}
+	}
+	request.URL = provider.AuthCodeURL(cfg, request.State, request.Nonce, request.CodeVerifier)
+
+	return request, nil
+}
+
+// LoginWithOidc completes an authorization request with the code returned
+// by the provider. The user linked to the subject of the ID token is
+// created or updated, and a new session token is returned. The props
+// record the client that logged in.
+func (a *App) LoginWithOidc(ctx context.Context, request *OidcAuthRequest, code string, props map[string]interface{}) (string, error) {
+	provider, cfg, err := a.getOidcProvider(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	token, err := provider.Exchange(ctx, cfg, code, request.CodeVerifier)
+	if err != nil {
+		// the response of the provider stays in the server logs, it can
+		// reveal details of the client registration
+		fields := []mlog.Field{mlog.Err(err)}
+		var tokenErr *oidc.TokenEndpointError
+		if errors.As(err, &tokenErr) {
+			fields = append(fields, mlog.String("body", tokenErr.Body))
+		}
+		a.logger.Warn("Unable to exchange the OpenID Connect authorization code", fields...)
+		a.metrics.IncrementLoginFailCount(1)
+		return "", model.NewErrUnauthorized("unable to exchange the authorization code")
+	}
+
+	claims, err := provider.VerifyIDToken(ctx, cfg, token.IDToken, request.Nonce)
+	if err != nil {
+		a.metrics.IncrementLoginFailCount(1)
+		return "", model.NewErrUnauthorized(err.Error())
+	}
+
+	user, err := a.provisionOidcUser(claims)
+	if err != nil {
+		a.metrics.IncrementLoginFailCount(1)
+		return "", err
+	}
+
+	if user.DeleteAt != 0 {
+		a.metrics.IncrementLoginFailCount(1)
+		return "", model.NewErrUnauthorized("the account is deactivated")
+	}
+	if user.IsExpiredGuest(utils.GetMillis()) {
+		a.metrics.IncrementLoginFailCount(1)
+		return "", model.NewErrUnauthorized("the guest account has expired")
+	}
+
+	// the identity provider is responsible for the multi-factor
+	// authentication of its users, see IsMfaSetupRequired. The sessions of
+	// the server are validated against its auth mode, the user auth
+	// service only records how the account is linked
+	session, err := a.createSession(user.ID, a.config.AuthMode, props)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to create session")
+	}
+
+	a.metrics.IncrementLoginCount(1)
+
+	return session.Token, nil
+}
+
+// provisionOidcUser returns the user linked to the subject of the claims,
+// creating it on its first login and keeping its username and email in
+// sync with the provider afterwards.
+func (a *App) provisionOidcUser(claims oidc.Claims) (*model.User, error) {
+	oidcConfig := a.config.Oidc
+	subject := claims.String("sub")
+
+	email := strings.ToLower(strings.TrimSpace(claims.String(claimName(oidcConfig.EmailClaim, oidcDefaultEmailClaim))))
+	if email == "" {
+		return nil, model.NewErrUnauthorized("the identity provider didn't return an email address")
+	}
+	if verified, ok := claims.Bool("email_verified"); ok && !verified {
+		return nil, model.NewErrUnauthorized("the email address is not verified")
+	}
+	if !isEmailDomainAllowed(email, oidcConfig.AllowedDomains) {
+		return nil, model.NewErrPermission("the email domain is not allowed")
+	}
+
+	username := sanitizeExternalUsername(claims.String(claimName(oidcConfig.UsernameClaim, oidcDefaultUsernameClaim)), email)
+
+	user, err := a.store.GetUserByAuthData(model.OidcAuthService, subject)
+	if err != nil && !model.IsErrNotFound(err) {
+		return nil, err
+	}
+
+	if user != nil {
+		if user.Email == email && user.Username == username {
+			return user, nil
+		}
+		return a.updateOidcUser(user, username, email)
+	}
+
+	existing, err := a.store.GetUserByEmail(email)
+	if err != nil && !model.IsErrNotFound(err) {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, model.NewErrUnauthorized("an account with this email address already exists")
+	}
+
+	username, err = a.getAvailableUsername(username, "")
+	if err != nil {
+		return nil, err
+	}
+
+	user, err = a.store.CreateUser(&model.User{
+		ID:          utils.NewID(utils.IDTypeUser),
+		Username:    username,
+		Email:       email,
+		AuthService: model.OidcAuthService,
+		AuthData:    subject,
+		// the identity provider verified the email address
+		EmailVerified: true,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create the new user")
+	}
+
+	a.logger.Info("Provisioned OpenID Connect user", mlog.String("userID", user.ID))
+	return user, nil
+}
+
+func (a *App) updateOidcUser(user *model.User, username, email string) (*model.User, error) {
+	if user.Email != email {
+		existing, err := a.store.GetUserByEmail(email)
+		if err != nil && !model.IsErrNotFound(err) {
+			return nil, err
+		}
+		if existing != nil && existing.ID != user.ID {
+			return nil, model.NewErrUnauthorized("an account with this email address already exists")
+		}
+	}
+
+	if user.Username != username {
+		var err error
+		if username, err = a.getAvailableUsername(username, user.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	updated := *user
+	updated.Username = username
+	updated.Email = email
+	return a.store.UpdateUser(&updated)
+}
+
+// getAvailableUsername returns the username, or the username with a
+// numeric suffix if it is taken by another user.
+func (a *App) getAvailableUsername(username, userID string) (string, error) {
+	for i := 0; i < oidcMaxUsernameAttempts; i++ {
+		candidate := username
+		if i > 0 {
+			candidate = username + strconv.Itoa(i)
+		}
+
+		user, err := a.store.GetUserByUsername(candidate)
+		if model.IsErrNotFound(err) || (err == nil && user.ID == userID) {
+			return candidate, nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return "", model.NewErrBadRequest("unable to find an available username")
+}
+
+// getOidcProvider discovers the configured provider, which is cached
+// until the issuer changes.
+func (a *App) getOidcProvider(ctx context.Context) (*oidc.Provider, oidc.Config, error) {
+	oidcConfig := a.config.Oidc
+	if !oidcConfig.Enable || oidcConfig.Issuer == "" || oidcConfig.ClientID == "" {
+		return nil, oidc.Config{}, model.NewErrNotImplemented("OpenID Connect single sign-on is not enabled")
+	}
+
+	cfg := oidc.Config{
+		ClientID:     oidcConfig.ClientID,
+		ClientSecret: oidcConfig.ClientSecret,
+		RedirectURL:  strings.TrimSuffix(a.config.ServerRoot, "/") + OidcCallbackPath,
+		Scopes:       oidcConfig.Scopes,
+	}
+
+	a.oidcMux.Lock()
+	defer a.oidcMux.Unlock()
+
+	if a.oidcProvider != nil && a.oidcIssuer == oidcConfig.Issuer {
+		return a.oidcProvider, cfg, nil
+	}
+
+	provider, err := oidc.Discover(ctx, &http.Client{Timeout: oidcHTTPTimeout}, oidcConfig.Issuer)
+	if err != nil {
+		return nil, cfg, errors.Wrap(err, "unable to discover the OpenID Connect provider")
+	}
+	a.oidcProvider = provider
+	a.oidcIssuer = oidcConfig.Issuer
+
+	return provider, cfg, nil
+}
+
+func oidcDisplayName(cfg *config.Configuration) string {
+	if !cfg.Oidc.Enable {
+		return ""
+	}
+	return cfg.Oidc.DisplayName
+}
+
+func claimName(name, defaultName string) string {
+	if name == "" {
+		return defaultName
+	}
+	return name
+}
+
+// isEmailDomainAllowed checks the domain of the email against the allowed
+// domains, any domain is allowed if there are none.
+func isEmailDomainAllowed(email string, allowedDomains []string) bool {
+	if len(allowedDomains) == 0 {
+		return true
+	}
+
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	domain := strings.ToLower(email[at+1:])
+
+	for _, allowed := range allowedDomains {
+		if strings.ToLower(strings.TrimPrefix(strings.TrimSpace(allowed), "@")) == domain {
+			return true
+		}
+	}
+	return false
+}
+
+// sanitizeExternalUsername turns the username of the identity provider or
+// of the directory, or the local part of the email if there is none, into
+// a valid username.
+func sanitizeExternalUsername(username, email string) string {
+	if username == "" {
+		username = email
+	}
+	if at := strings.Index(username, "@"); at >= 0 {
+		username = username[:at]
+	}
+
+	username = externalUsernameInvalidChars.ReplaceAllString(strings.ToLower(username), "-")
+	username = strings.Trim(username, "-")
+	if username == "" {
+		username = "user"
+	}
+	return username
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeExternalUsername(t *testing.T) {
+	testCases := []struct {
+		username string
+		email    string
+		expected string
+	}{
+		{"jane.doe", "jane@example.com", "jane.doe"},
+		{"Jane Doe", "jane@example.com", "jane-doe"},
+		{"", "John.Smith@example.com", "john.smith"},
+		{"jane@example.com", "", "jane"},
+		{"ÉÈ", "", "user"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.username+tc.email, func(t *testing.T) {
+			require.Equal(t, tc.expected, sanitizeExternalUsername(tc.username, tc.email))
+		})
+	}
+}
+
+func TestIsEmailDomainAllowed(t *testing.T) {
+	require.True(t, isEmailDomainAllowed("jane@example.com", nil))
+	require.True(t, isEmailDomainAllowed("jane@example.com", []string{"other.com", "Example.com"}))
+	require.True(t, isEmailDomainAllowed("jane@example.com", []string{"@example.com"}))
+	require.False(t, isEmailDomainAllowed("jane@sub.example.com", []string{"example.com"}))
+	require.False(t, isEmailDomainAllowed("jane@example.com.evil", []string{"example.com"}))
+	require.False(t, isEmailDomainAllowed("jane", []string{"example.com"}))
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/auth"
+	"github.com/mattermost/focalboard/server/services/email"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const (
+	PasswordResetPath         = "/reset_password"
+	defaultResetExpiryMinutes = 60
+)
+
+// SendPasswordReset emails a password reset link to the account with the
+// email address. Nothing is sent to unknown addresses or to the accounts
+// without a local password, but no error is returned either so that the
+// registered addresses can't be discovered.
+func (a *App) SendPasswordReset(emailAddress string) error {
+	if !a.isEmailConfigured() {
+		return model.NewErrNotImplemented("the SMTP server is not configured")
+	}
+
+	user, err := a.store.GetUserByEmail(emailAddress)
+	if model.IsErrNotFound(err) {
+		a.logger.Debug("Password reset requested for an unknown email address")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if user.DeleteAt != 0 || !hasLocalPassword(user) {
+		a.logger.Debug("Password reset requested for an account without a local password", mlog.String("userID", user.ID))
+		return nil
+	}
+
+	if err = a.store.DeletePasswordResetTokensForUser(user.ID); err != nil {
+		return err
+	}
+
+	expiry := a.config.Password.ResetExpiryMinutes
+	if expiry <= 0 {
+		expiry = defaultResetExpiryMinutes
+	}
+	rawToken := utils.NewID(utils.IDTypeToken)
+	now := utils.GetMillis()
+	_, err = a.store.CreatePasswordResetToken(&model.PasswordResetToken{
+		ID:        utils.NewID(utils.IDTypeToken),
+		UserID:    user.ID,
+		TokenHash: model.HashToken(rawToken),
+		ExpireAt:  now + (time.Duration(expiry) * time.Minute).Milliseconds(),
+		CreateAt:  now,
+	})
+	if err != nil {
+		return err
+	}
+
+	link := a.serverLink(PasswordResetPath + "?token=" + url.QueryEscape(rawToken))
+	msg := email.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("A password reset was requested for the account %s.\n\n"+
+			"Open the link below to choose a new password, it expires in %d minutes:\n\n%s\n\n"+
+			"If you didn't request a password reset, you can ignore this email.\n",
+			user.Username, expiry, link),
+	}
+
+	a.sendEmailInBackground(msg, user.ID)
+	return nil
+}
+
+// ResetPassword sets a new password with a password reset token. The token
+// can only be used once, and all the sessions of the user are revoked.
+func (a *App) ResetPassword(token, newPassword string) error {
+	if err := auth.IsPasswordValid(newPassword, a.passwordSettings()); err != nil {
+		return model.NewErrBadRequest(err.Error())
+	}
+
+	resetToken, err := a.store.ConsumePasswordResetToken(model.HashToken(token))
+	if model.IsErrNotFound(err) {
+		return model.NewErrUnauthorized("invalid or expired password reset token")
+	}
+	if err != nil {
+		return err
+	}
+	if resetToken.IsExpired(utils.GetMillis()) {
+		return model.NewErrUnauthorized("invalid or expired password reset token")
+	}
+
+	user, err := a.store.GetUserByID(resetToken.UserID)
+	if err != nil {
+		return err
+	}
+	if user.DeleteAt != 0 || !hasLocalPassword(user) {
+		return model.NewErrUnauthorized("invalid or expired password reset token")
+	}
+
+	if err = a.store.UpdateUserPasswordByID(user.ID, auth.HashPassword(newPassword)); err != nil {
+		return err
+	}
+	if err = a.store.DeletePasswordResetTokensForUser(user.ID); err != nil {
+		return err
+	}
+	if err = a.RevokeSessionsForUser(user.ID); err != nil {
+		return err
+	}
+	// the access tokens could have been created by whoever knew the old
+	// password, so they are revoked with the sessions
+	if err = a.store.DeleteAccessTokensForUser(user.ID); err != nil {
+		return err
+	}
+	return a.UnlockUserLogin(user.ID)
+}
+
+// hasLocalPassword tells if the user logs in with a password stored by
+// the server, rather than with a directory or an identity provider.
+func hasLocalPassword(user *model.User) bool {
+	return user.AuthService != model.LdapAuthService && user.AuthService != model.OidcAuthService
+}
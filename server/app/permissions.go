@@ -1,9 +1,18 @@
 package app
 
 import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/permissions"
+
 	mm_model "github.com/mattermost/mattermost/server/public/model"
 )
 
 func (a *App) HasPermissionToBoard(userID, boardID string, permission *mm_model.Permission) bool {
 	return a.permissions.HasPermissionToBoard(userID, boardID, permission)
 }
+
+// ExplainBoardPermissions returns every board permission of a user and the
+// rule that granted or denied it.
+func (a *App) ExplainBoardPermissions(userID, boardID string) *model.BoardPermissionsExplanation {
+	return permissions.ExplainBoardPermissions(a.permissions, userID, boardID)
+}
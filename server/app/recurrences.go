@@ -0,0 +1,217 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const (
+	dueCardRecurrencesBatchSize = 100
+)
+
+func (a *App) GetCardRecurrence(cardID string) (*model.CardRecurrence, error) {
+	return a.store.GetCardRecurrence(cardID)
+}
+
+// SetCardRecurrence creates or replaces the recurrence of a card. When no
+// next run time is provided the first occurrence is computed from now.
+// Occurrences are computed in UTC and keep the time of day of the first one.
+func (a *App) SetCardRecurrence(recurrence *model.CardRecurrence, userID string) (*model.CardRecurrence, error) {
+	card, err := a.GetCardByID(recurrence.CardID)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := model.ParseRecurrenceRule(recurrence.Rule)
+	if err != nil {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+
+	if err = a.checkCardNotLocked(card.ID); err != nil {
+		return nil, err
+	}
+
+	recurrence.BoardID = card.BoardID
+	recurrence.Rule = rule.String()
+	recurrence.CreatedBy = userID
+
+	now := utils.GetMillis()
+	if recurrence.NextRunAt <= now {
+		from := utils.GetTimeForMillis(now).UTC()
+		if recurrence.NextRunAt != 0 {
+			from = utils.GetTimeForMillis(recurrence.NextRunAt).UTC()
+		}
+		recurrence.NextRunAt = utils.GetMillisForTime(nextOccurrenceAfter(rule, from, now))
+	}
+
+	if err = recurrence.IsValid(); err != nil {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+
+	return a.store.UpsertCardRecurrence(recurrence)
+}
+
+func (a *App) DeleteCardRecurrence(cardID string) error {
+	if err := a.checkCardNotLocked(cardID); err != nil {
+		return err
+	}
+	return a.store.DeleteCardRecurrence(cardID)
+}
+
+// ProcessDueCardRecurrences creates a copy of every card whose recurrence is
+// due. Each recurrence is claimed in the database before its copy is created,
+// so that several servers running this job never create the same occurrence
+// twice. Occurrences missed while the server was down are collapsed into one.
+func (a *App) ProcessDueCardRecurrences() {
+	now := utils.GetMillis()
+
+	recurrences, err := a.store.GetDueCardRecurrences(now, dueCardRecurrencesBatchSize)
+	if err != nil {
+		a.logger.Error("Cannot fetch due card recurrences", mlog.Err(err))
+		return
+	}
+
+	for _, recurrence := range recurrences {
+		if err := a.runCardRecurrence(recurrence, now); err != nil {
+			a.logger.Error("Cannot create card occurrence",
+				mlog.String("card_id", recurrence.CardID),
+				mlog.String("board_id", recurrence.BoardID),
+				mlog.Err(err),
+			)
+		}
+	}
+}
+
+func (a *App) runCardRecurrence(recurrence *model.CardRecurrence, now int64) error {
+	rule, err := model.ParseRecurrenceRule(recurrence.Rule)
+	if err != nil {
+		return err
+	}
+
+	card, err := a.store.GetBlock(recurrence.CardID)
+	if model.IsErrNotFound(err) {
+		// the source card is gone, so is its schedule
+		a.logger.Debug("Removing recurrence of deleted card", mlog.String("card_id", recurrence.CardID))
+		return a.store.DeleteCardRecurrence(recurrence.CardID)
+	}
+	if err != nil {
+		return err
+	}
+
+	occurrence := utils.GetTimeForMillis(recurrence.NextRunAt).UTC()
+	nextRunAt := utils.GetMillisForTime(nextOccurrenceAfter(rule, occurrence, now))
+
+	claimed, err := a.store.ClaimCardRecurrenceRun(recurrence.CardID, recurrence.NextRunAt, nextRunAt)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		// another node is creating this occurrence
+		return nil
+	}
+
+	if archivedAt, _ := model.BlockArchivedAt(card); archivedAt != 0 {
+		// the recurrence is paused while its card is archived, the run is
+		// still claimed so that the missed occurrences aren't created later
+		a.logger.Debug("Skipping recurrence of archived card", mlog.String("card_id", recurrence.CardID))
+		return nil
+	}
+
+	return a.createCardOccurrence(recurrence, card, recurrence.NextRunAt)
+}
+
+// createCardOccurrence clones the card with its content and moves its due
+// date to the occurrence. Assignees and other properties are carried over,
+// the archived state isn't.
+func (a *App) createCardOccurrence(recurrence *model.CardRecurrence, card *model.Block, occurrenceAt int64) error {
+	blocks, err := a.DuplicateBlock(recurrence.BoardID, recurrence.CardID, recurrence.CreatedBy, false)
+	if err != nil {
+		return fmt.Errorf("cannot duplicate card %s: %w", recurrence.CardID, err)
+	}
+	if len(blocks) == 0 {
+		return nil
+	}
+	newCard := blocks[0]
+
+	board, err := a.store.GetBoard(recurrence.BoardID)
+	if err != nil {
+		return err
+	}
+
+	patch := &model.BlockPatch{
+		UpdatedFields: map[string]interface{}{},
+		DeletedFields: []string{"archivedAt"},
+	}
+	if propertyID := recurrenceDueDateProperty(board, recurrence); propertyID != "" {
+		properties := make(map[string]interface{})
+		if props, ok := newCard.Fields["properties"].(map[string]interface{}); ok {
+			for k, v := range props {
+				properties[k] = v
+			}
+		}
+		properties[propertyID] = occurrenceDateValue(card, propertyID, occurrenceAt)
+		patch.UpdatedFields["properties"] = properties
+	}
+
+	if _, err := a.PatchBlockAndNotify(newCard.ID, patch, recurrence.CreatedBy, true); err != nil {
+		return fmt.Errorf("cannot update card occurrence %s: %w", newCard.ID, err)
+	}
+	return nil
+}
+
+// nextOccurrenceAfter returns the first occurrence after `from` that is also
+// after `now`.
+func nextOccurrenceAfter(rule *model.RecurrenceRule, from time.Time, now int64) time.Time {
+	next := rule.Next(from)
+	for utils.GetMillisForTime(next) <= now {
+		next = rule.Next(next)
+	}
+	return next
+}
+
+// recurrenceDueDateProperty returns the configured due date property, or the
+// first date property of the board.
+func recurrenceDueDateProperty(board *model.Board, recurrence *model.CardRecurrence) string {
+	for _, prop := range board.CardProperties {
+		propID, _ := prop["id"].(string)
+		propType, _ := prop["type"].(string)
+		if propType != "date" {
+			continue
+		}
+		if recurrence.DueDatePropertyID == "" || recurrence.DueDatePropertyID == propID {
+			return propID
+		}
+	}
+	return ""
+}
+
+// occurrenceDateValue builds the date property value for an occurrence,
+// keeping the length of the source card's date range if it has one.
+func occurrenceDateValue(card *model.Block, propertyID string, occurrenceAt int64) string {
+	value := map[string]int64{"from": occurrenceAt}
+
+	if props, ok := card.Fields["properties"].(map[string]interface{}); ok {
+		if dateStr, ok := props[propertyID].(string); ok {
+			var date map[string]int64
+			if err := json.Unmarshal([]byte(dateStr), &date); err == nil {
+				from, hasFrom := date["from"]
+				to, hasTo := date["to"]
+				if hasFrom && hasTo && to > from {
+					value["to"] = occurrenceAt + (to - from)
+				}
+			}
+		}
+	}
+
+	data, _ := json.Marshal(value)
+	return string(data)
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func TestSetCardRecurrence(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	card := &model.Block{
+		ID:      "card-id",
+		BoardID: "board-id",
+		Type:    model.TypeCard,
+		Fields:  map[string]interface{}{},
+	}
+
+	t.Run("computes the first occurrence", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card-id").Return(card, nil).Times(2)
+		th.Store.EXPECT().GetBoard("board-id").Return(&model.Board{ID: "board-id"}, nil)
+		th.Store.EXPECT().UpsertCardRecurrence(gomock.Any()).DoAndReturn(
+			func(recurrence *model.CardRecurrence) (*model.CardRecurrence, error) {
+				return recurrence, nil
+			})
+
+		recurrence, err := th.App.SetCardRecurrence(&model.CardRecurrence{
+			CardID: "card-id",
+			Rule:   "freq=weekly;byday=mo",
+		}, "user-id")
+		require.NoError(t, err)
+		assert.Equal(t, "board-id", recurrence.BoardID)
+		assert.Equal(t, "FREQ=WEEKLY;BYDAY=MO", recurrence.Rule)
+		assert.Equal(t, "user-id", recurrence.CreatedBy)
+		assert.Greater(t, recurrence.NextRunAt, utils.GetMillis())
+	})
+
+	t.Run("invalid rule", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card-id").Return(card, nil)
+
+		_, err := th.App.SetCardRecurrence(&model.CardRecurrence{
+			CardID: "card-id",
+			Rule:   "FREQ=YEARLY",
+		}, "user-id")
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("locked board", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card-id").Return(card, nil).Times(2)
+		th.Store.EXPECT().GetBoard("board-id").Return(&model.Board{ID: "board-id", IsLocked: true}, nil)
+
+		_, err := th.App.SetCardRecurrence(&model.CardRecurrence{
+			CardID: "card-id",
+			Rule:   "FREQ=DAILY",
+		}, "user-id")
+		require.ErrorIs(t, err, model.ErrBoardIsLocked)
+	})
+}
+
+func TestProcessDueCardRecurrences(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	now := utils.GetMillis()
+
+	t.Run("occurrence claimed by another node", func(t *testing.T) {
+		recurrence := &model.CardRecurrence{
+			CardID:    "card-id",
+			BoardID:   "board-id",
+			Rule:      "FREQ=DAILY",
+			NextRunAt: now - 1000,
+			CreatedBy: "user-id",
+		}
+		th.Store.EXPECT().GetDueCardRecurrences(gomock.Any(), uint64(dueCardRecurrencesBatchSize)).Return([]*model.CardRecurrence{recurrence}, nil)
+		th.Store.EXPECT().GetBlock("card-id").Return(&model.Block{ID: "card-id", BoardID: "board-id", Type: model.TypeCard}, nil)
+		th.Store.EXPECT().ClaimCardRecurrenceRun("card-id", now-1000, gomock.Any()).Return(false, nil)
+
+		// no DuplicateBlock call is expected
+		th.App.ProcessDueCardRecurrences()
+	})
+
+	t.Run("source card deleted", func(t *testing.T) {
+		recurrence := &model.CardRecurrence{
+			CardID:    "deleted-card-id",
+			BoardID:   "board-id",
+			Rule:      "FREQ=DAILY",
+			NextRunAt: now - 1000,
+			CreatedBy: "user-id",
+		}
+		th.Store.EXPECT().GetDueCardRecurrences(gomock.Any(), uint64(dueCardRecurrencesBatchSize)).Return([]*model.CardRecurrence{recurrence}, nil)
+		th.Store.EXPECT().GetBlock("deleted-card-id").Return(nil, model.NewErrNotFound("card"))
+		th.Store.EXPECT().DeleteCardRecurrence("deleted-card-id").Return(nil)
+
+		th.App.ProcessDueCardRecurrences()
+	})
+
+	t.Run("source card archived", func(t *testing.T) {
+		recurrence := &model.CardRecurrence{
+			CardID:    "archived-card-id",
+			BoardID:   "board-id",
+			Rule:      "FREQ=DAILY",
+			NextRunAt: now - 1000,
+			CreatedBy: "user-id",
+		}
+		card := &model.Block{
+			ID:      "archived-card-id",
+			BoardID: "board-id",
+			Type:    model.TypeCard,
+			Fields:  map[string]interface{}{"archivedAt": float64(now - 5000)},
+		}
+		th.Store.EXPECT().GetDueCardRecurrences(gomock.Any(), uint64(dueCardRecurrencesBatchSize)).Return([]*model.CardRecurrence{recurrence}, nil)
+		th.Store.EXPECT().GetBlock("archived-card-id").Return(card, nil)
+		th.Store.EXPECT().ClaimCardRecurrenceRun("archived-card-id", now-1000, gomock.Any()).Return(true, nil)
+
+		// the run is claimed but no DuplicateBlock call is expected
+		th.App.ProcessDueCardRecurrences()
+	})
+}
+
+func TestOccurrenceDateValue(t *testing.T) {
+	card := &model.Block{
+		Fields: map[string]interface{}{
+			"properties": map[string]interface{}{
+				"due":   `{"from":1000,"to":5000}`,
+				"start": `{"from":1000}`,
+			},
+		},
+	}
+
+	assert.Equal(t, `{"from":10000,"to":14000}`, occurrenceDateValue(card, "due", 10000))
+	assert.Equal(t, `{"from":10000}`, occurrenceDateValue(card, "start", 10000))
+	assert.Equal(t, `{"from":10000}`, occurrenceDateValue(card, "missing", 10000))
+}
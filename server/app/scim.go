@@ -0,0 +1,528 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"crypto/subtle"
+	"sort"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/auth"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/pkg/errors"
+)
+
+// ScimPath is the path prefix of the SCIM 2.0 provisioning API.
+const ScimPath = "/scim/v2"
+
+// IsScimEnabled returns true if the SCIM provisioning API is enabled and
+// has a token.
+func (a *App) IsScimEnabled() bool {
+	return a.config.Scim.Enable && a.config.Scim.Token != ""
+}
+
+// IsValidScimToken checks the bearer token of an identity provider.
+func (a *App) IsValidScimToken(token string) bool {
+	if !a.IsScimEnabled() || token == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(a.config.Scim.Token)) == 1
+}
+
+func (a *App) scimLocation(resourceType, id string) string {
+	return strings.TrimSuffix(a.config.ServerRoot, "/") + ScimPath + "/" + resourceType + "/" + id
+}
+
+// scimPage returns the page of a list starting at the 1-based startIndex.
+// A negative count returns the rest of the list.
+func scimPage(length, startIndex, count int) (int, int) {
+	start := startIndex - 1
+	if start < 0 {
+		start = 0
+	}
+	if start > length {
+		start = length
+	}
+	end := length
+	if count >= 0 && start+count < end {
+		end = start + count
+	}
+	return start, end
+}
+
+// GetScimUsers returns the page of the users matching the SCIM filter and
+// the number of matching users. The deactivated users are returned as
+// inactive, the users deleted by the identity provider aren't returned.
+// The supported filters are the equality of the userName, the email
+// address or the ID.
+func (a *App) GetScimUsers(filter string, startIndex, count int) ([]*model.ScimUser, int, error) {
+	opts := model.QueryUsersOptions{IncludeDeleted: true}
+	if filter != "" {
+		attribute, value, err := model.ParseScimFilter(filter)
+		if err != nil {
+			return nil, 0, err
+		}
+		switch attribute {
+		case "username":
+			opts.Username = value
+		case "emails", "emails.value":
+			opts.Email = strings.ToLower(value)
+		case "id":
+			opts.UserID = value
+		default:
+			return nil, 0, model.NewErrBadRequest("unsupported filter attribute " + attribute)
+		}
+	}
+
+	allUsers, err := a.store.GetUsers(opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	users := make([]*model.User, 0, len(allUsers))
+	for _, user := range allUsers {
+		if !isScimDeleted(user) {
+			users = append(users, user)
+		}
+	}
+
+	start, end := scimPage(len(users), startIndex, count)
+	scimUsers := make([]*model.ScimUser, 0, end-start)
+	for _, user := range users[start:end] {
+		scimUsers = append(scimUsers, model.ScimUserFromUser(user, a.scimLocation("Users", user.ID)))
+	}
+	return scimUsers, len(users), nil
+}
+
+// isScimDeleted tells if the identity provider deleted the user, rather
+// than only deactivating it. A user reactivated since then isn't deleted.
+func isScimDeleted(user *model.User) bool {
+	return user.ScimDeleted && user.DeleteAt != 0
+}
+
+// getScimUser fetches a provisioned user. The deactivated users are found,
+// so that the identity provider can update and reactivate them, but as
+// required by RFC 7644 the deleted users aren't found anymore.
+func (a *App) getScimUser(userID string) (*model.User, error) {
+	user, err := a.getUserIncludingDeleted(userID)
+	if err != nil {
+		return nil, err
+	}
+	if isScimDeleted(user) {
+		return nil, model.NewErrNotFound("user ID=" + userID)
+	}
+	return user, nil
+}
+
+func (a *App) GetScimUser(userID string) (*model.ScimUser, error) {
+	user, err := a.getScimUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	return model.ScimUserFromUser(user, a.scimLocation("Users", user.ID)), nil
+}
+
+// checkScimUserUnique checks that no other user, deactivated or not, has
+// the username or the email address.
+func (a *App) checkScimUserUnique(userID, username, email string) error {
+	for _, opts := range []model.QueryUsersOptions{
+		{Username: username, IncludeDeleted: true},
+		{Email: email, IncludeDeleted: true},
+	} {
+		users, err := a.store.GetUsers(opts)
+		if err != nil {
+			return err
+		}
+		for _, user := range users {
+			if user.ID != userID {
+				return model.NewErrConflict("a user with this userName or email address already exists")
+			}
+		}
+	}
+	return nil
+}
+
+// CreateScimUser provisions a user. The identity provider is trusted with
+// the email address, and the user has no password unless one is provided.
+func (a *App) CreateScimUser(scimUser *model.ScimUser) (*model.ScimUser, error) {
+	if err := scimUser.IsValid(); err != nil {
+		return nil, err
+	}
+
+	username := strings.TrimSpace(scimUser.UserName)
+	email := strings.ToLower(scimUser.PrimaryEmail())
+	if err := a.checkScimUserUnique("", username, email); err != nil {
+		return nil, err
+	}
+
+	password := ""
+	if scimUser.Password != "" {
+		if err := auth.IsPasswordValid(scimUser.Password, a.passwordSettings()); err != nil {
+			return nil, model.NewErrBadRequest(err.Error())
+		}
+		password = auth.HashPassword(scimUser.Password)
+	}
+
+	user := &model.User{
+		ID:            utils.NewID(utils.IDTypeUser),
+		Username:      username,
+		Email:         email,
+		Password:      password,
+		Nickname:      scimUser.DisplayName,
+		AuthService:   a.config.AuthMode,
+		EmailVerified: true,
+	}
+	if scimUser.Name != nil {
+		user.FirstName = scimUser.Name.GivenName
+		user.LastName = scimUser.Name.FamilyName
+	}
+
+	user, err := a.store.CreateUser(user)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create the new user")
+	}
+	a.logger.Info("Provisioned SCIM user", mlog.String("userID", user.ID))
+
+	if !scimUser.IsActive() {
+		if err := a.deactivateUser(user.ID); err != nil {
+			return nil, err
+		}
+	}
+	return a.GetScimUser(user.ID)
+}
+
+// ReplaceScimUser replaces the attributes of a user, and deactivates or
+// reactivates it.
+func (a *App) ReplaceScimUser(userID string, scimUser *model.ScimUser) (*model.ScimUser, error) {
+	user, err := a.getScimUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	return a.updateScimUser(user, scimUser)
+}
+
+// PatchScimUser applies the operations of a SCIM patch request to a user.
+func (a *App) PatchScimUser(userID string, operations []model.ScimPatchOperation) (*model.ScimUser, error) {
+	user, err := a.getScimUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	scimUser := model.ScimUserFromUser(user, "")
+	if err = scimUser.ApplyPatch(operations); err != nil {
+		return nil, err
+	}
+	return a.updateScimUser(user, scimUser)
+}
+
+// updateScimUser validates the whole update before writing any of it, so
+// that a rejected request doesn't leave the user partially updated.
+func (a *App) updateScimUser(user *model.User, scimUser *model.ScimUser) (*model.ScimUser, error) {
+	if err := scimUser.IsValid(); err != nil {
+		return nil, err
+	}
+
+	updated := *user
+	updated.Username = strings.TrimSpace(scimUser.UserName)
+	updated.Email = strings.ToLower(scimUser.PrimaryEmail())
+	updated.Nickname = scimUser.DisplayName
+	updated.FirstName = ""
+	updated.LastName = ""
+	if scimUser.Name != nil {
+		updated.FirstName = scimUser.Name.GivenName
+		updated.LastName = scimUser.Name.FamilyName
+	}
+	if err := a.checkScimUserUnique(user.ID, updated.Username, updated.Email); err != nil {
+		return nil, err
+	}
+
+	password := ""
+	if scimUser.Password != "" {
+		if err := auth.IsPasswordValid(scimUser.Password, a.passwordSettings()); err != nil {
+			return nil, model.NewErrBadRequest(err.Error())
+		}
+		password = auth.HashPassword(scimUser.Password)
+	}
+
+	if _, err := a.store.UpdateUser(&updated); err != nil {
+		return nil, err
+	}
+	if password != "" {
+		if err := a.store.UpdateUserPasswordByID(user.ID, password); err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case scimUser.IsActive() && user.DeleteAt != 0:
+		if err := a.store.UpdateUserDeleteAt(user.ID, 0); err != nil {
+			return nil, err
+		}
+		a.logger.Info("Reactivated SCIM user", mlog.String("userID", user.ID))
+	case !scimUser.IsActive() && user.DeleteAt == 0:
+		if err := a.deactivateUser(user.ID); err != nil {
+			return nil, err
+		}
+		a.logger.Info("Deactivated SCIM user", mlog.String("userID", user.ID))
+	}
+	return a.GetScimUser(user.ID)
+}
+
+// DeleteScimUser deprovisions a user: it is deactivated, which revokes its
+// sessions, and removed from the managed teams, except the ones it is the
+// last admin of. The account is kept so that the boards and their history
+// keep their authors, but it isn't found by the SCIM API anymore.
+func (a *App) DeleteScimUser(userID string) error {
+	user, err := a.getScimUser(userID)
+	if err != nil {
+		return err
+	}
+	if user.DeleteAt == 0 {
+		if err = a.deactivateUser(user.ID); err != nil {
+			return err
+		}
+	}
+
+	teams, err := a.store.GetTeamsForUser(user.ID)
+	if err != nil {
+		return err
+	}
+	for _, team := range teams {
+		if !team.IsManaged() {
+			continue
+		}
+		err = a.checkNotLastTeamAdmin(team.ID, user.ID)
+		if err != nil && !model.IsErrBadRequest(err) {
+			return err
+		}
+		if err != nil {
+			a.logger.Warn("Deprovisioned SCIM user kept as the last admin of a team",
+				mlog.String("userID", user.ID),
+				mlog.String("teamID", team.ID),
+			)
+			continue
+		}
+		if err = a.store.DeleteTeamMember(team.ID, user.ID); err != nil && !model.IsErrNotFound(err) {
+			return err
+		}
+	}
+
+	if err = a.store.UpdateUserScimDeleted(user.ID, true); err != nil {
+		return err
+	}
+	a.logger.Info("Deprovisioned SCIM user", mlog.String("userID", user.ID))
+	return nil
+}
+
+// getScimTeams returns the active managed teams, sorted by title.
+func (a *App) getScimTeams() ([]*model.Team, error) {
+	teams, err := a.store.GetAllTeams()
+	if err != nil {
+		return nil, err
+	}
+
+	managed := make([]*model.Team, 0, len(teams))
+	for _, team := range teams {
+		if team.IsManaged() && team.DeleteAt == 0 {
+			managed = append(managed, team)
+		}
+	}
+	sort.Slice(managed, func(i, j int) bool {
+		if managed[i].Title != managed[j].Title {
+			return managed[i].Title < managed[j].Title
+		}
+		return managed[i].ID < managed[j].ID
+	})
+	return managed, nil
+}
+
+func (a *App) scimGroupFromTeam(team *model.Team) (*model.ScimGroup, error) {
+	members, err := a.store.GetTeamMembers(team.ID)
+	if err != nil {
+		return nil, err
+	}
+	return model.ScimGroupFromTeam(team, members, a.scimLocation("Groups", team.ID)), nil
+}
+
+// GetScimGroups returns the page of the managed teams matching the SCIM
+// filter and the number of matching teams. The supported filters are the
+// equality of the displayName or the ID.
+func (a *App) GetScimGroups(filter string, startIndex, count int) ([]*model.ScimGroup, int, error) {
+	teams, err := a.getScimTeams()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if filter != "" {
+		attribute, value, err := model.ParseScimFilter(filter)
+		if err != nil {
+			return nil, 0, err
+		}
+		if attribute != "displayname" && attribute != "id" {
+			return nil, 0, model.NewErrBadRequest("unsupported filter attribute " + attribute)
+		}
+
+		matching := []*model.Team{}
+		for _, team := range teams {
+			if (attribute == "displayname" && team.Title == value) || (attribute == "id" && team.ID == value) {
+				matching = append(matching, team)
+			}
+		}
+		teams = matching
+	}
+
+	start, end := scimPage(len(teams), startIndex, count)
+	groups := make([]*model.ScimGroup, 0, end-start)
+	for _, team := range teams[start:end] {
+		group, err := a.scimGroupFromTeam(team)
+		if err != nil {
+			return nil, 0, err
+		}
+		groups = append(groups, group)
+	}
+	return groups, len(teams), nil
+}
+
+func (a *App) GetScimGroup(teamID string) (*model.ScimGroup, error) {
+	team, err := a.getManagedTeam(teamID)
+	if err != nil {
+		if model.IsErrBadRequest(err) {
+			return nil, model.NewErrNotFound("group ID=" + teamID)
+		}
+		return nil, err
+	}
+	return a.scimGroupFromTeam(team)
+}
+
+// checkScimGroupUnique checks that no other active managed team has the
+// title.
+func (a *App) checkScimGroupUnique(teamID, title string) error {
+	teams, err := a.getScimTeams()
+	if err != nil {
+		return err
+	}
+	for _, team := range teams {
+		if team.ID != teamID && team.Title == strings.TrimSpace(title) {
+			return model.NewErrConflict("a group with this displayName already exists")
+		}
+	}
+	return nil
+}
+
+// CreateScimGroup creates a managed team with the members of the group.
+// The team has no admin, it is managed by the identity provider and the
+// system admins.
+func (a *App) CreateScimGroup(group *model.ScimGroup) (*model.ScimGroup, error) {
+	if err := a.checkScimGroupUnique("", group.DisplayName); err != nil {
+		return nil, err
+	}
+
+	team, err := a.store.CreateTeam(&model.Team{Title: group.DisplayName}, model.SystemUserID)
+	if err != nil {
+		return nil, err
+	}
+	a.logger.Info("Provisioned SCIM group", mlog.String("teamID", team.ID))
+
+	if err = a.syncScimGroupMembers(team.ID, group.Members); err != nil {
+		return nil, err
+	}
+	return a.GetScimGroup(team.ID)
+}
+
+// ReplaceScimGroup replaces the title and the members of a managed team.
+func (a *App) ReplaceScimGroup(teamID string, group *model.ScimGroup) (*model.ScimGroup, error) {
+	current, err := a.GetScimGroup(teamID)
+	if err != nil {
+		return nil, err
+	}
+	return a.updateScimGroup(current, group)
+}
+
+// PatchScimGroup applies the operations of a SCIM patch request to a
+// managed team.
+func (a *App) PatchScimGroup(teamID string, operations []model.ScimPatchOperation) (*model.ScimGroup, error) {
+	current, err := a.GetScimGroup(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	group := *current
+	group.Members = append([]model.ScimMember{}, current.Members...)
+	if err = group.ApplyPatch(operations); err != nil {
+		return nil, err
+	}
+	return a.updateScimGroup(current, &group)
+}
+
+func (a *App) updateScimGroup(current, group *model.ScimGroup) (*model.ScimGroup, error) {
+	if group.DisplayName != current.DisplayName {
+		if err := a.checkScimGroupUnique(current.ID, group.DisplayName); err != nil {
+			return nil, err
+		}
+		title := group.DisplayName
+		if _, err := a.store.PatchTeam(current.ID, &model.TeamPatch{Title: &title}, model.SystemUserID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := a.syncScimGroupMembers(current.ID, group.Members); err != nil {
+		return nil, err
+	}
+	return a.GetScimGroup(current.ID)
+}
+
+// syncScimGroupMembers makes the members of the group the members of the
+// team. The team admins are kept, so that the teams created in focalboard
+// remain manageable, and the new members are regular team members.
+func (a *App) syncScimGroupMembers(teamID string, members []model.ScimMember) error {
+	current, err := a.store.GetTeamMembers(teamID)
+	if err != nil {
+		return err
+	}
+	currentIDs := make(map[string]*model.TeamMember, len(current))
+	for _, member := range current {
+		currentIDs[member.UserID] = member
+	}
+
+	wanted := make(map[string]bool, len(members))
+	for _, member := range members {
+		if wanted[member.Value] {
+			continue
+		}
+		wanted[member.Value] = true
+		if _, ok := currentIDs[member.Value]; ok {
+			continue
+		}
+
+		if _, err = a.getScimUser(member.Value); err != nil {
+			if model.IsErrNotFound(err) {
+				return model.NewErrBadRequest("unknown member " + member.Value)
+			}
+			return err
+		}
+		if _, err = a.store.SaveTeamMember(&model.TeamMember{TeamID: teamID, UserID: member.Value}); err != nil {
+			return err
+		}
+	}
+
+	for _, member := range current {
+		if wanted[member.UserID] || member.SchemeAdmin {
+			continue
+		}
+		if err = a.store.DeleteTeamMember(teamID, member.UserID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteScimGroup archives a managed team.
+func (a *App) DeleteScimGroup(teamID string) error {
+	if _, err := a.GetScimGroup(teamID); err != nil {
+		return err
+	}
+	return a.store.ArchiveTeam(teamID, model.SystemUserID)
+}
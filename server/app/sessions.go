@@ -0,0 +1,105 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// createSession creates a new session for the user. The props record the
+// client that logged in, they may be nil.
+func (a *App) createSession(userID, authService string, props map[string]interface{}) (*model.Session, error) {
+	if props == nil {
+		props = map[string]interface{}{}
+	}
+
+	session := &model.Session{
+		ID:          utils.NewID(utils.IDTypeSession),
+		Token:       utils.NewID(utils.IDTypeToken),
+		UserID:      userID,
+		AuthService: authService,
+		Props:       props,
+	}
+	if err := a.store.CreateSession(session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// GetSessionsForUser returns the active sessions of the user, the most
+// recently active first.
+func (a *App) GetSessionsForUser(userID string) ([]*model.Session, error) {
+	return a.store.GetSessionsForUser(userID, a.config.SessionExpireTime)
+}
+
+// RevokeSession revokes one of the sessions of the user and closes its
+// websocket connections.
+func (a *App) RevokeSession(userID, sessionID string) error {
+	sessions, err := a.GetSessionsForUser(userID)
+	if err != nil {
+		return err
+	}
+
+	for _, session := range sessions {
+		if session.ID == sessionID {
+			return a.revokeSessions([]string{sessionID})
+		}
+	}
+	return model.NewErrNotFound("session ID=" + sessionID)
+}
+
+// RevokeOtherSessions revokes every session of the user but the current
+// one, and returns the number of revoked sessions.
+func (a *App) RevokeOtherSessions(userID, currentSessionID string) (int, error) {
+	sessions, err := a.GetSessionsForUser(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	sessionIDs := []string{}
+	for _, session := range sessions {
+		if session.ID != currentSessionID {
+			sessionIDs = append(sessionIDs, session.ID)
+		}
+	}
+	return len(sessionIDs), a.revokeSessions(sessionIDs)
+}
+
+// RevokeSessionsForUser revokes every session of the user and closes their
+// websocket connections.
+func (a *App) RevokeSessionsForUser(userID string) error {
+	sessions, err := a.GetSessionsForUser(userID)
+	if err != nil {
+		return err
+	}
+
+	if err := a.store.DeleteSessionsForUser(userID); err != nil {
+		return err
+	}
+
+	sessionIDs := make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		sessionIDs = append(sessionIDs, session.ID)
+	}
+	a.wsAdapter.CloseSessionConnections(sessionIDs)
+
+	a.logger.Debug("Revoked the sessions of the user",
+		mlog.String("userID", userID),
+		mlog.Int("sessions", len(sessionIDs)),
+	)
+	return nil
+}
+
+func (a *App) revokeSessions(sessionIDs []string) error {
+	for _, sessionID := range sessionIDs {
+		if err := a.store.DeleteSession(sessionID); err != nil {
+			return err
+		}
+	}
+	a.wsAdapter.CloseSessionConnections(sessionIDs)
+	return nil
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/auth"
+)
+
+func (a *App) GetShareLinksForBoard(boardID string) ([]*model.ShareLink, error) {
+	return a.store.GetShareLinksForBoard(boardID)
+}
+
+// GetShareLink fetches a share link, making sure that it belongs to the
+// board.
+func (a *App) GetShareLink(boardID, linkID string) (*model.ShareLink, error) {
+	link, err := a.store.GetShareLink(linkID)
+	if err != nil {
+		return nil, err
+	}
+	if link.BoardID != boardID {
+		return nil, model.NewErrNotFound("share link ID=" + linkID)
+	}
+	return link, nil
+}
+
+// CreateShareLink creates a new share link for a board, with a new read
+// token and the password, if any, hashed.
+func (a *App) CreateShareLink(link *model.ShareLink, userID string) (*model.ShareLink, error) {
+	if !a.config.EnablePublicSharedBoards {
+		return nil, model.NewErrBadRequest("public shared boards disabled")
+	}
+
+	link.ID = ""
+	link.Token = ""
+	link.CreatedBy = userID
+	if link.Password != "" {
+		if err := auth.IsPasswordValid(link.Password, auth.PasswordSettings{MinimumLength: 1}); err != nil {
+			return nil, model.NewErrBadRequest(err.Error())
+		}
+		link.Password = auth.HashPassword(link.Password)
+	}
+	return a.store.CreateShareLink(link)
+}
+
+// DeleteShareLink revokes a share link. The rest of the links of the board
+// keep working.
+func (a *App) DeleteShareLink(boardID, linkID string) error {
+	if _, err := a.GetShareLink(boardID, linkID); err != nil {
+		return err
+	}
+	return a.store.DeleteShareLink(linkID)
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/email"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func (a *App) GetTeamInvites(teamID string) ([]*model.TeamInvite, error) {
+	if _, err := a.getManagedTeam(teamID); err != nil {
+		return nil, err
+	}
+	return a.store.GetTeamInvites(teamID)
+}
+
+// CreateTeamInvite creates an invitation to a team. The invitation id is
+// the token that users register or accept the invitation with. The
+// invitations restricted to an email address are emailed to it when the
+// server can send emails.
+func (a *App) CreateTeamInvite(invite *model.TeamInvite, userID string) (*model.TeamInvite, error) {
+	team, err := a.getManagedTeam(invite.TeamID)
+	if err != nil {
+		return nil, err
+	}
+	if invite.ExpireAt != 0 && invite.ExpireAt <= utils.GetMillis() {
+		return nil, model.NewErrBadRequest("the invitation expiry time is past")
+	}
+	for _, boardID := range invite.BoardIDs {
+		board, err := a.store.GetBoard(boardID)
+		if err != nil && !model.IsErrNotFound(err) {
+			return nil, err
+		}
+		if board == nil || board.TeamID != invite.TeamID {
+			return nil, model.NewErrBadRequest("the board " + boardID + " is not a board of the team")
+		}
+	}
+
+	invite.CreatedBy = userID
+	created, err := a.store.CreateTeamInvite(invite)
+	if err != nil {
+		return nil, err
+	}
+
+	if created.Email != "" && a.isEmailConfigured() {
+		a.sendTeamInviteEmail(created, team)
+	}
+	return created, nil
+}
+
+func (a *App) sendTeamInviteEmail(invite *model.TeamInvite, team *model.Team) {
+	link := a.serverLink("/register?t=" + url.QueryEscape(invite.ID))
+	expireAt := time.UnixMilli(invite.ExpireAt).UTC().Format("January 2, 2006 15:04 MST")
+	a.sendEmailInBackground(email.Message{
+		To:      invite.Email,
+		Subject: "You're invited to join " + team.Title,
+		Body: fmt.Sprintf("You're invited to join the team %s.\n\n"+
+			"Open the link below to create your account, the invitation expires on %s:\n\n%s\n\n"+
+			"If you already have an account, accept the invitation with the token %s.\n",
+			team.Title, expireAt, link, invite.ID),
+	}, invite.CreatedBy)
+}
+
+// GetTeamInvite fetches an invitation, making sure that it belongs to the
+// team.
+func (a *App) GetTeamInvite(teamID, inviteID string) (*model.TeamInvite, error) {
+	invite, err := a.store.GetTeamInvite(inviteID)
+	if err != nil {
+		return nil, err
+	}
+	if invite.TeamID != teamID {
+		return nil, model.NewErrNotFound("team invite")
+	}
+	return invite, nil
+}
+
+func (a *App) DeleteTeamInvite(teamID, inviteID string) error {
+	if _, err := a.GetTeamInvite(teamID, inviteID); err != nil {
+		return err
+	}
+	return a.store.DeleteTeamInvite(inviteID)
+}
+
+// getAcceptableTeamInvite returns a pending invitation to an active team
+// that the user with the email can accept.
+func (a *App) getAcceptableTeamInvite(inviteID, email string) (*model.TeamInvite, error) {
+	invite, err := a.store.GetTeamInvite(inviteID)
+	if err != nil {
+		return nil, err
+	}
+	if err = invite.CanBeAcceptedBy(email, utils.GetMillis()); err != nil {
+		return nil, err
+	}
+	if _, err = a.getManagedTeam(invite.TeamID); err != nil {
+		return nil, err
+	}
+	return invite, nil
+}
+
+// AcceptTeamInvite adds the user to the team of the invitation with the
+// role of the invitation.
+func (a *App) AcceptTeamInvite(inviteID, userID string) (*model.TeamMember, error) {
+	user, err := a.store.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	invite, err := a.getAcceptableTeamInvite(inviteID, user.Email)
+	if err != nil {
+		return nil, err
+	}
+	member, err := a.store.AcceptTeamInvite(inviteID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err = a.addTeamInviteBoardMembers(invite, userID); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// addTeamInviteBoardMembers adds the user who accepted an invitation to
+// the boards of the invitation. The boards deleted since the invitation
+// was created are skipped.
+func (a *App) addTeamInviteBoardMembers(invite *model.TeamInvite, userID string) error {
+	for _, boardID := range invite.BoardIDs {
+		member := &model.BoardMember{BoardID: boardID, UserID: userID}
+		setBoardMemberRole(member, invite.GetBoardRole())
+		if _, err := a.AddMemberToBoard(member); err != nil {
+			return err
+		}
+	}
+	return nil
+}
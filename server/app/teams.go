@@ -63,3 +63,115 @@ func (a *App) UpsertTeamSignupToken(team model.Team) error {
 func (a *App) GetTeamCount() (int64, error) {
 	return a.store.GetTeamCount()
 }
+
+// CreateTeam creates a managed team with the user as its admin.
+func (a *App) CreateTeam(team *model.Team, userID string) (*model.Team, error) {
+	return a.store.CreateTeam(team, userID)
+}
+
+// getManagedTeam returns an active managed team. The root team can't be
+// modified and has no members.
+func (a *App) getManagedTeam(teamID string) (*model.Team, error) {
+	team, err := a.store.GetTeam(teamID)
+	if err != nil {
+		return nil, err
+	}
+	if !team.IsManaged() {
+		return nil, model.NewErrBadRequest("the root team cannot be managed")
+	}
+	if team.DeleteAt != 0 {
+		return nil, model.NewErrBadRequest("the team is archived")
+	}
+	return team, nil
+}
+
+func (a *App) PatchTeam(teamID string, patch *model.TeamPatch, userID string) (*model.Team, error) {
+	if _, err := a.getManagedTeam(teamID); err != nil {
+		return nil, err
+	}
+	return a.store.PatchTeam(teamID, patch, userID)
+}
+
+// ArchiveTeam archives a team. Its members lose access to the team and its
+// boards, which are kept in the store.
+func (a *App) ArchiveTeam(teamID string, userID string) error {
+	if _, err := a.getManagedTeam(teamID); err != nil {
+		return err
+	}
+	return a.store.ArchiveTeam(teamID, userID)
+}
+
+// RegenerateTeamSignupToken replaces the signup token of a managed team.
+func (a *App) RegenerateTeamSignupToken(teamID string, userID string) error {
+	team, err := a.getManagedTeam(teamID)
+	if err != nil {
+		return err
+	}
+	team.SignupToken = utils.NewID(utils.IDTypeToken)
+	team.ModifiedBy = userID
+	return a.store.UpsertTeamSignupToken(*team)
+}
+
+func (a *App) GetTeamMembers(teamID string) ([]*model.TeamMember, error) {
+	if _, err := a.getManagedTeam(teamID); err != nil {
+		return nil, err
+	}
+	return a.store.GetTeamMembers(teamID)
+}
+
+func (a *App) GetTeamMember(teamID, userID string) (*model.TeamMember, error) {
+	return a.store.GetTeamMember(teamID, userID)
+}
+
+// SaveTeamMember adds a user to a team or changes its team role. The last
+// admin of a team can't be demoted.
+func (a *App) SaveTeamMember(member *model.TeamMember) (*model.TeamMember, error) {
+	if _, err := a.getManagedTeam(member.TeamID); err != nil {
+		return nil, err
+	}
+	if _, err := a.store.GetUserByID(member.UserID); err != nil {
+		return nil, err
+	}
+
+	if !member.SchemeAdmin {
+		if err := a.checkNotLastTeamAdmin(member.TeamID, member.UserID); err != nil {
+			return nil, err
+		}
+	}
+	return a.store.SaveTeamMember(member)
+}
+
+// DeleteTeamMember removes a user from a team. The last admin of a team
+// can't leave it.
+func (a *App) DeleteTeamMember(teamID, userID string) error {
+	if _, err := a.getManagedTeam(teamID); err != nil {
+		return err
+	}
+	if err := a.checkNotLastTeamAdmin(teamID, userID); err != nil {
+		return err
+	}
+	return a.store.DeleteTeamMember(teamID, userID)
+}
+
+func (a *App) checkNotLastTeamAdmin(teamID, userID string) error {
+	members, err := a.store.GetTeamMembers(teamID)
+	if err != nil {
+		return err
+	}
+
+	isAdmin := false
+	admins := 0
+	for _, member := range members {
+		if !member.SchemeAdmin {
+			continue
+		}
+		admins++
+		if member.UserID == userID {
+			isAdmin = true
+		}
+	}
+	if isAdmin && admins == 1 {
+		return model.NewErrBadRequest("cannot remove the last admin of the team")
+	}
+	return nil
+}
@@ -152,3 +152,67 @@ func TestTeamOperations(t *testing.T) {
 	assert.NoError(t, errGetTeamCount)
 	assert.Equal(t, int64(10), count)
 }
+
+func TestTeamMembers(t *testing.T) {
+	managedTeam := &model.Team{ID: "managed-team-id", Title: "Managed"}
+	members := []*model.TeamMember{
+		{TeamID: managedTeam.ID, UserID: "admin-id", SchemeAdmin: true},
+		{TeamID: managedTeam.ID, UserID: "member-id"},
+	}
+
+	t.Run("the root team has no members to manage", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		th.Store.EXPECT().GetTeam(model.GlobalTeamID).Return(&model.Team{ID: model.GlobalTeamID}, nil)
+
+		_, err := th.App.GetTeamMembers(model.GlobalTeamID)
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("the last admin can't be demoted", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		th.Store.EXPECT().GetTeam(managedTeam.ID).Return(managedTeam, nil)
+		th.Store.EXPECT().GetUserByID("admin-id").Return(&model.User{ID: "admin-id"}, nil)
+		th.Store.EXPECT().GetTeamMembers(managedTeam.ID).Return(members, nil)
+
+		_, err := th.App.SaveTeamMember(&model.TeamMember{TeamID: managedTeam.ID, UserID: "admin-id"})
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("the last admin can't leave", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		th.Store.EXPECT().GetTeam(managedTeam.ID).Return(managedTeam, nil)
+		th.Store.EXPECT().GetTeamMembers(managedTeam.ID).Return(members, nil)
+
+		err := th.App.DeleteTeamMember(managedTeam.ID, "admin-id")
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("members can leave", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		th.Store.EXPECT().GetTeam(managedTeam.ID).Return(managedTeam, nil)
+		th.Store.EXPECT().GetTeamMembers(managedTeam.ID).Return(members, nil)
+		th.Store.EXPECT().DeleteTeamMember(managedTeam.ID, "member-id").Return(nil)
+
+		require.NoError(t, th.App.DeleteTeamMember(managedTeam.ID, "member-id"))
+	})
+
+	t.Run("archived teams can't be modified", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		archived := &model.Team{ID: "archived-team-id", Title: "Archived", DeleteAt: 1}
+		th.Store.EXPECT().GetTeam(archived.ID).Return(archived, nil)
+
+		title := "Renamed"
+		_, err := th.App.PatchTeam(archived.ID, &model.TeamPatch{Title: &title}, "admin-id")
+		require.True(t, model.IsErrBadRequest(err))
+	})
+}
@@ -9,6 +9,10 @@ func (a *App) GetTeamUsers(teamID string, asGuestID string) ([]*model.User, erro
 	return a.store.GetUsersByTeam(teamID, asGuestID, a.config.ShowEmailAddress, a.config.ShowFullName)
 }
 
+func (a *App) GetUserByUsername(username string) (*model.User, error) {
+	return a.store.GetUserByUsername(username)
+}
+
 func (a *App) SearchTeamUsers(teamID string, searchQuery string, asGuestID string, excludeBots bool) ([]*model.User, error) {
 	users, err := a.store.SearchUsersByTeam(teamID, searchQuery, asGuestID, excludeBots, a.config.ShowEmailAddress, a.config.ShowFullName)
 	if err != nil {
@@ -47,6 +51,16 @@ func (a *App) UserIsGuest(userID string) (bool, error) {
 	return user.IsGuest, nil
 }
 
+// UpdateUserGuest converts a user to a guest, that can only see the boards
+// that it is explicitly added to, or back to a regular user. Guest accounts
+// with an expiry time can't be used after it.
+func (a *App) UpdateUserGuest(userID string, isGuest bool, guestExpireAt int64) error {
+	if guestExpireAt < 0 {
+		return model.NewErrBadRequest("invalid guest expiry time")
+	}
+	return a.store.UpdateUserGuest(userID, isGuest, guestExpireAt)
+}
+
 func (a *App) CanSeeUser(seerUser string, seenUser string) (bool, error) {
 	isGuest, err := a.UserIsGuest(seerUser)
 	if err != nil {
@@ -2,6 +2,9 @@
 package auth
 
 import (
+	"strings"
+	"time"
+
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/config"
 	"github.com/mattermost/focalboard/server/services/permissions"
@@ -12,7 +15,7 @@ import (
 
 type AuthInterface interface {
 	GetSession(token string) (*model.Session, error)
-	IsValidReadToken(boardID string, readToken string) (bool, error)
+	IsValidReadToken(boardID string, readToken string, credentials model.ShareLinkCredentials) (bool, error)
 	DoesUserHaveTeamAccess(userID string, teamID string) bool
 	GetUserID() string
 }
@@ -35,11 +38,19 @@ func (a *Auth) GetSession(token string) (*model.Session, error) {
 	if len(token) < 1 {
 		return nil, errors.New("no session token")
 	}
+	if strings.HasPrefix(token, model.AccessTokenPrefix) {
+		return a.getAccessTokenSession(token)
+	}
 
 	session, err := a.store.GetSession(token, a.config.SessionExpireTime)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to get the session for the token")
 	}
+	// the sessions of expired guest accounts can't be used anymore
+	user, err := a.store.GetUserByID(session.UserID)
+	if err == nil && user.IsExpiredGuest(utils.GetMillis()) {
+		return nil, errors.New("the guest account has expired")
+	}
 	if session.UpdateAt < (utils.GetMillis() - utils.SecondsToMillis(a.config.SessionRefreshTime)) {
 		_ = a.store.RefreshSession(session)
 	}
@@ -47,16 +58,69 @@ func (a *Auth) GetSession(token string) (*model.Session, error) {
 	return session, nil
 }
 
-// IsValidReadToken validates the read token for a board.
-func (a *Auth) IsValidReadToken(boardID string, readToken string) (bool, error) {
-	sharing, err := a.store.GetSharing(boardID)
-	if model.IsErrNotFound(err) {
-		return false, nil
+// getAccessTokenSession returns a session for a personal access token,
+// limited to the scopes of the token.
+func (a *Auth) getAccessTokenSession(token string) (*model.Session, error) {
+	accessToken, err := a.store.GetAccessTokenByHash(model.HashToken(token))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get the access token")
 	}
+
+	now := utils.GetMillis()
+	if accessToken.IsExpired(now) {
+		return nil, errors.New("the access token has expired")
+	}
+
+	// the tokens of deactivated users are deleted with their sessions,
+	// and deactivated users aren't returned either, so the tokens don't
+	// work again if the user is reactivated
+	user, err := a.store.GetUserByID(accessToken.UserID)
 	if err != nil {
+		return nil, errors.Wrap(err, "unable to get the user of the access token")
+	}
+	if user.IsExpiredGuest(now) {
+		return nil, errors.New("the guest account has expired")
+	}
+
+	if accessToken.LastUsedAt < (now - utils.SecondsToMillis(a.config.SessionRefreshTime)) {
+		_ = a.store.UpdateAccessTokenLastUsed(accessToken.ID, now)
+	}
+
+	a.userID = accessToken.UserID
+	return &model.Session{
+		ID:          accessToken.ID,
+		Token:       token,
+		UserID:      accessToken.UserID,
+		AuthService: a.config.AuthMode,
+		Props: map[string]interface{}{
+			model.SessionPropAccessTokenID:     accessToken.ID,
+			model.SessionPropAccessTokenScopes: accessToken.Scopes,
+		},
+		CreateAt: accessToken.CreateAt,
+		UpdateAt: now,
+	}, nil
+}
+
+// IsValidReadToken validates the read token for a board. The token can be
+// the one of the board sharing settings or the one of any of its share
+// links, in which case the link must be usable and the password, if it
+// has one, must match. Single view links are bound to the first view they
+// are used for.
+func (a *Auth) IsValidReadToken(boardID string, readToken string, credentials model.ShareLinkCredentials) (bool, error) {
+	sharing, err := a.store.GetSharing(boardID)
+	if err != nil && !model.IsErrNotFound(err) {
 		return false, err
 	}
 
+	link, err := a.store.GetShareLinkByToken(boardID, readToken)
+	if err != nil && !model.IsErrNotFound(err) {
+		return false, err
+	}
+
+	if sharing == nil && link == nil {
+		return false, nil
+	}
+
 	if !a.config.EnablePublicSharedBoards {
 		return false, errors.New("public shared boards disabled")
 	}
@@ -65,6 +129,63 @@ func (a *Auth) IsValidReadToken(boardID string, readToken string) (bool, error)
 		return true, nil
 	}
 
+	if link == nil {
+		return false, nil
+	}
+
+	now := utils.GetMillis()
+	if !link.IsUsable(now, credentials.ViewID) {
+		return false, nil
+	}
+	if link.Password != "" {
+		valid, err := a.checkShareLinkPassword(link, credentials.Password, now)
+		if !valid || err != nil {
+			return false, err
+		}
+	}
+
+	if link.SingleView && link.ViewID == "" {
+		claimed, err := a.store.ClaimShareLinkView(link.ID, credentials.ViewID)
+		if !claimed || err != nil {
+			return false, err
+		}
+	}
+
+	if err := a.store.RecordShareLinkAccess(link.ID, now); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// checkShareLinkPassword checks the password of a share link. The wrong
+// passwords are counted like failed logins, and once the link reaches the
+// maximum number of failures of an account it's locked out.
+func (a *Auth) checkShareLinkPassword(link *model.ShareLink, password string, now int64) (bool, error) {
+	lockout := a.config.LoginLockout
+	key := model.LoginAttemptsShareLinkKey(link.ID)
+	lockoutDuration := (time.Duration(lockout.LockoutMinutes) * time.Minute).Milliseconds()
+
+	if lockout.Enable && lockout.MaxAccountFailures > 0 {
+		attempts, err := a.store.GetLoginAttempts(key)
+		if err != nil && !model.IsErrNotFound(err) {
+			return false, err
+		}
+		if attempts != nil && attempts.FailedCount >= lockout.MaxAccountFailures {
+			if retryAt := attempts.LastFailureAt + lockoutDuration; retryAt > now {
+				return false, model.NewErrTooManyRequests("too many wrong share link passwords, try again later", (retryAt-now+999)/1000)
+			}
+		}
+	}
+
+	if link.CheckPassword(password) {
+		return true, nil
+	}
+
+	if lockout.Enable {
+		if _, err := a.store.IncrementLoginAttempts(key, now-lockoutDuration); err != nil {
+			return false, err
+		}
+	}
 	return false, nil
 }
 
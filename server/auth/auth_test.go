@@ -5,6 +5,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/mattermost/focalboard/server/model"
+	passwordauth "github.com/mattermost/focalboard/server/services/auth"
 	"github.com/mattermost/focalboard/server/services/config"
 	"github.com/mattermost/focalboard/server/services/permissions/localpermissions"
 	mockpermissions "github.com/mattermost/focalboard/server/services/permissions/mocks"
@@ -69,6 +70,7 @@ func TestGetSession(t *testing.T) {
 	th.Store.EXPECT().GetSession("badToken", gomock.Any()).Return(nil, errors.New("Invalid Token"))
 	th.Store.EXPECT().GetSession("goodToken", gomock.Any()).Return(mockSession, nil)
 	th.Store.EXPECT().RefreshSession(gomock.Any()).Return(nil)
+	th.Store.EXPECT().GetUserByID(mockSession.UserID).Return(&model.User{ID: mockSession.UserID}, nil)
 
 	for _, test := range testcases {
 		t.Run(test.title, func(t *testing.T) {
@@ -87,6 +89,73 @@ func TestGetSession(t *testing.T) {
 	}
 }
 
+func TestGetSessionExpiredGuest(t *testing.T) {
+	th := setupTestHelper(t)
+
+	guest := &model.User{ID: mockSession.UserID, IsGuest: true, GuestExpireAt: utils.GetMillis() - 1000}
+	th.Store.EXPECT().GetSession("goodToken", gomock.Any()).Return(mockSession, nil)
+	th.Store.EXPECT().GetUserByID(mockSession.UserID).Return(guest, nil)
+
+	session, err := th.Auth.GetSession("goodToken")
+	require.Error(t, err)
+	require.Nil(t, session)
+}
+
+func TestGetAccessTokenSession(t *testing.T) {
+	token := model.AccessTokenPrefix + "token"
+	accessToken := &model.AccessToken{
+		ID:     "token-id",
+		UserID: mockSession.UserID,
+		Scopes: []string{model.AccessTokenScopeRead},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		th := setupTestHelper(t)
+		th.Auth.config.AuthMode = "native"
+		th.Store.EXPECT().GetAccessTokenByHash(model.HashToken(token)).Return(accessToken, nil)
+		th.Store.EXPECT().GetUserByID(mockSession.UserID).Return(&model.User{ID: mockSession.UserID}, nil)
+		th.Store.EXPECT().UpdateAccessTokenLastUsed(accessToken.ID, gomock.Any()).Return(nil)
+
+		session, err := th.Auth.GetSession(token)
+		require.NoError(t, err)
+		require.Equal(t, mockSession.UserID, session.UserID)
+		require.Equal(t, "native", session.AuthService)
+		require.True(t, session.IsAccessTokenSession())
+		require.True(t, session.HasAccessTokenScope(model.AccessTokenScopeRead))
+		require.False(t, session.HasAccessTokenScope(model.AccessTokenScopeBoardsWrite))
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		th := setupTestHelper(t)
+		expired := *accessToken
+		expired.ExpireAt = utils.GetMillis() - 1000
+		th.Store.EXPECT().GetAccessTokenByHash(model.HashToken(token)).Return(&expired, nil)
+
+		session, err := th.Auth.GetSession(token)
+		require.Error(t, err)
+		require.Nil(t, session)
+	})
+
+	t.Run("deactivated user", func(t *testing.T) {
+		th := setupTestHelper(t)
+		th.Store.EXPECT().GetAccessTokenByHash(model.HashToken(token)).Return(accessToken, nil)
+		th.Store.EXPECT().GetUserByID(mockSession.UserID).Return(nil, model.NewErrNotFound("user"))
+
+		session, err := th.Auth.GetSession(token)
+		require.Error(t, err)
+		require.Nil(t, session)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		th := setupTestHelper(t)
+		th.Store.EXPECT().GetAccessTokenByHash(model.HashToken(token)).Return(nil, model.NewErrNotFound("access token"))
+
+		session, err := th.Auth.GetSession(token)
+		require.Error(t, err)
+		require.Nil(t, session)
+	})
+}
+
 func TestIsValidReadToken(t *testing.T) {
 	// ToDo: reimplement
 
@@ -142,3 +211,141 @@ func TestIsValidReadToken(t *testing.T) {
 	// 	})
 	// }
 }
+
+func TestIsValidReadTokenShareLinks(t *testing.T) {
+	th := setupTestHelper(t)
+	th.Auth.config.EnablePublicSharedBoards = true
+	th.Auth.config.LoginLockout = config.LockoutConfig{
+		Enable:             true,
+		MaxAccountFailures: 10,
+		LockoutMinutes:     15,
+	}
+
+	boardID := "testBoardID"
+	link := &model.ShareLink{
+		ID:      "link-id",
+		BoardID: boardID,
+		Token:   "linkToken",
+	}
+
+	t.Run("success, valid share link", func(t *testing.T) {
+		th.Store.EXPECT().GetSharing(boardID).Return(nil, model.NewErrNotFound(boardID))
+		th.Store.EXPECT().GetShareLinkByToken(boardID, "linkToken").Return(link, nil)
+		th.Store.EXPECT().RecordShareLinkAccess("link-id", gomock.Any()).Return(nil)
+
+		isValid, err := th.Auth.IsValidReadToken(boardID, "linkToken", model.ShareLinkCredentials{})
+		require.NoError(t, err)
+		require.True(t, isValid)
+	})
+
+	t.Run("fail, unknown token", func(t *testing.T) {
+		th.Store.EXPECT().GetSharing(boardID).Return(nil, model.NewErrNotFound(boardID))
+		th.Store.EXPECT().GetShareLinkByToken(boardID, "badToken").Return(nil, model.NewErrNotFound("share link"))
+
+		isValid, err := th.Auth.IsValidReadToken(boardID, "badToken", model.ShareLinkCredentials{})
+		require.NoError(t, err)
+		require.False(t, isValid)
+	})
+
+	t.Run("fail, expired share link", func(t *testing.T) {
+		expired := *link
+		expired.ExpireAt = utils.GetMillis() - 1000
+		th.Store.EXPECT().GetSharing(boardID).Return(nil, model.NewErrNotFound(boardID))
+		th.Store.EXPECT().GetShareLinkByToken(boardID, "linkToken").Return(&expired, nil)
+
+		isValid, err := th.Auth.IsValidReadToken(boardID, "linkToken", model.ShareLinkCredentials{})
+		require.NoError(t, err)
+		require.False(t, isValid)
+	})
+
+	t.Run("password protected share link", func(t *testing.T) {
+		protected := *link
+		protected.Password = passwordauth.HashPassword("secret")
+		th.Store.EXPECT().GetSharing(boardID).Return(nil, model.NewErrNotFound(boardID)).Times(2)
+		th.Store.EXPECT().GetShareLinkByToken(boardID, "linkToken").Return(&protected, nil).Times(2)
+		th.Store.EXPECT().GetLoginAttempts(model.LoginAttemptsShareLinkKey("link-id")).Return(nil, model.NewErrNotFound("login attempts")).Times(2)
+		th.Store.EXPECT().IncrementLoginAttempts(model.LoginAttemptsShareLinkKey("link-id"), gomock.Any()).Return(&model.LoginAttempts{FailedCount: 1}, nil)
+		th.Store.EXPECT().RecordShareLinkAccess("link-id", gomock.Any()).Return(nil)
+
+		isValid, err := th.Auth.IsValidReadToken(boardID, "linkToken", model.ShareLinkCredentials{Password: "wrong"})
+		require.NoError(t, err)
+		require.False(t, isValid)
+
+		isValid, err = th.Auth.IsValidReadToken(boardID, "linkToken", model.ShareLinkCredentials{Password: "secret"})
+		require.NoError(t, err)
+		require.True(t, isValid)
+	})
+
+	t.Run("fail, locked out password protected share link", func(t *testing.T) {
+		protected := *link
+		protected.Password = passwordauth.HashPassword("secret")
+		th.Store.EXPECT().GetSharing(boardID).Return(nil, model.NewErrNotFound(boardID))
+		th.Store.EXPECT().GetShareLinkByToken(boardID, "linkToken").Return(&protected, nil)
+		th.Store.EXPECT().GetLoginAttempts(model.LoginAttemptsShareLinkKey("link-id")).Return(&model.LoginAttempts{
+			FailedCount:   th.Auth.config.LoginLockout.MaxAccountFailures,
+			LastFailureAt: utils.GetMillis(),
+		}, nil)
+
+		isValid, err := th.Auth.IsValidReadToken(boardID, "linkToken", model.ShareLinkCredentials{Password: "secret"})
+		require.True(t, model.IsErrTooManyRequests(err))
+		require.False(t, isValid)
+	})
+
+	t.Run("single view share link", func(t *testing.T) {
+		singleView := *link
+		singleView.SingleView = true
+
+		t.Run("fail, no view id", func(t *testing.T) {
+			th.Store.EXPECT().GetSharing(boardID).Return(nil, model.NewErrNotFound(boardID))
+			th.Store.EXPECT().GetShareLinkByToken(boardID, "linkToken").Return(&singleView, nil)
+
+			isValid, err := th.Auth.IsValidReadToken(boardID, "linkToken", model.ShareLinkCredentials{})
+			require.NoError(t, err)
+			require.False(t, isValid)
+		})
+
+		t.Run("success, the first view claims the link", func(t *testing.T) {
+			th.Store.EXPECT().GetSharing(boardID).Return(nil, model.NewErrNotFound(boardID))
+			th.Store.EXPECT().GetShareLinkByToken(boardID, "linkToken").Return(&singleView, nil)
+			th.Store.EXPECT().ClaimShareLinkView("link-id", "view-1").Return(true, nil)
+			th.Store.EXPECT().RecordShareLinkAccess("link-id", gomock.Any()).Return(nil)
+
+			isValid, err := th.Auth.IsValidReadToken(boardID, "linkToken", model.ShareLinkCredentials{ViewID: "view-1"})
+			require.NoError(t, err)
+			require.True(t, isValid)
+		})
+
+		t.Run("fail, another view claimed the link concurrently", func(t *testing.T) {
+			th.Store.EXPECT().GetSharing(boardID).Return(nil, model.NewErrNotFound(boardID))
+			th.Store.EXPECT().GetShareLinkByToken(boardID, "linkToken").Return(&singleView, nil)
+			th.Store.EXPECT().ClaimShareLinkView("link-id", "view-2").Return(false, nil)
+
+			isValid, err := th.Auth.IsValidReadToken(boardID, "linkToken", model.ShareLinkCredentials{ViewID: "view-2"})
+			require.NoError(t, err)
+			require.False(t, isValid)
+		})
+
+		t.Run("fail, the link is bound to another view", func(t *testing.T) {
+			bound := singleView
+			bound.ViewID = "view-1"
+			th.Store.EXPECT().GetSharing(boardID).Return(nil, model.NewErrNotFound(boardID))
+			th.Store.EXPECT().GetShareLinkByToken(boardID, "linkToken").Return(&bound, nil)
+
+			isValid, err := th.Auth.IsValidReadToken(boardID, "linkToken", model.ShareLinkCredentials{ViewID: "view-2"})
+			require.NoError(t, err)
+			require.False(t, isValid)
+		})
+	})
+
+	t.Run("fail, public shared boards disabled", func(t *testing.T) {
+		th.Auth.config.EnablePublicSharedBoards = false
+		defer func() { th.Auth.config.EnablePublicSharedBoards = true }()
+
+		th.Store.EXPECT().GetSharing(boardID).Return(nil, model.NewErrNotFound(boardID))
+		th.Store.EXPECT().GetShareLinkByToken(boardID, "linkToken").Return(link, nil)
+
+		isValid, err := th.Auth.IsValidReadToken(boardID, "linkToken", model.ShareLinkCredentials{})
+		require.Error(t, err)
+		require.False(t, isValid)
+	})
+}
@@ -63,17 +63,31 @@ func (mr *MockAuthInterfaceMockRecorder) GetSession(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockAuthInterface)(nil).GetSession), arg0)
 }
 
+// GetUserID mocks base method.
+func (m *MockAuthInterface) GetUserID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetUserID indicates an expected call of GetUserID.
+func (mr *MockAuthInterfaceMockRecorder) GetUserID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserID", reflect.TypeOf((*MockAuthInterface)(nil).GetUserID))
+}
+
 // IsValidReadToken mocks base method.
-func (m *MockAuthInterface) IsValidReadToken(arg0, arg1 string) (bool, error) {
+func (m *MockAuthInterface) IsValidReadToken(arg0, arg1 string, arg2 model.ShareLinkCredentials) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IsValidReadToken", arg0, arg1)
+	ret := m.ctrl.Call(m, "IsValidReadToken", arg0, arg1, arg2)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // IsValidReadToken indicates an expected call of IsValidReadToken.
-func (mr *MockAuthInterfaceMockRecorder) IsValidReadToken(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockAuthInterfaceMockRecorder) IsValidReadToken(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsValidReadToken", reflect.TypeOf((*MockAuthInterface)(nil).IsValidReadToken), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsValidReadToken", reflect.TypeOf((*MockAuthInterface)(nil).IsValidReadToken), arg0, arg1, arg2)
 }
@@ -7,6 +7,7 @@ import (
 	"io"
 	"mime/multipart"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/mattermost/focalboard/server/api"
@@ -235,6 +236,16 @@ func (c *Client) GetAllBlocksForBoard(boardID string) ([]*model.Block, *Response
 	return model.BlocksFromJSON(r.Body), BuildResponse(r)
 }
 
+func (c *Client) GetAllBlocksForBoardIncludingArchived(boardID string) ([]*model.Block, *Response) {
+	r, err := c.DoAPIGet(c.GetAllBlocksRoute(boardID)+"&include_archived=true", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BlocksFromJSON(r.Body), BuildResponse(r)
+}
+
 const disableNotifyQueryParam = "disable_notify=true"
 
 func (c *Client) PatchBlock(boardID, blockID string, blockPatch *model.BlockPatch, disableNotify bool) (bool, *Response) {
@@ -265,173 +276,1028 @@ func (c *Client) DuplicateBoard(boardID string, asTemplate bool, teamID string)
 	}
 	defer closeBody(r)
 
-	return model.BoardsAndBlocksFromJSON(r.Body), BuildResponse(r)
-}
-
-func (c *Client) DuplicateBlock(boardID, blockID string, asTemplate bool) (bool, *Response) {
-	queryParams := "?asTemplate=false"
-	if asTemplate {
-		queryParams = "?asTemplate=true"
-	}
-	r, err := c.DoAPIPost(c.GetBlockRoute(boardID, blockID)+"/duplicate"+queryParams, "")
+	return model.BoardsAndBlocksFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) DuplicateBlock(boardID, blockID string, asTemplate bool) (bool, *Response) {
+	queryParams := "?asTemplate=false"
+	if asTemplate {
+		queryParams = "?asTemplate=true"
+	}
+	r, err := c.DoAPIPost(c.GetBlockRoute(boardID, blockID)+"/duplicate"+queryParams, "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) UndeleteBlock(boardID, blockID string) (bool, *Response) {
+	r, err := c.DoAPIPost(c.GetBlockRoute(boardID, blockID)+"/undelete", "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) InsertBlocks(boardID string, blocks []*model.Block, disableNotify bool) ([]*model.Block, *Response) {
+	var queryParams string
+	if disableNotify {
+		queryParams = "?" + disableNotifyQueryParam
+	}
+	r, err := c.DoAPIPost(c.GetBlocksRoute(boardID)+queryParams, toJSON(blocks))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BlocksFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) DeleteBlock(boardID, blockID string, disableNotify bool) (bool, *Response) {
+	var queryParams string
+	if disableNotify {
+		queryParams = "?" + disableNotifyQueryParam
+	}
+	r, err := c.DoAPIDelete(c.GetBlockRoute(boardID, blockID)+queryParams, "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+//
+// Cards
+//
+
+func (c *Client) CreateCard(boardID string, card *model.Card, disableNotify bool) (*model.Card, *Response) {
+	var queryParams string
+	if disableNotify {
+		queryParams = "?" + disableNotifyQueryParam
+	}
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/cards"+queryParams, toJSON(card))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var cardNew *model.Card
+	if err := json.NewDecoder(r.Body).Decode(&cardNew); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return cardNew, BuildResponse(r)
+}
+
+func (c *Client) GetCards(boardID string, page int, perPage int) ([]*model.Card, *Response) {
+	url := fmt.Sprintf("%s/cards?page=%d&per_page=%d", c.GetBoardRoute(boardID), page, perPage)
+	return c.getCards(url)
+}
+
+func (c *Client) GetCardsIncludingArchived(boardID string, page int, perPage int) ([]*model.Card, *Response) {
+	url := fmt.Sprintf("%s/cards?page=%d&per_page=%d&include_archived=true", c.GetBoardRoute(boardID), page, perPage)
+	return c.getCards(url)
+}
+
+func (c *Client) getCards(url string) ([]*model.Card, *Response) {
+	r, err := c.DoAPIGet(url, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	defer closeBody(r)
+
+	var cards []*model.Card
+	if err := json.NewDecoder(r.Body).Decode(&cards); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return cards, BuildResponse(r)
+}
+
+func (c *Client) PatchCard(cardID string, cardPatch *model.CardPatch, disableNotify bool) (*model.Card, *Response) {
+	var queryParams string
+	if disableNotify {
+		queryParams = "?" + disableNotifyQueryParam
+	}
+	r, err := c.DoAPIPatch(c.GetCardRoute(cardID)+queryParams, toJSON(cardPatch))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	defer closeBody(r)
+
+	var cardNew *model.Card
+	if err := json.NewDecoder(r.Body).Decode(&cardNew); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return cardNew, BuildResponse(r)
+}
+
+func (c *Client) GetCard(cardID string) (*model.Card, *Response) {
+	r, err := c.DoAPIGet(c.GetCardRoute(cardID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	defer closeBody(r)
+
+	var card *model.Card
+	if err := json.NewDecoder(r.Body).Decode(&card); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return card, BuildResponse(r)
+}
+
+func (c *Client) BulkUpdateCards(boardID string, request *model.BulkCardRequest) ([]*model.BulkCardResult, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/cards/bulk", toJSON(request))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var results []*model.BulkCardResult
+	if err := json.NewDecoder(r.Body).Decode(&results); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return results, BuildResponse(r)
+}
+
+func (c *Client) ArchiveCard(cardID string) (*model.Card, *Response) {
+	return c.postCardAction(cardID, "archive")
+}
+
+func (c *Client) UnarchiveCard(cardID string) (*model.Card, *Response) {
+	return c.postCardAction(cardID, "unarchive")
+}
+
+func (c *Client) SetCardAccess(cardID string, access *model.CardAccess) (*model.Card, *Response) {
+	r, err := c.DoAPIPut(c.GetCardRoute(cardID)+"/access", toJSON(access))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var card *model.Card
+	if err := json.NewDecoder(r.Body).Decode(&card); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return card, BuildResponse(r)
+}
+
+func (c *Client) LockCard(cardID string) (*model.Card, *Response) {
+	return c.postCardAction(cardID, "lock")
+}
+
+func (c *Client) UnlockCard(cardID string) (*model.Card, *Response) {
+	return c.postCardAction(cardID, "unlock")
+}
+
+func (c *Client) postCardAction(cardID string, action string) (*model.Card, *Response) {
+	r, err := c.DoAPIPost(c.GetCardRoute(cardID)+"/"+action, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var card *model.Card
+	if err := json.NewDecoder(r.Body).Decode(&card); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return card, BuildResponse(r)
+}
+
+func (c *Client) GetCardHistory(cardID string, before int64, perPage int) ([]*model.CardVersion, *Response) {
+	url := fmt.Sprintf("%s/history?before=%d&per_page=%d", c.GetCardRoute(cardID), before, perPage)
+	r, err := c.DoAPIGet(url, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var versions []*model.CardVersion
+	if err := json.NewDecoder(r.Body).Decode(&versions); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return versions, BuildResponse(r)
+}
+
+func (c *Client) RevertCard(cardID string, toUpdateAt int64) (*model.Card, *Response) {
+	return c.postCardAction(cardID, fmt.Sprintf("revert?to=%d", toUpdateAt))
+}
+
+func (c *Client) MoveCard(cardID string, request *model.MoveCardRequest) (*model.Card, *Response) {
+	r, err := c.DoAPIPost(c.GetCardRoute(cardID)+"/move", toJSON(request))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var card *model.Card
+	if err := json.NewDecoder(r.Body).Decode(&card); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return card, BuildResponse(r)
+}
+
+func (c *Client) GetCardRecurrenceRoute(cardID string) string {
+	return fmt.Sprintf("%s/recurrence", c.GetCardRoute(cardID))
+}
+
+func (c *Client) GetCardRecurrence(cardID string) (*model.CardRecurrence, *Response) {
+	r, err := c.DoAPIGet(c.GetCardRecurrenceRoute(cardID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var recurrence *model.CardRecurrence
+	if err := json.NewDecoder(r.Body).Decode(&recurrence); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return recurrence, BuildResponse(r)
+}
+
+func (c *Client) SetCardRecurrence(cardID string, recurrence *model.CardRecurrence) (*model.CardRecurrence, *Response) {
+	r, err := c.DoAPIPut(c.GetCardRecurrenceRoute(cardID), toJSON(recurrence))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var recurrenceNew *model.CardRecurrence
+	if err := json.NewDecoder(r.Body).Decode(&recurrenceNew); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return recurrenceNew, BuildResponse(r)
+}
+
+func (c *Client) DeleteCardRecurrence(cardID string) *Response {
+	r, err := c.DoAPIDelete(c.GetCardRecurrenceRoute(cardID), "")
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+func (c *Client) GetBoardSnapshotsRoute(boardID string) string {
+	return fmt.Sprintf("%s/snapshots", c.GetBoardRoute(boardID))
+}
+
+func (c *Client) GetBoardSnapshots(boardID string) ([]*model.BoardSnapshot, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardSnapshotsRoute(boardID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var snapshots []*model.BoardSnapshot
+	if err := json.NewDecoder(r.Body).Decode(&snapshots); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return snapshots, BuildResponse(r)
+}
+
+func (c *Client) CreateBoardSnapshot(boardID, name string) (*model.BoardSnapshot, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardSnapshotsRoute(boardID), toJSON(&model.BoardSnapshot{Name: name}))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var snapshot *model.BoardSnapshot
+	if err := json.NewDecoder(r.Body).Decode(&snapshot); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return snapshot, BuildResponse(r)
+}
+
+func (c *Client) DeleteBoardSnapshot(boardID, snapshotID string) *Response {
+	r, err := c.DoAPIDelete(c.GetBoardSnapshotsRoute(boardID)+"/"+snapshotID, "")
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+func (c *Client) RestoreBoard(boardID string, request *model.BoardRestoreRequest) (*model.Board, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/restore", toJSON(request))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var board *model.Board
+	if err := json.NewDecoder(r.Body).Decode(&board); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return board, BuildResponse(r)
+}
+
+func (c *Client) GetCustomBoardRolesRoute(teamID string) string {
+	return c.GetTeamRoute(teamID) + "/board-roles"
+}
+
+func (c *Client) GetCustomBoardRoles(teamID string) ([]*model.CustomBoardRole, *Response) {
+	r, err := c.DoAPIGet(c.GetCustomBoardRolesRoute(teamID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var roles []*model.CustomBoardRole
+	if err := json.NewDecoder(r.Body).Decode(&roles); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return roles, BuildResponse(r)
+}
+
+func (c *Client) CreateCustomBoardRole(role *model.CustomBoardRole) (*model.CustomBoardRole, *Response) {
+	r, err := c.DoAPIPost(c.GetCustomBoardRolesRoute(role.TeamID), toJSON(role))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var created *model.CustomBoardRole
+	if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return created, BuildResponse(r)
+}
+
+func (c *Client) PatchCustomBoardRole(teamID, roleID string, patch *model.CustomBoardRolePatch) (*model.CustomBoardRole, *Response) {
+	r, err := c.DoAPIPatch(c.GetCustomBoardRolesRoute(teamID)+"/"+roleID, toJSON(patch))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var role *model.CustomBoardRole
+	if err := json.NewDecoder(r.Body).Decode(&role); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return role, BuildResponse(r)
+}
+
+func (c *Client) DeleteCustomBoardRole(teamID, roleID string) *Response {
+	r, err := c.DoAPIDelete(c.GetCustomBoardRolesRoute(teamID)+"/"+roleID, "")
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+func (c *Client) ExplainBoardPermissions(boardID, userID string) (*model.BoardPermissionsExplanation, *Response) {
+	route := c.GetBoardRoute(boardID) + "/permissions/explain"
+	if userID != "" {
+		route += "?userId=" + userID
+	}
+
+	r, err := c.DoAPIGet(route, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var explanation *model.BoardPermissionsExplanation
+	if err := json.NewDecoder(r.Body).Decode(&explanation); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return explanation, BuildResponse(r)
+}
+
+func (c *Client) GetTeams() ([]*model.Team, *Response) {
+	r, err := c.DoAPIGet(c.GetTeamsRoute(), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.TeamsFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) CreateTeam(team *model.Team) (*model.Team, *Response) {
+	r, err := c.DoAPIPost(c.GetTeamsRoute(), toJSON(team))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.TeamFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) PatchTeam(teamID string, patch *model.TeamPatch) (*model.Team, *Response) {
+	r, err := c.DoAPIPatch(c.GetTeamRoute(teamID), toJSON(patch))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.TeamFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) ArchiveTeam(teamID string) *Response {
+	r, err := c.DoAPIPost(c.GetTeamRoute(teamID)+"/archive", "")
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+func (c *Client) RegenerateTeamSignupToken(teamID string) *Response {
+	r, err := c.DoAPIPost(c.GetTeamRoute(teamID)+"/regenerate_signup_token", "")
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+func (c *Client) GetTeamMembersRoute(teamID string) string {
+	return c.GetTeamRoute(teamID) + "/members"
+}
+
+func (c *Client) GetTeamMembers(teamID string) ([]*model.TeamMember, *Response) {
+	r, err := c.DoAPIGet(c.GetTeamMembersRoute(teamID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var members []*model.TeamMember
+	if err := json.NewDecoder(r.Body).Decode(&members); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return members, BuildResponse(r)
+}
+
+func (c *Client) AddTeamMember(member *model.TeamMember) (*model.TeamMember, *Response) {
+	r, err := c.DoAPIPost(c.GetTeamMembersRoute(member.TeamID), toJSON(member))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var added *model.TeamMember
+	if err := json.NewDecoder(r.Body).Decode(&added); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return added, BuildResponse(r)
+}
+
+func (c *Client) UpdateTeamMember(member *model.TeamMember) (*model.TeamMember, *Response) {
+	r, err := c.DoAPIPut(c.GetTeamMembersRoute(member.TeamID)+"/"+member.UserID, toJSON(member))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var updated *model.TeamMember
+	if err := json.NewDecoder(r.Body).Decode(&updated); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return updated, BuildResponse(r)
+}
+
+func (c *Client) DeleteTeamMember(teamID, userID string) *Response {
+	r, err := c.DoAPIDelete(c.GetTeamMembersRoute(teamID)+"/"+userID, "")
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+func (c *Client) GetTeamInvitesRoute(teamID string) string {
+	return c.GetTeamRoute(teamID) + "/invites"
+}
+
+func (c *Client) GetTeamInvites(teamID string) ([]*model.TeamInvite, *Response) {
+	r, err := c.DoAPIGet(c.GetTeamInvitesRoute(teamID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var invites []*model.TeamInvite
+	if err := json.NewDecoder(r.Body).Decode(&invites); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return invites, BuildResponse(r)
+}
+
+func (c *Client) CreateTeamInvite(invite *model.TeamInvite) (*model.TeamInvite, *Response) {
+	r, err := c.DoAPIPost(c.GetTeamInvitesRoute(invite.TeamID), toJSON(invite))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var created *model.TeamInvite
+	if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return created, BuildResponse(r)
+}
+
+func (c *Client) DeleteTeamInvite(teamID, inviteID string) *Response {
+	r, err := c.DoAPIDelete(c.GetTeamInvitesRoute(teamID)+"/"+inviteID, "")
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+func (c *Client) AcceptTeamInvite(inviteID string) (*model.TeamMember, *Response) {
+	r, err := c.DoAPIPost("/invites/"+inviteID+"/accept", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var member *model.TeamMember
+	if err := json.NewDecoder(r.Body).Decode(&member); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return member, BuildResponse(r)
+}
+
+func (c *Client) GetBoardAutomationsRoute(boardID string) string {
+	return fmt.Sprintf("%s/automations", c.GetBoardRoute(boardID))
+}
+
+func (c *Client) GetBoardAutomations(boardID string) ([]*model.AutomationRule, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardAutomationsRoute(boardID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var rules []*model.AutomationRule
+	if err := json.NewDecoder(r.Body).Decode(&rules); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return rules, BuildResponse(r)
+}
+
+func (c *Client) SetBoardAutomations(boardID string, rules []*model.AutomationRule) ([]*model.AutomationRule, *Response) {
+	r, err := c.DoAPIPut(c.GetBoardAutomationsRoute(boardID), toJSON(rules))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var savedRules []*model.AutomationRule
+	if err := json.NewDecoder(r.Body).Decode(&savedRules); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return savedRules, BuildResponse(r)
+}
+
+//
+// Boards and blocks.
+//
+
+func (c *Client) CreateBoardsAndBlocks(bab *model.BoardsAndBlocks) (*model.BoardsAndBlocks, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardsAndBlocksRoute(), toJSON(bab))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardsAndBlocksFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) CreateCategory(category model.Category) (*model.Category, *Response) {
+	r, err := c.DoAPIPost(c.GetTeamRoute(category.TeamID)+"/categories", toJSON(category))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.CategoryFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) DeleteCategory(teamID, categoryID string) *Response {
+	r, err := c.DoAPIDelete(c.GetTeamRoute(teamID)+"/categories/"+categoryID, "")
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+
+	defer closeBody(r)
+	return BuildResponse(r)
+}
+
+func (c *Client) UpdateCategoryBoard(teamID, categoryID, boardID string) *Response {
+	r, err := c.DoAPIPost(fmt.Sprintf("%s/categories/%s/boards/%s", c.GetTeamRoute(teamID), categoryID, boardID), "")
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+func (c *Client) GetUserCategoryBoards(teamID string) ([]model.CategoryBoards, *Response) {
+	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/categories", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var categoryBoards []model.CategoryBoards
+	_ = json.NewDecoder(r.Body).Decode(&categoryBoards)
+	return categoryBoards, BuildResponse(r)
+}
+
+func (c *Client) ReorderCategories(teamID string, newOrder []string) ([]string, *Response) {
+	r, err := c.DoAPIPut(c.GetTeamRoute(teamID)+"/categories/reorder", toJSON(newOrder))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var updatedCategoryOrder []string
+	_ = json.NewDecoder(r.Body).Decode(&updatedCategoryOrder)
+	return updatedCategoryOrder, BuildResponse(r)
+}
+
+func (c *Client) ReorderCategoryBoards(teamID, categoryID string, newOrder []string) ([]string, *Response) {
+	r, err := c.DoAPIPut(c.GetTeamRoute(teamID)+"/categories/"+categoryID+"/reorder", toJSON(newOrder))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var updatedBoardsOrder []string
+	_ = json.NewDecoder(r.Body).Decode(&updatedBoardsOrder)
+	return updatedBoardsOrder, BuildResponse(r)
+}
+
+func (c *Client) PatchBoardsAndBlocks(pbab *model.PatchBoardsAndBlocks) (*model.BoardsAndBlocks, *Response) {
+	r, err := c.DoAPIPatch(c.GetBoardsAndBlocksRoute(), toJSON(pbab))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardsAndBlocksFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) DeleteBoardsAndBlocks(dbab *model.DeleteBoardsAndBlocks) (bool, *Response) {
+	r, err := c.DoAPIDelete(c.GetBoardsAndBlocksRoute(), toJSON(dbab))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+// Sharing
+
+func (c *Client) GetSharingRoute(boardID string) string {
+	return fmt.Sprintf("%s/sharing", c.GetBoardRoute(boardID))
+}
+
+func (c *Client) GetSharing(boardID string) (*model.Sharing, *Response) {
+	r, err := c.DoAPIGet(c.GetSharingRoute(boardID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	sharing := model.SharingFromJSON(r.Body)
+	return &sharing, BuildResponse(r)
+}
+
+func (c *Client) PostSharing(sharing *model.Sharing) (bool, *Response) {
+	r, err := c.DoAPIPost(c.GetSharingRoute(sharing.ID), toJSON(sharing))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) GetShareLinksRoute(boardID string) string {
+	return fmt.Sprintf("%s/sharelinks", c.GetBoardRoute(boardID))
+}
+
+func (c *Client) GetShareLinks(boardID string) ([]*model.ShareLink, *Response) {
+	r, err := c.DoAPIGet(c.GetShareLinksRoute(boardID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var links []*model.ShareLink
+	if err := json.NewDecoder(r.Body).Decode(&links); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return links, BuildResponse(r)
+}
+
+func (c *Client) CreateShareLink(link *model.ShareLink) (*model.ShareLink, *Response) {
+	r, err := c.DoAPIPost(c.GetShareLinksRoute(link.BoardID), toJSON(link))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var created *model.ShareLink
+	if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return created, BuildResponse(r)
+}
+
+func (c *Client) DeleteShareLink(boardID, linkID string) *Response {
+	r, err := c.DoAPIDelete(c.GetShareLinksRoute(boardID)+"/"+linkID, "")
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+// GetSharedBoard fetches a board using the read token of a share link,
+// sending the password and the view id of the link in their headers.
+func (c *Client) GetSharedBoard(boardID, readToken string, credentials model.ShareLinkCredentials) (*model.Board, *Response) {
+	query := url.Values{}
+	query.Set("read_token", readToken)
+
+	opt := func(r *http.Request) {
+		if credentials.Password != "" {
+			r.Header.Set(model.HeaderReadTokenPassword, credentials.Password)
+		}
+		if credentials.ViewID != "" {
+			r.Header.Set(model.HeaderReadTokenViewID, credentials.ViewID)
+		}
+	}
+
+	r, err := c.doAPIRequestReader(http.MethodGet, c.APIURL+c.GetBoardRoute(boardID)+"?"+query.Encode(), http.NoBody, "", opt)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) GetRegisterRoute() string {
+	return "/register"
+}
+
+func (c *Client) Register(request *model.RegisterRequest) (bool, *Response) {
+	r, err := c.DoAPIPost(c.GetRegisterRoute(), toJSON(&request))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) GetLoginRoute() string {
+	return "/login"
+}
+
+func (c *Client) Login(request *model.LoginRequest) (*model.LoginResponse, *Response) {
+	r, err := c.DoAPIPost(c.GetLoginRoute(), toJSON(&request))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	data, err := model.LoginResponseFromJSON(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	if data.Token != "" {
+		c.Token = data.Token
+	}
+
+	return data, BuildResponse(r)
+}
+
+func (c *Client) GetMeRoute() string {
+	return "/users/me"
+}
+
+func (c *Client) GetMe() (*model.User, *Response) {
+	r, err := c.DoAPIGet(c.GetMeRoute(), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	me, err := model.UserFromJSON(r.Body)
 	if err != nil {
-		return false, BuildErrorResponse(r, err)
+		return nil, BuildErrorResponse(r, err)
 	}
-	defer closeBody(r)
+	return me, BuildResponse(r)
+}
 
-	return true, BuildResponse(r)
+func (c *Client) GetMfaRoute() string {
+	return "/users/me/mfa"
 }
 
-func (c *Client) UndeleteBlock(boardID, blockID string) (bool, *Response) {
-	r, err := c.DoAPIPost(c.GetBlockRoute(boardID, blockID)+"/undelete", "")
+func (c *Client) GenerateMfaSecret() (*model.MfaSecret, *Response) {
+	r, err := c.DoAPIPost(c.GetMfaRoute()+"/generate", "")
 	if err != nil {
-		return false, BuildErrorResponse(r, err)
+		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return true, BuildResponse(r)
+	var secret *model.MfaSecret
+	if err := json.NewDecoder(r.Body).Decode(&secret); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return secret, BuildResponse(r)
 }
 
-func (c *Client) InsertBlocks(boardID string, blocks []*model.Block, disableNotify bool) ([]*model.Block, *Response) {
-	var queryParams string
-	if disableNotify {
-		queryParams = "?" + disableNotifyQueryParam
-	}
-	r, err := c.DoAPIPost(c.GetBlocksRoute(boardID)+queryParams, toJSON(blocks))
+func (c *Client) ActivateMfa(code string) (*model.MfaRecoveryCodes, *Response) {
+	return c.postMfaCode(c.GetMfaRoute()+"/activate", code)
+}
+
+func (c *Client) RegenerateMfaRecoveryCodes(code string) (*model.MfaRecoveryCodes, *Response) {
+	return c.postMfaCode(c.GetMfaRoute()+"/recovery-codes", code)
+}
+
+func (c *Client) DeactivateMfa(code string) *Response {
+	r, err := c.DoAPIPost(c.GetMfaRoute()+"/deactivate", toJSON(model.MfaCodeRequest{Code: code}))
 	if err != nil {
-		return nil, BuildErrorResponse(r, err)
+		return BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return model.BlocksFromJSON(r.Body), BuildResponse(r)
+	return BuildResponse(r)
 }
 
-func (c *Client) DeleteBlock(boardID, blockID string, disableNotify bool) (bool, *Response) {
-	var queryParams string
-	if disableNotify {
-		queryParams = "?" + disableNotifyQueryParam
-	}
-	r, err := c.DoAPIDelete(c.GetBlockRoute(boardID, blockID)+queryParams, "")
+func (c *Client) postMfaCode(route, code string) (*model.MfaRecoveryCodes, *Response) {
+	r, err := c.DoAPIPost(route, toJSON(model.MfaCodeRequest{Code: code}))
 	if err != nil {
-		return false, BuildErrorResponse(r, err)
+		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return true, BuildResponse(r)
+	var recoveryCodes *model.MfaRecoveryCodes
+	if err := json.NewDecoder(r.Body).Decode(&recoveryCodes); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return recoveryCodes, BuildResponse(r)
 }
 
-//
-// Cards
-//
+func (c *Client) GetAccessTokensRoute() string {
+	return "/users/me/access-tokens"
+}
 
-func (c *Client) CreateCard(boardID string, card *model.Card, disableNotify bool) (*model.Card, *Response) {
-	var queryParams string
-	if disableNotify {
-		queryParams = "?" + disableNotifyQueryParam
-	}
-	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/cards"+queryParams, toJSON(card))
+func (c *Client) GetAccessTokens() ([]*model.AccessToken, *Response) {
+	r, err := c.DoAPIGet(c.GetAccessTokensRoute(), "")
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	var cardNew *model.Card
-	if err := json.NewDecoder(r.Body).Decode(&cardNew); err != nil {
+	var tokens []*model.AccessToken
+	if err := json.NewDecoder(r.Body).Decode(&tokens); err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 
-	return cardNew, BuildResponse(r)
+	return tokens, BuildResponse(r)
 }
 
-func (c *Client) GetCards(boardID string, page int, perPage int) ([]*model.Card, *Response) {
-	url := fmt.Sprintf("%s/cards?page=%d&per_page=%d", c.GetBoardRoute(boardID), page, perPage)
-	r, err := c.DoAPIGet(url, "")
+func (c *Client) CreateAccessToken(token *model.AccessToken) (*model.AccessToken, *Response) {
+	r, err := c.DoAPIPost(c.GetAccessTokensRoute(), toJSON(token))
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
-
 	defer closeBody(r)
 
-	var cards []*model.Card
-	if err := json.NewDecoder(r.Body).Decode(&cards); err != nil {
+	var created *model.AccessToken
+	if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 
-	return cards, BuildResponse(r)
+	return created, BuildResponse(r)
 }
 
-func (c *Client) PatchCard(cardID string, cardPatch *model.CardPatch, disableNotify bool) (*model.Card, *Response) {
-	var queryParams string
-	if disableNotify {
-		queryParams = "?" + disableNotifyQueryParam
-	}
-	r, err := c.DoAPIPatch(c.GetCardRoute(cardID)+queryParams, toJSON(cardPatch))
+func (c *Client) DeleteAccessToken(tokenID string) *Response {
+	r, err := c.DoAPIDelete(c.GetAccessTokensRoute()+"/"+tokenID, "")
 	if err != nil {
-		return nil, BuildErrorResponse(r, err)
+		return BuildErrorResponse(r, err)
 	}
-
 	defer closeBody(r)
 
-	var cardNew *model.Card
-	if err := json.NewDecoder(r.Body).Decode(&cardNew); err != nil {
-		return nil, BuildErrorResponse(r, err)
-	}
+	return BuildResponse(r)
+}
 
-	return cardNew, BuildResponse(r)
+func (c *Client) GetSessionsRoute() string {
+	return "/users/me/sessions"
 }
 
-func (c *Client) GetCard(cardID string) (*model.Card, *Response) {
-	r, err := c.DoAPIGet(c.GetCardRoute(cardID), "")
+func (c *Client) GetSessions() ([]*model.SessionInfo, *Response) {
+	r, err := c.DoAPIGet(c.GetSessionsRoute(), "")
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
-
 	defer closeBody(r)
 
-	var card *model.Card
-	if err := json.NewDecoder(r.Body).Decode(&card); err != nil {
+	var sessions []*model.SessionInfo
+	if err := json.NewDecoder(r.Body).Decode(&sessions); err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 
-	return card, BuildResponse(r)
+	return sessions, BuildResponse(r)
 }
 
-//
-// Boards and blocks.
-//
-
-func (c *Client) CreateBoardsAndBlocks(bab *model.BoardsAndBlocks) (*model.BoardsAndBlocks, *Response) {
-	r, err := c.DoAPIPost(c.GetBoardsAndBlocksRoute(), toJSON(bab))
+func (c *Client) RevokeSession(sessionID string) *Response {
+	r, err := c.DoAPIDelete(c.GetSessionsRoute()+"/"+sessionID, "")
 	if err != nil {
-		return nil, BuildErrorResponse(r, err)
+		return BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return model.BoardsAndBlocksFromJSON(r.Body), BuildResponse(r)
+	return BuildResponse(r)
 }
 
-func (c *Client) CreateCategory(category model.Category) (*model.Category, *Response) {
-	r, err := c.DoAPIPost(c.GetTeamRoute(category.TeamID)+"/categories", toJSON(category))
+func (c *Client) RevokeOtherSessions() *Response {
+	r, err := c.DoAPIDelete(c.GetSessionsRoute(), "")
 	if err != nil {
-		return nil, BuildErrorResponse(r, err)
+		return BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return model.CategoryFromJSON(r.Body), BuildResponse(r)
+	return BuildResponse(r)
 }
 
-func (c *Client) DeleteCategory(teamID, categoryID string) *Response {
-	r, err := c.DoAPIDelete(c.GetTeamRoute(teamID)+"/categories/"+categoryID, "")
+func (c *Client) GetPasswordResetRoute() string {
+	return "/password/reset"
+}
+
+func (c *Client) SendPasswordReset(email string) *Response {
+	r, err := c.DoAPIPost(c.GetPasswordResetRoute()+"/send", toJSON(&model.SendPasswordResetRequest{Email: email}))
 	if err != nil {
 		return BuildErrorResponse(r, err)
 	}
-
 	defer closeBody(r)
+
 	return BuildResponse(r)
 }
 
-func (c *Client) UpdateCategoryBoard(teamID, categoryID, boardID string) *Response {
-	r, err := c.DoAPIPost(fmt.Sprintf("%s/categories/%s/boards/%s", c.GetTeamRoute(teamID), categoryID, boardID), "")
+func (c *Client) ResetPassword(token, newPassword string) *Response {
+	request := &model.ResetPasswordRequest{Token: token, NewPassword: newPassword}
+	r, err := c.DoAPIPost(c.GetPasswordResetRoute(), toJSON(request))
 	if err != nil {
 		return BuildErrorResponse(r, err)
 	}
@@ -440,142 +1306,219 @@ func (c *Client) UpdateCategoryBoard(teamID, categoryID, boardID string) *Respon
 	return BuildResponse(r)
 }
 
-func (c *Client) GetUserCategoryBoards(teamID string) ([]model.CategoryBoards, *Response) {
-	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/categories", "")
+func (c *Client) GetEmailVerificationRoute() string {
+	return "/email/verify"
+}
+
+func (c *Client) SendEmailVerification(email string) *Response {
+	r, err := c.DoAPIPost(c.GetEmailVerificationRoute()+"/send", toJSON(&model.SendEmailVerificationRequest{Email: email}))
 	if err != nil {
-		return nil, BuildErrorResponse(r, err)
+		return BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	var categoryBoards []model.CategoryBoards
-	_ = json.NewDecoder(r.Body).Decode(&categoryBoards)
-	return categoryBoards, BuildResponse(r)
+	return BuildResponse(r)
 }
 
-func (c *Client) ReorderCategories(teamID string, newOrder []string) ([]string, *Response) {
-	r, err := c.DoAPIPut(c.GetTeamRoute(teamID)+"/categories/reorder", toJSON(newOrder))
+func (c *Client) VerifyEmail(token string) *Response {
+	r, err := c.DoAPIPost(c.GetEmailVerificationRoute(), toJSON(&model.VerifyEmailRequest{Token: token}))
 	if err != nil {
-		return nil, BuildErrorResponse(r, err)
+		return BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	var updatedCategoryOrder []string
-	_ = json.NewDecoder(r.Body).Decode(&updatedCategoryOrder)
-	return updatedCategoryOrder, BuildResponse(r)
+	return BuildResponse(r)
 }
 
-func (c *Client) ReorderCategoryBoards(teamID, categoryID string, newOrder []string) ([]string, *Response) {
-	r, err := c.DoAPIPut(c.GetTeamRoute(teamID)+"/categories/"+categoryID+"/reorder", toJSON(newOrder))
+// GetScimURL returns the URL of the SCIM provisioning API, which is
+// outside the /api/v2 path. The client token must be the SCIM token.
+func (c *Client) GetAdminUsersRoute() string {
+	return "/admin/users"
+}
+
+func (c *Client) GetAdminUserRoute(username string) string {
+	return fmt.Sprintf("%s/%s", c.GetAdminUsersRoute(), url.PathEscape(username))
+}
+
+func (c *Client) AdminGetUsers(term string, includeDeleted bool, page, perPage int) ([]*model.AdminUser, *Response) {
+	query := fmt.Sprintf("?q=%s&include_deleted=%t&page=%d&per_page=%d", url.QueryEscape(term), includeDeleted, page, perPage)
+	r, err := c.DoAPIGet(c.GetAdminUsersRoute()+query, "")
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	var updatedBoardsOrder []string
-	_ = json.NewDecoder(r.Body).Decode(&updatedBoardsOrder)
-	return updatedBoardsOrder, BuildResponse(r)
+	var users []*model.AdminUser
+	if err := json.NewDecoder(r.Body).Decode(&users); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return users, BuildResponse(r)
 }
 
-func (c *Client) PatchBoardsAndBlocks(pbab *model.PatchBoardsAndBlocks) (*model.BoardsAndBlocks, *Response) {
-	r, err := c.DoAPIPatch(c.GetBoardsAndBlocksRoute(), toJSON(pbab))
+func (c *Client) AdminGetUser(username string) (*model.AdminUser, *Response) {
+	r, err := c.DoAPIGet(c.GetAdminUserRoute(username), "")
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return model.BoardsAndBlocksFromJSON(r.Body), BuildResponse(r)
+	var user *model.AdminUser
+	if err := json.NewDecoder(r.Body).Decode(&user); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return user, BuildResponse(r)
 }
 
-func (c *Client) DeleteBoardsAndBlocks(dbab *model.DeleteBoardsAndBlocks) (bool, *Response) {
-	r, err := c.DoAPIDelete(c.GetBoardsAndBlocksRoute(), toJSON(dbab))
+func (c *Client) AdminGetUserBoards(username string) ([]*model.UserBoardMembership, *Response) {
+	r, err := c.DoAPIGet(c.GetAdminUserRoute(username)+"/boards", "")
 	if err != nil {
-		return false, BuildErrorResponse(r, err)
+		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return true, BuildResponse(r)
-}
-
-// Sharing
+	var memberships []*model.UserBoardMembership
+	if err := json.NewDecoder(r.Body).Decode(&memberships); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
 
-func (c *Client) GetSharingRoute(boardID string) string {
-	return fmt.Sprintf("%s/sharing", c.GetBoardRoute(boardID))
+	return memberships, BuildResponse(r)
 }
 
-func (c *Client) GetSharing(boardID string) (*model.Sharing, *Response) {
-	r, err := c.DoAPIGet(c.GetSharingRoute(boardID), "")
+func (c *Client) AdminDeactivateUser(username string) *Response {
+	r, err := c.DoAPIPost(c.GetAdminUserRoute(username)+"/deactivate", "")
 	if err != nil {
-		return nil, BuildErrorResponse(r, err)
+		return BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	sharing := model.SharingFromJSON(r.Body)
-	return &sharing, BuildResponse(r)
+	return BuildResponse(r)
 }
 
-func (c *Client) PostSharing(sharing *model.Sharing) (bool, *Response) {
-	r, err := c.DoAPIPost(c.GetSharingRoute(sharing.ID), toJSON(sharing))
+func (c *Client) AdminReactivateUser(username string) *Response {
+	r, err := c.DoAPIPost(c.GetAdminUserRoute(username)+"/reactivate", "")
 	if err != nil {
-		return false, BuildErrorResponse(r, err)
+		return BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return true, BuildResponse(r)
+	return BuildResponse(r)
 }
 
-func (c *Client) GetRegisterRoute() string {
-	return "/register"
+func (c *Client) AdminSetSystemAdmin(username string, isSystemAdmin bool) *Response {
+	data := api.AdminSetSystemAdminData{IsSystemAdmin: isSystemAdmin}
+	r, err := c.DoAPIPost(c.GetAdminUserRoute(username)+"/system-admin", toJSON(data))
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
 }
 
-func (c *Client) Register(request *model.RegisterRequest) (bool, *Response) {
-	r, err := c.DoAPIPost(c.GetRegisterRoute(), toJSON(&request))
+func (c *Client) AdminRevokeSessions(username string) *Response {
+	r, err := c.DoAPIPost(c.GetAdminUserRoute(username)+"/sessions/revoke", "")
 	if err != nil {
-		return false, BuildErrorResponse(r, err)
+		return BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return true, BuildResponse(r)
+	return BuildResponse(r)
 }
 
-func (c *Client) GetLoginRoute() string {
-	return "/login"
+func (c *Client) GetScimURL() string {
+	return c.URL + "/scim/v2"
 }
 
-func (c *Client) Login(request *model.LoginRequest) (*model.LoginResponse, *Response) {
-	r, err := c.DoAPIPost(c.GetLoginRoute(), toJSON(&request))
+func (c *Client) doScimRequest(method, route string, data interface{}, v interface{}) *Response {
+	body := ""
+	if data != nil {
+		body = toJSON(data)
+	}
+
+	r, err := c.DoAPIRequest(method, c.GetScimURL()+route, body, "")
 	if err != nil {
-		return nil, BuildErrorResponse(r, err)
+		return BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	data, err := model.LoginResponseFromJSON(r.Body)
-	if err != nil {
-		return nil, BuildErrorResponse(r, err)
+	if v != nil {
+		if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+			return BuildErrorResponse(r, err)
+		}
 	}
+	return BuildResponse(r)
+}
 
-	if data.Token != "" {
-		c.Token = data.Token
+// ScimGetUsers returns the users matching the SCIM filter.
+func (c *Client) ScimGetUsers(filter string) ([]*model.ScimUser, *Response) {
+	var list struct {
+		Resources []*model.ScimUser `json:"Resources"`
 	}
+	resp := c.doScimRequest(http.MethodGet, "/Users?filter="+url.QueryEscape(filter), nil, &list)
+	return list.Resources, resp
+}
 
-	return data, BuildResponse(r)
+func (c *Client) ScimGetUser(userID string) (*model.ScimUser, *Response) {
+	var user *model.ScimUser
+	resp := c.doScimRequest(http.MethodGet, "/Users/"+userID, nil, &user)
+	return user, resp
 }
 
-func (c *Client) GetMeRoute() string {
-	return "/users/me"
+func (c *Client) ScimCreateUser(user *model.ScimUser) (*model.ScimUser, *Response) {
+	var created *model.ScimUser
+	resp := c.doScimRequest(http.MethodPost, "/Users", user, &created)
+	return created, resp
 }
 
-func (c *Client) GetMe() (*model.User, *Response) {
-	r, err := c.DoAPIGet(c.GetMeRoute(), "")
-	if err != nil {
-		return nil, BuildErrorResponse(r, err)
-	}
-	defer closeBody(r)
+func (c *Client) ScimReplaceUser(userID string, user *model.ScimUser) (*model.ScimUser, *Response) {
+	var updated *model.ScimUser
+	resp := c.doScimRequest(http.MethodPut, "/Users/"+userID, user, &updated)
+	return updated, resp
+}
 
-	me, err := model.UserFromJSON(r.Body)
-	if err != nil {
-		return nil, BuildErrorResponse(r, err)
+func (c *Client) ScimPatchUser(userID string, operations []model.ScimPatchOperation) (*model.ScimUser, *Response) {
+	patch := &model.ScimPatchRequest{Schemas: []string{model.ScimSchemaPatchOp}, Operations: operations}
+	var updated *model.ScimUser
+	resp := c.doScimRequest(http.MethodPatch, "/Users/"+userID, patch, &updated)
+	return updated, resp
+}
+
+func (c *Client) ScimDeleteUser(userID string) *Response {
+	return c.doScimRequest(http.MethodDelete, "/Users/"+userID, nil, nil)
+}
+
+// ScimGetGroups returns the groups matching the SCIM filter.
+func (c *Client) ScimGetGroups(filter string) ([]*model.ScimGroup, *Response) {
+	var list struct {
+		Resources []*model.ScimGroup `json:"Resources"`
 	}
-	return me, BuildResponse(r)
+	resp := c.doScimRequest(http.MethodGet, "/Groups?filter="+url.QueryEscape(filter), nil, &list)
+	return list.Resources, resp
+}
+
+func (c *Client) ScimGetGroup(groupID string) (*model.ScimGroup, *Response) {
+	var group *model.ScimGroup
+	resp := c.doScimRequest(http.MethodGet, "/Groups/"+groupID, nil, &group)
+	return group, resp
+}
+
+func (c *Client) ScimCreateGroup(group *model.ScimGroup) (*model.ScimGroup, *Response) {
+	var created *model.ScimGroup
+	resp := c.doScimRequest(http.MethodPost, "/Groups", group, &created)
+	return created, resp
+}
+
+func (c *Client) ScimPatchGroup(groupID string, operations []model.ScimPatchOperation) (*model.ScimGroup, *Response) {
+	patch := &model.ScimPatchRequest{Schemas: []string{model.ScimSchemaPatchOp}, Operations: operations}
+	var updated *model.ScimGroup
+	resp := c.doScimRequest(http.MethodPatch, "/Groups/"+groupID, patch, &updated)
+	return updated, resp
+}
+
+func (c *Client) ScimDeleteGroup(groupID string) *Response {
+	return c.doScimRequest(http.MethodDelete, "/Groups/"+groupID, nil, nil)
 }
 
 func (c *Client) GetUserID() string {
@@ -658,6 +1601,24 @@ func (c *Client) PatchBoard(boardID string, patch *model.BoardPatch) (*model.Boa
 	return model.BoardFromJSON(r.Body), BuildResponse(r)
 }
 
+func (c *Client) LockBoard(boardID string) (*model.Board, *Response) {
+	return c.postBoardLock(boardID, "lock")
+}
+
+func (c *Client) UnlockBoard(boardID string) (*model.Board, *Response) {
+	return c.postBoardLock(boardID, "unlock")
+}
+
+func (c *Client) postBoardLock(boardID string, action string) (*model.Board, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/"+action, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardFromJSON(r.Body), BuildResponse(r)
+}
+
 func (c *Client) DeleteBoard(boardID string) (bool, *Response) {
 	r, err := c.DoAPIDelete(c.GetBoardRoute(boardID), "")
 	if err != nil {
@@ -905,7 +1866,15 @@ func (c *Client) GetTemplatesForTeam(teamID string) ([]*model.Board, *Response)
 }
 
 func (c *Client) ExportBoardArchive(boardID string) ([]byte, *Response) {
-	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/archive/export", "")
+	return c.exportBoardArchive(c.GetBoardRoute(boardID) + "/archive/export")
+}
+
+func (c *Client) ExportBoardArchiveWithArchivedCards(boardID string, archivedCards model.ArchivedCardsFilter) ([]byte, *Response) {
+	return c.exportBoardArchive(c.GetBoardRoute(boardID) + "/archive/export?archived_cards=" + string(archivedCards))
+}
+
+func (c *Client) exportBoardArchive(url string) ([]byte, *Response) {
+	r, err := c.DoAPIGet(url, "")
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
 	}
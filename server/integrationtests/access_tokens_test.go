@@ -0,0 +1,153 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package integrationtests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/client"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessTokens(t *testing.T) {
+	createToken := func(th *TestHelper, scopes ...string) *model.AccessToken {
+		token, resp := th.Client.CreateAccessToken(&model.AccessToken{
+			Name:   "script",
+			Scopes: scopes,
+		})
+		th.CheckOK(resp)
+		require.NotEmpty(t, token.ID)
+		require.Contains(t, token.Token, model.AccessTokenPrefix)
+		return token
+	}
+
+	tokenClient := func(th *TestHelper, token *model.AccessToken) *client.Client {
+		return client.NewClient(th.Server.Config().ServerRoot, token.Token)
+	}
+
+	newBoard := func() *model.Board {
+		return &model.Board{TeamID: testTeamID, Type: model.BoardTypeOpen, Title: "from a script"}
+	}
+
+	t.Run("invalid tokens are rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		_, resp := th.Client.CreateAccessToken(&model.AccessToken{Name: "no scopes"})
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.CreateAccessToken(&model.AccessToken{
+			Name:   "unknown scope",
+			Scopes: []string{"everything"},
+		})
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.CreateAccessToken(&model.AccessToken{
+			Name:     "expired",
+			Scopes:   []string{model.AccessTokenScopeRead},
+			ExpireAt: utils.GetMillis() - 1000,
+		})
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("the token authenticates as its user and is only returned once", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		token := createToken(th, model.AccessTokenScopeRead)
+
+		me, resp := tokenClient(th, token).GetMe()
+		th.CheckOK(resp)
+		require.Equal(t, th.GetUser1().ID, me.ID)
+
+		tokens, resp := th.Client.GetAccessTokens()
+		th.CheckOK(resp)
+		require.Len(t, tokens, 1)
+		require.Equal(t, token.ID, tokens[0].ID)
+		require.Empty(t, tokens[0].Token)
+		require.NotZero(t, tokens[0].LastUsedAt)
+
+		// tokens are personal
+		tokens, resp = th.Client2.GetAccessTokens()
+		th.CheckOK(resp)
+		require.Empty(t, tokens)
+	})
+
+	t.Run("scopes limit what the token can do", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		readClient := tokenClient(th, createToken(th, model.AccessTokenScopeRead))
+		_, resp := readClient.GetMe()
+		th.CheckOK(resp)
+		_, resp = readClient.CreateBoard(newBoard())
+		th.CheckForbidden(resp)
+
+		writeClient := tokenClient(th, createToken(th, model.AccessTokenScopeBoardsWrite))
+		_, resp = writeClient.CreateBoard(newBoard())
+		th.CheckOK(resp)
+		_, resp = writeClient.CreateAccessToken(&model.AccessToken{
+			Name:   "escalation",
+			Scopes: []string{model.AccessTokenScopeAdmin},
+		})
+		th.CheckForbidden(resp)
+		_, resp = writeClient.CreateTeam(&model.Team{Title: "Marketing"})
+		th.CheckForbidden(resp)
+
+		adminClient := tokenClient(th, createToken(th, model.AccessTokenScopeAdmin))
+		_, resp = adminClient.CreateBoard(newBoard())
+		th.CheckOK(resp)
+		_, resp = adminClient.CreateTeam(&model.Team{Title: "Marketing"})
+		th.CheckOK(resp)
+	})
+
+	t.Run("expired and revoked tokens are rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		token := createToken(th, model.AccessTokenScopeRead)
+		c := tokenClient(th, token)
+		_, resp := c.GetMe()
+		th.CheckOK(resp)
+
+		// only the owner can revoke the token
+		th.CheckNotFound(th.Client2.DeleteAccessToken(token.ID))
+
+		th.CheckOK(th.Client.DeleteAccessToken(token.ID))
+		_, resp = c.GetMe()
+		th.CheckUnauthorized(resp)
+
+		expiring, resp := th.Client.CreateAccessToken(&model.AccessToken{
+			Name:     "expiring",
+			Scopes:   []string{model.AccessTokenScopeRead},
+			ExpireAt: utils.GetMillis() + 1000,
+		})
+		th.CheckOK(resp)
+		require.Eventually(t, func() bool {
+			_, resp := tokenClient(th, expiring).GetMe()
+			return resp.StatusCode == 401
+		}, 5*time.Second, 100*time.Millisecond)
+	})
+
+	t.Run("the tokens are revoked when the user is deactivated", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		userID := th.GetUser1().ID
+		token := createToken(th, model.AccessTokenScopeRead)
+		require.NoError(t, th.Server.App().DeactivateUser(userID))
+
+		tokens, err := th.Server.Store().GetAccessTokensForUser(userID)
+		require.NoError(t, err)
+		require.Empty(t, tokens)
+
+		// the token doesn't come back with the reactivation of the user
+		require.NoError(t, th.Server.App().ReactivateUser(userID))
+		_, resp := tokenClient(th, token).GetMe()
+		th.CheckUnauthorized(resp)
+	})
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/client"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminUsers(t *testing.T) {
+	setup := func(t *testing.T) *TestHelper {
+		th := SetupTestHelper(t).InitBasic()
+		t.Cleanup(th.TearDown)
+
+		require.NoError(t, th.Server.App().UpdateUserSystemAdmin(th.GetUser1().ID, true))
+		return th
+	}
+
+	t.Run("the API requires a system admin", func(t *testing.T) {
+		th := setup(t)
+
+		_, resp := th.Client2.AdminGetUsers("", false, 0, 100)
+		th.CheckForbidden(resp)
+		th.CheckForbidden(th.Client2.AdminDeactivateUser(user1Username))
+		th.CheckForbidden(th.Client2.AdminSetSystemAdmin(user2Username, true))
+
+		_, resp = client.NewClient(th.Server.Config().ServerRoot, "").AdminGetUsers("", false, 0, 100)
+		th.CheckUnauthorized(resp)
+
+		// a read access token of a system admin can't use the API
+		token, resp := th.Client.CreateAccessToken(&model.AccessToken{
+			Name:   "script",
+			Scopes: []string{model.AccessTokenScopeRead},
+		})
+		th.CheckOK(resp)
+		_, resp = client.NewClient(th.Server.Config().ServerRoot, token.Token).AdminGetUsers("", false, 0, 100)
+		th.CheckForbidden(resp)
+	})
+
+	t.Run("list and search users", func(t *testing.T) {
+		th := setup(t)
+
+		users, resp := th.Client.AdminGetUsers("", false, 0, 100)
+		th.CheckOK(resp)
+		require.Len(t, users, 2)
+		require.Equal(t, user1Username, users[0].Username)
+		require.Equal(t, "user1@sample.com", users[0].Email)
+		require.True(t, users[0].IsSystemAdmin)
+		require.False(t, users[1].IsSystemAdmin)
+
+		users, resp = th.Client.AdminGetUsers("user2@", false, 0, 100)
+		th.CheckOK(resp)
+		require.Len(t, users, 1)
+		require.Equal(t, user2Username, users[0].Username)
+
+		users, resp = th.Client.AdminGetUsers("", false, 1, 1)
+		th.CheckOK(resp)
+		require.Len(t, users, 1)
+		require.Equal(t, user2Username, users[0].Username)
+
+		_, resp = th.Client.AdminGetUsers("", false, 0, 0)
+		th.CheckBadRequest(resp)
+
+		user, resp := th.Client.AdminGetUser(user2Username)
+		th.CheckOK(resp)
+		require.Equal(t, th.GetUser2().ID, user.ID)
+
+		_, resp = th.Client.AdminGetUser("nonexistent")
+		th.CheckNotFound(resp)
+	})
+
+	t.Run("deactivate and reactivate a user", func(t *testing.T) {
+		th := setup(t)
+
+		th.CheckOK(th.Client.AdminDeactivateUser(user2Username))
+
+		// the sessions of the deactivated user are revoked
+		_, resp := th.Client2.GetMe()
+		th.CheckUnauthorized(resp)
+		_, resp = th.Client2.Login(&model.LoginRequest{Type: "normal", Username: user2Username, Password: password})
+		require.Error(t, resp.Error)
+
+		user, resp := th.Client.AdminGetUser(user2Username)
+		th.CheckOK(resp)
+		require.NotZero(t, user.DeleteAt)
+
+		users, resp := th.Client.AdminGetUsers("", false, 0, 100)
+		th.CheckOK(resp)
+		require.Len(t, users, 1)
+		users, resp = th.Client.AdminGetUsers("", true, 0, 100)
+		th.CheckOK(resp)
+		require.Len(t, users, 2)
+
+		th.CheckBadRequest(th.Client.AdminDeactivateUser(user2Username))
+
+		th.CheckOK(th.Client.AdminReactivateUser(user2Username))
+		th.Login(th.Client2, user2Username, password)
+		th.CheckBadRequest(th.Client.AdminReactivateUser(user2Username))
+
+		// the system admins can't deactivate themselves
+		th.CheckBadRequest(th.Client.AdminDeactivateUser(user1Username))
+	})
+
+	t.Run("promote a user to system admin", func(t *testing.T) {
+		th := setup(t)
+
+		th.CheckOK(th.Client.AdminSetSystemAdmin(user2Username, true))
+		user, resp := th.Client2.AdminGetUser(user2Username)
+		th.CheckOK(resp)
+		require.True(t, user.IsSystemAdmin)
+
+		th.CheckOK(th.Client2.AdminSetSystemAdmin(user1Username, false))
+		_, resp = th.Client.AdminGetUsers("", false, 0, 100)
+		th.CheckForbidden(resp)
+
+		// the system admins can't demote themselves
+		th.CheckBadRequest(th.Client2.AdminSetSystemAdmin(user2Username, false))
+	})
+
+	t.Run("force logout a user", func(t *testing.T) {
+		th := setup(t)
+
+		th.CheckOK(th.Client.AdminRevokeSessions(user2Username))
+		_, resp := th.Client2.GetMe()
+		th.CheckUnauthorized(resp)
+
+		th.Login(th.Client2, user2Username, password)
+	})
+
+	t.Run("list the board memberships of a user", func(t *testing.T) {
+		th := setup(t)
+
+		board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+		_, resp := th.Client.AddMemberToBoard(&model.BoardMember{
+			BoardID:      board.ID,
+			UserID:       th.GetUser2().ID,
+			SchemeEditor: true,
+		})
+		th.CheckOK(resp)
+
+		memberships, resp := th.Client.AdminGetUserBoards(user2Username)
+		th.CheckOK(resp)
+		require.Len(t, memberships, 1)
+		require.Equal(t, board.ID, memberships[0].Board.ID)
+		require.Equal(t, th.GetUser2().ID, memberships[0].Member.UserID)
+		require.True(t, memberships[0].Member.SchemeEditor)
+	})
+}
@@ -0,0 +1,107 @@
+package integrationtests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardAutomations(t *testing.T) {
+	newRule := func() *model.AutomationRule {
+		return &model.AutomationRule{
+			Name:    "new cards are todo",
+			Enabled: true,
+			Trigger: model.AutomationTrigger{Type: model.AutomationTriggerCardCreated},
+			Actions: []model.AutomationAction{
+				{Type: model.AutomationActionSetProperty, PropertyID: "status", Value: "todo"},
+			},
+		}
+	}
+
+	t.Run("a non authenticated user should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+
+		th.Logout(th.Client)
+
+		rules, resp := th.Client.SetBoardAutomations(board.ID, []*model.AutomationRule{newRule()})
+		th.CheckUnauthorized(resp)
+		require.Nil(t, rules)
+	})
+
+	t.Run("user without access to the board", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board, resp := th.Client2.CreateBoard(&model.Board{TeamID: testTeamID, Type: model.BoardTypePrivate})
+		th.CheckOK(resp)
+
+		rules, resp := th.Client.SetBoardAutomations(board.ID, []*model.AutomationRule{newRule()})
+		th.CheckForbidden(resp)
+		require.Nil(t, rules)
+
+		rules, resp = th.Client.GetBoardAutomations(board.ID)
+		th.CheckForbidden(resp)
+		require.Nil(t, rules)
+	})
+
+	t.Run("move to a board the user can't manage", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+		otherBoard, resp := th.Client2.CreateBoard(&model.Board{TeamID: testTeamID, Type: model.BoardTypePrivate})
+		th.CheckOK(resp)
+
+		rule := newRule()
+		rule.Actions = []model.AutomationAction{
+			{Type: model.AutomationActionMoveToBoard, Move: &model.MoveCardRequest{TargetBoardID: otherBoard.ID}},
+		}
+		rules, resp := th.Client.SetBoardAutomations(board.ID, []*model.AutomationRule{rule})
+		th.CheckForbidden(resp)
+		require.Nil(t, rules)
+	})
+
+	t.Run("invalid rule", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+
+		rule := newRule()
+		rule.Actions = nil
+		rules, resp := th.Client.SetBoardAutomations(board.ID, []*model.AutomationRule{rule})
+		th.CheckBadRequest(resp)
+		require.Nil(t, rules)
+	})
+
+	t.Run("rules run when cards are created", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+
+		rules, resp := th.Client.SetBoardAutomations(board.ID, []*model.AutomationRule{newRule()})
+		th.CheckOK(resp)
+		require.Len(t, rules, 1)
+		require.NotEmpty(t, rules[0].ID)
+		require.Equal(t, th.GetUser1().ID, rules[0].CreatedBy)
+
+		fetched, resp := th.Client.GetBoardAutomations(board.ID)
+		th.CheckOK(resp)
+		require.Equal(t, rules, fetched)
+
+		card, resp := th.Client.CreateCard(board.ID, &model.Card{Title: "card"}, false)
+		th.CheckOK(resp)
+
+		require.Eventually(t, func() bool {
+			fetchedCard, resp := th.Client.GetCard(card.ID)
+			th.CheckOK(resp)
+			return fetchedCard.Properties["status"] == "todo"
+		}, 5*time.Second, 50*time.Millisecond)
+	})
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardOwnershipOnDeactivation(t *testing.T) {
+	t.Run("a team admin takes over the boards of a deactivated user", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		user1 := th.GetUser1()
+		user2 := th.GetUser2()
+		require.NoError(t, th.Server.App().UpdateUserSystemAdmin(user1.ID, true))
+
+		team, resp := th.Client.CreateTeam(&model.Team{Title: "Marketing"})
+		th.CheckOK(resp)
+		_, resp = th.Client.AddTeamMember(&model.TeamMember{TeamID: team.ID, UserID: user2.ID})
+		th.CheckOK(resp)
+
+		board, resp := th.Client2.CreateBoard(&model.Board{TeamID: team.ID, Type: model.BoardTypeOpen})
+		th.CheckOK(resp)
+
+		th.CheckOK(th.Client.AdminDeactivateUser(user2Username))
+
+		members, resp := th.Client.GetMembersForBoard(board.ID)
+		th.CheckOK(resp)
+		admins := []string{}
+		for _, member := range members {
+			if member.SchemeAdmin {
+				admins = append(admins, member.UserID)
+			}
+		}
+		require.Equal(t, []string{user1.ID}, admins)
+
+		// the deactivated user is demoted and the team admin added
+		history, err := th.Server.Store().GetBoardMemberHistory(board.ID, user2.ID, 1)
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		require.Equal(t, model.BoardMemberHistoryDemoted, history[0].Action)
+
+		history, err = th.Server.Store().GetBoardMemberHistory(board.ID, user1.ID, 1)
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		require.Equal(t, model.BoardMemberHistoryCreated, history[0].Action)
+	})
+
+	t.Run("a system admin takes over the boards of the default team", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		user1 := th.GetUser1()
+		user2 := th.GetUser2()
+		require.NoError(t, th.Server.App().UpdateUserSystemAdmin(user1.ID, true))
+
+		board, resp := th.Client2.CreateBoard(&model.Board{TeamID: model.GlobalTeamID, Type: model.BoardTypeOpen})
+		th.CheckOK(resp)
+
+		th.CheckOK(th.Client.AdminDeactivateUser(user2Username))
+
+		members, resp := th.Client.GetMembersForBoard(board.ID)
+		th.CheckOK(resp)
+		admins := []string{}
+		for _, member := range members {
+			if member.SchemeAdmin {
+				admins = append(admins, member.UserID)
+			}
+		}
+		require.Equal(t, []string{user1.ID}, admins)
+
+		history, err := th.Server.Store().GetBoardMemberHistory(board.ID, user2.ID, 1)
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		require.Equal(t, model.BoardMemberHistoryDemoted, history[0].Action)
+	})
+
+	t.Run("boards with other admins are left unchanged", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		user2 := th.GetUser2()
+		require.NoError(t, th.Server.App().UpdateUserSystemAdmin(th.GetUser1().ID, true))
+
+		board, resp := th.Client2.CreateBoard(&model.Board{TeamID: testTeamID, Type: model.BoardTypeOpen})
+		th.CheckOK(resp)
+		_, resp = th.Client2.AddMemberToBoard(&model.BoardMember{
+			BoardID:     board.ID,
+			UserID:      th.GetUser1().ID,
+			SchemeAdmin: true,
+		})
+		th.CheckOK(resp)
+
+		th.CheckOK(th.Client.AdminDeactivateUser(user2Username))
+
+		history, err := th.Server.Store().GetBoardMemberHistory(board.ID, user2.ID, 0)
+		require.NoError(t, err)
+		for _, entry := range history {
+			require.NotEqual(t, model.BoardMemberHistoryDemoted, entry.Action)
+		}
+	})
+}
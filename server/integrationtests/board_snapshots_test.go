@@ -0,0 +1,158 @@
+package integrationtests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardSnapshots(t *testing.T) {
+	t.Run("a non authenticated user should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+
+		th.Logout(th.Client)
+
+		snapshot, resp := th.Client.CreateBoardSnapshot(board.ID, "snapshot")
+		th.CheckUnauthorized(resp)
+		require.Nil(t, snapshot)
+	})
+
+	t.Run("user without access to the board", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board, resp := th.Client2.CreateBoard(&model.Board{TeamID: testTeamID, Type: model.BoardTypePrivate})
+		th.CheckOK(resp)
+
+		snapshot, resp := th.Client.CreateBoardSnapshot(board.ID, "snapshot")
+		th.CheckForbidden(resp)
+		require.Nil(t, snapshot)
+
+		snapshots, resp := th.Client.GetBoardSnapshots(board.ID)
+		th.CheckForbidden(resp)
+		require.Nil(t, snapshots)
+	})
+
+	t.Run("create, list and delete snapshots", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+
+		snapshot, resp := th.Client.CreateBoardSnapshot(board.ID, "")
+		th.CheckBadRequest(resp)
+		require.Nil(t, snapshot)
+
+		snapshot, resp = th.Client.CreateBoardSnapshot(board.ID, "before cleanup")
+		th.CheckOK(resp)
+		require.Equal(t, model.BoardSnapshotManual, snapshot.Type)
+		require.Equal(t, th.GetUser1().ID, snapshot.CreatedBy)
+
+		snapshots, resp := th.Client.GetBoardSnapshots(board.ID)
+		th.CheckOK(resp)
+		require.Len(t, snapshots, 1)
+		require.Equal(t, "before cleanup", snapshots[0].Name)
+
+		resp = th.Client.DeleteBoardSnapshot(board.ID, snapshot.ID)
+		th.CheckOK(resp)
+
+		snapshots, resp = th.Client.GetBoardSnapshots(board.ID)
+		th.CheckOK(resp)
+		require.Empty(t, snapshots)
+	})
+
+	t.Run("bulk operations take an automatic snapshot", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board, cards := th.CreateBoardAndCards(testTeamID, model.BoardTypeOpen, 2)
+
+		_, resp := th.Client.BulkUpdateCards(board.ID, &model.BulkCardRequest{
+			Operation: model.BulkCardOperationDelete,
+			CardIDs:   []string{cards[0].ID, cards[1].ID},
+		})
+		th.CheckOK(resp)
+
+		snapshots, resp := th.Client.GetBoardSnapshots(board.ID)
+		th.CheckOK(resp)
+		require.Len(t, snapshots, 1)
+		require.Equal(t, model.BoardSnapshotAutomatic, snapshots[0].Type)
+	})
+}
+
+func TestRestoreBoard(t *testing.T) {
+	t.Run("user without access to the board", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board, resp := th.Client2.CreateBoard(&model.Board{TeamID: testTeamID, Type: model.BoardTypePrivate})
+		th.CheckOK(resp)
+
+		restored, resp := th.Client.RestoreBoard(board.ID, &model.BoardRestoreRequest{Timestamp: board.CreateAt})
+		th.CheckForbidden(resp)
+		require.Nil(t, restored)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+
+		restored, resp := th.Client.RestoreBoard(board.ID, &model.BoardRestoreRequest{})
+		th.CheckBadRequest(resp)
+		require.Nil(t, restored)
+
+		restored, resp = th.Client.RestoreBoard(board.ID, &model.BoardRestoreRequest{Timestamp: board.CreateAt - 1})
+		th.CheckBadRequest(resp)
+		require.Nil(t, restored)
+	})
+
+	t.Run("restore to a snapshot", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board, cards := th.CreateBoardAndCards(testTeamID, model.BoardTypeOpen, 2)
+
+		snapshot, resp := th.Client.CreateBoardSnapshot(board.ID, "snapshot")
+		th.CheckOK(resp)
+		time.Sleep(5 * time.Millisecond)
+
+		boardTitle := "changed board"
+		_, resp = th.Client.PatchBoard(board.ID, &model.BoardPatch{Title: &boardTitle})
+		th.CheckOK(resp)
+		cardTitle := "changed card"
+		_, resp = th.Client.PatchCard(cards[0].ID, &model.CardPatch{Title: &cardTitle}, true)
+		th.CheckOK(resp)
+		_, resp = th.Client.DeleteBlock(board.ID, cards[1].ID, true)
+		th.CheckOK(resp)
+		newCard, resp := th.Client.CreateCard(board.ID, &model.Card{Title: "new card"}, true)
+		th.CheckOK(resp)
+
+		restored, resp := th.Client.RestoreBoard(board.ID, &model.BoardRestoreRequest{SnapshotID: snapshot.ID})
+		th.CheckOK(resp)
+		require.Equal(t, board.Title, restored.Title)
+
+		fetched, resp := th.Client.GetCards(board.ID, 0, 10)
+		th.CheckOK(resp)
+		require.Len(t, fetched, 2)
+		titles := map[string]string{}
+		for _, card := range fetched {
+			titles[card.ID] = card.Title
+		}
+		require.Equal(t, cards[0].Title, titles[cards[0].ID])
+		require.Contains(t, titles, cards[1].ID)
+		require.NotContains(t, titles, newCard.ID)
+
+		// the state before the restore is kept as a snapshot
+		snapshots, resp := th.Client.GetBoardSnapshots(board.ID)
+		th.CheckOK(resp)
+		require.Len(t, snapshots, 2)
+		require.Equal(t, model.BoardSnapshotAutomatic, snapshots[0].Type)
+	})
+}
@@ -940,6 +940,33 @@ func TestPatchBoard(t *testing.T) {
 		require.Nil(t, rBoard)
 	})
 
+	t.Run("automation rules can't be patched", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		user1 := th.GetUser1()
+
+		newBoard := &model.Board{
+			Title:  "title",
+			Type:   model.BoardTypeOpen,
+			TeamID: teamID,
+		}
+		board, err := th.Server.App().CreateBoard(newBoard, user1.ID, true)
+		require.NoError(t, err)
+
+		patch := &model.BoardPatch{
+			UpdatedProperties: map[string]interface{}{model.BoardPropertyAutomations: []interface{}{}},
+		}
+		rBoard, resp := th.Client.PatchBoard(board.ID, patch)
+		th.CheckBadRequest(resp)
+		require.Nil(t, rBoard)
+
+		patch = &model.BoardPatch{DeletedProperties: []string{model.BoardPropertyAutomations}}
+		rBoard, resp = th.Client.PatchBoard(board.ID, patch)
+		th.CheckBadRequest(resp)
+		require.Nil(t, rBoard)
+	})
+
 	t.Run("valid patch on a board with permissions", func(t *testing.T) {
 		th := SetupTestHelper(t).InitBasic()
 		defer th.TearDown()
@@ -0,0 +1,187 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+
+	mmModel "github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestSetCardAccess(t *testing.T) {
+	setupBoard := func(th *TestHelper) (*model.Board, []*model.Card) {
+		board, cards := th.CreateBoardAndCards(testTeamID, model.BoardTypePrivate, 2)
+		_, resp := th.Client.AddMemberToBoard(&model.BoardMember{
+			BoardID:      board.ID,
+			UserID:       th.GetUser2().ID,
+			SchemeEditor: true,
+		})
+		th.CheckOK(resp)
+		return board, cards
+	}
+
+	cardIDs := func(cards []*model.Card) []string {
+		ids := make([]string, 0, len(cards))
+		for _, card := range cards {
+			ids = append(ids, card.ID)
+		}
+		return ids
+	}
+
+	t.Run("a non authenticated user should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		_, cards := th.CreateBoardAndCards(testTeamID, model.BoardTypeOpen, 1)
+
+		th.Logout(th.Client)
+
+		card, resp := th.Client.SetCardAccess(cards[0].ID, &model.CardAccess{Restricted: true})
+		th.CheckUnauthorized(resp)
+		require.Nil(t, card)
+	})
+
+	t.Run("invalid access", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		_, cards := th.CreateBoardAndCards(testTeamID, model.BoardTypeOpen, 1)
+
+		card, resp := th.Client.SetCardAccess(cards[0].ID, &model.CardAccess{AllowedUserIDs: []string{th.GetUser2().ID}})
+		th.CheckBadRequest(resp)
+		require.Nil(t, card)
+	})
+
+	t.Run("restricted cards are hidden from other board members", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board, cards := setupBoard(th)
+
+		restricted, resp := th.Client.SetCardAccess(cards[0].ID, &model.CardAccess{Restricted: true})
+		th.CheckOK(resp)
+		require.True(t, restricted.IsRestricted)
+
+		// the creator still sees the card
+		fetched, resp := th.Client.GetCards(board.ID, 0, 10)
+		th.CheckOK(resp)
+		require.ElementsMatch(t, cardIDs(cards), cardIDs(fetched))
+
+		fetched, resp = th.Client2.GetCards(board.ID, 0, 10)
+		th.CheckOK(resp)
+		require.Equal(t, []string{cards[1].ID}, cardIDs(fetched))
+
+		blocks, resp := th.Client2.GetBlocksForBoard(board.ID)
+		th.CheckOK(resp)
+		for _, block := range blocks {
+			require.NotEqual(t, cards[0].ID, block.ID)
+		}
+
+		// the hidden cards are reported as not found by every card API
+		card, resp := th.Client2.GetCard(cards[0].ID)
+		th.CheckNotFound(resp)
+		require.Nil(t, card)
+
+		card, resp = th.Client2.SetCardAccess(cards[0].ID, &model.CardAccess{})
+		th.CheckNotFound(resp)
+		require.Nil(t, card)
+
+		_, resp = th.Client2.GetCardHistory(cards[0].ID, 0, 10)
+		th.CheckNotFound(resp)
+		_, resp = th.Client2.PatchCard(cards[0].ID, &model.CardPatch{Title: mmModel.NewString("new title")}, true)
+		th.CheckNotFound(resp)
+		_, resp = th.Client2.ArchiveCard(cards[0].ID)
+		th.CheckNotFound(resp)
+		_, resp = th.Client2.RevertCard(cards[0].ID, restricted.UpdateAt)
+		th.CheckNotFound(resp)
+		targetBoard := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+		_, resp = th.Client2.MoveCard(cards[0].ID, &model.MoveCardRequest{TargetBoardID: targetBoard.ID})
+		th.CheckNotFound(resp)
+		_, resp = th.Client2.PatchBlock(board.ID, cards[0].ID, &model.BlockPatch{Title: mmModel.NewString("new title")}, true)
+		th.CheckNotFound(resp)
+
+		comment := &model.Block{
+			BoardID:  board.ID,
+			ParentID: cards[0].ID,
+			Type:     model.TypeComment,
+			CreateAt: 1,
+			UpdateAt: 1,
+		}
+		_, resp = th.Client2.InsertBlocks(board.ID, []*model.Block{comment}, true)
+		th.CheckNotFound(resp)
+
+		results, resp := th.Client2.BulkUpdateCards(board.ID, &model.BulkCardRequest{
+			Operation: model.BulkCardOperationArchive,
+			CardIDs:   cardIDs(cards),
+		})
+		th.CheckOK(resp)
+		require.Len(t, results, 2)
+		require.Equal(t, "card not found", results[0].Error)
+		require.True(t, results[1].Success)
+
+		card, resp = th.Client.GetCard(cards[0].ID)
+		th.CheckOK(resp)
+		require.Equal(t, restricted.Title, card.Title)
+		require.Zero(t, card.ArchivedAt)
+
+		// the restriction can't be removed with a regular patch
+		_, resp = th.Client.PatchBlock(board.ID, cards[0].ID, &model.BlockPatch{
+			DeletedFields: []string{model.CardRestrictedField},
+		}, true)
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("allowed users can see restricted cards", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board, cards := setupBoard(th)
+
+		_, resp := th.Client.SetCardAccess(cards[0].ID, &model.CardAccess{
+			Restricted:     true,
+			AllowedUserIDs: []string{th.GetUser2().ID},
+		})
+		th.CheckOK(resp)
+
+		fetched, resp := th.Client2.GetCards(board.ID, 0, 10)
+		th.CheckOK(resp)
+		require.ElementsMatch(t, cardIDs(cards), cardIDs(fetched))
+
+		// allowed users can change the access too
+		card, resp := th.Client2.SetCardAccess(cards[0].ID, &model.CardAccess{})
+		th.CheckOK(resp)
+		require.False(t, card.IsRestricted)
+	})
+	t.Run("boards with hidden cards can't be duplicated", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board, cards := setupBoard(th)
+
+		_, resp := th.Client.SetCardAccess(cards[0].ID, &model.CardAccess{Restricted: true})
+		th.CheckOK(resp)
+
+		bab, resp := th.Client2.DuplicateBoard(board.ID, false, testTeamID)
+		th.CheckForbidden(resp)
+		require.Nil(t, bab)
+
+		// the users who see every card can still duplicate the board
+		bab, resp = th.Client.DuplicateBoard(board.ID, false, testTeamID)
+		th.CheckOK(resp)
+		require.Len(t, bab.Boards, 1)
+
+		_, resp = th.Client.SetCardAccess(cards[0].ID, &model.CardAccess{
+			Restricted:     true,
+			AllowedUserIDs: []string{th.GetUser2().ID},
+		})
+		th.CheckOK(resp)
+
+		bab, resp = th.Client2.DuplicateBoard(board.ID, false, testTeamID)
+		th.CheckOK(resp)
+		require.Len(t, bab.Boards, 1)
+	})
+}
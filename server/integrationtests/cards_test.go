@@ -3,8 +3,11 @@ package integrationtests
 import (
 	"fmt"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/gorilla/websocket"
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/utils"
 	"github.com/stretchr/testify/assert"
@@ -269,6 +272,426 @@ func TestGetCard(t *testing.T) {
 	})
 }
 
+func TestMoveCard(t *testing.T) {
+	statusProperty := func(id string, options ...string) map[string]interface{} {
+		opts := make([]interface{}, 0, len(options))
+		for _, value := range options {
+			opts = append(opts, map[string]interface{}{"id": id + "-" + value, "value": value})
+		}
+		return map[string]interface{}{"id": id, "name": "Status", "type": "select", "options": opts}
+	}
+
+	createBoard := func(th *TestHelper, props ...map[string]interface{}) *model.Board {
+		board, resp := th.Client.CreateBoard(&model.Board{
+			TeamID:         testTeamID,
+			Type:           model.BoardTypeOpen,
+			CardProperties: props,
+		})
+		th.CheckOK(resp)
+		return board
+	}
+
+	t.Run("a non authenticated user should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		_, cards := th.CreateBoardAndCards(testTeamID, model.BoardTypeOpen, 1)
+		target := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+
+		th.Logout(th.Client)
+
+		card, resp := th.Client.MoveCard(cards[0].ID, &model.MoveCardRequest{TargetBoardID: target.ID})
+		th.CheckUnauthorized(resp)
+		require.Nil(t, card)
+	})
+
+	t.Run("missing target board", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		_, cards := th.CreateBoardAndCards(testTeamID, model.BoardTypeOpen, 1)
+
+		card, resp := th.Client.MoveCard(cards[0].ID, &model.MoveCardRequest{})
+		th.CheckBadRequest(resp)
+		require.Nil(t, card)
+	})
+
+	t.Run("user without access to the target board", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		_, cards := th.CreateBoardAndCards(testTeamID, model.BoardTypeOpen, 1)
+
+		target, resp := th.Client2.CreateBoard(&model.Board{TeamID: testTeamID, Type: model.BoardTypePrivate})
+		th.CheckOK(resp)
+
+		card, resp := th.Client.MoveCard(cards[0].ID, &model.MoveCardRequest{TargetBoardID: target.ID})
+		th.CheckForbidden(resp)
+		require.Nil(t, card)
+	})
+
+	t.Run("good", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		source := createBoard(th, statusProperty("src-status", "Todo", "Done"))
+		target := createBoard(th, statusProperty("tgt-status", "Done", "Archived"))
+
+		card, resp := th.Client.CreateCard(source.ID, &model.Card{
+			Title:      "card to move",
+			Properties: map[string]any{"src-status": "src-status-Done"},
+		}, true)
+		th.CheckOK(resp)
+
+		moved, resp := th.Client.MoveCard(card.ID, &model.MoveCardRequest{TargetBoardID: target.ID})
+		th.CheckOK(resp)
+		require.NotNil(t, moved)
+		require.Equal(t, card.ID, moved.ID)
+		require.Equal(t, target.ID, moved.BoardID)
+		require.Equal(t, map[string]any{"tgt-status": "tgt-status-Done"}, moved.Properties)
+
+		fetched, resp := th.Client.GetCard(card.ID)
+		th.CheckOK(resp)
+		require.Equal(t, target.ID, fetched.BoardID)
+
+		sourceCards, resp := th.Client.GetCards(source.ID, 0, 10)
+		th.CheckOK(resp)
+		require.Empty(t, sourceCards)
+	})
+}
+
+func TestArchiveCard(t *testing.T) {
+	t.Run("a non authenticated user should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		_, cards := th.CreateBoardAndCards(testTeamID, model.BoardTypeOpen, 1)
+
+		th.Logout(th.Client)
+
+		card, resp := th.Client.ArchiveCard(cards[0].ID)
+		th.CheckUnauthorized(resp)
+		require.Nil(t, card)
+	})
+
+	t.Run("user without access to the board", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board, resp := th.Client2.CreateBoard(&model.Board{TeamID: testTeamID, Type: model.BoardTypePrivate})
+		th.CheckOK(resp)
+		card, resp := th.Client2.CreateCard(board.ID, &model.Card{Title: "private card"}, true)
+		th.CheckOK(resp)
+
+		archived, resp := th.Client.ArchiveCard(card.ID)
+		th.CheckForbidden(resp)
+		require.Nil(t, archived)
+	})
+
+	t.Run("archive and unarchive", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board, cards := th.CreateBoardAndCards(testTeamID, model.BoardTypeOpen, 3)
+
+		archived, resp := th.Client.ArchiveCard(cards[0].ID)
+		th.CheckOK(resp)
+		require.NotZero(t, archived.ArchivedAt)
+		require.Zero(t, archived.DeleteAt)
+
+		fetched, resp := th.Client.GetCards(board.ID, 0, 10)
+		th.CheckOK(resp)
+		require.Len(t, fetched, 2)
+
+		fetched, resp = th.Client.GetCardsIncludingArchived(board.ID, 0, 10)
+		th.CheckOK(resp)
+		require.Len(t, fetched, 3)
+
+		// archived cards remain readable
+		card, resp := th.Client.GetCard(cards[0].ID)
+		th.CheckOK(resp)
+		require.Equal(t, archived.ArchivedAt, card.ArchivedAt)
+
+		unarchived, resp := th.Client.UnarchiveCard(cards[0].ID)
+		th.CheckOK(resp)
+		require.Zero(t, unarchived.ArchivedAt)
+
+		fetched, resp = th.Client.GetCards(board.ID, 0, 10)
+		th.CheckOK(resp)
+		require.Len(t, fetched, 3)
+	})
+
+	t.Run("the blocks of archived cards are hidden", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board, cards := th.CreateBoardAndCards(testTeamID, model.BoardTypeOpen, 2)
+
+		content := &model.Block{
+			ID:       utils.NewID(utils.IDTypeBlock),
+			BoardID:  board.ID,
+			ParentID: cards[0].ID,
+			CreateAt: 1,
+			UpdateAt: 1,
+			Type:     model.TypeText,
+			Title:    "content",
+		}
+		_, resp := th.Client.InsertBlocks(board.ID, []*model.Block{content}, false)
+		th.CheckOK(resp)
+
+		_, resp = th.Client.ArchiveCard(cards[0].ID)
+		th.CheckOK(resp)
+
+		blocks, resp := th.Client.GetAllBlocksForBoard(board.ID)
+		th.CheckOK(resp)
+		require.Len(t, blocks, 1)
+		require.Equal(t, cards[1].ID, blocks[0].ID)
+
+		blocks, resp = th.Client.GetAllBlocksForBoardIncludingArchived(board.ID)
+		th.CheckOK(resp)
+		require.Len(t, blocks, 3)
+	})
+}
+
+func TestBulkUpdateCards(t *testing.T) {
+	createBoard := func(th *TestHelper) *model.Board {
+		board, resp := th.Client.CreateBoard(&model.Board{
+			TeamID: testTeamID,
+			Type:   model.BoardTypeOpen,
+			CardProperties: []map[string]interface{}{
+				{"id": "status", "name": "Status", "type": "text"},
+				{"id": "assignee", "name": "Assignee", "type": "multiPerson"},
+			},
+		})
+		th.CheckOK(resp)
+		return board
+	}
+
+	createCards := func(th *TestHelper, boardID string, count int) []*model.Card {
+		cards := make([]*model.Card, 0, count)
+		for i := 0; i < count; i++ {
+			card, resp := th.Client.CreateCard(boardID, &model.Card{
+				Title:      fmt.Sprintf("card %d", i+1),
+				Properties: map[string]any{"status": "todo"},
+			}, true)
+			th.CheckOK(resp)
+			cards = append(cards, card)
+		}
+		return cards
+	}
+
+	t.Run("a non authenticated user should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board, cards := th.CreateBoardAndCards(testTeamID, model.BoardTypeOpen, 1)
+
+		th.Logout(th.Client)
+
+		results, resp := th.Client.BulkUpdateCards(board.ID, &model.BulkCardRequest{
+			Operation: model.BulkCardOperationDelete,
+			CardIDs:   []string{cards[0].ID},
+		})
+		th.CheckUnauthorized(resp)
+		require.Nil(t, results)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+
+		results, resp := th.Client.BulkUpdateCards(board.ID, &model.BulkCardRequest{Operation: model.BulkCardOperationDelete})
+		th.CheckBadRequest(resp)
+		require.Nil(t, results)
+	})
+
+	t.Run("set property with per card results", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := createBoard(th)
+		cards := createCards(th, board.ID, 2)
+		_, otherCards := th.CreateBoardAndCards(testTeamID, model.BoardTypeOpen, 1)
+
+		results, resp := th.Client.BulkUpdateCards(board.ID, &model.BulkCardRequest{
+			Operation:  model.BulkCardOperationSetProperty,
+			CardIDs:    []string{cards[0].ID, "missing-card", otherCards[0].ID, cards[1].ID},
+			PropertyID: "status",
+			Value:      "done",
+		})
+		th.CheckOK(resp)
+		require.Len(t, results, 4)
+		require.True(t, results[0].Success)
+		require.False(t, results[1].Success)
+		require.NotEmpty(t, results[1].Error)
+		require.False(t, results[2].Success)
+		require.True(t, results[3].Success)
+
+		for _, card := range cards {
+			fetched, resp := th.Client.GetCard(card.ID)
+			th.CheckOK(resp)
+			require.Equal(t, "done", fetched.Properties["status"])
+		}
+	})
+
+	t.Run("add person by filter", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := createBoard(th)
+		cards := createCards(th, board.ID, 3)
+
+		_, resp := th.Client.PatchCard(cards[2].ID, &model.CardPatch{UpdatedProperties: map[string]any{"status": "done"}}, true)
+		th.CheckOK(resp)
+
+		results, resp := th.Client.BulkUpdateCards(board.ID, &model.BulkCardRequest{
+			Operation:  model.BulkCardOperationAddPerson,
+			Filter:     &model.BulkCardFilter{Properties: map[string]any{"status": "todo"}},
+			PropertyID: "assignee",
+			UserID:     th.GetUser2().ID,
+		})
+		th.CheckOK(resp)
+		require.Len(t, results, 2)
+
+		fetched, resp := th.Client.GetCard(cards[0].ID)
+		th.CheckOK(resp)
+		require.Equal(t, []any{th.GetUser2().ID}, fetched.Properties["assignee"])
+
+		fetched, resp = th.Client.GetCard(cards[2].ID)
+		th.CheckOK(resp)
+		require.Nil(t, fetched.Properties["assignee"])
+	})
+
+	t.Run("archive, duplicate and delete", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := createBoard(th)
+		cards := createCards(th, board.ID, 3)
+
+		results, resp := th.Client.BulkUpdateCards(board.ID, &model.BulkCardRequest{
+			Operation: model.BulkCardOperationArchive,
+			CardIDs:   []string{cards[0].ID},
+		})
+		th.CheckOK(resp)
+		require.True(t, results[0].Success)
+
+		results, resp = th.Client.BulkUpdateCards(board.ID, &model.BulkCardRequest{
+			Operation: model.BulkCardOperationDuplicate,
+			CardIDs:   []string{cards[1].ID, cards[2].ID},
+		})
+		th.CheckOK(resp)
+		require.Len(t, results, 2)
+		require.NotEmpty(t, results[0].NewCardID)
+		require.NotEqual(t, cards[1].ID, results[0].NewCardID)
+
+		fetched, resp := th.Client.GetCards(board.ID, 0, 10)
+		th.CheckOK(resp)
+		require.Len(t, fetched, 4)
+
+		results, resp = th.Client.BulkUpdateCards(board.ID, &model.BulkCardRequest{
+			Operation: model.BulkCardOperationDelete,
+			Filter:    &model.BulkCardFilter{IncludeArchived: true},
+		})
+		th.CheckOK(resp)
+		require.Len(t, results, 5)
+
+		fetched, resp = th.Client.GetCardsIncludingArchived(board.ID, 0, 10)
+		th.CheckOK(resp)
+		require.Empty(t, fetched)
+	})
+
+	t.Run("move", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := createBoard(th)
+		target := createBoard(th)
+		cards := createCards(th, board.ID, 2)
+
+		results, resp := th.Client.BulkUpdateCards(board.ID, &model.BulkCardRequest{
+			Operation: model.BulkCardOperationMove,
+			CardIDs:   []string{cards[0].ID, cards[1].ID},
+			Move:      &model.MoveCardRequest{TargetBoardID: target.ID},
+		})
+		th.CheckOK(resp)
+		require.Len(t, results, 2)
+
+		fetched, resp := th.Client.GetCards(target.ID, 0, 10)
+		th.CheckOK(resp)
+		require.Len(t, fetched, 2)
+		require.Equal(t, "todo", fetched[0].Properties["status"])
+	})
+
+	t.Run("the changes are broadcast in one message", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := createBoard(th)
+		cards := createCards(th, board.ID, 3)
+
+		wsURL := "ws" + strings.TrimPrefix(th.Server.Config().ServerRoot, "http") + "/ws"
+		conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		require.NoError(t, conn.WriteJSON(map[string]string{"action": "AUTH", "token": th.Client.Token}))
+		require.NoError(t, conn.WriteJSON(map[string]string{"action": "SUBSCRIBE_TEAM", "teamId": testTeamID}))
+
+		type message struct {
+			Action string         `json:"action"`
+			Blocks []*model.Block `json:"blocks"`
+		}
+		messages := make(chan message, 100)
+		go func() {
+			for {
+				var msg message
+				if err := conn.ReadJSON(&msg); err != nil {
+					return
+				}
+				if strings.HasPrefix(msg.Action, "UPDATE_BLOCK") {
+					messages <- msg
+				}
+			}
+		}()
+
+		// the bulk changes are only received once the connection is
+		// authenticated and subscribed
+		value := 0
+		require.Eventually(t, func() bool {
+			value++
+			_, resp := th.Client.BulkUpdateCards(board.ID, &model.BulkCardRequest{
+				Operation:  model.BulkCardOperationSetProperty,
+				CardIDs:    []string{cards[0].ID, cards[1].ID, cards[2].ID},
+				PropertyID: "status",
+				Value:      strconv.Itoa(value),
+			})
+			th.CheckOK(resp)
+
+			select {
+			case msg := <-messages:
+				require.Equal(t, "UPDATE_BLOCKS", msg.Action)
+				require.Len(t, msg.Blocks, 3)
+				require.ElementsMatch(t,
+					[]string{cards[0].ID, cards[1].ID, cards[2].ID},
+					[]string{msg.Blocks[0].ID, msg.Blocks[1].ID, msg.Blocks[2].ID},
+				)
+				return true
+			case <-time.After(100 * time.Millisecond):
+				return false
+			}
+		}, 5*time.Second, 10*time.Millisecond)
+
+		select {
+		case msg := <-messages:
+			require.Failf(t, "unexpected message", "action %s", msg.Action)
+		case <-time.After(200 * time.Millisecond):
+		}
+	})
+}
+
 // Helpers.
 func reverse(src []string) []string {
 	out := make([]string, 0, len(src))
@@ -285,3 +708,183 @@ func modifyCardProps(m map[string]any) map[string]any {
 	}
 	return out
 }
+
+func TestCardHistory(t *testing.T) {
+	t.Run("a non authenticated user should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		_, cards := th.CreateBoardAndCards(testTeamID, model.BoardTypeOpen, 1)
+
+		th.Logout(th.Client)
+
+		versions, resp := th.Client.GetCardHistory(cards[0].ID, 0, 0)
+		th.CheckUnauthorized(resp)
+		require.Nil(t, versions)
+	})
+
+	t.Run("user without access to the board", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board, resp := th.Client2.CreateBoard(&model.Board{TeamID: testTeamID, Type: model.BoardTypePrivate})
+		th.CheckOK(resp)
+		card, resp := th.Client2.CreateCard(board.ID, &model.Card{Title: "private card"}, true)
+		th.CheckOK(resp)
+
+		versions, resp := th.Client.GetCardHistory(card.ID, 0, 0)
+		th.CheckForbidden(resp)
+		require.Nil(t, versions)
+	})
+
+	t.Run("history of title and content changes", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board, resp := th.Client.CreateBoard(&model.Board{TeamID: testTeamID, Type: model.BoardTypeOpen})
+		th.CheckOK(resp)
+		card, resp := th.Client.CreateCard(board.ID, &model.Card{Title: "first"}, true)
+		th.CheckOK(resp)
+
+		time.Sleep(5 * time.Millisecond)
+		title := "second"
+		_, resp = th.Client.PatchCard(card.ID, &model.CardPatch{Title: &title}, true)
+		th.CheckOK(resp)
+
+		time.Sleep(5 * time.Millisecond)
+		text := &model.Block{
+			ID:       utils.NewID(utils.IDTypeBlock),
+			BoardID:  board.ID,
+			ParentID: card.ID,
+			Type:     model.TypeText,
+			CreateAt: 1,
+			UpdateAt: 1,
+			Title:    "some text",
+		}
+		inserted, resp := th.Client.InsertBlocks(board.ID, []*model.Block{text}, true)
+		th.CheckOK(resp)
+		text = inserted[0]
+
+		versions, resp := th.Client.GetCardHistory(card.ID, 0, 0)
+		th.CheckOK(resp)
+		require.Len(t, versions, 3)
+
+		require.Len(t, versions[0].ContentChanges, 1)
+		require.Equal(t, text.ID, versions[0].ContentChanges[0].BlockID)
+		require.Equal(t, model.CardContentAdded, versions[0].ContentChanges[0].Action)
+		require.Nil(t, versions[0].TitleChange)
+
+		require.NotNil(t, versions[1].TitleChange)
+		require.Equal(t, "first", versions[1].TitleChange.OldValue)
+		require.Equal(t, "second", versions[1].TitleChange.NewValue)
+		require.Equal(t, []string{th.GetUser1().ID}, versions[1].ModifiedBy)
+
+		require.Equal(t, "first", versions[2].Card.Title)
+
+		// paging
+		versions, resp = th.Client.GetCardHistory(card.ID, versions[0].UpdateAt, 1)
+		th.CheckOK(resp)
+		require.Len(t, versions, 1)
+		require.Equal(t, "second", versions[0].Card.Title)
+	})
+}
+
+func TestRevertCard(t *testing.T) {
+	t.Run("a non authenticated user should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		_, cards := th.CreateBoardAndCards(testTeamID, model.BoardTypeOpen, 1)
+
+		th.Logout(th.Client)
+
+		card, resp := th.Client.RevertCard(cards[0].ID, cards[0].UpdateAt)
+		th.CheckUnauthorized(resp)
+		require.Nil(t, card)
+	})
+
+	t.Run("user without access to the board", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board, resp := th.Client2.CreateBoard(&model.Board{TeamID: testTeamID, Type: model.BoardTypePrivate})
+		th.CheckOK(resp)
+		card, resp := th.Client2.CreateCard(board.ID, &model.Card{Title: "private card"}, true)
+		th.CheckOK(resp)
+
+		reverted, resp := th.Client.RevertCard(card.ID, card.UpdateAt)
+		th.CheckForbidden(resp)
+		require.Nil(t, reverted)
+	})
+
+	t.Run("no version at the given time", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		_, cards := th.CreateBoardAndCards(testTeamID, model.BoardTypeOpen, 1)
+
+		reverted, resp := th.Client.RevertCard(cards[0].ID, cards[0].CreateAt-1)
+		th.CheckBadRequest(resp)
+		require.Nil(t, reverted)
+	})
+
+	t.Run("revert title and content", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board, resp := th.Client.CreateBoard(&model.Board{TeamID: testTeamID, Type: model.BoardTypeOpen})
+		th.CheckOK(resp)
+		card, resp := th.Client.CreateCard(board.ID, &model.Card{Title: "first"}, true)
+		th.CheckOK(resp)
+
+		time.Sleep(5 * time.Millisecond)
+		text := &model.Block{
+			ID:       utils.NewID(utils.IDTypeBlock),
+			BoardID:  board.ID,
+			ParentID: card.ID,
+			Type:     model.TypeText,
+			CreateAt: 1,
+			UpdateAt: 1,
+			Title:    "kept text",
+		}
+		inserted, resp := th.Client.InsertBlocks(board.ID, []*model.Block{text}, true)
+		th.CheckOK(resp)
+		text = inserted[0]
+
+		versions, resp := th.Client.GetCardHistory(card.ID, 0, 0)
+		th.CheckOK(resp)
+		revertTo := versions[0].UpdateAt
+
+		time.Sleep(5 * time.Millisecond)
+		title := "second"
+		_, resp = th.Client.PatchCard(card.ID, &model.CardPatch{Title: &title}, true)
+		th.CheckOK(resp)
+		_, resp = th.Client.DeleteBlock(board.ID, text.ID, true)
+		th.CheckOK(resp)
+		added := &model.Block{
+			ID:       utils.NewID(utils.IDTypeBlock),
+			BoardID:  board.ID,
+			ParentID: card.ID,
+			Type:     model.TypeText,
+			CreateAt: 1,
+			UpdateAt: 1,
+			Title:    "added text",
+		}
+		_, resp = th.Client.InsertBlocks(board.ID, []*model.Block{added}, true)
+		th.CheckOK(resp)
+
+		reverted, resp := th.Client.RevertCard(card.ID, revertTo)
+		th.CheckOK(resp)
+		require.Equal(t, "first", reverted.Title)
+
+		blocks, resp := th.Client.GetBlocksForBoard(board.ID)
+		th.CheckOK(resp)
+		var contentIDs []string
+		for _, block := range blocks {
+			if block.ParentID == card.ID {
+				contentIDs = append(contentIDs, block.ID)
+			}
+		}
+		require.Equal(t, []string{text.ID}, contentIDs)
+	})
+}
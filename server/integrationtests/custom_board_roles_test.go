@@ -0,0 +1,97 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomBoardRoles(t *testing.T) {
+	createRole := func(th *TestHelper, teamID string, permissions ...string) *model.CustomBoardRole {
+		role, err := th.Server.App().CreateCustomBoardRole(&model.CustomBoardRole{
+			TeamID:      teamID,
+			Name:        "card editor",
+			Permissions: permissions,
+		}, th.GetUser1().ID)
+		require.NoError(t, err)
+		return role
+	}
+
+	t.Run("a non authenticated user should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		th.Logout(th.Client)
+
+		roles, resp := th.Client.GetCustomBoardRoles(testTeamID)
+		th.CheckUnauthorized(resp)
+		require.Nil(t, roles)
+	})
+
+	t.Run("only team admins can manage custom roles", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		role, resp := th.Client.CreateCustomBoardRole(&model.CustomBoardRole{
+			TeamID:      testTeamID,
+			Name:        "card editor",
+			Permissions: []string{model.PermissionManageBoardCards.Id},
+		})
+		th.CheckForbidden(resp)
+		require.Nil(t, role)
+
+		existing := createRole(th, testTeamID, model.PermissionManageBoardCards.Id)
+
+		name := "new name"
+		role, resp = th.Client.PatchCustomBoardRole(testTeamID, existing.ID, &model.CustomBoardRolePatch{Name: &name})
+		th.CheckForbidden(resp)
+		require.Nil(t, role)
+
+		resp = th.Client.DeleteCustomBoardRole(testTeamID, existing.ID)
+		th.CheckForbidden(resp)
+
+		roles, resp := th.Client.GetCustomBoardRoles(testTeamID)
+		th.CheckOK(resp)
+		require.Equal(t, []*model.CustomBoardRole{existing}, roles)
+	})
+
+	t.Run("custom roles grant their permissions to the board members", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+		member, resp := th.Client.AddMemberToBoard(&model.BoardMember{
+			BoardID:         board.ID,
+			UserID:          th.GetUser2().ID,
+			SchemeCommenter: true,
+		})
+		th.CheckOK(resp)
+
+		card, resp := th.Client2.CreateCard(board.ID, &model.Card{Title: "card"}, true)
+		th.CheckForbidden(resp)
+		require.Nil(t, card)
+
+		otherTeamRole := createRole(th, "other-team-id", model.PermissionManageBoardCards.Id)
+		member.CustomRoleID = otherTeamRole.ID
+		_, resp = th.Client.UpdateBoardMember(member)
+		th.CheckBadRequest(resp)
+
+		role := createRole(th, testTeamID, model.PermissionManageBoardCards.Id)
+		member.CustomRoleID = role.ID
+		member, resp = th.Client.UpdateBoardMember(member)
+		th.CheckOK(resp)
+		require.Equal(t, role.ID, member.CustomRoleID)
+
+		card, resp = th.Client2.CreateCard(board.ID, &model.Card{Title: "card"}, true)
+		th.CheckOK(resp)
+		require.NotNil(t, card)
+
+		// the role doesn't grant the permissions it doesn't have
+		_, resp = th.Client2.PatchBoard(board.ID, &model.BoardPatch{Title: &card.Title})
+		th.CheckForbidden(resp)
+	})
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package integrationtests
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/client"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/services/email/emailtest"
+	"github.com/stretchr/testify/require"
+)
+
+var verifyLinkRegexp = regexp.MustCompile(`/verify_email\?token=(\S+)`)
+
+func TestEmailVerification(t *testing.T) {
+	setup := func(t *testing.T) (*TestHelper, *emailtest.Server, string) {
+		th := SetupTestHelper(t).InitBasic()
+		t.Cleanup(th.TearDown)
+
+		server, err := emailtest.NewServer()
+		require.NoError(t, err)
+		t.Cleanup(server.Close)
+
+		th.Server.Config().SMTP = config.SMTPConfig{
+			Server:      server.Host(),
+			Port:        server.Port(),
+			FromAddress: "boards@example.com",
+		}
+		th.Server.Config().RequireEmailVerification = true
+
+		rootTeam, err := th.Server.App().GetRootTeam()
+		require.NoError(t, err)
+		return th, server, rootTeam.SignupToken
+	}
+
+	// receiveEmail waits for the count-th email and returns it.
+	receiveEmail := func(t *testing.T, server *emailtest.Server, count int) emailtest.Message {
+		require.Eventually(t, func() bool {
+			return len(server.Messages()) == count
+		}, 5*time.Second, 20*time.Millisecond)
+		return server.Messages()[count-1]
+	}
+
+	verificationToken := func(t *testing.T, msg emailtest.Message) string {
+		match := verifyLinkRegexp.FindStringSubmatch(msg.Body)
+		require.Len(t, match, 2)
+		token, err := url.QueryUnescape(match[1])
+		require.NoError(t, err)
+		return token
+	}
+
+	register := func(th *TestHelper, username, email, token string) *client.Response {
+		_, resp := th.Client.Register(&model.RegisterRequest{
+			Username: username,
+			Email:    email,
+			Password: password,
+			Token:    token,
+		})
+		return resp
+	}
+
+	login := func(th *TestHelper, username string) *client.Response {
+		c := client.NewClient(th.Server.Config().ServerRoot, "")
+		_, resp := c.Login(&model.LoginRequest{Type: "normal", Username: username, Password: password})
+		return resp
+	}
+
+	t.Run("users log in after verifying their email address", func(t *testing.T) {
+		th, server, signupToken := setup(t)
+
+		th.CheckOK(register(th, "user3", "user3@sample.com", signupToken))
+
+		resp := login(th, "user3")
+		th.CheckUnauthorized(resp)
+		require.Contains(t, resp.Error.Error(), "not verified")
+
+		msg := receiveEmail(t, server, 1)
+		require.Equal(t, []string{"user3@sample.com"}, msg.To)
+		token := verificationToken(t, msg)
+
+		th.CheckOK(th.Client.VerifyEmail(token))
+		th.CheckOK(login(th, "user3"))
+
+		// the token can only be used once
+		th.CheckUnauthorized(th.Client.VerifyEmail(token))
+
+		// the existing users aren't affected
+		th.CheckOK(login(th, user1Username))
+	})
+
+	t.Run("a new email replaces the previous link", func(t *testing.T) {
+		th, server, signupToken := setup(t)
+
+		th.CheckOK(register(th, "user3", "user3@sample.com", signupToken))
+		first := verificationToken(t, receiveEmail(t, server, 1))
+
+		th.CheckOK(th.Client.SendEmailVerification("user3@sample.com"))
+		second := verificationToken(t, receiveEmail(t, server, 2))
+
+		th.CheckUnauthorized(th.Client.VerifyEmail(first))
+		th.CheckOK(th.Client.VerifyEmail(second))
+
+		// no email for verified or unknown addresses
+		th.CheckOK(th.Client.SendEmailVerification("user3@sample.com"))
+		th.CheckOK(th.Client.SendEmailVerification("nobody@sample.com"))
+		time.Sleep(100 * time.Millisecond)
+		require.Len(t, server.Messages(), 2)
+	})
+
+	t.Run("invited users don't need to verify their email address", func(t *testing.T) {
+		th, server, _ := setup(t)
+
+		team, resp := th.Client.CreateTeam(&model.Team{Title: "Marketing"})
+		th.CheckOK(resp)
+		invite, resp := th.Client.CreateTeamInvite(&model.TeamInvite{TeamID: team.ID, Email: "user3@sample.com"})
+		th.CheckOK(resp)
+
+		msg := receiveEmail(t, server, 1)
+		require.Equal(t, []string{"user3@sample.com"}, msg.To)
+		require.Contains(t, msg.Body, "/register?t="+invite.ID)
+
+		th.CheckOK(register(th, "user3", "user3@sample.com", invite.ID))
+		th.CheckOK(login(th, "user3"))
+	})
+
+	t.Run("signups fail without an SMTP server", func(t *testing.T) {
+		th, _, signupToken := setup(t)
+		th.Server.Config().SMTP = config.SMTPConfig{}
+
+		resp := register(th, "user3", "user3@sample.com", signupToken)
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusNotImplemented, resp.StatusCode)
+	})
+}
+
+func TestAllowedSignupDomains(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+	th.Server.Config().AllowedSignupDomains = []string{"example.com"}
+
+	rootTeam, err := th.Server.App().GetRootTeam()
+	require.NoError(t, err)
+
+	register := func(username, email, token string) *client.Response {
+		_, resp := th.Client.Register(&model.RegisterRequest{
+			Username: username,
+			Email:    email,
+			Password: password,
+			Token:    token,
+		})
+		return resp
+	}
+
+	th.CheckForbidden(register("user3", "user3@sample.com", rootTeam.SignupToken))
+	th.CheckForbidden(register("user3", "user3@sub.example.com", rootTeam.SignupToken))
+	th.CheckOK(register("user3", "user3@Example.com", rootTeam.SignupToken))
+
+	// the invitations restricted to an email address bypass the domains
+	team, resp := th.Client.CreateTeam(&model.Team{Title: "Marketing"})
+	th.CheckOK(resp)
+	invite, resp := th.Client.CreateTeamInvite(&model.TeamInvite{TeamID: team.ID, Email: "user4@sample.com"})
+	th.CheckOK(resp)
+	th.CheckOK(register("user4", "user4@sample.com", invite.ID))
+
+	openInvite, resp := th.Client.CreateTeamInvite(&model.TeamInvite{TeamID: team.ID})
+	th.CheckOK(resp)
+	th.CheckForbidden(register("user5", "user5@sample.com", openInvite.ID))
+}
@@ -63,4 +63,39 @@ func TestExportBoard(t *testing.T) {
 		require.Len(t, blocksImported, 1)
 		require.Equal(t, block.Title, blocksImported[0].Title)
 	})
+	t.Run("export without archived cards", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board, cards := th.CreateBoardAndCards(testTeamID, model.BoardTypeOpen, 2)
+
+		_, resp := th.Client.ArchiveCard(cards[0].ID)
+		th.CheckOK(resp)
+
+		buf, resp := th.Client.ExportBoardArchiveWithArchivedCards(board.ID, model.ArchivedCardsExclude)
+		th.CheckOK(resp)
+		require.NotNil(t, buf)
+
+		resp = th.Client.ImportArchive(model.GlobalTeamID, bytes.NewReader(buf))
+		th.CheckOK(resp)
+
+		boardsImported, err := th.Server.App().GetBoardsForUserAndTeam(th.GetUser1().ID, model.GlobalTeamID, true)
+		require.NoError(t, err)
+		require.Len(t, boardsImported, 1)
+		cardsImported, err := th.Server.App().GetCardsForBoard(boardsImported[0].ID, 0, 0, true)
+		require.NoError(t, err)
+		require.Len(t, cardsImported, 1)
+		require.Equal(t, cards[1].Title, cardsImported[0].Title)
+	})
+
+	t.Run("invalid archived cards filter", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+
+		buf, resp := th.Client.ExportBoardArchiveWithArchivedCards(board.ID, "sometimes")
+		th.CheckBadRequest(resp)
+		require.Nil(t, buf)
+	})
 }
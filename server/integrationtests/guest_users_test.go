@@ -0,0 +1,70 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuestUsers(t *testing.T) {
+	t.Run("guests can't create boards or teams", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		err := th.Server.App().UpdateUserGuest(th.GetUser2().ID, true, 0)
+		require.NoError(t, err)
+
+		board, resp := th.Client2.CreateBoard(&model.Board{
+			TeamID: testTeamID,
+			Type:   model.BoardTypeOpen,
+			Title:  "guest board",
+		})
+		th.CheckForbidden(resp)
+		require.Nil(t, board)
+
+		team, resp := th.Client2.CreateTeam(&model.Team{Title: "guest team"})
+		th.CheckForbidden(resp)
+		require.Nil(t, team)
+	})
+
+	t.Run("guests can use boards they are added to", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+		_, resp := th.Client.AddMemberToBoard(&model.BoardMember{
+			BoardID:      board.ID,
+			UserID:       th.GetUser2().ID,
+			SchemeEditor: true,
+		})
+		th.CheckOK(resp)
+
+		err := th.Server.App().UpdateUserGuest(th.GetUser2().ID, true, 0)
+		require.NoError(t, err)
+
+		got, resp := th.Client2.GetBoard(board.ID, "")
+		th.CheckOK(resp)
+		require.Equal(t, board.ID, got.ID)
+	})
+
+	t.Run("expired guests are logged out", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		me, resp := th.Client2.GetMe()
+		th.CheckOK(resp)
+		require.Equal(t, th.GetUser2().ID, me.ID)
+
+		err := th.Server.App().UpdateUserGuest(th.GetUser2().ID, true, utils.GetMillis()-1000)
+		require.NoError(t, err)
+
+		me, resp = th.Client2.GetMe()
+		th.CheckUnauthorized(resp)
+		require.Nil(t, me)
+	})
+}
@@ -0,0 +1,296 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/client"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/services/ldap/ldaptest"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	ldapUserDN      = "uid=jdoe,ou=people,dc=example,dc=com"
+	ldapOtherUserDN = "uid=asmith,ou=people,dc=example,dc=com"
+	ldapGroupDN     = "cn=developers,ou=groups,dc=example,dc=com"
+	ldapPassword    = "directory-password"
+	ldapBindDN      = "cn=admin,dc=example,dc=com"
+	ldapBindSecret  = "admin-password"
+)
+
+func ldapUserAttributes() map[string][]string {
+	return map[string][]string{
+		"objectClass":  {"person", "inetOrgPerson"},
+		"uid":          {"jdoe"},
+		"mail":         {"JDoe@Example.com"},
+		"givenName":    {"John"},
+		"sn":           {"Doe"},
+		"userPassword": {ldapPassword},
+	}
+}
+
+func ldapOtherUserAttributes() map[string][]string {
+	return map[string][]string{
+		"objectClass":  {"person", "inetOrgPerson"},
+		"uid":          {"asmith"},
+		"mail":         {"asmith@example.com"},
+		"userPassword": {ldapPassword},
+	}
+}
+
+func setupLdap(t *testing.T, th *TestHelper) *ldaptest.Server {
+	server, err := ldaptest.NewServer()
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+
+	server.AddEntry(ldapBindDN, map[string][]string{
+		"objectClass":  {"organizationalRole"},
+		"cn":           {"admin"},
+		"userPassword": {ldapBindSecret},
+	})
+	server.AddEntry(ldapUserDN, ldapUserAttributes())
+	server.AddEntry(ldapGroupDN, map[string][]string{
+		"objectClass": {"groupOfNames"},
+		"cn":          {"developers"},
+		"member":      {ldapUserDN},
+	})
+
+	cfg := th.Server.Config()
+	cfg.Ldap = config.LdapConfig{
+		Enable:             true,
+		URL:                server.URL(),
+		BindDN:             ldapBindDN,
+		BindPassword:       ldapBindSecret,
+		BaseDN:             "dc=example,dc=com",
+		UserFilter:         "(objectClass=person)",
+		LoginAttribute:     "uid",
+		UsernameAttribute:  "uid",
+		EmailAttribute:     "mail",
+		FirstNameAttribute: "givenName",
+		LastNameAttribute:  "sn",
+		GroupFilter:        "(objectClass=groupOfNames)",
+		GroupIDAttribute:   "cn",
+	}
+	return server
+}
+
+func ldapLogin(th *TestHelper, username, password string) (*client.Client, *client.Response) {
+	ldapClient := client.NewClient(th.Server.Config().ServerRoot, "")
+	_, resp := ldapClient.Login(&model.LoginRequest{Type: "normal", Username: username, Password: password})
+	return ldapClient, resp
+}
+
+func TestLdapLogin(t *testing.T) {
+	t.Run("provisions the user on the first login", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		setupLdap(t, th)
+
+		ldapClient, resp := ldapLogin(th, "jdoe", ldapPassword)
+		th.CheckOK(resp)
+
+		me, err := th.Server.App().GetUser(th.Me(ldapClient).ID)
+		require.NoError(t, err)
+		require.Equal(t, "jdoe", me.Username)
+		require.Equal(t, "jdoe@example.com", me.Email)
+		require.Equal(t, "John", me.FirstName)
+		require.Equal(t, "Doe", me.LastName)
+		require.Equal(t, model.LdapAuthService, me.AuthService)
+		require.Equal(t, "jdoe", me.AuthData)
+
+		// the following logins use the same user
+		ldapClient, resp = ldapLogin(th, "jdoe", ldapPassword)
+		th.CheckOK(resp)
+		require.Equal(t, me.ID, th.Me(ldapClient).ID)
+	})
+
+	t.Run("invalid directory password", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		setupLdap(t, th)
+
+		_, resp := ldapLogin(th, "jdoe", "wrong")
+		th.CheckUnauthorized(resp)
+
+		_, resp = ldapLogin(th, "jdoe", "")
+		th.CheckUnauthorized(resp)
+	})
+
+	t.Run("local accounts can still log in", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		setupLdap(t, th)
+
+		th.Login1()
+		require.Equal(t, user1Username, th.Me(th.Client).Username)
+	})
+
+	t.Run("existing accounts aren't taken over", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		server := setupLdap(t, th)
+		server.SetAttribute(ldapUserDN, "mail", []string{"user1@sample.com"})
+
+		_, resp := ldapLogin(th, "jdoe", ldapPassword)
+		th.CheckUnauthorized(resp)
+	})
+}
+
+func TestLdapSync(t *testing.T) {
+	t.Run("deactivates the users removed from the directory", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		server := setupLdap(t, th)
+
+		ldapClient, resp := ldapLogin(th, "jdoe", ldapPassword)
+		th.CheckOK(resp)
+		userID := th.Me(ldapClient).ID
+
+		// the directory isn't empty after the removal
+		server.AddEntry(ldapOtherUserDN, ldapOtherUserAttributes())
+		server.DeleteEntry(ldapUserDN)
+		result, err := th.Server.App().SyncLdap()
+		require.NoError(t, err)
+		require.Equal(t, 1, result.Deactivated)
+
+		// the sessions are revoked
+		_, resp = ldapClient.GetMe()
+		th.CheckUnauthorized(resp)
+
+		_, err = th.Server.App().GetUser(userID)
+		require.Error(t, err)
+
+		// the user is reactivated when it is back in the directory
+		server.AddEntry(ldapUserDN, ldapUserAttributes())
+		result, err = th.Server.App().SyncLdap()
+		require.NoError(t, err)
+		require.Equal(t, 1, result.Reactivated)
+
+		ldapClient, resp = ldapLogin(th, "jdoe", ldapPassword)
+		th.CheckOK(resp)
+		require.Equal(t, userID, th.Me(ldapClient).ID)
+	})
+
+	t.Run("an empty directory doesn't deactivate the users", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		server := setupLdap(t, th)
+
+		ldapClient, resp := ldapLogin(th, "jdoe", ldapPassword)
+		th.CheckOK(resp)
+
+		server.DeleteEntry(ldapUserDN)
+		result, err := th.Server.App().SyncLdap()
+		require.Error(t, err)
+		require.Nil(t, result)
+
+		_, resp = ldapClient.GetMe()
+		th.CheckOK(resp)
+	})
+
+	t.Run("too many removed users abort the synchronization", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		server := setupLdap(t, th)
+		server.AddEntry(ldapOtherUserDN, ldapOtherUserAttributes())
+		th.Server.Config().Ldap.MaxSyncDeactivations = 1
+
+		jdoeClient, resp := ldapLogin(th, "jdoe", ldapPassword)
+		th.CheckOK(resp)
+		asmithClient, resp := ldapLogin(th, "asmith", ldapPassword)
+		th.CheckOK(resp)
+
+		server.AddEntry("uid=bjones,ou=people,dc=example,dc=com", map[string][]string{
+			"objectClass": {"person", "inetOrgPerson"},
+			"uid":         {"bjones"},
+			"mail":        {"bjones@example.com"},
+		})
+		server.DeleteEntry(ldapUserDN)
+		server.DeleteEntry(ldapOtherUserDN)
+		result, err := th.Server.App().SyncLdap()
+		require.Error(t, err)
+		require.Nil(t, result)
+
+		_, resp = jdoeClient.GetMe()
+		th.CheckOK(resp)
+		_, resp = asmithClient.GetMe()
+		th.CheckOK(resp)
+
+		// the removals within the maximum are applied
+		th.Server.Config().Ldap.MaxSyncDeactivations = 2
+		result, err = th.Server.App().SyncLdap()
+		require.NoError(t, err)
+		require.Equal(t, 2, result.Deactivated)
+	})
+
+	t.Run("updates the user attributes", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		server := setupLdap(t, th)
+
+		ldapClient, resp := ldapLogin(th, "jdoe", ldapPassword)
+		th.CheckOK(resp)
+		userID := th.Me(ldapClient).ID
+
+		server.SetAttribute(ldapUserDN, "sn", []string{"Smith"})
+		result, err := th.Server.App().SyncLdap()
+		require.NoError(t, err)
+		require.Equal(t, 1, result.Updated)
+
+		user, err := th.Server.App().GetUser(userID)
+		require.NoError(t, err)
+		require.Equal(t, "Smith", user.LastName)
+	})
+
+	t.Run("maps the groups to teams and boards", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		server := setupLdap(t, th)
+
+		team, resp := th.Client.CreateTeam(&model.Team{Title: "Developers"})
+		th.CheckOK(resp)
+		board := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+
+		th.Server.Config().Ldap.GroupMappings = []config.LdapGroupMapping{
+			{Group: "developers", TeamID: team.ID},
+			{Group: "developers", BoardID: board.ID, Role: "commenter"},
+		}
+
+		ldapClient, resp := ldapLogin(th, "jdoe", ldapPassword)
+		th.CheckOK(resp)
+		userID := th.Me(ldapClient).ID
+
+		teamMember, err := th.Server.App().GetTeamMember(team.ID, userID)
+		require.NoError(t, err)
+		require.False(t, teamMember.SchemeAdmin)
+
+		boardMember, err := th.Server.App().GetMemberForBoard(board.ID, userID)
+		require.NoError(t, err)
+		require.True(t, boardMember.SchemeCommenter)
+		require.False(t, boardMember.SchemeEditor)
+
+		// the memberships follow the groups
+		server.SetAttribute(ldapGroupDN, "member", []string{ldapBindDN})
+		result, err := th.Server.App().SyncLdap()
+		require.NoError(t, err)
+		require.Equal(t, 2, result.MembershipsRemoved)
+
+		_, err = th.Server.App().GetTeamMember(team.ID, userID)
+		require.True(t, model.IsErrNotFound(err))
+
+		members, err := th.Server.App().GetMembersForBoard(board.ID)
+		require.NoError(t, err)
+		for _, member := range members {
+			require.NotEqual(t, userID, member.UserID)
+		}
+
+		server.SetAttribute(ldapGroupDN, "member", []string{ldapUserDN})
+		result, err = th.Server.App().SyncLdap()
+		require.NoError(t, err)
+		require.Equal(t, 2, result.MembershipsAdded)
+	})
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package integrationtests
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+
+	mmModel "github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestLockBoard(t *testing.T) {
+	setupBoard := func(th *TestHelper) (*model.Board, []*model.Card) {
+		board, cards := th.CreateBoardAndCards(testTeamID, model.BoardTypePrivate, 2)
+		_, resp := th.Client.AddMemberToBoard(&model.BoardMember{
+			BoardID:      board.ID,
+			UserID:       th.GetUser2().ID,
+			SchemeEditor: true,
+		})
+		th.CheckOK(resp)
+		return board, cards
+	}
+
+	t.Run("a non authenticated user should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+
+		th.Logout(th.Client)
+
+		locked, resp := th.Client.LockBoard(board.ID)
+		th.CheckUnauthorized(resp)
+		require.Nil(t, locked)
+	})
+
+	t.Run("editors can't lock a board", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board, _ := setupBoard(th)
+
+		locked, resp := th.Client2.LockBoard(board.ID)
+		th.CheckForbidden(resp)
+		require.Nil(t, locked)
+	})
+
+	t.Run("lock and unlock", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board, cards := setupBoard(th)
+
+		locked, resp := th.Client.LockBoard(board.ID)
+		th.CheckOK(resp)
+		require.True(t, locked.IsLocked)
+
+		fetched, resp := th.Client2.GetBoard(board.ID, "")
+		th.CheckOK(resp)
+		require.True(t, fetched.IsLocked)
+
+		// nobody can change a locked board, not even its admins
+		patch := &model.CardPatch{Title: mmModel.NewString("new title")}
+		_, resp = th.Client2.PatchCard(cards[0].ID, patch, true)
+		th.CheckForbidden(resp)
+		_, resp = th.Client.PatchCard(cards[0].ID, patch, true)
+		th.CheckForbidden(resp)
+		_, resp = th.Client.PatchBoard(board.ID, &model.BoardPatch{Title: mmModel.NewString("new title")})
+		th.CheckForbidden(resp)
+		_, resp = th.Client.CreateCard(board.ID, &model.Card{Title: "new card"}, true)
+		th.CheckForbidden(resp)
+		_, resp = th.Client.SetCardRecurrence(cards[0].ID, &model.CardRecurrence{Rule: "FREQ=DAILY"})
+		th.CheckForbidden(resp)
+		_, resp = th.Client.SetBoardAutomations(board.ID, []*model.AutomationRule{})
+		th.CheckForbidden(resp)
+		_, resp = th.Client.TeamUploadFile(testTeamID, board.ID, bytes.NewBufferString("test"))
+		th.CheckForbidden(resp)
+
+		// the cards of a locked board can still be read
+		card, resp := th.Client2.GetCard(cards[0].ID)
+		th.CheckOK(resp)
+		require.Equal(t, cards[0].ID, card.ID)
+		fetchedCards, resp := th.Client2.GetCards(board.ID, 0, 10)
+		th.CheckOK(resp)
+		require.Len(t, fetchedCards, 2)
+
+		unlocked, resp := th.Client.UnlockBoard(board.ID)
+		th.CheckOK(resp)
+		require.False(t, unlocked.IsLocked)
+
+		card, resp = th.Client2.PatchCard(cards[0].ID, patch, true)
+		th.CheckOK(resp)
+		require.Equal(t, "new title", card.Title)
+	})
+
+	t.Run("members can be added to a locked board", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+		_, resp := th.Client.LockBoard(board.ID)
+		th.CheckOK(resp)
+
+		member, resp := th.Client.AddMemberToBoard(&model.BoardMember{
+			BoardID:      board.ID,
+			UserID:       th.GetUser2().ID,
+			SchemeEditor: true,
+		})
+		th.CheckOK(resp)
+		require.Equal(t, th.GetUser2().ID, member.UserID)
+	})
+}
+
+func TestLockCard(t *testing.T) {
+	t.Run("a non authenticated user should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		_, cards := th.CreateBoardAndCards(testTeamID, model.BoardTypeOpen, 1)
+
+		th.Logout(th.Client)
+
+		card, resp := th.Client.LockCard(cards[0].ID)
+		th.CheckUnauthorized(resp)
+		require.Nil(t, card)
+	})
+
+	t.Run("user without access to the board", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board, resp := th.Client2.CreateBoard(&model.Board{TeamID: testTeamID, Type: model.BoardTypePrivate})
+		th.CheckOK(resp)
+		card, resp := th.Client2.CreateCard(board.ID, &model.Card{Title: "private card"}, true)
+		th.CheckOK(resp)
+
+		locked, resp := th.Client.LockCard(card.ID)
+		th.CheckForbidden(resp)
+		require.Nil(t, locked)
+	})
+
+	t.Run("lock and unlock", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board, cards := th.CreateBoardAndCards(testTeamID, model.BoardTypeOpen, 2)
+
+		locked, resp := th.Client.LockCard(cards[0].ID)
+		th.CheckOK(resp)
+		require.True(t, locked.IsLocked)
+
+		patch := &model.CardPatch{Title: mmModel.NewString("new title")}
+		_, resp = th.Client.PatchCard(cards[0].ID, patch, true)
+		th.CheckForbidden(resp)
+		_, resp = th.Client.DeleteBlock(board.ID, cards[0].ID, true)
+		th.CheckForbidden(resp)
+
+		// other cards of the board can still be changed
+		_, resp = th.Client.PatchCard(cards[1].ID, patch, true)
+		th.CheckOK(resp)
+
+		// the lock can't be removed with a regular patch
+		_, resp = th.Client.PatchBlock(board.ID, cards[0].ID, &model.BlockPatch{
+			DeletedFields: []string{"isLocked"},
+		}, true)
+		th.CheckBadRequest(resp)
+
+		unlocked, resp := th.Client.UnlockCard(cards[0].ID)
+		th.CheckOK(resp)
+		require.False(t, unlocked.IsLocked)
+
+		card, resp := th.Client.PatchCard(cards[0].ID, patch, true)
+		th.CheckOK(resp)
+		require.Equal(t, "new title", card.Title)
+	})
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package integrationtests
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/client"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginLockout(t *testing.T) {
+	setupLockout := func(th *TestHelper, lockout config.LockoutConfig) *client.Client {
+		lockout.Enable = true
+		lockout.LockoutMinutes = 15
+		th.Server.Config().LoginLockout = lockout
+		return client.NewClient(th.Server.Config().ServerRoot, "")
+	}
+
+	login := func(c *client.Client, username, password string) *client.Response {
+		_, resp := c.Login(&model.LoginRequest{
+			Type:     "normal",
+			Username: username,
+			Password: password,
+		})
+		return resp
+	}
+
+	checkTooManyRequests := func(t *testing.T, resp *client.Response) {
+		require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		require.NotEmpty(t, resp.Header.Get("Retry-After"))
+	}
+
+	t.Run("accounts are locked out after too many failures", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		c := setupLockout(th, config.LockoutConfig{FreeFailures: 5, MaxAccountFailures: 3, MaxIPFailures: 50})
+
+		for i := 0; i < 3; i++ {
+			th.CheckUnauthorized(login(c, user1Username, "wrong-password"))
+		}
+
+		// the right password is rejected too while locked out
+		checkTooManyRequests(t, login(c, user1Username, password))
+
+		attempts, err := th.Server.Store().GetLoginAttempts(model.LoginAttemptsUserKey(th.GetUser1().ID))
+		require.NoError(t, err)
+		require.Equal(t, 3, attempts.FailedCount)
+
+		// the other accounts aren't locked out
+		th.CheckOK(login(c, user2Username, password))
+
+		require.NoError(t, th.Server.App().UnlockUserLogin(th.GetUser1().ID))
+		th.CheckOK(login(c, user1Username, password))
+	})
+
+	t.Run("the username and the email address share the account failures", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		c := setupLockout(th, config.LockoutConfig{FreeFailures: 5, MaxAccountFailures: 3, MaxIPFailures: 50})
+
+		loginWithEmail := func(password string) *client.Response {
+			_, resp := c.Login(&model.LoginRequest{Type: "normal", Email: "user1@sample.com", Password: password})
+			return resp
+		}
+
+		th.CheckUnauthorized(login(c, user1Username, "wrong-password"))
+		th.CheckUnauthorized(loginWithEmail("wrong-password"))
+		th.CheckUnauthorized(login(c, user1Username, "wrong-password"))
+		checkTooManyRequests(t, loginWithEmail(password))
+
+		require.NoError(t, th.Server.App().UnlockUserLogin(th.GetUser1().ID))
+		th.CheckUnauthorized(login(c, user1Username, "wrong-password"))
+		th.CheckUnauthorized(login(c, user1Username, "wrong-password"))
+
+		// a login with the email address resets the failures of the username
+		th.CheckOK(loginWithEmail(password))
+		_, err := th.Server.Store().GetLoginAttempts(model.LoginAttemptsUserKey(th.GetUser1().ID))
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("a successful login resets the account failures", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		c := setupLockout(th, config.LockoutConfig{FreeFailures: 5, MaxAccountFailures: 3, MaxIPFailures: 50})
+
+		th.CheckUnauthorized(login(c, user1Username, "wrong-password"))
+		th.CheckUnauthorized(login(c, user1Username, "wrong-password"))
+		th.CheckOK(login(c, user1Username, password))
+		th.CheckUnauthorized(login(c, user1Username, "wrong-password"))
+		th.CheckUnauthorized(login(c, user1Username, "wrong-password"))
+		th.CheckOK(login(c, user1Username, password))
+	})
+
+	t.Run("IP addresses are locked out after too many failures", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		c := setupLockout(th, config.LockoutConfig{FreeFailures: 5, MaxAccountFailures: 10, MaxIPFailures: 3})
+
+		for _, username := range []string{"unknown1", "unknown2", "unknown3"} {
+			th.CheckUnauthorized(login(c, username, "wrong-password"))
+		}
+		checkTooManyRequests(t, login(c, user1Username, password))
+
+		require.NoError(t, th.Server.App().UnlockIPAddressLogin("127.0.0.1"))
+		th.CheckOK(login(c, user1Username, password))
+	})
+
+	t.Run("the IP address is read from the trusted proxy header", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		c := setupLockout(th, config.LockoutConfig{FreeFailures: 5, MaxAccountFailures: 10, MaxIPFailures: 2})
+		th.Server.Config().TrustedProxyIPHeader = "X-Forwarded-For"
+
+		// the addresses set by the client before the proxy one are ignored
+		c.HTTPHeader["X-Forwarded-For"] = "10.0.0.1, 10.0.0.2"
+		th.CheckUnauthorized(login(c, "unknown1", "wrong-password"))
+		c.HTTPHeader["X-Forwarded-For"] = "10.0.0.3, 10.0.0.2"
+		th.CheckUnauthorized(login(c, "unknown2", "wrong-password"))
+		checkTooManyRequests(t, login(c, user1Username, password))
+
+		attempts, err := th.Server.Store().GetLoginAttempts(model.LoginAttemptsIPKey("10.0.0.2"))
+		require.NoError(t, err)
+		require.Equal(t, 2, attempts.FailedCount)
+
+		// the other clients of the proxy aren't locked out
+		c.HTTPHeader["X-Forwarded-For"] = "10.0.0.4"
+		th.CheckOK(login(c, user1Username, password))
+	})
+
+	t.Run("the failures past the free ones delay the next login", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		c := setupLockout(th, config.LockoutConfig{FreeFailures: 1, MaxDelaySeconds: 1, MaxAccountFailures: 10, MaxIPFailures: 50})
+
+		th.CheckUnauthorized(login(c, user1Username, "wrong-password"))
+		th.CheckUnauthorized(login(c, user1Username, "wrong-password"))
+
+		resp := login(c, user1Username, password)
+		checkTooManyRequests(t, resp)
+		require.Equal(t, "1", resp.Header.Get("Retry-After"))
+
+		time.Sleep(1100 * time.Millisecond)
+		th.CheckOK(login(c, user1Username, password))
+	})
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package integrationtests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/client"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/auth"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMfa(t *testing.T) {
+	activateMfa := func(th *TestHelper, c *client.Client) (string, []string) {
+		secret, resp := c.GenerateMfaSecret()
+		th.CheckOK(resp)
+		require.NotEmpty(t, secret.Secret)
+		require.Contains(t, secret.ProvisioningURI, "otpauth://totp/")
+
+		code, err := auth.GenerateMfaCode(secret.Secret, time.Now())
+		require.NoError(t, err)
+
+		recoveryCodes, resp := c.ActivateMfa(code)
+		th.CheckOK(resp)
+		require.Len(t, recoveryCodes.RecoveryCodes, auth.MfaRecoveryCodeCount)
+		return secret.Secret, recoveryCodes.RecoveryCodes
+	}
+
+	login := func(c *client.Client, mfaToken string) *client.Response {
+		_, resp := c.Login(&model.LoginRequest{
+			Type:     "normal",
+			Username: user1Username,
+			Password: password,
+			MfaToken: mfaToken,
+		})
+		return resp
+	}
+
+	t.Run("activation needs a valid code", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		_, resp := th.Client.GenerateMfaSecret()
+		th.CheckOK(resp)
+
+		recoveryCodes, resp := th.Client.ActivateMfa("000000")
+		th.CheckBadRequest(resp)
+		require.Nil(t, recoveryCodes)
+
+		require.False(t, th.Me(th.Client).MfaActive)
+	})
+
+	t.Run("login requires a TOTP code or a recovery code", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		secret, recoveryCodes := activateMfa(th, th.Client)
+		require.True(t, th.Me(th.Client).MfaActive)
+
+		anonClient := client.NewClient(th.Server.Config().ServerRoot, "")
+
+		resp := login(anonClient, "")
+		th.CheckUnauthorized(resp)
+		require.Contains(t, resp.Error.Error(), "multi-factor authentication token required")
+
+		th.CheckUnauthorized(login(anonClient, "000000"))
+
+		// the activation code was already used, so the code of the next
+		// period is used, which is accepted to allow for clock drift
+		code, err := auth.GenerateMfaCode(secret, time.Now().Add(auth.MfaPeriod))
+		require.NoError(t, err)
+		th.CheckOK(login(anonClient, code))
+		// TOTP codes can't be replayed either
+		th.CheckUnauthorized(login(anonClient, code))
+
+		th.CheckOK(login(anonClient, recoveryCodes[0]))
+		// recovery codes can only be used once
+		th.CheckUnauthorized(login(anonClient, recoveryCodes[0]))
+		th.CheckOK(login(anonClient, recoveryCodes[1]))
+	})
+
+	t.Run("recovery codes can be regenerated and MFA deactivated", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		_, recoveryCodes := activateMfa(th, th.Client)
+
+		newCodes, resp := th.Client.RegenerateMfaRecoveryCodes(recoveryCodes[0])
+		th.CheckOK(resp)
+		require.Len(t, newCodes.RecoveryCodes, auth.MfaRecoveryCodeCount)
+
+		resp = th.Client.DeactivateMfa(recoveryCodes[1])
+		th.CheckBadRequest(resp)
+
+		resp = th.Client.DeactivateMfa(newCodes.RecoveryCodes[0])
+		th.CheckOK(resp)
+		require.False(t, th.Me(th.Client).MfaActive)
+
+		th.CheckOK(login(client.NewClient(th.Server.Config().ServerRoot, ""), ""))
+	})
+
+	t.Run("users need to activate MFA when the server requires it", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		th.Server.Config().RequireMfa = true
+
+		teams, resp := th.Client.GetTeams()
+		th.CheckForbidden(resp)
+		require.Nil(t, teams)
+
+		// the user can still get its information and activate MFA
+		require.False(t, th.Me(th.Client).MfaActive)
+		_, recoveryCodes := activateMfa(th, th.Client)
+
+		_, resp = th.Client.GetTeams()
+		th.CheckOK(resp)
+
+		resp = th.Client.DeactivateMfa(recoveryCodes[0])
+		th.CheckForbidden(resp)
+	})
+}
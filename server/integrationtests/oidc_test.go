@@ -0,0 +1,238 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package integrationtests
+
+import (
+	"net/http"
+	"net/http/cookiejar"
+	"strings"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/client"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/auth"
+	"github.com/mattermost/focalboard/server/services/oidc/oidctest"
+	"github.com/stretchr/testify/require"
+)
+
+func setupOidc(t *testing.T, th *TestHelper) *oidctest.Provider {
+	provider, err := oidctest.NewProvider()
+	require.NoError(t, err)
+	t.Cleanup(provider.Close)
+
+	cfg := th.Server.Config()
+	cfg.Oidc.Enable = true
+	cfg.Oidc.Issuer = provider.Issuer()
+	cfg.Oidc.ClientID = provider.ClientID
+	cfg.Oidc.ClientSecret = provider.ClientSecret
+	cfg.Oidc.Scopes = []string{"openid", "profile", "email"}
+	cfg.Oidc.UsernameClaim = "preferred_username"
+	cfg.Oidc.EmailClaim = "email"
+	return provider
+}
+
+// oidcLogin runs the OpenID Connect flow with the browser redirects, and
+// returns the callback response and the session token it sets.
+func oidcLogin(t *testing.T, th *TestHelper, redirectTo string) (*http.Response, string) {
+	jar, err := cookiejar.New(nil)
+	require.NoError(t, err)
+	browser := &http.Client{
+		Jar: jar,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	follow := func(url string) *http.Response {
+		resp, err := browser.Get(url)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+
+	resp := follow(th.Server.Config().ServerRoot + "/oauth/oidc/login?redirect_to=" + redirectTo)
+	if resp.StatusCode != http.StatusFound {
+		return resp, ""
+	}
+
+	// the provider authenticates the user and redirects to the callback
+	resp = follow(resp.Header.Get("Location"))
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+	callbackURL := resp.Header.Get("Location")
+	require.True(t, strings.HasPrefix(callbackURL, th.Server.Config().ServerRoot+"/oauth/oidc/callback"))
+
+	resp = follow(callbackURL)
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name == auth.SessionCookieToken {
+			return resp, cookie.Value
+		}
+	}
+	return resp, ""
+}
+
+func TestOidcLogin(t *testing.T) {
+	userClaims := func(sub, username, email string) map[string]interface{} {
+		return map[string]interface{}{
+			"sub":                sub,
+			"preferred_username": username,
+			"email":              email,
+			"email_verified":     true,
+		}
+	}
+
+	t.Run("not enabled", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		resp, token := oidcLogin(t, th, "/")
+		require.Equal(t, http.StatusNotImplemented, resp.StatusCode)
+		require.Empty(t, token)
+	})
+
+	t.Run("provisions the user on the first login", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		provider := setupOidc(t, th)
+
+		provider.SetClaims(userClaims("subject-1", "Jane.Doe", "Jane@Example.com"))
+		resp, token := oidcLogin(t, th, "/team/board")
+		require.Equal(t, http.StatusFound, resp.StatusCode)
+		require.Equal(t, "/team/board", resp.Header.Get("Location"))
+		require.NotEmpty(t, token)
+
+		oidcClient := client.NewClient(th.Server.Config().ServerRoot, token)
+		me, err := th.Server.App().GetUser(th.Me(oidcClient).ID)
+		require.NoError(t, err)
+		require.Equal(t, "jane.doe", me.Username)
+		require.Equal(t, "jane@example.com", me.Email)
+		require.Equal(t, model.OidcAuthService, me.AuthService)
+		require.Equal(t, "subject-1", me.AuthData)
+
+		// the following logins are linked to the same user, and update it
+		provider.SetClaims(userClaims("subject-1", "jdoe", "jdoe@example.com"))
+		_, token = oidcLogin(t, th, "/")
+		require.NotEmpty(t, token)
+
+		updated, err := th.Server.App().GetUser(th.Me(client.NewClient(th.Server.Config().ServerRoot, token)).ID)
+		require.NoError(t, err)
+		require.Equal(t, me.ID, updated.ID)
+		require.Equal(t, "jdoe", updated.Username)
+		require.Equal(t, "jdoe@example.com", updated.Email)
+
+		// the provisioned user has no password
+		_, loginResp := th.Client.Login(&model.LoginRequest{Type: "normal", Username: "jdoe", Password: ""})
+		th.CheckUnauthorized(loginResp)
+	})
+
+	t.Run("usernames are made unique", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		provider := setupOidc(t, th)
+
+		provider.SetClaims(userClaims("subject-1", user1Username, "other@example.com"))
+		_, token := oidcLogin(t, th, "/")
+		require.NotEmpty(t, token)
+
+		me := th.Me(client.NewClient(th.Server.Config().ServerRoot, token))
+		require.NotEqual(t, user1Username, me.Username)
+		require.True(t, strings.HasPrefix(me.Username, user1Username))
+	})
+
+	t.Run("the username falls back to the email", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		provider := setupOidc(t, th)
+		th.Server.Config().Oidc.UsernameClaim = "nickname"
+
+		provider.SetClaims(userClaims("subject-1", "ignored", "someone@example.com"))
+		_, token := oidcLogin(t, th, "/")
+		require.NotEmpty(t, token)
+
+		me := th.Me(client.NewClient(th.Server.Config().ServerRoot, token))
+		require.Equal(t, "someone", me.Username)
+	})
+
+	t.Run("the identity provider handles multi-factor authentication", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		provider := setupOidc(t, th)
+		th.Server.Config().RequireMfa = true
+
+		provider.SetClaims(userClaims("subject-1", "jdoe", "jdoe@example.com"))
+		_, token := oidcLogin(t, th, "/")
+		require.NotEmpty(t, token)
+
+		// the user isn't asked to set up a factor that is never checked
+		oidcClient := client.NewClient(th.Server.Config().ServerRoot, token)
+		_, resp := oidcClient.GetTeams()
+		th.CheckOK(resp)
+
+		_, resp = oidcClient.GenerateMfaSecret()
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("existing accounts aren't taken over", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		provider := setupOidc(t, th)
+
+		provider.SetClaims(userClaims("subject-1", "attacker", "user1@sample.com"))
+		resp, token := oidcLogin(t, th, "/")
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		require.Empty(t, token)
+	})
+
+	t.Run("unverified emails are rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		provider := setupOidc(t, th)
+
+		claims := userClaims("subject-1", "jane", "jane@example.com")
+		claims["email_verified"] = false
+		provider.SetClaims(claims)
+		resp, token := oidcLogin(t, th, "/")
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		require.Empty(t, token)
+	})
+
+	t.Run("allowed email domains", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		provider := setupOidc(t, th)
+		th.Server.Config().Oidc.AllowedDomains = []string{"example.com"}
+
+		provider.SetClaims(userClaims("subject-1", "jane", "jane@other.com"))
+		resp, token := oidcLogin(t, th, "/")
+		require.Equal(t, http.StatusForbidden, resp.StatusCode)
+		require.Empty(t, token)
+
+		provider.SetClaims(userClaims("subject-2", "john", "john@EXAMPLE.com"))
+		resp, token = oidcLogin(t, th, "/")
+		require.Equal(t, http.StatusFound, resp.StatusCode)
+		require.NotEmpty(t, token)
+	})
+
+	t.Run("only local redirects are allowed", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		provider := setupOidc(t, th)
+
+		provider.SetClaims(userClaims("subject-1", "jane", "jane@example.com"))
+		resp, token := oidcLogin(t, th, "//evil.example.com/")
+		require.Equal(t, http.StatusFound, resp.StatusCode)
+		require.Equal(t, "/", resp.Header.Get("Location"))
+		require.NotEmpty(t, token)
+	})
+
+	t.Run("callback requires the request state", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		setupOidc(t, th)
+
+		resp, err := http.Get(th.Server.Config().ServerRoot + "/oauth/oidc/callback?code=code&state=state")
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+}
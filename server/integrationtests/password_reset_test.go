@@ -0,0 +1,153 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package integrationtests
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/client"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/services/email/emailtest"
+	"github.com/stretchr/testify/require"
+)
+
+var resetLinkRegexp = regexp.MustCompile(`/reset_password\?token=(\S+)`)
+
+func TestPasswordReset(t *testing.T) {
+	setupSMTP := func(t *testing.T, th *TestHelper) *emailtest.Server {
+		server, err := emailtest.NewServer()
+		require.NoError(t, err)
+		t.Cleanup(server.Close)
+
+		th.Server.Config().SMTP = config.SMTPConfig{
+			Server:      server.Host(),
+			Port:        server.Port(),
+			FromAddress: "boards@example.com",
+		}
+		th.Server.Config().Password = config.PasswordConfig{
+			MinimumLength:      8,
+			ResetExpiryMinutes: 60,
+		}
+		return server
+	}
+
+	// receiveToken waits for the reset email and returns its token.
+	receiveToken := func(t *testing.T, server *emailtest.Server, count int) string {
+		require.Eventually(t, func() bool {
+			return len(server.Messages()) == count
+		}, 5*time.Second, 20*time.Millisecond)
+
+		msg := server.Messages()[count-1]
+		require.Equal(t, []string{"user1@sample.com"}, msg.To)
+		match := resetLinkRegexp.FindStringSubmatch(msg.Body)
+		require.Len(t, match, 2)
+		token, err := url.QueryUnescape(match[1])
+		require.NoError(t, err)
+		return token
+	}
+
+	t.Run("the password is reset and the sessions are revoked", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		server := setupSMTP(t, th)
+
+		accessToken, resp := th.Client.CreateAccessToken(&model.AccessToken{
+			Name:   "script",
+			Scopes: []string{model.AccessTokenScopeRead},
+		})
+		th.CheckOK(resp)
+
+		th.CheckOK(th.Client.SendPasswordReset("user1@sample.com"))
+		token := receiveToken(t, server, 1)
+
+		th.CheckOK(th.Client.ResetPassword(token, "new-password"))
+
+		// the existing sessions and access tokens are revoked
+		_, resp = th.Client.GetMe()
+		th.CheckUnauthorized(resp)
+		_, resp = client.NewClient(th.Server.Config().ServerRoot, accessToken.Token).GetMe()
+		th.CheckUnauthorized(resp)
+
+		// the token can only be used once
+		th.CheckUnauthorized(th.Client.ResetPassword(token, "other-password"))
+
+		c := client.NewClient(th.Server.Config().ServerRoot, "")
+		_, resp = c.Login(&model.LoginRequest{Type: "normal", Username: user1Username, Password: password})
+		th.CheckUnauthorized(resp)
+		_, resp = c.Login(&model.LoginRequest{Type: "normal", Username: user1Username, Password: "new-password"})
+		th.CheckOK(resp)
+	})
+
+	t.Run("a new email invalidates the previous token", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		server := setupSMTP(t, th)
+
+		th.CheckOK(th.Client.SendPasswordReset("user1@sample.com"))
+		first := receiveToken(t, server, 1)
+		th.CheckOK(th.Client.SendPasswordReset("user1@sample.com"))
+		second := receiveToken(t, server, 2)
+
+		th.CheckUnauthorized(th.Client.ResetPassword(first, "new-password"))
+		th.CheckOK(th.Client.ResetPassword(second, "new-password"))
+	})
+
+	t.Run("the password policy is enforced", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		server := setupSMTP(t, th)
+		th.Server.Config().Password.Number = true
+
+		th.CheckOK(th.Client.SendPasswordReset("user1@sample.com"))
+		token := receiveToken(t, server, 1)
+
+		th.CheckBadRequest(th.Client.ResetPassword(token, "short"))
+		th.CheckBadRequest(th.Client.ResetPassword(token, "no-numbers"))
+
+		// the rejected password didn't consume the token
+		th.CheckOK(th.Client.ResetPassword(token, "with-numbers-42"))
+	})
+
+	t.Run("expired tokens are rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		setupSMTP(t, th)
+
+		token := "expired-token"
+		_, err := th.Server.Store().CreatePasswordResetToken(&model.PasswordResetToken{
+			ID:        "reset-token-id",
+			UserID:    th.GetUser1().ID,
+			TokenHash: model.HashToken(token),
+			ExpireAt:  model.GetMillis() - 1000,
+			CreateAt:  model.GetMillis() - 2000,
+		})
+		require.NoError(t, err)
+
+		th.CheckUnauthorized(th.Client.ResetPassword(token, "new-password"))
+	})
+
+	t.Run("unknown email addresses get the same response", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		server := setupSMTP(t, th)
+
+		th.CheckOK(th.Client.SendPasswordReset("nobody@sample.com"))
+		th.CheckOK(th.Client.SendPasswordReset("user1@sample.com"))
+		receiveToken(t, server, 1)
+	})
+
+	t.Run("not available without an SMTP server", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		resp := th.Client.SendPasswordReset("user1@sample.com")
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusNotImplemented, resp.StatusCode)
+	})
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainBoardPermissions(t *testing.T) {
+	findExplanation := func(explanation *model.BoardPermissionsExplanation, permissionID string) *model.BoardPermissionExplanation {
+		for _, p := range explanation.Permissions {
+			if p.Permission == permissionID {
+				return p
+			}
+		}
+		return nil
+	}
+
+	t.Run("a non authenticated user should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+		th.Logout(th.Client)
+
+		explanation, resp := th.Client.ExplainBoardPermissions(board.ID, "")
+		th.CheckUnauthorized(resp)
+		require.Nil(t, explanation)
+	})
+
+	t.Run("not existing board", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		explanation, resp := th.Client.ExplainBoardPermissions("not-a-board", "")
+		th.CheckNotFound(resp)
+		require.Nil(t, explanation)
+	})
+
+	t.Run("users can explain their own permissions", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+
+		explanation, resp := th.Client2.ExplainBoardPermissions(board.ID, "")
+		th.CheckOK(resp)
+		require.Equal(t, th.GetUser2().ID, explanation.UserID)
+		require.Len(t, explanation.Permissions, len(model.BoardPermissions))
+		for _, p := range explanation.Permissions {
+			require.False(t, p.Granted)
+			require.Equal(t, model.BoardPermissionRuleNotMember, p.Rule)
+		}
+
+		explanation, resp = th.Client2.ExplainBoardPermissions(board.ID, th.GetUser1().ID)
+		th.CheckForbidden(resp)
+		require.Nil(t, explanation)
+	})
+
+	t.Run("board admins can explain the permissions of the members", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+		_, resp := th.Client.AddMemberToBoard(&model.BoardMember{
+			BoardID:         board.ID,
+			UserID:          th.GetUser2().ID,
+			SchemeCommenter: true,
+		})
+		th.CheckOK(resp)
+
+		explanation, resp := th.Client.ExplainBoardPermissions(board.ID, th.GetUser2().ID)
+		th.CheckOK(resp)
+		require.Equal(t, th.GetUser2().ID, explanation.UserID)
+
+		comment := findExplanation(explanation, model.PermissionCommentBoardCards.Id)
+		require.True(t, comment.Granted)
+		require.Equal(t, model.BoardPermissionRuleMemberRole, comment.Rule)
+		require.Equal(t, "commenter", comment.Role)
+
+		cards := findExplanation(explanation, model.PermissionManageBoardCards.Id)
+		require.False(t, cards.Granted)
+		require.Equal(t, model.BoardPermissionRuleInsufficientRole, cards.Rule)
+	})
+}
@@ -0,0 +1,324 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package integrationtests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/client"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+const scimToken = "scim-test-token"
+
+func TestScim(t *testing.T) {
+	setup := func(t *testing.T) (*TestHelper, *client.Client) {
+		th := SetupTestHelper(t).InitBasic()
+		t.Cleanup(th.TearDown)
+
+		th.Server.Config().Scim.Enable = true
+		th.Server.Config().Scim.Token = scimToken
+		return th, client.NewClient(th.Server.Config().ServerRoot, scimToken)
+	}
+
+	newScimUser := func(username string) *model.ScimUser {
+		return &model.ScimUser{
+			Schemas:     []string{model.ScimSchemaUser},
+			UserName:    username,
+			DisplayName: "Display " + username,
+			Name:        &model.ScimName{GivenName: "Given", FamilyName: "Family"},
+			Emails:      []model.ScimEmail{{Value: username + "@Example.com", Primary: true}},
+			Password:    password,
+		}
+	}
+
+	rawValue := func(v interface{}) json.RawMessage {
+		data, err := json.Marshal(v)
+		require.NoError(t, err)
+		return data
+	}
+
+	t.Run("the API requires the SCIM token", func(t *testing.T) {
+		th, _ := setup(t)
+
+		_, resp := client.NewClient(th.Server.Config().ServerRoot, "wrong").ScimGetUsers("")
+		th.CheckUnauthorized(resp)
+
+		// a session token isn't accepted
+		_, resp = client.NewClient(th.Server.Config().ServerRoot, th.Client.Token).ScimGetUsers("")
+		th.CheckUnauthorized(resp)
+
+		th.Server.Config().Scim.Enable = false
+		_, resp = client.NewClient(th.Server.Config().ServerRoot, scimToken).ScimGetUsers("")
+		th.CheckNotImplemented(resp)
+	})
+
+	t.Run("provision a user", func(t *testing.T) {
+		th, scim := setup(t)
+
+		user, resp := scim.ScimCreateUser(newScimUser("scimuser"))
+		require.NoError(t, resp.Error)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		require.NotEmpty(t, user.ID)
+		require.Equal(t, "scimuser", user.UserName)
+		require.Equal(t, "scimuser@example.com", user.PrimaryEmail())
+		require.Equal(t, "Display scimuser", user.DisplayName)
+		require.True(t, user.IsActive())
+		require.Empty(t, user.Password)
+		require.Equal(t, th.Server.Config().ServerRoot+"/scim/v2/Users/"+user.ID, user.Meta.Location)
+
+		// the provisioned user can log in
+		c := client.NewClient(th.Server.Config().ServerRoot, "")
+		th.Login(c, "scimuser", password)
+
+		users, resp := scim.ScimGetUsers(`userName eq "scimuser"`)
+		th.CheckOK(resp)
+		require.Len(t, users, 1)
+		require.Equal(t, user.ID, users[0].ID)
+
+		users, resp = scim.ScimGetUsers(`emails.value eq "SCIMUSER@example.com"`)
+		th.CheckOK(resp)
+		require.Len(t, users, 1)
+
+		_, resp = scim.ScimGetUsers(`displayName co "scim"`)
+		th.CheckBadRequest(resp)
+
+		// the username and the email address must be unique
+		_, resp = scim.ScimCreateUser(newScimUser("scimuser"))
+		require.Equal(t, http.StatusConflict, resp.StatusCode)
+		duplicate := newScimUser("other")
+		duplicate.Emails = []model.ScimEmail{{Value: "user1@sample.com"}}
+		_, resp = scim.ScimCreateUser(duplicate)
+		require.Equal(t, http.StatusConflict, resp.StatusCode)
+
+		invalid := newScimUser("noemail")
+		invalid.Emails = nil
+		_, resp = scim.ScimCreateUser(invalid)
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("update a user", func(t *testing.T) {
+		th, scim := setup(t)
+
+		user, resp := scim.ScimCreateUser(newScimUser("scimuser"))
+		require.NoError(t, resp.Error)
+
+		replacement := newScimUser("renamed")
+		replacement.Password = ""
+		replacement.Name = nil
+		updated, resp := scim.ScimReplaceUser(user.ID, replacement)
+		th.CheckOK(resp)
+		require.Equal(t, "renamed", updated.UserName)
+		require.Nil(t, updated.Name)
+
+		updated, resp = scim.ScimPatchUser(user.ID, []model.ScimPatchOperation{
+			{Op: "replace", Path: "name.givenName", Value: rawValue("Jane")},
+			{Op: "Replace", Value: rawValue(map[string]interface{}{"displayName": "Jane D."})},
+		})
+		th.CheckOK(resp)
+		require.Equal(t, "Jane", updated.Name.GivenName)
+		require.Equal(t, "Jane D.", updated.DisplayName)
+		require.Equal(t, "renamed", updated.UserName)
+
+		_, resp = scim.ScimPatchUser(user.ID, []model.ScimPatchOperation{
+			{Op: "replace", Path: "userName", Value: rawValue(user1Username)},
+		})
+		require.Equal(t, http.StatusConflict, resp.StatusCode)
+
+		_, resp = scim.ScimPatchUser("nonexistent", []model.ScimPatchOperation{
+			{Op: "replace", Path: "displayName", Value: rawValue("x")},
+		})
+		th.CheckNotFound(resp)
+	})
+
+	t.Run("an invalid update doesn't change the user", func(t *testing.T) {
+		_, scim := setup(t)
+
+		user, resp := scim.ScimCreateUser(newScimUser("scimuser"))
+		require.NoError(t, resp.Error)
+
+		replacement := newScimUser("renamed")
+		replacement.Password = "short"
+		_, resp = scim.ScimReplaceUser(user.ID, replacement)
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		got, resp := scim.ScimGetUser(user.ID)
+		require.NoError(t, resp.Error)
+		require.Equal(t, "scimuser", got.UserName)
+	})
+
+	t.Run("deactivated users can be reactivated", func(t *testing.T) {
+		th, scim := setup(t)
+
+		user, resp := scim.ScimCreateUser(newScimUser("scimuser"))
+		require.NoError(t, resp.Error)
+		c := client.NewClient(th.Server.Config().ServerRoot, "")
+		th.Login(c, "scimuser", password)
+
+		updated, resp := scim.ScimPatchUser(user.ID, []model.ScimPatchOperation{
+			{Op: "replace", Path: "active", Value: rawValue("False")},
+		})
+		th.CheckOK(resp)
+		require.False(t, updated.IsActive())
+
+		// the sessions of the deactivated user are revoked
+		_, resp = c.GetMe()
+		th.CheckUnauthorized(resp)
+		_, resp = c.Login(&model.LoginRequest{Type: "normal", Username: "scimuser", Password: password})
+		require.Error(t, resp.Error)
+
+		// the deactivated users are still found, inactive
+		got, resp := scim.ScimGetUser(user.ID)
+		th.CheckOK(resp)
+		require.False(t, got.IsActive())
+		users, resp := scim.ScimGetUsers(`userName eq "scimuser"`)
+		th.CheckOK(resp)
+		require.Len(t, users, 1)
+		require.False(t, users[0].IsActive())
+
+		// and can be updated and reactivated
+		updated, resp = scim.ScimPatchUser(user.ID, []model.ScimPatchOperation{
+			{Op: "replace", Value: rawValue(map[string]interface{}{"active": true, "displayName": "Reactivated"})},
+		})
+		th.CheckOK(resp)
+		require.True(t, updated.IsActive())
+		require.Equal(t, "Reactivated", updated.DisplayName)
+
+		th.Login(c, "scimuser", password)
+		_, resp = c.GetMe()
+		th.CheckOK(resp)
+	})
+
+	t.Run("delete a user", func(t *testing.T) {
+		th, scim := setup(t)
+
+		user, resp := scim.ScimCreateUser(newScimUser("scimuser"))
+		require.NoError(t, resp.Error)
+		group, resp := scim.ScimCreateGroup(&model.ScimGroup{
+			Schemas:     []string{model.ScimSchemaGroup},
+			DisplayName: "Engineering",
+			Members:     []model.ScimMember{{Value: user.ID}},
+		})
+		require.NoError(t, resp.Error)
+
+		c := client.NewClient(th.Server.Config().ServerRoot, "")
+		th.Login(c, "scimuser", password)
+
+		resp = scim.ScimDeleteUser(user.ID)
+		require.NoError(t, resp.Error)
+		require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		_, resp = c.GetMe()
+		th.CheckUnauthorized(resp)
+
+		_, resp = scim.ScimGetUser(user.ID)
+		th.CheckNotFound(resp)
+		users, resp := scim.ScimGetUsers(`id eq "` + user.ID + `"`)
+		th.CheckOK(resp)
+		require.Empty(t, users)
+		th.CheckNotFound(scim.ScimDeleteUser(user.ID))
+
+		group, resp = scim.ScimGetGroup(group.ID)
+		th.CheckOK(resp)
+		require.Empty(t, group.Members)
+
+		// a deleted user can't be added to a group
+		_, resp = scim.ScimPatchGroup(group.ID, []model.ScimPatchOperation{
+			{Op: "add", Path: "members", Value: rawValue([]model.ScimMember{{Value: user.ID}})},
+		})
+		th.CheckBadRequest(resp)
+
+		th.CheckNotFound(scim.ScimDeleteUser("nonexistent"))
+	})
+
+	t.Run("deleting the last admin of a team keeps it in the team", func(t *testing.T) {
+		th, scim := setup(t)
+
+		user, resp := scim.ScimCreateUser(newScimUser("scimuser"))
+		require.NoError(t, resp.Error)
+		group, resp := scim.ScimCreateGroup(&model.ScimGroup{
+			Schemas:     []string{model.ScimSchemaGroup},
+			DisplayName: "Engineering",
+			Members:     []model.ScimMember{{Value: user.ID}},
+		})
+		require.NoError(t, resp.Error)
+		_, err := th.Server.App().SaveTeamMember(&model.TeamMember{TeamID: group.ID, UserID: user.ID, SchemeAdmin: true})
+		require.NoError(t, err)
+
+		resp = scim.ScimDeleteUser(user.ID)
+		require.NoError(t, resp.Error)
+		th.CheckNotFound(scim.ScimDeleteUser(user.ID))
+
+		member, err := th.Server.Store().GetTeamMember(group.ID, user.ID)
+		require.NoError(t, err)
+		require.True(t, member.SchemeAdmin)
+	})
+
+	t.Run("groups are synced to teams", func(t *testing.T) {
+		th, scim := setup(t)
+
+		user1 := th.GetUser1()
+		user2 := th.GetUser2()
+
+		group, resp := scim.ScimCreateGroup(&model.ScimGroup{
+			Schemas:     []string{model.ScimSchemaGroup},
+			DisplayName: "Engineering",
+			Members:     []model.ScimMember{{Value: user1.ID}},
+		})
+		require.NoError(t, resp.Error)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		require.Equal(t, "Engineering", group.DisplayName)
+		require.Equal(t, []model.ScimMember{{Value: user1.ID}}, group.Members)
+
+		team, err := th.Server.App().GetTeam(group.ID)
+		require.NoError(t, err)
+		require.Equal(t, "Engineering", team.Title)
+		member, err := th.Server.App().GetTeamMember(group.ID, user1.ID)
+		require.NoError(t, err)
+		require.False(t, member.SchemeAdmin)
+
+		_, resp = scim.ScimCreateGroup(&model.ScimGroup{DisplayName: "Engineering"})
+		require.Equal(t, http.StatusConflict, resp.StatusCode)
+
+		group, resp = scim.ScimPatchGroup(group.ID, []model.ScimPatchOperation{
+			{Op: "add", Path: "members", Value: rawValue([]model.ScimMember{{Value: user2.ID}})},
+			{Op: "remove", Path: `members[value eq "` + user1.ID + `"]`},
+			{Op: "replace", Path: "displayName", Value: rawValue("R&D")},
+		})
+		th.CheckOK(resp)
+		require.Equal(t, "R&D", group.DisplayName)
+		require.Equal(t, []model.ScimMember{{Value: user2.ID}}, group.Members)
+
+		_, err = th.Server.App().GetTeamMember(group.ID, user1.ID)
+		require.True(t, model.IsErrNotFound(err))
+
+		_, resp = scim.ScimPatchGroup(group.ID, []model.ScimPatchOperation{
+			{Op: "add", Path: "members", Value: rawValue([]model.ScimMember{{Value: "nonexistent"}})},
+		})
+		th.CheckBadRequest(resp)
+
+		groups, resp := scim.ScimGetGroups(`displayName eq "R&D"`)
+		th.CheckOK(resp)
+		require.Len(t, groups, 1)
+		require.Equal(t, group.ID, groups[0].ID)
+
+		// the root team isn't a group
+		_, resp = scim.ScimGetGroup(model.GlobalTeamID)
+		th.CheckNotFound(resp)
+
+		resp = scim.ScimDeleteGroup(group.ID)
+		require.NoError(t, resp.Error)
+		require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		_, resp = scim.ScimGetGroup(group.ID)
+		th.CheckNotFound(resp)
+		groups, resp = scim.ScimGetGroups("")
+		th.CheckOK(resp)
+		require.Empty(t, groups)
+	})
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package integrationtests
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/mattermost/focalboard/server/client"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessions(t *testing.T) {
+	newSession := func(th *TestHelper) *client.Client {
+		c := client.NewClient(th.Server.Config().ServerRoot, "")
+		th.Login(c, user1Username, password)
+		return c
+	}
+
+	t.Run("the sessions of the user are listed", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		other := newSession(th)
+
+		sessions, resp := th.Client.GetSessions()
+		th.CheckOK(resp)
+		require.Len(t, sessions, 2)
+
+		current := 0
+		for _, session := range sessions {
+			require.NotEmpty(t, session.ID)
+			require.Equal(t, "Go-http-client/1.1", session.Device)
+			require.NotEmpty(t, session.IPAddress)
+			require.NotZero(t, session.CreateAt)
+			require.NotZero(t, session.LastActivityAt)
+			if session.Current {
+				current++
+			}
+		}
+		require.Equal(t, 1, current)
+
+		otherSessions, resp := other.GetSessions()
+		th.CheckOK(resp)
+		require.Len(t, otherSessions, 2)
+
+		// the sessions of other users aren't listed
+		sessions, resp = th.Client2.GetSessions()
+		th.CheckOK(resp)
+		require.Len(t, sessions, 1)
+	})
+
+	t.Run("revoke a session", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		other := newSession(th)
+		otherSessions, resp := other.GetSessions()
+		th.CheckOK(resp)
+
+		var otherID string
+		for _, session := range otherSessions {
+			if session.Current {
+				otherID = session.ID
+			}
+		}
+		require.NotEmpty(t, otherID)
+
+		// users can only revoke their own sessions
+		th.CheckNotFound(th.Client2.RevokeSession(otherID))
+
+		th.CheckOK(th.Client.RevokeSession(otherID))
+		_, resp = other.GetMe()
+		th.CheckUnauthorized(resp)
+
+		_, resp = th.Client.GetMe()
+		th.CheckOK(resp)
+	})
+
+	t.Run("revoke the other sessions", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		other1 := newSession(th)
+		other2 := newSession(th)
+
+		th.CheckOK(th.Client.RevokeOtherSessions())
+
+		_, resp := other1.GetMe()
+		th.CheckUnauthorized(resp)
+		_, resp = other2.GetMe()
+		th.CheckUnauthorized(resp)
+
+		sessions, resp := th.Client.GetSessions()
+		th.CheckOK(resp)
+		require.Len(t, sessions, 1)
+		require.True(t, sessions[0].Current)
+
+		_, resp = th.Client2.GetMe()
+		th.CheckOK(resp)
+	})
+
+	t.Run("revoking the sessions closes their websocket connections", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		other := newSession(th)
+
+		wsURL := "ws" + strings.TrimPrefix(th.Server.Config().ServerRoot, "http") + "/ws"
+		conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		require.NoError(t, conn.WriteJSON(map[string]string{"action": "AUTH", "token": other.Token}))
+		require.NoError(t, conn.WriteJSON(map[string]string{"action": "SUBSCRIBE_TEAM", "teamId": testTeamID}))
+
+		messages := make(chan struct{}, 100)
+		closed := make(chan error, 1)
+		go func() {
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					closed <- err
+					return
+				}
+				messages <- struct{}{}
+			}
+		}()
+
+		// the board changes are only received once the connection is
+		// authenticated and subscribed
+		require.Eventually(t, func() bool {
+			th.CreateBoard(testTeamID, model.BoardTypeOpen)
+			select {
+			case <-messages:
+				return true
+			case <-time.After(100 * time.Millisecond):
+				return false
+			}
+		}, 5*time.Second, 10*time.Millisecond)
+
+		require.NoError(t, th.Server.App().RevokeSessionsForUser(th.GetUser1().ID))
+
+		select {
+		case err := <-closed:
+			require.Error(t, err)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "the websocket connection wasn't closed")
+		}
+
+		_, resp := th.Client.GetMe()
+		th.CheckUnauthorized(resp)
+	})
+}
@@ -0,0 +1,203 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package integrationtests
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/client"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShareLinks(t *testing.T) {
+	setup := func(t *testing.T) (*TestHelper, *model.Board, *client.Client) {
+		th := SetupTestHelper(t).InitBasic()
+		th.Server.Config().EnablePublicSharedBoards = true
+
+		board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+		anonClient := client.NewClient(th.Server.Config().ServerRoot, "")
+		return th, board, anonClient
+	}
+
+	t.Run("only users that can share the board can manage its links", func(t *testing.T) {
+		th, board, _ := setup(t)
+		defer th.TearDown()
+
+		link, resp := th.Client2.CreateShareLink(&model.ShareLink{BoardID: board.ID})
+		th.CheckForbidden(resp)
+		require.Nil(t, link)
+
+		links, resp := th.Client2.GetShareLinks(board.ID)
+		th.CheckForbidden(resp)
+		require.Nil(t, links)
+
+		link, resp = th.Client.CreateShareLink(&model.ShareLink{BoardID: board.ID, Name: "public"})
+		th.CheckOK(resp)
+		require.NotEmpty(t, link.Token)
+
+		resp = th.Client2.DeleteShareLink(board.ID, link.ID)
+		th.CheckForbidden(resp)
+	})
+
+	t.Run("multiple links can be used and revoked independently", func(t *testing.T) {
+		th, board, anonClient := setup(t)
+		defer th.TearDown()
+
+		link1, resp := th.Client.CreateShareLink(&model.ShareLink{BoardID: board.ID, Name: "link 1"})
+		th.CheckOK(resp)
+		link2, resp := th.Client.CreateShareLink(&model.ShareLink{BoardID: board.ID, Name: "link 2"})
+		th.CheckOK(resp)
+
+		for _, link := range []*model.ShareLink{link1, link2} {
+			got, resp := anonClient.GetBoard(board.ID, link.Token)
+			th.CheckOK(resp)
+			require.Equal(t, board.ID, got.ID)
+		}
+
+		resp = th.Client.DeleteShareLink(board.ID, link1.ID)
+		th.CheckOK(resp)
+
+		got, resp := anonClient.GetBoard(board.ID, link1.Token)
+		th.CheckUnauthorized(resp)
+		require.Nil(t, got)
+
+		got, resp = anonClient.GetBoard(board.ID, link2.Token)
+		th.CheckOK(resp)
+		require.Equal(t, board.ID, got.ID)
+
+		links, resp := th.Client.GetShareLinks(board.ID)
+		th.CheckOK(resp)
+		require.Len(t, links, 1)
+		require.Equal(t, link2.ID, links[0].ID)
+		require.EqualValues(t, 2, links[0].UseCount)
+		require.NotZero(t, links[0].LastAccessAt)
+	})
+
+	t.Run("links can't be used after they expire", func(t *testing.T) {
+		th, board, anonClient := setup(t)
+		defer th.TearDown()
+
+		link, resp := th.Client.CreateShareLink(&model.ShareLink{
+			BoardID:  board.ID,
+			ExpireAt: utils.GetMillis() + 60*1000,
+		})
+		th.CheckOK(resp)
+
+		_, resp = anonClient.GetBoard(board.ID, link.Token)
+		th.CheckOK(resp)
+
+		expired, err := th.Server.App().CreateShareLink(&model.ShareLink{
+			BoardID:  board.ID,
+			ExpireAt: 1,
+		}, th.GetUser1().ID)
+		require.NoError(t, err)
+
+		got, resp := anonClient.GetBoard(board.ID, expired.Token)
+		th.CheckUnauthorized(resp)
+		require.Nil(t, got)
+	})
+
+	t.Run("password protected links", func(t *testing.T) {
+		th, board, anonClient := setup(t)
+		defer th.TearDown()
+
+		link, resp := th.Client.CreateShareLink(&model.ShareLink{
+			BoardID:  board.ID,
+			Password: "secret",
+		})
+		th.CheckOK(resp)
+		require.True(t, link.HasPassword)
+		require.Empty(t, link.Password)
+
+		got, resp := anonClient.GetBoard(board.ID, link.Token)
+		th.CheckUnauthorized(resp)
+		require.Nil(t, got)
+
+		got, resp = anonClient.GetSharedBoard(board.ID, link.Token, model.ShareLinkCredentials{Password: "wrong"})
+		th.CheckUnauthorized(resp)
+		require.Nil(t, got)
+
+		got, resp = anonClient.GetSharedBoard(board.ID, link.Token, model.ShareLinkCredentials{Password: "secret"})
+		th.CheckOK(resp)
+		require.Equal(t, board.ID, got.ID)
+	})
+
+	t.Run("the password isn't read from the query string", func(t *testing.T) {
+		th, board, anonClient := setup(t)
+		defer th.TearDown()
+
+		link, resp := th.Client.CreateShareLink(&model.ShareLink{
+			BoardID:  board.ID,
+			Password: "secret",
+		})
+		th.CheckOK(resp)
+
+		r, err := anonClient.DoAPIGet(anonClient.GetBoardRoute(board.ID)+"?read_token="+link.Token+"&read_token_password=secret", "")
+		require.Error(t, err)
+		require.Equal(t, http.StatusUnauthorized, r.StatusCode)
+	})
+
+	t.Run("password protected links are locked out after too many wrong passwords", func(t *testing.T) {
+		th, board, anonClient := setup(t)
+		defer th.TearDown()
+		th.Server.Config().LoginLockout.Enable = true
+		th.Server.Config().LoginLockout.MaxAccountFailures = 3
+		th.Server.Config().LoginLockout.LockoutMinutes = 15
+
+		link, resp := th.Client.CreateShareLink(&model.ShareLink{
+			BoardID:  board.ID,
+			Password: "secret",
+		})
+		th.CheckOK(resp)
+
+		for i := 0; i < 3; i++ {
+			_, resp = anonClient.GetSharedBoard(board.ID, link.Token, model.ShareLinkCredentials{Password: "wrong"})
+			th.CheckUnauthorized(resp)
+		}
+
+		got, resp := anonClient.GetSharedBoard(board.ID, link.Token, model.ShareLinkCredentials{Password: "secret"})
+		th.CheckUnauthorized(resp)
+		require.Nil(t, got)
+	})
+
+	t.Run("single view links are bound to the first view", func(t *testing.T) {
+		th, board, anonClient := setup(t)
+		defer th.TearDown()
+
+		link, resp := th.Client.CreateShareLink(&model.ShareLink{
+			BoardID:    board.ID,
+			SingleView: true,
+		})
+		th.CheckOK(resp)
+
+		got, resp := anonClient.GetBoard(board.ID, link.Token)
+		th.CheckUnauthorized(resp)
+		require.Nil(t, got)
+
+		got, resp = anonClient.GetSharedBoard(board.ID, link.Token, model.ShareLinkCredentials{ViewID: "view-1"})
+		th.CheckOK(resp)
+		require.Equal(t, board.ID, got.ID)
+
+		got, resp = anonClient.GetSharedBoard(board.ID, link.Token, model.ShareLinkCredentials{ViewID: "view-1"})
+		th.CheckOK(resp)
+		require.Equal(t, board.ID, got.ID)
+
+		got, resp = anonClient.GetSharedBoard(board.ID, link.Token, model.ShareLinkCredentials{ViewID: "view-2"})
+		th.CheckUnauthorized(resp)
+		require.Nil(t, got)
+	})
+
+	t.Run("links can't be created with public shared boards disabled", func(t *testing.T) {
+		th, board, _ := setup(t)
+		defer th.TearDown()
+		th.Server.Config().EnablePublicSharedBoards = false
+
+		link, resp := th.Client.CreateShareLink(&model.ShareLink{BoardID: board.ID})
+		th.CheckBadRequest(resp)
+		require.Nil(t, link)
+	})
+}
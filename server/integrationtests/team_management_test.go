@@ -0,0 +1,242 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/client"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamManagement(t *testing.T) {
+	t.Run("not available in plugin mode", func(t *testing.T) {
+		th := SetupTestHelperPluginMode(t)
+		defer th.TearDown()
+		clients := setupClients(th)
+
+		team, resp := clients.TeamMember.CreateTeam(&model.Team{Title: "Marketing"})
+		th.CheckNotImplemented(resp)
+		require.Nil(t, team)
+	})
+
+	t.Run("the creator of a team becomes its admin", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		team, resp := th.Client.CreateTeam(&model.Team{Title: "Marketing"})
+		th.CheckOK(resp)
+		require.NotEmpty(t, team.ID)
+		require.NotEmpty(t, team.SignupToken)
+
+		members, resp := th.Client.GetTeamMembers(team.ID)
+		th.CheckOK(resp)
+		require.Len(t, members, 1)
+		require.Equal(t, th.GetUser1().ID, members[0].UserID)
+		require.True(t, members[0].SchemeAdmin)
+
+		teams, resp := th.Client.GetTeams()
+		th.CheckOK(resp)
+		require.Len(t, teams, 2)
+
+		title := "Marketing EMEA"
+		team, resp = th.Client.PatchTeam(team.ID, &model.TeamPatch{Title: &title})
+		th.CheckOK(resp)
+		require.Equal(t, title, team.Title)
+	})
+
+	t.Run("only members can access a team and its boards", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		team, resp := th.Client.CreateTeam(&model.Team{Title: "Marketing"})
+		th.CheckOK(resp)
+		board := th.CreateBoard(team.ID, model.BoardTypeOpen)
+
+		_, resp = th.Client2.GetTeam(team.ID)
+		th.CheckForbidden(resp)
+		_, resp = th.Client2.GetTeamMembers(team.ID)
+		th.CheckForbidden(resp)
+		_, resp = th.Client2.GetBoard(board.ID, "")
+		th.CheckForbidden(resp)
+
+		teams, resp := th.Client2.GetTeams()
+		th.CheckOK(resp)
+		require.Len(t, teams, 1)
+
+		member, resp := th.Client.AddTeamMember(&model.TeamMember{TeamID: team.ID, UserID: th.GetUser2().ID})
+		th.CheckOK(resp)
+		require.False(t, member.SchemeAdmin)
+
+		got, resp := th.Client2.GetTeam(team.ID)
+		th.CheckOK(resp)
+		require.Equal(t, team.Title, got.Title)
+		require.Empty(t, got.SignupToken)
+
+		// team members are not team admins
+		title := "Renamed"
+		_, resp = th.Client2.PatchTeam(team.ID, &model.TeamPatch{Title: &title})
+		th.CheckForbidden(resp)
+		resp = th.Client2.ArchiveTeam(team.ID)
+		th.CheckForbidden(resp)
+		resp = th.Client2.RegenerateTeamSignupToken(team.ID)
+		th.CheckForbidden(resp)
+
+		// members can leave, the last admin can't
+		resp = th.Client2.DeleteTeamMember(team.ID, th.GetUser2().ID)
+		th.CheckOK(resp)
+		resp = th.Client.DeleteTeamMember(team.ID, th.GetUser1().ID)
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("team admins can be promoted and archive the team", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		team, resp := th.Client.CreateTeam(&model.Team{Title: "Marketing"})
+		th.CheckOK(resp)
+
+		_, resp = th.Client.AddTeamMember(&model.TeamMember{TeamID: team.ID, UserID: th.GetUser2().ID})
+		th.CheckOK(resp)
+		member, resp := th.Client.UpdateTeamMember(&model.TeamMember{TeamID: team.ID, UserID: th.GetUser2().ID, SchemeAdmin: true})
+		th.CheckOK(resp)
+		require.True(t, member.SchemeAdmin)
+
+		resp = th.Client2.ArchiveTeam(team.ID)
+		th.CheckOK(resp)
+
+		_, resp = th.Client.GetTeamMembers(team.ID)
+		th.CheckForbidden(resp)
+	})
+
+	t.Run("register with a team signup token", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		team, resp := th.Client.CreateTeam(&model.Team{Title: "Marketing"})
+		th.CheckOK(resp)
+
+		client3 := client.NewClient(th.Server.Config().ServerRoot, "")
+		th.RegisterAndLogin(client3, "user3", "user3@sample.com", password, team.SignupToken)
+
+		members, resp := client3.GetTeamMembers(team.ID)
+		th.CheckOK(resp)
+		require.Len(t, members, 2)
+
+		success, resp := th.Client.Register(&model.RegisterRequest{
+			Username: "user4",
+			Email:    "user4@sample.com",
+			Password: password,
+			Token:    "invalid-token",
+		})
+		th.CheckUnauthorized(resp)
+		require.False(t, success)
+	})
+
+	t.Run("team invitations", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		team, resp := th.Client.CreateTeam(&model.Team{Title: "Marketing"})
+		th.CheckOK(resp)
+
+		invite, resp := th.Client2.CreateTeamInvite(&model.TeamInvite{TeamID: team.ID, Email: "user2@sample.com"})
+		th.CheckForbidden(resp)
+		require.Nil(t, invite)
+
+		invite, resp = th.Client.CreateTeamInvite(&model.TeamInvite{TeamID: team.ID, Email: "user2@sample.com", SchemeAdmin: true})
+		th.CheckOK(resp)
+		require.NotEmpty(t, invite.ID)
+
+		newUserInvite, resp := th.Client.CreateTeamInvite(&model.TeamInvite{TeamID: team.ID, Email: "user3@sample.com"})
+		th.CheckOK(resp)
+
+		invites, resp := th.Client.GetTeamInvites(team.ID)
+		th.CheckOK(resp)
+		require.Len(t, invites, 2)
+
+		// the invitation is restricted to its email
+		client3 := client.NewClient(th.Server.Config().ServerRoot, "")
+		success, resp := th.Client.Register(&model.RegisterRequest{
+			Username: "user3",
+			Email:    "other@sample.com",
+			Password: password,
+			Token:    newUserInvite.ID,
+		})
+		th.CheckUnauthorized(resp)
+		require.False(t, success)
+		th.RegisterAndLogin(client3, "user3", "user3@sample.com", password, newUserInvite.ID)
+
+		member, resp := th.Client2.AcceptTeamInvite(invite.ID)
+		th.CheckOK(resp)
+		require.True(t, member.SchemeAdmin)
+
+		_, resp = th.Client2.AcceptTeamInvite(invite.ID)
+		th.CheckBadRequest(resp)
+
+		members, resp := client3.GetTeamMembers(team.ID)
+		th.CheckOK(resp)
+		require.Len(t, members, 3)
+
+		invites, resp = th.Client.GetTeamInvites(team.ID)
+		th.CheckOK(resp)
+		require.Empty(t, invites)
+
+		revoked, resp := th.Client.CreateTeamInvite(&model.TeamInvite{TeamID: team.ID})
+		th.CheckOK(resp)
+		resp = th.Client.DeleteTeamInvite(team.ID, revoked.ID)
+		th.CheckOK(resp)
+		_, resp = th.Client2.AcceptTeamInvite(revoked.ID)
+		th.CheckNotFound(resp)
+	})
+
+	t.Run("team invitations with boards", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		team, resp := th.Client.CreateTeam(&model.Team{Title: "Marketing"})
+		th.CheckOK(resp)
+		board := th.CreateBoard(team.ID, model.BoardTypePrivate)
+		otherBoard := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+
+		// the boards must be boards of the team
+		_, resp = th.Client.CreateTeamInvite(&model.TeamInvite{TeamID: team.ID, BoardIDs: []string{otherBoard.ID}})
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.CreateTeamInvite(&model.TeamInvite{TeamID: team.ID, BoardRole: "owner"})
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.CreateTeamInvite(&model.TeamInvite{TeamID: team.ID, ExpireAt: 1000})
+		th.CheckBadRequest(resp)
+
+		invite, resp := th.Client.CreateTeamInvite(&model.TeamInvite{
+			TeamID:    team.ID,
+			Email:     "user2@sample.com",
+			BoardIDs:  []string{board.ID},
+			BoardRole: model.BoardRoleViewer,
+		})
+		th.CheckOK(resp)
+		require.Equal(t, []string{board.ID}, invite.BoardIDs)
+
+		_, resp = th.Client2.AcceptTeamInvite(invite.ID)
+		th.CheckOK(resp)
+
+		members, resp := th.Client.GetMembersForBoard(board.ID)
+		th.CheckOK(resp)
+		var invited *model.BoardMember
+		for _, member := range members {
+			if member.UserID == th.GetUser2().ID {
+				invited = member
+			}
+		}
+		require.NotNil(t, invited)
+		require.True(t, invited.SchemeViewer)
+		require.False(t, invited.SchemeEditor)
+		require.False(t, invited.SchemeAdmin)
+
+		_, resp = th.Client2.GetBoard(board.ID, "")
+		th.CheckOK(resp)
+	})
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/services/auth"
+)
+
+const (
+	// AccessTokenPrefix starts every personal access token, so they can be
+	// told apart from session tokens.
+	AccessTokenPrefix = auth.AccessTokenPrefix
+
+	// MaxAccessTokenNameLength is the maximum length of an access token name.
+	MaxAccessTokenNameLength = 64
+
+	// AccessTokenScopeRead only allows reading.
+	AccessTokenScopeRead = "read"
+	// AccessTokenScopeBoardsWrite allows reading and changing the boards
+	// and their content.
+	AccessTokenScopeBoardsWrite = "boards:write"
+	// AccessTokenScopeAdmin allows everything the user can do, including
+	// managing its account, its teams and the access to its boards.
+	AccessTokenScopeAdmin = "admin"
+
+	// SessionPropAccessTokenID is the session prop with the ID of the
+	// access token the session was created from.
+	SessionPropAccessTokenID = "accessTokenId"
+	// SessionPropAccessTokenScopes is the session prop with the scopes of
+	// the access token the session was created from.
+	SessionPropAccessTokenScopes = "accessTokenScopes"
+)
+
+// AccessToken is a personal access token, that authenticates scripts as
+// its user with a limited set of scopes.
+// swagger:model
+type AccessToken struct {
+	// The id of the access token
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the user the token authenticates
+	// required: true
+	UserID string `json:"userId"`
+
+	// A name to identify the token
+	// required: true
+	Name string `json:"name"`
+
+	// The token, only returned when it is created
+	// required: false
+	Token string `json:"token,omitempty"`
+
+	// The hash of the token, the token itself isn't stored
+	TokenHash string `json:"-"`
+
+	// The scopes of the token: read, boards:write or admin
+	// required: true
+	Scopes []string `json:"scopes"`
+
+	// Expiry time in miliseconds since the current epoch, zero if the token doesn't expire
+	// required: false
+	ExpireAt int64 `json:"expireAt"`
+
+	// The last time the token was used in miliseconds since the current epoch
+	// required: false
+	LastUsedAt int64 `json:"lastUsedAt"`
+
+	// The creation time in miliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+}
+
+func (t *AccessToken) IsValid() error {
+	if t == nil {
+		return NewErrBadRequest("access token cannot be nil")
+	}
+	if t.UserID == "" {
+		return NewErrBadRequest("missing user id")
+	}
+	if strings.TrimSpace(t.Name) == "" {
+		return NewErrBadRequest("missing access token name")
+	}
+	if len(t.Name) > MaxAccessTokenNameLength {
+		return NewErrBadRequest("access token name is too long")
+	}
+	if len(t.Scopes) == 0 {
+		return NewErrBadRequest("missing access token scopes")
+	}
+	for _, scope := range t.Scopes {
+		if !IsAccessTokenScopeValid(scope) {
+			return NewErrBadRequest("invalid access token scope " + scope)
+		}
+	}
+	if t.ExpireAt < 0 {
+		return NewErrBadRequest("invalid access token expiry time")
+	}
+	return nil
+}
+
+// IsExpired returns true if the token has an expiry time in the past.
+func (t *AccessToken) IsExpired(now int64) bool {
+	return t.ExpireAt != 0 && t.ExpireAt <= now
+}
+
+func IsAccessTokenScopeValid(scope string) bool {
+	return scope == AccessTokenScopeRead || scope == AccessTokenScopeBoardsWrite || scope == AccessTokenScopeAdmin
+}
+
+// AccessTokenScopesAllow tells if the scopes allow the required scope. The
+// admin scope allows everything, and the boards:write scope allows reading.
+func AccessTokenScopesAllow(scopes []string, required string) bool {
+	for _, scope := range scopes {
+		switch {
+		case scope == required, scope == AccessTokenScopeAdmin:
+			return true
+		case scope == AccessTokenScopeBoardsWrite && required == AccessTokenScopeRead:
+			return true
+		}
+	}
+	return false
+}
+
+// IsAccessTokenSession returns true for the sessions created from a
+// personal access token.
+func (s *Session) IsAccessTokenSession() bool {
+	_, ok := s.Props[SessionPropAccessTokenID]
+	return ok
+}
+
+// HasAccessTokenScope tells if the session is allowed the scope. Sessions
+// that don't come from an access token are allowed every scope.
+func (s *Session) HasAccessTokenScope(scope string) bool {
+	if !s.IsAccessTokenSession() {
+		return true
+	}
+	scopes, _ := s.Props[SessionPropAccessTokenScopes].([]string)
+	return AccessTokenScopesAllow(scopes, scope)
+}
+
+func AccessTokenFromJSON(data io.Reader) (*AccessToken, error) {
+	var token AccessToken
+	if err := json.NewDecoder(data).Decode(&token); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
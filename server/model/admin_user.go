@@ -0,0 +1,105 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// AdminUser describes a user to the system admins, with its email address
+// and account status.
+// swagger:model
+type AdminUser struct {
+	// The user ID
+	// required: true
+	ID string `json:"id"`
+
+	// The user name
+	// required: true
+	Username string `json:"username"`
+
+	// The user's email
+	// required: true
+	Email string `json:"email"`
+
+	// The user's nickname
+	// required: false
+	Nickname string `json:"nickname"`
+
+	// The user's first name
+	// required: false
+	FirstName string `json:"firstname"`
+
+	// The user's last name
+	// required: false
+	LastName string `json:"lastname"`
+
+	// The service the user authenticates with, empty for local accounts
+	// required: false
+	AuthService string `json:"authService"`
+
+	// Whether the email address has been verified
+	// required: true
+	EmailVerified bool `json:"emailVerified"`
+
+	// Whether multi-factor authentication is active for the user
+	// required: true
+	MfaActive bool `json:"mfaActive"`
+
+	// If the user is a guest or not
+	// required: true
+	IsGuest bool `json:"isGuest"`
+
+	// Time in miliseconds since the current epoch after which the guest
+	// account can't be used anymore, 0 if the guest account doesn't expire
+	// required: false
+	GuestExpireAt int64 `json:"guestExpireAt,omitempty"`
+
+	// If the user is a system admin or not
+	// required: true
+	IsSystemAdmin bool `json:"isSystemAdmin"`
+
+	// Created time in miliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// Updated time in miliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+
+	// Deactivation time in miliseconds since the current epoch, 0 for the
+	// active users
+	// required: true
+	DeleteAt int64 `json:"deleteAt"`
+}
+
+// NewAdminUser returns the description of the user shown to the system
+// admins.
+func NewAdminUser(user *User) *AdminUser {
+	return &AdminUser{
+		ID:            user.ID,
+		Username:      user.Username,
+		Email:         user.Email,
+		Nickname:      user.Nickname,
+		FirstName:     user.FirstName,
+		LastName:      user.LastName,
+		AuthService:   user.AuthService,
+		EmailVerified: user.EmailVerified,
+		MfaActive:     user.MfaActive,
+		IsGuest:       user.IsGuest,
+		GuestExpireAt: user.GuestExpireAt,
+		IsSystemAdmin: user.IsSystemAdmin(),
+		CreateAt:      user.CreateAt,
+		UpdateAt:      user.UpdateAt,
+		DeleteAt:      user.DeleteAt,
+	}
+}
+
+// UserBoardMembership is a board membership of a user with its board.
+// swagger:model
+type UserBoardMembership struct {
+	// The board
+	// required: true
+	Board *Board `json:"board"`
+
+	// The membership of the user
+	// required: true
+	Member *BoardMember `json:"member"`
+}
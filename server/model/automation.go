@@ -0,0 +1,327 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+)
+
+const (
+	// BoardPropertyAutomations is the board property holding the automation rules.
+	BoardPropertyAutomations = "automations"
+
+	// MaxAutomationRules is the maximum number of automation rules of a board.
+	MaxAutomationRules = 50
+
+	// MaxAutomationActions is the maximum number of actions of a rule.
+	MaxAutomationActions = 10
+)
+
+type AutomationTriggerType string
+
+const (
+	AutomationTriggerCardCreated     AutomationTriggerType = "cardCreated"
+	AutomationTriggerPropertyChanged AutomationTriggerType = "propertyChanged"
+	AutomationTriggerCommentAdded    AutomationTriggerType = "commentAdded"
+	AutomationTriggerDueDateReached  AutomationTriggerType = "dueDateReached"
+)
+
+type AutomationConditionOperator string
+
+const (
+	AutomationConditionIs         AutomationConditionOperator = "is"
+	AutomationConditionIsNot      AutomationConditionOperator = "isNot"
+	AutomationConditionIsEmpty    AutomationConditionOperator = "isEmpty"
+	AutomationConditionIsNotEmpty AutomationConditionOperator = "isNotEmpty"
+)
+
+type AutomationActionType string
+
+const (
+	AutomationActionSetProperty      AutomationActionType = "setProperty"
+	AutomationActionAssignPerson     AutomationActionType = "assignPerson"
+	AutomationActionAddComment       AutomationActionType = "addComment"
+	AutomationActionMoveToBoard      AutomationActionType = "moveToBoard"
+	AutomationActionSendNotification AutomationActionType = "sendNotification"
+	AutomationActionCallWebhook      AutomationActionType = "callWebhook"
+)
+
+// AutomationRule runs its actions on a card when the trigger fires and all
+// of the conditions match the card.
+// swagger:model
+type AutomationRule struct {
+	// The id of the rule
+	// required: false
+	ID string `json:"id"`
+
+	// The name of the rule
+	// required: true
+	Name string `json:"name"`
+
+	// Disabled rules are kept but never run
+	// required: true
+	Enabled bool `json:"enabled"`
+
+	// The id of the user who last saved the rule
+	// required: false
+	CreatedBy string `json:"createdBy"`
+
+	// The event that runs the rule
+	// required: true
+	Trigger AutomationTrigger `json:"trigger"`
+
+	// The conditions the card must match for the actions to run
+	// required: false
+	Conditions []AutomationCondition `json:"conditions"`
+
+	// The actions to run, in order
+	// required: true
+	Actions []AutomationAction `json:"actions"`
+}
+
+// AutomationTrigger is the event that runs a rule.
+// swagger:model
+type AutomationTrigger struct {
+	// One of cardCreated, propertyChanged, commentAdded or dueDateReached
+	// required: true
+	Type AutomationTriggerType `json:"type"`
+
+	// The property that changed, or the date property, for the
+	// propertyChanged and dueDateReached triggers
+	// required: false
+	PropertyID string `json:"propertyId"`
+
+	// The value the property changed to, for the propertyChanged trigger.
+	// When empty any change of the property runs the rule
+	// required: false
+	Value any `json:"value"`
+}
+
+// AutomationCondition compares a property value of the card.
+// swagger:model
+type AutomationCondition struct {
+	// The id of the property
+	// required: true
+	PropertyID string `json:"propertyId"`
+
+	// One of is, isNot, isEmpty or isNotEmpty
+	// required: true
+	Operator AutomationConditionOperator `json:"operator"`
+
+	// The value to compare with, for the is and isNot operators. Multi-value
+	// properties match when they contain the value
+	// required: false
+	Value any `json:"value"`
+}
+
+// AutomationAction is a change made by a rule.
+// swagger:model
+type AutomationAction struct {
+	// One of setProperty, assignPerson, addComment, moveToBoard,
+	// sendNotification or callWebhook
+	// required: true
+	Type AutomationActionType `json:"type"`
+
+	// The property to set, for the setProperty and assignPerson actions
+	// required: false
+	PropertyID string `json:"propertyId"`
+
+	// The new property value, for the setProperty action
+	// required: false
+	Value any `json:"value"`
+
+	// The user to assign or notify, for the assignPerson and sendNotification actions
+	// required: false
+	UserID string `json:"userId"`
+
+	// The text of the comment or notification
+	// required: false
+	Text string `json:"text"`
+
+	// The target board and property mapping, for the moveToBoard action
+	// required: false
+	Move *MoveCardRequest `json:"move"`
+
+	// The URL that receives the card, for the callWebhook action. It must
+	// not resolve to a loopback, private or link local address
+	// required: false
+	URL string `json:"url"`
+}
+
+func (r *AutomationRule) IsValid() error {
+	if r == nil {
+		return NewErrBadRequest("automation rule cannot be nil")
+	}
+	if r.Name == "" {
+		return NewErrBadRequest("missing automation rule name")
+	}
+
+	switch r.Trigger.Type {
+	case AutomationTriggerCardCreated, AutomationTriggerCommentAdded:
+	case AutomationTriggerPropertyChanged, AutomationTriggerDueDateReached:
+		if r.Trigger.PropertyID == "" {
+			return NewErrBadRequest(fmt.Sprintf("missing property id for trigger %s", r.Trigger.Type))
+		}
+	default:
+		return NewErrBadRequest(fmt.Sprintf("invalid automation trigger: %s", r.Trigger.Type))
+	}
+
+	for _, condition := range r.Conditions {
+		if err := condition.IsValid(); err != nil {
+			return err
+		}
+	}
+
+	if len(r.Actions) == 0 {
+		return NewErrBadRequest("automation rule has no actions")
+	}
+	if len(r.Actions) > MaxAutomationActions {
+		return NewErrBadRequest(fmt.Sprintf("automation rule cannot have more than %d actions", MaxAutomationActions))
+	}
+	for _, action := range r.Actions {
+		if err := action.IsValid(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *AutomationCondition) IsValid() error {
+	if c.PropertyID == "" {
+		return NewErrBadRequest("missing property id for condition")
+	}
+	switch c.Operator {
+	case AutomationConditionIs, AutomationConditionIsNot, AutomationConditionIsEmpty, AutomationConditionIsNotEmpty:
+		return nil
+	default:
+		return NewErrBadRequest(fmt.Sprintf("invalid condition operator: %s", c.Operator))
+	}
+}
+
+func (a *AutomationAction) IsValid() error {
+	switch a.Type {
+	case AutomationActionSetProperty:
+		if a.PropertyID == "" {
+			return NewErrBadRequest("missing property id for setProperty action")
+		}
+	case AutomationActionAssignPerson:
+		if a.PropertyID == "" || a.UserID == "" {
+			return NewErrBadRequest("assignPerson action requires a property id and a user id")
+		}
+	case AutomationActionAddComment:
+		if a.Text == "" {
+			return NewErrBadRequest("missing text for addComment action")
+		}
+	case AutomationActionMoveToBoard:
+		if a.Move == nil {
+			return NewErrBadRequest("missing move request for moveToBoard action")
+		}
+		return a.Move.IsValid()
+	case AutomationActionSendNotification:
+		if a.UserID == "" || a.Text == "" {
+			return NewErrBadRequest("sendNotification action requires a user id and a text")
+		}
+	case AutomationActionCallWebhook:
+		u, err := url.Parse(a.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return NewErrBadRequest(fmt.Sprintf("invalid webhook url: %s", a.URL))
+		}
+	default:
+		return NewErrBadRequest(fmt.Sprintf("invalid automation action: %s", a.Type))
+	}
+	return nil
+}
+
+// Matches returns true if the card matches the condition.
+func (c *AutomationCondition) Matches(card *Block) bool {
+	value := CardPropertyValue(card, c.PropertyID)
+	switch c.Operator {
+	case AutomationConditionIs:
+		return propertyValueMatches(value, c.Value)
+	case AutomationConditionIsNot:
+		return !propertyValueMatches(value, c.Value)
+	case AutomationConditionIsEmpty:
+		return isEmptyPropertyValue(value)
+	case AutomationConditionIsNotEmpty:
+		return !isEmptyPropertyValue(value)
+	}
+	return false
+}
+
+// ConditionsMatch returns true if the card matches all of the rule's conditions.
+func (r *AutomationRule) ConditionsMatch(card *Block) bool {
+	for i := range r.Conditions {
+		if !r.Conditions[i].Matches(card) {
+			return false
+		}
+	}
+	return true
+}
+
+// PropertyChangeMatches returns true if the card change sets the trigger
+// property, to the trigger value if there is one.
+func (t *AutomationTrigger) PropertyChangeMatches(oldCard, newCard *Block) bool {
+	oldValue := CardPropertyValue(oldCard, t.PropertyID)
+	newValue := CardPropertyValue(newCard, t.PropertyID)
+	if reflect.DeepEqual(oldValue, newValue) {
+		return false
+	}
+	if isEmptyPropertyValue(t.Value) {
+		return true
+	}
+	return propertyValueMatches(newValue, t.Value) && !propertyValueMatches(oldValue, t.Value)
+}
+
+// CardPropertyValue returns the value of a card property, or nil if it
+// isn't set.
+func CardPropertyValue(card *Block, propertyID string) any {
+	if card == nil {
+		return nil
+	}
+	properties, _ := card.Fields["properties"].(map[string]any)
+	return properties[propertyID]
+}
+
+func isEmptyPropertyValue(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []any:
+		return len(v) == 0
+	}
+	return false
+}
+
+// AutomationRulesFromBoard returns the automation rules stored in the board
+// properties.
+func AutomationRulesFromBoard(board *Board) ([]*AutomationRule, error) {
+	rules := []*AutomationRule{}
+	value, ok := board.Properties[BoardPropertyAutomations]
+	if !ok || value == nil {
+		return rules, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("invalid automation rules for board %s: %w", board.ID, err)
+	}
+	return rules, nil
+}
+
+func AutomationRulesFromJSON(data io.Reader) ([]*AutomationRule, error) {
+	var rules []*AutomationRule
+	if err := json.NewDecoder(data).Decode(&rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
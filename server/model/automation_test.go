@@ -0,0 +1,110 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutomationRuleIsValid(t *testing.T) {
+	validRule := func() *AutomationRule {
+		return &AutomationRule{
+			Name:    "rule",
+			Enabled: true,
+			Trigger: AutomationTrigger{Type: AutomationTriggerCardCreated},
+			Actions: []AutomationAction{{Type: AutomationActionAddComment, Text: "hello"}},
+		}
+	}
+
+	require.NoError(t, validRule().IsValid())
+
+	testCases := []struct {
+		name   string
+		modify func(rule *AutomationRule)
+	}{
+		{"missing name", func(rule *AutomationRule) { rule.Name = "" }},
+		{"invalid trigger", func(rule *AutomationRule) { rule.Trigger.Type = "other" }},
+		{"property trigger without property", func(rule *AutomationRule) { rule.Trigger.Type = AutomationTriggerPropertyChanged }},
+		{"no actions", func(rule *AutomationRule) { rule.Actions = nil }},
+		{"invalid condition", func(rule *AutomationRule) {
+			rule.Conditions = []AutomationCondition{{PropertyID: "p", Operator: "contains"}}
+		}},
+		{"invalid action", func(rule *AutomationRule) { rule.Actions[0].Type = "other" }},
+		{"move without request", func(rule *AutomationRule) { rule.Actions[0].Type = AutomationActionMoveToBoard }},
+		{"webhook with invalid url", func(rule *AutomationRule) {
+			rule.Actions[0] = AutomationAction{Type: AutomationActionCallWebhook, URL: "file:///etc/passwd"}
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rule := validRule()
+			tc.modify(rule)
+			require.Error(t, rule.IsValid())
+		})
+	}
+}
+
+func TestAutomationConditionMatches(t *testing.T) {
+	card := &Block{
+		Type: TypeCard,
+		Fields: map[string]any{
+			"properties": map[string]any{
+				"status": "done",
+				"tags":   []any{"a", "b"},
+			},
+		},
+	}
+
+	assert.True(t, (&AutomationCondition{PropertyID: "status", Operator: AutomationConditionIs, Value: "done"}).Matches(card))
+	assert.False(t, (&AutomationCondition{PropertyID: "status", Operator: AutomationConditionIsNot, Value: "done"}).Matches(card))
+	assert.True(t, (&AutomationCondition{PropertyID: "tags", Operator: AutomationConditionIs, Value: "b"}).Matches(card))
+	assert.True(t, (&AutomationCondition{PropertyID: "owner", Operator: AutomationConditionIsEmpty}).Matches(card))
+	assert.False(t, (&AutomationCondition{PropertyID: "tags", Operator: AutomationConditionIsEmpty}).Matches(card))
+	assert.True(t, (&AutomationCondition{PropertyID: "tags", Operator: AutomationConditionIsNotEmpty}).Matches(card))
+}
+
+func TestAutomationTriggerPropertyChangeMatches(t *testing.T) {
+	cardWithStatus := func(status any) *Block {
+		return &Block{Fields: map[string]any{"properties": map[string]any{"status": status}}}
+	}
+
+	anyChange := &AutomationTrigger{Type: AutomationTriggerPropertyChanged, PropertyID: "status"}
+	assert.True(t, anyChange.PropertyChangeMatches(cardWithStatus("todo"), cardWithStatus("done")))
+	assert.False(t, anyChange.PropertyChangeMatches(cardWithStatus("done"), cardWithStatus("done")))
+
+	toDone := &AutomationTrigger{Type: AutomationTriggerPropertyChanged, PropertyID: "status", Value: "done"}
+	assert.True(t, toDone.PropertyChangeMatches(cardWithStatus("todo"), cardWithStatus("done")))
+	assert.True(t, toDone.PropertyChangeMatches(nil, cardWithStatus("done")))
+	assert.False(t, toDone.PropertyChangeMatches(cardWithStatus("done"), cardWithStatus("todo")))
+}
+
+func TestAutomationRulesFromBoard(t *testing.T) {
+	board := &Board{ID: "board-id", Properties: map[string]any{}}
+	rules, err := AutomationRulesFromBoard(board)
+	require.NoError(t, err)
+	require.Empty(t, rules)
+
+	board.Properties[BoardPropertyAutomations] = []any{
+		map[string]any{
+			"id":      "rule-id",
+			"name":    "rule",
+			"enabled": true,
+			"trigger": map[string]any{"type": "commentAdded"},
+			"actions": []any{map[string]any{"type": "addComment", "text": "thanks"}},
+		},
+	}
+	rules, err = AutomationRulesFromBoard(board)
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	require.Equal(t, AutomationTriggerCommentAdded, rules[0].Trigger.Type)
+	require.Equal(t, "thanks", rules[0].Actions[0].Text)
+
+	board.Properties[BoardPropertyAutomations] = "not rules"
+	_, err = AutomationRulesFromBoard(board)
+	require.Error(t, err)
+}
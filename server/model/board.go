@@ -29,6 +29,14 @@ const (
 	BoardSearchFieldPropertyName BoardSearchField = "property_name"
 )
 
+// Actions recorded in the board member history.
+const (
+	BoardMemberHistoryCreated  = "created"
+	BoardMemberHistoryDeleted  = "deleted"
+	BoardMemberHistoryPromoted = "promoted"
+	BoardMemberHistoryDemoted  = "demoted"
+)
+
 // Board groups a set of blocks and its layout
 // swagger:model
 type Board struct {
@@ -84,6 +92,10 @@ type Board struct {
 	// required: false
 	TemplateVersion int `json:"templateVersion"`
 
+	// Locked boards can't be changed until they are unlocked
+	// required: false
+	IsLocked bool `json:"isLocked"`
+
 	// The properties of the board
 	// required: false
 	Properties map[string]interface{} `json:"properties"`
@@ -187,6 +199,10 @@ type BoardMember struct {
 	// required: false
 	MinimumRole string `json:"minimumRole"`
 
+	// The ID of the custom board role of the user on the board, if any
+	// required: false
+	CustomRoleID string `json:"customRoleId"`
+
 	// Marks the user as an admin of the board
 	// required: true
 	SchemeAdmin bool `json:"schemeAdmin"`
@@ -292,6 +308,10 @@ func (p *BoardPatch) Patch(board *Board) *Board {
 		board.ChannelID = *p.ChannelID
 	}
 
+	if len(p.UpdatedProperties) != 0 && board.Properties == nil {
+		board.Properties = map[string]interface{}{}
+	}
+
 	for key, property := range p.UpdatedProperties {
 		board.Properties[key] = property
 	}
@@ -368,6 +388,16 @@ func (p *BoardPatch) IsValid() error {
 		return InvalidBoardErr{"invalid-board-minimum-role"}
 	}
 
+	// automation rules are validated and authorized by their own API
+	if _, ok := p.UpdatedProperties[BoardPropertyAutomations]; ok {
+		return InvalidBoardErr{"automations-not-patchable"}
+	}
+	for _, key := range p.DeletedProperties {
+		if key == BoardPropertyAutomations {
+			return InvalidBoardErr{"automations-not-patchable"}
+		}
+	}
+
 	return nil
 }
 
@@ -406,7 +436,8 @@ type BoardMemberHistoryEntry struct {
 	// required: true
 	UserID string `json:"userId"`
 
-	// The action that added this history entry (created or deleted)
+	// The action that added this history entry (created, deleted, promoted
+	// to admin or demoted from admin)
 	// required: false
 	Action string `json:"action"`
 
@@ -0,0 +1,114 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// MaxBoardSnapshotNameLength is the maximum length of a snapshot name.
+const MaxBoardSnapshotNameLength = 255
+
+type BoardSnapshotType string
+
+const (
+	BoardSnapshotManual    BoardSnapshotType = "manual"
+	BoardSnapshotAutomatic BoardSnapshotType = "automatic"
+)
+
+// BoardSnapshot is a named point in time of a board that the board can be
+// restored to. Snapshots don't copy any data, the board and block history
+// is used to restore the board.
+// swagger:model
+type BoardSnapshot struct {
+	// The id of the snapshot
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The name of the snapshot
+	// required: true
+	Name string `json:"name"`
+
+	// The snapshot type, manual or automatic. Automatic snapshots are taken
+	// before bulk operations and restores
+	// required: true
+	Type BoardSnapshotType `json:"type"`
+
+	// The id of the user who took the snapshot
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// The time of the snapshot in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+}
+
+// BoardRestoreRequest restores a board to a snapshot or to a point in time.
+// swagger:model
+type BoardRestoreRequest struct {
+	// The id of the snapshot to restore. Either snapshotId or timestamp is required
+	// required: false
+	SnapshotID string `json:"snapshotId"`
+
+	// The time to restore the board to in milliseconds since the current epoch.
+	// Either snapshotId or timestamp is required
+	// required: false
+	Timestamp int64 `json:"timestamp"`
+}
+
+func (s *BoardSnapshot) IsValid() error {
+	if s == nil {
+		return NewErrBadRequest("snapshot cannot be nil")
+	}
+	if s.BoardID == "" {
+		return NewErrBadRequest("missing board id")
+	}
+	if s.Name == "" {
+		return NewErrBadRequest("missing snapshot name")
+	}
+	if len(s.Name) > MaxBoardSnapshotNameLength {
+		return NewErrBadRequest("snapshot name is too long")
+	}
+	if s.Type != BoardSnapshotManual && s.Type != BoardSnapshotAutomatic {
+		return NewErrBadRequest("invalid snapshot type: " + string(s.Type))
+	}
+	return nil
+}
+
+func (r *BoardRestoreRequest) IsValid() error {
+	if r == nil {
+		return NewErrBadRequest("restore request cannot be nil")
+	}
+	if r.SnapshotID == "" && r.Timestamp == 0 {
+		return NewErrBadRequest("either snapshotId or timestamp is required")
+	}
+	if r.SnapshotID != "" && r.Timestamp != 0 {
+		return NewErrBadRequest("snapshotId and timestamp cannot be used together")
+	}
+	if r.Timestamp < 0 {
+		return NewErrBadRequest("invalid timestamp")
+	}
+	return nil
+}
+
+func BoardSnapshotFromJSON(data io.Reader) (*BoardSnapshot, error) {
+	var snapshot BoardSnapshot
+	if err := json.NewDecoder(data).Decode(&snapshot); err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+func BoardRestoreRequestFromJSON(data io.Reader) (*BoardRestoreRequest, error) {
+	var request BoardRestoreRequest
+	if err := json.NewDecoder(data).Decode(&request); err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardSnapshotIsValid(t *testing.T) {
+	snapshot := &BoardSnapshot{BoardID: "board-id", Name: "snapshot", Type: BoardSnapshotManual}
+	require.NoError(t, snapshot.IsValid())
+
+	invalid := *snapshot
+	invalid.Name = strings.Repeat("a", MaxBoardSnapshotNameLength+1)
+	require.Error(t, invalid.IsValid())
+
+	invalid = *snapshot
+	invalid.Type = "other"
+	require.Error(t, invalid.IsValid())
+
+	invalid = *snapshot
+	invalid.BoardID = ""
+	require.Error(t, invalid.IsValid())
+}
+
+func TestBoardRestoreRequestIsValid(t *testing.T) {
+	require.NoError(t, (&BoardRestoreRequest{SnapshotID: "snapshot-id"}).IsValid())
+	require.NoError(t, (&BoardRestoreRequest{Timestamp: 1}).IsValid())
+	require.Error(t, (&BoardRestoreRequest{}).IsValid())
+	require.Error(t, (&BoardRestoreRequest{SnapshotID: "snapshot-id", Timestamp: 1}).IsValid())
+	require.Error(t, (&BoardRestoreRequest{Timestamp: -1}).IsValid())
+}
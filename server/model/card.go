@@ -84,6 +84,23 @@ type Card struct {
 	// The deleted time in milliseconds since the current epoch. Set to indicate this card is deleted
 	// required: false
 	DeleteAt int64 `json:"deleteAt"`
+
+	// The archived time in milliseconds since the current epoch. Set to indicate this card is archived
+	// required: false
+	ArchivedAt int64 `json:"archivedAt,omitempty"`
+
+	// Locked cards and their content can't be changed until they are unlocked
+	// required: false
+	IsLocked bool `json:"isLocked,omitempty"`
+
+	// Restricted cards are only visible to their creator, their assignees,
+	// the allowed users and the board admins
+	// required: false
+	IsRestricted bool `json:"isRestricted,omitempty"`
+
+	// The IDs of the users that can see the restricted card
+	// required: false
+	AllowedUserIDs []string `json:"allowedUserIds,omitempty"`
 }
 
 // Populate populates a Card with default values.
@@ -200,6 +217,16 @@ func Card2Block(card *Card) *Block {
 	fields["icon"] = card.Icon
 	fields["isTemplate"] = card.IsTemplate
 	fields["properties"] = card.Properties
+	if card.ArchivedAt != 0 {
+		fields["archivedAt"] = card.ArchivedAt
+	}
+	if card.IsLocked {
+		fields["isLocked"] = true
+	}
+	if card.IsRestricted {
+		fields[CardRestrictedField] = true
+		fields[CardAllowedUsersField] = card.AllowedUserIDs
+	}
 
 	return &Block{
 		ID:         card.ID,
@@ -261,6 +288,11 @@ func Block2Card(block *Block) (*Card, error) {
 		}
 	}
 
+	archivedAt, err := BlockArchivedAt(block)
+	if err != nil {
+		return nil, err
+	}
+
 	if props, ok := block.Fields["properties"]; ok {
 		if propMap, ok := props.(map[string]any); ok {
 			for k, v := range propMap {
@@ -284,11 +316,46 @@ func Block2Card(block *Block) (*Card, error) {
 		CreateAt:     block.CreateAt,
 		UpdateAt:     block.UpdateAt,
 		DeleteAt:     block.DeleteAt,
+		ArchivedAt:   archivedAt,
+		IsLocked:     BlockIsLocked(block),
+	}
+	if BlockIsRestricted(block) {
+		card.IsRestricted = true
+		card.AllowedUserIDs = BlockAllowedUserIDs(block)
 	}
 	card.Populate()
 	return card, nil
 }
 
+// BlockArchivedAt returns the time a card block was archived, or zero if it
+// is not archived.
+func BlockArchivedAt(block *Block) (int64, error) {
+	archivedAtAny, ok := block.Fields["archivedAt"]
+	if !ok || archivedAtAny == nil {
+		return 0, nil
+	}
+
+	switch v := archivedAtAny.(type) {
+	case float64:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case int:
+		return int64(v), nil
+	default:
+		return 0, ErrInvalidFieldType{"archivedAt"}
+	}
+}
+
+// BlockIsLocked returns true if the block is a locked card.
+func BlockIsLocked(block *Block) bool {
+	if block == nil || block.Type != TypeCard {
+		return false
+	}
+	locked, _ := block.Fields["isLocked"].(bool)
+	return locked
+}
+
 // CardPatch2BlockPatch converts a CardPatch to a BlockPatch. Not needed once cards are first class entities.
 func CardPatch2BlockPatch(cardPatch *CardPatch) (*BlockPatch, error) {
 	if err := cardPatch.CheckValid(); err != nil {
@@ -0,0 +1,161 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+const (
+	// CardRestrictedField is the card block field that marks restricted cards.
+	CardRestrictedField = "restricted"
+
+	// CardAllowedUsersField is the card block field with the IDs of the
+	// users a restricted card is visible to, besides its assignees.
+	CardAllowedUsersField = "allowedUserIds"
+
+	// MaxCardAllowedUsers is the maximum number of users a card can be
+	// restricted to.
+	MaxCardAllowedUsers = 250
+)
+
+// CardAccess restricts who can see a card
+// swagger:model
+type CardAccess struct {
+	// Restricted cards are only visible to their creator, their assignees,
+	// the allowed users and the board admins
+	// required: true
+	Restricted bool `json:"restricted"`
+
+	// The IDs of the users that can see the restricted card
+	// required: false
+	AllowedUserIDs []string `json:"allowedUserIds"`
+}
+
+func CardAccessFromJSON(data io.Reader) (*CardAccess, error) {
+	var access *CardAccess
+	if err := json.NewDecoder(data).Decode(&access); err != nil {
+		return nil, err
+	}
+	return access, nil
+}
+
+// IsValid returns an error if the card access is not valid.
+func (ca *CardAccess) IsValid() error {
+	if !ca.Restricted && len(ca.AllowedUserIDs) != 0 {
+		return NewErrBadRequest("allowed users can only be set on restricted cards")
+	}
+	if len(ca.AllowedUserIDs) > MaxCardAllowedUsers {
+		return NewErrBadRequest(fmt.Sprintf("a card can't be restricted to more than %d users", MaxCardAllowedUsers))
+	}
+	for _, userID := range ca.AllowedUserIDs {
+		if userID == "" {
+			return NewErrBadRequest("allowed user IDs can't be empty")
+		}
+	}
+	return nil
+}
+
+// BlockIsRestricted returns true if the block is a restricted card.
+func BlockIsRestricted(block *Block) bool {
+	if block == nil || block.Type != TypeCard {
+		return false
+	}
+	restricted, _ := block.Fields[CardRestrictedField].(bool)
+	return restricted
+}
+
+// BlockAllowedUserIDs returns the IDs of the users a card block is
+// explicitly restricted to.
+func BlockAllowedUserIDs(block *Block) []string {
+	var userIDs []string
+	switch ids := block.Fields[CardAllowedUsersField].(type) {
+	case []string:
+		userIDs = append(userIDs, ids...)
+	case []any:
+		for _, id := range ids {
+			if userID, ok := id.(string); ok {
+				userIDs = append(userIDs, userID)
+			}
+		}
+	}
+	return userIDs
+}
+
+// CardAssigneeIDs returns the IDs of the users set on the person and
+// multi-person properties of a card block.
+func CardAssigneeIDs(card *Block, board *Board) []string {
+	schema, err := ParsePropertySchema(board)
+	if err != nil {
+		return nil
+	}
+	props, _ := card.Fields["properties"].(map[string]any)
+
+	var userIDs []string
+	for propID, value := range props {
+		propDef, ok := schema[propID]
+		if !ok {
+			continue
+		}
+		switch propDef.Type {
+		case "person":
+			if userID, ok := value.(string); ok && userID != "" {
+				userIDs = append(userIDs, userID)
+			}
+		case "multiPerson":
+			values, _ := value.([]any)
+			for _, v := range values {
+				if userID, ok := v.(string); ok && userID != "" {
+					userIDs = append(userIDs, userID)
+				}
+			}
+		}
+	}
+	return userIDs
+}
+
+// CardIsVisibleTo returns true if the card is not restricted, or if the user
+// is its creator, one of its assignees or one of its allowed users. Board
+// admins can see every card, which callers need to check on their own.
+func CardIsVisibleTo(card *Block, board *Board, userID string) bool {
+	if !BlockIsRestricted(card) {
+		return true
+	}
+	if userID == "" {
+		return false
+	}
+	if card.CreatedBy == userID {
+		return true
+	}
+	for _, id := range BlockAllowedUserIDs(card) {
+		if id == userID {
+			return true
+		}
+	}
+	for _, id := range CardAssigneeIDs(card, board) {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterHiddenCardBlocks removes the hidden cards and the blocks that belong
+// to them.
+func FilterHiddenCardBlocks(blocks []*Block, hiddenCardIDs map[string]bool) []*Block {
+	if len(hiddenCardIDs) == 0 {
+		return blocks
+	}
+
+	filtered := make([]*Block, 0, len(blocks))
+	for _, block := range blocks {
+		if hiddenCardIDs[block.ID] || hiddenCardIDs[block.ParentID] {
+			continue
+		}
+		filtered = append(filtered, block)
+	}
+	return filtered
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCardAccessIsValid(t *testing.T) {
+	t.Run("restricted card with allowed users", func(t *testing.T) {
+		access := &CardAccess{Restricted: true, AllowedUserIDs: []string{"user_id_1"}}
+		require.NoError(t, access.IsValid())
+	})
+
+	t.Run("unrestricted card with allowed users", func(t *testing.T) {
+		access := &CardAccess{AllowedUserIDs: []string{"user_id_1"}}
+		require.True(t, IsErrBadRequest(access.IsValid()))
+	})
+
+	t.Run("empty allowed user", func(t *testing.T) {
+		access := &CardAccess{Restricted: true, AllowedUserIDs: []string{""}}
+		require.True(t, IsErrBadRequest(access.IsValid()))
+	})
+
+	t.Run("too many allowed users", func(t *testing.T) {
+		access := &CardAccess{Restricted: true, AllowedUserIDs: make([]string, MaxCardAllowedUsers+1)}
+		require.True(t, IsErrBadRequest(access.IsValid()))
+	})
+}
+
+func TestCardIsVisibleTo(t *testing.T) {
+	board := &Board{
+		ID: "board_id",
+		CardProperties: []map[string]interface{}{
+			{"id": "owner", "name": "Owner", "type": "person"},
+			{"id": "assignees", "name": "Assignees", "type": "multiPerson"},
+			{"id": "notes", "name": "Notes", "type": "text"},
+		},
+	}
+	card := &Block{
+		ID:        "card_id",
+		BoardID:   board.ID,
+		Type:      TypeCard,
+		CreatedBy: "creator_id",
+		Fields: map[string]interface{}{
+			CardRestrictedField:   true,
+			CardAllowedUsersField: []interface{}{"allowed_id"},
+			"properties": map[string]interface{}{
+				"owner":     "owner_id",
+				"assignees": []interface{}{"assignee_id"},
+				"notes":     "notes_id",
+			},
+		},
+	}
+
+	testCases := []struct {
+		userID  string
+		visible bool
+	}{
+		{"creator_id", true},
+		{"allowed_id", true},
+		{"owner_id", true},
+		{"assignee_id", true},
+		{"notes_id", false},
+		{"other_id", false},
+		{"", false},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.visible, CardIsVisibleTo(card, board, tc.userID), tc.userID)
+	}
+
+	t.Run("unrestricted card", func(t *testing.T) {
+		card := &Block{ID: "card_id", Type: TypeCard, Fields: map[string]interface{}{}}
+		require.True(t, CardIsVisibleTo(card, board, "other_id"))
+	})
+}
+
+func TestCardAccessConversion(t *testing.T) {
+	card := &Card{
+		ID:             "card_id",
+		BoardID:        "board_id",
+		IsRestricted:   true,
+		AllowedUserIDs: []string{"user_id_1", "user_id_2"},
+	}
+
+	block := Card2Block(card)
+	require.True(t, BlockIsRestricted(block))
+	require.Equal(t, []string{"user_id_1", "user_id_2"}, BlockAllowedUserIDs(block))
+
+	converted, err := Block2Card(block)
+	require.NoError(t, err)
+	require.True(t, converted.IsRestricted)
+	require.Equal(t, card.AllowedUserIDs, converted.AllowedUserIDs)
+}
+
+func TestFilterHiddenCardBlocks(t *testing.T) {
+	blocks := []*Block{
+		{ID: "view_id", ParentID: "board_id"},
+		{ID: "card_id_1", ParentID: "board_id"},
+		{ID: "text_id_1", ParentID: "card_id_1"},
+		{ID: "card_id_2", ParentID: "board_id"},
+		{ID: "text_id_2", ParentID: "card_id_2"},
+	}
+
+	filtered := FilterHiddenCardBlocks(blocks, map[string]bool{"card_id_2": true})
+	require.Equal(t, blocks[:3], filtered)
+	require.Equal(t, blocks, FilterHiddenCardBlocks(blocks, nil))
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// MaxBulkCards is the maximum number of cards a single bulk operation can change.
+const MaxBulkCards = 500
+
+// BulkCardOperation is the operation applied to every card of a bulk request.
+type BulkCardOperation string
+
+const (
+	BulkCardOperationSetProperty  BulkCardOperation = "setProperty"
+	BulkCardOperationAddPerson    BulkCardOperation = "addPerson"
+	BulkCardOperationRemovePerson BulkCardOperation = "removePerson"
+	BulkCardOperationDelete       BulkCardOperation = "delete"
+	BulkCardOperationArchive      BulkCardOperation = "archive"
+	BulkCardOperationMove         BulkCardOperation = "move"
+	BulkCardOperationDuplicate    BulkCardOperation = "duplicate"
+)
+
+// BulkCardRequest applies one operation to a list of cards of a board, or
+// to the cards of the board matching a filter.
+// swagger:model
+type BulkCardRequest struct {
+	// The operation to apply. One of setProperty, addPerson, removePerson,
+	// delete, archive, move or duplicate
+	// required: true
+	Operation BulkCardOperation `json:"operation"`
+
+	// The ids of the cards to change. Either cardIds or filter is required
+	// required: false
+	CardIDs []string `json:"cardIds"`
+
+	// A filter selecting the cards to change. Either cardIds or filter is required
+	// required: false
+	Filter *BulkCardFilter `json:"filter"`
+
+	// The property to change, for the setProperty, addPerson and removePerson operations
+	// required: false
+	PropertyID string `json:"propertyId"`
+
+	// The new property value for setProperty. A null value clears the property
+	// required: false
+	Value any `json:"value"`
+
+	// The user to add or remove, for the addPerson and removePerson operations
+	// required: false
+	UserID string `json:"userId"`
+
+	// The target board and property mapping, for the move operation
+	// required: false
+	Move *MoveCardRequest `json:"move"`
+}
+
+// BulkCardFilter selects the cards of a board by property value.
+// swagger:model
+type BulkCardFilter struct {
+	// Values to match, keyed by property id. A card matches when every listed
+	// property has the value, or contains it for multi-value properties
+	// required: false
+	Properties map[string]any `json:"properties"`
+
+	// Also match archived cards
+	// required: false
+	IncludeArchived bool `json:"includeArchived"`
+}
+
+// BulkCardResult is the outcome of a bulk operation for one card.
+// swagger:model
+type BulkCardResult struct {
+	// The id of the card
+	// required: true
+	CardID string `json:"cardId"`
+
+	// True if the operation was applied to the card
+	// required: true
+	Success bool `json:"success"`
+
+	// The reason the operation was not applied
+	// required: false
+	Error string `json:"error,omitempty"`
+
+	// The id of the new card, for the duplicate operation
+	// required: false
+	NewCardID string `json:"newCardId,omitempty"`
+}
+
+func (r *BulkCardRequest) IsValid() error {
+	if r == nil {
+		return NewErrBadRequest("bulk request cannot be nil")
+	}
+
+	if len(r.CardIDs) == 0 && r.Filter == nil {
+		return NewErrBadRequest("either cardIds or filter is required")
+	}
+	if len(r.CardIDs) != 0 && r.Filter != nil {
+		return NewErrBadRequest("cardIds and filter cannot be used together")
+	}
+	if len(r.CardIDs) > MaxBulkCards {
+		return NewErrBadRequest(fmt.Sprintf("cannot change more than %d cards at once", MaxBulkCards))
+	}
+
+	switch r.Operation {
+	case BulkCardOperationSetProperty:
+		if r.PropertyID == "" {
+			return NewErrBadRequest("missing property id")
+		}
+	case BulkCardOperationAddPerson, BulkCardOperationRemovePerson:
+		if r.PropertyID == "" {
+			return NewErrBadRequest("missing property id")
+		}
+		if r.UserID == "" {
+			return NewErrBadRequest("missing user id")
+		}
+	case BulkCardOperationMove:
+		if r.Move == nil {
+			return NewErrBadRequest("missing move request")
+		}
+		return r.Move.IsValid()
+	case BulkCardOperationDelete, BulkCardOperationArchive, BulkCardOperationDuplicate:
+	default:
+		return NewErrBadRequest(fmt.Sprintf("invalid bulk operation: %s", r.Operation))
+	}
+
+	return nil
+}
+
+func BulkCardRequestFromJSON(data io.Reader) (*BulkCardRequest, error) {
+	var request BulkCardRequest
+	if err := json.NewDecoder(data).Decode(&request); err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// Matches returns true if the card block passes the filter.
+func (f *BulkCardFilter) Matches(block *Block) bool {
+	if isTemplate, _ := block.Fields["isTemplate"].(bool); isTemplate {
+		return false
+	}
+
+	if !f.IncludeArchived {
+		if archivedAt, _ := BlockArchivedAt(block); archivedAt != 0 {
+			return false
+		}
+	}
+
+	properties, _ := block.Fields["properties"].(map[string]any)
+	for propID, want := range f.Properties {
+		if !propertyValueMatches(properties[propID], want) {
+			return false
+		}
+	}
+	return true
+}
+
+func propertyValueMatches(value any, want any) bool {
+	if values, ok := value.([]any); ok {
+		for _, v := range values {
+			if reflect.DeepEqual(v, want) {
+				return true
+			}
+		}
+		return false
+	}
+	return reflect.DeepEqual(value, want)
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkCardRequestIsValid(t *testing.T) {
+	testCases := []struct {
+		name    string
+		request *BulkCardRequest
+		valid   bool
+	}{
+		{"nil request", nil, false},
+		{"no cards", &BulkCardRequest{Operation: BulkCardOperationDelete}, false},
+		{"cards and filter", &BulkCardRequest{Operation: BulkCardOperationDelete, CardIDs: []string{"c1"}, Filter: &BulkCardFilter{}}, false},
+		{"unknown operation", &BulkCardRequest{Operation: "rename", CardIDs: []string{"c1"}}, false},
+		{"set property without property", &BulkCardRequest{Operation: BulkCardOperationSetProperty, CardIDs: []string{"c1"}}, false},
+		{"add person without user", &BulkCardRequest{Operation: BulkCardOperationAddPerson, CardIDs: []string{"c1"}, PropertyID: "p1"}, false},
+		{"move without target", &BulkCardRequest{Operation: BulkCardOperationMove, CardIDs: []string{"c1"}, Move: &MoveCardRequest{}}, false},
+		{"delete", &BulkCardRequest{Operation: BulkCardOperationDelete, CardIDs: []string{"c1"}}, true},
+		{"archive by filter", &BulkCardRequest{Operation: BulkCardOperationArchive, Filter: &BulkCardFilter{}}, true},
+		{"add person", &BulkCardRequest{Operation: BulkCardOperationAddPerson, CardIDs: []string{"c1"}, PropertyID: "p1", UserID: "u1"}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.request.IsValid()
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.True(t, IsErrBadRequest(err))
+			}
+		})
+	}
+}
+
+func TestBulkCardFilterMatches(t *testing.T) {
+	card := &Block{
+		Type: TypeCard,
+		Fields: map[string]any{
+			"properties": map[string]any{
+				"status": "done",
+				"people": []any{"user1", "user2"},
+			},
+		},
+	}
+
+	filter := &BulkCardFilter{Properties: map[string]any{"status": "done", "people": "user2"}}
+	assert.True(t, filter.Matches(card))
+
+	filter = &BulkCardFilter{Properties: map[string]any{"people": "user3"}}
+	assert.False(t, filter.Matches(card))
+
+	filter = &BulkCardFilter{Properties: map[string]any{"status": []any{"done"}}}
+	assert.False(t, filter.Matches(card))
+
+	archived := &Block{Type: TypeCard, Fields: map[string]any{"archivedAt": float64(1000)}}
+	assert.False(t, (&BulkCardFilter{}).Matches(archived))
+	assert.True(t, (&BulkCardFilter{IncludeArchived: true}).Matches(archived))
+
+	template := &Block{Type: TypeCard, Fields: map[string]any{"isTemplate": true}}
+	assert.False(t, (&BulkCardFilter{}).Matches(template))
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+const (
+	CardContentAdded   = "added"
+	CardContentUpdated = "updated"
+	CardContentDeleted = "deleted"
+)
+
+// CardVersion is a version of a card, with the changes made to the card
+// and its content blocks since the previous version.
+// swagger:model
+type CardVersion struct {
+	// The update time of this version in milliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+
+	// The ids of the users who made the changes
+	// required: true
+	ModifiedBy []string `json:"modifiedBy"`
+
+	// The card as of this version
+	// required: true
+	Card *Card `json:"card"`
+
+	// The title change, if the title changed
+	// required: false
+	TitleChange *CardValueChange `json:"titleChange,omitempty"`
+
+	// The changed property values
+	// required: false
+	PropertyChanges []CardPropertyChange `json:"propertyChanges"`
+
+	// The added, updated and deleted content blocks
+	// required: false
+	ContentChanges []CardContentChange `json:"contentChanges"`
+}
+
+// CardValueChange is a change of a card value.
+// swagger:model
+type CardValueChange struct {
+	// The value before the change
+	// required: true
+	OldValue string `json:"oldValue"`
+
+	// The value after the change
+	// required: true
+	NewValue string `json:"newValue"`
+}
+
+// CardPropertyChange is a change of a card property value.
+// swagger:model
+type CardPropertyChange struct {
+	// The id of the property
+	// required: true
+	PropertyID string `json:"propertyId"`
+
+	// The name of the property
+	// required: true
+	Name string `json:"name"`
+
+	// The displayed value before the change
+	// required: true
+	OldValue string `json:"oldValue"`
+
+	// The displayed value after the change
+	// required: true
+	NewValue string `json:"newValue"`
+}
+
+// CardContentChange is a change of a content block of a card.
+// swagger:model
+type CardContentChange struct {
+	// The id of the content block
+	// required: true
+	BlockID string `json:"blockId"`
+
+	// The type of the content block
+	// required: true
+	Type BlockType `json:"type"`
+
+	// One of added, updated or deleted
+	// required: true
+	Action string `json:"action"`
+
+	// The title of the content block before the change
+	// required: false
+	OldTitle string `json:"oldTitle"`
+
+	// The title of the content block after the change
+	// required: false
+	NewTitle string `json:"newTitle"`
+}
+
+// QueryCardHistoryOptions are query options for the versions of a card.
+type QueryCardHistoryOptions struct {
+	BeforeUpdateAt int64 // if non-zero then only versions older than BeforeUpdateAt are returned
+	PerPage        int   // number of versions to return
+}
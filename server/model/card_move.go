@@ -0,0 +1,224 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+)
+
+// MoveCardRequest describes how a card is moved to another board. Properties
+// are matched by name, and select options by value. Explicit mappings take
+// precedence, and values that can't be mapped are dropped.
+// swagger:model
+type MoveCardRequest struct {
+	// The id of the board the card is moved to
+	// required: true
+	TargetBoardID string `json:"targetBoardId"`
+
+	// Maps source property names to target property names. Properties not
+	// listed are matched by their own name. An empty target name drops the property
+	// required: false
+	PropertyMapping map[string]string `json:"propertyMapping"`
+
+	// Maps, per source property name, source option values to target option values.
+	// Options not listed are matched by their own value
+	// required: false
+	OptionMapping map[string]map[string]string `json:"optionMapping"`
+
+	// Default values, keyed by target property name, for target properties that
+	// receive no value from the card. Select options are given by value
+	// required: false
+	Defaults map[string]any `json:"defaults"`
+}
+
+func (r *MoveCardRequest) IsValid() error {
+	if r == nil {
+		return NewErrBadRequest("move request cannot be nil")
+	}
+	if r.TargetBoardID == "" {
+		return NewErrBadRequest("missing target board id")
+	}
+	return nil
+}
+
+func MoveCardRequestFromJSON(data io.Reader) (*MoveCardRequest, error) {
+	var request MoveCardRequest
+	if err := json.NewDecoder(data).Decode(&request); err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// RemapCardProperties translates a card's property values from the source
+// board's property schema to the target board's schema.
+func RemapCardProperties(properties map[string]any, source, target PropSchema, request *MoveCardRequest) map[string]any {
+	remapped := make(map[string]any)
+
+	for propID, value := range properties {
+		srcDef, ok := source[propID]
+		if !ok {
+			continue
+		}
+
+		targetName := srcDef.Name
+		if mapped, ok := request.PropertyMapping[srcDef.Name]; ok {
+			targetName = mapped
+		}
+		if targetName == "" {
+			continue
+		}
+
+		tgtDef, ok := target.findByName(targetName)
+		if !ok {
+			continue
+		}
+
+		if newValue, ok := remapPropertyValue(value, srcDef, tgtDef, request.OptionMapping[srcDef.Name]); ok {
+			remapped[tgtDef.ID] = newValue
+		}
+	}
+
+	for name, value := range request.Defaults {
+		tgtDef, ok := target.findByName(name)
+		if !ok {
+			continue
+		}
+		if _, ok := remapped[tgtDef.ID]; ok {
+			continue
+		}
+		if newValue, ok := defaultPropertyValue(value, tgtDef); ok {
+			remapped[tgtDef.ID] = newValue
+		}
+	}
+
+	return remapped
+}
+
+// findByName returns the first property definition, in board order, with
+// the given name.
+func (ps PropSchema) findByName(name string) (PropDef, bool) {
+	defs := make([]PropDef, 0, len(ps))
+	for _, def := range ps {
+		if strings.EqualFold(def.Name, name) {
+			defs = append(defs, def)
+		}
+	}
+	if len(defs) == 0 {
+		return PropDef{}, false
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Index < defs[j].Index })
+	return defs[0], true
+}
+
+// findOptionByValue returns the id of the option with the given value.
+func (pd PropDef) findOptionByValue(value string) (string, bool) {
+	for _, opt := range pd.Options {
+		if strings.EqualFold(opt.Value, value) {
+			return opt.ID, true
+		}
+	}
+	return "", false
+}
+
+func remapPropertyValue(value any, srcDef, tgtDef PropDef, optionMapping map[string]string) (any, bool) {
+	isSelect := func(t string) bool { return t == "select" || t == "multiSelect" }
+
+	if !isSelect(srcDef.Type) || !isSelect(tgtDef.Type) {
+		if srcDef.Type != tgtDef.Type {
+			return nil, false
+		}
+		return value, true
+	}
+
+	var srcOptionIDs []string
+	switch v := value.(type) {
+	case string:
+		srcOptionIDs = append(srcOptionIDs, v)
+	case []any:
+		for _, id := range v {
+			if s, ok := id.(string); ok {
+				srcOptionIDs = append(srcOptionIDs, s)
+			}
+		}
+	case []string:
+		srcOptionIDs = append(srcOptionIDs, v...)
+	}
+
+	tgtOptionIDs := make([]any, 0, len(srcOptionIDs))
+	for _, srcOptionID := range srcOptionIDs {
+		srcOption, ok := srcDef.Options[srcOptionID]
+		if !ok {
+			continue
+		}
+		optionValue := srcOption.Value
+		if mapped, ok := optionMapping[optionValue]; ok {
+			optionValue = mapped
+		}
+		if tgtOptionID, ok := tgtDef.findOptionByValue(optionValue); ok {
+			tgtOptionIDs = append(tgtOptionIDs, tgtOptionID)
+		}
+	}
+
+	if len(tgtOptionIDs) == 0 {
+		return nil, false
+	}
+	if tgtDef.Type == "select" {
+		return tgtOptionIDs[0], true
+	}
+	return tgtOptionIDs, true
+}
+
+func defaultPropertyValue(value any, tgtDef PropDef) (any, bool) {
+	switch tgtDef.Type {
+	case "select":
+		optionValue, ok := value.(string)
+		if !ok {
+			return nil, false
+		}
+		return tgtDef.findOptionByValue(optionValue)
+	case "multiSelect":
+		var optionValues []any
+		switch v := value.(type) {
+		case string:
+			optionValues = []any{v}
+		case []any:
+			optionValues = v
+		}
+		optionIDs := make([]any, 0, len(optionValues))
+		for _, optionValue := range optionValues {
+			s, ok := optionValue.(string)
+			if !ok {
+				continue
+			}
+			if optionID, ok := tgtDef.findOptionByValue(s); ok {
+				optionIDs = append(optionIDs, optionID)
+			}
+		}
+		return optionIDs, len(optionIDs) != 0
+	}
+	return value, true
+}
+
+// FilterContentOrder removes the ids that are not in `ids` from a card's
+// content order. Content order entries may be ids or arrays of ids.
+func FilterContentOrder(contentOrder []any, ids map[string]bool) []any {
+	filtered := make([]any, 0, len(contentOrder))
+	for _, entry := range contentOrder {
+		switch v := entry.(type) {
+		case string:
+			if ids[v] {
+				filtered = append(filtered, v)
+			}
+		case []any:
+			row := FilterContentOrder(v, ids)
+			if len(row) != 0 {
+				filtered = append(filtered, row)
+			}
+		}
+	}
+	return filtered
+}
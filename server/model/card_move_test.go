@@ -0,0 +1,102 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemapCardProperties(t *testing.T) {
+	source := PropSchema{
+		"src-status": {
+			ID:   "src-status",
+			Name: "Status",
+			Type: "select",
+			Options: map[string]PropDefOption{
+				"src-todo": {ID: "src-todo", Value: "To Do"},
+				"src-done": {ID: "src-done", Value: "Done"},
+			},
+		},
+		"src-tags": {
+			ID:   "src-tags",
+			Name: "Tags",
+			Type: "multiSelect",
+			Options: map[string]PropDefOption{
+				"src-a": {ID: "src-a", Value: "A"},
+				"src-b": {ID: "src-b", Value: "B"},
+			},
+		},
+		"src-estimate": {ID: "src-estimate", Name: "Estimate", Type: "number"},
+		"src-notes":    {ID: "src-notes", Name: "Notes", Type: "text"},
+	}
+
+	target := PropSchema{
+		"tgt-state": {
+			ID:   "tgt-state",
+			Name: "state",
+			Type: "select",
+			Options: map[string]PropDefOption{
+				"tgt-open":   {ID: "tgt-open", Value: "Open"},
+				"tgt-closed": {ID: "tgt-closed", Value: "Closed"},
+			},
+		},
+		"tgt-tags": {
+			ID:   "tgt-tags",
+			Name: "tags",
+			Type: "multiSelect",
+			Options: map[string]PropDefOption{
+				"tgt-a": {ID: "tgt-a", Value: "a"},
+			},
+		},
+		"tgt-estimate": {ID: "tgt-estimate", Name: "Estimate", Type: "text"},
+		"tgt-owner":    {ID: "tgt-owner", Name: "Owner", Type: "text"},
+	}
+
+	properties := map[string]any{
+		"src-status":   "src-done",
+		"src-tags":     []any{"src-a", "src-b"},
+		"src-estimate": "3",
+		"src-notes":    "some notes",
+		"unknown":      "value",
+	}
+
+	t.Run("match by name only", func(t *testing.T) {
+		remapped := RemapCardProperties(properties, source, target, &MoveCardRequest{})
+		assert.Equal(t, map[string]any{
+			"tgt-tags": []any{"tgt-a"},
+		}, remapped)
+	})
+
+	t.Run("explicit mappings and defaults", func(t *testing.T) {
+		request := &MoveCardRequest{
+			PropertyMapping: map[string]string{
+				"Status": "State",
+				"Tags":   "",
+			},
+			OptionMapping: map[string]map[string]string{
+				"Status": {"Done": "Closed"},
+			},
+			Defaults: map[string]any{
+				"State": "Open",
+				"Owner": "nobody",
+			},
+		}
+
+		remapped := RemapCardProperties(properties, source, target, request)
+		assert.Equal(t, map[string]any{
+			"tgt-state": "tgt-closed",
+			"tgt-owner": "nobody",
+		}, remapped)
+	})
+}
+
+func TestFilterContentOrder(t *testing.T) {
+	contentOrder := []any{"a", []any{"b", "x"}, "y", []any{"z"}}
+	ids := map[string]bool{"a": true, "b": true}
+
+	assert.Equal(t, []any{"a", []any{"b"}}, FilterContentOrder(contentOrder, ids))
+	assert.Empty(t, FilterContentOrder(nil, ids))
+}
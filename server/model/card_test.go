@@ -45,6 +45,25 @@ func TestBlock2Card(t *testing.T) {
 		assert.EqualValues(t, fields["properties"], card.Properties)
 	})
 
+	t.Run("Archived card", func(t *testing.T) {
+		archived := *block
+		archived.Fields = map[string]any{"archivedAt": float64(now)}
+
+		card, err := Block2Card(&archived)
+		require.NoError(t, err)
+		assert.Equal(t, now, card.ArchivedAt)
+		assert.Equal(t, now, Card2Block(card).Fields["archivedAt"])
+	})
+
+	t.Run("Invalid archivedAt", func(t *testing.T) {
+		invalid := *block
+		invalid.Fields = map[string]any{"archivedAt": "yesterday"}
+
+		card, err := Block2Card(&invalid)
+		require.Error(t, err)
+		require.Nil(t, card)
+	})
+
 	t.Run("Not a card", func(t *testing.T) {
 		blockNotCard := &Block{}
 
@@ -26,4 +26,16 @@ type ClientConfig struct {
 	// Required for file upload to check the size of the file
 	// required: true
 	MaxFileSize int64 `json:"maxFileSize"`
+
+	// Whether users need to activate multi-factor authentication
+	// required: true
+	RequireMfa bool `json:"requireMfa"`
+
+	// Whether users can log in with OpenID Connect single sign-on
+	// required: true
+	EnableOidc bool `json:"enableOidc"`
+
+	// The name of the OpenID Connect provider shown on the login page
+	// required: false
+	OidcDisplayName string `json:"oidcDisplayName,omitempty"`
 }
@@ -0,0 +1,164 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+
+	mmModel "github.com/mattermost/mattermost/server/public/model"
+)
+
+const (
+	// MaxCustomBoardRoleNameLength is the maximum length of a custom role name.
+	MaxCustomBoardRoleNameLength = 64
+
+	// MaxCustomBoardRoleDescriptionLength is the maximum length of a custom
+	// role description.
+	MaxCustomBoardRoleDescriptionLength = 1024
+)
+
+// CustomBoardRole is a named set of board permissions defined by the admins
+// of a team. Board members that are assigned a custom role get its
+// permissions on top of the ones of their scheme role. The permissions apply
+// to the whole board: a role can't be limited to the cards the member
+// created.
+// swagger:model
+type CustomBoardRole struct {
+	// The id of the role
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the team the role belongs to
+	// required: true
+	TeamID string `json:"teamId"`
+
+	// The name of the role
+	// required: true
+	Name string `json:"name"`
+
+	// The description of the role
+	// required: false
+	Description string `json:"description"`
+
+	// The ids of the board permissions granted by the role
+	// required: true
+	Permissions []string `json:"permissions"`
+
+	// The id of the user who created the role
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last modified time in milliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+// CustomBoardRolePatch is a patch for modifying a custom board role.
+// swagger:model
+type CustomBoardRolePatch struct {
+	// The name of the role
+	// required: false
+	Name *string `json:"name"`
+
+	// The description of the role
+	// required: false
+	Description *string `json:"description"`
+
+	// The ids of the board permissions granted by the role
+	// required: false
+	Permissions []string `json:"permissions"`
+}
+
+func (r *CustomBoardRole) IsValid() error {
+	if r == nil {
+		return NewErrBadRequest("custom board role cannot be nil")
+	}
+	if r.TeamID == "" {
+		return NewErrBadRequest("missing team id")
+	}
+	if r.Name == "" {
+		return NewErrBadRequest("missing role name")
+	}
+	if len(r.Name) > MaxCustomBoardRoleNameLength {
+		return NewErrBadRequest("role name is too long")
+	}
+	if len(r.Description) > MaxCustomBoardRoleDescriptionLength {
+		return NewErrBadRequest("role description is too long")
+	}
+	if len(r.Permissions) == 0 {
+		return NewErrBadRequest("a role needs at least one permission")
+	}
+	for _, id := range r.Permissions {
+		permission := BoardPermissionByID(id)
+		if permission == nil {
+			return NewErrBadRequest("invalid board permission: " + id)
+		}
+		if !IsCustomBoardRolePermission(permission) {
+			return NewErrBadRequest("board permission reserved to the board admins: " + id)
+		}
+	}
+	return nil
+}
+
+// IsCustomBoardRolePermission returns true if custom board roles can grant
+// the permission. The permissions to change who can access a board and to
+// delete it are reserved to the board admins, as granting them would make a
+// custom role as powerful as the admin role.
+func IsCustomBoardRolePermission(permission *mmModel.Permission) bool {
+	switch permission {
+	case PermissionManageBoardRoles, PermissionManageBoardType, PermissionDeleteBoard:
+		return false
+	default:
+		return BoardPermissionByID(permission.Id) != nil
+	}
+}
+
+// HasPermission returns true if the role grants the permission. Reserved
+// permissions are never granted, even if the role was saved with them.
+func (r *CustomBoardRole) HasPermission(permission *mmModel.Permission) bool {
+	if permission == nil || !IsCustomBoardRolePermission(permission) {
+		return false
+	}
+	for _, id := range r.Permissions {
+		if id == permission.Id {
+			return true
+		}
+	}
+	return false
+}
+
+// Patch returns an updated version of the role.
+func (p *CustomBoardRolePatch) Patch(role *CustomBoardRole) *CustomBoardRole {
+	if p.Name != nil {
+		role.Name = *p.Name
+	}
+	if p.Description != nil {
+		role.Description = *p.Description
+	}
+	if p.Permissions != nil {
+		role.Permissions = p.Permissions
+	}
+	return role
+}
+
+func CustomBoardRoleFromJSON(data io.Reader) (*CustomBoardRole, error) {
+	var role CustomBoardRole
+	if err := json.NewDecoder(data).Decode(&role); err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+func CustomBoardRolePatchFromJSON(data io.Reader) (*CustomBoardRolePatch, error) {
+	var patch CustomBoardRolePatch
+	if err := json.NewDecoder(data).Decode(&patch); err != nil {
+		return nil, err
+	}
+	return &patch, nil
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomBoardRoleIsValid(t *testing.T) {
+	newRole := func() *CustomBoardRole {
+		return &CustomBoardRole{
+			TeamID:      "team_id",
+			Name:        "card editor",
+			Permissions: []string{PermissionViewBoard.Id, PermissionManageBoardCards.Id},
+		}
+	}
+
+	t.Run("valid role", func(t *testing.T) {
+		require.NoError(t, newRole().IsValid())
+	})
+
+	t.Run("missing team", func(t *testing.T) {
+		role := newRole()
+		role.TeamID = ""
+		require.True(t, IsErrBadRequest(role.IsValid()))
+	})
+
+	t.Run("missing name", func(t *testing.T) {
+		role := newRole()
+		role.Name = ""
+		require.True(t, IsErrBadRequest(role.IsValid()))
+	})
+
+	t.Run("name too long", func(t *testing.T) {
+		role := newRole()
+		role.Name = strings.Repeat("a", MaxCustomBoardRoleNameLength+1)
+		require.True(t, IsErrBadRequest(role.IsValid()))
+	})
+
+	t.Run("no permissions", func(t *testing.T) {
+		role := newRole()
+		role.Permissions = nil
+		require.True(t, IsErrBadRequest(role.IsValid()))
+	})
+
+	t.Run("not a board permission", func(t *testing.T) {
+		role := newRole()
+		role.Permissions = []string{PermissionManageTeam.Id}
+		require.True(t, IsErrBadRequest(role.IsValid()))
+	})
+
+	t.Run("permission reserved to the board admins", func(t *testing.T) {
+		for _, permission := range []string{PermissionManageBoardRoles.Id, PermissionManageBoardType.Id, PermissionDeleteBoard.Id} {
+			role := newRole()
+			role.Permissions = append(role.Permissions, permission)
+			require.True(t, IsErrBadRequest(role.IsValid()), permission)
+		}
+	})
+}
+
+func TestCustomBoardRoleHasPermission(t *testing.T) {
+	role := &CustomBoardRole{Permissions: []string{PermissionCommentBoardCards.Id}}
+
+	require.True(t, role.HasPermission(PermissionCommentBoardCards))
+	require.False(t, role.HasPermission(PermissionManageBoardCards))
+	require.False(t, role.HasPermission(nil))
+
+	// reserved permissions saved before they were reserved are not granted
+	role = &CustomBoardRole{Permissions: []string{PermissionManageBoardRoles.Id}}
+	require.False(t, role.HasPermission(PermissionManageBoardRoles))
+}
+
+func TestCustomBoardRolePatch(t *testing.T) {
+	name := "new name"
+	role := &CustomBoardRole{
+		Name:        "name",
+		Description: "description",
+		Permissions: []string{PermissionViewBoard.Id},
+	}
+
+	patched := (&CustomBoardRolePatch{Name: &name}).Patch(role)
+	require.Equal(t, "new name", patched.Name)
+	require.Equal(t, "description", patched.Description)
+	require.Equal(t, []string{PermissionViewBoard.Id}, patched.Permissions)
+
+	patched = (&CustomBoardRolePatch{Permissions: []string{PermissionShareBoard.Id}}).Patch(role)
+	require.Equal(t, []string{PermissionShareBoard.Id}, patched.Permissions)
+}
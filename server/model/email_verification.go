@@ -0,0 +1,68 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/mattermost/focalboard/server/services/auth"
+)
+
+// EmailVerificationExpiry is how long an email verification link can be
+// used for.
+const EmailVerificationExpiry = 24 * time.Hour
+
+// EmailVerificationToken is a single-use token that verifies the email
+// address of a user. Only the hash of the token is stored.
+type EmailVerificationToken struct {
+	ID        string `json:"id"`
+	UserID    string `json:"userId"`
+	Email     string `json:"email"`
+	TokenHash string `json:"-"`
+	ExpireAt  int64  `json:"expireAt"`
+	CreateAt  int64  `json:"createAt"`
+}
+
+// IsExpired returns true if the token expiry time is past.
+func (t *EmailVerificationToken) IsExpired(now int64) bool {
+	return t.ExpireAt <= now
+}
+
+// SendEmailVerificationRequest is a request for a new email verification
+// email
+// swagger:model
+type SendEmailVerificationRequest struct {
+	// The email address of the account
+	// required: true
+	Email string `json:"email"`
+}
+
+// IsValid validates an email verification email request.
+func (rd *SendEmailVerificationRequest) IsValid() error {
+	if strings.TrimSpace(rd.Email) == "" {
+		return NewErrAuthParam("email is required")
+	}
+	if !auth.IsEmailValid(rd.Email) {
+		return NewErrAuthParam("invalid email format")
+	}
+	return nil
+}
+
+// VerifyEmailRequest verifies an email address with the token of an email
+// verification email
+// swagger:model
+type VerifyEmailRequest struct {
+	// The token of the email verification email
+	// required: true
+	Token string `json:"token"`
+}
+
+// IsValid validates an email verification request.
+func (rd *VerifyEmailRequest) IsValid() error {
+	if rd.Token == "" {
+		return NewErrAuthParam("token is required")
+	}
+	return nil
+}
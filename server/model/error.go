@@ -26,6 +26,9 @@ var (
 
 	ErrBoardMemberIsLastAdmin = errors.New("cannot leave a board with no admins")
 
+	ErrBoardIsLocked = errors.New("board is locked")
+	ErrCardIsLocked  = errors.New("card is locked")
+
 	ErrRequestEntityTooLarge = errors.New("request entity too large")
 
 	ErrInvalidBoardSearchField = errors.New("invalid board search field")
@@ -135,6 +138,23 @@ func (br *ErrForbidden) Error() string {
 	return br.reason
 }
 
+// ErrConflict can be returned when a resource conflicts with an existing
+// one, like a username already taken.
+type ErrConflict struct {
+	reason string
+}
+
+// NewErrConflict creates a new ErrConflict instance.
+func NewErrConflict(reason string) *ErrConflict {
+	return &ErrConflict{
+		reason: reason,
+	}
+}
+
+func (c *ErrConflict) Error() string {
+	return c.reason
+}
+
 // ErrInternalServer can be returned when an internal server error occurs.
 type ErrInternalServer struct {
 	reason string
@@ -151,6 +171,24 @@ func (is *ErrInternalServer) Error() string {
 	return is.reason
 }
 
+// ErrTooManyRequests is returned when a client has to wait before trying
+// again, RetryAfter is the wait in seconds.
+type ErrTooManyRequests struct {
+	reason     string
+	RetryAfter int64
+}
+
+func NewErrTooManyRequests(reason string, retryAfter int64) *ErrTooManyRequests {
+	return &ErrTooManyRequests{
+		reason:     reason,
+		RetryAfter: retryAfter,
+	}
+}
+
+func (tmr *ErrTooManyRequests) Error() string {
+	return tmr.reason
+}
+
 type ErrInvalidCategory struct {
 	msg string
 }
@@ -251,6 +289,8 @@ func IsErrUnauthorized(err error) bool {
 // - model.ErrForbidden
 // - model.ErrPermission
 // - model.ErrPatchUpdatesLimitedCards
+// - model.ErrBoardIsLocked
+// - model.ErrCardIsLocked
 // - model.ErrorCategoryPermissionDenied.
 func IsErrForbidden(err error) bool {
 	if err == nil {
@@ -274,6 +314,11 @@ func IsErrForbidden(err error) bool {
 		return true
 	}
 
+	// check if this is a model.ErrBoardIsLocked or model.ErrCardIsLocked
+	if errors.Is(err, ErrBoardIsLocked) || errors.Is(err, ErrCardIsLocked) {
+		return true
+	}
+
 	// check if this is a model.ErrCategoryPermissionDenied
 	return errors.Is(err, ErrCategoryPermissionDenied)
 }
@@ -323,6 +368,16 @@ func IsErrNotFound(err error) bool {
 	return errors.Is(err, ErrCategoryDeleted)
 }
 
+// IsErrConflict returns true if `err` is or wraps a model.ErrConflict.
+func IsErrConflict(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var c *ErrConflict
+	return errors.As(err, &c)
+}
+
 // IsErrRequestEntityTooLarge returns true if `err` is or wraps one of:
 // - model.ErrRequestEntityTooLarge.
 func IsErrRequestEntityTooLarge(err error) bool {
@@ -330,6 +385,17 @@ func IsErrRequestEntityTooLarge(err error) bool {
 	return errors.Is(err, ErrRequestEntityTooLarge)
 }
 
+// IsErrTooManyRequests returns true if `err` is or wraps a
+// model.ErrTooManyRequests.
+func IsErrTooManyRequests(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var tmr *ErrTooManyRequests
+	return errors.As(err, &tmr)
+}
+
 // IsErrNotImplemented returns true if `err` is or wraps one of:
 // - model.ErrNotImplemented
 // - model.ErrInsufficientLicense.
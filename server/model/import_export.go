@@ -38,6 +38,47 @@ type ExportArchiveOptions struct {
 	// BoardIDs is the list of boards to include in the archive.
 	// Empty slice means export all boards from workspace/team.
 	BoardIDs []string
+
+	// ArchivedCards selects which cards to include based on their archived
+	// state. Empty means all cards are included.
+	ArchivedCards ArchivedCardsFilter
+
+	// UserID is the user the archive is exported for. The restricted cards
+	// the user can't see are left out. Empty means all cards are included.
+	UserID string
+}
+
+// ArchivedCardsFilter selects cards by archived state when exporting.
+type ArchivedCardsFilter string
+
+const (
+	ArchivedCardsInclude ArchivedCardsFilter = "include"
+	ArchivedCardsExclude ArchivedCardsFilter = "exclude"
+	ArchivedCardsOnly    ArchivedCardsFilter = "only"
+)
+
+// ArchivedCardsFilterFromString parses an archived cards filter, defaulting
+// to ArchivedCardsInclude for an empty string.
+func ArchivedCardsFilterFromString(s string) (ArchivedCardsFilter, error) {
+	switch filter := ArchivedCardsFilter(s); filter {
+	case "":
+		return ArchivedCardsInclude, nil
+	case ArchivedCardsInclude, ArchivedCardsExclude, ArchivedCardsOnly:
+		return filter, nil
+	}
+	return "", NewErrBadRequest(fmt.Sprintf("invalid archived cards filter: %s", s))
+}
+
+// Matches returns true if a card with the given archived state passes the filter.
+func (f ArchivedCardsFilter) Matches(archived bool) bool {
+	switch f {
+	case ArchivedCardsExclude:
+		return !archived
+	case ArchivedCardsOnly:
+		return archived
+	default:
+		return true
+	}
 }
 
 // ImportArchiveOptions provides options when importing an archive.
@@ -0,0 +1,50 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import "strings"
+
+const (
+	loginAttemptsAccountPrefix   = "account:"
+	loginAttemptsUserPrefix      = "user:"
+	loginAttemptsIPPrefix        = "ip:"
+	loginAttemptsShareLinkPrefix = "sharelink:"
+)
+
+// LoginAttempts counts the recent failed logins of an account or of an IP
+// address.
+type LoginAttempts struct {
+	// The account or IP address key of the counter
+	Key string `json:"key"`
+
+	// The number of failed logins since the counter was reset
+	FailedCount int `json:"failedCount"`
+
+	// The time of the last failed login in miliseconds since the current epoch
+	LastFailureAt int64 `json:"lastFailureAt"`
+}
+
+// LoginAttemptsAccountKey returns the counter key of a username or email
+// address used to log in that doesn't match any user. Such counters behave
+// like the ones of the users, so they don't tell which accounts exist.
+func LoginAttemptsAccountKey(login string) string {
+	return loginAttemptsAccountPrefix + strings.ToLower(strings.TrimSpace(login))
+}
+
+// LoginAttemptsUserKey returns the counter key of a user, shared by the
+// logins with its username and with its email address.
+func LoginAttemptsUserKey(userID string) string {
+	return loginAttemptsUserPrefix + userID
+}
+
+// LoginAttemptsIPKey returns the counter key of an IP address.
+func LoginAttemptsIPKey(ipAddress string) string {
+	return loginAttemptsIPPrefix + ipAddress
+}
+
+// LoginAttemptsShareLinkKey returns the counter key of the wrong passwords
+// of a share link.
+func LoginAttemptsShareLinkKey(linkID string) string {
+	return loginAttemptsShareLinkPrefix + linkID
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// MfaSecret is a newly generated TOTP secret, that needs to be verified
+// before multi-factor authentication is activated.
+// swagger:model
+type MfaSecret struct {
+	// The base32 encoded secret
+	// required: true
+	Secret string `json:"secret"`
+
+	// The otpauth URI of the secret, to be shown as a QR code
+	// required: true
+	ProvisioningURI string `json:"provisioningUri"`
+}
+
+// MfaCodeRequest contains a TOTP code or a recovery code.
+// swagger:model
+type MfaCodeRequest struct {
+	// The code
+	// required: true
+	Code string `json:"code"`
+}
+
+// MfaRecoveryCodes are the single use codes that can be used instead of a
+// TOTP code. They are only returned when they are generated.
+// swagger:model
+type MfaRecoveryCodes struct {
+	// The recovery codes
+	// required: true
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+
+	"github.com/mattermost/focalboard/server/services/auth"
+)
+
+// PasswordResetToken is a single-use token that lets a user choose a new
+// password. Only the hash of the token is stored.
+type PasswordResetToken struct {
+	ID        string `json:"id"`
+	UserID    string `json:"userId"`
+	TokenHash string `json:"-"`
+	ExpireAt  int64  `json:"expireAt"`
+	CreateAt  int64  `json:"createAt"`
+}
+
+// IsExpired returns true if the token expiry time is past.
+func (t *PasswordResetToken) IsExpired(now int64) bool {
+	return t.ExpireAt <= now
+}
+
+// SendPasswordResetRequest is a request for a password reset email
+// swagger:model
+type SendPasswordResetRequest struct {
+	// The email address of the account
+	// required: true
+	Email string `json:"email"`
+}
+
+// IsValid validates a password reset email request.
+func (rd *SendPasswordResetRequest) IsValid() error {
+	if strings.TrimSpace(rd.Email) == "" {
+		return NewErrAuthParam("email is required")
+	}
+	if !auth.IsEmailValid(rd.Email) {
+		return NewErrAuthParam("invalid email format")
+	}
+	return nil
+}
+
+// ResetPasswordRequest sets a new password with a password reset token
+// swagger:model
+type ResetPasswordRequest struct {
+	// The token of the password reset email
+	// required: true
+	Token string `json:"token"`
+
+	// New password
+	// required: true
+	NewPassword string `json:"newPassword"`
+}
+
+// IsValid validates a password reset request. The password policy is
+// checked when the password is set.
+func (rd *ResetPasswordRequest) IsValid() error {
+	if rd.Token == "" {
+		return NewErrAuthParam("token is required")
+	}
+	if rd.NewPassword == "" {
+		return NewErrAuthParam("new password is required")
+	}
+	return nil
+}
@@ -22,4 +22,33 @@ var (
 	PermissionManageBoardProperties = &mmModel.Permission{Id: "manage_board_properties", Name: "", Description: "", Scope: ""}
 	PermissionCommentBoardCards     = &mmModel.Permission{Id: "comment_board_cards", Name: "", Description: "", Scope: ""}
 	PermissionDeleteOthersComments  = &mmModel.Permission{Id: "delete_others_comments", Name: "", Description: "", Scope: ""}
+	PermissionLockBoard             = &mmModel.Permission{Id: "lock_board", Name: "", Description: "", Scope: ""}
+	PermissionViewRestrictedCards   = &mmModel.Permission{Id: "view_restricted_cards", Name: "", Description: "", Scope: ""}
 )
+
+// BoardPermissions are the permissions that can be granted on a board, and
+// therefore the permissions that custom board roles can be made of.
+var BoardPermissions = []*mmModel.Permission{
+	PermissionManageBoardType,
+	PermissionDeleteBoard,
+	PermissionViewBoard,
+	PermissionManageBoardRoles,
+	PermissionShareBoard,
+	PermissionManageBoardCards,
+	PermissionManageBoardProperties,
+	PermissionCommentBoardCards,
+	PermissionDeleteOthersComments,
+	PermissionLockBoard,
+	PermissionViewRestrictedCards,
+}
+
+// BoardPermissionByID returns the board permission with the specified id,
+// or nil if there is none.
+func BoardPermissionByID(id string) *mmModel.Permission {
+	for _, permission := range BoardPermissions {
+		if permission.Id == id {
+			return permission
+		}
+	}
+	return nil
+}
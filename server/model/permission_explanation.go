@@ -0,0 +1,106 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	mmModel "github.com/mattermost/mattermost/server/public/model"
+)
+
+type BoardPermissionRule string
+
+const (
+	// BoardPermissionRuleInvalidInput denies the permission when the user,
+	// board or permission is missing.
+	BoardPermissionRuleInvalidInput BoardPermissionRule = "invalidInput"
+
+	// BoardPermissionRuleError denies the permission when it couldn't be
+	// resolved because of an internal error.
+	BoardPermissionRuleError BoardPermissionRule = "error"
+
+	// BoardPermissionRuleBoardNotFound denies the permission on boards that
+	// don't exist.
+	BoardPermissionRuleBoardNotFound BoardPermissionRule = "boardNotFound"
+
+	// BoardPermissionRuleNoTeamAccess denies the permission to the users
+	// that can't see the team of the board.
+	BoardPermissionRuleNoTeamAccess BoardPermissionRule = "noTeamAccess"
+
+	// BoardPermissionRuleNotMember denies the permission to the users that
+	// are not members of the board, explicitly or synthetically.
+	BoardPermissionRuleNotMember BoardPermissionRule = "notMember"
+
+	// BoardPermissionRuleTeamAdmin grants every permission to the team admins.
+	BoardPermissionRuleTeamAdmin BoardPermissionRule = "teamAdmin"
+
+	// BoardPermissionRuleMemberRole grants the permission through the role of
+	// the member row of the user.
+	BoardPermissionRuleMemberRole BoardPermissionRule = "memberRole"
+
+	// BoardPermissionRuleSyntheticMember grants the permission through the
+	// role of a synthetic membership, like the one given by the membership
+	// of the channel linked to the board.
+	BoardPermissionRuleSyntheticMember BoardPermissionRule = "syntheticMember"
+
+	// BoardPermissionRuleMinimumRole grants the permission through the
+	// minimum role of the board.
+	BoardPermissionRuleMinimumRole BoardPermissionRule = "minimumRole"
+
+	// BoardPermissionRuleCustomRole grants the permission through the custom
+	// board role of the member.
+	BoardPermissionRuleCustomRole BoardPermissionRule = "customRole"
+
+	// BoardPermissionRuleInsufficientRole denies the permission to the
+	// members whose roles don't include it.
+	BoardPermissionRuleInsufficientRole BoardPermissionRule = "insufficientRole"
+)
+
+// BoardPermissionExplanation tells whether a user has a board permission and
+// the rule that granted or denied it.
+// swagger:model
+type BoardPermissionExplanation struct {
+	// The id of the permission
+	// required: true
+	Permission string `json:"permission"`
+
+	// Whether the user has the permission
+	// required: true
+	Granted bool `json:"granted"`
+
+	// The rule that granted or denied the permission
+	// required: true
+	Rule BoardPermissionRule `json:"rule"`
+
+	// The role the rule is about: admin, editor, commenter or viewer, or
+	// the id of a custom board role
+	// required: false
+	Role string `json:"role,omitempty"`
+}
+
+// BoardPermissionsExplanation explains every board permission of a user.
+// swagger:model
+type BoardPermissionsExplanation struct {
+	// The id of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The id of the user
+	// required: true
+	UserID string `json:"userId"`
+
+	// The explanation of each board permission
+	// required: true
+	Permissions []*BoardPermissionExplanation `json:"permissions"`
+}
+
+func NewBoardPermissionExplanation(permission *mmModel.Permission, granted bool, rule BoardPermissionRule, role string) *BoardPermissionExplanation {
+	explanation := &BoardPermissionExplanation{
+		Granted: granted,
+		Rule:    rule,
+		Role:    role,
+	}
+	if permission != nil {
+		explanation.Permission = permission.Id
+	}
+	return explanation
+}
@@ -0,0 +1,293 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type RecurrenceFrequency string
+
+const (
+	RecurrenceDaily   RecurrenceFrequency = "DAILY"
+	RecurrenceWeekly  RecurrenceFrequency = "WEEKLY"
+	RecurrenceMonthly RecurrenceFrequency = "MONTHLY"
+
+	recurrenceRulePrefix  = "RRULE:"
+	maxRecurrenceMonthDay = 31
+)
+
+var recurrenceWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+type ErrInvalidRecurrence struct {
+	msg string
+}
+
+func NewErrInvalidRecurrence(msg string) ErrInvalidRecurrence {
+	return ErrInvalidRecurrence{msg: msg}
+}
+
+func (e ErrInvalidRecurrence) Error() string {
+	return fmt.Sprintf("invalid recurrence, %s", e.msg)
+}
+
+// RecurrenceRule is the supported subset of an iCalendar RRULE: daily,
+// weekly on a set of weekdays and monthly on a given day of the month.
+type RecurrenceRule struct {
+	Frequency  RecurrenceFrequency
+	Interval   int
+	ByDay      []time.Weekday
+	ByMonthDay int
+}
+
+// ParseRecurrenceRule parses a rule such as `FREQ=WEEKLY;BYDAY=MO,WE,FR`.
+func ParseRecurrenceRule(s string) (*RecurrenceRule, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), recurrenceRulePrefix)
+	if s == "" {
+		return nil, NewErrInvalidRecurrence("empty rule")
+	}
+
+	rule := &RecurrenceRule{Interval: 1}
+	for _, part := range strings.Split(s, ";") {
+		if part == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, NewErrInvalidRecurrence("malformed rule part " + part)
+		}
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.Frequency = RecurrenceFrequency(strings.ToUpper(value))
+		case "INTERVAL":
+			interval, err := strconv.Atoi(value)
+			if err != nil || interval < 1 {
+				return nil, NewErrInvalidRecurrence("invalid interval " + value)
+			}
+			rule.Interval = interval
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				weekday, ok := recurrenceWeekdays[strings.ToUpper(day)]
+				if !ok {
+					return nil, NewErrInvalidRecurrence("invalid weekday " + day)
+				}
+				rule.ByDay = append(rule.ByDay, weekday)
+			}
+		case "BYMONTHDAY":
+			day, err := strconv.Atoi(value)
+			if err != nil || day < 1 || day > maxRecurrenceMonthDay {
+				return nil, NewErrInvalidRecurrence("invalid month day " + value)
+			}
+			rule.ByMonthDay = day
+		default:
+			return nil, NewErrInvalidRecurrence("unsupported rule part " + key)
+		}
+	}
+
+	if err := rule.IsValid(); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// IsValid returns an error if the rule uses a combination of parts that is
+// not supported.
+func (r *RecurrenceRule) IsValid() error {
+	switch r.Frequency {
+	case RecurrenceDaily:
+		if len(r.ByDay) != 0 || r.ByMonthDay != 0 {
+			return NewErrInvalidRecurrence("daily rules do not support BYDAY or BYMONTHDAY")
+		}
+	case RecurrenceWeekly:
+		if r.ByMonthDay != 0 {
+			return NewErrInvalidRecurrence("weekly rules do not support BYMONTHDAY")
+		}
+	case RecurrenceMonthly:
+		if len(r.ByDay) != 0 {
+			return NewErrInvalidRecurrence("monthly rules do not support BYDAY")
+		}
+	default:
+		return NewErrInvalidRecurrence("unsupported frequency " + string(r.Frequency))
+	}
+	if r.Interval < 1 {
+		return NewErrInvalidRecurrence("interval must be positive")
+	}
+	return nil
+}
+
+// String returns the canonical RRULE representation of the rule.
+func (r *RecurrenceRule) String() string {
+	parts := []string{"FREQ=" + string(r.Frequency)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) != 0 {
+		days := make([]string, 0, len(r.ByDay))
+		for _, weekday := range r.ByDay {
+			days = append(days, strings.ToUpper(weekday.String()[:2]))
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.ByMonthDay != 0 {
+		parts = append(parts, "BYMONTHDAY="+strconv.Itoa(r.ByMonthDay))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Next returns the first occurrence strictly after `after`. Occurrences keep
+// the time of day of `after`.
+func (r *RecurrenceRule) Next(after time.Time) time.Time {
+	switch r.Frequency {
+	case RecurrenceWeekly:
+		return r.nextWeekly(after)
+	case RecurrenceMonthly:
+		return r.nextMonthly(after)
+	default:
+		return after.AddDate(0, 0, r.Interval)
+	}
+}
+
+func (r *RecurrenceRule) nextWeekly(after time.Time) time.Time {
+	if len(r.ByDay) == 0 {
+		return after.AddDate(0, 0, 7*r.Interval)
+	}
+
+	days := make(map[time.Weekday]bool, len(r.ByDay))
+	for _, weekday := range r.ByDay {
+		days[weekday] = true
+	}
+
+	// weeks start on monday; only every `Interval` weeks are eligible
+	weekStart := startOfWeek(after)
+	for i := 1; i <= 7*(r.Interval+1); i++ {
+		candidate := after.AddDate(0, 0, i)
+		if !days[candidate.Weekday()] {
+			continue
+		}
+		weeks := int(startOfWeek(candidate).Sub(weekStart).Hours()/24+0.5) / 7
+		if weeks%r.Interval == 0 {
+			return candidate
+		}
+	}
+	return after.AddDate(0, 0, 7*r.Interval)
+}
+
+func (r *RecurrenceRule) nextMonthly(after time.Time) time.Time {
+	day := r.ByMonthDay
+	if day == 0 {
+		day = after.Day()
+	}
+
+	year, month := after.Year(), after.Month()
+	for {
+		candidate := dateInMonth(year, month, day, after)
+		if candidate.After(after) {
+			return candidate
+		}
+		month += time.Month(r.Interval)
+		for month > time.December {
+			month -= 12
+			year++
+		}
+	}
+}
+
+// dateInMonth returns the given day of the month, clamped to the last day of
+// shorter months, at the time of day of `clock`.
+func dateInMonth(year int, month time.Month, day int, clock time.Time) time.Time {
+	lastDay := time.Date(year, month+1, 0, 0, 0, 0, 0, clock.Location()).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(year, month, day, clock.Hour(), clock.Minute(), clock.Second(), clock.Nanosecond(), clock.Location())
+}
+
+func startOfWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	y, m, d := t.AddDate(0, 0, -offset).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// CardRecurrence schedules copies of a card (or card template) to be created
+// each time its recurrence rule is due.
+// swagger:model
+type CardRecurrence struct {
+	// The id of the card or card template that is cloned
+	// required: true
+	CardID string `json:"cardId"`
+
+	// The id of the board the card belongs to
+	// required: false
+	BoardID string `json:"boardId"`
+
+	// The recurrence rule, e.g. `FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR`
+	// required: true
+	Rule string `json:"rule"`
+
+	// The id of the date property that receives the occurrence date on each copy.
+	// When empty the first date property of the board is used
+	// required: false
+	DueDatePropertyID string `json:"dueDatePropertyId"`
+
+	// The time of the next occurrence in milliseconds since the current epoch
+	// required: false
+	NextRunAt int64 `json:"nextRunAt"`
+
+	// The time of the last created occurrence in milliseconds since the current epoch
+	// required: false
+	LastRunAt int64 `json:"lastRunAt"`
+
+	// The id of the user who created the recurrence. Copies are created on behalf of this user
+	// required: false
+	CreatedBy string `json:"createdBy"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: false
+	CreateAt int64 `json:"createAt"`
+
+	// The last modified time in milliseconds since the current epoch
+	// required: false
+	UpdateAt int64 `json:"updateAt"`
+}
+
+func (r *CardRecurrence) IsValid() error {
+	if r == nil {
+		return NewErrInvalidRecurrence("cannot be nil")
+	}
+	if r.CardID == "" {
+		return NewErrInvalidRecurrence("missing card id")
+	}
+	if r.BoardID == "" {
+		return NewErrInvalidRecurrence("missing board id")
+	}
+	if r.NextRunAt == 0 {
+		return NewErrInvalidRecurrence("missing next run time")
+	}
+	if _, err := ParseRecurrenceRule(r.Rule); err != nil {
+		return err
+	}
+	return nil
+}
+
+func CardRecurrenceFromJSON(data io.Reader) (*CardRecurrence, error) {
+	var recurrence CardRecurrence
+	if err := json.NewDecoder(data).Decode(&recurrence); err != nil {
+		return nil, err
+	}
+	return &recurrence, nil
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRecurrenceRule(t *testing.T) {
+	t.Run("valid rules", func(t *testing.T) {
+		testCases := []struct {
+			rule      string
+			canonical string
+		}{
+			{"FREQ=DAILY", "FREQ=DAILY"},
+			{"RRULE:FREQ=DAILY;INTERVAL=2", "FREQ=DAILY;INTERVAL=2"},
+			{"freq=weekly;byday=mo,tu,we,th,fr", "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR"},
+			{"FREQ=MONTHLY;BYMONTHDAY=15", "FREQ=MONTHLY;BYMONTHDAY=15"},
+		}
+
+		for _, tc := range testCases {
+			rule, err := ParseRecurrenceRule(tc.rule)
+			require.NoError(t, err, tc.rule)
+			assert.Equal(t, tc.canonical, rule.String())
+		}
+	})
+
+	t.Run("invalid rules", func(t *testing.T) {
+		rules := []string{
+			"",
+			"FREQ=YEARLY",
+			"FREQ=DAILY;BYDAY=MO",
+			"FREQ=WEEKLY;BYDAY=XX",
+			"FREQ=MONTHLY;BYMONTHDAY=32",
+			"FREQ=MONTHLY;BYDAY=MO",
+			"FREQ=DAILY;INTERVAL=0",
+			"FREQ=DAILY;COUNT=3",
+			"FREQ",
+		}
+
+		for _, s := range rules {
+			_, err := ParseRecurrenceRule(s)
+			require.Error(t, err, s)
+		}
+	})
+}
+
+func TestRecurrenceRuleNext(t *testing.T) {
+	// Friday
+	start := time.Date(2024, time.May, 31, 9, 30, 0, 0, time.UTC)
+
+	t.Run("daily", func(t *testing.T) {
+		rule, err := ParseRecurrenceRule("FREQ=DAILY;INTERVAL=3")
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2024, time.June, 3, 9, 30, 0, 0, time.UTC), rule.Next(start))
+	})
+
+	t.Run("weekly on weekdays skips the weekend", func(t *testing.T) {
+		rule, err := ParseRecurrenceRule("FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR")
+		require.NoError(t, err)
+		next := rule.Next(start)
+		assert.Equal(t, time.Date(2024, time.June, 3, 9, 30, 0, 0, time.UTC), next)
+		assert.Equal(t, time.Date(2024, time.June, 4, 9, 30, 0, 0, time.UTC), rule.Next(next))
+	})
+
+	t.Run("every other week", func(t *testing.T) {
+		rule, err := ParseRecurrenceRule("FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR")
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2024, time.June, 10, 9, 30, 0, 0, time.UTC), rule.Next(start))
+	})
+
+	t.Run("monthly on a day", func(t *testing.T) {
+		rule, err := ParseRecurrenceRule("FREQ=MONTHLY;BYMONTHDAY=15")
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2024, time.June, 15, 9, 30, 0, 0, time.UTC), rule.Next(start))
+	})
+
+	t.Run("monthly clamps to the end of short months", func(t *testing.T) {
+		rule, err := ParseRecurrenceRule("FREQ=MONTHLY;BYMONTHDAY=31")
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2024, time.June, 30, 9, 30, 0, 0, time.UTC), rule.Next(start))
+	})
+}
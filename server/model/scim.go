@@ -0,0 +1,389 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattermost/focalboard/server/services/auth"
+)
+
+const (
+	ScimSchemaUser         = "urn:ietf:params:scim:schemas:core:2.0:User"
+	ScimSchemaGroup        = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	ScimSchemaListResponse = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	ScimSchemaPatchOp      = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	ScimSchemaError        = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+	// ScimMaxResults is the maximum number of resources of a SCIM list
+	// response.
+	ScimMaxResults = 200
+)
+
+// ScimName is the name of a SCIM user.
+type ScimName struct {
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// ScimEmail is an email address of a SCIM user.
+type ScimEmail struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// ScimMeta is the metadata of a SCIM resource.
+type ScimMeta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Location     string `json:"location,omitempty"`
+}
+
+// ScimUser is the SCIM representation of a user. The display name is the
+// nickname of the user, and an inactive user is a deactivated user.
+type ScimUser struct {
+	Schemas     []string    `json:"schemas"`
+	ID          string      `json:"id,omitempty"`
+	UserName    string      `json:"userName"`
+	Name        *ScimName   `json:"name,omitempty"`
+	DisplayName string      `json:"displayName,omitempty"`
+	Emails      []ScimEmail `json:"emails,omitempty"`
+	Active      *bool       `json:"active,omitempty"`
+	Password    string      `json:"password,omitempty"`
+	Meta        *ScimMeta   `json:"meta,omitempty"`
+}
+
+// ScimMember is a member of a SCIM group, its value is the user ID.
+type ScimMember struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+}
+
+// ScimGroup is the SCIM representation of a team, its members are the
+// members of the team.
+type ScimGroup struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id,omitempty"`
+	DisplayName string       `json:"displayName"`
+	Members     []ScimMember `json:"members"`
+	Meta        *ScimMeta    `json:"meta,omitempty"`
+}
+
+// ScimListResponse is a page of SCIM resources.
+type ScimListResponse struct {
+	Schemas      []string    `json:"schemas"`
+	TotalResults int         `json:"totalResults"`
+	StartIndex   int         `json:"startIndex"`
+	ItemsPerPage int         `json:"itemsPerPage"`
+	Resources    interface{} `json:"Resources"`
+}
+
+// ScimPatchOperation is an operation of a SCIM patch request.
+type ScimPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// ScimPatchRequest is a SCIM patch request.
+type ScimPatchRequest struct {
+	Schemas    []string             `json:"schemas"`
+	Operations []ScimPatchOperation `json:"Operations"`
+}
+
+// ScimError is the body of the SCIM error responses.
+type ScimError struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+// ScimUserFromUser returns the SCIM representation of a user.
+func ScimUserFromUser(user *User, location string) *ScimUser {
+	active := user.DeleteAt == 0
+	scimUser := &ScimUser{
+		Schemas:     []string{ScimSchemaUser},
+		ID:          user.ID,
+		UserName:    user.Username,
+		DisplayName: user.Nickname,
+		Active:      &active,
+		Meta: &ScimMeta{
+			ResourceType: "User",
+			Created:      scimTime(user.CreateAt),
+			LastModified: scimTime(user.UpdateAt),
+			Location:     location,
+		},
+	}
+	if user.FirstName != "" || user.LastName != "" {
+		scimUser.Name = &ScimName{GivenName: user.FirstName, FamilyName: user.LastName}
+	}
+	if user.Email != "" {
+		scimUser.Emails = []ScimEmail{{Value: user.Email, Type: "work", Primary: true}}
+	}
+	return scimUser
+}
+
+// ScimGroupFromTeam returns the SCIM representation of a team.
+func ScimGroupFromTeam(team *Team, members []*TeamMember, location string) *ScimGroup {
+	group := &ScimGroup{
+		Schemas:     []string{ScimSchemaGroup},
+		ID:          team.ID,
+		DisplayName: team.Title,
+		Members:     make([]ScimMember, 0, len(members)),
+		Meta: &ScimMeta{
+			ResourceType: "Group",
+			Created:      scimTime(team.CreateAt),
+			LastModified: scimTime(team.UpdateAt),
+			Location:     location,
+		},
+	}
+	for _, member := range members {
+		group.Members = append(group.Members, ScimMember{Value: member.UserID})
+	}
+	return group
+}
+
+func scimTime(millis int64) string {
+	if millis == 0 {
+		return ""
+	}
+	return time.UnixMilli(millis).UTC().Format(time.RFC3339)
+}
+
+// PrimaryEmail returns the primary email address of the user, or its
+// first one.
+func (u *ScimUser) PrimaryEmail() string {
+	for _, email := range u.Emails {
+		if email.Primary {
+			return strings.TrimSpace(email.Value)
+		}
+	}
+	if len(u.Emails) > 0 {
+		return strings.TrimSpace(u.Emails[0].Value)
+	}
+	return ""
+}
+
+// IsActive returns false if the user is explicitly inactive.
+func (u *ScimUser) IsActive() bool {
+	return u.Active == nil || *u.Active
+}
+
+// IsValid validates a SCIM user.
+func (u *ScimUser) IsValid() error {
+	if strings.TrimSpace(u.UserName) == "" {
+		return NewErrBadRequest("userName is required")
+	}
+	email := u.PrimaryEmail()
+	if email == "" {
+		return NewErrBadRequest("an email address is required")
+	}
+	if !auth.IsEmailValid(email) {
+		return NewErrBadRequest("invalid email format")
+	}
+	return nil
+}
+
+// ApplyPatch applies the operations of a patch request to the user.
+func (u *ScimUser) ApplyPatch(operations []ScimPatchOperation) error {
+	for _, operation := range operations {
+		op := strings.ToLower(operation.Op)
+		if op != "add" && op != "replace" {
+			return NewErrBadRequest("unsupported patch operation " + operation.Op + " for users")
+		}
+
+		if operation.Path != "" {
+			if err := u.setAttribute(operation.Path, operation.Value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var values map[string]json.RawMessage
+		if err := json.Unmarshal(operation.Value, &values); err != nil {
+			return NewErrBadRequest("the value of a patch operation without path must be an object")
+		}
+		for path, value := range values {
+			if err := u.setAttribute(path, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (u *ScimUser) setAttribute(path string, value json.RawMessage) error {
+	switch strings.ToLower(path) {
+	case "active":
+		active, err := scimBool(value)
+		if err != nil {
+			return err
+		}
+		u.Active = &active
+		return nil
+	case "username":
+		return scimString(value, &u.UserName)
+	case "displayname":
+		return scimString(value, &u.DisplayName)
+	case "password":
+		return scimString(value, &u.Password)
+	case "name":
+		var name ScimName
+		if err := json.Unmarshal(value, &name); err != nil {
+			return NewErrBadRequest("invalid value for name")
+		}
+		u.Name = &name
+		return nil
+	case "name.givenname":
+		if u.Name == nil {
+			u.Name = &ScimName{}
+		}
+		return scimString(value, &u.Name.GivenName)
+	case "name.familyname":
+		if u.Name == nil {
+			u.Name = &ScimName{}
+		}
+		return scimString(value, &u.Name.FamilyName)
+	case "emails":
+		var emails []ScimEmail
+		if err := json.Unmarshal(value, &emails); err != nil {
+			return NewErrBadRequest("invalid value for emails")
+		}
+		u.Emails = emails
+		return nil
+	case `emails[type eq "work"].value`, `emails[primary eq true].value`:
+		var email string
+		if err := scimString(value, &email); err != nil {
+			return err
+		}
+		u.Emails = []ScimEmail{{Value: email, Type: "work", Primary: true}}
+		return nil
+	}
+	return NewErrBadRequest("unsupported attribute " + path)
+}
+
+// ApplyPatch applies the operations of a patch request to the group.
+func (g *ScimGroup) ApplyPatch(operations []ScimPatchOperation) error {
+	for _, operation := range operations {
+		op := strings.ToLower(operation.Op)
+		path := strings.ToLower(operation.Path)
+
+		switch {
+		case op == "remove" && path == "members" && len(operation.Value) == 0:
+			g.Members = []ScimMember{}
+		case op == "remove" && path == "members":
+			var members []ScimMember
+			if err := json.Unmarshal(operation.Value, &members); err != nil {
+				return NewErrBadRequest("invalid value for members")
+			}
+			for _, member := range members {
+				g.removeMember(member.Value)
+			}
+		case op == "remove" && strings.HasPrefix(path, "members["):
+			attribute, userID, err := ParseScimFilter(operation.Path[len("members[") : len(operation.Path)-1])
+			if err != nil || attribute != "value" {
+				return NewErrBadRequest("unsupported path " + operation.Path)
+			}
+			g.removeMember(userID)
+		case (op == "add" || op == "replace") && path == "members":
+			var members []ScimMember
+			if err := json.Unmarshal(operation.Value, &members); err != nil {
+				return NewErrBadRequest("invalid value for members")
+			}
+			if op == "replace" {
+				g.Members = []ScimMember{}
+			}
+			for _, member := range members {
+				g.addMember(member.Value)
+			}
+		case (op == "add" || op == "replace") && path == "displayname":
+			if err := scimString(operation.Value, &g.DisplayName); err != nil {
+				return err
+			}
+		case op == "replace" && path == "":
+			var values struct {
+				DisplayName *string      `json:"displayName"`
+				Members     []ScimMember `json:"members"`
+			}
+			if err := json.Unmarshal(operation.Value, &values); err != nil {
+				return NewErrBadRequest("the value of a patch operation without path must be an object")
+			}
+			if values.DisplayName != nil {
+				g.DisplayName = *values.DisplayName
+			}
+			if values.Members != nil {
+				g.Members = values.Members
+			}
+		default:
+			return NewErrBadRequest("unsupported patch operation " + operation.Op + " " + operation.Path + " for groups")
+		}
+	}
+	return nil
+}
+
+func (g *ScimGroup) addMember(userID string) {
+	for _, member := range g.Members {
+		if member.Value == userID {
+			return
+		}
+	}
+	g.Members = append(g.Members, ScimMember{Value: userID})
+}
+
+func (g *ScimGroup) removeMember(userID string) {
+	members := make([]ScimMember, 0, len(g.Members))
+	for _, member := range g.Members {
+		if member.Value != userID {
+			members = append(members, member)
+		}
+	}
+	g.Members = members
+}
+
+// scimBool reads a boolean value, that some identity providers send as a
+// string.
+func scimBool(value json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return b, nil
+	}
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b, nil
+		}
+	}
+	return false, NewErrBadRequest("invalid boolean value " + string(value))
+}
+
+func scimString(value json.RawMessage, dest *string) error {
+	if err := json.Unmarshal(value, dest); err != nil {
+		return NewErrBadRequest("invalid string value " + string(value))
+	}
+	return nil
+}
+
+var scimFilterRegexp = regexp.MustCompile(`^\s*([A-Za-z.]+)\s+[eE][qQ]\s+"((?:[^"\\]|\\.)*)"\s*$`)
+
+// ParseScimFilter parses a SCIM filter of the form `attribute eq "value"`,
+// the only one supported, and returns the attribute in lower case and the
+// value.
+func ParseScimFilter(filter string) (string, string, error) {
+	match := scimFilterRegexp.FindStringSubmatch(filter)
+	if match == nil {
+		return "", "", NewErrBadRequest("unsupported filter " + filter)
+	}
+	value, err := strconv.Unquote(`"` + match[2] + `"`)
+	if err != nil {
+		return "", "", NewErrBadRequest("invalid filter value " + match[2])
+	}
+	return strings.ToLower(match[1]), value, nil
+}
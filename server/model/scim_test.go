@@ -0,0 +1,85 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScimFilter(t *testing.T) {
+	attribute, value, err := ParseScimFilter(`userName eq "jane.doe"`)
+	require.NoError(t, err)
+	require.Equal(t, "username", attribute)
+	require.Equal(t, "jane.doe", value)
+
+	attribute, value, err = ParseScimFilter(` emails.value EQ "a\"b@example.com" `)
+	require.NoError(t, err)
+	require.Equal(t, "emails.value", attribute)
+	require.Equal(t, `a"b@example.com`, value)
+
+	for _, filter := range []string{`userName co "jane"`, `userName eq jane`, `eq "jane"`, ``} {
+		_, _, err = ParseScimFilter(filter)
+		require.True(t, IsErrBadRequest(err), filter)
+	}
+}
+
+func TestScimUserApplyPatch(t *testing.T) {
+	active := true
+	user := &ScimUser{
+		UserName: "jane",
+		Emails:   []ScimEmail{{Value: "jane@example.com", Primary: true}},
+		Active:   &active,
+	}
+
+	err := user.ApplyPatch([]ScimPatchOperation{
+		{Op: "Replace", Path: "active", Value: json.RawMessage(`"False"`)},
+		{Op: "add", Path: "name.familyName", Value: json.RawMessage(`"Doe"`)},
+		{Op: "replace", Path: `emails[type eq "work"].value`, Value: json.RawMessage(`"jdoe@example.com"`)},
+		{Op: "replace", Value: json.RawMessage(`{"displayName": "Jane D.", "userName": "jdoe"}`)},
+	})
+	require.NoError(t, err)
+	require.False(t, user.IsActive())
+	require.Equal(t, "Doe", user.Name.FamilyName)
+	require.Equal(t, "jdoe@example.com", user.PrimaryEmail())
+	require.Equal(t, "Jane D.", user.DisplayName)
+	require.Equal(t, "jdoe", user.UserName)
+
+	err = user.ApplyPatch([]ScimPatchOperation{{Op: "remove", Path: "displayName"}})
+	require.True(t, IsErrBadRequest(err))
+
+	err = user.ApplyPatch([]ScimPatchOperation{{Op: "replace", Path: "title", Value: json.RawMessage(`"CEO"`)}})
+	require.True(t, IsErrBadRequest(err))
+}
+
+func TestScimGroupApplyPatch(t *testing.T) {
+	group := &ScimGroup{
+		DisplayName: "Engineering",
+		Members:     []ScimMember{{Value: "user-1"}, {Value: "user-2"}},
+	}
+
+	err := group.ApplyPatch([]ScimPatchOperation{
+		{Op: "add", Path: "members", Value: json.RawMessage(`[{"value": "user-2"}, {"value": "user-3"}]`)},
+		{Op: "remove", Path: `members[value eq "user-1"]`},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []ScimMember{{Value: "user-2"}, {Value: "user-3"}}, group.Members)
+
+	err = group.ApplyPatch([]ScimPatchOperation{
+		{Op: "replace", Value: json.RawMessage(`{"displayName": "R&D"}`)},
+		{Op: "remove", Path: "members", Value: json.RawMessage(`[{"value": "user-3"}]`)},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "R&D", group.DisplayName)
+	require.Equal(t, []ScimMember{{Value: "user-2"}}, group.Members)
+
+	err = group.ApplyPatch([]ScimPatchOperation{{Op: "remove", Path: "members"}})
+	require.NoError(t, err)
+	require.Empty(t, group.Members)
+
+	err = group.ApplyPatch([]ScimPatchOperation{{Op: "remove", Path: "displayName"}})
+	require.True(t, IsErrBadRequest(err))
+}
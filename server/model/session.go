@@ -0,0 +1,56 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+const (
+	// SessionPropUserAgent is the session prop with the user agent of the
+	// client that logged in.
+	SessionPropUserAgent = "userAgent"
+	// SessionPropIPAddress is the session prop with the IP address the
+	// client logged in from.
+	SessionPropIPAddress = "ipAddress"
+)
+
+// SessionInfo describes an active session of the user, without its token.
+// swagger:model
+type SessionInfo struct {
+	// The id of the session
+	// required: true
+	ID string `json:"id"`
+
+	// The user agent of the client that logged in
+	// required: false
+	Device string `json:"device"`
+
+	// The IP address the client logged in from
+	// required: false
+	IPAddress string `json:"ipAddress"`
+
+	// The creation time in miliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last activity time in miliseconds since the current epoch
+	// required: true
+	LastActivityAt int64 `json:"lastActivityAt"`
+
+	// True for the session of the request
+	// required: true
+	Current bool `json:"current"`
+}
+
+// NewSessionInfo returns the information of the session shown to its
+// user. currentSessionID is the ID of the session of the request.
+func NewSessionInfo(session *Session, currentSessionID string) *SessionInfo {
+	device, _ := session.Props[SessionPropUserAgent].(string)
+	ipAddress, _ := session.Props[SessionPropIPAddress].(string)
+	return &SessionInfo{
+		ID:             session.ID,
+		Device:         device,
+		IPAddress:      ipAddress,
+		CreateAt:       session.CreateAt,
+		LastActivityAt: session.UpdateAt,
+		Current:        session.ID == currentSessionID,
+	}
+}
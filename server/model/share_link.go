@@ -0,0 +1,158 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/mattermost/focalboard/server/services/auth"
+)
+
+const (
+	// MaxShareLinkNameLength is the maximum length of a share link name.
+	MaxShareLinkNameLength = 64
+
+	// MaxShareLinkViewIDLength is the maximum length of the view id sent
+	// along with the read token of a single view link.
+	MaxShareLinkViewIDLength = 36
+
+	// HeaderReadTokenPassword is the request header carrying the password
+	// of a password protected share link.
+	HeaderReadTokenPassword = "X-Read-Token-Password"
+
+	// HeaderReadTokenViewID is the request header carrying the view id a
+	// single view share link is used for.
+	HeaderReadTokenViewID = "X-Read-Token-View-Id"
+)
+
+// ShareLinkCredentials are sent along with the read token of a share link.
+type ShareLinkCredentials struct {
+	// The password of a password protected link
+	Password string
+
+	// A random id generated by the client for the view of the board. The
+	// first view id a single view link is used with is bound to the link,
+	// so that the board, its blocks and the websocket subscription can be
+	// loaded as part of the same view, and the link can't be used for any
+	// other view
+	ViewID string
+}
+
+// ShareLink is a public read only link to a board. A board can have any
+// number of share links, and each of them can be revoked independently.
+// swagger:model
+type ShareLink struct {
+	// The id of the share link
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the shared board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// A name to identify the link
+	// required: false
+	Name string `json:"name"`
+
+	// The read token of the link
+	// required: true
+	Token string `json:"token"`
+
+	// The password of the link, only used when creating it
+	// required: false
+	Password string `json:"password,omitempty"`
+
+	// Whether a password is needed to use the link
+	// required: true
+	HasPassword bool `json:"hasPassword"`
+
+	// Whether the link can only be used for one view
+	// required: true
+	SingleView bool `json:"singleView"`
+
+	// Expiry time in miliseconds since the current epoch, zero if the link doesn't expire
+	// required: false
+	ExpireAt int64 `json:"expireAt"`
+
+	// The number of times the link was used
+	// required: true
+	UseCount int64 `json:"useCount"`
+
+	// The first access time in miliseconds since the current epoch
+	// required: false
+	FirstAccessAt int64 `json:"firstAccessAt"`
+
+	// The last access time in miliseconds since the current epoch
+	// required: false
+	LastAccessAt int64 `json:"lastAccessAt"`
+
+	// The id of the user who created the link
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// The creation time in miliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The view id a single view link is bound to, once it was used
+	ViewID string `json:"-"`
+}
+
+func (l *ShareLink) IsValid() error {
+	if l == nil {
+		return NewErrBadRequest("share link cannot be nil")
+	}
+	if l.BoardID == "" {
+		return NewErrBadRequest("missing board id")
+	}
+	if len(l.Name) > MaxShareLinkNameLength {
+		return NewErrBadRequest("share link name is too long")
+	}
+	if l.ExpireAt < 0 {
+		return NewErrBadRequest("invalid share link expiry time")
+	}
+	return nil
+}
+
+// IsUsable returns true if the link hasn't expired and, for single view
+// links, if it's used with a view id and the link isn't bound to another
+// view yet.
+func (l *ShareLink) IsUsable(now int64, viewID string) bool {
+	if l.ExpireAt != 0 && l.ExpireAt <= now {
+		return false
+	}
+	if l.SingleView {
+		if viewID == "" || len(viewID) > MaxShareLinkViewIDLength {
+			return false
+		}
+		if l.ViewID != "" && l.ViewID != viewID {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckPassword returns true if the link doesn't have a password or if the
+// password matches it.
+func (l *ShareLink) CheckPassword(password string) bool {
+	if l.Password == "" {
+		return true
+	}
+	return auth.ComparePassword(l.Password, password)
+}
+
+// Sanitize removes the password hash from the link.
+func (l *ShareLink) Sanitize() {
+	l.HasPassword = l.Password != ""
+	l.Password = ""
+}
+
+func ShareLinkFromJSON(data io.Reader) (*ShareLink, error) {
+	var link ShareLink
+	if err := json.NewDecoder(data).Decode(&link); err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
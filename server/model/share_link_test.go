@@ -0,0 +1,66 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/services/auth"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShareLinkIsValid(t *testing.T) {
+	t.Run("valid link", func(t *testing.T) {
+		require.NoError(t, (&ShareLink{BoardID: "board-id", Name: "link"}).IsValid())
+	})
+
+	t.Run("missing board", func(t *testing.T) {
+		require.True(t, IsErrBadRequest((&ShareLink{}).IsValid()))
+	})
+
+	t.Run("name too long", func(t *testing.T) {
+		link := &ShareLink{BoardID: "board-id", Name: strings.Repeat("a", MaxShareLinkNameLength+1)}
+		require.True(t, IsErrBadRequest(link.IsValid()))
+	})
+
+	t.Run("negative expiry", func(t *testing.T) {
+		require.True(t, IsErrBadRequest((&ShareLink{BoardID: "board-id", ExpireAt: -1}).IsValid()))
+	})
+}
+
+func TestShareLinkIsUsable(t *testing.T) {
+	now := int64(1_000_000_000)
+
+	require.True(t, (&ShareLink{}).IsUsable(now, ""))
+	require.True(t, (&ShareLink{ExpireAt: now + 1}).IsUsable(now, ""))
+	require.False(t, (&ShareLink{ExpireAt: now}).IsUsable(now, ""))
+
+	require.False(t, (&ShareLink{SingleView: true}).IsUsable(now, ""))
+	require.False(t, (&ShareLink{SingleView: true}).IsUsable(now, strings.Repeat("v", MaxShareLinkViewIDLength+1)))
+	require.True(t, (&ShareLink{SingleView: true}).IsUsable(now, "view-1"))
+	require.True(t, (&ShareLink{SingleView: true, ViewID: "view-1"}).IsUsable(now, "view-1"))
+	require.False(t, (&ShareLink{SingleView: true, ViewID: "view-1"}).IsUsable(now, "view-2"))
+	require.False(t, (&ShareLink{SingleView: true, ExpireAt: now, ViewID: "view-1"}).IsUsable(now, "view-1"))
+}
+
+func TestShareLinkSanitize(t *testing.T) {
+	link := &ShareLink{Password: "hash"}
+	link.Sanitize()
+	require.Empty(t, link.Password)
+	require.True(t, link.HasPassword)
+
+	link = &ShareLink{}
+	link.Sanitize()
+	require.False(t, link.HasPassword)
+}
+
+func TestShareLinkCheckPassword(t *testing.T) {
+	require.True(t, (&ShareLink{}).CheckPassword(""))
+
+	link := &ShareLink{Password: auth.HashPassword("secret")}
+	require.True(t, link.CheckPassword("secret"))
+	require.False(t, link.CheckPassword("wrong"))
+	require.False(t, link.CheckPassword(""))
+}
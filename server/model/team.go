@@ -3,8 +3,12 @@ package model
 import (
 	"encoding/json"
 	"io"
+	"strings"
 )
 
+// MaxTeamTitleLength is the maximum length of a team title.
+const MaxTeamTitleLength = 255
+
 // Team is information global to a team
 // swagger:model
 type Team struct {
@@ -31,6 +35,75 @@ type Team struct {
 	// Updated time in miliseconds since the current epoch
 	// required: true
 	UpdateAt int64 `json:"updateAt"`
+
+	// Created time in miliseconds since the current epoch
+	// required: false
+	CreateAt int64 `json:"createAt"`
+
+	// Archived time in miliseconds since the current epoch, 0 if the team
+	// is active
+	// required: false
+	DeleteAt int64 `json:"deleteAt"`
+}
+
+// TeamPatch is a patch for modifying a team
+// swagger:model
+type TeamPatch struct {
+	// The title of the team
+	// required: false
+	Title *string `json:"title"`
+}
+
+// TeamMember stores the membership of a user on a team
+// swagger:model
+type TeamMember struct {
+	// The ID of the team
+	// required: true
+	TeamID string `json:"teamId"`
+
+	// The ID of the user
+	// required: true
+	UserID string `json:"userId"`
+
+	// Marks the user as an admin of the team
+	// required: true
+	SchemeAdmin bool `json:"schemeAdmin"`
+
+	// Created time in miliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+}
+
+// IsManaged returns true for the teams whose access is restricted to their
+// members. The root team of standalone servers is open to every user.
+func (t *Team) IsManaged() bool {
+	return t.ID != GlobalTeamID
+}
+
+// Sanitize removes the signup token, that only team admins can see.
+func (t *Team) Sanitize() {
+	t.SignupToken = ""
+}
+
+func (t *Team) IsValid() error {
+	if t == nil {
+		return NewErrBadRequest("team cannot be nil")
+	}
+	if strings.TrimSpace(t.Title) == "" {
+		return NewErrBadRequest("missing team title")
+	}
+	if len(t.Title) > MaxTeamTitleLength {
+		return NewErrBadRequest("team title is too long")
+	}
+	return nil
+}
+
+// Patch returns an updated version of the team.
+func (p *TeamPatch) Patch(team *Team) *Team {
+	if p.Title != nil {
+		team.Title = strings.TrimSpace(*p.Title)
+	}
+	return team
 }
 
 func TeamFromJSON(data io.Reader) *Team {
@@ -44,3 +117,19 @@ func TeamsFromJSON(data io.Reader) []*Team {
 	_ = json.NewDecoder(data).Decode(&teams)
 	return teams
 }
+
+func TeamPatchFromJSON(data io.Reader) (*TeamPatch, error) {
+	var patch TeamPatch
+	if err := json.NewDecoder(data).Decode(&patch); err != nil {
+		return nil, err
+	}
+	return &patch, nil
+}
+
+func TeamMemberFromJSON(data io.Reader) (*TeamMember, error) {
+	var member TeamMember
+	if err := json.NewDecoder(data).Decode(&member); err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/mattermost/focalboard/server/services/auth"
+)
+
+// TeamInviteExpiry is how long a team invitation can be accepted for.
+const TeamInviteExpiry = 7 * 24 * time.Hour
+
+// TeamInvite invites a user to join a team. The id of the invitation is the
+// token that the user registers or accepts the invitation with.
+// swagger:model
+type TeamInvite struct {
+	// The id of the invitation, used as the invitation token
+	// required: true
+	ID string `json:"id"`
+
+	// The ID of the team
+	// required: true
+	TeamID string `json:"teamId"`
+
+	// The email the invitation is restricted to. Any user can accept
+	// invitations without email
+	// required: false
+	Email string `json:"email"`
+
+	// Makes the invited user an admin of the team
+	// required: false
+	SchemeAdmin bool `json:"schemeAdmin"`
+
+	// The IDs of the team boards the invited user is added to
+	// required: false
+	BoardIDs []string `json:"boardIds"`
+
+	// The role of the invited user on the boards, editor by default
+	// required: false
+	BoardRole BoardRole `json:"boardRole"`
+
+	// The ID of the user who created the invitation
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// Created time in miliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// Expiry time in miliseconds since the current epoch, the invitation
+	// expires after TeamInviteExpiry if it isn't set
+	// required: false
+	ExpireAt int64 `json:"expireAt"`
+
+	// The ID of the user who accepted the invitation
+	// required: false
+	AcceptedBy string `json:"acceptedBy"`
+
+	// Accepted time in miliseconds since the current epoch, 0 if the
+	// invitation is pending
+	// required: false
+	AcceptAt int64 `json:"acceptAt"`
+}
+
+func (i *TeamInvite) IsValid() error {
+	if i == nil {
+		return NewErrBadRequest("invitation cannot be nil")
+	}
+	if i.TeamID == "" {
+		return NewErrBadRequest("missing team id")
+	}
+	if i.Email != "" && !auth.IsEmailValid(i.Email) {
+		return NewErrBadRequest("invalid email")
+	}
+	if i.ExpireAt < 0 {
+		return NewErrBadRequest("invalid expiry time")
+	}
+	if !IsBoardMinimumRoleValid(i.BoardRole) {
+		return NewErrBadRequest("invalid board role")
+	}
+	for _, boardID := range i.BoardIDs {
+		if boardID == "" {
+			return NewErrBadRequest("invalid board id")
+		}
+	}
+	return nil
+}
+
+// GetBoardRole returns the role of the invited user on the boards of the
+// invitation.
+func (i *TeamInvite) GetBoardRole() BoardRole {
+	if i.BoardRole == BoardRoleNone {
+		return BoardRoleEditor
+	}
+	return i.BoardRole
+}
+
+// CanBeAcceptedBy returns an error if the invitation has already been
+// accepted, has expired or is restricted to another email.
+func (i *TeamInvite) CanBeAcceptedBy(email string, now int64) error {
+	if i.AcceptAt != 0 {
+		return NewErrBadRequest("the invitation has already been accepted")
+	}
+	if i.ExpireAt <= now {
+		return NewErrBadRequest("the invitation has expired")
+	}
+	if i.Email != "" && !strings.EqualFold(i.Email, strings.TrimSpace(email)) {
+		return NewErrPermission("the invitation is for another email")
+	}
+	return nil
+}
+
+func TeamInviteFromJSON(data io.Reader) (*TeamInvite, error) {
+	var invite TeamInvite
+	if err := json.NewDecoder(data).Decode(&invite); err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamInviteIsValid(t *testing.T) {
+	require.NoError(t, (&TeamInvite{TeamID: "team_id"}).IsValid())
+	require.NoError(t, (&TeamInvite{TeamID: "team_id", Email: "someone@example.com"}).IsValid())
+	require.True(t, IsErrBadRequest((&TeamInvite{}).IsValid()))
+	require.True(t, IsErrBadRequest((&TeamInvite{TeamID: "team_id", Email: "someone"}).IsValid()))
+	require.NoError(t, (&TeamInvite{TeamID: "team_id", BoardIDs: []string{"board_id"}, BoardRole: BoardRoleViewer}).IsValid())
+	require.True(t, IsErrBadRequest((&TeamInvite{TeamID: "team_id", BoardRole: "owner"}).IsValid()))
+	require.True(t, IsErrBadRequest((&TeamInvite{TeamID: "team_id", BoardIDs: []string{""}}).IsValid()))
+	require.True(t, IsErrBadRequest((&TeamInvite{TeamID: "team_id", ExpireAt: -1}).IsValid()))
+}
+
+func TestTeamInviteGetBoardRole(t *testing.T) {
+	require.Equal(t, BoardRoleEditor, (&TeamInvite{}).GetBoardRole())
+	require.Equal(t, BoardRoleViewer, (&TeamInvite{BoardRole: BoardRoleViewer}).GetBoardRole())
+}
+
+func TestTeamInviteCanBeAcceptedBy(t *testing.T) {
+	invite := &TeamInvite{
+		TeamID:   "team_id",
+		Email:    "someone@example.com",
+		ExpireAt: 1000,
+	}
+
+	t.Run("pending invite", func(t *testing.T) {
+		require.NoError(t, invite.CanBeAcceptedBy("Someone@Example.com", 999))
+	})
+
+	t.Run("other email", func(t *testing.T) {
+		require.True(t, IsErrForbidden(invite.CanBeAcceptedBy("other@example.com", 999)))
+	})
+
+	t.Run("invite without email", func(t *testing.T) {
+		open := *invite
+		open.Email = ""
+		require.NoError(t, open.CanBeAcceptedBy("other@example.com", 999))
+	})
+
+	t.Run("expired invite", func(t *testing.T) {
+		require.True(t, IsErrBadRequest(invite.CanBeAcceptedBy("someone@example.com", 1000)))
+	})
+
+	t.Run("accepted invite", func(t *testing.T) {
+		accepted := *invite
+		accepted.AcceptAt = 500
+		require.True(t, IsErrBadRequest(accepted.CanBeAcceptedBy("someone@example.com", 999)))
+	})
+}
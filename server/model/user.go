@@ -3,6 +3,9 @@ package model
 import (
 	"encoding/json"
 	"io"
+	"strings"
+
+	mmModel "github.com/mattermost/mattermost/server/public/model"
 )
 
 const (
@@ -10,6 +13,19 @@ const (
 	GlobalTeamID                  = "0"
 	SystemUserID                  = "system"
 	PreferencesCategoryFocalboard = "focalboard"
+
+	// OidcAuthService is the auth service of the users provisioned by the
+	// OpenID Connect single sign-on, their auth data is the subject of the
+	// provider.
+	OidcAuthService = "oidc"
+
+	// LdapAuthService is the auth service of the users provisioned from the
+	// LDAP directory, their auth data is the ID attribute of their entry.
+	LdapAuthService = "ldap"
+
+	// SystemAdminRole is the role of the system admins, the roles of a
+	// user are separated by spaces.
+	SystemAdminRole = mmModel.SystemAdminRoleId
 )
 
 // User is a user
@@ -40,12 +56,22 @@ type User struct {
 	// swagger:ignore
 	MfaSecret string `json:"-"`
 
+	// Whether multi-factor authentication is active for the user
+	// required: false
+	MfaActive bool `json:"mfa_active"`
+
 	// swagger:ignore
 	AuthService string `json:"-"`
 
 	// swagger:ignore
 	AuthData string `json:"-"`
 
+	// swagger:ignore
+	EmailVerified bool `json:"-"`
+
+	// swagger:ignore
+	ScimDeleted bool `json:"-"`
+
 	// Created time in miliseconds since the current epoch
 	// required: true
 	CreateAt int64 `json:"create_at,omitempty"`
@@ -66,6 +92,11 @@ type User struct {
 	// required: true
 	IsGuest bool `json:"is_guest"`
 
+	// Time in miliseconds since the current epoch after which the guest
+	// account can't be used anymore, 0 if the guest account doesn't expire
+	// required: false
+	GuestExpireAt int64 `json:"guest_expire_at,omitempty"`
+
 	// Special Permissions the user may have
 	Permissions []string `json:"permissions,omitempty"`
 
@@ -102,6 +133,22 @@ func UserFromJSON(data io.Reader) (*User, error) {
 	return &user, nil
 }
 
+// IsExpiredGuest returns true if the user is a guest whose account has
+// expired.
+func (u *User) IsExpiredGuest(now int64) bool {
+	return u.IsGuest && u.GuestExpireAt != 0 && u.GuestExpireAt <= now
+}
+
+// IsSystemAdmin returns true if the user has the system admin role.
+func (u *User) IsSystemAdmin() bool {
+	for _, role := range strings.Fields(u.Roles) {
+		if role == SystemAdminRole {
+			return true
+		}
+	}
+	return false
+}
+
 func (u *User) Sanitize(options map[string]bool) {
 	u.Password = ""
 	u.MfaSecret = ""
@@ -114,3 +161,14 @@ func (u *User) Sanitize(options map[string]bool) {
 		u.LastName = ""
 	}
 }
+
+// QueryUsersOptions filters the users of the user management APIs.
+type QueryUsersOptions struct {
+	UserID         string // if not empty then filter for the user ID
+	Username       string // if not empty then filter for the username
+	Email          string // if not empty then filter for the email address
+	Term           string // if not empty then filter for the usernames, emails and names containing it
+	IncludeDeleted bool   // if true then the deactivated users are included
+	Page           int    // page number to select when paginating
+	PerPage        int    // number of users per page, all the users if 0
+}
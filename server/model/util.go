@@ -4,6 +4,8 @@
 package model
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"time"
 
 	mm_model "github.com/mattermost/mattermost/server/public/model"
@@ -23,3 +25,11 @@ func GetMillisForTime(thisTime time.Time) int64 {
 func GetTimeForMillis(millis int64) time.Time {
 	return mm_model.GetTimeForMillis(millis)
 }
+
+// HashToken returns the hash the random tokens, like the access tokens, are
+// stored and looked up with. The tokens are random, so they don't need a
+// salted hash.
+func HashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
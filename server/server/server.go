@@ -22,6 +22,7 @@ import (
 	"github.com/mattermost/focalboard/server/services/config"
 	"github.com/mattermost/focalboard/server/services/metrics"
 	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/services/notify/notifyautomations"
 	"github.com/mattermost/focalboard/server/services/notify/notifylogger"
 	"github.com/mattermost/focalboard/server/services/scheduler"
 	"github.com/mattermost/focalboard/server/services/store"
@@ -38,8 +39,9 @@ import (
 )
 
 const (
-	cleanupSessionTaskFrequency = 10 * time.Minute
-	updateMetricsTaskFrequency  = 15 * time.Minute
+	cleanupSessionTaskFrequency  = 10 * time.Minute
+	updateMetricsTaskFrequency   = 15 * time.Minute
+	cardRecurrencesTaskFrequency = 1 * time.Minute
 
 	minSessionExpiryTime = int64(60 * 60 * 24 * 31) // 31 days
 
@@ -58,6 +60,8 @@ type Server struct {
 	metricsServer          *metrics.Service
 	metricsService         *metrics.Metrics
 	metricsUpdaterTask     *scheduler.ScheduledTask
+	cardRecurrencesTask    *scheduler.ScheduledTask
+	ldapSyncTask           *scheduler.ScheduledTask
 	auditService           *audit.Audit
 	notificationService    *notify.Service
 	servicesStartStopMutex sync.Mutex
@@ -143,6 +147,17 @@ func New(params Params) (*Server, error) {
 	}
 	app := app.New(params.Cfg, wsAdapter, appServices)
 
+	// The automations backend runs board rules through the app, so it is
+	// added once the app exists
+	automationsBackend := notifyautomations.New(notifyautomations.BackendParams{
+		AppAPI:      app,
+		Permissions: params.PermissionsService,
+		Logger:      params.Logger,
+	})
+	if err := notificationService.AddBackend(automationsBackend); err != nil {
+		return nil, fmt.Errorf("cannot initialize automations backend: %w", err)
+	}
+
 	focalboardAPI := api.NewAPI(app, params.SingleUserToken, params.Cfg.AuthMode, params.PermissionsService, params.Logger, auditService, params.DBStore, params.Cfg.ServerRoot, "", params.ServicesAPI)
 
 	// Local router for admin APIs
@@ -264,6 +279,23 @@ func (s *Server) Start() error {
 				s.logger.Error("Unable to clean up the sessions", mlog.Err(err))
 			}
 		}, cleanupSessionTaskFrequency)
+
+		if s.config.Ldap.Enable && s.config.Ldap.SyncIntervalMinutes > 0 {
+			s.ldapSyncTask = scheduler.CreateRecurringTask("ldapSync", func() {
+				result, err := s.app.SyncLdap()
+				if err != nil {
+					s.logger.Error("Unable to sync the LDAP users", mlog.Err(err))
+					return
+				}
+				s.logger.Info("LDAP users synced",
+					mlog.Int("updated", result.Updated),
+					mlog.Int("deactivated", result.Deactivated),
+					mlog.Int("reactivated", result.Reactivated),
+					mlog.Int("membershipsAdded", result.MembershipsAdded),
+					mlog.Int("membershipsRemoved", result.MembershipsRemoved),
+				)
+			}, time.Duration(s.config.Ldap.SyncIntervalMinutes)*time.Minute)
+		}
 	}
 
 	metricsUpdater := func() {
@@ -294,6 +326,8 @@ func (s *Server) Start() error {
 	// metricsUpdater()   Calling this immediately causes integration unit tests to fail.
 	s.metricsUpdaterTask = scheduler.CreateRecurringTask("updateMetrics", metricsUpdater, updateMetricsTaskFrequency)
 
+	s.cardRecurrencesTask = scheduler.CreateRecurringTask("cardRecurrences", s.app.ProcessDueCardRecurrences, cardRecurrencesTaskFrequency)
+
 	if s.config.Telemetry {
 		firstRun := utils.GetMillis()
 		s.telemetry.RunTelemetryJob(firstRun)
@@ -335,6 +369,14 @@ func (s *Server) Shutdown() error {
 		s.metricsUpdaterTask.Cancel()
 	}
 
+	if s.cardRecurrencesTask != nil {
+		s.cardRecurrencesTask.Cancel()
+	}
+
+	if s.ldapSyncTask != nil {
+		s.ldapSyncTask.Cancel()
+	}
+
 	if err := s.telemetry.Shutdown(); err != nil {
 		s.logger.Warn("Error occurred when shutting down telemetry", mlog.Err(err))
 	}
@@ -0,0 +1,134 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// MfaSecretSize is the size in bytes of the generated TOTP secrets.
+	MfaSecretSize = 20
+
+	// MfaCodeDigits is the number of digits of the TOTP codes.
+	MfaCodeDigits = 6
+
+	// MfaPeriod is the time a TOTP code is valid for.
+	MfaPeriod = 30 * time.Second
+
+	// MfaRecoveryCodeCount is the number of recovery codes generated for a
+	// user when MFA is activated.
+	MfaRecoveryCodeCount = 10
+
+	recoveryCodeChars = "abcdefghijkmnpqrstuvwxyz23456789"
+)
+
+var mfaEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateMfaSecret generates a random base32 encoded TOTP secret.
+func GenerateMfaSecret() (string, error) {
+	secret := make([]byte, MfaSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return mfaEncoding.EncodeToString(secret), nil
+}
+
+// GenerateMfaCode generates the TOTP code (RFC 6238) of the secret for the
+// specified time.
+func GenerateMfaCode(secret string, t time.Time) (string, error) {
+	key, err := mfaEncoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return "", err
+	}
+
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(MfaTimeStep(t)))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < MfaCodeDigits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", MfaCodeDigits, value%mod), nil
+}
+
+// MfaTimeStep returns the TOTP time step of the specified time.
+func MfaTimeStep(t time.Time) int64 {
+	return t.Unix() / int64(MfaPeriod.Seconds())
+}
+
+// ValidateMfaCode checks a TOTP code against the secret, accepting the
+// codes of the previous and next periods to allow for clock drift. It
+// returns the time step of the matching code, so that callers can reject
+// the codes that were already used.
+func ValidateMfaCode(secret, code string, t time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != MfaCodeDigits {
+		return 0, false
+	}
+
+	for _, drift := range []time.Duration{0, -MfaPeriod, MfaPeriod} {
+		expected, err := GenerateMfaCode(secret, t.Add(drift))
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return MfaTimeStep(t.Add(drift)), true
+		}
+	}
+	return 0, false
+}
+
+// MfaProvisioningURI returns the otpauth URI of a secret, to be shown as a
+// QR code and scanned by authenticator apps.
+func MfaProvisioningURI(issuer, accountName, secret string) string {
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(MfaCodeDigits))
+	params.Set("period", fmt.Sprint(int(MfaPeriod.Seconds())))
+
+	label := url.PathEscape(issuer + ":" + accountName)
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
+
+// GenerateMfaRecoveryCodes generates a new set of single use recovery
+// codes, formatted as two groups of five characters.
+func GenerateMfaRecoveryCodes() ([]string, error) {
+	codes := make([]string, MfaRecoveryCodeCount)
+	for i := range codes {
+		b := make([]byte, 10)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		for j := range b {
+			b[j] = recoveryCodeChars[int(b[j])%len(recoveryCodeChars)]
+		}
+		codes[i] = string(b[:5]) + "-" + string(b[5:])
+	}
+	return codes, nil
+}
+
+// HashMfaRecoveryCode hashes a recovery code to be stored. Recovery codes
+// are random, so a fast hash is enough.
+func HashMfaRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(code))))
+	return hex.EncodeToString(sum[:])
+}
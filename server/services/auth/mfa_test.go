@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"encoding/base32"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateMfaCode(t *testing.T) {
+	// test vectors from RFC 6238, truncated to six digits
+	secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+	for unix, expected := range map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	} {
+		code, err := GenerateMfaCode(secret, time.Unix(unix, 0))
+		require.NoError(t, err)
+		assert.Equal(t, expected, code, "time %d", unix)
+	}
+
+	_, err := GenerateMfaCode("not base32!", time.Now())
+	require.Error(t, err)
+}
+
+func TestValidateMfaCode(t *testing.T) {
+	secret, err := GenerateMfaSecret()
+	require.NoError(t, err)
+
+	now := time.Now()
+	code, err := GenerateMfaCode(secret, now)
+	require.NoError(t, err)
+
+	timeStep, ok := ValidateMfaCode(secret, code, now)
+	assert.True(t, ok)
+	assert.Equal(t, MfaTimeStep(now), timeStep)
+
+	_, ok = ValidateMfaCode(secret, " "+code+" ", now)
+	assert.True(t, ok)
+
+	// the code of the previous period is accepted with its own time step
+	timeStep, ok = ValidateMfaCode(secret, code, now.Add(MfaPeriod))
+	assert.True(t, ok)
+	assert.Equal(t, MfaTimeStep(now), timeStep)
+
+	_, ok = ValidateMfaCode(secret, code, now.Add(3*MfaPeriod))
+	assert.False(t, ok)
+	_, ok = ValidateMfaCode(secret, "", now)
+	assert.False(t, ok)
+	_, ok = ValidateMfaCode(secret, "12345", now)
+	assert.False(t, ok)
+}
+
+func TestMfaProvisioningURI(t *testing.T) {
+	uri := MfaProvisioningURI("Focalboard", "user@example.com", "SECRET")
+	require.True(t, strings.HasPrefix(uri, "otpauth://totp/Focalboard:user@example.com?"))
+
+	parsed, err := url.Parse(uri)
+	require.NoError(t, err)
+	assert.Equal(t, "SECRET", parsed.Query().Get("secret"))
+	assert.Equal(t, "Focalboard", parsed.Query().Get("issuer"))
+	assert.Equal(t, "6", parsed.Query().Get("digits"))
+}
+
+func TestGenerateMfaRecoveryCodes(t *testing.T) {
+	codes, err := GenerateMfaRecoveryCodes()
+	require.NoError(t, err)
+	require.Len(t, codes, MfaRecoveryCodeCount)
+
+	seen := map[string]bool{}
+	for _, code := range codes {
+		assert.Len(t, code, 11)
+		assert.False(t, seen[code])
+		seen[code] = true
+	}
+
+	assert.Equal(t, HashMfaRecoveryCode(codes[0]), HashMfaRecoveryCode(" "+strings.ToUpper(codes[0])))
+	assert.NotEqual(t, HashMfaRecoveryCode(codes[0]), HashMfaRecoveryCode(codes[1]))
+}
@@ -13,6 +13,9 @@ const (
 	HeaderAuth         = "Authorization"
 	HeaderBearer       = "BEARER"
 	SessionCookieToken = "FOCALBOARDAUTHTOKEN"
+
+	// AccessTokenPrefix starts every personal access token.
+	AccessTokenPrefix = "fbpat_"
 )
 
 type TokenLocation int
@@ -40,7 +43,13 @@ func (tl TokenLocation) String() string {
 }
 
 func ParseAuthTokenFromRequest(r *http.Request) (string, TokenLocation) {
-	authHeader := r.Header.Get(HeaderAuth)
+	headerToken := parseAuthHeader(r.Header.Get(HeaderAuth))
+
+	// A personal access token sent by a script takes precedence over the
+	// session cookie of a browser
+	if strings.HasPrefix(headerToken, AccessTokenPrefix) {
+		return headerToken, TokenLocationHeader
+	}
 
 	// Attempt to parse the token from the cookie
 	if cookie, err := r.Cookie(SessionCookieToken); err == nil {
@@ -48,14 +57,8 @@ func ParseAuthTokenFromRequest(r *http.Request) (string, TokenLocation) {
 	}
 
 	// Parse the token from the header
-	if len(authHeader) > 6 && strings.ToUpper(authHeader[0:6]) == HeaderBearer {
-		// Default session token
-		return authHeader[7:], TokenLocationHeader
-	}
-
-	if len(authHeader) > 5 && strings.ToLower(authHeader[0:5]) == HeaderToken {
-		// OAuth token
-		return authHeader[6:], TokenLocationHeader
+	if headerToken != "" {
+		return headerToken, TokenLocationHeader
 	}
 
 	// Attempt to parse token out of the query string
@@ -65,3 +68,17 @@ func ParseAuthTokenFromRequest(r *http.Request) (string, TokenLocation) {
 
 	return "", TokenLocationNotFound
 }
+
+func parseAuthHeader(authHeader string) string {
+	if len(authHeader) > 6 && strings.ToUpper(authHeader[0:6]) == HeaderBearer {
+		// Default session token
+		return authHeader[7:]
+	}
+
+	if len(authHeader) > 5 && strings.ToLower(authHeader[0:5]) == HeaderToken {
+		// OAuth token
+		return authHeader[6:]
+	}
+
+	return ""
+}
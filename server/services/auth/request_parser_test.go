@@ -22,6 +22,8 @@ func TestParseAuthTokenFromRequest(t *testing.T) {
 		{"BEARER mytoken", "", "", "mytoken", TokenLocationHeader},
 		{"", "mytoken", "", "mytoken", TokenLocationCookie},
 		{"", "", "mytoken", "mytoken", TokenLocationQueryString},
+		{"BEARER mytoken", "cookietoken", "", "cookietoken", TokenLocationCookie},
+		{"BEARER fbpat_mytoken", "cookietoken", "", "fbpat_mytoken", TokenLocationHeader},
 	}
 
 	for testnum, tc := range cases {
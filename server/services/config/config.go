@@ -26,6 +26,97 @@ type AmazonS3Config struct {
 	Timeout         int64
 }
 
+// OidcConfig is the OpenID Connect single sign-on configuration of
+// standalone servers.
+type OidcConfig struct {
+	Enable         bool     `json:"enable" mapstructure:"enable"`
+	DisplayName    string   `json:"displayName" mapstructure:"displayName"`
+	Issuer         string   `json:"issuer" mapstructure:"issuer"`
+	ClientID       string   `json:"clientId" mapstructure:"clientId"`
+	ClientSecret   string   `json:"clientSecret" mapstructure:"clientSecret"`
+	Scopes         []string `json:"scopes" mapstructure:"scopes"`
+	AllowedDomains []string `json:"allowedDomains" mapstructure:"allowedDomains"`
+	UsernameClaim  string   `json:"usernameClaim" mapstructure:"usernameClaim"`
+	EmailClaim     string   `json:"emailClaim" mapstructure:"emailClaim"`
+}
+
+// LdapConfig is the LDAP authentication and synchronization configuration
+// of standalone servers.
+type LdapConfig struct {
+	Enable                      bool               `json:"enable" mapstructure:"enable"`
+	URL                         string             `json:"url" mapstructure:"url"`
+	StartTLS                    bool               `json:"startTls" mapstructure:"startTls"`
+	SkipCertificateVerification bool               `json:"skipCertificateVerification" mapstructure:"skipCertificateVerification"`
+	BindDN                      string             `json:"bindDn" mapstructure:"bindDn"`
+	BindPassword                string             `json:"bindPassword" mapstructure:"bindPassword"`
+	BaseDN                      string             `json:"baseDn" mapstructure:"baseDn"`
+	UserFilter                  string             `json:"userFilter" mapstructure:"userFilter"`
+	LoginAttribute              string             `json:"loginAttribute" mapstructure:"loginAttribute"`
+	IDAttribute                 string             `json:"idAttribute" mapstructure:"idAttribute"`
+	UsernameAttribute           string             `json:"usernameAttribute" mapstructure:"usernameAttribute"`
+	EmailAttribute              string             `json:"emailAttribute" mapstructure:"emailAttribute"`
+	FirstNameAttribute          string             `json:"firstNameAttribute" mapstructure:"firstNameAttribute"`
+	LastNameAttribute           string             `json:"lastNameAttribute" mapstructure:"lastNameAttribute"`
+	NicknameAttribute           string             `json:"nicknameAttribute" mapstructure:"nicknameAttribute"`
+	GroupBaseDN                 string             `json:"groupBaseDn" mapstructure:"groupBaseDn"`
+	GroupFilter                 string             `json:"groupFilter" mapstructure:"groupFilter"`
+	GroupIDAttribute            string             `json:"groupIdAttribute" mapstructure:"groupIdAttribute"`
+	GroupMemberAttribute        string             `json:"groupMemberAttribute" mapstructure:"groupMemberAttribute"`
+	GroupMappings               []LdapGroupMapping `json:"groupMappings" mapstructure:"groupMappings"`
+	SyncIntervalMinutes         int                `json:"syncIntervalMinutes" mapstructure:"syncIntervalMinutes"`
+	MaxSyncDeactivations        int                `json:"maxSyncDeactivations" mapstructure:"maxSyncDeactivations"`
+}
+
+// LdapGroupMapping gives the members of an LDAP group access to a team or
+// to a board with a role.
+type LdapGroupMapping struct {
+	Group   string `json:"group" mapstructure:"group"`
+	TeamID  string `json:"teamId" mapstructure:"teamId"`
+	BoardID string `json:"boardId" mapstructure:"boardId"`
+	Role    string `json:"role" mapstructure:"role"`
+}
+
+// LockoutConfig is the brute-force protection of the logins of standalone
+// servers.
+type LockoutConfig struct {
+	Enable             bool `json:"enable" mapstructure:"enable"`
+	FreeFailures       int  `json:"freeFailures" mapstructure:"freeFailures"`
+	MaxDelaySeconds    int  `json:"maxDelaySeconds" mapstructure:"maxDelaySeconds"`
+	MaxAccountFailures int  `json:"maxAccountFailures" mapstructure:"maxAccountFailures"`
+	MaxIPFailures      int  `json:"maxIpFailures" mapstructure:"maxIpFailures"`
+	LockoutMinutes     int  `json:"lockoutMinutes" mapstructure:"lockoutMinutes"`
+}
+
+// SMTPConfig is the outgoing email server of standalone servers.
+type SMTPConfig struct {
+	Server                      string `json:"server" mapstructure:"server"`
+	Port                        int    `json:"port" mapstructure:"port"`
+	Username                    string `json:"username" mapstructure:"username"`
+	Password                    string `json:"password" mapstructure:"password"`
+	ConnectionSecurity          string `json:"connectionSecurity" mapstructure:"connectionSecurity"`
+	SkipCertificateVerification bool   `json:"skipCertificateVerification" mapstructure:"skipCertificateVerification"`
+	FromAddress                 string `json:"fromAddress" mapstructure:"fromAddress"`
+	FromName                    string `json:"fromName" mapstructure:"fromName"`
+}
+
+// PasswordConfig is the password policy of the local accounts, and the
+// expiry of the password reset links.
+type PasswordConfig struct {
+	MinimumLength      int  `json:"minimumLength" mapstructure:"minimumLength"`
+	Lowercase          bool `json:"lowercase" mapstructure:"lowercase"`
+	Uppercase          bool `json:"uppercase" mapstructure:"uppercase"`
+	Number             bool `json:"number" mapstructure:"number"`
+	Symbol             bool `json:"symbol" mapstructure:"symbol"`
+	ResetExpiryMinutes int  `json:"resetExpiryMinutes" mapstructure:"resetExpiryMinutes"`
+}
+
+// ScimConfig is the SCIM 2.0 provisioning API of standalone servers. The
+// identity providers authenticate with the bearer token.
+type ScimConfig struct {
+	Enable bool   `json:"enable" mapstructure:"enable"`
+	Token  string `json:"token" mapstructure:"token"`
+}
+
 // Configuration is the app configuration stored in a json file.
 type Configuration struct {
 	ServerRoot               string            `json:"serverRoot" mapstructure:"serverRoot"`
@@ -58,6 +149,16 @@ type Configuration struct {
 	TeammateNameDisplay      string            `json:"teammate_name_display" mapstructure:"teammateNameDisplay"`
 	ShowEmailAddress         bool              `json:"show_email_address" mapstructure:"showEmailAddress"`
 	ShowFullName             bool              `json:"show_full_name" mapstructure:"showFullName"`
+	RequireMfa               bool              `json:"require_mfa" mapstructure:"requireMfa"`
+	Oidc                     OidcConfig        `json:"oidc" mapstructure:"oidc"`
+	Ldap                     LdapConfig        `json:"ldap" mapstructure:"ldap"`
+	LoginLockout             LockoutConfig     `json:"loginLockout" mapstructure:"loginLockout"`
+	TrustedProxyIPHeader     string            `json:"trustedProxyIpHeader" mapstructure:"trustedProxyIpHeader"`
+	SMTP                     SMTPConfig        `json:"smtp" mapstructure:"smtp"`
+	Password                 PasswordConfig    `json:"password" mapstructure:"password"`
+	RequireEmailVerification bool              `json:"requireEmailVerification" mapstructure:"requireEmailVerification"`
+	AllowedSignupDomains     []string          `json:"allowedSignupDomains" mapstructure:"allowedSignupDomains"`
+	Scim                     ScimConfig        `json:"scim" mapstructure:"scim"`
 
 	AuthMode string `json:"authMode" mapstructure:"authMode"`
 
@@ -110,6 +211,37 @@ func ReadConfigFile(configFilePath string) (*Configuration, error) {
 	viper.SetDefault("TeammateNameDisplay", "username")
 	viper.SetDefault("ShowEmailAddress", false)
 	viper.SetDefault("ShowFullName", false)
+	viper.SetDefault("RequireMfa", false)
+	viper.SetDefault("Oidc.Enable", false)
+	viper.SetDefault("Oidc.DisplayName", "OpenID Connect")
+	viper.SetDefault("Oidc.Scopes", []string{"openid", "profile", "email"})
+	viper.SetDefault("Oidc.UsernameClaim", "preferred_username")
+	viper.SetDefault("Oidc.EmailClaim", "email")
+	viper.SetDefault("Ldap.Enable", false)
+	viper.SetDefault("Ldap.UserFilter", "(objectClass=person)")
+	viper.SetDefault("Ldap.LoginAttribute", "uid")
+	viper.SetDefault("Ldap.IDAttribute", "uid")
+	viper.SetDefault("Ldap.UsernameAttribute", "uid")
+	viper.SetDefault("Ldap.EmailAttribute", "mail")
+	viper.SetDefault("Ldap.FirstNameAttribute", "givenName")
+	viper.SetDefault("Ldap.LastNameAttribute", "sn")
+	viper.SetDefault("Ldap.GroupFilter", "(objectClass=groupOfNames)")
+	viper.SetDefault("Ldap.GroupIDAttribute", "cn")
+	viper.SetDefault("Ldap.GroupMemberAttribute", "member")
+	viper.SetDefault("Ldap.SyncIntervalMinutes", 60)
+	viper.SetDefault("Ldap.MaxSyncDeactivations", 50)
+	viper.SetDefault("LoginLockout.Enable", true)
+	viper.SetDefault("LoginLockout.FreeFailures", 3)
+	viper.SetDefault("LoginLockout.MaxDelaySeconds", 30)
+	viper.SetDefault("LoginLockout.MaxAccountFailures", 10)
+	viper.SetDefault("LoginLockout.MaxIPFailures", 50)
+	viper.SetDefault("LoginLockout.LockoutMinutes", 15)
+	viper.SetDefault("SMTP.Port", 25)
+	viper.SetDefault("Password.MinimumLength", 8)
+	viper.SetDefault("Password.ResetExpiryMinutes", 60)
+	viper.SetDefault("RequireEmailVerification", false)
+	viper.SetDefault("AllowedSignupDomains", []string{})
+	viper.SetDefault("Scim.Enable", false)
 
 	err := viper.ReadInConfig() // Find and read the config file
 	if err != nil {             // Handle errors reading the config file
@@ -131,5 +263,17 @@ func ReadConfigFile(configFilePath string) (*Configuration, error) {
 
 func removeSecurityData(config Configuration) Configuration {
 	clean := config
+	if clean.Oidc.ClientSecret != "" {
+		clean.Oidc.ClientSecret = "********"
+	}
+	if clean.Ldap.BindPassword != "" {
+		clean.Ldap.BindPassword = "********"
+	}
+	if clean.SMTP.Password != "" {
+		clean.SMTP.Password = "********"
+	}
+	if clean.Scim.Token != "" {
+		clean.Scim.Token = "********"
+	}
 	return clean
 }
@@ -0,0 +1,197 @@
+// Package email sends the emails of standalone servers through an SMTP
+// server.
+package email
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+const (
+	// ConnectionSecurityNone sends the emails in clear text.
+	ConnectionSecurityNone = ""
+	// ConnectionSecurityTLS connects to the server with TLS.
+	ConnectionSecurityTLS = "TLS"
+	// ConnectionSecuritySTARTTLS upgrades the connection with STARTTLS.
+	ConnectionSecuritySTARTTLS = "STARTTLS"
+
+	connectTimeout = 10 * time.Second
+	sendTimeout    = 30 * time.Second
+)
+
+var (
+	ErrNotConfigured  = errors.New("the SMTP server is not configured")
+	ErrInvalidAddress = errors.New("invalid email address")
+)
+
+// Message is a plain text email.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Sender sends emails with the SMTP configuration.
+type Sender struct {
+	cfg config.SMTPConfig
+}
+
+// New returns a sender for the SMTP configuration.
+func New(cfg config.SMTPConfig) *Sender {
+	return &Sender{cfg: cfg}
+}
+
+// IsConfigured tells if the SMTP server and the sender address are set.
+func IsConfigured(cfg config.SMTPConfig) bool {
+	return cfg.Server != "" && cfg.FromAddress != ""
+}
+
+// Send sends the message, it returns once the server accepted it.
+func (s *Sender) Send(msg Message) error {
+	if !IsConfigured(s.cfg) {
+		return ErrNotConfigured
+	}
+
+	from, err := mail.ParseAddress(s.cfg.FromAddress)
+	if err != nil {
+		return fmt.Errorf("invalid sender address: %w", err)
+	}
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidAddress, err.Error())
+	}
+	from.Name = s.cfg.FromName
+
+	data, err := buildMessage(from, to, msg)
+	if err != nil {
+		return err
+	}
+
+	client, err := s.connect()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if s.cfg.Username != "" {
+		auth := smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Server)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("unable to authenticate with the SMTP server: %w", err)
+		}
+	}
+
+	if err := client.Mail(from.Address); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to.Address); err != nil {
+		return err
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(data); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+func (s *Sender) connect() (*smtp.Client, error) {
+	address := net.JoinHostPort(s.cfg.Server, strconv.Itoa(s.cfg.Port))
+	tlsConfig := &tls.Config{
+		ServerName:         s.cfg.Server,
+		InsecureSkipVerify: s.cfg.SkipCertificateVerification, //nolint:gosec
+		MinVersion:         tls.VersionTLS12,
+	}
+
+	dialer := &net.Dialer{Timeout: connectTimeout}
+	var conn net.Conn
+	var err error
+	switch s.cfg.ConnectionSecurity {
+	case ConnectionSecurityTLS:
+		conn, err = tls.DialWithDialer(dialer, "tcp", address, tlsConfig)
+	case ConnectionSecurityNone, ConnectionSecuritySTARTTLS:
+		conn, err = dialer.Dial("tcp", address)
+	default:
+		return nil, fmt.Errorf("invalid SMTP connection security %q", s.cfg.ConnectionSecurity)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to the SMTP server: %w", err)
+	}
+	if err := conn.SetDeadline(time.Now().Add(sendTimeout)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	client, err := smtp.NewClient(conn, s.cfg.Server)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if s.cfg.ConnectionSecurity == ConnectionSecuritySTARTTLS {
+		if err := client.StartTLS(tlsConfig); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("unable to start TLS with the SMTP server: %w", err)
+		}
+	}
+	return client, nil
+}
+
+// buildMessage returns the headers and the quoted-printable body of the
+// message.
+func buildMessage(from, to *mail.Address, msg Message) ([]byte, error) {
+	if strings.ContainsAny(msg.Subject, "\r\n") {
+		return nil, errors.New("invalid email subject")
+	}
+
+	var buf bytes.Buffer
+	headers := [][2]string{
+		{"From", from.String()},
+		{"To", to.String()},
+		{"Subject", mime.QEncoding.Encode("utf-8", msg.Subject)},
+		{"Date", time.Now().Format(time.RFC1123Z)},
+		{"Message-ID", fmt.Sprintf("<%s@%s>", utils.NewID(utils.IDTypeNone), domainOf(from.Address))},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "text/plain; charset=UTF-8"},
+		{"Content-Transfer-Encoding", "quoted-printable"},
+	}
+	for _, header := range headers {
+		fmt.Fprintf(&buf, "%s: %s\r\n", header[0], header[1])
+	}
+	buf.WriteString("\r\n")
+
+	writer := quotedprintable.NewWriter(&buf)
+	body := strings.ReplaceAll(strings.ReplaceAll(msg.Body, "\r\n", "\n"), "\n", "\r\n")
+	if _, err := writer.Write([]byte(body)); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func domainOf(address string) string {
+	if i := strings.LastIndex(address, "@"); i >= 0 {
+		return address[i+1:]
+	}
+	return "localhost"
+}
@@ -0,0 +1,69 @@
+package email
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/services/email/emailtest"
+	"github.com/stretchr/testify/require"
+)
+
+func setupServer(t *testing.T) (*emailtest.Server, config.SMTPConfig) {
+	server, err := emailtest.NewServer()
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+
+	return server, config.SMTPConfig{
+		Server:      server.Host(),
+		Port:        server.Port(),
+		FromAddress: "boards@example.com",
+		FromName:    "Boards",
+	}
+}
+
+func TestSend(t *testing.T) {
+	t.Run("not configured", func(t *testing.T) {
+		err := New(config.SMTPConfig{}).Send(Message{To: "jdoe@example.com"})
+		require.ErrorIs(t, err, ErrNotConfigured)
+	})
+
+	t.Run("invalid recipient", func(t *testing.T) {
+		_, cfg := setupServer(t)
+		err := New(cfg).Send(Message{To: "not an address"})
+		require.ErrorIs(t, err, ErrInvalidAddress)
+	})
+
+	t.Run("invalid subject", func(t *testing.T) {
+		_, cfg := setupServer(t)
+		err := New(cfg).Send(Message{To: "jdoe@example.com", Subject: "Hello\r\nBcc: other@example.com"})
+		require.Error(t, err)
+	})
+
+	t.Run("the message is sent", func(t *testing.T) {
+		server, cfg := setupServer(t)
+
+		longLine := strings.Repeat("a", 100)
+		err := New(cfg).Send(Message{
+			To:      "John Doe <jdoe@example.com>",
+			Subject: "Réinitialiser",
+			Body:    "Hello,\n.leading dot\n" + longLine + "\n",
+		})
+		require.NoError(t, err)
+
+		messages := server.Messages()
+		require.Len(t, messages, 1)
+		require.Equal(t, "boards@example.com", messages[0].From)
+		require.Equal(t, []string{"jdoe@example.com"}, messages[0].To)
+		require.Equal(t, "Réinitialiser", messages[0].Subject)
+		require.Equal(t, "Hello,\n.leading dot\n"+longLine+"\n", messages[0].Body)
+		require.Contains(t, messages[0].Raw, `From: "Boards" <boards@example.com>`)
+	})
+
+	t.Run("invalid connection security", func(t *testing.T) {
+		_, cfg := setupServer(t)
+		cfg.ConnectionSecurity = "SSL"
+		err := New(cfg).Send(Message{To: "jdoe@example.com"})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,184 @@
+// Package emailtest provides an in-process SMTP server to test the emails
+// without an external mail server.
+//
+// The server accepts every message without authentication and keeps them
+// in memory.
+package emailtest
+
+import (
+	"bufio"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Message is an email received by the server.
+type Message struct {
+	From    string
+	To      []string
+	Subject string
+	Body    string
+	Raw     string
+}
+
+// Server is an SMTP server listening on a local port.
+type Server struct {
+	listener net.Listener
+	mu       sync.Mutex
+	messages []Message
+	wg       sync.WaitGroup
+}
+
+// NewServer starts a server on a random local port.
+func NewServer() (*Server, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{listener: listener}
+	s.wg.Add(1)
+	go s.serve()
+	return s, nil
+}
+
+// Host returns the host the server listens on.
+func (s *Server) Host() string {
+	return s.listener.Addr().(*net.TCPAddr).IP.String()
+}
+
+// Port returns the port the server listens on.
+func (s *Server) Port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+// Messages returns the messages received so far.
+func (s *Server) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Message{}, s.messages...)
+}
+
+// Close stops the server.
+func (s *Server) Close() {
+	s.listener.Close()
+	s.wg.Wait()
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handle(conn)
+		}()
+	}
+}
+
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	reply := func(code int, text string) {
+		_, _ = io.WriteString(conn, strconv.Itoa(code)+" "+text+"\r\n")
+	}
+
+	reply(220, "emailtest ready")
+	var from string
+	var to []string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		verb := strings.ToUpper(line)
+		if i := strings.Index(verb, " "); i >= 0 {
+			verb = verb[:i]
+		}
+
+		switch verb {
+		case "HELO", "EHLO":
+			reply(250, "emailtest")
+		case "MAIL":
+			from = addressArgument(line)
+			to = nil
+			reply(250, "OK")
+		case "RCPT":
+			to = append(to, addressArgument(line))
+			reply(250, "OK")
+		case "DATA":
+			reply(354, "end data with <CR><LF>.<CR><LF>")
+			raw, err := readData(reader)
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.messages = append(s.messages, parseMessage(from, to, raw))
+			s.mu.Unlock()
+			reply(250, "OK")
+		case "RSET", "NOOP":
+			reply(250, "OK")
+		case "QUIT":
+			reply(221, "bye")
+			return
+		default:
+			reply(502, "command not implemented")
+		}
+	}
+}
+
+func addressArgument(line string) string {
+	start := strings.Index(line, "<")
+	end := strings.LastIndex(line, ">")
+	if start < 0 || end < start {
+		return ""
+	}
+	return line[start+1 : end]
+}
+
+func readData(reader *bufio.Reader) (string, error) {
+	var data strings.Builder
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return "", err
+		}
+		if line == ".\r\n" || line == ".\n" {
+			return data.String(), nil
+		}
+		data.WriteString(strings.TrimPrefix(line, "."))
+	}
+}
+
+func parseMessage(from string, to []string, raw string) Message {
+	message := Message{From: from, To: to, Raw: raw}
+
+	parsed, err := mail.ReadMessage(strings.NewReader(raw))
+	if err != nil {
+		return message
+	}
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if err == nil {
+		message.Subject = subject
+	}
+
+	var body io.Reader = parsed.Body
+	if strings.EqualFold(parsed.Header.Get("Content-Transfer-Encoding"), "quoted-printable") {
+		body = quotedprintable.NewReader(body)
+	}
+	if data, err := io.ReadAll(body); err == nil {
+		message.Body = strings.ReplaceAll(string(data), "\r\n", "\n")
+	}
+	return message
+}
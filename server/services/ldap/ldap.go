@@ -0,0 +1,359 @@
+// Package ldap authenticates users against an LDAP directory and reads the
+// users and groups to synchronize.
+package ldap
+
+import (
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mattermost/focalboard/server/services/config"
+
+	goldap "github.com/mattermost/ldap"
+)
+
+const (
+	defaultLoginAttribute       = "uid"
+	defaultUsernameAttribute    = "uid"
+	defaultEmailAttribute       = "mail"
+	defaultGroupIDAttribute     = "cn"
+	defaultGroupMemberAttribute = "member"
+	defaultGroupFilter          = "(objectClass=groupOfNames)"
+
+	searchPageSize = 500
+	connectTimeout = 10 * time.Second
+)
+
+var (
+	ErrUserNotFound       = errors.New("user not found in the directory")
+	ErrInvalidCredentials = errors.New("invalid directory credentials")
+	ErrNotConfigured      = errors.New("the LDAP server is not configured")
+)
+
+// Entry is a user of the directory with the attributes mapped to the
+// fields of the users.
+type Entry struct {
+	DN        string
+	ID        string
+	Username  string
+	Email     string
+	FirstName string
+	LastName  string
+	Nickname  string
+}
+
+// Group is a group of the directory with the DNs of its members.
+type Group struct {
+	DN      string
+	ID      string
+	Members []string
+}
+
+// HasMember tells if the user entry is a member of the group. Members are
+// matched by DN, or by ID for the groups listing user IDs like posixGroup.
+func (g *Group) HasMember(entry *Entry) bool {
+	dn := normalizeDN(entry.DN)
+	for _, member := range g.Members {
+		if normalizeDN(member) == dn || strings.EqualFold(member, entry.ID) {
+			return true
+		}
+	}
+	return false
+}
+
+// Client reads the directory with the configured service account.
+type Client struct {
+	cfg config.LdapConfig
+}
+
+// New returns a client for the LDAP configuration.
+func New(cfg config.LdapConfig) *Client {
+	return &Client{cfg: cfg}
+}
+
+// Authenticate finds the user with the login attribute and checks its
+// password by binding as the user.
+func (c *Client) Authenticate(login, password string) (*Entry, error) {
+	// an empty password would be an unauthenticated bind, that succeeds
+	// on some servers
+	if login == "" || password == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	conn, err := c.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	filter := fmt.Sprintf("(%s=%s)", attributeOrDefault(c.cfg.LoginAttribute, defaultLoginAttribute), goldap.EscapeFilter(login))
+	entries, err := c.searchUsers(conn, filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, ErrUserNotFound
+	}
+	if len(entries) > 1 {
+		return nil, fmt.Errorf("the login %q matches %d directory entries", login, len(entries))
+	}
+
+	if err := conn.Bind(entries[0].DN, password); err != nil {
+		if goldap.IsErrorWithCode(err, goldap.LDAPResultInvalidCredentials) {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("unable to bind as the user: %w", err)
+	}
+
+	return entries[0], nil
+}
+
+// GetUsers returns all the users of the directory matching the user
+// filter.
+func (c *Client) GetUsers() ([]*Entry, error) {
+	conn, err := c.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	return c.searchUsers(conn, "")
+}
+
+// GetGroups returns the groups of the directory matching the group
+// filter.
+func (c *Client) GetGroups() ([]*Group, error) {
+	conn, err := c.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	baseDN := c.cfg.GroupBaseDN
+	if baseDN == "" {
+		baseDN = c.cfg.BaseDN
+	}
+	idAttribute := attributeOrDefault(c.cfg.GroupIDAttribute, defaultGroupIDAttribute)
+	memberAttribute := attributeOrDefault(c.cfg.GroupMemberAttribute, defaultGroupMemberAttribute)
+
+	request := goldap.NewSearchRequest(
+		baseDN, goldap.ScopeWholeSubtree, goldap.NeverDerefAliases, 0, 0, false,
+		normalizeFilter(attributeOrDefault(c.cfg.GroupFilter, defaultGroupFilter)),
+		[]string{idAttribute, memberAttribute},
+		nil,
+	)
+	result, err := conn.SearchWithPaging(request, searchPageSize)
+	if err != nil {
+		return nil, fmt.Errorf("unable to search the groups: %w", err)
+	}
+
+	groups := make([]*Group, 0, len(result.Entries))
+	for _, entry := range result.Entries {
+		id := attributeValue(entry, idAttribute)
+		if id == "" {
+			continue
+		}
+		groups = append(groups, &Group{
+			DN:      entry.DN,
+			ID:      id,
+			Members: attributeValues(entry, memberAttribute),
+		})
+	}
+	return groups, nil
+}
+
+func (c *Client) searchUsers(conn *goldap.Conn, filter string) ([]*Entry, error) {
+	userFilter := normalizeFilter(c.cfg.UserFilter)
+	switch {
+	case userFilter != "" && filter != "":
+		filter = "(&" + userFilter + filter + ")"
+	case filter == "":
+		filter = userFilter
+	}
+	if filter == "" {
+		filter = "(objectClass=*)"
+	}
+
+	request := goldap.NewSearchRequest(
+		c.cfg.BaseDN, goldap.ScopeWholeSubtree, goldap.NeverDerefAliases, 0, 0, false,
+		filter, c.userAttributes(), nil,
+	)
+	result, err := conn.SearchWithPaging(request, searchPageSize)
+	if err != nil {
+		return nil, fmt.Errorf("unable to search the users: %w", err)
+	}
+
+	entries := make([]*Entry, 0, len(result.Entries))
+	for _, ldapEntry := range result.Entries {
+		entry := c.mapEntry(ldapEntry)
+		if entry.ID == "" || entry.Username == "" {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (c *Client) userAttributes() []string {
+	attributes := []string{}
+	for _, attribute := range []string{
+		c.idAttribute(),
+		attributeOrDefault(c.cfg.UsernameAttribute, defaultUsernameAttribute),
+		attributeOrDefault(c.cfg.EmailAttribute, defaultEmailAttribute),
+		c.cfg.FirstNameAttribute,
+		c.cfg.LastNameAttribute,
+		c.cfg.NicknameAttribute,
+	} {
+		if attribute != "" {
+			attributes = append(attributes, attribute)
+		}
+	}
+	return attributes
+}
+
+func (c *Client) mapEntry(ldapEntry *goldap.Entry) *Entry {
+	entry := &Entry{
+		DN:       ldapEntry.DN,
+		ID:       attributeValue(ldapEntry, c.idAttribute()),
+		Username: attributeValue(ldapEntry, attributeOrDefault(c.cfg.UsernameAttribute, defaultUsernameAttribute)),
+		Email:    attributeValue(ldapEntry, attributeOrDefault(c.cfg.EmailAttribute, defaultEmailAttribute)),
+	}
+	if c.cfg.FirstNameAttribute != "" {
+		entry.FirstName = attributeValue(ldapEntry, c.cfg.FirstNameAttribute)
+	}
+	if c.cfg.LastNameAttribute != "" {
+		entry.LastName = attributeValue(ldapEntry, c.cfg.LastNameAttribute)
+	}
+	if c.cfg.NicknameAttribute != "" {
+		entry.Nickname = attributeValue(ldapEntry, c.cfg.NicknameAttribute)
+	}
+	return entry
+}
+
+// idAttribute is the attribute that identifies the users, it should not
+// change when users are renamed.
+func (c *Client) idAttribute() string {
+	if c.cfg.IDAttribute != "" {
+		return c.cfg.IDAttribute
+	}
+	return attributeOrDefault(c.cfg.LoginAttribute, defaultLoginAttribute)
+}
+
+// connect opens a connection bound with the service account, or an
+// anonymous one if there is no service account.
+func (c *Client) connect() (*goldap.Conn, error) {
+	if c.cfg.URL == "" || c.cfg.BaseDN == "" {
+		return nil, ErrNotConfigured
+	}
+
+	serverURL, err := url.Parse(c.cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid LDAP URL: %w", err)
+	}
+	tlsConfig := &tls.Config{
+		ServerName:         serverURL.Hostname(),
+		InsecureSkipVerify: c.cfg.SkipCertificateVerification, //nolint:gosec
+	}
+
+	var conn *goldap.Conn
+	switch serverURL.Scheme {
+	case "ldaps":
+		conn, err = goldap.DialTLS("tcp", hostWithPort(serverURL, "636"), tlsConfig)
+	case "ldap":
+		conn, err = goldap.Dial("tcp", hostWithPort(serverURL, "389"))
+	default:
+		return nil, fmt.Errorf("unsupported LDAP URL scheme %q", serverURL.Scheme)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to the LDAP server: %w", err)
+	}
+	conn.Start()
+	conn.SetTimeout(connectTimeout)
+
+	if c.cfg.StartTLS && serverURL.Scheme == "ldap" {
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("unable to start TLS: %w", err)
+		}
+	}
+
+	if c.cfg.BindDN != "" {
+		if err := conn.Bind(c.cfg.BindDN, c.cfg.BindPassword); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("unable to bind with the service account: %w", err)
+		}
+	}
+
+	return conn, nil
+}
+
+func hostWithPort(serverURL *url.URL, defaultPort string) string {
+	if serverURL.Port() != "" {
+		return serverURL.Host
+	}
+	return serverURL.Hostname() + ":" + defaultPort
+}
+
+// findAttribute returns an attribute of the entry, the attribute names
+// are case insensitive.
+func findAttribute(entry *goldap.Entry, name string) *goldap.EntryAttribute {
+	for _, attribute := range entry.Attributes {
+		if strings.EqualFold(attribute.Name, name) {
+			return attribute
+		}
+	}
+	return nil
+}
+
+func attributeValues(entry *goldap.Entry, name string) []string {
+	if attribute := findAttribute(entry, name); attribute != nil {
+		return attribute.Values
+	}
+	return nil
+}
+
+// attributeValue returns the first value of an attribute. Binary values,
+// like Active Directory GUIDs, are hex encoded.
+func attributeValue(entry *goldap.Entry, name string) string {
+	attribute := findAttribute(entry, name)
+	if attribute == nil || len(attribute.ByteValues) == 0 {
+		return ""
+	}
+	raw := attribute.ByteValues[0]
+	if !utf8.Valid(raw) {
+		return hex.EncodeToString(raw)
+	}
+	return strings.TrimSpace(string(raw))
+}
+
+func attributeOrDefault(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+// normalizeDN lowercases the DN and removes the spaces between its
+// components, so equivalent DNs compare equal.
+func normalizeDN(dn string) string {
+	parts := strings.Split(dn, ",")
+	for i, part := range parts {
+		parts[i] = strings.ToLower(strings.TrimSpace(part))
+	}
+	return strings.Join(parts, ",")
+}
+
+// normalizeFilter wraps filters in parentheses, which are often omitted
+// in the configuration.
+func normalizeFilter(filter string) string {
+	filter = strings.TrimSpace(filter)
+	if filter != "" && !strings.HasPrefix(filter, "(") {
+		filter = "(" + filter + ")"
+	}
+	return filter
+}
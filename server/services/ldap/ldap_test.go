@@ -0,0 +1,148 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/services/ldap/ldaptest"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testBaseDN       = "dc=example,dc=com"
+	testBindDN       = "cn=admin,dc=example,dc=com"
+	testBindPassword = "admin-password"
+)
+
+func setupServer(t *testing.T) (*ldaptest.Server, config.LdapConfig) {
+	server, err := ldaptest.NewServer()
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+
+	server.AddEntry(testBindDN, map[string][]string{
+		"objectClass":  {"organizationalRole"},
+		"cn":           {"admin"},
+		"userPassword": {testBindPassword},
+	})
+	server.AddEntry("uid=jdoe,ou=people,dc=example,dc=com", map[string][]string{
+		"objectClass":  {"person", "inetOrgPerson"},
+		"uid":          {"jdoe"},
+		"mail":         {"jdoe@example.com"},
+		"givenName":    {"John"},
+		"sn":           {"Doe"},
+		"userPassword": {"secret"},
+	})
+	server.AddEntry("uid=asmith,ou=people,dc=example,dc=com", map[string][]string{
+		"objectClass":  {"person", "inetOrgPerson"},
+		"uid":          {"asmith"},
+		"mail":         {"asmith@example.com"},
+		"userPassword": {"other-secret"},
+	})
+	server.AddEntry("cn=developers,ou=groups,dc=example,dc=com", map[string][]string{
+		"objectClass": {"groupOfNames"},
+		"cn":          {"developers"},
+		"member":      {"uid=jdoe, ou=people, dc=example, dc=com"},
+	})
+
+	cfg := config.LdapConfig{
+		Enable:             true,
+		URL:                server.URL(),
+		BindDN:             testBindDN,
+		BindPassword:       testBindPassword,
+		BaseDN:             testBaseDN,
+		UserFilter:         "objectClass=person",
+		LoginAttribute:     "uid",
+		EmailAttribute:     "mail",
+		FirstNameAttribute: "givenName",
+		LastNameAttribute:  "sn",
+	}
+	return server, cfg
+}
+
+func TestAuthenticate(t *testing.T) {
+	_, cfg := setupServer(t)
+	client := New(cfg)
+
+	t.Run("valid credentials", func(t *testing.T) {
+		entry, err := client.Authenticate("jdoe", "secret")
+		require.NoError(t, err)
+		require.Equal(t, "uid=jdoe,ou=people,dc=example,dc=com", entry.DN)
+		require.Equal(t, "jdoe", entry.ID)
+		require.Equal(t, "jdoe", entry.Username)
+		require.Equal(t, "jdoe@example.com", entry.Email)
+		require.Equal(t, "John", entry.FirstName)
+		require.Equal(t, "Doe", entry.LastName)
+	})
+
+	t.Run("invalid password", func(t *testing.T) {
+		_, err := client.Authenticate("jdoe", "wrong")
+		require.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+
+	t.Run("empty password", func(t *testing.T) {
+		_, err := client.Authenticate("jdoe", "")
+		require.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		_, err := client.Authenticate("nobody", "secret")
+		require.ErrorIs(t, err, ErrUserNotFound)
+	})
+
+	t.Run("filter characters are escaped", func(t *testing.T) {
+		_, err := client.Authenticate("*", "secret")
+		require.ErrorIs(t, err, ErrUserNotFound)
+	})
+
+	t.Run("invalid service account", func(t *testing.T) {
+		invalidCfg := cfg
+		invalidCfg.BindPassword = "wrong"
+		_, err := New(invalidCfg).Authenticate("jdoe", "secret")
+		require.Error(t, err)
+		require.NotErrorIs(t, err, ErrInvalidCredentials)
+	})
+
+	t.Run("not configured", func(t *testing.T) {
+		_, err := New(config.LdapConfig{Enable: true}).Authenticate("jdoe", "secret")
+		require.ErrorIs(t, err, ErrNotConfigured)
+	})
+}
+
+func TestGetUsersAndGroups(t *testing.T) {
+	server, cfg := setupServer(t)
+	client := New(cfg)
+
+	users, err := client.GetUsers()
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+
+	usernames := []string{users[0].Username, users[1].Username}
+	require.ElementsMatch(t, []string{"jdoe", "asmith"}, usernames)
+
+	groups, err := client.GetGroups()
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	require.Equal(t, "developers", groups[0].ID)
+
+	for _, user := range users {
+		require.Equal(t, user.Username == "jdoe", groups[0].HasMember(user), user.Username)
+	}
+
+	t.Run("removed users aren't returned", func(t *testing.T) {
+		server.DeleteEntry("uid=asmith,ou=people,dc=example,dc=com")
+
+		users, err := client.GetUsers()
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		require.Equal(t, "jdoe", users[0].Username)
+	})
+}
+
+func TestGroupHasMember(t *testing.T) {
+	entry := &Entry{DN: "uid=jdoe,ou=people,dc=example,dc=com", ID: "jdoe"}
+
+	require.True(t, (&Group{Members: []string{"UID=jdoe, OU=People, DC=example, DC=com"}}).HasMember(entry))
+	require.True(t, (&Group{Members: []string{"jdoe"}}).HasMember(entry))
+	require.False(t, (&Group{Members: []string{"uid=jdoe2,ou=people,dc=example,dc=com"}}).HasMember(entry))
+	require.False(t, (&Group{}).HasMember(entry))
+}
@@ -0,0 +1,467 @@
+// Package ldaptest provides an in-process LDAP server to test the LDAP
+// authentication and synchronization without an external directory.
+//
+// The server supports simple binds and searches with the usual filters,
+// entries authenticate with the clear text value of their userPassword
+// attribute.
+package ldaptest
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"sync"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+)
+
+const (
+	applicationBindRequest       = 0
+	applicationBindResponse      = 1
+	applicationUnbindRequest     = 2
+	applicationSearchRequest     = 3
+	applicationSearchResultEntry = 4
+	applicationSearchResultDone  = 5
+	applicationExtendedRequest   = 23
+	applicationExtendedResponse  = 24
+
+	resultSuccess            = 0
+	resultProtocolError      = 2
+	resultInvalidCredentials = 49
+	resultUnwillingToPerform = 53
+	resultNoSuchObject       = 32
+
+	scopeBaseObject   = 0
+	scopeSingleLevel  = 1
+	scopeWholeSubtree = 2
+
+	filterAnd            = 0
+	filterOr             = 1
+	filterNot            = 2
+	filterEqualityMatch  = 3
+	filterSubstrings     = 4
+	filterGreaterOrEqual = 5
+	filterLessOrEqual    = 6
+	filterPresent        = 7
+	filterApproxMatch    = 8
+
+	substringsInitial = 0
+	substringsAny     = 1
+	substringsFinal   = 2
+
+	passwordAttribute = "userPassword"
+)
+
+// Entry is an entry of the directory.
+type Entry struct {
+	DN         string
+	Attributes map[string][]string
+}
+
+func (e *Entry) values(name string) []string {
+	for attribute, values := range e.Attributes {
+		if strings.EqualFold(attribute, name) {
+			return values
+		}
+	}
+	return nil
+}
+
+// Server is an in-process LDAP server.
+type Server struct {
+	listener net.Listener
+
+	mux     sync.RWMutex
+	entries map[string]*Entry
+
+	wg sync.WaitGroup
+}
+
+// NewServer starts an LDAP server on a random local port, it must be
+// closed after use.
+func NewServer() (*Server, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		listener: listener,
+		entries:  map[string]*Entry{},
+	}
+
+	s.wg.Add(1)
+	go s.serve()
+
+	return s, nil
+}
+
+// URL returns the ldap:// URL of the server.
+func (s *Server) URL() string {
+	return "ldap://" + s.listener.Addr().String()
+}
+
+// AddEntry adds an entry to the directory, replacing any entry with the
+// same DN.
+func (s *Server) AddEntry(dn string, attributes map[string][]string) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.entries[normalizeDN(dn)] = &Entry{DN: dn, Attributes: attributes}
+}
+
+// DeleteEntry removes an entry from the directory.
+func (s *Server) DeleteEntry(dn string) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	delete(s.entries, normalizeDN(dn))
+}
+
+// SetAttribute replaces the values of an attribute of an entry.
+func (s *Server) SetAttribute(dn, name string, values []string) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	entry, ok := s.entries[normalizeDN(dn)]
+	if !ok {
+		return
+	}
+	for attribute := range entry.Attributes {
+		if strings.EqualFold(attribute, name) {
+			delete(entry.Attributes, attribute)
+		}
+	}
+	entry.Attributes[name] = values
+}
+
+// Close stops the server.
+func (s *Server) Close() {
+	_ = s.listener.Close()
+	s.wg.Wait()
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.wg.Add(1)
+		go s.handleConn(conn)
+	}
+}
+
+func (s *Server) handleConn(conn net.Conn) {
+	defer s.wg.Done()
+	defer conn.Close()
+
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil {
+			return
+		}
+		if len(packet.Children) < 2 {
+			return
+		}
+
+		messageID, _ := packet.Children[0].Value.(int64)
+		request := packet.Children[1]
+
+		var responses []*ber.Packet
+		switch request.Tag {
+		case applicationBindRequest:
+			responses = []*ber.Packet{s.handleBind(request)}
+		case applicationSearchRequest:
+			responses = s.handleSearch(request)
+		case applicationUnbindRequest:
+			return
+		case applicationExtendedRequest:
+			// StartTLS and the other extended operations aren't supported
+			responses = []*ber.Packet{newResult(applicationExtendedResponse, resultProtocolError, "unsupported extended operation")}
+		default:
+			return
+		}
+
+		for _, response := range responses {
+			message := ber.NewSequence("LDAPMessage")
+			message.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageID, "messageID"))
+			message.AppendChild(response)
+			if _, err := conn.Write(message.Bytes()); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (s *Server) handleBind(request *ber.Packet) *ber.Packet {
+	if len(request.Children) < 3 {
+		return newResult(applicationBindResponse, resultProtocolError, "invalid bind request")
+	}
+
+	dn, _ := request.Children[1].Value.(string)
+	credentials := request.Children[2]
+	if credentials.ClassType != ber.ClassContext || credentials.Tag != 0 {
+		return newResult(applicationBindResponse, resultUnwillingToPerform, "only simple binds are supported")
+	}
+	password := ber.DecodeString(credentials.Data.Bytes())
+
+	// anonymous binds are allowed, unauthenticated ones aren't
+	if dn == "" && password == "" {
+		return newResult(applicationBindResponse, resultSuccess, "")
+	}
+	if password == "" {
+		return newResult(applicationBindResponse, resultUnwillingToPerform, "unauthenticated bind")
+	}
+
+	s.mux.RLock()
+	entry, ok := s.entries[normalizeDN(dn)]
+	s.mux.RUnlock()
+	if !ok || !containsValue(entry.values(passwordAttribute), password) {
+		return newResult(applicationBindResponse, resultInvalidCredentials, "invalid credentials")
+	}
+
+	return newResult(applicationBindResponse, resultSuccess, "")
+}
+
+func (s *Server) handleSearch(request *ber.Packet) []*ber.Packet {
+	if len(request.Children) < 8 {
+		return []*ber.Packet{newResult(applicationSearchResultDone, resultProtocolError, "invalid search request")}
+	}
+
+	baseDN, _ := request.Children[0].Value.(string)
+	scope, _ := request.Children[1].Value.(int64)
+	sizeLimit, _ := request.Children[3].Value.(int64)
+	filter := request.Children[6]
+
+	var attributes []string
+	for _, attribute := range request.Children[7].Children {
+		if name, ok := attribute.Value.(string); ok {
+			attributes = append(attributes, name)
+		}
+	}
+
+	base := normalizeDN(baseDN)
+
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	if _, ok := s.entries[base]; !ok && scope == scopeBaseObject {
+		return []*ber.Packet{newResult(applicationSearchResultDone, resultNoSuchObject, "no such object")}
+	}
+
+	responses := []*ber.Packet{}
+	for key, entry := range s.entries {
+		if !inScope(key, base, scope) {
+			continue
+		}
+		matches, err := matchFilter(entry, filter)
+		if err != nil {
+			return []*ber.Packet{newResult(applicationSearchResultDone, resultProtocolError, err.Error())}
+		}
+		if !matches {
+			continue
+		}
+		responses = append(responses, encodeEntry(entry, attributes))
+		if sizeLimit > 0 && int64(len(responses)) >= sizeLimit {
+			break
+		}
+	}
+
+	return append(responses, newResult(applicationSearchResultDone, resultSuccess, ""))
+}
+
+func inScope(dn, base string, scope int64) bool {
+	switch scope {
+	case scopeBaseObject:
+		return dn == base
+	case scopeSingleLevel:
+		parent := ""
+		if i := strings.Index(dn, ","); i >= 0 {
+			parent = dn[i+1:]
+		}
+		return parent == base
+	default:
+		return base == "" || dn == base || strings.HasSuffix(dn, ","+base)
+	}
+}
+
+func matchFilter(entry *Entry, filter *ber.Packet) (bool, error) {
+	if filter.ClassType != ber.ClassContext {
+		return false, errors.New("invalid filter")
+	}
+
+	switch filter.Tag {
+	case filterAnd:
+		for _, child := range filter.Children {
+			matches, err := matchFilter(entry, child)
+			if err != nil || !matches {
+				return false, err
+			}
+		}
+		return true, nil
+	case filterOr:
+		for _, child := range filter.Children {
+			matches, err := matchFilter(entry, child)
+			if err != nil {
+				return false, err
+			}
+			if matches {
+				return true, nil
+			}
+		}
+		return false, nil
+	case filterNot:
+		if len(filter.Children) != 1 {
+			return false, errors.New("invalid not filter")
+		}
+		matches, err := matchFilter(entry, filter.Children[0])
+		return !matches, err
+	case filterPresent:
+		name := ber.DecodeString(filter.Data.Bytes())
+		if strings.EqualFold(name, "objectClass") {
+			return true, nil
+		}
+		return len(entry.values(name)) > 0, nil
+	case filterEqualityMatch, filterApproxMatch, filterGreaterOrEqual, filterLessOrEqual:
+		if len(filter.Children) != 2 {
+			return false, errors.New("invalid attribute value assertion")
+		}
+		name := ber.DecodeString(filter.Children[0].Data.Bytes())
+		value := ber.DecodeString(filter.Children[1].Data.Bytes())
+		for _, v := range entry.values(name) {
+			switch filter.Tag {
+			case filterGreaterOrEqual:
+				if strings.ToLower(v) >= strings.ToLower(value) {
+					return true, nil
+				}
+			case filterLessOrEqual:
+				if strings.ToLower(v) <= strings.ToLower(value) {
+					return true, nil
+				}
+			default:
+				if matchValue(name, v, value) {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	case filterSubstrings:
+		if len(filter.Children) != 2 {
+			return false, errors.New("invalid substrings filter")
+		}
+		name := ber.DecodeString(filter.Children[0].Data.Bytes())
+		for _, v := range entry.values(name) {
+			if matchSubstrings(strings.ToLower(v), filter.Children[1].Children) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	return false, errors.New("unsupported filter")
+}
+
+func matchValue(name, value, assertion string) bool {
+	if isDNAttribute(name) {
+		return normalizeDN(value) == normalizeDN(assertion)
+	}
+	return strings.EqualFold(value, assertion)
+}
+
+func matchSubstrings(value string, parts []*ber.Packet) bool {
+	for _, part := range parts {
+		substring := strings.ToLower(ber.DecodeString(part.Data.Bytes()))
+		switch part.Tag {
+		case substringsInitial:
+			if !strings.HasPrefix(value, substring) {
+				return false
+			}
+			value = value[len(substring):]
+		case substringsAny:
+			i := strings.Index(value, substring)
+			if i < 0 {
+				return false
+			}
+			value = value[i+len(substring):]
+		case substringsFinal:
+			if !strings.HasSuffix(value, substring) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func encodeEntry(entry *Entry, attributes []string) *ber.Packet {
+	response := ber.Encode(ber.ClassApplication, ber.TypeConstructed, applicationSearchResultEntry, nil, "Search Result Entry")
+	response.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, entry.DN, "objectName"))
+
+	list := ber.NewSequence("attributes")
+	for name, values := range entry.Attributes {
+		if strings.EqualFold(name, passwordAttribute) || !isRequested(name, attributes) {
+			continue
+		}
+		attribute := ber.NewSequence("partialAttribute")
+		attribute.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "type"))
+		set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "vals")
+		for _, value := range values {
+			set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, "value"))
+		}
+		attribute.AppendChild(set)
+		list.AppendChild(attribute)
+	}
+	response.AppendChild(list)
+
+	return response
+}
+
+func isRequested(name string, attributes []string) bool {
+	if len(attributes) == 0 {
+		return true
+	}
+	for _, attribute := range attributes {
+		if attribute == "*" || strings.EqualFold(attribute, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func newResult(application ber.Tag, code int64, message string) *ber.Packet {
+	response := ber.Encode(ber.ClassApplication, ber.TypeConstructed, application, nil, "Response")
+	response.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, "resultCode"))
+	response.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "matchedDN"))
+	response.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, message, "diagnosticMessage"))
+	return response
+}
+
+func isDNAttribute(name string) bool {
+	switch strings.ToLower(name) {
+	case "member", "uniquemember", "memberof", "manager":
+		return true
+	}
+	return false
+}
+
+// normalizeDN makes DNs comparable, ignoring the case and the spaces
+// around the separators.
+func normalizeDN(dn string) string {
+	parts := strings.Split(dn, ",")
+	for i, part := range parts {
+		rdn := strings.SplitN(part, "=", 2)
+		for j := range rdn {
+			rdn[j] = strings.TrimSpace(rdn[j])
+		}
+		parts[i] = strings.ToLower(strings.Join(rdn, "="))
+	}
+	return strings.Join(parts, ",")
+}
+
+func containsValue(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
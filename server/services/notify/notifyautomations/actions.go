@@ -0,0 +1,255 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifyautomations
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/permissions"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/wiggin77/merror"
+)
+
+const (
+	webhookTimeout = 10 * time.Second
+)
+
+// WebhookPayload is the body posted by the callWebhook action.
+type WebhookPayload struct {
+	RuleID   string       `json:"ruleId"`
+	RuleName string       `json:"ruleName"`
+	BoardID  string       `json:"boardId"`
+	Card     *model.Block `json:"card"`
+}
+
+// carrierGradeNAT is the shared address space of RFC 6598, which isn't
+// covered by net.IP.IsPrivate.
+var carrierGradeNAT = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// isPublicIP returns true if the address isn't a loopback, private, link
+// local or otherwise internal address.
+func isPublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsUnspecified() &&
+		!carrierGradeNAT.Contains(ip)
+}
+
+type webhookClient struct {
+	client    *http.Client
+	isAllowed func(ip net.IP) bool
+}
+
+// newWebhookClient returns a client that only connects to the addresses
+// isAllowed accepts. The address is checked when connecting, after the host
+// name is resolved, so it applies to redirects and can't be bypassed by a
+// DNS record pointing to an internal address.
+func newWebhookClient(isAllowed func(ip net.IP) bool) *webhookClient {
+	c := &webhookClient{isAllowed: isAllowed}
+	dialer := &net.Dialer{
+		Timeout: webhookTimeout,
+		Control: c.checkAddress,
+	}
+	c.client = &http.Client{
+		Timeout:   webhookTimeout,
+		Transport: &http.Transport{DialContext: dialer.DialContext},
+	}
+	return c
+}
+
+func (c *webhookClient) checkAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !c.isAllowed(ip) {
+		return fmt.Errorf("webhook address %s is not allowed", host)
+	}
+	return nil
+}
+
+func (c *webhookClient) post(url string, payload *WebhookPayload) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.Post(url, "application/json", bytes.NewReader(data)) //nolint:gosec
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("webhook %s returned status %d", url, resp.StatusCode)
+	}
+	return nil
+}
+
+// runRule runs the actions of a rule on the current version of a card. A
+// failing action doesn't prevent the next ones from running.
+func (b *Backend) runRule(boardID string, rule *model.AutomationRule, cardID string) error {
+	merr := merror.New()
+	for i := range rule.Actions {
+		action := &rule.Actions[i]
+
+		card, err := b.appAPI.GetBlockByID(cardID)
+		if model.IsErrNotFound(err) {
+			// an earlier action or another user deleted the card
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		board, err := b.appAPI.GetBoard(card.BoardID)
+		if err != nil {
+			return err
+		}
+
+		if err := b.authorizeAction(board, rule, action, card); err != nil {
+			merr.Append(fmt.Errorf("action %s: %w", action.Type, err))
+			continue
+		}
+
+		if err := b.runAction(board, rule, action, card); err != nil {
+			merr.Append(fmt.Errorf("action %s: %w", action.Type, err))
+		}
+	}
+	return merr.ErrorOrNil()
+}
+
+// authorizeAction checks that the user who saved the rule can still manage
+// the rules of the board and make the change of the action. Actions run as
+// the system user, so this is what limits them.
+func (b *Backend) authorizeAction(board *model.Board, rule *model.AutomationRule, action *model.AutomationAction, card *model.Block) error {
+	userID := rule.CreatedBy
+	if userID == "" || !b.permissions.HasPermissionToBoard(userID, board.ID, model.PermissionManageBoardProperties) {
+		return model.NewErrPermission("the rule author can't manage the board automations")
+	}
+	if !permissions.CanSeeCard(b.permissions, userID, board, card) {
+		return model.NewErrPermission("the rule author can't see the card")
+	}
+
+	switch action.Type {
+	case model.AutomationActionSetProperty, model.AutomationActionAssignPerson:
+		if !b.permissions.HasPermissionToBoard(userID, board.ID, model.PermissionManageBoardCards) {
+			return model.NewErrPermission("the rule author can't change the cards of the board")
+		}
+	case model.AutomationActionAddComment:
+		if !b.permissions.HasPermissionToBoard(userID, board.ID, model.PermissionCommentBoardCards) {
+			return model.NewErrPermission("the rule author can't comment on the cards of the board")
+		}
+	case model.AutomationActionMoveToBoard:
+		if !b.permissions.HasPermissionToBoard(userID, board.ID, model.PermissionManageBoardCards) ||
+			!b.permissions.HasPermissionToBoard(userID, action.Move.TargetBoardID, model.PermissionManageBoardCards) {
+			return model.NewErrPermission("the rule author can't move the card to the target board")
+		}
+	case model.AutomationActionSendNotification:
+		// the notification links to the card, so its recipient must see it
+		if !b.permissions.HasPermissionToBoard(action.UserID, board.ID, model.PermissionViewBoard) ||
+			!permissions.CanSeeCard(b.permissions, action.UserID, board, card) {
+			return model.NewErrPermission("the notified user can't see the card")
+		}
+	}
+	return nil
+}
+
+func (b *Backend) runAction(board *model.Board, rule *model.AutomationRule, action *model.AutomationAction, card *model.Block) error {
+	switch action.Type {
+	case model.AutomationActionSetProperty:
+		return b.setProperty(card, action.PropertyID, action.Value)
+	case model.AutomationActionAssignPerson:
+		return b.assignPerson(board, card, action)
+	case model.AutomationActionAddComment:
+		now := utils.GetMillis()
+		comment := &model.Block{
+			ID:       utils.NewID(utils.IDTypeBlock),
+			BoardID:  card.BoardID,
+			ParentID: card.ID,
+			Type:     model.TypeComment,
+			Title:    action.Text,
+			CreateAt: now,
+			UpdateAt: now,
+		}
+		return b.appAPI.InsertBlockAndNotify(comment, model.SystemUserID, true)
+	case model.AutomationActionMoveToBoard:
+		_, err := b.appAPI.MoveCard(card.ID, action.Move, model.SystemUserID)
+		return err
+	case model.AutomationActionSendNotification:
+		_, err := b.appAPI.CreateNotificationWithParams(action.UserID, action.Text, rule.Name, card.BoardID, card.ID)
+		return err
+	case model.AutomationActionCallWebhook:
+		return b.httpClient.post(action.URL, &WebhookPayload{
+			RuleID:   rule.ID,
+			RuleName: rule.Name,
+			BoardID:  card.BoardID,
+			Card:     card,
+		})
+	}
+	return fmt.Errorf("unsupported automation action: %s", action.Type)
+}
+
+func (b *Backend) setProperty(card *model.Block, propertyID string, value any) error {
+	properties := make(map[string]any)
+	if props, ok := card.Fields["properties"].(map[string]any); ok {
+		for k, v := range props {
+			properties[k] = v
+		}
+	}
+
+	if value == nil {
+		delete(properties, propertyID)
+	} else {
+		properties[propertyID] = value
+	}
+
+	patch := &model.BlockPatch{
+		UpdatedFields: map[string]any{"properties": properties},
+	}
+	_, err := b.appAPI.PatchBlockAndNotify(card.ID, patch, model.SystemUserID, true)
+	return err
+}
+
+// assignPerson sets a person property, or adds the user to a multi person
+// property.
+func (b *Backend) assignPerson(board *model.Board, card *model.Block, action *model.AutomationAction) error {
+	propType := ""
+	for _, prop := range board.CardProperties {
+		if id, _ := prop["id"].(string); id == action.PropertyID {
+			propType, _ = prop["type"].(string)
+			break
+		}
+	}
+
+	switch propType {
+	case "person":
+		return b.setProperty(card, action.PropertyID, action.UserID)
+	case "multiPerson":
+		current, _ := model.CardPropertyValue(card, action.PropertyID).([]any)
+		users := make([]any, 0, len(current)+1)
+		for _, user := range current {
+			if user == action.UserID {
+				return nil
+			}
+			users = append(users, user)
+		}
+		return b.setProperty(card, action.PropertyID, append(users, action.UserID))
+	}
+	return fmt.Errorf("property %s is not a person property", action.PropertyID)
+}
@@ -0,0 +1,19 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifyautomations
+
+import "github.com/mattermost/focalboard/server/model"
+
+type AppAPI interface {
+	GetBoard(boardID string) (*model.Board, error)
+	GetBoardsWithAutomations() ([]*model.Board, error)
+	GetAutomationsDueDateCheckAt() (int64, error)
+	ClaimAutomationsDueDateCheck(prevCheckAt, checkAt int64) (bool, error)
+	GetBlockByID(blockID string) (*model.Block, error)
+	GetBlocks(boardID, parentID string, blockType string) ([]*model.Block, error)
+	PatchBlockAndNotify(blockID string, blockPatch *model.BlockPatch, modifiedByID string, disableNotify bool) (*model.Block, error)
+	InsertBlockAndNotify(block *model.Block, modifiedByID string, disableNotify bool) error
+	MoveCard(cardID string, request *model.MoveCardRequest, userID string) (*model.Card, error)
+	CreateNotificationWithParams(userID, message, from string, boardID, cardID string) (*model.Notification, error)
+}
@@ -0,0 +1,260 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifyautomations
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/services/permissions"
+	"github.com/mattermost/focalboard/server/services/scheduler"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const (
+	backendName = "notifyAutomations"
+
+	ruleQueueSize       = 1000
+	rulePoolSize        = 4
+	ruleShutdownTimeout = time.Second * 10
+
+	dueDateCheckFrequency = 1 * time.Minute
+)
+
+type BackendParams struct {
+	AppAPI      AppAPI
+	Permissions permissions.PermissionsService
+	Logger      mlog.LoggerIFace
+}
+
+// Backend runs the automation rules of a board when its cards change.
+//
+// Rule actions are made on behalf of the system user, but only if the user
+// who saved the rule is still allowed to make them. Changes made by the
+// system user don't emit block change events, so actions never trigger
+// other rules and rules can't loop.
+type Backend struct {
+	appAPI      AppAPI
+	permissions permissions.PermissionsService
+	logger      mlog.LoggerIFace
+	queue       *utils.CallbackQueue
+	httpClient  *webhookClient
+
+	mux         sync.Mutex
+	dueDateTask *scheduler.ScheduledTask
+}
+
+func New(params BackendParams) *Backend {
+	return &Backend{
+		appAPI:      params.AppAPI,
+		permissions: params.Permissions,
+		logger:      params.Logger,
+		queue:       utils.NewCallbackQueue("automations", ruleQueueSize, rulePoolSize, params.Logger),
+		httpClient:  newWebhookClient(isPublicIP),
+	}
+}
+
+func (b *Backend) Start() error {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	b.dueDateTask = scheduler.CreateRecurringTask("automationDueDates", b.checkDueDates, dueDateCheckFrequency)
+	return nil
+}
+
+func (b *Backend) ShutDown() error {
+	b.mux.Lock()
+	if b.dueDateTask != nil {
+		b.dueDateTask.Cancel()
+		b.dueDateTask = nil
+	}
+	b.mux.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), ruleShutdownTimeout)
+	defer cancel()
+	if !b.queue.Shutdown(ctx) {
+		b.logger.Warn("Automations backend could not finish all rules before shutdown")
+	}
+	_ = b.logger.Flush()
+	return nil
+}
+
+func (b *Backend) Name() string {
+	return backendName
+}
+
+func (b *Backend) BlockChanged(evt notify.BlockChangeEvent) error {
+	if evt.Board == nil || evt.Card == nil || evt.Action == notify.Delete {
+		return nil
+	}
+	if evt.ModifiedBy != nil && evt.ModifiedBy.UserID == model.SystemUserID {
+		return nil
+	}
+	if isTemplate, _ := evt.Card.Fields["isTemplate"].(bool); isTemplate {
+		return nil
+	}
+
+	var triggerType model.AutomationTriggerType
+	switch {
+	case evt.Action == notify.Add && evt.BlockChanged.Type == model.TypeCard:
+		triggerType = model.AutomationTriggerCardCreated
+	case evt.Action == notify.Add && evt.BlockChanged.Type == model.TypeComment:
+		triggerType = model.AutomationTriggerCommentAdded
+	case evt.Action == notify.Update && evt.BlockChanged.Type == model.TypeCard:
+		triggerType = model.AutomationTriggerPropertyChanged
+	default:
+		return nil
+	}
+
+	rules, err := model.AutomationRulesFromBoard(evt.Board)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		if !b.isActive(evt.Board, rule) || rule.Trigger.Type != triggerType {
+			continue
+		}
+		if triggerType == model.AutomationTriggerPropertyChanged &&
+			!rule.Trigger.PropertyChangeMatches(evt.BlockOld, evt.BlockChanged) {
+			continue
+		}
+		if !rule.ConditionsMatch(evt.Card) {
+			continue
+		}
+		b.enqueueRule(evt.Board.ID, rule, evt.Card.ID)
+	}
+	return nil
+}
+
+// isActive returns true if the rule is enabled and valid. Rules are
+// validated when saved through the API, but board properties can also be
+// patched directly.
+func (b *Backend) isActive(board *model.Board, rule *model.AutomationRule) bool {
+	if !rule.Enabled {
+		return false
+	}
+	if err := rule.IsValid(); err != nil {
+		b.logger.Debug("Skipping invalid automation rule",
+			mlog.String("board_id", board.ID),
+			mlog.String("rule_id", rule.ID),
+			mlog.Err(err),
+		)
+		return false
+	}
+	return true
+}
+
+func (b *Backend) enqueueRule(boardID string, rule *model.AutomationRule, cardID string) {
+	b.queue.Enqueue(func() error {
+		if err := b.runRule(boardID, rule, cardID); err != nil {
+			b.logger.Error("Error running automation rule",
+				mlog.String("board_id", boardID),
+				mlog.String("rule_id", rule.ID),
+				mlog.String("card_id", cardID),
+				mlog.Err(err),
+			)
+		}
+		return nil
+	})
+}
+
+// checkDueDates runs the dueDateReached rules for the cards whose date
+// property passed since the last check. The time of the last check is kept
+// in the database and claimed before the rules run, so that several servers
+// running this task never run a rule twice for the same due date, and the
+// due dates reached while the servers were down aren't missed.
+func (b *Backend) checkDueDates() {
+	now := utils.GetMillis()
+
+	since, err := b.appAPI.GetAutomationsDueDateCheckAt()
+	if err != nil {
+		b.logger.Error("Cannot fetch the last automations due date check", mlog.Err(err))
+		return
+	}
+
+	claimed, err := b.appAPI.ClaimAutomationsDueDateCheck(since, now)
+	if err != nil {
+		b.logger.Error("Cannot claim the automations due date check", mlog.Err(err))
+		return
+	}
+	if !claimed {
+		// another node is checking these due dates
+		return
+	}
+	if since == 0 {
+		// the first check only starts tracking the due dates
+		return
+	}
+
+	boards, err := b.appAPI.GetBoardsWithAutomations()
+	if err != nil {
+		b.logger.Error("Cannot fetch boards with automations", mlog.Err(err))
+		return
+	}
+
+	for _, board := range boards {
+		b.checkBoardDueDates(board, since, now)
+	}
+}
+
+func (b *Backend) checkBoardDueDates(board *model.Board, since, now int64) {
+	rules, err := model.AutomationRulesFromBoard(board)
+	if err != nil {
+		b.logger.Warn("Cannot read automation rules", mlog.String("board_id", board.ID), mlog.Err(err))
+		return
+	}
+
+	var dueRules []*model.AutomationRule
+	for _, rule := range rules {
+		if rule.Trigger.Type == model.AutomationTriggerDueDateReached && b.isActive(board, rule) {
+			dueRules = append(dueRules, rule)
+		}
+	}
+	if len(dueRules) == 0 {
+		return
+	}
+
+	cards, err := b.appAPI.GetBlocks(board.ID, "", string(model.TypeCard))
+	if err != nil {
+		b.logger.Error("Cannot fetch cards for due date automations", mlog.String("board_id", board.ID), mlog.Err(err))
+		return
+	}
+
+	for _, card := range cards {
+		if isTemplate, _ := card.Fields["isTemplate"].(bool); isTemplate {
+			continue
+		}
+		for _, rule := range dueRules {
+			dueAt := cardDueDate(card, rule.Trigger.PropertyID)
+			if dueAt > since && dueAt <= now && rule.ConditionsMatch(card) {
+				b.enqueueRule(board.ID, rule, card.ID)
+			}
+		}
+	}
+}
+
+// cardDueDate returns the end of the date property of a card, or its start
+// if it isn't a range.
+func cardDueDate(card *model.Block, propertyID string) int64 {
+	value, ok := model.CardPropertyValue(card, propertyID).(string)
+	if !ok || value == "" {
+		return 0
+	}
+
+	var date map[string]int64
+	if err := json.Unmarshal([]byte(value), &date); err != nil {
+		return 0
+	}
+	if to, ok := date["to"]; ok && to != 0 {
+		return to
+	}
+	return date["from"]
+}
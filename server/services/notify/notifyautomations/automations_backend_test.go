@@ -0,0 +1,405 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifyautomations
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+
+	mmModel "github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const testAuthorID = "author-id"
+
+// fakePermissions grants every permission to the users it contains.
+type fakePermissions struct {
+	users map[string]bool
+}
+
+func (p *fakePermissions) HasPermissionTo(userID string, permission *mmModel.Permission) bool {
+	return p.users[userID]
+}
+
+func (p *fakePermissions) HasPermissionToTeam(userID, teamID string, permission *mmModel.Permission) bool {
+	return p.users[userID]
+}
+
+func (p *fakePermissions) HasPermissionToChannel(userID, channelID string, permission *mmModel.Permission) bool {
+	return p.users[userID]
+}
+
+func (p *fakePermissions) HasPermissionToBoard(userID, boardID string, permission *mmModel.Permission) bool {
+	return p.users[userID]
+}
+
+func (p *fakePermissions) ExplainPermissionToBoard(userID, boardID string, permission *mmModel.Permission) *model.BoardPermissionExplanation {
+	return model.NewBoardPermissionExplanation(permission, p.users[userID], "", "")
+}
+
+type fakeAppAPI struct {
+	mux            sync.Mutex
+	board          *model.Board
+	blocks         map[string]*model.Block
+	patchedBy      []string
+	inserted       []*model.Block
+	notifications  []string
+	dueDateCheckAt int64
+}
+
+func newFakeAppAPI(board *model.Board, blocks ...*model.Block) *fakeAppAPI {
+	api := &fakeAppAPI{board: board, blocks: make(map[string]*model.Block)}
+	for _, block := range blocks {
+		api.blocks[block.ID] = block
+	}
+	return api
+}
+
+func (f *fakeAppAPI) GetBoard(boardID string) (*model.Board, error) {
+	return f.board, nil
+}
+
+func (f *fakeAppAPI) GetBoardsWithAutomations() ([]*model.Board, error) {
+	return []*model.Board{f.board}, nil
+}
+
+func (f *fakeAppAPI) GetAutomationsDueDateCheckAt() (int64, error) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	return f.dueDateCheckAt, nil
+}
+
+func (f *fakeAppAPI) ClaimAutomationsDueDateCheck(prevCheckAt, checkAt int64) (bool, error) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	if f.dueDateCheckAt != prevCheckAt {
+		return false, nil
+	}
+	f.dueDateCheckAt = checkAt
+	return true, nil
+}
+
+func (f *fakeAppAPI) GetBlockByID(blockID string) (*model.Block, error) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	block, ok := f.blocks[blockID]
+	if !ok {
+		return nil, model.NewErrNotFound(blockID)
+	}
+	return block, nil
+}
+
+func (f *fakeAppAPI) GetBlocks(boardID, parentID string, blockType string) ([]*model.Block, error) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	blocks := []*model.Block{}
+	for _, block := range f.blocks {
+		if string(block.Type) == blockType {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks, nil
+}
+
+func (f *fakeAppAPI) PatchBlockAndNotify(blockID string, blockPatch *model.BlockPatch, modifiedByID string, disableNotify bool) (*model.Block, error) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	block := f.blocks[blockID]
+	patched := blockPatch.Patch(block)
+	f.blocks[blockID] = patched
+	f.patchedBy = append(f.patchedBy, modifiedByID)
+	return patched, nil
+}
+
+func (f *fakeAppAPI) InsertBlockAndNotify(block *model.Block, modifiedByID string, disableNotify bool) error {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	f.inserted = append(f.inserted, block)
+	return nil
+}
+
+func (f *fakeAppAPI) MoveCard(cardID string, request *model.MoveCardRequest, userID string) (*model.Card, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (f *fakeAppAPI) CreateNotificationWithParams(userID, message, from string, boardID, cardID string) (*model.Notification, error) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	f.notifications = append(f.notifications, userID+":"+message)
+	return &model.Notification{}, nil
+}
+
+func (f *fakeAppAPI) cardProperty(cardID, propertyID string) any {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	return model.CardPropertyValue(f.blocks[cardID], propertyID)
+}
+
+func (f *fakeAppAPI) insertedCount() int {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	return len(f.inserted)
+}
+
+func newTestBackend(t *testing.T, api *fakeAppAPI) *Backend {
+	logger, err := mlog.NewLogger()
+	require.NoError(t, err)
+
+	backend := New(BackendParams{
+		AppAPI:      api,
+		Permissions: &fakePermissions{users: map[string]bool{testAuthorID: true, "user-id": true, "reviewer": true}},
+		Logger:      logger,
+	})
+	require.NoError(t, backend.Start())
+	t.Cleanup(func() { _ = backend.ShutDown() })
+	return backend
+}
+
+func newTestBoard(rules ...*model.AutomationRule) *model.Board {
+	return &model.Board{
+		ID: "board-id",
+		CardProperties: []map[string]any{
+			{"id": "status", "type": "select"},
+			{"id": "owner", "type": "person"},
+			{"id": "due", "type": "date"},
+		},
+		Properties: map[string]any{model.BoardPropertyAutomations: rules},
+	}
+}
+
+func newTestCard(properties map[string]any) *model.Block {
+	return &model.Block{
+		ID:      "card-id",
+		BoardID: "board-id",
+		Type:    model.TypeCard,
+		Fields:  map[string]any{"properties": properties},
+	}
+}
+
+func TestBlockChanged(t *testing.T) {
+	t.Run("card created sets a property", func(t *testing.T) {
+		rule := &model.AutomationRule{
+			ID:        "rule-id",
+			Name:      "new cards are todo",
+			Enabled:   true,
+			CreatedBy: testAuthorID,
+			Trigger:   model.AutomationTrigger{Type: model.AutomationTriggerCardCreated},
+			Actions:   []model.AutomationAction{{Type: model.AutomationActionSetProperty, PropertyID: "status", Value: "todo"}},
+		}
+		board := newTestBoard(rule)
+		card := newTestCard(map[string]any{})
+		api := newFakeAppAPI(board, card)
+		backend := newTestBackend(t, api)
+
+		err := backend.BlockChanged(notify.BlockChangeEvent{
+			Action:       notify.Add,
+			Board:        board,
+			Card:         card,
+			BlockChanged: card,
+			ModifiedBy:   &model.BoardMember{UserID: "user-id"},
+		})
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool { return api.cardProperty(card.ID, "status") == "todo" }, time.Second, 10*time.Millisecond)
+		assert.Equal(t, []string{model.SystemUserID}, api.patchedBy)
+	})
+
+	t.Run("property changed to a value assigns a person when conditions match", func(t *testing.T) {
+		rule := &model.AutomationRule{
+			ID:         "rule-id",
+			Name:       "review done cards",
+			Enabled:    true,
+			CreatedBy:  testAuthorID,
+			Trigger:    model.AutomationTrigger{Type: model.AutomationTriggerPropertyChanged, PropertyID: "status", Value: "done"},
+			Conditions: []model.AutomationCondition{{PropertyID: "owner", Operator: model.AutomationConditionIsEmpty}},
+			Actions:    []model.AutomationAction{{Type: model.AutomationActionAssignPerson, PropertyID: "owner", UserID: "reviewer"}},
+		}
+		board := newTestBoard(rule)
+		oldCard := newTestCard(map[string]any{"status": "todo"})
+		card := newTestCard(map[string]any{"status": "done"})
+		api := newFakeAppAPI(board, card)
+		backend := newTestBackend(t, api)
+
+		err := backend.BlockChanged(notify.BlockChangeEvent{
+			Action:       notify.Update,
+			Board:        board,
+			Card:         card,
+			BlockChanged: card,
+			BlockOld:     oldCard,
+		})
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool { return api.cardProperty(card.ID, "owner") == "reviewer" }, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("disabled rules and changes by the system user are ignored", func(t *testing.T) {
+		rule := &model.AutomationRule{
+			ID:        "rule-id",
+			Name:      "thank commenters",
+			Enabled:   true,
+			CreatedBy: testAuthorID,
+			Trigger:   model.AutomationTrigger{Type: model.AutomationTriggerCommentAdded},
+			Actions:   []model.AutomationAction{{Type: model.AutomationActionAddComment, Text: "thanks"}},
+		}
+		disabled := *rule
+		disabled.Enabled = false
+		board := newTestBoard(rule, &disabled)
+		card := newTestCard(map[string]any{})
+		comment := &model.Block{ID: "comment-id", BoardID: "board-id", ParentID: card.ID, Type: model.TypeComment}
+		api := newFakeAppAPI(board, card)
+		backend := newTestBackend(t, api)
+
+		evt := notify.BlockChangeEvent{
+			Action:       notify.Add,
+			Board:        board,
+			Card:         card,
+			BlockChanged: comment,
+			ModifiedBy:   &model.BoardMember{UserID: model.SystemUserID},
+		}
+		require.NoError(t, backend.BlockChanged(evt))
+
+		evt.ModifiedBy = &model.BoardMember{UserID: "user-id"}
+		require.NoError(t, backend.BlockChanged(evt))
+
+		require.Eventually(t, func() bool { return api.insertedCount() == 1 }, time.Second, 10*time.Millisecond)
+		time.Sleep(50 * time.Millisecond)
+		require.Equal(t, 1, api.insertedCount())
+	})
+
+	t.Run("actions don't run if the rule author lost access to the board", func(t *testing.T) {
+		rule := &model.AutomationRule{
+			ID:        "rule-id",
+			Name:      "new cards are todo",
+			Enabled:   true,
+			CreatedBy: "former-member-id",
+			Trigger:   model.AutomationTrigger{Type: model.AutomationTriggerCardCreated},
+			Actions: []model.AutomationAction{
+				{Type: model.AutomationActionAddComment, Text: "welcome"},
+				{Type: model.AutomationActionSetProperty, PropertyID: "status", Value: "todo"},
+			},
+		}
+		board := newTestBoard(rule)
+		card := newTestCard(map[string]any{})
+		api := newFakeAppAPI(board, card)
+		backend := newTestBackend(t, api)
+
+		require.Error(t, backend.runRule(board.ID, rule, card.ID))
+		require.Zero(t, api.insertedCount())
+		require.Nil(t, api.cardProperty(card.ID, "status"))
+	})
+}
+
+func TestCheckDueDates(t *testing.T) {
+	var calls int
+	var callsMux sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callsMux.Lock()
+		defer callsMux.Unlock()
+		calls++
+	}))
+	defer server.Close()
+
+	rule := &model.AutomationRule{
+		ID:        "rule-id",
+		Name:      "due cards",
+		Enabled:   true,
+		CreatedBy: testAuthorID,
+		Trigger:   model.AutomationTrigger{Type: model.AutomationTriggerDueDateReached, PropertyID: "due"},
+		Actions: []model.AutomationAction{
+			{Type: model.AutomationActionSendNotification, UserID: "user-id", Text: "card is due"},
+			{Type: model.AutomationActionCallWebhook, URL: server.URL},
+		},
+	}
+	board := newTestBoard(rule)
+	api := newFakeAppAPI(board,
+		newTestCard(map[string]any{"due": `{"from":1500}`}),
+		&model.Block{ID: "late", BoardID: "board-id", Type: model.TypeCard, Fields: map[string]any{
+			"properties": map[string]any{"due": `{"from":1000,"to":3000}`},
+		}},
+	)
+	backend := newTestBackend(t, api)
+	backend.httpClient = newWebhookClient(func(ip net.IP) bool { return true })
+
+	backend.checkBoardDueDates(board, 1000, 2000)
+
+	require.Eventually(t, func() bool {
+		callsMux.Lock()
+		defer callsMux.Unlock()
+		return calls == 1
+	}, time.Second, 10*time.Millisecond)
+
+	api.mux.Lock()
+	defer api.mux.Unlock()
+	require.Equal(t, []string{"user-id:card is due"}, api.notifications)
+}
+
+func TestCheckDueDatesClaim(t *testing.T) {
+	rule := &model.AutomationRule{
+		ID:        "rule-id",
+		Name:      "due cards",
+		Enabled:   true,
+		CreatedBy: testAuthorID,
+		Trigger:   model.AutomationTrigger{Type: model.AutomationTriggerDueDateReached, PropertyID: "due"},
+		Actions:   []model.AutomationAction{{Type: model.AutomationActionSendNotification, UserID: "user-id", Text: "card is due"}},
+	}
+	board := newTestBoard(rule)
+	dueAt := time.Now().Add(-time.Minute).UnixMilli()
+	api := newFakeAppAPI(board, newTestCard(map[string]any{"due": fmt.Sprintf(`{"from":%d}`, dueAt)}))
+	backend := newTestBackend(t, api)
+
+	// the first check only records its time
+	backend.checkDueDates()
+	api.mux.Lock()
+	require.NotZero(t, api.dueDateCheckAt)
+	api.dueDateCheckAt = dueAt - 1000
+	api.mux.Unlock()
+
+	// the next check runs the rules from the recorded time, even if it was
+	// recorded by another server
+	backend.checkDueDates()
+	require.Eventually(t, func() bool {
+		api.mux.Lock()
+		defer api.mux.Unlock()
+		return len(api.notifications) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	backend.checkDueDates()
+	time.Sleep(50 * time.Millisecond)
+	api.mux.Lock()
+	defer api.mux.Unlock()
+	require.Len(t, api.notifications, 1)
+}
+
+func TestWebhookClient(t *testing.T) {
+	t.Run("internal addresses are rejected", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Error("the webhook should not be called")
+		}))
+		defer server.Close()
+
+		client := newWebhookClient(isPublicIP)
+		err := client.post(server.URL, &WebhookPayload{RuleID: "rule-id"})
+		require.ErrorContains(t, err, "is not allowed")
+	})
+
+	t.Run("public addresses", func(t *testing.T) {
+		for _, addr := range []string{"8.8.8.8", "2001:4860:4860::8888"} {
+			assert.True(t, isPublicIP(net.ParseIP(addr)), addr)
+		}
+		for _, addr := range []string{"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254", "100.64.0.1", "0.0.0.0", "::1", "fe80::1", "fd00::1", "::ffff:127.0.0.1"} {
+			assert.False(t, isPublicIP(net.ParseIP(addr)), addr)
+		}
+	})
+}
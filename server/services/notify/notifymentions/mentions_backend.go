@@ -175,6 +175,12 @@ func (b *Backend) deliverMentionNotification(username string, extract string, ev
 		return "", fmt.Errorf("invalid user cannot mention: %w", ErrMentionPermission)
 	}
 
+	// restricted cards are only visible to some users, the others are
+	// neither notified nor added to the board
+	if !permissions.CanSeeCard(b.permissions, mentionedUser.Id, evt.Board, evt.Card) {
+		return "", fmt.Errorf("%s cannot mention user %s on a restricted card: %w", evt.ModifiedBy.UserID, mentionedUser.Id, ErrMentionPermission)
+	}
+
 	if evt.Board.Type == model.BoardTypeOpen {
 		// public board rules:
 		//    - admin, editor, commenter: can mention anyone on team (mentioned users are automatically added to board)
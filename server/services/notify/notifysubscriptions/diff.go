@@ -52,9 +52,38 @@ type diffGenerator struct {
 	store        AppAPI
 	hint         *model.NotificationHint
 	lastNotifyAt int64
+	untilAt      int64 // if non-zero then changes after this time are ignored
 	logger       mlog.LoggerIFace
 }
 
+// GenerateCardVersionDiff returns the changes made to a card and its content
+// blocks after sinceUpdateAt, up to and including untilUpdateAt. The card must
+// be the version of the card as of untilUpdateAt.
+func GenerateCardVersionDiff(store AppAPI, board *model.Board, card *model.Block, sinceUpdateAt int64, untilUpdateAt int64, logger mlog.LoggerIFace) (*Diff, error) {
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse property schema for board %s: %w", board.ID, err)
+	}
+
+	dg := &diffGenerator{
+		board:        board,
+		card:         card,
+		store:        store,
+		lastNotifyAt: sinceUpdateAt,
+		untilAt:      untilUpdateAt,
+		logger:       logger,
+	}
+	return dg.generateDiffsForCard(card, schema)
+}
+
+// beforeUntil returns the BeforeUpdateAt option matching untilAt.
+func (dg *diffGenerator) beforeUntil() int64 {
+	if dg.untilAt == 0 {
+		return 0
+	}
+	return dg.untilAt + 1
+}
+
 func (dg *diffGenerator) generateDiffs() ([]*Diff, error) {
 	// use block_history to fetch blocks in case they were deleted and no longer exist in blocks table.
 	opts := model.QueryBlockHistoryOptions{
@@ -150,7 +179,8 @@ func (dg *diffGenerator) generateDiffsForCard(card *model.Block, schema model.Pr
 
 	// fetch all card content blocks that were updated after last notify
 	opts := model.QueryBlockHistoryChildOptions{
-		AfterUpdateAt: dg.lastNotifyAt,
+		AfterUpdateAt:  dg.lastNotifyAt,
+		BeforeUpdateAt: dg.beforeUntil(),
 	}
 	blocks, _, err := dg.store.GetBlockHistoryNewestChildren(card.ID, opts)
 	if err != nil {
@@ -238,8 +268,9 @@ func (dg *diffGenerator) generateDiffForBlock(newBlock *model.Block, schema mode
 
 	// find all the versions of the blocks that changed so we can gather all the author usernames.
 	opts = model.QueryBlockHistoryOptions{
-		AfterUpdateAt: dg.lastNotifyAt,
-		Descending:    true,
+		AfterUpdateAt:  dg.lastNotifyAt,
+		BeforeUpdateAt: dg.beforeUntil(),
+		Descending:     true,
 	}
 	chgBlocks, err := dg.store.GetBlockHistory(newBlock.ID, opts)
 	if err != nil {
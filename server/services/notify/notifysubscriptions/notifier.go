@@ -239,6 +239,16 @@ func (n *notifier) notifySubscribers(hint *model.NotificationHint) error {
 				continue
 			}
 
+			// restricted cards are only visible to some of the board members.
+			if !permissions.CanSeeCard(n.permissions, sub.SubscriberID, board, card) {
+				n.logger.Debug("notifySubscribers - skipping user without access to restricted card",
+					mlog.Any("hint", hint),
+					mlog.String("subscriber_id", sub.SubscriberID),
+					mlog.String("card_id", card.ID),
+				)
+				continue
+			}
+
 			n.logger.Debug("notifySubscribers - deliver",
 				mlog.Any("hint", hint),
 				mlog.String("modified_by_id", hint.ModifiedByID),
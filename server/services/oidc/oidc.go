@@ -0,0 +1,437 @@
+// Package oidc implements the parts of OpenID Connect needed for the single
+// sign-on of standalone servers: provider discovery, the authorization code
+// flow with PKCE and the validation of RS256 signed ID tokens.
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DiscoveryPath is the path of the provider configuration document,
+	// relative to the issuer.
+	DiscoveryPath = "/.well-known/openid-configuration"
+
+	// ScopeOpenID is the scope every OpenID Connect request must include.
+	ScopeOpenID = "openid"
+
+	// ClockSkew is the tolerance applied when validating the time based
+	// claims of the ID tokens.
+	ClockSkew = time.Minute
+
+	// KeysRefreshInterval is the minimum time between two fetches of the
+	// provider key set, so that tokens with unknown key IDs can't make the
+	// server hammer the provider.
+	KeysRefreshInterval = time.Minute
+
+	maxResponseSize = 1 << 20
+)
+
+var (
+	ErrInvalidIDToken = errors.New("invalid ID token")
+	ErrUnknownKey     = errors.New("unknown ID token signing key")
+)
+
+// TokenEndpointError is returned when the token endpoint rejects the
+// authorization code. The response body of the provider is only meant for
+// the server logs, it isn't part of the error message.
+type TokenEndpointError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *TokenEndpointError) Error() string {
+	return fmt.Sprintf("token endpoint returned %d", e.StatusCode)
+}
+
+// Config is the client registration with the provider.
+type Config struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+// Token is the response of the provider token endpoint.
+type Token struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	IDToken     string `json:"id_token"`
+}
+
+// Claims are the claims of a validated ID token.
+type Claims map[string]interface{}
+
+// String returns the value of a string claim, or an empty string if the
+// claim is missing or is not a string.
+func (c Claims) String(name string) string {
+	value, _ := c[name].(string)
+	return value
+}
+
+// Bool returns the value of a boolean claim and whether it is present.
+// Some providers send booleans as strings, both forms are accepted.
+func (c Claims) Bool(name string) (bool, bool) {
+	switch value := c[name].(type) {
+	case bool:
+		return value, true
+	case string:
+		return value == "true", true
+	}
+	return false, false
+}
+
+type discoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Provider is an OpenID Connect provider discovered from its issuer.
+type Provider struct {
+	Issuer                string
+	AuthorizationEndpoint string
+	TokenEndpoint         string
+	JWKSURI               string
+
+	client *http.Client
+
+	keysMux       sync.RWMutex
+	keys          map[string]*rsa.PublicKey
+	keysFetchedAt time.Time
+}
+
+// Discover fetches the configuration document of the issuer. The issuer
+// of the document must match the requested one.
+func Discover(ctx context.Context, client *http.Client, issuer string) (*Provider, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	issuer = strings.TrimSuffix(issuer, "/")
+
+	var doc discoveryDocument
+	if err := getJSON(ctx, client, issuer+DiscoveryPath, &doc); err != nil {
+		return nil, fmt.Errorf("unable to discover the provider configuration: %w", err)
+	}
+
+	if strings.TrimSuffix(doc.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("issuer mismatch, expected %q got %q", issuer, doc.Issuer)
+	}
+	if doc.AuthorizationEndpoint == "" || doc.TokenEndpoint == "" || doc.JWKSURI == "" {
+		return nil, errors.New("incomplete provider configuration")
+	}
+
+	return &Provider{
+		Issuer:                doc.Issuer,
+		AuthorizationEndpoint: doc.AuthorizationEndpoint,
+		TokenEndpoint:         doc.TokenEndpoint,
+		JWKSURI:               doc.JWKSURI,
+		client:                client,
+		keys:                  map[string]*rsa.PublicKey{},
+	}, nil
+}
+
+// AuthCodeURL returns the URL of the provider authorization endpoint to
+// redirect the user to, using the S256 PKCE challenge of the verifier.
+func (p *Provider) AuthCodeURL(cfg Config, state, nonce, codeVerifier string) string {
+	scopes := cfg.Scopes
+	if !containsString(scopes, ScopeOpenID) {
+		scopes = append([]string{ScopeOpenID}, scopes...)
+	}
+
+	values := url.Values{}
+	values.Set("response_type", "code")
+	values.Set("client_id", cfg.ClientID)
+	values.Set("redirect_uri", cfg.RedirectURL)
+	values.Set("scope", strings.Join(scopes, " "))
+	values.Set("state", state)
+	values.Set("nonce", nonce)
+	values.Set("code_challenge", CodeChallengeS256(codeVerifier))
+	values.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(p.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return p.AuthorizationEndpoint + separator + values.Encode()
+}
+
+// Exchange redeems the authorization code at the provider token endpoint.
+func (p *Provider) Exchange(ctx context.Context, cfg Config, code, codeVerifier string) (*Token, error) {
+	values := url.Values{}
+	values.Set("grant_type", "authorization_code")
+	values.Set("code", code)
+	values.Set("redirect_uri", cfg.RedirectURL)
+	values.Set("code_verifier", codeVerifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.TokenEndpoint, strings.NewReader(values.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(cfg.ClientID), url.QueryEscape(cfg.ClientSecret))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to exchange the authorization code: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &TokenEndpointError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var token Token
+	if err := json.Unmarshal(body, &token); err != nil {
+		return nil, fmt.Errorf("unable to decode the token response: %w", err)
+	}
+	if token.IDToken == "" {
+		return nil, errors.New("the token response has no ID token")
+	}
+	return &token, nil
+}
+
+// VerifyIDToken validates the signature, the issuer, the audience, the
+// expiration and the nonce of an ID token, and returns its claims.
+func (p *Provider) VerifyIDToken(ctx context.Context, cfg Config, rawIDToken, nonce string) (Claims, error) {
+	parts := strings.Split(rawIDToken, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidIDToken)
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidIDToken, err)
+	}
+	if header.Alg != "RS256" {
+		return nil, fmt.Errorf("%w: unsupported signing algorithm %q", ErrInvalidIDToken, header.Alg)
+	}
+
+	key, err := p.signingKey(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidIDToken, err)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return nil, fmt.Errorf("%w: bad signature", ErrInvalidIDToken)
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidIDToken, err)
+	}
+
+	if claims.String("iss") != p.Issuer {
+		return nil, fmt.Errorf("%w: issuer mismatch", ErrInvalidIDToken)
+	}
+	if !claims.hasAudience(cfg.ClientID) {
+		return nil, fmt.Errorf("%w: audience mismatch", ErrInvalidIDToken)
+	}
+	if claims.String("sub") == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidIDToken)
+	}
+
+	now := time.Now()
+	exp, ok := claims.time("exp")
+	if !ok {
+		return nil, fmt.Errorf("%w: missing expiration", ErrInvalidIDToken)
+	}
+	if now.After(exp.Add(ClockSkew)) {
+		return nil, fmt.Errorf("%w: token expired", ErrInvalidIDToken)
+	}
+	if iat, ok := claims.time("iat"); ok && iat.After(now.Add(ClockSkew)) {
+		return nil, fmt.Errorf("%w: token issued in the future", ErrInvalidIDToken)
+	}
+
+	if claims.String("nonce") != nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidIDToken)
+	}
+
+	return claims, nil
+}
+
+// signingKey returns the provider key with the ID. The key set is cached,
+// and fetched again if the key is unknown, to support key rotation, at
+// most once per KeysRefreshInterval.
+func (p *Provider) signingKey(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	p.keysMux.RLock()
+	key := p.findKey(kid)
+	p.keysMux.RUnlock()
+	if key != nil {
+		return key, nil
+	}
+
+	p.keysMux.Lock()
+	defer p.keysMux.Unlock()
+
+	// the keys may have been fetched while waiting for the lock
+	if key := p.findKey(kid); key != nil {
+		return key, nil
+	}
+	if !p.keysFetchedAt.IsZero() && time.Since(p.keysFetchedAt) < KeysRefreshInterval {
+		return nil, ErrUnknownKey
+	}
+
+	p.keysFetchedAt = time.Now()
+	keys, err := p.fetchKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p.keys = keys
+	if key := p.findKey(kid); key != nil {
+		return key, nil
+	}
+	return nil, ErrUnknownKey
+}
+
+func (p *Provider) findKey(kid string) *rsa.PublicKey {
+	if kid != "" {
+		return p.keys[kid]
+	}
+	// without key ID the token can only be validated if there is one key
+	if len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key
+		}
+	}
+	return nil
+}
+
+func (p *Provider) fetchKeys(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := getJSON(ctx, p.client, p.JWKSURI, &set); err != nil {
+		return nil, fmt.Errorf("unable to fetch the provider keys: %w", err)
+	}
+
+	keys := map[string]*rsa.PublicKey{}
+	for _, jwk := range set.Keys {
+		if jwk.Kty != "RSA" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			continue
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	return keys, nil
+}
+
+func (c Claims) hasAudience(clientID string) bool {
+	switch aud := c["aud"].(type) {
+	case string:
+		return aud == clientID
+	case []interface{}:
+		for _, value := range aud {
+			if value == clientID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c Claims) time(name string) (time.Time, bool) {
+	value, ok := c[name].(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(value), 0), true
+}
+
+// NewCodeVerifier returns a random PKCE code verifier. It is also suitable
+// for the state and nonce values of the authorization requests.
+func NewCodeVerifier() (string, error) {
+	data := make([]byte, 32)
+	if _, err := rand.Read(data); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// CodeChallengeS256 returns the S256 PKCE challenge of a code verifier.
+func CodeChallengeS256(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(segment, "="))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func getJSON(ctx context.Context, client *http.Client, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %d", url, resp.StatusCode)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(v)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
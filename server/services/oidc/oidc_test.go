@@ -0,0 +1,220 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/services/oidc/oidctest"
+	"github.com/stretchr/testify/require"
+)
+
+const testRedirectURL = "http://localhost:8000/oauth/oidc/callback"
+
+func setupProvider(t *testing.T) (*oidctest.Provider, *Provider, Config) {
+	mock, err := oidctest.NewProvider()
+	require.NoError(t, err)
+	t.Cleanup(mock.Close)
+
+	provider, err := Discover(context.Background(), nil, mock.Issuer())
+	require.NoError(t, err)
+
+	cfg := Config{
+		ClientID:     mock.ClientID,
+		ClientSecret: mock.ClientSecret,
+		RedirectURL:  testRedirectURL,
+		Scopes:       []string{"profile", "email"},
+	}
+	return mock, provider, cfg
+}
+
+// authorize follows the authorization URL and returns the code and state
+// the provider redirects back with.
+func authorize(t *testing.T, authURL string) (string, string) {
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := client.Get(authURL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(location.String(), testRedirectURL))
+	return location.Query().Get("code"), location.Query().Get("state")
+}
+
+func TestDiscover(t *testing.T) {
+	mock, provider, _ := setupProvider(t)
+
+	require.Equal(t, mock.Issuer(), provider.Issuer)
+	require.Equal(t, mock.Issuer()+"/authorize", provider.AuthorizationEndpoint)
+	require.Equal(t, mock.Issuer()+"/token", provider.TokenEndpoint)
+	require.Equal(t, mock.Issuer()+"/keys", provider.JWKSURI)
+
+	t.Run("issuer mismatch", func(t *testing.T) {
+		_, err := Discover(context.Background(), nil, strings.Replace(mock.Issuer(), "127.0.0.1", "localhost", 1))
+		require.Error(t, err)
+	})
+
+	t.Run("unreachable issuer", func(t *testing.T) {
+		_, err := Discover(context.Background(), nil, mock.Issuer()+"/missing")
+		require.Error(t, err)
+	})
+}
+
+func TestAuthCodeFlow(t *testing.T) {
+	mock, provider, cfg := setupProvider(t)
+	mock.SetClaims(map[string]interface{}{
+		"sub":                "user-sub",
+		"email":              "user@example.com",
+		"email_verified":     true,
+		"preferred_username": "user",
+	})
+
+	verifier, err := NewCodeVerifier()
+	require.NoError(t, err)
+
+	authURL := provider.AuthCodeURL(cfg, "the-state", "the-nonce", verifier)
+	parsed, err := url.Parse(authURL)
+	require.NoError(t, err)
+	require.Equal(t, "openid profile email", parsed.Query().Get("scope"))
+	require.Equal(t, CodeChallengeS256(verifier), parsed.Query().Get("code_challenge"))
+	require.Equal(t, "S256", parsed.Query().Get("code_challenge_method"))
+
+	t.Run("valid flow", func(t *testing.T) {
+		code, state := authorize(t, authURL)
+		require.Equal(t, "the-state", state)
+
+		token, err := provider.Exchange(context.Background(), cfg, code, verifier)
+		require.NoError(t, err)
+
+		claims, err := provider.VerifyIDToken(context.Background(), cfg, token.IDToken, "the-nonce")
+		require.NoError(t, err)
+		require.Equal(t, "user-sub", claims.String("sub"))
+		require.Equal(t, "user@example.com", claims.String("email"))
+		verified, ok := claims.Bool("email_verified")
+		require.True(t, ok)
+		require.True(t, verified)
+	})
+
+	t.Run("wrong code verifier", func(t *testing.T) {
+		code, _ := authorize(t, authURL)
+		_, err := provider.Exchange(context.Background(), cfg, code, verifier+"x")
+		var tokenErr *TokenEndpointError
+		require.ErrorAs(t, err, &tokenErr)
+		require.Equal(t, http.StatusBadRequest, tokenErr.StatusCode)
+		require.Contains(t, tokenErr.Body, "invalid_grant")
+		require.NotContains(t, err.Error(), "invalid_grant")
+	})
+
+	t.Run("code can't be reused", func(t *testing.T) {
+		code, _ := authorize(t, authURL)
+		_, err := provider.Exchange(context.Background(), cfg, code, verifier)
+		require.NoError(t, err)
+		_, err = provider.Exchange(context.Background(), cfg, code, verifier)
+		require.Error(t, err)
+	})
+
+	t.Run("nonce mismatch", func(t *testing.T) {
+		code, _ := authorize(t, authURL)
+		token, err := provider.Exchange(context.Background(), cfg, code, verifier)
+		require.NoError(t, err)
+		_, err = provider.VerifyIDToken(context.Background(), cfg, token.IDToken, "other-nonce")
+		require.ErrorIs(t, err, ErrInvalidIDToken)
+	})
+}
+
+func TestVerifyIDToken(t *testing.T) {
+	mock, provider, cfg := setupProvider(t)
+
+	validClaims := func() map[string]interface{} {
+		return map[string]interface{}{
+			"iss":   mock.Issuer(),
+			"aud":   mock.ClientID,
+			"sub":   "user-sub",
+			"nonce": "nonce",
+			"iat":   time.Now().Unix(),
+			"exp":   time.Now().Add(time.Hour).Unix(),
+		}
+	}
+
+	testCases := []struct {
+		name    string
+		modify  func(claims map[string]interface{})
+		isError bool
+	}{
+		{"valid", func(map[string]interface{}) {}, false},
+		{"audience list", func(c map[string]interface{}) { c["aud"] = []string{"other", mock.ClientID} }, false},
+		{"wrong audience", func(c map[string]interface{}) { c["aud"] = "other" }, true},
+		{"wrong issuer", func(c map[string]interface{}) { c["iss"] = "https://evil.example.com" }, true},
+		{"expired", func(c map[string]interface{}) { c["exp"] = time.Now().Add(-time.Hour).Unix() }, true},
+		{"missing expiration", func(c map[string]interface{}) { delete(c, "exp") }, true},
+		{"issued in the future", func(c map[string]interface{}) { c["iat"] = time.Now().Add(time.Hour).Unix() }, true},
+		{"missing subject", func(c map[string]interface{}) { delete(c, "sub") }, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			claims := validClaims()
+			tc.modify(claims)
+			rawToken, err := mock.SignIDToken(claims)
+			require.NoError(t, err)
+
+			_, err = provider.VerifyIDToken(context.Background(), cfg, rawToken, "nonce")
+			if tc.isError {
+				require.ErrorIs(t, err, ErrInvalidIDToken)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("tampered payload", func(t *testing.T) {
+		rawToken, err := mock.SignIDToken(validClaims())
+		require.NoError(t, err)
+		other, err := mock.SignIDToken(map[string]interface{}{"sub": "other"})
+		require.NoError(t, err)
+
+		parts := strings.Split(rawToken, ".")
+		parts[1] = strings.Split(other, ".")[1]
+		_, err = provider.VerifyIDToken(context.Background(), cfg, strings.Join(parts, "."), "nonce")
+		require.ErrorIs(t, err, ErrInvalidIDToken)
+	})
+
+	t.Run("malformed token", func(t *testing.T) {
+		_, err := provider.VerifyIDToken(context.Background(), cfg, "not-a-token", "nonce")
+		require.ErrorIs(t, err, ErrInvalidIDToken)
+	})
+
+	t.Run("the keys are cached and refetched at most once per interval", func(t *testing.T) {
+		// the key set was fetched by the first verification
+		require.Equal(t, 1, mock.KeyRequests())
+
+		rawToken, err := mock.SignIDToken(validClaims())
+		require.NoError(t, err)
+		_, err = provider.VerifyIDToken(context.Background(), cfg, rawToken, "nonce")
+		require.NoError(t, err)
+		require.Equal(t, 1, mock.KeyRequests())
+
+		// the key set was fetched less than an interval ago
+		for i := 0; i < 3; i++ {
+			rawToken, err = mock.SignIDTokenWithKeyID(validClaims(), "unknown-key")
+			require.NoError(t, err)
+			_, err = provider.VerifyIDToken(context.Background(), cfg, rawToken, "nonce")
+			require.ErrorIs(t, err, ErrUnknownKey)
+		}
+		require.Equal(t, 1, mock.KeyRequests())
+
+		provider.keysFetchedAt = time.Now().Add(-KeysRefreshInterval)
+		_, err = provider.VerifyIDToken(context.Background(), cfg, rawToken, "nonce")
+		require.ErrorIs(t, err, ErrUnknownKey)
+		require.Equal(t, 2, mock.KeyRequests())
+	})
+}
@@ -0,0 +1,269 @@
+// Package oidctest provides a local OpenID Connect provider to test the
+// single sign-on flows without an external identity provider.
+package oidctest
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultClientID     = "test-client"
+	DefaultClientSecret = "test-secret"
+
+	keyID = "test-key"
+)
+
+type authRequest struct {
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+	claims        map[string]interface{}
+}
+
+// Provider is a mock OpenID Connect provider. Its authorization endpoint
+// authenticates the user with the configured claims and redirects back to
+// the client right away.
+type Provider struct {
+	Server       *httptest.Server
+	ClientID     string
+	ClientSecret string
+
+	key *rsa.PrivateKey
+
+	mux         sync.Mutex
+	claims      map[string]interface{}
+	codes       map[string]authRequest
+	keyRequests int
+}
+
+// NewProvider starts a mock provider, it must be closed after use.
+func NewProvider() (*Provider, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Provider{
+		ClientID:     DefaultClientID,
+		ClientSecret: DefaultClientSecret,
+		key:          key,
+		claims:       map[string]interface{}{},
+		codes:        map[string]authRequest{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", p.handleDiscovery)
+	mux.HandleFunc("/authorize", p.handleAuthorize)
+	mux.HandleFunc("/token", p.handleToken)
+	mux.HandleFunc("/keys", p.handleKeys)
+	p.Server = httptest.NewServer(mux)
+
+	return p, nil
+}
+
+// Issuer returns the issuer URL of the provider.
+func (p *Provider) Issuer() string {
+	return p.Server.URL
+}
+
+// SetClaims sets the claims of the user authenticated by the next
+// authorization requests.
+func (p *Provider) SetClaims(claims map[string]interface{}) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	p.claims = claims
+}
+
+// KeyRequests returns the number of times the key set was fetched.
+func (p *Provider) KeyRequests() int {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	return p.keyRequests
+}
+
+// SignIDToken returns an ID token with the claims signed by the provider
+// key, allowing tests to build tokens the provider would not issue.
+func (p *Provider) SignIDToken(claims map[string]interface{}) (string, error) {
+	return p.SignIDTokenWithKeyID(claims, keyID)
+}
+
+// SignIDTokenWithKeyID returns an ID token signed by the provider key, with
+// the key ID in its header.
+func (p *Provider) SignIDTokenWithKeyID(claims map[string]interface{}, kid string) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": kid})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, p.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// Close shuts the provider server down.
+func (p *Provider) Close() {
+	p.Server.Close()
+}
+
+func (p *Provider) handleDiscovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                p.Issuer(),
+		"authorization_endpoint":                p.Issuer() + "/authorize",
+		"token_endpoint":                        p.Issuer() + "/token",
+		"jwks_uri":                              p.Issuer() + "/keys",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (p *Provider) handleAuthorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("client_id") != p.ClientID || query.Get("response_type") != "code" {
+		http.Error(w, "invalid client or response type", http.StatusBadRequest)
+		return
+	}
+	if query.Get("code_challenge") == "" || query.Get("code_challenge_method") != "S256" {
+		http.Error(w, "PKCE is required", http.StatusBadRequest)
+		return
+	}
+
+	redirectURL, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil || redirectURL.Host == "" {
+		http.Error(w, "invalid redirect URI", http.StatusBadRequest)
+		return
+	}
+
+	code := randomString()
+	p.mux.Lock()
+	p.codes[code] = authRequest{
+		redirectURI:   query.Get("redirect_uri"),
+		nonce:         query.Get("nonce"),
+		codeChallenge: query.Get("code_challenge"),
+		claims:        p.claims,
+	}
+	p.mux.Unlock()
+
+	values := redirectURL.Query()
+	values.Set("code", code)
+	values.Set("state", query.Get("state"))
+	redirectURL.RawQuery = values.Encode()
+	http.Redirect(w, r, redirectURL.String(), http.StatusFound)
+}
+
+func (p *Provider) handleToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	clientID, clientSecret, ok := r.BasicAuth()
+	if ok {
+		clientID, _ = url.QueryUnescape(clientID)
+		clientSecret, _ = url.QueryUnescape(clientSecret)
+	} else {
+		clientID, clientSecret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if clientID != p.ClientID || clientSecret != p.ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	code := r.PostForm.Get("code")
+	p.mux.Lock()
+	request, ok := p.codes[code]
+	delete(p.codes, code)
+	p.mux.Unlock()
+
+	if r.PostForm.Get("grant_type") != "authorization_code" || !ok ||
+		request.redirectURI != r.PostForm.Get("redirect_uri") {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+	verifier := r.PostForm.Get("code_verifier")
+	sum := sha256.Sum256([]byte(verifier))
+	if base64.RawURLEncoding.EncodeToString(sum[:]) != request.codeChallenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	claims := map[string]interface{}{
+		"iss": p.Issuer(),
+		"aud": p.ClientID,
+		"iat": now.Unix(),
+		"exp": now.Add(time.Hour).Unix(),
+	}
+	if request.nonce != "" {
+		claims["nonce"] = request.nonce
+	}
+	for key, value := range request.claims {
+		claims[key] = value
+	}
+
+	idToken, err := p.SignIDToken(claims)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": randomString(),
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idToken,
+	})
+}
+
+func (p *Provider) handleKeys(w http.ResponseWriter, r *http.Request) {
+	p.mux.Lock()
+	p.keyRequests++
+	p.mux.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"use": "sig",
+			"alg": "RS256",
+			"kid": keyID,
+			"n":   base64.RawURLEncoding.EncodeToString(p.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(p.key.E)).Bytes()),
+		}},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func randomString() string {
+	data := make([]byte, 16)
+	_, _ = rand.Read(data)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
@@ -35,6 +35,15 @@ func SetupTestHelper(t *testing.T) *TestHelper {
 }
 
 func (th *TestHelper) checkBoardPermissions(roleName string, member *model.BoardMember, hasPermissionTo, hasNotPermissionTo []*mmModel.Permission) {
+	th.store.EXPECT().
+		GetBoard(member.BoardID).
+		Return(&model.Board{ID: member.BoardID, TeamID: "team-id"}, nil).
+		AnyTimes()
+	th.store.EXPECT().
+		GetTeam("team-id").
+		Return(nil, model.NewErrNotFound("team-id")).
+		AnyTimes()
+
 	for _, p := range hasPermissionTo {
 		th.t.Run(roleName+" "+p.Id, func(t *testing.T) {
 			th.store.EXPECT().
@@ -23,18 +23,74 @@ func New(store permissions.Store, logger mlog.LoggerIFace) *Service {
 	}
 }
 
+// HasPermissionTo grants the manage system permission, the only system
+// permission of standalone servers, to the active system admins.
 func (s *Service) HasPermissionTo(userID string, permission *mmModel.Permission) bool {
-	return false
+	if userID == "" || permission == nil || permission.Id != model.PermissionManageSystem.Id {
+		return false
+	}
+
+	user, err := s.store.GetUserByID(userID)
+	if model.IsErrNotFound(err) {
+		return false
+	}
+	if err != nil {
+		s.logger.Error("error getting user",
+			mlog.String("userID", userID),
+			mlog.Err(err),
+		)
+		return false
+	}
+	return user.IsSystemAdmin()
 }
 
 func (s *Service) HasPermissionToTeam(userID, teamID string, permission *mmModel.Permission) bool {
 	if userID == "" || teamID == "" || permission == nil {
 		return false
 	}
+	hasAccess, isAdmin := s.teamAccess(userID, teamID)
 	if permission.Id == model.PermissionManageTeam.Id {
-		return false
+		return isAdmin
 	}
-	return true
+	return hasAccess
+}
+
+// teamAccess returns whether the user can access the team and whether the
+// user is an admin of the team. Every user can access the root team and the
+// teams that don't exist in the store, but nobody administers them.
+func (s *Service) teamAccess(userID, teamID string) (bool, bool) {
+	team, err := s.store.GetTeam(teamID)
+	if model.IsErrNotFound(err) {
+		return true, false
+	}
+	if err != nil {
+		s.logger.Error("error getting team",
+			mlog.String("teamID", teamID),
+			mlog.String("userID", userID),
+			mlog.Err(err),
+		)
+		return false, false
+	}
+	if !team.IsManaged() {
+		return true, false
+	}
+	if team.DeleteAt != 0 {
+		return false, false
+	}
+
+	member, err := s.store.GetTeamMember(teamID, userID)
+	if model.IsErrNotFound(err) {
+		return false, false
+	}
+	if err != nil {
+		s.logger.Error("error getting team member",
+			mlog.String("teamID", teamID),
+			mlog.String("userID", userID),
+			mlog.Err(err),
+		)
+		return false, false
+	}
+	return true, member.SchemeAdmin
 }
 
 func (s *Service) HasPermissionToChannel(userID, channelID string, permission *mmModel.Permission) bool {
@@ -45,13 +101,19 @@ func (s *Service) HasPermissionToChannel(userID, channelID string, permission *m
 }
 
 func (s *Service) HasPermissionToBoard(userID, boardID string, permission *mmModel.Permission) bool {
+	return s.ExplainPermissionToBoard(userID, boardID, permission).Granted
+}
+
+// ExplainPermissionToBoard resolves a board permission of a user and
+// returns the rule that granted or denied it.
+func (s *Service) ExplainPermissionToBoard(userID, boardID string, permission *mmModel.Permission) *model.BoardPermissionExplanation {
 	if userID == "" || boardID == "" || permission == nil {
-		return false
+		return model.NewBoardPermissionExplanation(permission, false, model.BoardPermissionRuleInvalidInput, "")
 	}
 
 	member, err := s.store.GetMemberForBoard(boardID, userID)
 	if model.IsErrNotFound(err) {
-		return false
+		return model.NewBoardPermissionExplanation(permission, false, model.BoardPermissionRuleNotMember, "")
 	}
 	if err != nil {
 		s.logger.Error("error getting member for board",
@@ -59,30 +121,42 @@ func (s *Service) HasPermissionToBoard(userID, boardID string, permission *mmMod
 			mlog.String("userID", userID),
 			mlog.Err(err),
 		)
-		return false
+		return model.NewBoardPermissionExplanation(permission, false, model.BoardPermissionRuleError, "")
 	}
 
-	switch member.MinimumRole {
-	case "admin":
-		member.SchemeAdmin = true
-	case "editor":
-		member.SchemeEditor = true
-	case "commenter":
-		member.SchemeCommenter = true
-	case "viewer":
-		member.SchemeViewer = true
-	}
-
-	switch permission {
-	case model.PermissionManageBoardType, model.PermissionDeleteBoard, model.PermissionManageBoardRoles, model.PermissionShareBoard, model.PermissionDeleteOthersComments:
-		return member.SchemeAdmin
-	case model.PermissionManageBoardCards, model.PermissionManageBoardProperties:
-		return member.SchemeAdmin || member.SchemeEditor
-	case model.PermissionCommentBoardCards:
-		return member.SchemeAdmin || member.SchemeEditor || member.SchemeCommenter
-	case model.PermissionViewBoard:
-		return member.SchemeAdmin || member.SchemeEditor || member.SchemeCommenter || member.SchemeViewer
-	default:
-		return false
+	board, err := s.store.GetBoard(boardID)
+	if err != nil && !model.IsErrNotFound(err) {
+		s.logger.Error("error getting board",
+			mlog.String("boardID", boardID),
+			mlog.String("userID", userID),
+			mlog.Err(err),
+		)
+		return model.NewBoardPermissionExplanation(permission, false, model.BoardPermissionRuleError, "")
+	}
+
+	if board != nil {
+		// we need to check that the user has permission to see the team
+		// regardless of its local permissions to the board
+		hasTeamAccess, isTeamAdmin := s.teamAccess(userID, board.TeamID)
+		if !hasTeamAccess {
+			return model.NewBoardPermissionExplanation(permission, false, model.BoardPermissionRuleNoTeamAccess, "")
+		}
+
+		// team admins get every permission on the boards of their team
+		isBoardAdmin := member.SchemeAdmin || member.MinimumRole == string(model.BoardRoleAdmin)
+		if !isBoardAdmin && isTeamAdmin {
+			return model.NewBoardPermissionExplanation(permission, true, model.BoardPermissionRuleTeamAdmin, "")
+		}
+	}
+
+	explanation, err := permissions.ExplainMemberPermission(s.store, member, permission)
+	if err != nil {
+		s.logger.Error("error getting custom board role",
+			mlog.String("boardID", boardID),
+			mlog.String("userID", userID),
+			mlog.String("roleID", member.CustomRoleID),
+			mlog.Err(err),
+		)
 	}
+	return explanation
 }
@@ -14,23 +14,101 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func TestHasPermissionTo(t *testing.T) {
+	th := SetupTestHelper(t)
+
+	th.store.EXPECT().
+		GetUserByID("admin-id").
+		Return(&model.User{ID: "admin-id", Roles: "system_user " + model.SystemAdminRole}, nil).
+		AnyTimes()
+	th.store.EXPECT().
+		GetUserByID("user-id").
+		Return(&model.User{ID: "user-id"}, nil).
+		AnyTimes()
+	th.store.EXPECT().
+		GetUserByID("deactivated-id").
+		Return(nil, model.NewErrNotFound("deactivated-id")).
+		AnyTimes()
+
+	t.Run("empty input should always unauthorize", func(t *testing.T) {
+		assert.False(t, th.permissions.HasPermissionTo("", model.PermissionManageSystem))
+		assert.False(t, th.permissions.HasPermissionTo("admin-id", nil))
+	})
+
+	t.Run("only active system admins have PermissionManageSystem", func(t *testing.T) {
+		assert.True(t, th.permissions.HasPermissionTo("admin-id", model.PermissionManageSystem))
+		assert.False(t, th.permissions.HasPermissionTo("user-id", model.PermissionManageSystem))
+		assert.False(t, th.permissions.HasPermissionTo("deactivated-id", model.PermissionManageSystem))
+	})
+
+	t.Run("other system permissions are never granted", func(t *testing.T) {
+		assert.False(t, th.permissions.HasPermissionTo("admin-id", model.PermissionManageTeam))
+	})
+}
+
 func TestHasPermissionToTeam(t *testing.T) {
 	th := SetupTestHelper(t)
 
+	th.store.EXPECT().
+		GetTeam("team-id").
+		Return(nil, model.NewErrNotFound("team-id")).
+		AnyTimes()
+	th.store.EXPECT().
+		GetTeam(model.GlobalTeamID).
+		Return(&model.Team{ID: model.GlobalTeamID}, nil).
+		AnyTimes()
+	th.store.EXPECT().
+		GetTeam("managed-team-id").
+		Return(&model.Team{ID: "managed-team-id", Title: "Managed"}, nil).
+		AnyTimes()
+	th.store.EXPECT().
+		GetTeam("archived-team-id").
+		Return(&model.Team{ID: "archived-team-id", Title: "Archived", DeleteAt: 1}, nil).
+		AnyTimes()
+	th.store.EXPECT().
+		GetTeamMember("managed-team-id", "admin-id").
+		Return(&model.TeamMember{TeamID: "managed-team-id", UserID: "admin-id", SchemeAdmin: true}, nil).
+		AnyTimes()
+	th.store.EXPECT().
+		GetTeamMember("managed-team-id", "member-id").
+		Return(&model.TeamMember{TeamID: "managed-team-id", UserID: "member-id"}, nil).
+		AnyTimes()
+	th.store.EXPECT().
+		GetTeamMember("managed-team-id", "user-id").
+		Return(nil, model.NewErrNotFound("user-id")).
+		AnyTimes()
+
 	t.Run("empty input should always unauthorize", func(t *testing.T) {
 		assert.False(t, th.permissions.HasPermissionToTeam("", "team-id", model.PermissionManageBoardCards))
 		assert.False(t, th.permissions.HasPermissionToTeam("user-id", "", model.PermissionManageBoardCards))
 		assert.False(t, th.permissions.HasPermissionToTeam("user-id", "team-id", nil))
 	})
 
-	t.Run("all users have all permissions on teams", func(t *testing.T) {
-		hasPermission := th.permissions.HasPermissionToTeam("user-id", "team-id", model.PermissionManageBoardCards)
-		assert.True(t, hasPermission)
+	t.Run("all users have all permissions on unmanaged teams", func(t *testing.T) {
+		assert.True(t, th.permissions.HasPermissionToTeam("user-id", "team-id", model.PermissionManageBoardCards))
+		assert.True(t, th.permissions.HasPermissionToTeam("user-id", model.GlobalTeamID, model.PermissionViewTeam))
 	})
 
-	t.Run("no users have PermissionManageTeam on teams", func(t *testing.T) {
-		hasPermission := th.permissions.HasPermissionToTeam("user-id", "team-id", model.PermissionManageTeam)
-		assert.False(t, hasPermission)
+	t.Run("no users have PermissionManageTeam on unmanaged teams", func(t *testing.T) {
+		assert.False(t, th.permissions.HasPermissionToTeam("user-id", "team-id", model.PermissionManageTeam))
+		assert.False(t, th.permissions.HasPermissionToTeam("user-id", model.GlobalTeamID, model.PermissionManageTeam))
+	})
+
+	t.Run("only members can access managed teams", func(t *testing.T) {
+		assert.True(t, th.permissions.HasPermissionToTeam("admin-id", "managed-team-id", model.PermissionViewTeam))
+		assert.True(t, th.permissions.HasPermissionToTeam("member-id", "managed-team-id", model.PermissionViewTeam))
+		assert.False(t, th.permissions.HasPermissionToTeam("user-id", "managed-team-id", model.PermissionViewTeam))
+	})
+
+	t.Run("only team admins have PermissionManageTeam on managed teams", func(t *testing.T) {
+		assert.True(t, th.permissions.HasPermissionToTeam("admin-id", "managed-team-id", model.PermissionManageTeam))
+		assert.False(t, th.permissions.HasPermissionToTeam("member-id", "managed-team-id", model.PermissionManageTeam))
+		assert.False(t, th.permissions.HasPermissionToTeam("user-id", "managed-team-id", model.PermissionManageTeam))
+	})
+
+	t.Run("nobody can access archived teams", func(t *testing.T) {
+		assert.False(t, th.permissions.HasPermissionToTeam("admin-id", "archived-team-id", model.PermissionViewTeam))
+		assert.False(t, th.permissions.HasPermissionToTeam("admin-id", "archived-team-id", model.PermissionManageTeam))
 	})
 }
 
@@ -71,6 +149,8 @@ func TestHasPermissionToBoard(t *testing.T) {
 			model.PermissionManageBoardCards,
 			model.PermissionViewBoard,
 			model.PermissionManageBoardProperties,
+			model.PermissionLockBoard,
+			model.PermissionViewRestrictedCards,
 		}
 
 		hasNotPermissionTo := []*mmModel.Permission{}
@@ -96,11 +176,40 @@ func TestHasPermissionToBoard(t *testing.T) {
 			model.PermissionDeleteBoard,
 			model.PermissionManageBoardRoles,
 			model.PermissionShareBoard,
+			model.PermissionLockBoard,
+			model.PermissionViewRestrictedCards,
 		}
 
 		th.checkBoardPermissions("editor", member, hasPermissionTo, hasNotPermissionTo)
 	})
 
+	t.Run("the lock of a board doesn't change the permissions", func(t *testing.T) {
+		userID := "user-id"
+		boardID := "locked-board-id"
+		member := &model.BoardMember{
+			UserID:      userID,
+			BoardID:     boardID,
+			SchemeAdmin: true,
+		}
+
+		th.store.EXPECT().
+			GetMemberForBoard(boardID, userID).
+			Return(member, nil).
+			Times(3)
+		th.store.EXPECT().
+			GetBoard(boardID).
+			Return(&model.Board{ID: boardID, IsLocked: true}, nil).
+			Times(3)
+		th.store.EXPECT().
+			GetTeam("").
+			Return(nil, model.NewErrNotFound("team")).
+			Times(3)
+
+		assert.True(t, th.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards))
+		assert.True(t, th.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties))
+		assert.True(t, th.permissions.HasPermissionToBoard(userID, boardID, model.PermissionLockBoard))
+	})
+
 	t.Run("board commenter", func(t *testing.T) {
 		member := &model.BoardMember{
 			UserID:          "user-id",
@@ -124,6 +233,39 @@ func TestHasPermissionToBoard(t *testing.T) {
 		th.checkBoardPermissions("commenter", member, hasPermissionTo, hasNotPermissionTo)
 	})
 
+	t.Run("board commenter with a custom role", func(t *testing.T) {
+		member := &model.BoardMember{
+			UserID:          "user-id",
+			BoardID:         "board-id",
+			SchemeCommenter: true,
+			CustomRoleID:    "role-id",
+		}
+
+		th.store.EXPECT().
+			GetCustomBoardRole("role-id").
+			Return(&model.CustomBoardRole{
+				ID:          "role-id",
+				Permissions: []string{model.PermissionManageBoardCards.Id},
+			}, nil).
+			AnyTimes()
+
+		hasPermissionTo := []*mmModel.Permission{
+			model.PermissionViewBoard,
+			model.PermissionCommentBoardCards,
+			model.PermissionManageBoardCards,
+		}
+
+		hasNotPermissionTo := []*mmModel.Permission{
+			model.PermissionManageBoardType,
+			model.PermissionDeleteBoard,
+			model.PermissionManageBoardRoles,
+			model.PermissionShareBoard,
+			model.PermissionManageBoardProperties,
+		}
+
+		th.checkBoardPermissions("custom-role", member, hasPermissionTo, hasNotPermissionTo)
+	})
+
 	t.Run("board viewer", func(t *testing.T) {
 		member := &model.BoardMember{
 			UserID:       "user-id",
@@ -169,4 +311,103 @@ func TestHasPermissionToBoard(t *testing.T) {
 
 		th.checkBoardPermissions("viewer", member, hasPermissionTo, hasNotPermissionTo)
 	})
+
+	t.Run("boards of managed teams", func(t *testing.T) {
+		th := SetupTestHelper(t)
+		boardID := "team-board-id"
+
+		th.store.EXPECT().
+			GetBoard(boardID).
+			Return(&model.Board{ID: boardID, TeamID: "managed-team-id"}, nil).
+			AnyTimes()
+		th.store.EXPECT().
+			GetTeam("managed-team-id").
+			Return(&model.Team{ID: "managed-team-id", Title: "Managed"}, nil).
+			AnyTimes()
+		th.store.EXPECT().
+			GetTeamMember("managed-team-id", "admin-id").
+			Return(&model.TeamMember{TeamID: "managed-team-id", UserID: "admin-id", SchemeAdmin: true}, nil).
+			AnyTimes()
+		th.store.EXPECT().
+			GetTeamMember("managed-team-id", "user-id").
+			Return(nil, model.NewErrNotFound("user-id")).
+			AnyTimes()
+		th.store.EXPECT().
+			GetMemberForBoard(boardID, "admin-id").
+			Return(&model.BoardMember{UserID: "admin-id", BoardID: boardID, SchemeViewer: true}, nil).
+			AnyTimes()
+		th.store.EXPECT().
+			GetMemberForBoard(boardID, "user-id").
+			Return(&model.BoardMember{UserID: "user-id", BoardID: boardID, SchemeAdmin: true}, nil).
+			AnyTimes()
+
+		// team admins are elevated to board admins
+		assert.True(t, th.permissions.HasPermissionToBoard("admin-id", boardID, model.PermissionDeleteBoard))
+		// board members that left the team lose access to its boards
+		assert.False(t, th.permissions.HasPermissionToBoard("user-id", boardID, model.PermissionViewBoard))
+	})
+}
+
+func TestExplainPermissionToBoard(t *testing.T) {
+	th := SetupTestHelper(t)
+
+	explain := func(member *model.BoardMember, permission *mmModel.Permission) *model.BoardPermissionExplanation {
+		th.store.EXPECT().
+			GetMemberForBoard(member.BoardID, member.UserID).
+			Return(member, nil).
+			Times(1)
+		return th.permissions.ExplainPermissionToBoard(member.UserID, member.BoardID, permission)
+	}
+
+	th.store.EXPECT().
+		GetBoard("board-id").
+		Return(&model.Board{ID: "board-id"}, nil).
+		AnyTimes()
+	th.store.EXPECT().
+		GetTeam("").
+		Return(nil, model.NewErrNotFound("team")).
+		AnyTimes()
+
+	t.Run("empty input", func(t *testing.T) {
+		explanation := th.permissions.ExplainPermissionToBoard("", "board-id", model.PermissionViewBoard)
+		assert.Equal(t, model.NewBoardPermissionExplanation(model.PermissionViewBoard, false, model.BoardPermissionRuleInvalidInput, ""), explanation)
+	})
+
+	t.Run("not a member", func(t *testing.T) {
+		th.store.EXPECT().
+			GetMemberForBoard("board-id", "user-id").
+			Return(nil, model.NewErrNotFound("user-id")).
+			Times(1)
+
+		explanation := th.permissions.ExplainPermissionToBoard("user-id", "board-id", model.PermissionViewBoard)
+		assert.Equal(t, model.NewBoardPermissionExplanation(model.PermissionViewBoard, false, model.BoardPermissionRuleNotMember, ""), explanation)
+	})
+
+	t.Run("member role", func(t *testing.T) {
+		member := &model.BoardMember{UserID: "user-id", BoardID: "board-id", SchemeEditor: true, SchemeViewer: true}
+
+		explanation := explain(member, model.PermissionCommentBoardCards)
+		assert.Equal(t, model.NewBoardPermissionExplanation(model.PermissionCommentBoardCards, true, model.BoardPermissionRuleMemberRole, "editor"), explanation)
+	})
+
+	t.Run("minimum role", func(t *testing.T) {
+		member := &model.BoardMember{UserID: "user-id", BoardID: "board-id", SchemeViewer: true, MinimumRole: "editor"}
+
+		explanation := explain(member, model.PermissionManageBoardProperties)
+		assert.Equal(t, model.NewBoardPermissionExplanation(model.PermissionManageBoardProperties, true, model.BoardPermissionRuleMinimumRole, "editor"), explanation)
+	})
+
+	t.Run("custom role", func(t *testing.T) {
+		member := &model.BoardMember{UserID: "user-id", BoardID: "board-id", SchemeViewer: true, CustomRoleID: "role-id"}
+		th.store.EXPECT().
+			GetCustomBoardRole("role-id").
+			Return(&model.CustomBoardRole{ID: "role-id", Permissions: []string{model.PermissionShareBoard.Id}}, nil).
+			Times(2)
+
+		explanation := explain(member, model.PermissionShareBoard)
+		assert.Equal(t, model.NewBoardPermissionExplanation(model.PermissionShareBoard, true, model.BoardPermissionRuleCustomRole, "role-id"), explanation)
+
+		explanation = explain(member, model.PermissionDeleteBoard)
+		assert.Equal(t, model.NewBoardPermissionExplanation(model.PermissionDeleteBoard, false, model.BoardPermissionRuleInsufficientRole, ""), explanation)
+	})
 }
@@ -53,8 +53,14 @@ func (s *Service) HasPermissionToChannel(userID, channelID string, permission *m
 }
 
 func (s *Service) HasPermissionToBoard(userID, boardID string, permission *mmModel.Permission) bool {
+	return s.ExplainPermissionToBoard(userID, boardID, permission).Granted
+}
+
+// ExplainPermissionToBoard resolves a board permission of a user and
+// returns the rule that granted or denied it.
+func (s *Service) ExplainPermissionToBoard(userID, boardID string, permission *mmModel.Permission) *model.BoardPermissionExplanation {
 	if userID == "" || boardID == "" || permission == nil {
-		return false
+		return model.NewBoardPermissionExplanation(permission, false, model.BoardPermissionRuleInvalidInput, "")
 	}
 
 	board, err := s.store.GetBoard(boardID)
@@ -62,10 +68,10 @@ func (s *Service) HasPermissionToBoard(userID, boardID string, permission *mmMod
 		var boards []*model.Board
 		boards, err = s.store.GetBoardHistory(boardID, model.QueryBoardHistoryOptions{Limit: 1, Descending: true})
 		if err != nil {
-			return false
+			return model.NewBoardPermissionExplanation(permission, false, model.BoardPermissionRuleError, "")
 		}
 		if len(boards) == 0 {
-			return false
+			return model.NewBoardPermissionExplanation(permission, false, model.BoardPermissionRuleBoardNotFound, "")
 		}
 		board = boards[0]
 	} else if err != nil {
@@ -74,17 +80,17 @@ func (s *Service) HasPermissionToBoard(userID, boardID string, permission *mmMod
 			mlog.String("userID", userID),
 			mlog.Err(err),
 		)
-		return false
+		return model.NewBoardPermissionExplanation(permission, false, model.BoardPermissionRuleError, "")
 	}
 
 	// we need to check that the user has permission to see the team
 	// regardless of its local permissions to the board
 	if !s.HasPermissionToTeam(userID, board.TeamID, model.PermissionViewTeam) {
-		return false
+		return model.NewBoardPermissionExplanation(permission, false, model.BoardPermissionRuleNoTeamAccess, "")
 	}
 	member, err := s.store.GetMemberForBoard(boardID, userID)
 	if model.IsErrNotFound(err) {
-		return false
+		return model.NewBoardPermissionExplanation(permission, false, model.BoardPermissionRuleNotMember, "")
 	}
 	if err != nil {
 		s.logger.Error("error getting member for board",
@@ -92,37 +98,25 @@ func (s *Service) HasPermissionToBoard(userID, boardID string, permission *mmMod
 			mlog.String("userID", userID),
 			mlog.Err(err),
 		)
-		return false
-	}
-
-	switch member.MinimumRole {
-	case "admin":
-		member.SchemeAdmin = true
-	case "editor":
-		member.SchemeEditor = true
-	case "commenter":
-		member.SchemeCommenter = true
-	case "viewer":
-		member.SchemeViewer = true
+		return model.NewBoardPermissionExplanation(permission, false, model.BoardPermissionRuleError, "")
 	}
 
 	// Admins become member of boards, but get minimal role
 	// if they are a System/Team Admin (model.PermissionManageTeam)
 	// elevate their permissions
-	if !member.SchemeAdmin && s.HasPermissionToTeam(userID, board.TeamID, model.PermissionManageTeam) {
-		return true
+	isBoardAdmin := member.SchemeAdmin || member.MinimumRole == string(model.BoardRoleAdmin)
+	if !isBoardAdmin && s.HasPermissionToTeam(userID, board.TeamID, model.PermissionManageTeam) {
+		return model.NewBoardPermissionExplanation(permission, true, model.BoardPermissionRuleTeamAdmin, "")
 	}
 
-	switch permission {
-	case model.PermissionManageBoardType, model.PermissionDeleteBoard, model.PermissionManageBoardRoles, model.PermissionShareBoard, model.PermissionDeleteOthersComments:
-		return member.SchemeAdmin
-	case model.PermissionManageBoardCards, model.PermissionManageBoardProperties:
-		return member.SchemeAdmin || member.SchemeEditor
-	case model.PermissionCommentBoardCards:
-		return member.SchemeAdmin || member.SchemeEditor || member.SchemeCommenter
-	case model.PermissionViewBoard:
-		return member.SchemeAdmin || member.SchemeEditor || member.SchemeCommenter || member.SchemeViewer
-	default:
-		return false
+	explanation, err := permissions.ExplainMemberPermission(s.store, member, permission)
+	if err != nil {
+		s.logger.Error("error getting custom board role",
+			mlog.String("boardID", boardID),
+			mlog.String("userID", userID),
+			mlog.String("roleID", member.CustomRoleID),
+			mlog.Err(err),
+		)
 	}
+	return explanation
 }
@@ -144,6 +144,8 @@ func TestHasPermissionToBoard(t *testing.T) {
 			model.PermissionManageBoardCards,
 			model.PermissionViewBoard,
 			model.PermissionManageBoardProperties,
+			model.PermissionLockBoard,
+			model.PermissionViewRestrictedCards,
 		}
 
 		hasNotPermissionTo := []*mmModel.Permission{}
@@ -197,6 +199,39 @@ func TestHasPermissionToBoard(t *testing.T) {
 		th.checkBoardPermissions("commenter", member, teamID, hasPermissionTo, hasNotPermissionTo)
 	})
 
+	t.Run("board commenter with a custom role", func(t *testing.T) {
+		member := &model.BoardMember{
+			UserID:          userID,
+			BoardID:         boardID,
+			SchemeCommenter: true,
+			CustomRoleID:    "role-id",
+		}
+
+		th.store.EXPECT().
+			GetCustomBoardRole("role-id").
+			Return(&model.CustomBoardRole{
+				ID:          "role-id",
+				Permissions: []string{model.PermissionManageBoardCards.Id},
+			}, nil).
+			AnyTimes()
+
+		hasPermissionTo := []*mmModel.Permission{
+			model.PermissionViewBoard,
+			model.PermissionCommentBoardCards,
+			model.PermissionManageBoardCards,
+		}
+
+		hasNotPermissionTo := []*mmModel.Permission{
+			model.PermissionManageBoardType,
+			model.PermissionDeleteBoard,
+			model.PermissionManageBoardRoles,
+			model.PermissionShareBoard,
+			model.PermissionManageBoardProperties,
+		}
+
+		th.checkBoardPermissions("custom-role", member, teamID, hasPermissionTo, hasNotPermissionTo)
+	})
+
 	t.Run("board viewer", func(t *testing.T) {
 		member := &model.BoardMember{
 			UserID:       userID,
@@ -241,3 +276,50 @@ func TestHasPermissionToBoard(t *testing.T) {
 		th.checkBoardPermissions("elevated-admin", member, teamID, hasPermissionTo, hasNotPermissionTo)
 	})
 }
+
+func TestExplainPermissionToBoard(t *testing.T) {
+	th := SetupTestHelper(t)
+
+	board := &model.Board{ID: testBoardID, TeamID: testTeamID}
+
+	t.Run("no team access", func(t *testing.T) {
+		th.store.EXPECT().GetBoard(testBoardID).Return(board, nil).Times(1)
+		th.api.EXPECT().HasPermissionToTeam(testUserID, testTeamID, model.PermissionViewTeam).Return(false).Times(1)
+
+		explanation := th.permissions.ExplainPermissionToBoard(testUserID, testBoardID, model.PermissionViewBoard)
+		assert.Equal(t, model.NewBoardPermissionExplanation(model.PermissionViewBoard, false, model.BoardPermissionRuleNoTeamAccess, ""), explanation)
+	})
+
+	t.Run("board not found", func(t *testing.T) {
+		th.store.EXPECT().GetBoard(testBoardID).Return(nil, model.NewErrNotFound(testBoardID)).Times(1)
+		th.store.EXPECT().
+			GetBoardHistory(testBoardID, model.QueryBoardHistoryOptions{Limit: 1, Descending: true}).
+			Return([]*model.Board{}, nil).
+			Times(1)
+
+		explanation := th.permissions.ExplainPermissionToBoard(testUserID, testBoardID, model.PermissionViewBoard)
+		assert.Equal(t, model.NewBoardPermissionExplanation(model.PermissionViewBoard, false, model.BoardPermissionRuleBoardNotFound, ""), explanation)
+	})
+
+	t.Run("team admin", func(t *testing.T) {
+		member := &model.BoardMember{UserID: testUserID, BoardID: testBoardID, SchemeViewer: true}
+		th.store.EXPECT().GetBoard(testBoardID).Return(board, nil).Times(1)
+		th.api.EXPECT().HasPermissionToTeam(testUserID, testTeamID, model.PermissionViewTeam).Return(true).Times(1)
+		th.store.EXPECT().GetMemberForBoard(testBoardID, testUserID).Return(member, nil).Times(1)
+		th.api.EXPECT().HasPermissionToTeam(testUserID, testTeamID, model.PermissionManageTeam).Return(true).Times(1)
+
+		explanation := th.permissions.ExplainPermissionToBoard(testUserID, testBoardID, model.PermissionDeleteBoard)
+		assert.Equal(t, model.NewBoardPermissionExplanation(model.PermissionDeleteBoard, true, model.BoardPermissionRuleTeamAdmin, ""), explanation)
+	})
+
+	t.Run("synthetic membership", func(t *testing.T) {
+		member := &model.BoardMember{UserID: testUserID, BoardID: testBoardID, SchemeEditor: true, Synthetic: true}
+		th.store.EXPECT().GetBoard(testBoardID).Return(board, nil).Times(1)
+		th.api.EXPECT().HasPermissionToTeam(testUserID, testTeamID, model.PermissionViewTeam).Return(true).Times(1)
+		th.store.EXPECT().GetMemberForBoard(testBoardID, testUserID).Return(member, nil).Times(1)
+		th.api.EXPECT().HasPermissionToTeam(testUserID, testTeamID, model.PermissionManageTeam).Return(false).Times(1)
+
+		explanation := th.permissions.ExplainPermissionToBoard(testUserID, testBoardID, model.PermissionManageBoardCards)
+		assert.Equal(t, model.NewBoardPermissionExplanation(model.PermissionManageBoardCards, true, model.BoardPermissionRuleSyntheticMember, "editor"), explanation)
+	})
+}
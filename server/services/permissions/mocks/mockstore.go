@@ -64,6 +64,21 @@ func (mr *MockStoreMockRecorder) GetBoardHistory(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardHistory", reflect.TypeOf((*MockStore)(nil).GetBoardHistory), arg0, arg1)
 }
 
+// GetCustomBoardRole mocks base method.
+func (m *MockStore) GetCustomBoardRole(arg0 string) (*model.CustomBoardRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomBoardRole", arg0)
+	ret0, _ := ret[0].(*model.CustomBoardRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomBoardRole indicates an expected call of GetCustomBoardRole.
+func (mr *MockStoreMockRecorder) GetCustomBoardRole(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomBoardRole", reflect.TypeOf((*MockStore)(nil).GetCustomBoardRole), arg0)
+}
+
 // GetMemberForBoard mocks base method.
 func (m *MockStore) GetMemberForBoard(arg0, arg1 string) (*model.BoardMember, error) {
 	m.ctrl.T.Helper()
@@ -78,3 +93,48 @@ func (mr *MockStoreMockRecorder) GetMemberForBoard(arg0, arg1 interface{}) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberForBoard", reflect.TypeOf((*MockStore)(nil).GetMemberForBoard), arg0, arg1)
 }
+
+// GetTeam mocks base method.
+func (m *MockStore) GetTeam(arg0 string) (*model.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeam", arg0)
+	ret0, _ := ret[0].(*model.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeam indicates an expected call of GetTeam.
+func (mr *MockStoreMockRecorder) GetTeam(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeam", reflect.TypeOf((*MockStore)(nil).GetTeam), arg0)
+}
+
+// GetTeamMember mocks base method.
+func (m *MockStore) GetTeamMember(arg0, arg1 string) (*model.TeamMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamMember", arg0, arg1)
+	ret0, _ := ret[0].(*model.TeamMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamMember indicates an expected call of GetTeamMember.
+func (mr *MockStoreMockRecorder) GetTeamMember(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamMember", reflect.TypeOf((*MockStore)(nil).GetTeamMember), arg0, arg1)
+}
+
+// GetUserByID mocks base method.
+func (m *MockStore) GetUserByID(arg0 string) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", arg0)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockStoreMockRecorder) GetUserByID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockStore)(nil).GetUserByID), arg0)
+}
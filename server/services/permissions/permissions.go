@@ -15,10 +15,114 @@ type PermissionsService interface {
 	HasPermissionToTeam(userID, teamID string, permission *mmModel.Permission) bool
 	HasPermissionToChannel(userID, channelID string, permission *mmModel.Permission) bool
 	HasPermissionToBoard(userID, boardID string, permission *mmModel.Permission) bool
+	ExplainPermissionToBoard(userID, boardID string, permission *mmModel.Permission) *model.BoardPermissionExplanation
 }
 
 type Store interface {
 	GetBoard(boardID string) (*model.Board, error)
 	GetMemberForBoard(boardID, userID string) (*model.BoardMember, error)
 	GetBoardHistory(boardID string, opts model.QueryBoardHistoryOptions) ([]*model.Board, error)
+	GetCustomBoardRole(roleID string) (*model.CustomBoardRole, error)
+	GetTeam(teamID string) (*model.Team, error)
+	GetTeamMember(teamID, userID string) (*model.TeamMember, error)
+	GetUserByID(userID string) (*model.User, error)
+}
+
+// CanSeeCard returns true if the user can see the card. Restricted cards are
+// only visible to the users they are restricted to and to the board admins.
+func CanSeeCard(p PermissionsService, userID string, board *model.Board, card *model.Block) bool {
+	return model.CardIsVisibleTo(card, board, userID) ||
+		p.HasPermissionToBoard(userID, board.ID, model.PermissionViewRestrictedCards)
+}
+
+// HasCustomRolePermission returns true if the custom board role assigned to
+// the board member grants the permission.
+func HasCustomRolePermission(store Store, member *model.BoardMember, permission *mmModel.Permission) (bool, error) {
+	if member.CustomRoleID == "" {
+		return false, nil
+	}
+	role, err := store.GetCustomBoardRole(member.CustomRoleID)
+	if model.IsErrNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return role.HasPermission(permission), nil
+}
+
+// boardRolesWithPermission returns the scheme roles that grant a board
+// permission, from the highest to the lowest.
+func boardRolesWithPermission(permission *mmModel.Permission) []model.BoardRole {
+	switch permission {
+	case model.PermissionManageBoardType, model.PermissionDeleteBoard, model.PermissionManageBoardRoles, model.PermissionShareBoard, model.PermissionDeleteOthersComments, model.PermissionLockBoard, model.PermissionViewRestrictedCards:
+		return []model.BoardRole{model.BoardRoleAdmin}
+	case model.PermissionManageBoardCards, model.PermissionManageBoardProperties:
+		return []model.BoardRole{model.BoardRoleAdmin, model.BoardRoleEditor}
+	case model.PermissionCommentBoardCards:
+		return []model.BoardRole{model.BoardRoleAdmin, model.BoardRoleEditor, model.BoardRoleCommenter}
+	case model.PermissionViewBoard:
+		return []model.BoardRole{model.BoardRoleAdmin, model.BoardRoleEditor, model.BoardRoleCommenter, model.BoardRoleViewer}
+	default:
+		return nil
+	}
+}
+
+func memberHasSchemeRole(member *model.BoardMember, role model.BoardRole) bool {
+	switch role {
+	case model.BoardRoleAdmin:
+		return member.SchemeAdmin
+	case model.BoardRoleEditor:
+		return member.SchemeEditor
+	case model.BoardRoleCommenter:
+		return member.SchemeCommenter
+	case model.BoardRoleViewer:
+		return member.SchemeViewer
+	default:
+		return false
+	}
+}
+
+// ExplainMemberPermission resolves a board permission of a board member
+// from its scheme roles, the minimum role of the board and its custom board
+// role, in that order, and returns the rule that granted or denied it.
+func ExplainMemberPermission(store Store, member *model.BoardMember, permission *mmModel.Permission) (*model.BoardPermissionExplanation, error) {
+	memberRule := model.BoardPermissionRuleMemberRole
+	if member.Synthetic {
+		memberRule = model.BoardPermissionRuleSyntheticMember
+	}
+
+	roles := boardRolesWithPermission(permission)
+	for _, role := range roles {
+		if memberHasSchemeRole(member, role) {
+			return model.NewBoardPermissionExplanation(permission, true, memberRule, string(role)), nil
+		}
+	}
+	for _, role := range roles {
+		if model.BoardRole(member.MinimumRole) == role {
+			return model.NewBoardPermissionExplanation(permission, true, model.BoardPermissionRuleMinimumRole, string(role)), nil
+		}
+	}
+
+	hasPermission, err := HasCustomRolePermission(store, member, permission)
+	if err != nil {
+		return model.NewBoardPermissionExplanation(permission, false, model.BoardPermissionRuleError, member.CustomRoleID), err
+	}
+	if hasPermission {
+		return model.NewBoardPermissionExplanation(permission, true, model.BoardPermissionRuleCustomRole, member.CustomRoleID), nil
+	}
+	return model.NewBoardPermissionExplanation(permission, false, model.BoardPermissionRuleInsufficientRole, ""), nil
+}
+
+// ExplainBoardPermissions explains every board permission of a user.
+func ExplainBoardPermissions(p PermissionsService, userID, boardID string) *model.BoardPermissionsExplanation {
+	explanation := &model.BoardPermissionsExplanation{
+		BoardID:     boardID,
+		UserID:      userID,
+		Permissions: make([]*model.BoardPermissionExplanation, 0, len(model.BoardPermissions)),
+	}
+	for _, permission := range model.BoardPermissions {
+		explanation.Permissions = append(explanation.Permissions, p.ExplainPermissionToBoard(userID, boardID, permission))
+	}
+	return explanation
 }
@@ -115,6 +115,22 @@ func (s *MattermostAuthLayer) GetUserByUsername(username string) (*model.User, e
 	return &user, nil
 }
 
+func (s *MattermostAuthLayer) GetUserByAuthData(authService, authData string) (*model.User, error) {
+	return nil, store.NewNotSupportedError("users are authenticated by mattermost")
+}
+
+func (s *MattermostAuthLayer) GetUsersByAuthService(authService string) ([]*model.User, error) {
+	return nil, store.NewNotSupportedError("users are authenticated by mattermost")
+}
+
+func (s *MattermostAuthLayer) GetUsers(opts model.QueryUsersOptions) ([]*model.User, error) {
+	return nil, store.NewNotSupportedError("users are managed by mattermost")
+}
+
+func (s *MattermostAuthLayer) UpdateUserDeleteAt(userID string, deleteAt int64) error {
+	return store.NewNotSupportedError("no update allowed from focalboard, update it using mattermost")
+}
+
 func (s *MattermostAuthLayer) CreateUser(user *model.User) (*model.User, error) {
 	return nil, store.NewNotSupportedError("no user creation allowed from focalboard, create it using mattermost")
 }
@@ -131,6 +147,34 @@ func (s *MattermostAuthLayer) UpdateUserPasswordByID(userID, password string) er
 	return store.NewNotSupportedError("no update allowed from focalboard, update it using mattermost")
 }
 
+func (s *MattermostAuthLayer) UpdateUserGuest(userID string, isGuest bool, guestExpireAt int64) error {
+	return store.NewNotSupportedError("no update allowed from focalboard, update it using mattermost")
+}
+
+func (s *MattermostAuthLayer) UpdateUserEmailVerified(userID string, emailVerified bool) error {
+	return store.NewNotSupportedError("no update allowed from focalboard, update it using mattermost")
+}
+
+func (s *MattermostAuthLayer) UpdateUserRoles(userID string, roles string) error {
+	return store.NewNotSupportedError("no update allowed from focalboard, update it using mattermost")
+}
+
+func (s *MattermostAuthLayer) UpdateUserScimDeleted(userID string, scimDeleted bool) error {
+	return store.NewNotSupportedError("no update allowed from focalboard, update it using mattermost")
+}
+
+func (s *MattermostAuthLayer) UpdateUserMfa(userID string, secret string, active bool, recoveryCodes []string) error {
+	return store.NewNotSupportedError("no update allowed from focalboard, update it using mattermost")
+}
+
+func (s *MattermostAuthLayer) GetUserMfaRecoveryCodes(userID string) ([]string, error) {
+	return nil, store.NewNotSupportedError("multi-factor authentication is managed by mattermost")
+}
+
+func (s *MattermostAuthLayer) ClaimUserMfaTimeStep(userID string, timeStep int64) (bool, error) {
+	return false, store.NewNotSupportedError("multi-factor authentication is managed by mattermost")
+}
+
 func (s *MattermostAuthLayer) PatchUserPreferences(userID string, patch model.UserPreferencesPatch) (mmModel.Preferences, error) {
 	preferences, err := s.GetUserPreferences(userID)
 	if err != nil {
@@ -240,6 +284,10 @@ func (s *MattermostAuthLayer) GetSession(token string, expireTime int64) (*model
 	return nil, store.NewNotSupportedError("sessions not used when using mattermost")
 }
 
+func (s *MattermostAuthLayer) GetSessionsForUser(userID string, expireTime int64) ([]*model.Session, error) {
+	return nil, store.NewNotSupportedError("sessions not used when using mattermost")
+}
+
 func (s *MattermostAuthLayer) CreateSession(session *model.Session) error {
 	return store.NewNotSupportedError("no update allowed from focalboard, update it using mattermost")
 }
@@ -256,6 +304,10 @@ func (s *MattermostAuthLayer) DeleteSession(sessionID string) error {
 	return store.NewNotSupportedError("no update allowed from focalboard, update it using mattermost")
 }
 
+func (s *MattermostAuthLayer) DeleteSessionsForUser(userID string) error {
+	return store.NewNotSupportedError("no update allowed from focalboard, update it using mattermost")
+}
+
 func (s *MattermostAuthLayer) CleanUpSessions(expireTime int64) error {
 	return store.NewNotSupportedError("no update allowed from focalboard, update it using mattermost")
 }
@@ -500,6 +552,7 @@ func mmUserToFbUser(mmUser *mmModel.User) model.User {
 		FirstName:   mmUser.FirstName,
 		LastName:    mmUser.LastName,
 		MfaSecret:   mmUser.MfaSecret,
+		MfaActive:   mmUser.MfaActive,
 		AuthService: mmUser.AuthService,
 		AuthData:    authData,
 		CreateAt:    mmUser.CreateAt,
@@ -614,6 +667,7 @@ func boardFields(prefix string) []string { //nolint:unparam
 		"show_description",
 		"is_template",
 		"template_version",
+		"is_locked",
 		"COALESCE(properties, '{}')",
 		"COALESCE(card_properties, '[]')",
 		"create_at",
@@ -916,6 +970,7 @@ func (s *MattermostAuthLayer) boardsFromRows(rows *sql.Rows, removeDuplicates bo
 			&board.ShowDescription,
 			&board.IsTemplate,
 			&board.TemplateVersion,
+			&board.IsLocked,
 			&propertiesBytes,
 			&cardPropertiesBytes,
 			&board.CreateAt,
@@ -36,6 +36,21 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// AcceptTeamInvite mocks base method.
+func (m *MockStore) AcceptTeamInvite(arg0, arg1 string) (*model.TeamMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptTeamInvite", arg0, arg1)
+	ret0, _ := ret[0].(*model.TeamMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptTeamInvite indicates an expected call of AcceptTeamInvite.
+func (mr *MockStoreMockRecorder) AcceptTeamInvite(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptTeamInvite", reflect.TypeOf((*MockStore)(nil).AcceptTeamInvite), arg0, arg1)
+}
+
 // AddUpdateCategoryBoard mocks base method.
 func (m *MockStore) AddUpdateCategoryBoard(arg0, arg1 string, arg2 []string) error {
 	m.ctrl.T.Helper()
@@ -50,6 +65,20 @@ func (mr *MockStoreMockRecorder) AddUpdateCategoryBoard(arg0, arg1, arg2 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUpdateCategoryBoard", reflect.TypeOf((*MockStore)(nil).AddUpdateCategoryBoard), arg0, arg1, arg2)
 }
 
+// ArchiveTeam mocks base method.
+func (m *MockStore) ArchiveTeam(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveTeam", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ArchiveTeam indicates an expected call of ArchiveTeam.
+func (mr *MockStoreMockRecorder) ArchiveTeam(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveTeam", reflect.TypeOf((*MockStore)(nil).ArchiveTeam), arg0, arg1)
+}
+
 // CanSeeUser mocks base method.
 func (m *MockStore) CanSeeUser(arg0, arg1 string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +94,66 @@ func (mr *MockStoreMockRecorder) CanSeeUser(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanSeeUser", reflect.TypeOf((*MockStore)(nil).CanSeeUser), arg0, arg1)
 }
 
+// ClaimCardRecurrenceRun mocks base method.
+func (m *MockStore) ClaimCardRecurrenceRun(arg0 string, arg1, arg2 int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimCardRecurrenceRun", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimCardRecurrenceRun indicates an expected call of ClaimCardRecurrenceRun.
+func (mr *MockStoreMockRecorder) ClaimCardRecurrenceRun(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimCardRecurrenceRun", reflect.TypeOf((*MockStore)(nil).ClaimCardRecurrenceRun), arg0, arg1, arg2)
+}
+
+// ClaimShareLinkView mocks base method.
+func (m *MockStore) ClaimShareLinkView(arg0, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimShareLinkView", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimShareLinkView indicates an expected call of ClaimShareLinkView.
+func (mr *MockStoreMockRecorder) ClaimShareLinkView(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimShareLinkView", reflect.TypeOf((*MockStore)(nil).ClaimShareLinkView), arg0, arg1)
+}
+
+// ClaimSystemSetting mocks base method.
+func (m *MockStore) ClaimSystemSetting(arg0, arg1, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimSystemSetting", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimSystemSetting indicates an expected call of ClaimSystemSetting.
+func (mr *MockStoreMockRecorder) ClaimSystemSetting(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimSystemSetting", reflect.TypeOf((*MockStore)(nil).ClaimSystemSetting), arg0, arg1, arg2)
+}
+
+// ClaimUserMfaTimeStep mocks base method.
+func (m *MockStore) ClaimUserMfaTimeStep(arg0 string, arg1 int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimUserMfaTimeStep", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimUserMfaTimeStep indicates an expected call of ClaimUserMfaTimeStep.
+func (mr *MockStoreMockRecorder) ClaimUserMfaTimeStep(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimUserMfaTimeStep", reflect.TypeOf((*MockStore)(nil).ClaimUserMfaTimeStep), arg0, arg1)
+}
+
 // CleanUpSessions mocks base method.
 func (m *MockStore) CleanUpSessions(arg0 int64) error {
 	m.ctrl.T.Helper()
@@ -79,6 +168,66 @@ func (mr *MockStoreMockRecorder) CleanUpSessions(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanUpSessions", reflect.TypeOf((*MockStore)(nil).CleanUpSessions), arg0)
 }
 
+// ConsumeEmailVerificationToken mocks base method.
+func (m *MockStore) ConsumeEmailVerificationToken(arg0 string) (*model.EmailVerificationToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeEmailVerificationToken", arg0)
+	ret0, _ := ret[0].(*model.EmailVerificationToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeEmailVerificationToken indicates an expected call of ConsumeEmailVerificationToken.
+func (mr *MockStoreMockRecorder) ConsumeEmailVerificationToken(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeEmailVerificationToken", reflect.TypeOf((*MockStore)(nil).ConsumeEmailVerificationToken), arg0)
+}
+
+// ConsumePasswordResetToken mocks base method.
+func (m *MockStore) ConsumePasswordResetToken(arg0 string) (*model.PasswordResetToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumePasswordResetToken", arg0)
+	ret0, _ := ret[0].(*model.PasswordResetToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumePasswordResetToken indicates an expected call of ConsumePasswordResetToken.
+func (mr *MockStoreMockRecorder) ConsumePasswordResetToken(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumePasswordResetToken", reflect.TypeOf((*MockStore)(nil).ConsumePasswordResetToken), arg0)
+}
+
+// CreateAccessToken mocks base method.
+func (m *MockStore) CreateAccessToken(arg0 *model.AccessToken) (*model.AccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccessToken", arg0)
+	ret0, _ := ret[0].(*model.AccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAccessToken indicates an expected call of CreateAccessToken.
+func (mr *MockStoreMockRecorder) CreateAccessToken(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccessToken", reflect.TypeOf((*MockStore)(nil).CreateAccessToken), arg0)
+}
+
+// CreateBoardSnapshot mocks base method.
+func (m *MockStore) CreateBoardSnapshot(arg0 *model.BoardSnapshot) (*model.BoardSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBoardSnapshot", arg0)
+	ret0, _ := ret[0].(*model.BoardSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBoardSnapshot indicates an expected call of CreateBoardSnapshot.
+func (mr *MockStoreMockRecorder) CreateBoardSnapshot(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoardSnapshot", reflect.TypeOf((*MockStore)(nil).CreateBoardSnapshot), arg0)
+}
+
 // CreateBoardsAndBlocks mocks base method.
 func (m *MockStore) CreateBoardsAndBlocks(arg0 *model.BoardsAndBlocks, arg1 string) (*model.BoardsAndBlocks, error) {
 	m.ctrl.T.Helper()
@@ -124,6 +273,51 @@ func (mr *MockStoreMockRecorder) CreateCategory(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockStore)(nil).CreateCategory), arg0)
 }
 
+// CreateCustomBoardRole mocks base method.
+func (m *MockStore) CreateCustomBoardRole(arg0 *model.CustomBoardRole) (*model.CustomBoardRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCustomBoardRole", arg0)
+	ret0, _ := ret[0].(*model.CustomBoardRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCustomBoardRole indicates an expected call of CreateCustomBoardRole.
+func (mr *MockStoreMockRecorder) CreateCustomBoardRole(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomBoardRole", reflect.TypeOf((*MockStore)(nil).CreateCustomBoardRole), arg0)
+}
+
+// CreateEmailVerificationToken mocks base method.
+func (m *MockStore) CreateEmailVerificationToken(arg0 *model.EmailVerificationToken) (*model.EmailVerificationToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEmailVerificationToken", arg0)
+	ret0, _ := ret[0].(*model.EmailVerificationToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEmailVerificationToken indicates an expected call of CreateEmailVerificationToken.
+func (mr *MockStoreMockRecorder) CreateEmailVerificationToken(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmailVerificationToken", reflect.TypeOf((*MockStore)(nil).CreateEmailVerificationToken), arg0)
+}
+
+// CreatePasswordResetToken mocks base method.
+func (m *MockStore) CreatePasswordResetToken(arg0 *model.PasswordResetToken) (*model.PasswordResetToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePasswordResetToken", arg0)
+	ret0, _ := ret[0].(*model.PasswordResetToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePasswordResetToken indicates an expected call of CreatePasswordResetToken.
+func (mr *MockStoreMockRecorder) CreatePasswordResetToken(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasswordResetToken", reflect.TypeOf((*MockStore)(nil).CreatePasswordResetToken), arg0)
+}
+
 // CreateSession mocks base method.
 func (m *MockStore) CreateSession(arg0 *model.Session) error {
 	m.ctrl.T.Helper()
@@ -138,6 +332,21 @@ func (mr *MockStoreMockRecorder) CreateSession(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockStore)(nil).CreateSession), arg0)
 }
 
+// CreateShareLink mocks base method.
+func (m *MockStore) CreateShareLink(arg0 *model.ShareLink) (*model.ShareLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShareLink", arg0)
+	ret0, _ := ret[0].(*model.ShareLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateShareLink indicates an expected call of CreateShareLink.
+func (mr *MockStoreMockRecorder) CreateShareLink(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShareLink", reflect.TypeOf((*MockStore)(nil).CreateShareLink), arg0)
+}
+
 // CreateSubscription mocks base method.
 func (m *MockStore) CreateSubscription(arg0 *model.Subscription) (*model.Subscription, error) {
 	m.ctrl.T.Helper()
@@ -153,6 +362,36 @@ func (mr *MockStoreMockRecorder) CreateSubscription(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscription", reflect.TypeOf((*MockStore)(nil).CreateSubscription), arg0)
 }
 
+// CreateTeam mocks base method.
+func (m *MockStore) CreateTeam(arg0 *model.Team, arg1 string) (*model.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTeam", arg0, arg1)
+	ret0, _ := ret[0].(*model.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTeam indicates an expected call of CreateTeam.
+func (mr *MockStoreMockRecorder) CreateTeam(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTeam", reflect.TypeOf((*MockStore)(nil).CreateTeam), arg0, arg1)
+}
+
+// CreateTeamInvite mocks base method.
+func (m *MockStore) CreateTeamInvite(arg0 *model.TeamInvite) (*model.TeamInvite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTeamInvite", arg0)
+	ret0, _ := ret[0].(*model.TeamInvite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTeamInvite indicates an expected call of CreateTeamInvite.
+func (mr *MockStoreMockRecorder) CreateTeamInvite(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTeamInvite", reflect.TypeOf((*MockStore)(nil).CreateTeamInvite), arg0)
+}
+
 // CreateUser mocks base method.
 func (m *MockStore) CreateUser(arg0 *model.User) (*model.User, error) {
 	m.ctrl.T.Helper()
@@ -196,6 +435,34 @@ func (mr *MockStoreMockRecorder) DBVersion() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DBVersion", reflect.TypeOf((*MockStore)(nil).DBVersion))
 }
 
+// DeleteAccessToken mocks base method.
+func (m *MockStore) DeleteAccessToken(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccessToken", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccessToken indicates an expected call of DeleteAccessToken.
+func (mr *MockStoreMockRecorder) DeleteAccessToken(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccessToken", reflect.TypeOf((*MockStore)(nil).DeleteAccessToken), arg0)
+}
+
+// DeleteAccessTokensForUser mocks base method.
+func (m *MockStore) DeleteAccessTokensForUser(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccessTokensForUser", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccessTokensForUser indicates an expected call of DeleteAccessTokensForUser.
+func (mr *MockStoreMockRecorder) DeleteAccessTokensForUser(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccessTokensForUser", reflect.TypeOf((*MockStore)(nil).DeleteAccessTokensForUser), arg0)
+}
+
 // DeleteBlock mocks base method.
 func (m *MockStore) DeleteBlock(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -224,6 +491,20 @@ func (mr *MockStoreMockRecorder) DeleteBlockRecord(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBlockRecord", reflect.TypeOf((*MockStore)(nil).DeleteBlockRecord), arg0, arg1)
 }
 
+// DeleteBlocks mocks base method.
+func (m *MockStore) DeleteBlocks(arg0 []string, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBlocks", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBlocks indicates an expected call of DeleteBlocks.
+func (mr *MockStoreMockRecorder) DeleteBlocks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBlocks", reflect.TypeOf((*MockStore)(nil).DeleteBlocks), arg0, arg1)
+}
+
 // DeleteBoard mocks base method.
 func (m *MockStore) DeleteBoard(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -252,6 +533,20 @@ func (mr *MockStoreMockRecorder) DeleteBoardRecord(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoardRecord", reflect.TypeOf((*MockStore)(nil).DeleteBoardRecord), arg0, arg1)
 }
 
+// DeleteBoardSnapshot mocks base method.
+func (m *MockStore) DeleteBoardSnapshot(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBoardSnapshot", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBoardSnapshot indicates an expected call of DeleteBoardSnapshot.
+func (mr *MockStoreMockRecorder) DeleteBoardSnapshot(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoardSnapshot", reflect.TypeOf((*MockStore)(nil).DeleteBoardSnapshot), arg0)
+}
+
 // DeleteBoardsAndBlocks mocks base method.
 func (m *MockStore) DeleteBoardsAndBlocks(arg0 *model.DeleteBoardsAndBlocks, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -266,6 +561,20 @@ func (mr *MockStoreMockRecorder) DeleteBoardsAndBlocks(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoardsAndBlocks", reflect.TypeOf((*MockStore)(nil).DeleteBoardsAndBlocks), arg0, arg1)
 }
 
+// DeleteCardRecurrence mocks base method.
+func (m *MockStore) DeleteCardRecurrence(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCardRecurrence", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCardRecurrence indicates an expected call of DeleteCardRecurrence.
+func (mr *MockStoreMockRecorder) DeleteCardRecurrence(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCardRecurrence", reflect.TypeOf((*MockStore)(nil).DeleteCardRecurrence), arg0)
+}
+
 // DeleteCategory mocks base method.
 func (m *MockStore) DeleteCategory(arg0, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -280,6 +589,48 @@ func (mr *MockStoreMockRecorder) DeleteCategory(arg0, arg1, arg2 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockStore)(nil).DeleteCategory), arg0, arg1, arg2)
 }
 
+// DeleteCustomBoardRole mocks base method.
+func (m *MockStore) DeleteCustomBoardRole(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCustomBoardRole", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCustomBoardRole indicates an expected call of DeleteCustomBoardRole.
+func (mr *MockStoreMockRecorder) DeleteCustomBoardRole(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCustomBoardRole", reflect.TypeOf((*MockStore)(nil).DeleteCustomBoardRole), arg0)
+}
+
+// DeleteEmailVerificationTokensForUser mocks base method.
+func (m *MockStore) DeleteEmailVerificationTokensForUser(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEmailVerificationTokensForUser", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEmailVerificationTokensForUser indicates an expected call of DeleteEmailVerificationTokensForUser.
+func (mr *MockStoreMockRecorder) DeleteEmailVerificationTokensForUser(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEmailVerificationTokensForUser", reflect.TypeOf((*MockStore)(nil).DeleteEmailVerificationTokensForUser), arg0)
+}
+
+// DeleteLoginAttempts mocks base method.
+func (m *MockStore) DeleteLoginAttempts(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLoginAttempts", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLoginAttempts indicates an expected call of DeleteLoginAttempts.
+func (mr *MockStoreMockRecorder) DeleteLoginAttempts(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLoginAttempts", reflect.TypeOf((*MockStore)(nil).DeleteLoginAttempts), arg0)
+}
+
 // DeleteMember mocks base method.
 func (m *MockStore) DeleteMember(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -294,6 +645,20 @@ func (mr *MockStoreMockRecorder) DeleteMember(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMember", reflect.TypeOf((*MockStore)(nil).DeleteMember), arg0, arg1)
 }
 
+// DeleteNotification mocks base method.
+func (m *MockStore) DeleteNotification(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNotification", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNotification indicates an expected call of DeleteNotification.
+func (mr *MockStoreMockRecorder) DeleteNotification(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNotification", reflect.TypeOf((*MockStore)(nil).DeleteNotification), arg0)
+}
+
 // DeleteNotificationHint mocks base method.
 func (m *MockStore) DeleteNotificationHint(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -308,6 +673,34 @@ func (mr *MockStoreMockRecorder) DeleteNotificationHint(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNotificationHint", reflect.TypeOf((*MockStore)(nil).DeleteNotificationHint), arg0)
 }
 
+// DeleteNotificationsForUser mocks base method.
+func (m *MockStore) DeleteNotificationsForUser(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNotificationsForUser", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNotificationsForUser indicates an expected call of DeleteNotificationsForUser.
+func (mr *MockStoreMockRecorder) DeleteNotificationsForUser(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNotificationsForUser", reflect.TypeOf((*MockStore)(nil).DeleteNotificationsForUser), arg0)
+}
+
+// DeletePasswordResetTokensForUser mocks base method.
+func (m *MockStore) DeletePasswordResetTokensForUser(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePasswordResetTokensForUser", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePasswordResetTokensForUser indicates an expected call of DeletePasswordResetTokensForUser.
+func (mr *MockStoreMockRecorder) DeletePasswordResetTokensForUser(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePasswordResetTokensForUser", reflect.TypeOf((*MockStore)(nil).DeletePasswordResetTokensForUser), arg0)
+}
+
 // DeleteSession mocks base method.
 func (m *MockStore) DeleteSession(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -322,6 +715,34 @@ func (mr *MockStoreMockRecorder) DeleteSession(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockStore)(nil).DeleteSession), arg0)
 }
 
+// DeleteSessionsForUser mocks base method.
+func (m *MockStore) DeleteSessionsForUser(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSessionsForUser", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSessionsForUser indicates an expected call of DeleteSessionsForUser.
+func (mr *MockStoreMockRecorder) DeleteSessionsForUser(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSessionsForUser", reflect.TypeOf((*MockStore)(nil).DeleteSessionsForUser), arg0)
+}
+
+// DeleteShareLink mocks base method.
+func (m *MockStore) DeleteShareLink(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteShareLink", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteShareLink indicates an expected call of DeleteShareLink.
+func (mr *MockStoreMockRecorder) DeleteShareLink(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteShareLink", reflect.TypeOf((*MockStore)(nil).DeleteShareLink), arg0)
+}
+
 // DeleteSubscription mocks base method.
 func (m *MockStore) DeleteSubscription(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -336,6 +757,34 @@ func (mr *MockStoreMockRecorder) DeleteSubscription(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscription", reflect.TypeOf((*MockStore)(nil).DeleteSubscription), arg0, arg1)
 }
 
+// DeleteTeamInvite mocks base method.
+func (m *MockStore) DeleteTeamInvite(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTeamInvite", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTeamInvite indicates an expected call of DeleteTeamInvite.
+func (mr *MockStoreMockRecorder) DeleteTeamInvite(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTeamInvite", reflect.TypeOf((*MockStore)(nil).DeleteTeamInvite), arg0)
+}
+
+// DeleteTeamMember mocks base method.
+func (m *MockStore) DeleteTeamMember(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTeamMember", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTeamMember indicates an expected call of DeleteTeamMember.
+func (mr *MockStoreMockRecorder) DeleteTeamMember(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTeamMember", reflect.TypeOf((*MockStore)(nil).DeleteTeamMember), arg0, arg1)
+}
+
 // DuplicateBlock mocks base method.
 func (m *MockStore) DuplicateBlock(arg0, arg1, arg2 string, arg3 bool) ([]*model.Block, error) {
 	m.ctrl.T.Helper()
@@ -367,6 +816,51 @@ func (mr *MockStoreMockRecorder) DuplicateBoard(arg0, arg1, arg2, arg3 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DuplicateBoard", reflect.TypeOf((*MockStore)(nil).DuplicateBoard), arg0, arg1, arg2, arg3)
 }
 
+// GetAccessToken mocks base method.
+func (m *MockStore) GetAccessToken(arg0 string) (*model.AccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccessToken", arg0)
+	ret0, _ := ret[0].(*model.AccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccessToken indicates an expected call of GetAccessToken.
+func (mr *MockStoreMockRecorder) GetAccessToken(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccessToken", reflect.TypeOf((*MockStore)(nil).GetAccessToken), arg0)
+}
+
+// GetAccessTokenByHash mocks base method.
+func (m *MockStore) GetAccessTokenByHash(arg0 string) (*model.AccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccessTokenByHash", arg0)
+	ret0, _ := ret[0].(*model.AccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccessTokenByHash indicates an expected call of GetAccessTokenByHash.
+func (mr *MockStoreMockRecorder) GetAccessTokenByHash(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccessTokenByHash", reflect.TypeOf((*MockStore)(nil).GetAccessTokenByHash), arg0)
+}
+
+// GetAccessTokensForUser mocks base method.
+func (m *MockStore) GetAccessTokensForUser(arg0 string) ([]*model.AccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccessTokensForUser", arg0)
+	ret0, _ := ret[0].([]*model.AccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccessTokensForUser indicates an expected call of GetAccessTokensForUser.
+func (mr *MockStoreMockRecorder) GetAccessTokensForUser(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccessTokensForUser", reflect.TypeOf((*MockStore)(nil).GetAccessTokensForUser), arg0)
+}
+
 // GetActiveUserCount mocks base method.
 func (m *MockStore) GetActiveUserCount(arg0 int64) (int, error) {
 	m.ctrl.T.Helper()
@@ -473,6 +967,21 @@ func (mr *MockStoreMockRecorder) GetBlockHistoryNewestChildren(arg0, arg1 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockHistoryNewestChildren", reflect.TypeOf((*MockStore)(nil).GetBlockHistoryNewestChildren), arg0, arg1)
 }
 
+// GetBlockHistoryWithChildren mocks base method.
+func (m *MockStore) GetBlockHistoryWithChildren(arg0 string, arg1 model.QueryBlockHistoryOptions) ([]*model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockHistoryWithChildren", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockHistoryWithChildren indicates an expected call of GetBlockHistoryWithChildren.
+func (mr *MockStoreMockRecorder) GetBlockHistoryWithChildren(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockHistoryWithChildren", reflect.TypeOf((*MockStore)(nil).GetBlockHistoryWithChildren), arg0, arg1)
+}
+
 // GetBlocks mocks base method.
 func (m *MockStore) GetBlocks(arg0 model.QueryBlocksOptions) ([]*model.Block, error) {
 	m.ctrl.T.Helper()
@@ -671,6 +1180,51 @@ func (mr *MockStoreMockRecorder) GetBoardMemberHistory(arg0, arg1, arg2 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardMemberHistory", reflect.TypeOf((*MockStore)(nil).GetBoardMemberHistory), arg0, arg1, arg2)
 }
 
+// GetBoardSnapshot mocks base method.
+func (m *MockStore) GetBoardSnapshot(arg0 string) (*model.BoardSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardSnapshot", arg0)
+	ret0, _ := ret[0].(*model.BoardSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardSnapshot indicates an expected call of GetBoardSnapshot.
+func (mr *MockStoreMockRecorder) GetBoardSnapshot(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardSnapshot", reflect.TypeOf((*MockStore)(nil).GetBoardSnapshot), arg0)
+}
+
+// GetBoardSnapshots mocks base method.
+func (m *MockStore) GetBoardSnapshots(arg0 string) ([]*model.BoardSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardSnapshots", arg0)
+	ret0, _ := ret[0].([]*model.BoardSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardSnapshots indicates an expected call of GetBoardSnapshots.
+func (mr *MockStoreMockRecorder) GetBoardSnapshots(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardSnapshots", reflect.TypeOf((*MockStore)(nil).GetBoardSnapshots), arg0)
+}
+
+// GetBoardsAdministeredByUser mocks base method.
+func (m *MockStore) GetBoardsAdministeredByUser(arg0 string) ([]*model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardsAdministeredByUser", arg0)
+	ret0, _ := ret[0].([]*model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardsAdministeredByUser indicates an expected call of GetBoardsAdministeredByUser.
+func (mr *MockStoreMockRecorder) GetBoardsAdministeredByUser(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardsAdministeredByUser", reflect.TypeOf((*MockStore)(nil).GetBoardsAdministeredByUser), arg0)
+}
+
 // GetBoardsComplianceHistory mocks base method.
 func (m *MockStore) GetBoardsComplianceHistory(arg0 model.QueryBoardsComplianceHistoryOptions) ([]*model.BoardHistory, bool, error) {
 	m.ctrl.T.Helper()
@@ -733,6 +1287,21 @@ func (mr *MockStoreMockRecorder) GetBoardsInTeamByIds(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardsInTeamByIds", reflect.TypeOf((*MockStore)(nil).GetBoardsInTeamByIds), arg0, arg1)
 }
 
+// GetBoardsWithAutomations mocks base method.
+func (m *MockStore) GetBoardsWithAutomations() ([]*model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardsWithAutomations")
+	ret0, _ := ret[0].([]*model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardsWithAutomations indicates an expected call of GetBoardsWithAutomations.
+func (mr *MockStoreMockRecorder) GetBoardsWithAutomations() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardsWithAutomations", reflect.TypeOf((*MockStore)(nil).GetBoardsWithAutomations))
+}
+
 // GetCardLimitTimestamp mocks base method.
 func (m *MockStore) GetCardLimitTimestamp() (int64, error) {
 	m.ctrl.T.Helper()
@@ -748,6 +1317,21 @@ func (mr *MockStoreMockRecorder) GetCardLimitTimestamp() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardLimitTimestamp", reflect.TypeOf((*MockStore)(nil).GetCardLimitTimestamp))
 }
 
+// GetCardRecurrence mocks base method.
+func (m *MockStore) GetCardRecurrence(arg0 string) (*model.CardRecurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardRecurrence", arg0)
+	ret0, _ := ret[0].(*model.CardRecurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardRecurrence indicates an expected call of GetCardRecurrence.
+func (mr *MockStoreMockRecorder) GetCardRecurrence(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardRecurrence", reflect.TypeOf((*MockStore)(nil).GetCardRecurrence), arg0)
+}
+
 // GetCategory mocks base method.
 func (m *MockStore) GetCategory(arg0 string) (*model.Category, error) {
 	m.ctrl.T.Helper()
@@ -778,6 +1362,51 @@ func (mr *MockStoreMockRecorder) GetChannel(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannel", reflect.TypeOf((*MockStore)(nil).GetChannel), arg0, arg1)
 }
 
+// GetCustomBoardRole mocks base method.
+func (m *MockStore) GetCustomBoardRole(arg0 string) (*model.CustomBoardRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomBoardRole", arg0)
+	ret0, _ := ret[0].(*model.CustomBoardRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomBoardRole indicates an expected call of GetCustomBoardRole.
+func (mr *MockStoreMockRecorder) GetCustomBoardRole(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomBoardRole", reflect.TypeOf((*MockStore)(nil).GetCustomBoardRole), arg0)
+}
+
+// GetCustomBoardRolesForTeam mocks base method.
+func (m *MockStore) GetCustomBoardRolesForTeam(arg0 string) ([]*model.CustomBoardRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomBoardRolesForTeam", arg0)
+	ret0, _ := ret[0].([]*model.CustomBoardRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomBoardRolesForTeam indicates an expected call of GetCustomBoardRolesForTeam.
+func (mr *MockStoreMockRecorder) GetCustomBoardRolesForTeam(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomBoardRolesForTeam", reflect.TypeOf((*MockStore)(nil).GetCustomBoardRolesForTeam), arg0)
+}
+
+// GetDueCardRecurrences mocks base method.
+func (m *MockStore) GetDueCardRecurrences(arg0 int64, arg1 uint64) ([]*model.CardRecurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueCardRecurrences", arg0, arg1)
+	ret0, _ := ret[0].([]*model.CardRecurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueCardRecurrences indicates an expected call of GetDueCardRecurrences.
+func (mr *MockStoreMockRecorder) GetDueCardRecurrences(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueCardRecurrences", reflect.TypeOf((*MockStore)(nil).GetDueCardRecurrences), arg0, arg1)
+}
+
 // GetFileInfo mocks base method.
 func (m *MockStore) GetFileInfo(arg0 string) (*model0.FileInfo, error) {
 	m.ctrl.T.Helper()
@@ -807,6 +1436,21 @@ func (mr *MockStoreMockRecorder) GetLicense() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLicense", reflect.TypeOf((*MockStore)(nil).GetLicense))
 }
 
+// GetLoginAttempts mocks base method.
+func (m *MockStore) GetLoginAttempts(arg0 string) (*model.LoginAttempts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoginAttempts", arg0)
+	ret0, _ := ret[0].(*model.LoginAttempts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoginAttempts indicates an expected call of GetLoginAttempts.
+func (mr *MockStoreMockRecorder) GetLoginAttempts(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginAttempts", reflect.TypeOf((*MockStore)(nil).GetLoginAttempts), arg0)
+}
+
 // GetMemberForBoard mocks base method.
 func (m *MockStore) GetMemberForBoard(arg0, arg1 string) (*model.BoardMember, error) {
 	m.ctrl.T.Helper()
@@ -867,6 +1511,21 @@ func (mr *MockStoreMockRecorder) GetNextNotificationHint(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNextNotificationHint", reflect.TypeOf((*MockStore)(nil).GetNextNotificationHint), arg0)
 }
 
+// GetNotification mocks base method.
+func (m *MockStore) GetNotification(arg0 string) (*model.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotification", arg0)
+	ret0, _ := ret[0].(*model.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotification indicates an expected call of GetNotification.
+func (mr *MockStoreMockRecorder) GetNotification(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotification", reflect.TypeOf((*MockStore)(nil).GetNotification), arg0)
+}
+
 // GetNotificationHint mocks base method.
 func (m *MockStore) GetNotificationHint(arg0 string) (*model.NotificationHint, error) {
 	m.ctrl.T.Helper()
@@ -879,37 +1538,112 @@ func (m *MockStore) GetNotificationHint(arg0 string) (*model.NotificationHint, e
 // GetNotificationHint indicates an expected call of GetNotificationHint.
 func (mr *MockStoreMockRecorder) GetNotificationHint(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationHint", reflect.TypeOf((*MockStore)(nil).GetNotificationHint), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationHint", reflect.TypeOf((*MockStore)(nil).GetNotificationHint), arg0)
+}
+
+// GetNotificationsForUser mocks base method.
+func (m *MockStore) GetNotificationsForUser(arg0 string, arg1, arg2 int) ([]*model.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationsForUser", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationsForUser indicates an expected call of GetNotificationsForUser.
+func (mr *MockStoreMockRecorder) GetNotificationsForUser(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationsForUser", reflect.TypeOf((*MockStore)(nil).GetNotificationsForUser), arg0, arg1, arg2)
+}
+
+// GetRegisteredUserCount mocks base method.
+func (m *MockStore) GetRegisteredUserCount() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRegisteredUserCount")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRegisteredUserCount indicates an expected call of GetRegisteredUserCount.
+func (mr *MockStoreMockRecorder) GetRegisteredUserCount() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRegisteredUserCount", reflect.TypeOf((*MockStore)(nil).GetRegisteredUserCount))
+}
+
+// GetSession mocks base method.
+func (m *MockStore) GetSession(arg0 string, arg1 int64) (*model.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", arg0, arg1)
+	ret0, _ := ret[0].(*model.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockStoreMockRecorder) GetSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), arg0, arg1)
+}
+
+// GetSessionsForUser mocks base method.
+func (m *MockStore) GetSessionsForUser(arg0 string, arg1 int64) ([]*model.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionsForUser", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionsForUser indicates an expected call of GetSessionsForUser.
+func (mr *MockStoreMockRecorder) GetSessionsForUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionsForUser", reflect.TypeOf((*MockStore)(nil).GetSessionsForUser), arg0, arg1)
+}
+
+// GetShareLink mocks base method.
+func (m *MockStore) GetShareLink(arg0 string) (*model.ShareLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShareLink", arg0)
+	ret0, _ := ret[0].(*model.ShareLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShareLink indicates an expected call of GetShareLink.
+func (mr *MockStoreMockRecorder) GetShareLink(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShareLink", reflect.TypeOf((*MockStore)(nil).GetShareLink), arg0)
 }
 
-// GetRegisteredUserCount mocks base method.
-func (m *MockStore) GetRegisteredUserCount() (int, error) {
+// GetShareLinkByToken mocks base method.
+func (m *MockStore) GetShareLinkByToken(arg0, arg1 string) (*model.ShareLink, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRegisteredUserCount")
-	ret0, _ := ret[0].(int)
+	ret := m.ctrl.Call(m, "GetShareLinkByToken", arg0, arg1)
+	ret0, _ := ret[0].(*model.ShareLink)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetRegisteredUserCount indicates an expected call of GetRegisteredUserCount.
-func (mr *MockStoreMockRecorder) GetRegisteredUserCount() *gomock.Call {
+// GetShareLinkByToken indicates an expected call of GetShareLinkByToken.
+func (mr *MockStoreMockRecorder) GetShareLinkByToken(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRegisteredUserCount", reflect.TypeOf((*MockStore)(nil).GetRegisteredUserCount))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShareLinkByToken", reflect.TypeOf((*MockStore)(nil).GetShareLinkByToken), arg0, arg1)
 }
 
-// GetSession mocks base method.
-func (m *MockStore) GetSession(arg0 string, arg1 int64) (*model.Session, error) {
+// GetShareLinksForBoard mocks base method.
+func (m *MockStore) GetShareLinksForBoard(arg0 string) ([]*model.ShareLink, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSession", arg0, arg1)
-	ret0, _ := ret[0].(*model.Session)
+	ret := m.ctrl.Call(m, "GetShareLinksForBoard", arg0)
+	ret0, _ := ret[0].([]*model.ShareLink)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSession indicates an expected call of GetSession.
-func (mr *MockStoreMockRecorder) GetSession(arg0, arg1 interface{}) *gomock.Call {
+// GetShareLinksForBoard indicates an expected call of GetShareLinksForBoard.
+func (mr *MockStoreMockRecorder) GetShareLinksForBoard(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShareLinksForBoard", reflect.TypeOf((*MockStore)(nil).GetShareLinksForBoard), arg0)
 }
 
 // GetSharing mocks base method.
@@ -1047,6 +1781,21 @@ func (mr *MockStoreMockRecorder) GetTeam(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeam", reflect.TypeOf((*MockStore)(nil).GetTeam), arg0)
 }
 
+// GetTeamBySignupToken mocks base method.
+func (m *MockStore) GetTeamBySignupToken(arg0 string) (*model.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamBySignupToken", arg0)
+	ret0, _ := ret[0].(*model.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamBySignupToken indicates an expected call of GetTeamBySignupToken.
+func (mr *MockStoreMockRecorder) GetTeamBySignupToken(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamBySignupToken", reflect.TypeOf((*MockStore)(nil).GetTeamBySignupToken), arg0)
+}
+
 // GetTeamCount mocks base method.
 func (m *MockStore) GetTeamCount() (int64, error) {
 	m.ctrl.T.Helper()
@@ -1062,6 +1811,66 @@ func (mr *MockStoreMockRecorder) GetTeamCount() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamCount", reflect.TypeOf((*MockStore)(nil).GetTeamCount))
 }
 
+// GetTeamInvite mocks base method.
+func (m *MockStore) GetTeamInvite(arg0 string) (*model.TeamInvite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamInvite", arg0)
+	ret0, _ := ret[0].(*model.TeamInvite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamInvite indicates an expected call of GetTeamInvite.
+func (mr *MockStoreMockRecorder) GetTeamInvite(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamInvite", reflect.TypeOf((*MockStore)(nil).GetTeamInvite), arg0)
+}
+
+// GetTeamInvites mocks base method.
+func (m *MockStore) GetTeamInvites(arg0 string) ([]*model.TeamInvite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamInvites", arg0)
+	ret0, _ := ret[0].([]*model.TeamInvite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamInvites indicates an expected call of GetTeamInvites.
+func (mr *MockStoreMockRecorder) GetTeamInvites(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamInvites", reflect.TypeOf((*MockStore)(nil).GetTeamInvites), arg0)
+}
+
+// GetTeamMember mocks base method.
+func (m *MockStore) GetTeamMember(arg0, arg1 string) (*model.TeamMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamMember", arg0, arg1)
+	ret0, _ := ret[0].(*model.TeamMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamMember indicates an expected call of GetTeamMember.
+func (mr *MockStoreMockRecorder) GetTeamMember(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamMember", reflect.TypeOf((*MockStore)(nil).GetTeamMember), arg0, arg1)
+}
+
+// GetTeamMembers mocks base method.
+func (m *MockStore) GetTeamMembers(arg0 string) ([]*model.TeamMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamMembers", arg0)
+	ret0, _ := ret[0].([]*model.TeamMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamMembers indicates an expected call of GetTeamMembers.
+func (mr *MockStoreMockRecorder) GetTeamMembers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamMembers", reflect.TypeOf((*MockStore)(nil).GetTeamMembers), arg0)
+}
+
 // GetTeamsForUser mocks base method.
 func (m *MockStore) GetTeamsForUser(arg0 string) ([]*model.Team, error) {
 	m.ctrl.T.Helper()
@@ -1092,6 +1901,21 @@ func (mr *MockStoreMockRecorder) GetTemplateBoards(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateBoards", reflect.TypeOf((*MockStore)(nil).GetTemplateBoards), arg0, arg1)
 }
 
+// GetUnreadNotificationsCountForUser mocks base method.
+func (m *MockStore) GetUnreadNotificationsCountForUser(arg0 string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnreadNotificationsCountForUser", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnreadNotificationsCountForUser indicates an expected call of GetUnreadNotificationsCountForUser.
+func (mr *MockStoreMockRecorder) GetUnreadNotificationsCountForUser(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnreadNotificationsCountForUser", reflect.TypeOf((*MockStore)(nil).GetUnreadNotificationsCountForUser), arg0)
+}
+
 // GetUsedCardsCount mocks base method.
 func (m *MockStore) GetUsedCardsCount() (int, error) {
 	m.ctrl.T.Helper()
@@ -1107,6 +1931,21 @@ func (mr *MockStoreMockRecorder) GetUsedCardsCount() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsedCardsCount", reflect.TypeOf((*MockStore)(nil).GetUsedCardsCount))
 }
 
+// GetUserByAuthData mocks base method.
+func (m *MockStore) GetUserByAuthData(arg0, arg1 string) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByAuthData", arg0, arg1)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByAuthData indicates an expected call of GetUserByAuthData.
+func (mr *MockStoreMockRecorder) GetUserByAuthData(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByAuthData", reflect.TypeOf((*MockStore)(nil).GetUserByAuthData), arg0, arg1)
+}
+
 // GetUserByEmail mocks base method.
 func (m *MockStore) GetUserByEmail(arg0 string) (*model.User, error) {
 	m.ctrl.T.Helper()
@@ -1182,6 +2021,21 @@ func (mr *MockStoreMockRecorder) GetUserCategoryBoards(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserCategoryBoards", reflect.TypeOf((*MockStore)(nil).GetUserCategoryBoards), arg0, arg1)
 }
 
+// GetUserMfaRecoveryCodes mocks base method.
+func (m *MockStore) GetUserMfaRecoveryCodes(arg0 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserMfaRecoveryCodes", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserMfaRecoveryCodes indicates an expected call of GetUserMfaRecoveryCodes.
+func (mr *MockStoreMockRecorder) GetUserMfaRecoveryCodes(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserMfaRecoveryCodes", reflect.TypeOf((*MockStore)(nil).GetUserMfaRecoveryCodes), arg0)
+}
+
 // GetUserPreferences mocks base method.
 func (m *MockStore) GetUserPreferences(arg0 string) (model0.Preferences, error) {
 	m.ctrl.T.Helper()
@@ -1212,6 +2066,36 @@ func (mr *MockStoreMockRecorder) GetUserTimezone(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTimezone", reflect.TypeOf((*MockStore)(nil).GetUserTimezone), arg0)
 }
 
+// GetUsers mocks base method.
+func (m *MockStore) GetUsers(arg0 model.QueryUsersOptions) ([]*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsers", arg0)
+	ret0, _ := ret[0].([]*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsers indicates an expected call of GetUsers.
+func (mr *MockStoreMockRecorder) GetUsers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockStore)(nil).GetUsers), arg0)
+}
+
+// GetUsersByAuthService mocks base method.
+func (m *MockStore) GetUsersByAuthService(arg0 string) ([]*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersByAuthService", arg0)
+	ret0, _ := ret[0].([]*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersByAuthService indicates an expected call of GetUsersByAuthService.
+func (mr *MockStoreMockRecorder) GetUsersByAuthService(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByAuthService", reflect.TypeOf((*MockStore)(nil).GetUsersByAuthService), arg0)
+}
+
 // GetUsersByTeam mocks base method.
 func (m *MockStore) GetUsersByTeam(arg0, arg1 string, arg2, arg3 bool) ([]*model.User, error) {
 	m.ctrl.T.Helper()
@@ -1242,6 +2126,21 @@ func (mr *MockStoreMockRecorder) GetUsersList(arg0, arg1, arg2 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersList", reflect.TypeOf((*MockStore)(nil).GetUsersList), arg0, arg1, arg2)
 }
 
+// IncrementLoginAttempts mocks base method.
+func (m *MockStore) IncrementLoginAttempts(arg0 string, arg1 int64) (*model.LoginAttempts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementLoginAttempts", arg0, arg1)
+	ret0, _ := ret[0].(*model.LoginAttempts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrementLoginAttempts indicates an expected call of IncrementLoginAttempts.
+func (mr *MockStoreMockRecorder) IncrementLoginAttempts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementLoginAttempts", reflect.TypeOf((*MockStore)(nil).IncrementLoginAttempts), arg0, arg1)
+}
+
 // InsertBlock mocks base method.
 func (m *MockStore) InsertBlock(arg0 *model.Block, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -1301,6 +2200,20 @@ func (mr *MockStoreMockRecorder) InsertBoardWithAdmin(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBoardWithAdmin", reflect.TypeOf((*MockStore)(nil).InsertBoardWithAdmin), arg0, arg1)
 }
 
+// MoveBlocks mocks base method.
+func (m *MockStore) MoveBlocks(arg0 []*model.Block, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveBlocks", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveBlocks indicates an expected call of MoveBlocks.
+func (mr *MockStoreMockRecorder) MoveBlocks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveBlocks", reflect.TypeOf((*MockStore)(nil).MoveBlocks), arg0, arg1)
+}
+
 // PatchBlock mocks base method.
 func (m *MockStore) PatchBlock(arg0 string, arg1 *model.BlockPatch, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -1359,6 +2272,21 @@ func (mr *MockStoreMockRecorder) PatchBoardsAndBlocks(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchBoardsAndBlocks", reflect.TypeOf((*MockStore)(nil).PatchBoardsAndBlocks), arg0, arg1)
 }
 
+// PatchTeam mocks base method.
+func (m *MockStore) PatchTeam(arg0 string, arg1 *model.TeamPatch, arg2 string) (*model.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchTeam", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchTeam indicates an expected call of PatchTeam.
+func (mr *MockStoreMockRecorder) PatchTeam(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchTeam", reflect.TypeOf((*MockStore)(nil).PatchTeam), arg0, arg1, arg2)
+}
+
 // PatchUserPreferences mocks base method.
 func (m *MockStore) PatchUserPreferences(arg0 string, arg1 model.UserPreferencesPatch) (model0.Preferences, error) {
 	m.ctrl.T.Helper()
@@ -1388,6 +2316,20 @@ func (mr *MockStoreMockRecorder) PostMessage(arg0, arg1, arg2 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostMessage", reflect.TypeOf((*MockStore)(nil).PostMessage), arg0, arg1, arg2)
 }
 
+// RecordShareLinkAccess mocks base method.
+func (m *MockStore) RecordShareLinkAccess(arg0 string, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordShareLinkAccess", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordShareLinkAccess indicates an expected call of RecordShareLinkAccess.
+func (mr *MockStoreMockRecorder) RecordShareLinkAccess(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordShareLinkAccess", reflect.TypeOf((*MockStore)(nil).RecordShareLinkAccess), arg0, arg1)
+}
+
 // RefreshSession mocks base method.
 func (m *MockStore) RefreshSession(arg0 *model.Session) error {
 	m.ctrl.T.Helper()
@@ -1446,6 +2388,34 @@ func (mr *MockStoreMockRecorder) ReorderCategoryBoards(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderCategoryBoards", reflect.TypeOf((*MockStore)(nil).ReorderCategoryBoards), arg0, arg1)
 }
 
+// RestoreBoard mocks base method.
+func (m *MockStore) RestoreBoard(arg0 *model.Board, arg1 []*model.Block, arg2 []string, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreBoard", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreBoard indicates an expected call of RestoreBoard.
+func (mr *MockStoreMockRecorder) RestoreBoard(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreBoard", reflect.TypeOf((*MockStore)(nil).RestoreBoard), arg0, arg1, arg2, arg3)
+}
+
+// RevertBlocks mocks base method.
+func (m *MockStore) RevertBlocks(arg0 []*model.Block, arg1 []string, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevertBlocks", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevertBlocks indicates an expected call of RevertBlocks.
+func (mr *MockStoreMockRecorder) RevertBlocks(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevertBlocks", reflect.TypeOf((*MockStore)(nil).RevertBlocks), arg0, arg1, arg2)
+}
+
 // RunDataRetention mocks base method.
 func (m *MockStore) RunDataRetention(arg0, arg1 int64) (int64, error) {
 	m.ctrl.T.Helper()
@@ -1490,6 +2460,36 @@ func (mr *MockStoreMockRecorder) SaveMember(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMember", reflect.TypeOf((*MockStore)(nil).SaveMember), arg0)
 }
 
+// SaveNotification mocks base method.
+func (m *MockStore) SaveNotification(arg0 *model.Notification) (*model.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveNotification", arg0)
+	ret0, _ := ret[0].(*model.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveNotification indicates an expected call of SaveNotification.
+func (mr *MockStoreMockRecorder) SaveNotification(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveNotification", reflect.TypeOf((*MockStore)(nil).SaveNotification), arg0)
+}
+
+// SaveTeamMember mocks base method.
+func (m *MockStore) SaveTeamMember(arg0 *model.TeamMember) (*model.TeamMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTeamMember", arg0)
+	ret0, _ := ret[0].(*model.TeamMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveTeamMember indicates an expected call of SaveTeamMember.
+func (mr *MockStoreMockRecorder) SaveTeamMember(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTeamMember", reflect.TypeOf((*MockStore)(nil).SaveTeamMember), arg0)
+}
+
 // SearchBoardsForUser mocks base method.
 func (m *MockStore) SearchBoardsForUser(arg0 string, arg1 model.BoardSearchField, arg2 string, arg3 bool) ([]*model.Board, error) {
 	m.ctrl.T.Helper()
@@ -1606,6 +2606,21 @@ func (mr *MockStoreMockRecorder) Shutdown() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockStore)(nil).Shutdown))
 }
 
+// TransferBoardOwnership mocks base method.
+func (m *MockStore) TransferBoardOwnership(arg0, arg1, arg2 string) (*model.BoardMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferBoardOwnership", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.BoardMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransferBoardOwnership indicates an expected call of TransferBoardOwnership.
+func (mr *MockStoreMockRecorder) TransferBoardOwnership(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferBoardOwnership", reflect.TypeOf((*MockStore)(nil).TransferBoardOwnership), arg0, arg1, arg2)
+}
+
 // UndeleteBlock mocks base method.
 func (m *MockStore) UndeleteBlock(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -1634,6 +2649,20 @@ func (mr *MockStoreMockRecorder) UndeleteBoard(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UndeleteBoard", reflect.TypeOf((*MockStore)(nil).UndeleteBoard), arg0, arg1)
 }
 
+// UpdateAccessTokenLastUsed mocks base method.
+func (m *MockStore) UpdateAccessTokenLastUsed(arg0 string, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccessTokenLastUsed", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAccessTokenLastUsed indicates an expected call of UpdateAccessTokenLastUsed.
+func (mr *MockStoreMockRecorder) UpdateAccessTokenLastUsed(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccessTokenLastUsed", reflect.TypeOf((*MockStore)(nil).UpdateAccessTokenLastUsed), arg0, arg1)
+}
+
 // UpdateCardLimitTimestamp mocks base method.
 func (m *MockStore) UpdateCardLimitTimestamp(arg0 int) (int64, error) {
 	m.ctrl.T.Helper()
@@ -1663,6 +2692,35 @@ func (mr *MockStoreMockRecorder) UpdateCategory(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockStore)(nil).UpdateCategory), arg0)
 }
 
+// UpdateCustomBoardRole mocks base method.
+func (m *MockStore) UpdateCustomBoardRole(arg0 *model.CustomBoardRole) (*model.CustomBoardRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCustomBoardRole", arg0)
+	ret0, _ := ret[0].(*model.CustomBoardRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCustomBoardRole indicates an expected call of UpdateCustomBoardRole.
+func (mr *MockStoreMockRecorder) UpdateCustomBoardRole(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCustomBoardRole", reflect.TypeOf((*MockStore)(nil).UpdateCustomBoardRole), arg0)
+}
+
+// UpdateNotificationReadStatus mocks base method.
+func (m *MockStore) UpdateNotificationReadStatus(arg0 string, arg1 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNotificationReadStatus", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNotificationReadStatus indicates an expected call of UpdateNotificationReadStatus.
+func (mr *MockStoreMockRecorder) UpdateNotificationReadStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNotificationReadStatus", reflect.TypeOf((*MockStore)(nil).UpdateNotificationReadStatus), arg0, arg1)
+}
+
 // UpdateSession mocks base method.
 func (m *MockStore) UpdateSession(arg0 *model.Session) error {
 	m.ctrl.T.Helper()
@@ -1706,6 +2764,62 @@ func (mr *MockStoreMockRecorder) UpdateUser(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockStore)(nil).UpdateUser), arg0)
 }
 
+// UpdateUserDeleteAt mocks base method.
+func (m *MockStore) UpdateUserDeleteAt(arg0 string, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserDeleteAt", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserDeleteAt indicates an expected call of UpdateUserDeleteAt.
+func (mr *MockStoreMockRecorder) UpdateUserDeleteAt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserDeleteAt", reflect.TypeOf((*MockStore)(nil).UpdateUserDeleteAt), arg0, arg1)
+}
+
+// UpdateUserEmailVerified mocks base method.
+func (m *MockStore) UpdateUserEmailVerified(arg0 string, arg1 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserEmailVerified", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserEmailVerified indicates an expected call of UpdateUserEmailVerified.
+func (mr *MockStoreMockRecorder) UpdateUserEmailVerified(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserEmailVerified", reflect.TypeOf((*MockStore)(nil).UpdateUserEmailVerified), arg0, arg1)
+}
+
+// UpdateUserGuest mocks base method.
+func (m *MockStore) UpdateUserGuest(arg0 string, arg1 bool, arg2 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserGuest", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserGuest indicates an expected call of UpdateUserGuest.
+func (mr *MockStoreMockRecorder) UpdateUserGuest(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserGuest", reflect.TypeOf((*MockStore)(nil).UpdateUserGuest), arg0, arg1, arg2)
+}
+
+// UpdateUserMfa mocks base method.
+func (m *MockStore) UpdateUserMfa(arg0, arg1 string, arg2 bool, arg3 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserMfa", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserMfa indicates an expected call of UpdateUserMfa.
+func (mr *MockStoreMockRecorder) UpdateUserMfa(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserMfa", reflect.TypeOf((*MockStore)(nil).UpdateUserMfa), arg0, arg1, arg2, arg3)
+}
+
 // UpdateUserPassword mocks base method.
 func (m *MockStore) UpdateUserPassword(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -1734,6 +2848,49 @@ func (mr *MockStoreMockRecorder) UpdateUserPasswordByID(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPasswordByID", reflect.TypeOf((*MockStore)(nil).UpdateUserPasswordByID), arg0, arg1)
 }
 
+// UpdateUserRoles mocks base method.
+func (m *MockStore) UpdateUserRoles(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserRoles", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserRoles indicates an expected call of UpdateUserRoles.
+func (mr *MockStoreMockRecorder) UpdateUserRoles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRoles", reflect.TypeOf((*MockStore)(nil).UpdateUserRoles), arg0, arg1)
+}
+
+// UpdateUserScimDeleted mocks base method.
+func (m *MockStore) UpdateUserScimDeleted(arg0 string, arg1 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserScimDeleted", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserScimDeleted indicates an expected call of UpdateUserScimDeleted.
+func (mr *MockStoreMockRecorder) UpdateUserScimDeleted(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserScimDeleted", reflect.TypeOf((*MockStore)(nil).UpdateUserScimDeleted), arg0, arg1)
+}
+
+// UpsertCardRecurrence mocks base method.
+func (m *MockStore) UpsertCardRecurrence(arg0 *model.CardRecurrence) (*model.CardRecurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertCardRecurrence", arg0)
+	ret0, _ := ret[0].(*model.CardRecurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertCardRecurrence indicates an expected call of UpsertCardRecurrence.
+func (mr *MockStoreMockRecorder) UpsertCardRecurrence(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCardRecurrence", reflect.TypeOf((*MockStore)(nil).UpsertCardRecurrence), arg0)
+}
+
 // UpsertNotificationHint mocks base method.
 func (m *MockStore) UpsertNotificationHint(arg0 *model.NotificationHint, arg1 time.Duration) (*model.NotificationHint, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,211 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+var accessTokenFields = []string{
+	"id",
+	"user_id",
+	"name",
+	"token_hash",
+	"scopes",
+	"expire_at",
+	"last_used_at",
+	"create_at",
+}
+
+func (s *SQLStore) accessTokensFromRows(rows *sql.Rows) ([]*model.AccessToken, error) {
+	tokens := []*model.AccessToken{}
+
+	for rows.Next() {
+		var token model.AccessToken
+		var scopesJSON sql.NullString
+		err := rows.Scan(
+			&token.ID,
+			&token.UserID,
+			&token.Name,
+			&token.TokenHash,
+			&scopesJSON,
+			&token.ExpireAt,
+			&token.LastUsedAt,
+			&token.CreateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		token.Scopes = []string{}
+		if scopesJSON.String != "" {
+			if err := json.Unmarshal([]byte(scopesJSON.String), &token.Scopes); err != nil {
+				return nil, err
+			}
+		}
+		tokens = append(tokens, &token)
+	}
+	return tokens, nil
+}
+
+// createAccessToken creates a personal access token. Only the hash of the
+// token is stored, it is expected to be already computed.
+func (s *SQLStore) createAccessToken(db sq.BaseRunner, token *model.AccessToken) (*model.AccessToken, error) {
+	if err := token.IsValid(); err != nil {
+		return nil, err
+	}
+	if token.TokenHash == "" {
+		return nil, model.NewErrBadRequest("missing access token hash")
+	}
+
+	tokenAdd := *token
+	tokenAdd.ID = utils.NewID(utils.IDTypeNone)
+	tokenAdd.LastUsedAt = 0
+	tokenAdd.CreateAt = utils.GetMillis()
+
+	scopesJSON, err := json.Marshal(tokenAdd.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"access_tokens").
+		Columns(accessTokenFields...).
+		Values(
+			tokenAdd.ID,
+			tokenAdd.UserID,
+			tokenAdd.Name,
+			tokenAdd.TokenHash,
+			string(scopesJSON),
+			tokenAdd.ExpireAt,
+			tokenAdd.LastUsedAt,
+			tokenAdd.CreateAt,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot create access token",
+			mlog.String("user_id", token.UserID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+	return &tokenAdd, nil
+}
+
+func (s *SQLStore) getAccessTokenByCondition(db sq.BaseRunner, condition sq.Eq) (*model.AccessToken, error) {
+	query := s.getQueryBuilder(db).
+		Select(accessTokenFields...).
+		From(s.tablePrefix + "access_tokens").
+		Where(condition)
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch access token", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	tokens, err := s.accessTokensFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, model.NewErrNotFound("access token")
+	}
+	return tokens[0], nil
+}
+
+// getAccessToken fetches the specified access token.
+func (s *SQLStore) getAccessToken(db sq.BaseRunner, tokenID string) (*model.AccessToken, error) {
+	return s.getAccessTokenByCondition(db, sq.Eq{"id": tokenID})
+}
+
+// getAccessTokenByHash fetches the access token that has the specified
+// hash.
+func (s *SQLStore) getAccessTokenByHash(db sq.BaseRunner, tokenHash string) (*model.AccessToken, error) {
+	return s.getAccessTokenByCondition(db, sq.Eq{"token_hash": tokenHash})
+}
+
+// getAccessTokensForUser fetches the access tokens of a user, oldest
+// first.
+func (s *SQLStore) getAccessTokensForUser(db sq.BaseRunner, userID string) ([]*model.AccessToken, error) {
+	query := s.getQueryBuilder(db).
+		Select(accessTokenFields...).
+		From(s.tablePrefix+"access_tokens").
+		Where(sq.Eq{"user_id": userID}).
+		OrderBy("create_at", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch access tokens",
+			mlog.String("user_id", userID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.accessTokensFromRows(rows)
+}
+
+// updateAccessTokenLastUsed records the last time an access token was
+// used.
+func (s *SQLStore) updateAccessTokenLastUsed(db sq.BaseRunner, tokenID string, usedAt int64) error {
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"access_tokens").
+		Set("last_used_at", usedAt).
+		Where(sq.Eq{"id": tokenID})
+
+	result, err := query.Exec()
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return model.NewErrNotFound("access token ID=" + tokenID)
+	}
+	return nil
+}
+
+// deleteAccessToken revokes an access token.
+func (s *SQLStore) deleteAccessToken(db sq.BaseRunner, tokenID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "access_tokens").
+		Where(sq.Eq{"id": tokenID})
+
+	result, err := query.Exec()
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return model.NewErrNotFound("access token ID=" + tokenID)
+	}
+	return nil
+}
+
+// deleteAccessTokensForUser revokes all the access tokens of a user.
+func (s *SQLStore) deleteAccessTokensForUser(db sq.BaseRunner, userID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "access_tokens").
+		Where(sq.Eq{"user_id": userID})
+
+	_, err := query.Exec()
+	return err
+}
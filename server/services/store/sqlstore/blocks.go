@@ -70,6 +70,10 @@ func (s *SQLStore) getBlocks(db sq.BaseRunner, opts model.QueryBlocksOptions) ([
 		query = query.Where(sq.Eq{"type": opts.BlockType})
 	}
 
+	if opts.ExcludeArchived {
+		query = query.Where(s.notArchivedCondition())
+	}
+
 	if opts.Page != 0 {
 		query = query.Offset(uint64(opts.Page * opts.PerPage))
 	}
@@ -89,6 +93,14 @@ func (s *SQLStore) getBlocks(db sq.BaseRunner, opts model.QueryBlocksOptions) ([
 	return s.blocksFromRows(rows)
 }
 
+// notArchivedCondition matches blocks that have no `archivedAt` field set.
+func (s *SQLStore) notArchivedCondition() sq.Sqlizer {
+	if s.dbType == model.PostgresDBType {
+		return sq.Expr("COALESCE((fields->>'archivedAt')::bigint, 0) = 0")
+	}
+	return sq.Expr("COALESCE(JSON_EXTRACT(fields, '$.archivedAt'), 0) = 0")
+}
+
 func (s *SQLStore) getBlocksWithParentAndType(db sq.BaseRunner, boardID, parentID string, blockType string) ([]*model.Block, error) {
 	opts := model.QueryBlocksOptions{
 		BoardID:   boardID,
@@ -349,10 +361,94 @@ func (s *SQLStore) insertBlocks(db sq.BaseRunner, blocks []*model.Block, userID
 	return nil
 }
 
+// moveBlocks saves existing blocks that may have been moved to a different
+// board, updating their board, parent and fields and writing their history.
+func (s *SQLStore) moveBlocks(db sq.BaseRunner, blocks []*model.Block, userID string) error {
+	for _, block := range blocks {
+		if err := block.IsValid(); err != nil {
+			return fmt.Errorf("error validating block %s: %w", block.ID, err)
+		}
+	}
+
+	now := utils.GetMillis()
+	for _, block := range blocks {
+		fieldsJSON, err := json.Marshal(block.Fields)
+		if err != nil {
+			return err
+		}
+
+		block.UpdateAt = now
+		block.ModifiedBy = userID
+
+		query := s.getQueryBuilder(db).Update(s.tablePrefix+"blocks").
+			Where(sq.Eq{"id": block.ID}).
+			Set("board_id", block.BoardID).
+			Set("parent_id", block.ParentID).
+			Set("modified_by", block.ModifiedBy).
+			Set("title", block.Title).
+			Set("fields", fieldsJSON).
+			Set("update_at", block.UpdateAt)
+
+		result, err := query.Exec()
+		if err != nil {
+			s.logger.Error(`MoveBlocks error occurred while updating block`, mlog.String("blockID", block.ID), mlog.Err(err))
+			return err
+		}
+		count, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return model.NewErrNotFound("block ID=" + block.ID)
+		}
+
+		historyQuery := s.getQueryBuilder(db).Insert(s.tablePrefix + "blocks_history").
+			SetMap(map[string]interface{}{
+				"channel_id":            "",
+				"id":                    block.ID,
+				"parent_id":             block.ParentID,
+				s.escapeField("schema"): block.Schema,
+				"type":                  block.Type,
+				"title":                 block.Title,
+				"fields":                fieldsJSON,
+				"delete_at":             block.DeleteAt,
+				"created_by":            block.CreatedBy,
+				"modified_by":           block.ModifiedBy,
+				"create_at":             block.CreateAt,
+				"update_at":             block.UpdateAt,
+				"board_id":              block.BoardID,
+			})
+		if _, err := historyQuery.Exec(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *SQLStore) deleteBlock(db sq.BaseRunner, blockID string, modifiedBy string) error {
 	return s.deleteBlockAndChildren(db, blockID, modifiedBy, false)
 }
 
+// revertBlocks saves the given versions of blocks, inserting the ones that no
+// longer exist, and deletes the blocks in deletedBlockIDs.
+func (s *SQLStore) revertBlocks(db sq.BaseRunner, blocks []*model.Block, deletedBlockIDs []string, userID string) error {
+	for _, block := range blocks {
+		if err := s.insertBlock(db, block, userID); err != nil {
+			return err
+		}
+	}
+	return s.deleteBlocks(db, deletedBlockIDs, userID)
+}
+
+func (s *SQLStore) deleteBlocks(db sq.BaseRunner, blockIDs []string, modifiedBy string) error {
+	for _, blockID := range blockIDs {
+		if err := s.deleteBlockAndChildren(db, blockID, modifiedBy, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func retrieveFileIDFromBlockFieldStorage(id string) string {
 	parts := strings.Split(id, ".")
 	if len(parts) < 1 {
@@ -632,6 +728,42 @@ func (s *SQLStore) getBlockHistory(db sq.BaseRunner, blockID string, opts model.
 	return s.blocksFromRows(rows)
 }
 
+// getBlockHistoryWithChildren returns the history of a block and of its
+// direct children.
+func (s *SQLStore) getBlockHistoryWithChildren(db sq.BaseRunner, blockID string, opts model.QueryBlockHistoryOptions) ([]*model.Block, error) {
+	var order string
+	if opts.Descending {
+		order = descClause
+	}
+
+	query := s.getQueryBuilder(db).
+		Select(s.blockFields("")...).
+		From(s.tablePrefix + "blocks_history").
+		Where(sq.Or{sq.Eq{"id": blockID}, sq.Eq{"parent_id": blockID}}).
+		OrderBy("insert_at " + order + ", update_at" + order)
+
+	if opts.BeforeUpdateAt != 0 {
+		query = query.Where(sq.Lt{"update_at": opts.BeforeUpdateAt})
+	}
+
+	if opts.AfterUpdateAt != 0 {
+		query = query.Where(sq.Gt{"update_at": opts.AfterUpdateAt})
+	}
+
+	if opts.Limit != 0 {
+		query = query.Limit(opts.Limit)
+	}
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`GetBlockHistoryWithChildren ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.blocksFromRows(rows)
+}
+
 func (s *SQLStore) getBlockHistoryDescendants(db sq.BaseRunner, boardID string, opts model.QueryBlockHistoryOptions) ([]*model.Block, error) {
 	var order string
 	if opts.Descending {
@@ -36,6 +36,7 @@ func boardFields(tableAlias string) []string {
 		tableAlias + "show_description",
 		tableAlias + "is_template",
 		tableAlias + "template_version",
+		tableAlias + "is_locked",
 		"COALESCE(" + tableAlias + "properties, '{}')",
 		"COALESCE(" + tableAlias + "card_properties, '[]')",
 		tableAlias + "create_at",
@@ -59,6 +60,7 @@ func boardHistoryFields() []string {
 		"COALESCE(show_description, false)",
 		"COALESCE(is_template, false)",
 		"template_version",
+		"COALESCE(is_locked, false)",
 		"COALESCE(properties, '{}')",
 		"COALESCE(card_properties, '[]')",
 		"COALESCE(create_at, 0)",
@@ -78,6 +80,7 @@ var boardMemberFields = []string{
 	"BM.scheme_editor",
 	"BM.scheme_commenter",
 	"BM.scheme_viewer",
+	"COALESCE(BM.custom_role_id, '')",
 }
 
 func (s *SQLStore) boardsFromRows(rows *sql.Rows) ([]*model.Board, error) {
@@ -102,6 +105,7 @@ func (s *SQLStore) boardsFromRows(rows *sql.Rows) ([]*model.Board, error) {
 			&board.ShowDescription,
 			&board.IsTemplate,
 			&board.TemplateVersion,
+			&board.IsLocked,
 			&propertiesBytes,
 			&cardPropertiesBytes,
 			&board.CreateAt,
@@ -145,6 +149,7 @@ func (s *SQLStore) boardMembersFromRows(rows *sql.Rows) ([]*model.BoardMember, e
 			&boardMember.SchemeEditor,
 			&boardMember.SchemeCommenter,
 			&boardMember.SchemeViewer,
+			&boardMember.CustomRoleID,
 		)
 		if err != nil {
 			return nil, err
@@ -294,6 +299,30 @@ func (s *SQLStore) getBoardsInTeamByIds(db sq.BaseRunner, boardIDs []string, tea
 	return boards, nil
 }
 
+// getBoardsWithAutomations returns the boards that have automation rules
+// in their properties. Templates are not included.
+func (s *SQLStore) getBoardsWithAutomations(db sq.BaseRunner) ([]*model.Board, error) {
+	propertiesColumn := "properties"
+	if s.dbType == model.PostgresDBType {
+		propertiesColumn = "CAST(properties AS TEXT)"
+	}
+
+	query := s.getQueryBuilder(db).
+		Select(boardFields("")...).
+		From(s.tablePrefix + "boards").
+		Where(sq.Eq{"is_template": false}).
+		Where(sq.Like{propertiesColumn: `%"` + model.BoardPropertyAutomations + `"%`})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getBoardsWithAutomations ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.boardsFromRows(rows)
+}
+
 func (s *SQLStore) insertBoard(db sq.BaseRunner, board *model.Board, userID string) (*model.Board, error) {
 	// Generate tracking IDs for in-built templates
 	if board.IsTemplate && board.TeamID == model.GlobalTeamID {
@@ -350,6 +379,7 @@ func (s *SQLStore) insertBoard(db sq.BaseRunner, board *model.Board, userID stri
 		"show_description": board.ShowDescription,
 		"is_template":      board.IsTemplate,
 		"template_version": board.TemplateVersion,
+		"is_locked":        board.IsLocked,
 		"properties":       propertiesBytes,
 		"card_properties":  cardPropertiesBytes,
 		"create_at":        board.CreateAt,
@@ -370,6 +400,7 @@ func (s *SQLStore) insertBoard(db sq.BaseRunner, board *model.Board, userID stri
 			Set("show_description", board.ShowDescription).
 			Set("is_template", board.IsTemplate).
 			Set("template_version", board.TemplateVersion).
+			Set("is_locked", board.IsLocked).
 			Set("properties", propertiesBytes).
 			Set("card_properties", cardPropertiesBytes).
 			Set("update_at", board.UpdateAt).
@@ -446,6 +477,7 @@ func (s *SQLStore) deleteBoardAndChildren(db sq.BaseRunner, boardID, userID stri
 		"show_description": board.ShowDescription,
 		"is_template":      board.IsTemplate,
 		"template_version": board.TemplateVersion,
+		"is_locked":        board.IsLocked,
 		"properties":       propertiesBytes,
 		"card_properties":  cardPropertiesBytes,
 		"create_at":        board.CreateAt,
@@ -508,6 +540,7 @@ func (s *SQLStore) saveMember(db sq.BaseRunner, bm *model.BoardMember) (*model.B
 		"scheme_editor":    bm.SchemeEditor,
 		"scheme_commenter": bm.SchemeCommenter,
 		"scheme_viewer":    bm.SchemeViewer,
+		"custom_role_id":   bm.CustomRoleID,
 	}
 
 	oldMember, err := s.getMemberForBoard(db, bm.BoardID, bm.UserID)
@@ -521,13 +554,14 @@ func (s *SQLStore) saveMember(db sq.BaseRunner, bm *model.BoardMember) (*model.B
 
 	if s.dbType == model.MysqlDBType {
 		query = query.Suffix(
-			"ON DUPLICATE KEY UPDATE scheme_admin = ?, scheme_editor = ?, scheme_commenter = ?, scheme_viewer = ?",
-			bm.SchemeAdmin, bm.SchemeEditor, bm.SchemeCommenter, bm.SchemeViewer)
+			"ON DUPLICATE KEY UPDATE scheme_admin = ?, scheme_editor = ?, scheme_commenter = ?, scheme_viewer = ?, custom_role_id = ?",
+			bm.SchemeAdmin, bm.SchemeEditor, bm.SchemeCommenter, bm.SchemeViewer, bm.CustomRoleID)
 	} else {
 		query = query.Suffix(
 			`ON CONFLICT (board_id, user_id)
              DO UPDATE SET scheme_admin = EXCLUDED.scheme_admin, scheme_editor = EXCLUDED.scheme_editor,
-			   scheme_commenter = EXCLUDED.scheme_commenter, scheme_viewer = EXCLUDED.scheme_viewer`,
+			   scheme_commenter = EXCLUDED.scheme_commenter, scheme_viewer = EXCLUDED.scheme_viewer,
+			   custom_role_id = EXCLUDED.custom_role_id`,
 		)
 	}
 
@@ -539,7 +573,7 @@ func (s *SQLStore) saveMember(db sq.BaseRunner, bm *model.BoardMember) (*model.B
 		addToMembersHistory := s.getQueryBuilder(db).
 			Insert(s.tablePrefix+"board_members_history").
 			Columns("board_id", "user_id", "action").
-			Values(bm.BoardID, bm.UserID, "created")
+			Values(bm.BoardID, bm.UserID, model.BoardMemberHistoryCreated)
 
 		if _, err := addToMembersHistory.Exec(); err != nil {
 			return nil, err
@@ -569,7 +603,7 @@ func (s *SQLStore) deleteMember(db sq.BaseRunner, boardID, userID string) error
 		addToMembersHistory := s.getQueryBuilder(db).
 			Insert(s.tablePrefix+"board_members_history").
 			Columns("board_id", "user_id", "action").
-			Values(boardID, userID, "deleted")
+			Values(boardID, userID, model.BoardMemberHistoryDeleted)
 
 		if _, err := addToMembersHistory.Exec(); err != nil {
 			return err
@@ -579,6 +613,103 @@ func (s *SQLStore) deleteMember(db sq.BaseRunner, boardID, userID string) error
 	return nil
 }
 
+// addBoardMemberHistory records an action on a board membership.
+func (s *SQLStore) addBoardMemberHistory(db sq.BaseRunner, boardID, userID, action string) error {
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"board_members_history").
+		Columns("board_id", "user_id", "action").
+		Values(boardID, userID, action)
+
+	_, err := query.Exec()
+	return err
+}
+
+// getBoardsAdministeredByUser returns the boards and templates that the
+// user created or is an admin of.
+func (s *SQLStore) getBoardsAdministeredByUser(db sq.BaseRunner, userID string) ([]*model.Board, error) {
+	query := s.getQueryBuilder(db).
+		Select(boardFields("b.")...).
+		Distinct().
+		From(s.tablePrefix+"boards as b").
+		LeftJoin(s.tablePrefix+"board_members as bm on b.id=bm.board_id and bm.user_id=?", userID).
+		Where(sq.Or{
+			sq.Eq{"b.created_by": userID},
+			sq.Eq{"bm.scheme_admin": true},
+		})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getBoardsAdministeredByUser ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.boardsFromRows(rows)
+}
+
+// transferBoardOwnership makes toUserID an admin of the board, and its
+// creator if fromUserID created it. fromUserID loses the admin role but
+// keeps editing the board. Role changes are recorded in the member history.
+func (s *SQLStore) transferBoardOwnership(db sq.BaseRunner, boardID, fromUserID, toUserID string) (*model.BoardMember, error) {
+	board, err := s.getBoard(db, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if board.CreatedBy == fromUserID {
+		query := s.getQueryBuilder(db).
+			Update(s.tablePrefix+"boards").
+			Set("created_by", toUserID).
+			Where(sq.Eq{"id": boardID})
+
+		if _, err = query.Exec(); err != nil {
+			return nil, fmt.Errorf("cannot update creator of board %s: %w", boardID, err)
+		}
+	}
+
+	fromMember, err := s.getMemberForBoard(db, boardID, fromUserID)
+	if err != nil && !model.IsErrNotFound(err) {
+		return nil, err
+	}
+	if fromMember != nil && fromMember.SchemeAdmin {
+		fromMember.SchemeAdmin = false
+		fromMember.SchemeEditor = true
+		if _, err = s.saveMember(db, fromMember); err != nil {
+			return nil, err
+		}
+		if err = s.addBoardMemberHistory(db, boardID, fromUserID, model.BoardMemberHistoryDemoted); err != nil {
+			return nil, err
+		}
+	}
+
+	toMember, err := s.getMemberForBoard(db, boardID, toUserID)
+	if model.IsErrNotFound(err) {
+		// new members are recorded as created by saveMember
+		return s.saveMember(db, &model.BoardMember{
+			BoardID:      boardID,
+			UserID:       toUserID,
+			SchemeAdmin:  true,
+			SchemeEditor: true,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	if toMember.SchemeAdmin {
+		return toMember, nil
+	}
+
+	toMember.SchemeAdmin = true
+	toMember.SchemeEditor = true
+	if _, err = s.saveMember(db, toMember); err != nil {
+		return nil, err
+	}
+	if err = s.addBoardMemberHistory(db, boardID, toUserID, model.BoardMemberHistoryPromoted); err != nil {
+		return nil, err
+	}
+	return toMember, nil
+}
+
 func (s *SQLStore) getMemberForBoard(db sq.BaseRunner, boardID, userID string) (*model.BoardMember, error) {
 	query := s.getQueryBuilder(db).
 		Select(boardMemberFields...).
@@ -828,6 +959,7 @@ func (s *SQLStore) undeleteBoard(db sq.BaseRunner, boardID string, modifiedBy st
 		"show_description",
 		"is_template",
 		"template_version",
+		"is_locked",
 		"properties",
 		"card_properties",
 		"create_at",
@@ -849,6 +981,7 @@ func (s *SQLStore) undeleteBoard(db sq.BaseRunner, boardID string, modifiedBy st
 		board.ShowDescription,
 		board.IsTemplate,
 		board.TemplateVersion,
+		board.IsLocked,
 		propertiesJSON,
 		cardPropertiesJSON,
 		board.CreateAt,
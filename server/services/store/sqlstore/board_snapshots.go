@@ -0,0 +1,157 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+var boardSnapshotFields = []string{
+	"id",
+	"board_id",
+	"name",
+	"type",
+	"created_by",
+	"create_at",
+}
+
+func (s *SQLStore) boardSnapshotsFromRows(rows *sql.Rows) ([]*model.BoardSnapshot, error) {
+	snapshots := []*model.BoardSnapshot{}
+
+	for rows.Next() {
+		var snapshot model.BoardSnapshot
+		err := rows.Scan(
+			&snapshot.ID,
+			&snapshot.BoardID,
+			&snapshot.Name,
+			&snapshot.Type,
+			&snapshot.CreatedBy,
+			&snapshot.CreateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, &snapshot)
+	}
+	return snapshots, nil
+}
+
+// createBoardSnapshot records a snapshot of a board at the current time.
+func (s *SQLStore) createBoardSnapshot(db sq.BaseRunner, snapshot *model.BoardSnapshot) (*model.BoardSnapshot, error) {
+	if err := snapshot.IsValid(); err != nil {
+		return nil, err
+	}
+
+	snapshotAdd := *snapshot
+	if snapshotAdd.ID == "" {
+		snapshotAdd.ID = utils.NewID(utils.IDTypeNone)
+	}
+	snapshotAdd.CreateAt = utils.GetMillis()
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"board_snapshots").
+		Columns(boardSnapshotFields...).
+		Values(
+			snapshotAdd.ID,
+			snapshotAdd.BoardID,
+			snapshotAdd.Name,
+			snapshotAdd.Type,
+			snapshotAdd.CreatedBy,
+			snapshotAdd.CreateAt,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot create board snapshot",
+			mlog.String("board_id", snapshot.BoardID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+	return &snapshotAdd, nil
+}
+
+// getBoardSnapshot fetches the specified snapshot.
+func (s *SQLStore) getBoardSnapshot(db sq.BaseRunner, snapshotID string) (*model.BoardSnapshot, error) {
+	query := s.getQueryBuilder(db).
+		Select(boardSnapshotFields...).
+		From(s.tablePrefix + "board_snapshots").
+		Where(sq.Eq{"id": snapshotID})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch board snapshot",
+			mlog.String("snapshot_id", snapshotID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	snapshots, err := s.boardSnapshotsFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshots) == 0 {
+		return nil, model.NewErrNotFound("board snapshot ID=" + snapshotID)
+	}
+	return snapshots[0], nil
+}
+
+// getBoardSnapshots fetches the snapshots of a board, newest first.
+func (s *SQLStore) getBoardSnapshots(db sq.BaseRunner, boardID string) ([]*model.BoardSnapshot, error) {
+	query := s.getQueryBuilder(db).
+		Select(boardSnapshotFields...).
+		From(s.tablePrefix+"board_snapshots").
+		Where(sq.Eq{"board_id": boardID}).
+		OrderBy("create_at DESC", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch board snapshots",
+			mlog.String("board_id", boardID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.boardSnapshotsFromRows(rows)
+}
+
+// deleteBoardSnapshot deletes the specified snapshot.
+func (s *SQLStore) deleteBoardSnapshot(db sq.BaseRunner, snapshotID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "board_snapshots").
+		Where(sq.Eq{"id": snapshotID})
+
+	result, err := query.Exec()
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return model.NewErrNotFound("board snapshot ID=" + snapshotID)
+	}
+	return nil
+}
+
+// restoreBoard saves the restored version of a board and its blocks, and
+// deletes the blocks that didn't exist at the restored time.
+func (s *SQLStore) restoreBoard(db sq.BaseRunner, board *model.Board, blocks []*model.Block, deletedBlockIDs []string, userID string) error {
+	if _, err := s.insertBoard(db, board, userID); err != nil {
+		return err
+	}
+	return s.revertBlocks(db, blocks, deletedBlockIDs, userID)
+}
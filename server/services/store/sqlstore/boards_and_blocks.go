@@ -154,6 +154,7 @@ func (s *SQLStore) duplicateBoard(db sq.BaseRunner, boardID string, userID strin
 	// make new board private
 	board.Type = "P"
 	board.IsTemplate = asTemplate
+	board.IsLocked = false
 	board.CreatedBy = userID
 	board.ChannelID = ""
 
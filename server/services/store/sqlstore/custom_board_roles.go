@@ -0,0 +1,223 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+var customBoardRoleFields = []string{
+	"id",
+	"team_id",
+	"name",
+	"COALESCE(description, '')",
+	"COALESCE(permissions, '[]')",
+	"created_by",
+	"create_at",
+	"update_at",
+}
+
+func (s *SQLStore) customBoardRolesFromRows(rows *sql.Rows) ([]*model.CustomBoardRole, error) {
+	roles := []*model.CustomBoardRole{}
+
+	for rows.Next() {
+		var role model.CustomBoardRole
+		var permissionsBytes []byte
+		err := rows.Scan(
+			&role.ID,
+			&role.TeamID,
+			&role.Name,
+			&role.Description,
+			&permissionsBytes,
+			&role.CreatedBy,
+			&role.CreateAt,
+			&role.UpdateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(permissionsBytes, &role.Permissions); err != nil {
+			s.logger.Error("custom board role permissions unmarshal error", mlog.Err(err))
+			return nil, err
+		}
+		roles = append(roles, &role)
+	}
+	return roles, nil
+}
+
+// createCustomBoardRole creates a custom board role for a team.
+func (s *SQLStore) createCustomBoardRole(db sq.BaseRunner, role *model.CustomBoardRole) (*model.CustomBoardRole, error) {
+	if err := role.IsValid(); err != nil {
+		return nil, err
+	}
+
+	roleAdd := *role
+	if roleAdd.ID == "" {
+		roleAdd.ID = utils.NewID(utils.IDTypeNone)
+	}
+	roleAdd.CreateAt = utils.GetMillis()
+	roleAdd.UpdateAt = roleAdd.CreateAt
+
+	permissionsBytes, err := json.Marshal(roleAdd.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"custom_board_roles").
+		Columns("id", "team_id", "name", "description", "permissions", "created_by", "create_at", "update_at").
+		Values(
+			roleAdd.ID,
+			roleAdd.TeamID,
+			roleAdd.Name,
+			roleAdd.Description,
+			permissionsBytes,
+			roleAdd.CreatedBy,
+			roleAdd.CreateAt,
+			roleAdd.UpdateAt,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot create custom board role",
+			mlog.String("team_id", role.TeamID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+	return &roleAdd, nil
+}
+
+// updateCustomBoardRole saves the name, description and permissions of a
+// custom board role.
+func (s *SQLStore) updateCustomBoardRole(db sq.BaseRunner, role *model.CustomBoardRole) (*model.CustomBoardRole, error) {
+	if err := role.IsValid(); err != nil {
+		return nil, err
+	}
+
+	roleUpdate := *role
+	roleUpdate.UpdateAt = utils.GetMillis()
+
+	permissionsBytes, err := json.Marshal(roleUpdate.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"custom_board_roles").
+		Set("name", roleUpdate.Name).
+		Set("description", roleUpdate.Description).
+		Set("permissions", permissionsBytes).
+		Set("update_at", roleUpdate.UpdateAt).
+		Where(sq.Eq{"id": roleUpdate.ID})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("Cannot update custom board role",
+			mlog.String("role_id", role.ID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, model.NewErrNotFound("custom board role ID=" + role.ID)
+	}
+	return &roleUpdate, nil
+}
+
+// getCustomBoardRole fetches the specified custom board role.
+func (s *SQLStore) getCustomBoardRole(db sq.BaseRunner, roleID string) (*model.CustomBoardRole, error) {
+	query := s.getQueryBuilder(db).
+		Select(customBoardRoleFields...).
+		From(s.tablePrefix + "custom_board_roles").
+		Where(sq.Eq{"id": roleID})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch custom board role",
+			mlog.String("role_id", roleID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	roles, err := s.customBoardRolesFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(roles) == 0 {
+		return nil, model.NewErrNotFound("custom board role ID=" + roleID)
+	}
+	return roles[0], nil
+}
+
+// getCustomBoardRolesForTeam fetches the custom board roles of a team,
+// sorted by name.
+func (s *SQLStore) getCustomBoardRolesForTeam(db sq.BaseRunner, teamID string) ([]*model.CustomBoardRole, error) {
+	query := s.getQueryBuilder(db).
+		Select(customBoardRoleFields...).
+		From(s.tablePrefix+"custom_board_roles").
+		Where(sq.Eq{"team_id": teamID}).
+		OrderBy("name", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch custom board roles",
+			mlog.String("team_id", teamID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.customBoardRolesFromRows(rows)
+}
+
+// deleteCustomBoardRole deletes the specified custom board role and
+// unassigns it from the board members that had it.
+func (s *SQLStore) deleteCustomBoardRole(db sq.BaseRunner, roleID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "custom_board_roles").
+		Where(sq.Eq{"id": roleID})
+
+	result, err := query.Exec()
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return model.NewErrNotFound("custom board role ID=" + roleID)
+	}
+
+	unassignQuery := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"board_members").
+		Set("custom_role_id", "").
+		Where(sq.Eq{"custom_role_id": roleID})
+
+	if _, err := unassignQuery.Exec(); err != nil {
+		s.logger.Error("Cannot unassign custom board role",
+			mlog.String("role_id", roleID),
+			mlog.Err(err),
+		)
+		return err
+	}
+	return nil
+}
@@ -62,6 +62,11 @@ func (s *SQLStore) runDataRetention(db sq.BaseRunner, globalRetentionDate int64,
 			PrimaryKeys:   []string{"id"},
 			BoardIDColumn: "id",
 		},
+		{
+			Table:         "share_links",
+			PrimaryKeys:   []string{"id"},
+			BoardIDColumn: "board_id",
+		},
 		{
 			Table:         "category_boards",
 			PrimaryKeys:   []string{"id"},
@@ -170,7 +175,8 @@ func (s *SQLStore) genericRetentionPoliciesDeletion(
 			return 0, errors.Wrap(err, "failed to get rows affected for "+info.Table)
 		}
 		totalRowsAffected += batchRowsAffected
-		if batchRowsAffected != batchSize {
+		// without a batch size everything is deleted at once
+		if batchSize <= 0 || batchRowsAffected != batchSize {
 			break
 		}
 	}
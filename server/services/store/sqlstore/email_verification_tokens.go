@@ -0,0 +1,84 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// createEmailVerificationToken creates an email verification token. Only
+// the hash of the token is stored, it is expected to be already computed.
+func (s *SQLStore) createEmailVerificationToken(db sq.BaseRunner, token *model.EmailVerificationToken) (*model.EmailVerificationToken, error) {
+	if token.UserID == "" || token.Email == "" || token.TokenHash == "" {
+		return nil, model.NewErrBadRequest("missing email verification token user, email or hash")
+	}
+
+	tokenAdd := *token
+	tokenAdd.ID = utils.NewID(utils.IDTypeNone)
+	tokenAdd.Email = strings.ToLower(strings.TrimSpace(tokenAdd.Email))
+	tokenAdd.CreateAt = utils.GetMillis()
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"email_verification_tokens").
+		Columns("id", "user_id", "email", "token_hash", "expire_at", "create_at").
+		Values(tokenAdd.ID, tokenAdd.UserID, tokenAdd.Email, tokenAdd.TokenHash, tokenAdd.ExpireAt, tokenAdd.CreateAt)
+
+	if _, err := query.Exec(); err != nil {
+		return nil, err
+	}
+	return &tokenAdd, nil
+}
+
+// consumeEmailVerificationToken fetches the email verification token that
+// has the hash and deletes it, so it can only be used once.
+func (s *SQLStore) consumeEmailVerificationToken(db sq.BaseRunner, tokenHash string) (*model.EmailVerificationToken, error) {
+	query := s.getQueryBuilder(db).
+		Select("id", "user_id", "email", "token_hash", "expire_at", "create_at").
+		From(s.tablePrefix + "email_verification_tokens").
+		Where(sq.Eq{"token_hash": tokenHash})
+
+	token := model.EmailVerificationToken{}
+	err := query.QueryRow().Scan(&token.ID, &token.UserID, &token.Email, &token.TokenHash, &token.ExpireAt, &token.CreateAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, model.NewErrNotFound("email verification token")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	deleteQuery := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "email_verification_tokens").
+		Where(sq.Eq{"id": token.ID})
+
+	result, err := deleteQuery.Exec()
+	if err != nil {
+		return nil, err
+	}
+	count, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		// another request used the token first
+		return nil, model.NewErrNotFound("email verification token")
+	}
+	return &token, nil
+}
+
+// deleteEmailVerificationTokensForUser deletes the pending email
+// verification tokens of the user.
+func (s *SQLStore) deleteEmailVerificationTokensForUser(db sq.BaseRunner, userID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "email_verification_tokens").
+		Where(sq.Eq{"user_id": userID})
+
+	_, err := query.Exec()
+	return err
+}
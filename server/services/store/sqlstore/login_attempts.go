@@ -0,0 +1,76 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// getLoginAttempts fetches the failed login counter of an account or IP
+// address key.
+func (s *SQLStore) getLoginAttempts(db sq.BaseRunner, key string) (*model.LoginAttempts, error) {
+	query := s.getQueryBuilder(db).
+		Select("attempt_key", "failed_count", "last_failure_at").
+		From(s.tablePrefix + "login_attempts").
+		Where(sq.Eq{"attempt_key": key})
+
+	attempts := model.LoginAttempts{}
+	err := query.QueryRow().Scan(&attempts.Key, &attempts.FailedCount, &attempts.LastFailureAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, model.NewErrNotFound("login attempts key=" + key)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &attempts, nil
+}
+
+// incrementLoginAttempts records a failed login for the key. Counters
+// whose last failure is older than windowStart start over. The counter is
+// upserted in a single statement, so concurrent failures are all counted.
+func (s *SQLStore) incrementLoginAttempts(db sq.BaseRunner, key string, windowStart int64) (*model.LoginAttempts, error) {
+	now := utils.GetMillis()
+	table := s.tablePrefix + "login_attempts"
+
+	query := s.getQueryBuilder(db).
+		Insert(table).
+		Columns("attempt_key", "failed_count", "last_failure_at").
+		Values(key, 1, now)
+
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix(
+			`ON DUPLICATE KEY UPDATE
+			 failed_count = IF(last_failure_at < ?, 1, failed_count + 1),
+			 last_failure_at = VALUES(last_failure_at)`,
+			windowStart,
+		)
+	} else {
+		query = query.Suffix(
+			`ON CONFLICT (attempt_key)
+			 DO UPDATE SET failed_count = CASE WHEN `+table+`.last_failure_at < ? THEN 1 ELSE `+table+`.failed_count + 1 END,
+			 last_failure_at = EXCLUDED.last_failure_at`,
+			windowStart,
+		)
+	}
+
+	if _, err := query.Exec(); err != nil {
+		return nil, err
+	}
+	return s.getLoginAttempts(db, key)
+}
+
+// deleteLoginAttempts resets the failed login counter of the key.
+func (s *SQLStore) deleteLoginAttempts(db sq.BaseRunner, key string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "login_attempts").
+		Where(sq.Eq{"attempt_key": key})
+
+	_, err := query.Exec()
+	return err
+}
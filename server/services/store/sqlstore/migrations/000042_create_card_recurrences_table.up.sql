@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}card_recurrences (
+    card_id VARCHAR(36) NOT NULL,
+    board_id VARCHAR(36) NOT NULL,
+    rule VARCHAR(255) NOT NULL,
+    due_date_property_id VARCHAR(36),
+    next_run_at BIGINT NOT NULL,
+    last_run_at BIGINT NOT NULL DEFAULT 0,
+    created_by VARCHAR(36) NOT NULL,
+    create_at BIGINT NOT NULL,
+    update_at BIGINT NOT NULL,
+    PRIMARY KEY (card_id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "card_recurrences" "next_run_at" }}
+{{ createIndexIfNeeded "card_recurrences" "board_id" }}
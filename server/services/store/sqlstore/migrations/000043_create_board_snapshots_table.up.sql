@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}board_snapshots (
+    id VARCHAR(36) NOT NULL,
+    board_id VARCHAR(36) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    created_by VARCHAR(36) NOT NULL,
+    create_at BIGINT NOT NULL,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "board_snapshots" "board_id" }}
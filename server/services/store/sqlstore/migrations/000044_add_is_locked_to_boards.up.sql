@@ -0,0 +1,3 @@
+{{- /* addColumnIfNeeded tableName columnName datatype constraint */ -}}
+{{ addColumnIfNeeded "boards" "is_locked" "boolean" "NOT NULL DEFAULT false"}}
+{{ addColumnIfNeeded "boards_history" "is_locked" "boolean" "NOT NULL DEFAULT false"}}
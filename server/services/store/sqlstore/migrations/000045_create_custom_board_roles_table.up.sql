@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}custom_board_roles (
+    id VARCHAR(36) NOT NULL,
+    team_id VARCHAR(36) NOT NULL,
+    name VARCHAR(64) NOT NULL,
+    description TEXT,
+    permissions TEXT,
+    created_by VARCHAR(36) NOT NULL,
+    create_at BIGINT NOT NULL,
+    update_at BIGINT NOT NULL,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "custom_board_roles" "team_id" }}
+
+{{- /* addColumnIfNeeded tableName columnName datatype constraint */ -}}
+{{ addColumnIfNeeded "board_members" "custom_role_id" "varchar(36)" "NOT NULL DEFAULT ''"}}
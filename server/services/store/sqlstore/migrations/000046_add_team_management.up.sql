@@ -0,0 +1,29 @@
+{{- /* addColumnIfNeeded tableName columnName datatype constraint */ -}}
+{{ addColumnIfNeeded "teams" "title" "varchar(255)" "NOT NULL DEFAULT ''"}}
+{{ addColumnIfNeeded "teams" "create_at" "BIGINT" "NOT NULL DEFAULT 0"}}
+{{ addColumnIfNeeded "teams" "delete_at" "BIGINT" "NOT NULL DEFAULT 0"}}
+
+CREATE TABLE IF NOT EXISTS {{.prefix}}team_members (
+    team_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    scheme_admin BOOLEAN NOT NULL DEFAULT false,
+    create_at BIGINT NOT NULL,
+    PRIMARY KEY (team_id, user_id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE TABLE IF NOT EXISTS {{.prefix}}team_invites (
+    id VARCHAR(36) NOT NULL,
+    team_id VARCHAR(36) NOT NULL,
+    email VARCHAR(128) NOT NULL DEFAULT '',
+    scheme_admin BOOLEAN NOT NULL DEFAULT false,
+    created_by VARCHAR(36) NOT NULL,
+    create_at BIGINT NOT NULL,
+    expire_at BIGINT NOT NULL,
+    accepted_by VARCHAR(36) NOT NULL DEFAULT '',
+    accept_at BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "team_members" "user_id" }}
+{{ createIndexIfNeeded "team_invites" "team_id" }}
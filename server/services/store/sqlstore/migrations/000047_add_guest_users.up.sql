@@ -0,0 +1,3 @@
+{{- /* addColumnIfNeeded tableName columnName datatype constraint */ -}}
+{{ addColumnIfNeeded "users" "is_guest" "BOOLEAN" "NOT NULL DEFAULT false"}}
+{{ addColumnIfNeeded "users" "guest_expire_at" "BIGINT" "NOT NULL DEFAULT 0"}}
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}share_links (
+    id VARCHAR(36) NOT NULL,
+    board_id VARCHAR(36) NOT NULL,
+    name VARCHAR(64) NOT NULL DEFAULT '',
+    token VARCHAR(100) NOT NULL,
+    password VARCHAR(128) NOT NULL DEFAULT '',
+    single_view BOOLEAN NOT NULL DEFAULT false,
+    expire_at BIGINT NOT NULL DEFAULT 0,
+    use_count BIGINT NOT NULL DEFAULT 0,
+    first_access_at BIGINT NOT NULL DEFAULT 0,
+    last_access_at BIGINT NOT NULL DEFAULT 0,
+    created_by VARCHAR(36) NOT NULL,
+    create_at BIGINT NOT NULL,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "share_links" "board_id" }}
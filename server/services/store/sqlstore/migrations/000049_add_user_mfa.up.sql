@@ -0,0 +1,3 @@
+{{- /* addColumnIfNeeded tableName columnName datatype constraint */ -}}
+{{ addColumnIfNeeded "users" "mfa_active" "BOOLEAN" "NOT NULL DEFAULT false"}}
+{{ addColumnIfNeeded "users" "mfa_recovery_codes" "TEXT" ""}}
@@ -0,0 +1,4 @@
+{{- /* addColumnIfNeeded tableName columnName datatype constraint */ -}}
+{{ addColumnIfNeeded "users" "nickname" "VARCHAR(64)" ""}}
+{{ addColumnIfNeeded "users" "first_name" "VARCHAR(64)" ""}}
+{{ addColumnIfNeeded "users" "last_name" "VARCHAR(64)" ""}}
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}access_tokens (
+    id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    name VARCHAR(64) NOT NULL DEFAULT '',
+    token_hash VARCHAR(64) NOT NULL,
+    scopes TEXT,
+    expire_at BIGINT NOT NULL DEFAULT 0,
+    last_used_at BIGINT NOT NULL DEFAULT 0,
+    create_at BIGINT NOT NULL,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "access_tokens" "user_id" }}
+{{ createIndexIfNeeded "access_tokens" "token_hash" }}
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}login_attempts (
+    attempt_key VARCHAR(300) NOT NULL,
+    failed_count INT NOT NULL DEFAULT 0,
+    last_failure_at BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (attempt_key)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
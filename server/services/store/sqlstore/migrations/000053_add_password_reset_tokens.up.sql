@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}password_reset_tokens (
+    id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL,
+    expire_at BIGINT NOT NULL,
+    create_at BIGINT NOT NULL,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "password_reset_tokens" "user_id" }}
+{{ createIndexIfNeeded "password_reset_tokens" "token_hash" }}
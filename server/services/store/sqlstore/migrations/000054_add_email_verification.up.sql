@@ -0,0 +1,18 @@
+{{- /* addColumnIfNeeded tableName columnName datatype constraint */ -}}
+{{ addColumnIfNeeded "users" "email_verified" "BOOLEAN" "NOT NULL DEFAULT true"}}
+{{ addColumnIfNeeded "team_invites" "board_ids" "TEXT" ""}}
+{{ addColumnIfNeeded "team_invites" "board_role" "varchar(36)" "NOT NULL DEFAULT ''"}}
+
+CREATE TABLE IF NOT EXISTS {{.prefix}}email_verification_tokens (
+    id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    email VARCHAR(128) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL,
+    expire_at BIGINT NOT NULL,
+    create_at BIGINT NOT NULL,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "email_verification_tokens" "user_id" }}
+{{ createIndexIfNeeded "email_verification_tokens" "token_hash" }}
@@ -0,0 +1,2 @@
+{{- /* addColumnIfNeeded tableName columnName datatype constraint */ -}}
+{{ addColumnIfNeeded "users" "roles" "varchar(256)" "NOT NULL DEFAULT ''"}}
@@ -0,0 +1,2 @@
+{{- /* addColumnIfNeeded tableName columnName datatype constraint */ -}}
+{{ addColumnIfNeeded "share_links" "view_id" "varchar(36)" "NOT NULL DEFAULT ''"}}
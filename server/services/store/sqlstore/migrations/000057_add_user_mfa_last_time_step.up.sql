@@ -0,0 +1,2 @@
+{{- /* addColumnIfNeeded tableName columnName datatype constraint */ -}}
+{{ addColumnIfNeeded "users" "mfa_last_time_step" "BIGINT" "NOT NULL DEFAULT 0"}}
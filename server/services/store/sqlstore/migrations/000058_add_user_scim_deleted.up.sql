@@ -0,0 +1,2 @@
+{{- /* addColumnIfNeeded tableName columnName datatype constraint */ -}}
+{{ addColumnIfNeeded "users" "scim_deleted" "BOOLEAN" "NOT NULL DEFAULT false"}}
@@ -0,0 +1,82 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// createPasswordResetToken creates a password reset token. Only the hash
+// of the token is stored, it is expected to be already computed.
+func (s *SQLStore) createPasswordResetToken(db sq.BaseRunner, token *model.PasswordResetToken) (*model.PasswordResetToken, error) {
+	if token.UserID == "" || token.TokenHash == "" {
+		return nil, model.NewErrBadRequest("missing password reset token user or hash")
+	}
+
+	tokenAdd := *token
+	tokenAdd.ID = utils.NewID(utils.IDTypeNone)
+	tokenAdd.CreateAt = utils.GetMillis()
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"password_reset_tokens").
+		Columns("id", "user_id", "token_hash", "expire_at", "create_at").
+		Values(tokenAdd.ID, tokenAdd.UserID, tokenAdd.TokenHash, tokenAdd.ExpireAt, tokenAdd.CreateAt)
+
+	if _, err := query.Exec(); err != nil {
+		return nil, err
+	}
+	return &tokenAdd, nil
+}
+
+// consumePasswordResetToken fetches the password reset token that has the
+// hash and deletes it, so it can only be used once.
+func (s *SQLStore) consumePasswordResetToken(db sq.BaseRunner, tokenHash string) (*model.PasswordResetToken, error) {
+	query := s.getQueryBuilder(db).
+		Select("id", "user_id", "token_hash", "expire_at", "create_at").
+		From(s.tablePrefix + "password_reset_tokens").
+		Where(sq.Eq{"token_hash": tokenHash})
+
+	token := model.PasswordResetToken{}
+	err := query.QueryRow().Scan(&token.ID, &token.UserID, &token.TokenHash, &token.ExpireAt, &token.CreateAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, model.NewErrNotFound("password reset token")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	deleteQuery := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "password_reset_tokens").
+		Where(sq.Eq{"id": token.ID})
+
+	result, err := deleteQuery.Exec()
+	if err != nil {
+		return nil, err
+	}
+	count, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		// another request used the token first
+		return nil, model.NewErrNotFound("password reset token")
+	}
+	return &token, nil
+}
+
+// deletePasswordResetTokensForUser deletes the pending password reset
+// tokens of the user.
+func (s *SQLStore) deletePasswordResetTokensForUser(db sq.BaseRunner, userID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "password_reset_tokens").
+		Where(sq.Eq{"user_id": userID})
+
+	_, err := query.Exec()
+	return err
+}
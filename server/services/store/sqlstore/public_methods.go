@@ -22,6 +22,30 @@ import (
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 )
 
+func (s *SQLStore) AcceptTeamInvite(inviteID string, userID string) (*model.TeamMember, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.acceptTeamInvite(s.db, inviteID, userID)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return nil, txErr
+	}
+	result, err := s.acceptTeamInvite(tx, inviteID, userID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "AcceptTeamInvite"))
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+
+}
+
 func (s *SQLStore) AddUpdateCategoryBoard(userID string, categoryID string, boardIDs []string) error {
 	if s.dbType == model.SqliteDBType {
 		return s.addUpdateCategoryBoard(s.db, userID, categoryID, boardIDs)
@@ -46,16 +70,99 @@ func (s *SQLStore) AddUpdateCategoryBoard(userID string, categoryID string, boar
 
 }
 
+func (s *SQLStore) ArchiveTeam(teamID string, userID string) error {
+	return s.archiveTeam(s.db, teamID, userID)
+
+}
+
 func (s *SQLStore) CanSeeUser(seerID string, seenID string) (bool, error) {
 	return s.canSeeUser(s.db, seerID, seenID)
 
 }
 
+func (s *SQLStore) ClaimCardRecurrenceRun(cardID string, prevNextRunAt int64, nextRunAt int64) (bool, error) {
+	return s.claimCardRecurrenceRun(s.db, cardID, prevNextRunAt, nextRunAt)
+
+}
+
+func (s *SQLStore) ClaimShareLinkView(linkID string, viewID string) (bool, error) {
+	return s.claimShareLinkView(s.db, linkID, viewID)
+
+}
+
+func (s *SQLStore) ClaimSystemSetting(key string, prevValue string, value string) (bool, error) {
+	return s.claimSystemSetting(s.db, key, prevValue, value)
+
+}
+
+func (s *SQLStore) ClaimUserMfaTimeStep(userID string, timeStep int64) (bool, error) {
+	return s.claimUserMfaTimeStep(s.db, userID, timeStep)
+
+}
+
 func (s *SQLStore) CleanUpSessions(expireTime int64) error {
 	return s.cleanUpSessions(s.db, expireTime)
 
 }
 
+func (s *SQLStore) ConsumeEmailVerificationToken(tokenHash string) (*model.EmailVerificationToken, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.consumeEmailVerificationToken(s.db, tokenHash)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return nil, txErr
+	}
+	result, err := s.consumeEmailVerificationToken(tx, tokenHash)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "ConsumeEmailVerificationToken"))
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+
+}
+
+func (s *SQLStore) ConsumePasswordResetToken(tokenHash string) (*model.PasswordResetToken, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.consumePasswordResetToken(s.db, tokenHash)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return nil, txErr
+	}
+	result, err := s.consumePasswordResetToken(tx, tokenHash)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "ConsumePasswordResetToken"))
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+
+}
+
+func (s *SQLStore) CreateAccessToken(token *model.AccessToken) (*model.AccessToken, error) {
+	return s.createAccessToken(s.db, token)
+
+}
+
+func (s *SQLStore) CreateBoardSnapshot(snapshot *model.BoardSnapshot) (*model.BoardSnapshot, error) {
+	return s.createBoardSnapshot(s.db, snapshot)
+
+}
+
 func (s *SQLStore) CreateBoardsAndBlocks(bab *model.BoardsAndBlocks, userID string) (*model.BoardsAndBlocks, error) {
 	if s.dbType == model.SqliteDBType {
 		return s.createBoardsAndBlocks(s.db, bab, userID)
@@ -128,21 +235,80 @@ func (s *SQLStore) CreateCategory(category model.Category) error {
 
 }
 
+func (s *SQLStore) CreateCustomBoardRole(role *model.CustomBoardRole) (*model.CustomBoardRole, error) {
+	return s.createCustomBoardRole(s.db, role)
+
+}
+
+func (s *SQLStore) CreateEmailVerificationToken(token *model.EmailVerificationToken) (*model.EmailVerificationToken, error) {
+	return s.createEmailVerificationToken(s.db, token)
+
+}
+
+func (s *SQLStore) CreatePasswordResetToken(token *model.PasswordResetToken) (*model.PasswordResetToken, error) {
+	return s.createPasswordResetToken(s.db, token)
+
+}
+
 func (s *SQLStore) CreateSession(session *model.Session) error {
 	return s.createSession(s.db, session)
 
 }
 
+func (s *SQLStore) CreateShareLink(link *model.ShareLink) (*model.ShareLink, error) {
+	return s.createShareLink(s.db, link)
+
+}
+
 func (s *SQLStore) CreateSubscription(sub *model.Subscription) (*model.Subscription, error) {
 	return s.createSubscription(s.db, sub)
 
 }
 
+func (s *SQLStore) CreateTeam(team *model.Team, userID string) (*model.Team, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.createTeam(s.db, team, userID)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return nil, txErr
+	}
+	result, err := s.createTeam(tx, team, userID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "CreateTeam"))
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+
+}
+
+func (s *SQLStore) CreateTeamInvite(invite *model.TeamInvite) (*model.TeamInvite, error) {
+	return s.createTeamInvite(s.db, invite)
+
+}
+
 func (s *SQLStore) CreateUser(user *model.User) (*model.User, error) {
 	return s.createUser(s.db, user)
 
 }
 
+func (s *SQLStore) DeleteAccessToken(tokenID string) error {
+	return s.deleteAccessToken(s.db, tokenID)
+
+}
+
+func (s *SQLStore) DeleteAccessTokensForUser(userID string) error {
+	return s.deleteAccessTokensForUser(s.db, userID)
+
+}
+
 func (s *SQLStore) DeleteBlock(blockID string, modifiedBy string) error {
 	if s.dbType == model.SqliteDBType {
 		return s.deleteBlock(s.db, blockID, modifiedBy)
@@ -172,6 +338,30 @@ func (s *SQLStore) DeleteBlockRecord(blockID string, modifiedBy string) error {
 
 }
 
+func (s *SQLStore) DeleteBlocks(blockIDs []string, modifiedBy string) error {
+	if s.dbType == model.SqliteDBType {
+		return s.deleteBlocks(s.db, blockIDs, modifiedBy)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.deleteBlocks(tx, blockIDs, modifiedBy)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteBlocks"))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
 func (s *SQLStore) DeleteBoard(boardID string, userID string) error {
 	if s.dbType == model.SqliteDBType {
 		return s.deleteBoard(s.db, boardID, userID)
@@ -201,6 +391,11 @@ func (s *SQLStore) DeleteBoardRecord(boardID string, modifiedBy string) error {
 
 }
 
+func (s *SQLStore) DeleteBoardSnapshot(snapshotID string) error {
+	return s.deleteBoardSnapshot(s.db, snapshotID)
+
+}
+
 func (s *SQLStore) DeleteBoardsAndBlocks(dbab *model.DeleteBoardsAndBlocks, userID string) error {
 	if s.dbType == model.SqliteDBType {
 		return s.deleteBoardsAndBlocks(s.db, dbab, userID)
@@ -225,11 +420,50 @@ func (s *SQLStore) DeleteBoardsAndBlocks(dbab *model.DeleteBoardsAndBlocks, user
 
 }
 
+func (s *SQLStore) DeleteCardRecurrence(cardID string) error {
+	return s.deleteCardRecurrence(s.db, cardID)
+
+}
+
 func (s *SQLStore) DeleteCategory(categoryID string, userID string, teamID string) error {
 	return s.deleteCategory(s.db, categoryID, userID, teamID)
 
 }
 
+func (s *SQLStore) DeleteCustomBoardRole(roleID string) error {
+	if s.dbType == model.SqliteDBType {
+		return s.deleteCustomBoardRole(s.db, roleID)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.deleteCustomBoardRole(tx, roleID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteCustomBoardRole"))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+func (s *SQLStore) DeleteEmailVerificationTokensForUser(userID string) error {
+	return s.deleteEmailVerificationTokensForUser(s.db, userID)
+
+}
+
+func (s *SQLStore) DeleteLoginAttempts(key string) error {
+	return s.deleteLoginAttempts(s.db, key)
+
+}
+
 func (s *SQLStore) DeleteMember(boardID string, userID string) error {
 	return s.deleteMember(s.db, boardID, userID)
 
@@ -240,16 +474,41 @@ func (s *SQLStore) DeleteNotificationHint(blockID string) error {
 
 }
 
+func (s *SQLStore) DeletePasswordResetTokensForUser(userID string) error {
+	return s.deletePasswordResetTokensForUser(s.db, userID)
+
+}
+
 func (s *SQLStore) DeleteSession(sessionID string) error {
 	return s.deleteSession(s.db, sessionID)
 
 }
 
+func (s *SQLStore) DeleteSessionsForUser(userID string) error {
+	return s.deleteSessionsForUser(s.db, userID)
+
+}
+
+func (s *SQLStore) DeleteShareLink(linkID string) error {
+	return s.deleteShareLink(s.db, linkID)
+
+}
+
 func (s *SQLStore) DeleteSubscription(blockID string, subscriberID string) error {
 	return s.deleteSubscription(s.db, blockID, subscriberID)
 
 }
 
+func (s *SQLStore) DeleteTeamInvite(inviteID string) error {
+	return s.deleteTeamInvite(s.db, inviteID)
+
+}
+
+func (s *SQLStore) DeleteTeamMember(teamID string, userID string) error {
+	return s.deleteTeamMember(s.db, teamID, userID)
+
+}
+
 func (s *SQLStore) DuplicateBlock(boardID string, blockID string, userID string, asTemplate bool) ([]*model.Block, error) {
 	if s.dbType == model.SqliteDBType {
 		return s.duplicateBlock(s.db, boardID, blockID, userID, asTemplate)
@@ -298,6 +557,21 @@ func (s *SQLStore) DuplicateBoard(boardID string, userID string, toTeam string,
 
 }
 
+func (s *SQLStore) GetAccessToken(tokenID string) (*model.AccessToken, error) {
+	return s.getAccessToken(s.db, tokenID)
+
+}
+
+func (s *SQLStore) GetAccessTokenByHash(tokenHash string) (*model.AccessToken, error) {
+	return s.getAccessTokenByHash(s.db, tokenHash)
+
+}
+
+func (s *SQLStore) GetAccessTokensForUser(userID string) ([]*model.AccessToken, error) {
+	return s.getAccessTokensForUser(s.db, userID)
+
+}
+
 func (s *SQLStore) GetActiveUserCount(updatedSecondsAgo int64) (int, error) {
 	return s.getActiveUserCount(s.db, updatedSecondsAgo)
 
@@ -333,6 +607,11 @@ func (s *SQLStore) GetBlockHistoryNewestChildren(parentID string, opts model.Que
 
 }
 
+func (s *SQLStore) GetBlockHistoryWithChildren(blockID string, opts model.QueryBlockHistoryOptions) ([]*model.Block, error) {
+	return s.getBlockHistoryWithChildren(s.db, blockID, opts)
+
+}
+
 func (s *SQLStore) GetBlocks(opts model.QueryBlocksOptions) ([]*model.Block, error) {
 	return s.getBlocks(s.db, opts)
 
@@ -398,6 +677,21 @@ func (s *SQLStore) GetBoardMemberHistory(boardID string, userID string, limit ui
 
 }
 
+func (s *SQLStore) GetBoardSnapshot(snapshotID string) (*model.BoardSnapshot, error) {
+	return s.getBoardSnapshot(s.db, snapshotID)
+
+}
+
+func (s *SQLStore) GetBoardSnapshots(boardID string) ([]*model.BoardSnapshot, error) {
+	return s.getBoardSnapshots(s.db, boardID)
+
+}
+
+func (s *SQLStore) GetBoardsAdministeredByUser(userID string) ([]*model.Board, error) {
+	return s.getBoardsAdministeredByUser(s.db, userID)
+
+}
+
 func (s *SQLStore) GetBoardsComplianceHistory(opts model.QueryBoardsComplianceHistoryOptions) ([]*model.BoardHistory, bool, error) {
 	return s.getBoardsComplianceHistory(s.db, opts)
 
@@ -418,11 +712,21 @@ func (s *SQLStore) GetBoardsInTeamByIds(boardIDs []string, teamID string) ([]*mo
 
 }
 
+func (s *SQLStore) GetBoardsWithAutomations() ([]*model.Board, error) {
+	return s.getBoardsWithAutomations(s.db)
+
+}
+
 func (s *SQLStore) GetCardLimitTimestamp() (int64, error) {
 	return s.getCardLimitTimestamp(s.db)
 
 }
 
+func (s *SQLStore) GetCardRecurrence(cardID string) (*model.CardRecurrence, error) {
+	return s.getCardRecurrence(s.db, cardID)
+
+}
+
 func (s *SQLStore) GetCategory(id string) (*model.Category, error) {
 	return s.getCategory(s.db, id)
 
@@ -433,6 +737,21 @@ func (s *SQLStore) GetChannel(teamID string, channelID string) (*mmModel.Channel
 
 }
 
+func (s *SQLStore) GetCustomBoardRole(roleID string) (*model.CustomBoardRole, error) {
+	return s.getCustomBoardRole(s.db, roleID)
+
+}
+
+func (s *SQLStore) GetCustomBoardRolesForTeam(teamID string) ([]*model.CustomBoardRole, error) {
+	return s.getCustomBoardRolesForTeam(s.db, teamID)
+
+}
+
+func (s *SQLStore) GetDueCardRecurrences(now int64, limit uint64) ([]*model.CardRecurrence, error) {
+	return s.getDueCardRecurrences(s.db, now, limit)
+
+}
+
 func (s *SQLStore) GetFileInfo(id string) (*mmModel.FileInfo, error) {
 	return s.getFileInfo(s.db, id)
 
@@ -443,6 +762,11 @@ func (s *SQLStore) GetLicense() *mmModel.License {
 
 }
 
+func (s *SQLStore) GetLoginAttempts(key string) (*model.LoginAttempts, error) {
+	return s.getLoginAttempts(s.db, key)
+
+}
+
 func (s *SQLStore) GetMemberForBoard(boardID string, userID string) (*model.BoardMember, error) {
 	return s.getMemberForBoard(s.db, boardID, userID)
 
@@ -478,6 +802,26 @@ func (s *SQLStore) GetSession(token string, expireTime int64) (*model.Session, e
 
 }
 
+func (s *SQLStore) GetSessionsForUser(userID string, expireTime int64) ([]*model.Session, error) {
+	return s.getSessionsForUser(s.db, userID, expireTime)
+
+}
+
+func (s *SQLStore) GetShareLink(linkID string) (*model.ShareLink, error) {
+	return s.getShareLink(s.db, linkID)
+
+}
+
+func (s *SQLStore) GetShareLinkByToken(boardID string, token string) (*model.ShareLink, error) {
+	return s.getShareLinkByToken(s.db, boardID, token)
+
+}
+
+func (s *SQLStore) GetShareLinksForBoard(boardID string) ([]*model.ShareLink, error) {
+	return s.getShareLinksForBoard(s.db, boardID)
+
+}
+
 func (s *SQLStore) GetSharing(rootID string) (*model.Sharing, error) {
 	return s.getSharing(s.db, rootID)
 
@@ -523,11 +867,36 @@ func (s *SQLStore) GetTeam(ID string) (*model.Team, error) {
 
 }
 
+func (s *SQLStore) GetTeamBySignupToken(signupToken string) (*model.Team, error) {
+	return s.getTeamBySignupToken(s.db, signupToken)
+
+}
+
 func (s *SQLStore) GetTeamCount() (int64, error) {
 	return s.getTeamCount(s.db)
 
 }
 
+func (s *SQLStore) GetTeamInvite(inviteID string) (*model.TeamInvite, error) {
+	return s.getTeamInvite(s.db, inviteID)
+
+}
+
+func (s *SQLStore) GetTeamInvites(teamID string) ([]*model.TeamInvite, error) {
+	return s.getTeamInvites(s.db, teamID)
+
+}
+
+func (s *SQLStore) GetTeamMember(teamID string, userID string) (*model.TeamMember, error) {
+	return s.getTeamMember(s.db, teamID, userID)
+
+}
+
+func (s *SQLStore) GetTeamMembers(teamID string) ([]*model.TeamMember, error) {
+	return s.getTeamMembers(s.db, teamID)
+
+}
+
 func (s *SQLStore) GetTeamsForUser(userID string) ([]*model.Team, error) {
 	return s.getTeamsForUser(s.db, userID)
 
@@ -543,6 +912,11 @@ func (s *SQLStore) GetUsedCardsCount() (int, error) {
 
 }
 
+func (s *SQLStore) GetUserByAuthData(authService string, authData string) (*model.User, error) {
+	return s.getUserByAuthData(s.db, authService, authData)
+
+}
+
 func (s *SQLStore) GetUserByEmail(email string) (*model.User, error) {
 	return s.getUserByEmail(s.db, email)
 
@@ -568,6 +942,11 @@ func (s *SQLStore) GetUserCategoryBoards(userID string, teamID string) ([]model.
 
 }
 
+func (s *SQLStore) GetUserMfaRecoveryCodes(userID string) ([]string, error) {
+	return s.getUserMfaRecoveryCodes(s.db, userID)
+
+}
+
 func (s *SQLStore) GetUserPreferences(userID string) (mmModel.Preferences, error) {
 	return s.getUserPreferences(s.db, userID)
 
@@ -578,6 +957,16 @@ func (s *SQLStore) GetUserTimezone(userID string) (string, error) {
 
 }
 
+func (s *SQLStore) GetUsers(opts model.QueryUsersOptions) ([]*model.User, error) {
+	return s.getUsers(s.db, opts)
+
+}
+
+func (s *SQLStore) GetUsersByAuthService(authService string) ([]*model.User, error) {
+	return s.getUsersByAuthService(s.db, authService)
+
+}
+
 func (s *SQLStore) GetUsersByTeam(teamID string, asGuestID string, showEmail bool, showName bool) ([]*model.User, error) {
 	return s.getUsersByTeam(s.db, teamID, asGuestID, showEmail, showName)
 
@@ -588,6 +977,30 @@ func (s *SQLStore) GetUsersList(userIDs []string, showEmail bool, showName bool)
 
 }
 
+func (s *SQLStore) IncrementLoginAttempts(key string, windowStart int64) (*model.LoginAttempts, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.incrementLoginAttempts(s.db, key, windowStart)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return nil, txErr
+	}
+	result, err := s.incrementLoginAttempts(tx, key, windowStart)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "IncrementLoginAttempts"))
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+
+}
+
 func (s *SQLStore) InsertBlock(block *model.Block, userID string) error {
 	if s.dbType == model.SqliteDBType {
 		return s.insertBlock(s.db, block, userID)
@@ -665,6 +1078,30 @@ func (s *SQLStore) InsertBoardWithAdmin(board *model.Board, userID string) (*mod
 
 }
 
+func (s *SQLStore) MoveBlocks(blocks []*model.Block, userID string) error {
+	if s.dbType == model.SqliteDBType {
+		return s.moveBlocks(s.db, blocks, userID)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.moveBlocks(tx, blocks, userID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "MoveBlocks"))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
 func (s *SQLStore) PatchBlock(blockID string, blockPatch *model.BlockPatch, userID string) error {
 	if s.dbType == model.SqliteDBType {
 		return s.patchBlock(s.db, blockID, blockPatch, userID)
@@ -761,6 +1198,11 @@ func (s *SQLStore) PatchBoardsAndBlocks(pbab *model.PatchBoardsAndBlocks, userID
 
 }
 
+func (s *SQLStore) PatchTeam(teamID string, patch *model.TeamPatch, userID string) (*model.Team, error) {
+	return s.patchTeam(s.db, teamID, patch, userID)
+
+}
+
 func (s *SQLStore) PatchUserPreferences(userID string, patch model.UserPreferencesPatch) (mmModel.Preferences, error) {
 	return s.patchUserPreferences(s.db, userID, patch)
 
@@ -771,6 +1213,11 @@ func (s *SQLStore) PostMessage(message string, postType string, channelID string
 
 }
 
+func (s *SQLStore) RecordShareLinkAccess(linkID string, accessAt int64) error {
+	return s.recordShareLinkAccess(s.db, linkID, accessAt)
+
+}
+
 func (s *SQLStore) RefreshSession(session *model.Session) error {
 	return s.refreshSession(s.db, session)
 
@@ -791,6 +1238,54 @@ func (s *SQLStore) ReorderCategoryBoards(categoryID string, newBoardsOrder []str
 
 }
 
+func (s *SQLStore) RestoreBoard(board *model.Board, blocks []*model.Block, deletedBlockIDs []string, userID string) error {
+	if s.dbType == model.SqliteDBType {
+		return s.restoreBoard(s.db, board, blocks, deletedBlockIDs, userID)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.restoreBoard(tx, board, blocks, deletedBlockIDs, userID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "RestoreBoard"))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+func (s *SQLStore) RevertBlocks(blocks []*model.Block, deletedBlockIDs []string, userID string) error {
+	if s.dbType == model.SqliteDBType {
+		return s.revertBlocks(s.db, blocks, deletedBlockIDs, userID)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.revertBlocks(tx, blocks, deletedBlockIDs, userID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "RevertBlocks"))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
 func (s *SQLStore) RunDataRetention(globalRetentionDate int64, batchSize int64) (int64, error) {
 	if s.dbType == model.SqliteDBType {
 		return s.runDataRetention(s.db, globalRetentionDate, batchSize)
@@ -825,6 +1320,11 @@ func (s *SQLStore) SaveMember(bm *model.BoardMember) (*model.BoardMember, error)
 
 }
 
+func (s *SQLStore) SaveTeamMember(member *model.TeamMember) (*model.TeamMember, error) {
+	return s.saveTeamMember(s.db, member)
+
+}
+
 func (s *SQLStore) SearchBoardsForUser(term string, searchField model.BoardSearchField, userID string, includePublicBoards bool) ([]*model.Board, error) {
 	return s.searchBoardsForUser(s.db, term, searchField, userID, includePublicBoards)
 
@@ -860,6 +1360,30 @@ func (s *SQLStore) SetSystemSetting(key string, value string) error {
 
 }
 
+func (s *SQLStore) TransferBoardOwnership(boardID string, fromUserID string, toUserID string) (*model.BoardMember, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.transferBoardOwnership(s.db, boardID, fromUserID, toUserID)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return nil, txErr
+	}
+	result, err := s.transferBoardOwnership(tx, boardID, fromUserID, toUserID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "TransferBoardOwnership"))
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+
+}
+
 func (s *SQLStore) UndeleteBlock(blockID string, modifiedBy string) error {
 	if s.dbType == model.SqliteDBType {
 		return s.undeleteBlock(s.db, blockID, modifiedBy)
@@ -908,6 +1432,11 @@ func (s *SQLStore) UndeleteBoard(boardID string, modifiedBy string) error {
 
 }
 
+func (s *SQLStore) UpdateAccessTokenLastUsed(tokenID string, usedAt int64) error {
+	return s.updateAccessTokenLastUsed(s.db, tokenID, usedAt)
+
+}
+
 func (s *SQLStore) UpdateCardLimitTimestamp(cardLimit int) (int64, error) {
 	return s.updateCardLimitTimestamp(s.db, cardLimit)
 
@@ -918,6 +1447,11 @@ func (s *SQLStore) UpdateCategory(category model.Category) error {
 
 }
 
+func (s *SQLStore) UpdateCustomBoardRole(role *model.CustomBoardRole) (*model.CustomBoardRole, error) {
+	return s.updateCustomBoardRole(s.db, role)
+
+}
+
 func (s *SQLStore) UpdateSession(session *model.Session) error {
 	return s.updateSession(s.db, session)
 
@@ -933,6 +1467,26 @@ func (s *SQLStore) UpdateUser(user *model.User) (*model.User, error) {
 
 }
 
+func (s *SQLStore) UpdateUserDeleteAt(userID string, deleteAt int64) error {
+	return s.updateUserDeleteAt(s.db, userID, deleteAt)
+
+}
+
+func (s *SQLStore) UpdateUserEmailVerified(userID string, emailVerified bool) error {
+	return s.updateUserEmailVerified(s.db, userID, emailVerified)
+
+}
+
+func (s *SQLStore) UpdateUserGuest(userID string, isGuest bool, guestExpireAt int64) error {
+	return s.updateUserGuest(s.db, userID, isGuest, guestExpireAt)
+
+}
+
+func (s *SQLStore) UpdateUserMfa(userID string, secret string, active bool, recoveryCodes []string) error {
+	return s.updateUserMfa(s.db, userID, secret, active, recoveryCodes)
+
+}
+
 func (s *SQLStore) UpdateUserPassword(username string, password string) error {
 	return s.updateUserPassword(s.db, username, password)
 
@@ -943,6 +1497,21 @@ func (s *SQLStore) UpdateUserPasswordByID(userID string, password string) error
 
 }
 
+func (s *SQLStore) UpdateUserRoles(userID string, roles string) error {
+	return s.updateUserRoles(s.db, userID, roles)
+
+}
+
+func (s *SQLStore) UpdateUserScimDeleted(userID string, scimDeleted bool) error {
+	return s.updateUserScimDeleted(s.db, userID, scimDeleted)
+
+}
+
+func (s *SQLStore) UpsertCardRecurrence(recurrence *model.CardRecurrence) (*model.CardRecurrence, error) {
+	return s.upsertCardRecurrence(s.db, recurrence)
+
+}
+
 func (s *SQLStore) UpsertNotificationHint(hint *model.NotificationHint, notificationFreq time.Duration) (*model.NotificationHint, error) {
 	return s.upsertNotificationHint(s.db, hint, notificationFreq)
 
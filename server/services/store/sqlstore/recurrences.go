@@ -0,0 +1,205 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+var cardRecurrenceFields = []string{
+	"card_id",
+	"board_id",
+	"rule",
+	"COALESCE(due_date_property_id, '')",
+	"next_run_at",
+	"last_run_at",
+	"created_by",
+	"create_at",
+	"update_at",
+}
+
+func (s *SQLStore) cardRecurrencesFromRows(rows *sql.Rows) ([]*model.CardRecurrence, error) {
+	recurrences := []*model.CardRecurrence{}
+
+	for rows.Next() {
+		var recurrence model.CardRecurrence
+		err := rows.Scan(
+			&recurrence.CardID,
+			&recurrence.BoardID,
+			&recurrence.Rule,
+			&recurrence.DueDatePropertyID,
+			&recurrence.NextRunAt,
+			&recurrence.LastRunAt,
+			&recurrence.CreatedBy,
+			&recurrence.CreateAt,
+			&recurrence.UpdateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		recurrences = append(recurrences, &recurrence)
+	}
+	return recurrences, nil
+}
+
+// upsertCardRecurrence creates or replaces the recurrence for a card.
+func (s *SQLStore) upsertCardRecurrence(db sq.BaseRunner, recurrence *model.CardRecurrence) (*model.CardRecurrence, error) {
+	if err := recurrence.IsValid(); err != nil {
+		return nil, err
+	}
+
+	now := utils.GetMillis()
+	recurrenceAdd := *recurrence
+	if recurrenceAdd.CreateAt == 0 {
+		recurrenceAdd.CreateAt = now
+	}
+	recurrenceAdd.UpdateAt = now
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"card_recurrences").
+		Columns(
+			"card_id",
+			"board_id",
+			"rule",
+			"due_date_property_id",
+			"next_run_at",
+			"last_run_at",
+			"created_by",
+			"create_at",
+			"update_at",
+		).
+		Values(
+			recurrenceAdd.CardID,
+			recurrenceAdd.BoardID,
+			recurrenceAdd.Rule,
+			recurrenceAdd.DueDatePropertyID,
+			recurrenceAdd.NextRunAt,
+			recurrenceAdd.LastRunAt,
+			recurrenceAdd.CreatedBy,
+			recurrenceAdd.CreateAt,
+			recurrenceAdd.UpdateAt,
+		)
+
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE rule = ?, due_date_property_id = ?, next_run_at = ?, created_by = ?, update_at = ?",
+			recurrenceAdd.Rule, recurrenceAdd.DueDatePropertyID, recurrenceAdd.NextRunAt, recurrenceAdd.CreatedBy, recurrenceAdd.UpdateAt)
+	} else {
+		query = query.Suffix(
+			`ON CONFLICT (card_id)
+			 DO UPDATE SET rule = EXCLUDED.rule, due_date_property_id = EXCLUDED.due_date_property_id,
+			 next_run_at = EXCLUDED.next_run_at, created_by = EXCLUDED.created_by, update_at = EXCLUDED.update_at`,
+		)
+	}
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot upsert card recurrence",
+			mlog.String("card_id", recurrence.CardID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+	return s.getCardRecurrence(db, recurrence.CardID)
+}
+
+// getCardRecurrence fetches the recurrence for the specified card.
+func (s *SQLStore) getCardRecurrence(db sq.BaseRunner, cardID string) (*model.CardRecurrence, error) {
+	query := s.getQueryBuilder(db).
+		Select(cardRecurrenceFields...).
+		From(s.tablePrefix + "card_recurrences").
+		Where(sq.Eq{"card_id": cardID})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch card recurrence",
+			mlog.String("card_id", cardID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	recurrences, err := s.cardRecurrencesFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(recurrences) == 0 {
+		return nil, model.NewErrNotFound("card recurrence CardID=" + cardID)
+	}
+	return recurrences[0], nil
+}
+
+// getDueCardRecurrences fetches up to `limit` recurrences whose next run time
+// is at or before `now`, oldest first.
+func (s *SQLStore) getDueCardRecurrences(db sq.BaseRunner, now int64, limit uint64) ([]*model.CardRecurrence, error) {
+	query := s.getQueryBuilder(db).
+		Select(cardRecurrenceFields...).
+		From(s.tablePrefix + "card_recurrences").
+		Where(sq.LtOrEq{"next_run_at": now}).
+		OrderBy("next_run_at").
+		Limit(limit)
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch due card recurrences", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.cardRecurrencesFromRows(rows)
+}
+
+// claimCardRecurrenceRun advances a due recurrence from `prevNextRunAt` to
+// `nextRunAt`. It returns false if the recurrence was changed or claimed by
+// another node in the meantime, in which case the caller must not create the
+// occurrence.
+func (s *SQLStore) claimCardRecurrenceRun(db sq.BaseRunner, cardID string, prevNextRunAt int64, nextRunAt int64) (bool, error) {
+	now := utils.GetMillis()
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"card_recurrences").
+		Set("next_run_at", nextRunAt).
+		Set("last_run_at", now).
+		Set("update_at", now).
+		Where(sq.Eq{"card_id": cardID}).
+		Where(sq.Eq{"next_run_at": prevNextRunAt})
+
+	result, err := query.Exec()
+	if err != nil {
+		return false, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// deleteCardRecurrence deletes the recurrence for the specified card.
+func (s *SQLStore) deleteCardRecurrence(db sq.BaseRunner, cardID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "card_recurrences").
+		Where(sq.Eq{"card_id": cardID})
+
+	result, err := query.Exec()
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return model.NewErrNotFound("card recurrence CardID=" + cardID)
+	}
+	return nil
+}
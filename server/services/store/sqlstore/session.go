@@ -50,6 +50,41 @@ func (s *SQLStore) getSession(db sq.BaseRunner, token string, expireTimeSeconds
 	return &session, nil
 }
 
+// getSessionsForUser returns the sessions of the user that aren't
+// expired, the most recently active first.
+func (s *SQLStore) getSessionsForUser(db sq.BaseRunner, userID string, expireTimeSeconds int64) ([]*model.Session, error) {
+	query := s.getQueryBuilder(db).
+		Select("id", "token", "user_id", "auth_service", "props", "create_at", "update_at").
+		From(s.tablePrefix+"sessions").
+		Where(sq.Eq{"user_id": userID}).
+		Where(sq.Gt{"update_at": utils.GetMillis() - utils.SecondsToMillis(expireTimeSeconds)}).
+		OrderBy("update_at DESC", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	sessions := []*model.Session{}
+	for rows.Next() {
+		session := model.Session{}
+
+		var propsBytes []byte
+		err := rows.Scan(&session.ID, &session.Token, &session.UserID, &session.AuthService, &propsBytes, &session.CreateAt, &session.UpdateAt)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(propsBytes, &session.Props); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, &session)
+	}
+
+	return sessions, nil
+}
+
 func (s *SQLStore) createSession(db sq.BaseRunner, session *model.Session) error {
 	now := utils.GetMillis()
 
@@ -102,6 +137,14 @@ func (s *SQLStore) deleteSession(db sq.BaseRunner, sessionID string) error {
 	return err
 }
 
+func (s *SQLStore) deleteSessionsForUser(db sq.BaseRunner, userID string) error {
+	query := s.getQueryBuilder(db).Delete(s.tablePrefix + "sessions").
+		Where(sq.Eq{"user_id": userID})
+
+	_, err := query.Exec()
+	return err
+}
+
 func (s *SQLStore) cleanUpSessions(db sq.BaseRunner, expireTimeSeconds int64) error {
 	query := s.getQueryBuilder(db).Delete(s.tablePrefix + "sessions").
 		Where(sq.Lt{"update_at": utils.GetMillis() - utils.SecondsToMillis(expireTimeSeconds)})
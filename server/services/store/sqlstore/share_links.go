@@ -0,0 +1,239 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+var shareLinkFields = []string{
+	"id",
+	"board_id",
+	"name",
+	"token",
+	"password",
+	"single_view",
+	"expire_at",
+	"use_count",
+	"first_access_at",
+	"last_access_at",
+	"created_by",
+	"create_at",
+	"view_id",
+}
+
+func (s *SQLStore) shareLinksFromRows(rows *sql.Rows) ([]*model.ShareLink, error) {
+	links := []*model.ShareLink{}
+
+	for rows.Next() {
+		var link model.ShareLink
+		err := rows.Scan(
+			&link.ID,
+			&link.BoardID,
+			&link.Name,
+			&link.Token,
+			&link.Password,
+			&link.SingleView,
+			&link.ExpireAt,
+			&link.UseCount,
+			&link.FirstAccessAt,
+			&link.LastAccessAt,
+			&link.CreatedBy,
+			&link.CreateAt,
+			&link.ViewID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		link.HasPassword = link.Password != ""
+		links = append(links, &link)
+	}
+	return links, nil
+}
+
+// createShareLink creates a share link for a board. The password of the
+// link is expected to be already hashed.
+func (s *SQLStore) createShareLink(db sq.BaseRunner, link *model.ShareLink) (*model.ShareLink, error) {
+	if err := link.IsValid(); err != nil {
+		return nil, err
+	}
+
+	linkAdd := *link
+	linkAdd.ID = utils.NewID(utils.IDTypeNone)
+	if linkAdd.Token == "" {
+		linkAdd.Token = utils.NewID(utils.IDTypeToken)
+	}
+	linkAdd.HasPassword = linkAdd.Password != ""
+	linkAdd.UseCount = 0
+	linkAdd.FirstAccessAt = 0
+	linkAdd.LastAccessAt = 0
+	linkAdd.ViewID = ""
+	linkAdd.CreateAt = utils.GetMillis()
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"share_links").
+		Columns(shareLinkFields...).
+		Values(
+			linkAdd.ID,
+			linkAdd.BoardID,
+			linkAdd.Name,
+			linkAdd.Token,
+			linkAdd.Password,
+			linkAdd.SingleView,
+			linkAdd.ExpireAt,
+			linkAdd.UseCount,
+			linkAdd.FirstAccessAt,
+			linkAdd.LastAccessAt,
+			linkAdd.CreatedBy,
+			linkAdd.CreateAt,
+			linkAdd.ViewID,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot create share link",
+			mlog.String("board_id", link.BoardID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+	return &linkAdd, nil
+}
+
+func (s *SQLStore) getShareLinkByCondition(db sq.BaseRunner, condition sq.Eq) (*model.ShareLink, error) {
+	query := s.getQueryBuilder(db).
+		Select(shareLinkFields...).
+		From(s.tablePrefix + "share_links").
+		Where(condition)
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch share link", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	links, err := s.shareLinksFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(links) == 0 {
+		return nil, model.NewErrNotFound("share link")
+	}
+	return links[0], nil
+}
+
+// getShareLink fetches the specified share link.
+func (s *SQLStore) getShareLink(db sq.BaseRunner, linkID string) (*model.ShareLink, error) {
+	return s.getShareLinkByCondition(db, sq.Eq{"id": linkID})
+}
+
+// getShareLinkByToken fetches the share link of a board that has the
+// specified read token.
+func (s *SQLStore) getShareLinkByToken(db sq.BaseRunner, boardID, token string) (*model.ShareLink, error) {
+	return s.getShareLinkByCondition(db, sq.Eq{"board_id": boardID, "token": token})
+}
+
+// getShareLinksForBoard fetches the share links of a board, oldest first.
+func (s *SQLStore) getShareLinksForBoard(db sq.BaseRunner, boardID string) ([]*model.ShareLink, error) {
+	query := s.getQueryBuilder(db).
+		Select(shareLinkFields...).
+		From(s.tablePrefix+"share_links").
+		Where(sq.Eq{"board_id": boardID}).
+		OrderBy("create_at", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch share links",
+			mlog.String("board_id", boardID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.shareLinksFromRows(rows)
+}
+
+// recordShareLinkAccess increments the usage counter of a share link and
+// updates its access times.
+func (s *SQLStore) recordShareLinkAccess(db sq.BaseRunner, linkID string, accessAt int64) error {
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"share_links").
+		Set("use_count", sq.Expr("use_count + 1")).
+		Set("first_access_at", sq.Expr("CASE WHEN first_access_at = 0 THEN ? ELSE first_access_at END", accessAt)).
+		Set("last_access_at", accessAt).
+		Where(sq.Eq{"id": linkID})
+
+	result, err := query.Exec()
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return model.NewErrNotFound("share link ID=" + linkID)
+	}
+	return nil
+}
+
+// claimShareLinkView binds a single view link to a view id, unless it's
+// already bound to another one. It returns true if the link is bound to
+// the view id.
+func (s *SQLStore) claimShareLinkView(db sq.BaseRunner, linkID, viewID string) (bool, error) {
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"share_links").
+		Set("view_id", viewID).
+		Where(sq.Eq{"id": linkID, "view_id": ""})
+
+	result, err := query.Exec()
+	if err != nil {
+		return false, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if count == 1 {
+		return true, nil
+	}
+
+	// the link was already bound, possibly to the same view by a
+	// concurrent request of the view
+	link, err := s.getShareLink(db, linkID)
+	if err != nil {
+		return false, err
+	}
+	return link.ViewID == viewID, nil
+}
+
+// deleteShareLink revokes a share link.
+func (s *SQLStore) deleteShareLink(db sq.BaseRunner, linkID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "share_links").
+		Where(sq.Eq{"id": linkID})
+
+	result, err := query.Exec()
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return model.NewErrNotFound("share link ID=" + linkID)
+	}
+	return nil
+}
@@ -15,14 +15,23 @@ import (
 func TestSQLStore(t *testing.T) {
 	t.Run("BlocksStore", func(t *testing.T) { storetests.StoreTestBlocksStore(t, SetupTests) })
 	t.Run("SharingStore", func(t *testing.T) { storetests.StoreTestSharingStore(t, SetupTests) })
+	t.Run("ShareLinksStore", func(t *testing.T) { storetests.StoreTestShareLinksStore(t, SetupTests) })
+	t.Run("AccessTokensStore", func(t *testing.T) { storetests.StoreTestAccessTokensStore(t, SetupTests) })
+	t.Run("LoginAttemptsStore", func(t *testing.T) { storetests.StoreTestLoginAttemptsStore(t, SetupTests) })
+	t.Run("PasswordResetTokensStore", func(t *testing.T) { storetests.StoreTestPasswordResetTokensStore(t, SetupTests) })
+	t.Run("EmailVerificationTokensStore", func(t *testing.T) { storetests.StoreTestEmailVerificationTokensStore(t, SetupTests) })
 	t.Run("SystemStore", func(t *testing.T) { storetests.StoreTestSystemStore(t, SetupTests) })
 	t.Run("UserStore", func(t *testing.T) { storetests.StoreTestUserStore(t, SetupTests) })
 	t.Run("SessionStore", func(t *testing.T) { storetests.StoreTestSessionStore(t, SetupTests) })
 	t.Run("TeamStore", func(t *testing.T) { storetests.StoreTestTeamStore(t, SetupTests) })
+	t.Run("TeamManagementStore", func(t *testing.T) { storetests.StoreTestTeamManagementStore(t, SetupTests) })
 	t.Run("BoardStore", func(t *testing.T) { storetests.StoreTestBoardStore(t, SetupTests) })
 	t.Run("BoardsAndBlocksStore", func(t *testing.T) { storetests.StoreTestBoardsAndBlocksStore(t, SetupTests) })
 	t.Run("SubscriptionStore", func(t *testing.T) { storetests.StoreTestSubscriptionsStore(t, SetupTests) })
 	t.Run("NotificationHintStore", func(t *testing.T) { storetests.StoreTestNotificationHintsStore(t, SetupTests) })
+	t.Run("CardRecurrenceStore", func(t *testing.T) { storetests.StoreTestCardRecurrencesStore(t, SetupTests) })
+	t.Run("BoardSnapshotStore", func(t *testing.T) { storetests.StoreTestBoardSnapshotsStore(t, SetupTests) })
+	t.Run("CustomBoardRoleStore", func(t *testing.T) { storetests.StoreTestCustomBoardRolesStore(t, SetupTests) })
 	t.Run("DataRetention", func(t *testing.T) { storetests.StoreTestDataRetention(t, SetupTests) })
 	t.Run("CloudStore", func(t *testing.T) { storetests.StoreTestCloudStore(t, SetupTests) })
 	t.Run("StoreTestFileStore", func(t *testing.T) { storetests.StoreTestFileStore(t, SetupTests) })
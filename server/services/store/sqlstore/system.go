@@ -1,6 +1,8 @@
 package sqlstore
 
 import (
+	"database/sql"
+
 	sq "github.com/Masterminds/squirrel"
 	"github.com/mattermost/focalboard/server/model"
 )
@@ -63,3 +65,36 @@ func (s *SQLStore) setSystemSetting(db sq.BaseRunner, id, value string) error {
 
 	return nil
 }
+
+// claimSystemSetting sets a setting only if it still has the previous value,
+// an empty previous value meaning that the setting doesn't exist yet. It
+// returns false if another caller changed the setting first.
+func (s *SQLStore) claimSystemSetting(db sq.BaseRunner, id, prevValue, value string) (bool, error) {
+	var result sql.Result
+	var err error
+	if prevValue == "" {
+		query := s.getQueryBuilder(db).Insert(s.tablePrefix+"system_settings").Columns("id", "value").Values(id, value)
+		if s.dbType == model.MysqlDBType {
+			query = query.Options("IGNORE")
+		} else {
+			query = query.Suffix("ON CONFLICT (id) DO NOTHING")
+		}
+		result, err = query.Exec()
+	} else {
+		result, err = s.getQueryBuilder(db).
+			Update(s.tablePrefix+"system_settings").
+			Set("value", value).
+			Where(sq.Eq{"id": id}).
+			Where(sq.Eq{"value": prevValue}).
+			Exec()
+	}
+	if err != nil {
+		return false, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -3,6 +3,8 @@ package sqlstore
 import (
 	"database/sql"
 	"encoding/json"
+	"fmt"
+	"strings"
 
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/utils"
@@ -15,10 +17,13 @@ import (
 var (
 	teamFields = []string{
 		"id",
+		"COALESCE(title, '')",
 		"signup_token",
 		"COALESCE(settings, '{}')",
 		"modified_by",
 		"update_at",
+		"COALESCE(create_at, 0)",
+		"COALESCE(delete_at, 0)",
 	}
 )
 
@@ -92,43 +97,51 @@ func (s *SQLStore) upsertTeamSettings(db sq.BaseRunner, team model.Team) error {
 }
 
 func (s *SQLStore) getTeam(db sq.BaseRunner, id string) (*model.Team, error) {
-	var settingsJSON string
-
 	query := s.getQueryBuilder(db).
-		Select(
-			"id",
-			"signup_token",
-			"COALESCE(settings, '{}')",
-			"modified_by",
-			"update_at",
-		).
+		Select(teamFields...).
 		From(s.tablePrefix + "teams").
 		Where(sq.Eq{"id": id})
-	row := query.QueryRow()
-	team := model.Team{}
-
-	err := row.Scan(
-		&team.ID,
-		&team.SignupToken,
-		&settingsJSON,
-		&team.ModifiedBy,
-		&team.UpdateAt,
-	)
+
+	rows, err := query.Query()
 	if err != nil {
+		s.logger.Error("ERROR GetTeam", mlog.Err(err))
 		return nil, err
 	}
+	defer s.CloseRows(rows)
 
-	err = json.Unmarshal([]byte(settingsJSON), &team.Settings)
+	teams, err := s.teamsFromRows(rows)
 	if err != nil {
-		s.logger.Error(`ERROR GetTeam settings json.Unmarshal`, mlog.Err(err))
+		s.logger.Error("ERROR GetTeam teamsFromRows", mlog.Err(err))
 		return nil, err
 	}
+	if len(teams) == 0 {
+		return nil, model.NewErrNotFound("team ID=" + id)
+	}
 
-	return &team, nil
+	return teams[0], nil
 }
 
-func (s *SQLStore) getTeamsForUser(db sq.BaseRunner, _ string) ([]*model.Team, error) {
-	return s.getAllTeams(db)
+// getTeamsForUser returns the root team and the active teams that the user
+// is a member of.
+func (s *SQLStore) getTeamsForUser(db sq.BaseRunner, userID string) ([]*model.Team, error) {
+	query := s.getQueryBuilder(db).
+		Select(teamFields...).
+		From(s.tablePrefix + "teams").
+		Where(sq.Or{
+			sq.Eq{"id": model.GlobalTeamID},
+			sq.Expr("id IN (SELECT team_id FROM "+s.tablePrefix+"team_members WHERE user_id = ?)", userID),
+		}).
+		Where(sq.Eq{"delete_at": 0}).
+		OrderBy("title", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("ERROR GetTeamsForUser", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.teamsFromRows(rows)
 }
 
 func (s *SQLStore) getTeamCount(db sq.BaseRunner) (int64, error) {
@@ -165,10 +178,13 @@ func (s *SQLStore) teamsFromRows(rows *sql.Rows) ([]*model.Team, error) {
 
 		err := rows.Scan(
 			&team.ID,
+			&team.Title,
 			&team.SignupToken,
 			&settingsBytes,
 			&team.ModifiedBy,
 			&team.UpdateAt,
+			&team.CreateAt,
+			&team.DeleteAt,
 		)
 		if err != nil {
 			return nil, err
@@ -203,3 +219,154 @@ func (s *SQLStore) getAllTeams(db sq.BaseRunner) ([]*model.Team, error) {
 
 	return teams, nil
 }
+
+// createTeam creates a team and makes its creator a team admin, unless it
+// is the system user.
+func (s *SQLStore) createTeam(db sq.BaseRunner, team *model.Team, userID string) (*model.Team, error) {
+	if err := team.IsValid(); err != nil {
+		return nil, err
+	}
+
+	now := utils.GetMillis()
+	teamAdd := *team
+	teamAdd.ID = utils.NewID(utils.IDTypeTeam)
+	teamAdd.Title = strings.TrimSpace(teamAdd.Title)
+	teamAdd.SignupToken = utils.NewID(utils.IDTypeToken)
+	teamAdd.ModifiedBy = userID
+	teamAdd.CreateAt = now
+	teamAdd.UpdateAt = now
+	teamAdd.DeleteAt = 0
+	if teamAdd.Settings == nil {
+		teamAdd.Settings = map[string]interface{}{}
+	}
+
+	settingsJSON, err := json.Marshal(teamAdd.Settings)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"teams").
+		Columns(
+			"id",
+			"title",
+			"signup_token",
+			"settings",
+			"modified_by",
+			"update_at",
+			"create_at",
+			"delete_at",
+		).
+		Values(
+			teamAdd.ID,
+			teamAdd.Title,
+			teamAdd.SignupToken,
+			settingsJSON,
+			teamAdd.ModifiedBy,
+			teamAdd.UpdateAt,
+			teamAdd.CreateAt,
+			teamAdd.DeleteAt,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot create team", mlog.String("title", teamAdd.Title), mlog.Err(err))
+		return nil, err
+	}
+
+	// the teams created by the system, like the provisioned groups, have
+	// no admin
+	if userID != model.SystemUserID {
+		admin := &model.TeamMember{
+			TeamID:      teamAdd.ID,
+			UserID:      userID,
+			SchemeAdmin: true,
+		}
+		if _, err := s.saveTeamMember(db, admin); err != nil {
+			return nil, fmt.Errorf("cannot save admin %s while creating team %s: %w", userID, teamAdd.ID, err)
+		}
+	}
+
+	return &teamAdd, nil
+}
+
+// patchTeam updates the title of a team.
+func (s *SQLStore) patchTeam(db sq.BaseRunner, teamID string, patch *model.TeamPatch, userID string) (*model.Team, error) {
+	team, err := s.getTeam(db, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	team = patch.Patch(team)
+	if err = team.IsValid(); err != nil {
+		return nil, err
+	}
+	team.ModifiedBy = userID
+	team.UpdateAt = utils.GetMillis()
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"teams").
+		Set("title", team.Title).
+		Set("modified_by", team.ModifiedBy).
+		Set("update_at", team.UpdateAt).
+		Where(sq.Eq{"id": teamID})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot patch team", mlog.String("team_id", teamID), mlog.Err(err))
+		return nil, err
+	}
+	return team, nil
+}
+
+// archiveTeam marks a team as archived. The boards of archived teams are
+// kept but nobody can access them.
+func (s *SQLStore) archiveTeam(db sq.BaseRunner, teamID string, userID string) error {
+	now := utils.GetMillis()
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"teams").
+		Set("modified_by", userID).
+		Set("update_at", now).
+		Set("delete_at", now).
+		Where(sq.Eq{"id": teamID}).
+		Where(sq.Eq{"delete_at": 0})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("Cannot archive team", mlog.String("team_id", teamID), mlog.Err(err))
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return model.NewErrNotFound("team ID=" + teamID)
+	}
+	return nil
+}
+
+// getTeamBySignupToken returns the active team with the signup token.
+func (s *SQLStore) getTeamBySignupToken(db sq.BaseRunner, signupToken string) (*model.Team, error) {
+	query := s.getQueryBuilder(db).
+		Select(teamFields...).
+		From(s.tablePrefix + "teams").
+		Where(sq.Eq{"signup_token": signupToken}).
+		Where(sq.Eq{"delete_at": 0})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("ERROR GetTeamBySignupToken", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	teams, err := s.teamsFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(teams) == 0 {
+		return nil, model.NewErrNotFound("team signup token")
+	}
+	return teams[0], nil
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+var teamInviteFields = []string{
+	"id",
+	"team_id",
+	"email",
+	"scheme_admin",
+	"board_ids",
+	"board_role",
+	"created_by",
+	"create_at",
+	"expire_at",
+	"accepted_by",
+	"accept_at",
+}
+
+func (s *SQLStore) teamInvitesFromRows(rows *sql.Rows) ([]*model.TeamInvite, error) {
+	invites := []*model.TeamInvite{}
+
+	for rows.Next() {
+		var invite model.TeamInvite
+		var boardIDsJSON sql.NullString
+		err := rows.Scan(
+			&invite.ID,
+			&invite.TeamID,
+			&invite.Email,
+			&invite.SchemeAdmin,
+			&boardIDsJSON,
+			&invite.BoardRole,
+			&invite.CreatedBy,
+			&invite.CreateAt,
+			&invite.ExpireAt,
+			&invite.AcceptedBy,
+			&invite.AcceptAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		invite.BoardIDs = []string{}
+		if boardIDsJSON.String != "" {
+			if err := json.Unmarshal([]byte(boardIDsJSON.String), &invite.BoardIDs); err != nil {
+				return nil, err
+			}
+		}
+		invites = append(invites, &invite)
+	}
+	return invites, nil
+}
+
+// createTeamInvite creates an invitation to a team, that expires after
+// model.TeamInviteExpiry unless it has an expiry time.
+func (s *SQLStore) createTeamInvite(db sq.BaseRunner, invite *model.TeamInvite) (*model.TeamInvite, error) {
+	if err := invite.IsValid(); err != nil {
+		return nil, err
+	}
+
+	inviteAdd := *invite
+	inviteAdd.ID = utils.NewID(utils.IDTypeToken)
+	inviteAdd.Email = strings.ToLower(strings.TrimSpace(inviteAdd.Email))
+	inviteAdd.CreateAt = utils.GetMillis()
+	if inviteAdd.ExpireAt == 0 {
+		inviteAdd.ExpireAt = inviteAdd.CreateAt + model.TeamInviteExpiry.Milliseconds()
+	}
+	if inviteAdd.BoardIDs == nil {
+		inviteAdd.BoardIDs = []string{}
+	}
+	inviteAdd.AcceptedBy = ""
+	inviteAdd.AcceptAt = 0
+
+	boardIDsJSON, err := json.Marshal(inviteAdd.BoardIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"team_invites").
+		Columns(teamInviteFields...).
+		Values(
+			inviteAdd.ID,
+			inviteAdd.TeamID,
+			inviteAdd.Email,
+			inviteAdd.SchemeAdmin,
+			string(boardIDsJSON),
+			inviteAdd.BoardRole,
+			inviteAdd.CreatedBy,
+			inviteAdd.CreateAt,
+			inviteAdd.ExpireAt,
+			inviteAdd.AcceptedBy,
+			inviteAdd.AcceptAt,
+		)
+
+	if _, err = query.Exec(); err != nil {
+		s.logger.Error("Cannot create team invite",
+			mlog.String("team_id", invite.TeamID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+	return &inviteAdd, nil
+}
+
+func (s *SQLStore) getTeamInvite(db sq.BaseRunner, inviteID string) (*model.TeamInvite, error) {
+	query := s.getQueryBuilder(db).
+		Select(teamInviteFields...).
+		From(s.tablePrefix + "team_invites").
+		Where(sq.Eq{"id": inviteID})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch team invite", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	invites, err := s.teamInvitesFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(invites) == 0 {
+		return nil, model.NewErrNotFound("team invite")
+	}
+	return invites[0], nil
+}
+
+// getTeamInvites returns the pending invitations of a team, newest first.
+func (s *SQLStore) getTeamInvites(db sq.BaseRunner, teamID string) ([]*model.TeamInvite, error) {
+	query := s.getQueryBuilder(db).
+		Select(teamInviteFields...).
+		From(s.tablePrefix+"team_invites").
+		Where(sq.Eq{"team_id": teamID}).
+		Where(sq.Eq{"accept_at": 0}).
+		OrderBy("create_at DESC", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch team invites",
+			mlog.String("team_id", teamID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.teamInvitesFromRows(rows)
+}
+
+// acceptTeamInvite marks a pending invitation as accepted and adds the user
+// to the team with the role of the invitation.
+func (s *SQLStore) acceptTeamInvite(db sq.BaseRunner, inviteID string, userID string) (*model.TeamMember, error) {
+	invite, err := s.getTeamInvite(db, inviteID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"team_invites").
+		Set("accepted_by", userID).
+		Set("accept_at", utils.GetMillis()).
+		Where(sq.Eq{"id": inviteID}).
+		Where(sq.Eq{"accept_at": 0})
+
+	result, err := query.Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, model.NewErrBadRequest("the invitation has already been accepted")
+	}
+
+	return s.saveTeamMember(db, &model.TeamMember{
+		TeamID:      invite.TeamID,
+		UserID:      userID,
+		SchemeAdmin: invite.SchemeAdmin,
+	})
+}
+
+func (s *SQLStore) deleteTeamInvite(db sq.BaseRunner, inviteID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "team_invites").
+		Where(sq.Eq{"id": inviteID})
+
+	result, err := query.Exec()
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return model.NewErrNotFound("team invite")
+	}
+	return nil
+}
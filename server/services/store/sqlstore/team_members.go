@@ -0,0 +1,143 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+var teamMemberFields = []string{
+	"team_id",
+	"user_id",
+	"scheme_admin",
+	"create_at",
+}
+
+func (s *SQLStore) teamMembersFromRows(rows *sql.Rows) ([]*model.TeamMember, error) {
+	members := []*model.TeamMember{}
+
+	for rows.Next() {
+		var member model.TeamMember
+		err := rows.Scan(
+			&member.TeamID,
+			&member.UserID,
+			&member.SchemeAdmin,
+			&member.CreateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, &member)
+	}
+	return members, nil
+}
+
+// saveTeamMember adds a user to a team, or updates its team role if the
+// user is already a member.
+func (s *SQLStore) saveTeamMember(db sq.BaseRunner, member *model.TeamMember) (*model.TeamMember, error) {
+	memberAdd := *member
+	if memberAdd.CreateAt == 0 {
+		memberAdd.CreateAt = utils.GetMillis()
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"team_members").
+		Columns(teamMemberFields...).
+		Values(memberAdd.TeamID, memberAdd.UserID, memberAdd.SchemeAdmin, memberAdd.CreateAt)
+
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE scheme_admin = ?", memberAdd.SchemeAdmin)
+	} else {
+		query = query.Suffix(
+			`ON CONFLICT (team_id, user_id)
+			 DO UPDATE SET scheme_admin = EXCLUDED.scheme_admin`,
+		)
+	}
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot save team member",
+			mlog.String("team_id", member.TeamID),
+			mlog.String("user_id", member.UserID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+	return s.getTeamMember(db, memberAdd.TeamID, memberAdd.UserID)
+}
+
+func (s *SQLStore) getTeamMember(db sq.BaseRunner, teamID, userID string) (*model.TeamMember, error) {
+	query := s.getQueryBuilder(db).
+		Select(teamMemberFields...).
+		From(s.tablePrefix + "team_members").
+		Where(sq.Eq{"team_id": teamID}).
+		Where(sq.Eq{"user_id": userID})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch team member",
+			mlog.String("team_id", teamID),
+			mlog.String("user_id", userID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	members, err := s.teamMembersFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(members) == 0 {
+		return nil, model.NewErrNotFound("team member userID=" + userID)
+	}
+	return members[0], nil
+}
+
+// getTeamMembers returns the members of a team, oldest first.
+func (s *SQLStore) getTeamMembers(db sq.BaseRunner, teamID string) ([]*model.TeamMember, error) {
+	query := s.getQueryBuilder(db).
+		Select(teamMemberFields...).
+		From(s.tablePrefix+"team_members").
+		Where(sq.Eq{"team_id": teamID}).
+		OrderBy("create_at", "user_id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch team members",
+			mlog.String("team_id", teamID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.teamMembersFromRows(rows)
+}
+
+func (s *SQLStore) deleteTeamMember(db sq.BaseRunner, teamID, userID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "team_members").
+		Where(sq.Eq{"team_id": teamID}).
+		Where(sq.Eq{"user_id": userID})
+
+	result, err := query.Exec()
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return model.NewErrNotFound("team member userID=" + userID)
+	}
+	return nil
+}
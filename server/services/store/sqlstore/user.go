@@ -2,6 +2,7 @@ package sqlstore
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -58,22 +59,51 @@ func (s *SQLStore) getUserByCondition(db sq.BaseRunner, condition sq.Eq) (*model
 }
 
 func (s *SQLStore) getUsersByCondition(db sq.BaseRunner, condition interface{}, limit uint64) ([]*model.User, error) {
-	query := s.getQueryBuilder(db).
+	users, err := s.getUsersIncludingDeleted(db, limit, sq.Eq{"delete_at": 0}, condition)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(users) == 0 {
+		return nil, model.NewErrNotFound("user")
+	}
+
+	return users, nil
+}
+
+func (s *SQLStore) usersQuery(db sq.BaseRunner) sq.SelectBuilder {
+	return s.getQueryBuilder(db).
 		Select(
 			"id",
 			"username",
 			"email",
 			"password",
 			"mfa_secret",
+			"mfa_active",
 			"auth_service",
 			"auth_data",
+			"COALESCE(nickname, '')",
+			"COALESCE(first_name, '')",
+			"COALESCE(last_name, '')",
 			"create_at",
 			"update_at",
 			"delete_at",
+			"is_guest",
+			"guest_expire_at",
+			"email_verified",
+			"roles",
+			"scim_deleted",
 		).
-		From(s.tablePrefix + "users").
-		Where(sq.Eq{"delete_at": 0}).
-		Where(condition)
+		From(s.tablePrefix + "users")
+}
+
+// getUsersIncludingDeleted returns the users matching the conditions,
+// deactivated users included.
+func (s *SQLStore) getUsersIncludingDeleted(db sq.BaseRunner, limit uint64, conditions ...interface{}) ([]*model.User, error) {
+	query := s.usersQuery(db)
+	for _, condition := range conditions {
+		query = query.Where(condition)
+	}
 
 	if limit != 0 {
 		query = query.Limit(limit)
@@ -86,16 +116,7 @@ func (s *SQLStore) getUsersByCondition(db sq.BaseRunner, condition interface{},
 	}
 	defer s.CloseRows(rows)
 
-	users, err := s.usersFromRows(rows)
-	if err != nil {
-		return nil, err
-	}
-
-	if len(users) == 0 {
-		return nil, model.NewErrNotFound("user")
-	}
-
-	return users, nil
+	return s.usersFromRows(rows)
 }
 
 func (s *SQLStore) getUserByID(db sq.BaseRunner, userID string) (*model.User, error) {
@@ -123,6 +144,68 @@ func (s *SQLStore) getUserByUsername(db sq.BaseRunner, username string) (*model.
 	return s.getUserByCondition(db, sq.Eq{"username": username})
 }
 
+// getUserByAuthData returns the user linked to an external identity, even
+// if it is deactivated, so that the identity isn't linked to a new user.
+func (s *SQLStore) getUserByAuthData(db sq.BaseRunner, authService, authData string) (*model.User, error) {
+	users, err := s.getUsersIncludingDeleted(db, 1, sq.Eq{"auth_service": authService, "auth_data": authData})
+	if err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, model.NewErrNotFound("user")
+	}
+	return users[0], nil
+}
+
+// getUsersByAuthService returns all the users of an auth service, the
+// deactivated ones included.
+func (s *SQLStore) getUsersByAuthService(db sq.BaseRunner, authService string) ([]*model.User, error) {
+	return s.getUsersIncludingDeleted(db, 0, sq.Eq{"auth_service": authService})
+}
+
+// getUsers returns the users matching the options, sorted by username.
+func (s *SQLStore) getUsers(db sq.BaseRunner, opts model.QueryUsersOptions) ([]*model.User, error) {
+	query := s.usersQuery(db).OrderBy("username", "id")
+
+	if !opts.IncludeDeleted {
+		query = query.Where(sq.Eq{"delete_at": 0})
+	}
+	if opts.UserID != "" {
+		query = query.Where(sq.Eq{"id": opts.UserID})
+	}
+	if opts.Username != "" {
+		query = query.Where(sq.Eq{"username": opts.Username})
+	}
+	if opts.Email != "" {
+		query = query.Where(sq.Eq{"email": opts.Email})
+	}
+	if opts.Term != "" {
+		like := "%" + opts.Term + "%"
+		query = query.Where(sq.Or{
+			sq.Like{"username": like},
+			sq.Like{"email": like},
+			sq.Like{"nickname": like},
+			sq.Like{"first_name": like},
+			sq.Like{"last_name": like},
+		})
+	}
+	if opts.PerPage > 0 {
+		query = query.Limit(uint64(opts.PerPage))
+		if opts.Page > 0 {
+			query = query.Offset(uint64(opts.Page * opts.PerPage))
+		}
+	}
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getUsers ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.usersFromRows(rows)
+}
+
 func (s *SQLStore) createUser(db sq.BaseRunner, user *model.User) (*model.User, error) {
 	now := utils.GetMillis()
 	user.CreateAt = now
@@ -130,8 +213,8 @@ func (s *SQLStore) createUser(db sq.BaseRunner, user *model.User) (*model.User,
 	user.DeleteAt = 0
 
 	query := s.getQueryBuilder(db).Insert(s.tablePrefix+"users").
-		Columns("id", "username", "email", "password", "mfa_secret", "auth_service", "auth_data", "create_at", "update_at", "delete_at").
-		Values(user.ID, user.Username, user.Email, user.Password, user.MfaSecret, user.AuthService, user.AuthData, user.CreateAt, user.UpdateAt, user.DeleteAt)
+		Columns("id", "username", "email", "password", "mfa_secret", "mfa_active", "auth_service", "auth_data", "nickname", "first_name", "last_name", "create_at", "update_at", "delete_at", "is_guest", "guest_expire_at", "email_verified", "roles").
+		Values(user.ID, user.Username, user.Email, user.Password, user.MfaSecret, user.MfaActive, user.AuthService, user.AuthData, user.Nickname, user.FirstName, user.LastName, user.CreateAt, user.UpdateAt, user.DeleteAt, user.IsGuest, user.GuestExpireAt, user.EmailVerified, user.Roles)
 
 	_, err := query.Exec()
 	return user, err
@@ -144,6 +227,9 @@ func (s *SQLStore) updateUser(db sq.BaseRunner, user *model.User) (*model.User,
 	query := s.getQueryBuilder(db).Update(s.tablePrefix+"users").
 		Set("username", user.Username).
 		Set("email", user.Email).
+		Set("nickname", user.Nickname).
+		Set("first_name", user.FirstName).
+		Set("last_name", user.LastName).
 		Set("update_at", user.UpdateAt).
 		Where(sq.Eq{"id": user.ID})
 
@@ -214,8 +300,265 @@ func (s *SQLStore) updateUserPasswordByID(db sq.BaseRunner, userID, password str
 	return nil
 }
 
-func (s *SQLStore) getUsersByTeam(db sq.BaseRunner, _ string, _ string, _, _ bool) ([]*model.User, error) {
-	users, err := s.getUsersByCondition(db, nil, 0)
+// updateUserGuest converts a user to a guest or back to a regular user.
+// Guests with an expiry time can't use their account after it.
+func (s *SQLStore) updateUserGuest(db sq.BaseRunner, userID string, isGuest bool, guestExpireAt int64) error {
+	if !isGuest {
+		guestExpireAt = 0
+	}
+
+	query := s.getQueryBuilder(db).Update(s.tablePrefix+"users").
+		Set("is_guest", isGuest).
+		Set("guest_expire_at", guestExpireAt).
+		Set("update_at", utils.GetMillis()).
+		Where(sq.Eq{"id": userID})
+
+	result, err := query.Exec()
+	if err != nil {
+		return err
+	}
+
+	rowCount, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowCount < 1 {
+		return UserNotFoundError{userID}
+	}
+
+	return nil
+}
+
+// updateUserRoles replaces the system roles of the user.
+func (s *SQLStore) updateUserRoles(db sq.BaseRunner, userID string, roles string) error {
+	query := s.getQueryBuilder(db).Update(s.tablePrefix+"users").
+		Set("roles", roles).
+		Set("update_at", utils.GetMillis()).
+		Where(sq.Eq{"id": userID})
+
+	result, err := query.Exec()
+	if err != nil {
+		return err
+	}
+
+	rowCount, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowCount < 1 {
+		return UserNotFoundError{userID}
+	}
+
+	return nil
+}
+
+// updateUserEmailVerified sets if the email address of the user has been
+// verified.
+func (s *SQLStore) updateUserEmailVerified(db sq.BaseRunner, userID string, emailVerified bool) error {
+	query := s.getQueryBuilder(db).Update(s.tablePrefix+"users").
+		Set("email_verified", emailVerified).
+		Set("update_at", utils.GetMillis()).
+		Where(sq.Eq{"id": userID})
+
+	result, err := query.Exec()
+	if err != nil {
+		return err
+	}
+
+	rowCount, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowCount < 1 {
+		return UserNotFoundError{userID}
+	}
+
+	return nil
+}
+
+// updateUserDeleteAt deactivates a user, or reactivates it if deleteAt is
+// 0, which also clears its SCIM deletion. Deactivated users are ignored by
+// all the other user queries.
+func (s *SQLStore) updateUserDeleteAt(db sq.BaseRunner, userID string, deleteAt int64) error {
+	query := s.getQueryBuilder(db).Update(s.tablePrefix+"users").
+		Set("delete_at", deleteAt).
+		Set("update_at", utils.GetMillis()).
+		Where(sq.Eq{"id": userID})
+	if deleteAt == 0 {
+		query = query.Set("scim_deleted", false)
+	}
+
+	result, err := query.Exec()
+	if err != nil {
+		return err
+	}
+
+	rowCount, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowCount < 1 {
+		return UserNotFoundError{userID}
+	}
+
+	return nil
+}
+
+// updateUserScimDeleted records whether the identity provider deleted the
+// user with the SCIM API, rather than only deactivating it.
+func (s *SQLStore) updateUserScimDeleted(db sq.BaseRunner, userID string, scimDeleted bool) error {
+	query := s.getQueryBuilder(db).Update(s.tablePrefix+"users").
+		Set("scim_deleted", scimDeleted).
+		Set("update_at", utils.GetMillis()).
+		Where(sq.Eq{"id": userID})
+
+	result, err := query.Exec()
+	if err != nil {
+		return err
+	}
+
+	rowCount, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowCount < 1 {
+		return UserNotFoundError{userID}
+	}
+
+	return nil
+}
+
+// updateUserMfa saves the MFA secret of a user, whether MFA is active and
+// the hashes of its recovery codes.
+func (s *SQLStore) updateUserMfa(db sq.BaseRunner, userID string, secret string, active bool, recoveryCodes []string) error {
+	if recoveryCodes == nil {
+		recoveryCodes = []string{}
+	}
+	recoveryCodesJSON, err := json.Marshal(recoveryCodes)
+	if err != nil {
+		return err
+	}
+
+	query := s.getQueryBuilder(db).Update(s.tablePrefix+"users").
+		Set("mfa_secret", secret).
+		Set("mfa_active", active).
+		Set("mfa_recovery_codes", string(recoveryCodesJSON)).
+		Set("update_at", utils.GetMillis()).
+		Where(sq.Eq{"id": userID})
+
+	result, err := query.Exec()
+	if err != nil {
+		return err
+	}
+
+	rowCount, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowCount < 1 {
+		return UserNotFoundError{userID}
+	}
+
+	return nil
+}
+
+// getUserMfaRecoveryCodes returns the hashes of the MFA recovery codes of
+// a user that haven't been used yet.
+func (s *SQLStore) getUserMfaRecoveryCodes(db sq.BaseRunner, userID string) ([]string, error) {
+	query := s.getQueryBuilder(db).
+		Select("COALESCE(mfa_recovery_codes, '')").
+		From(s.tablePrefix + "users").
+		Where(sq.Eq{"id": userID})
+
+	var recoveryCodesJSON string
+	if err := query.QueryRow().Scan(&recoveryCodesJSON); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.NewErrNotFound("user ID=" + userID)
+		}
+		return nil, err
+	}
+
+	recoveryCodes := []string{}
+	if recoveryCodesJSON == "" {
+		return recoveryCodes, nil
+	}
+	if err := json.Unmarshal([]byte(recoveryCodesJSON), &recoveryCodes); err != nil {
+		return nil, err
+	}
+	return recoveryCodes, nil
+}
+
+// claimUserMfaTimeStep records the time step of the last TOTP code accepted
+// for a user. It returns false if a code of the same or a later time step
+// was already accepted, so that a code can't be used twice.
+func (s *SQLStore) claimUserMfaTimeStep(db sq.BaseRunner, userID string, timeStep int64) (bool, error) {
+	query := s.getQueryBuilder(db).Update(s.tablePrefix+"users").
+		Set("mfa_last_time_step", timeStep).
+		Where(sq.Eq{"id": userID}).
+		Where(sq.Lt{"mfa_last_time_step": timeStep})
+
+	result, err := query.Exec()
+	if err != nil {
+		return false, err
+	}
+
+	rowCount, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowCount == 1, nil
+}
+
+// teamUsersCondition restricts the users of managed teams to the team
+// members. Every user belongs to the root team and to the teams that don't
+// exist in the store.
+func (s *SQLStore) teamUsersCondition(db sq.BaseRunner, teamID string) (sq.Sqlizer, error) {
+	team, err := s.getTeam(db, teamID)
+	if model.IsErrNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !team.IsManaged() {
+		return nil, nil
+	}
+	return sq.Expr("id IN (SELECT user_id FROM "+s.tablePrefix+"team_members WHERE team_id = ?)", teamID), nil
+}
+
+// guestUsersCondition restricts the users that a guest can see to the
+// members of the boards of the team that the guest is a member of.
+func (s *SQLStore) guestUsersCondition(teamID, asGuestID string) sq.Sqlizer {
+	return sq.Expr(
+		"id IN (SELECT bm.user_id FROM "+s.tablePrefix+"board_members AS bm WHERE bm.board_id IN ("+
+			"SELECT gbm.board_id FROM "+s.tablePrefix+"board_members AS gbm "+
+			"JOIN "+s.tablePrefix+"boards AS b ON b.id = gbm.board_id "+
+			"WHERE gbm.user_id = ? AND b.team_id = ?))",
+		asGuestID, teamID,
+	)
+}
+
+func (s *SQLStore) getUsersByTeam(db sq.BaseRunner, teamID string, asGuestID string, _, _ bool) ([]*model.User, error) {
+	condition, err := s.teamUsersCondition(db, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := sq.And{}
+	if condition != nil {
+		conditions = append(conditions, condition)
+	}
+	if asGuestID != "" {
+		conditions = append(conditions, s.guestUsersCondition(teamID, asGuestID))
+	}
+
+	users, err := s.getUsersByCondition(db, conditions, 0)
 	if model.IsErrNotFound(err) {
 		return []*model.User{}, nil
 	}
@@ -223,8 +566,21 @@ func (s *SQLStore) getUsersByTeam(db sq.BaseRunner, _ string, _ string, _, _ boo
 	return users, err
 }
 
-func (s *SQLStore) searchUsersByTeam(db sq.BaseRunner, _ string, searchQuery string, _ string, _, _, _ bool) ([]*model.User, error) {
-	users, err := s.getUsersByCondition(db, &sq.Like{"username": "%" + searchQuery + "%"}, 10)
+func (s *SQLStore) searchUsersByTeam(db sq.BaseRunner, teamID string, searchQuery string, asGuestID string, _, _, _ bool) ([]*model.User, error) {
+	condition, err := s.teamUsersCondition(db, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := sq.And{sq.Like{"username": "%" + searchQuery + "%"}}
+	if condition != nil {
+		conditions = append(conditions, condition)
+	}
+	if asGuestID != "" {
+		conditions = append(conditions, s.guestUsersCondition(teamID, asGuestID))
+	}
+
+	users, err := s.getUsersByCondition(db, conditions, 10)
 	if model.IsErrNotFound(err) {
 		return []*model.User{}, nil
 	}
@@ -244,11 +600,20 @@ func (s *SQLStore) usersFromRows(rows *sql.Rows) ([]*model.User, error) {
 			&user.Email,
 			&user.Password,
 			&user.MfaSecret,
+			&user.MfaActive,
 			&user.AuthService,
 			&user.AuthData,
+			&user.Nickname,
+			&user.FirstName,
+			&user.LastName,
 			&user.CreateAt,
 			&user.UpdateAt,
 			&user.DeleteAt,
+			&user.IsGuest,
+			&user.GuestExpireAt,
+			&user.EmailVerified,
+			&user.Roles,
+			&user.ScimDeleted,
 		)
 		if err != nil {
 			return nil, err
@@ -353,8 +718,35 @@ func (s *SQLStore) deleteUserPreference(db sq.BaseRunner, preference mmModel.Pre
 	return nil
 }
 
+// canSeeUser returns true if the seer is a regular user or a guest that
+// shares a board with the seen user.
 func (s *SQLStore) canSeeUser(db sq.BaseRunner, seerID string, seenID string) (bool, error) {
-	return true, nil
+	seer, err := s.getUserByID(db, seerID)
+	if model.IsErrNotFound(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !seer.IsGuest || seerID == seenID {
+		return true, nil
+	}
+
+	query := s.getQueryBuilder(db).
+		Select("1").
+		From(s.tablePrefix + "board_members AS bm1").
+		Join(s.tablePrefix + "board_members AS bm2 ON bm1.board_id=bm2.board_id").
+		Where(sq.Eq{"bm1.user_id": seerID}).
+		Where(sq.Eq{"bm2.user_id": seenID}).
+		Limit(1)
+
+	rows, err := query.Query()
+	if err != nil {
+		return false, err
+	}
+	defer s.CloseRows(rows)
+
+	return rows.Next(), nil
 }
 
 func (s *SQLStore) sendMessage(db sq.BaseRunner, message, postType string, receipts []string) error {
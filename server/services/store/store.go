@@ -26,6 +26,8 @@ type Store interface {
 	// @withTransaction
 	DeleteBlock(blockID string, modifiedBy string) error
 	// @withTransaction
+	DeleteBlocks(blockIDs []string, modifiedBy string) error
+	// @withTransaction
 	InsertBlocks(blocks []*model.Block, userID string) error
 	// @withTransaction
 	UndeleteBlock(blockID string, modifiedBy string) error
@@ -38,6 +40,7 @@ type Store interface {
 	PatchBlock(blockID string, blockPatch *model.BlockPatch, userID string) error
 	GetBlockHistory(blockID string, opts model.QueryBlockHistoryOptions) ([]*model.Block, error)
 	GetBlockHistoryDescendants(boardID string, opts model.QueryBlockHistoryOptions) ([]*model.Block, error)
+	GetBlockHistoryWithChildren(blockID string, opts model.QueryBlockHistoryOptions) ([]*model.Block, error)
 	GetBlockHistoryNewestChildren(parentID string, opts model.QueryBlockHistoryChildOptions) ([]*model.Block, bool, error)
 	GetBoardHistory(boardID string, opts model.QueryBoardHistoryOptions) ([]*model.Board, error)
 	GetBoardAndCardByID(blockID string) (board *model.Board, card *model.Block, err error)
@@ -48,22 +51,38 @@ type Store interface {
 	DuplicateBlock(boardID string, blockID string, userID string, asTemplate bool) ([]*model.Block, error)
 	// @withTransaction
 	PatchBlocks(blockPatches *model.BlockPatchBatch, userID string) error
+	// @withTransaction
+	MoveBlocks(blocks []*model.Block, userID string) error
+	// @withTransaction
+	RevertBlocks(blocks []*model.Block, deletedBlockIDs []string, userID string) error
 
 	Shutdown() error
 
 	GetSystemSetting(key string) (string, error)
 	GetSystemSettings() (map[string]string, error)
 	SetSystemSetting(key, value string) error
+	ClaimSystemSetting(key, prevValue, value string) (bool, error)
 
 	GetRegisteredUserCount() (int, error)
 	GetUserByID(userID string) (*model.User, error)
 	GetUsersList(userIDs []string, showEmail, showName bool) ([]*model.User, error)
 	GetUserByEmail(email string) (*model.User, error)
 	GetUserByUsername(username string) (*model.User, error)
+	GetUserByAuthData(authService, authData string) (*model.User, error)
+	GetUsersByAuthService(authService string) ([]*model.User, error)
+	GetUsers(opts model.QueryUsersOptions) ([]*model.User, error)
 	CreateUser(user *model.User) (*model.User, error)
 	UpdateUser(user *model.User) (*model.User, error)
 	UpdateUserPassword(username, password string) error
 	UpdateUserPasswordByID(userID, password string) error
+	UpdateUserGuest(userID string, isGuest bool, guestExpireAt int64) error
+	UpdateUserEmailVerified(userID string, emailVerified bool) error
+	UpdateUserRoles(userID string, roles string) error
+	UpdateUserDeleteAt(userID string, deleteAt int64) error
+	UpdateUserScimDeleted(userID string, scimDeleted bool) error
+	UpdateUserMfa(userID string, secret string, active bool, recoveryCodes []string) error
+	GetUserMfaRecoveryCodes(userID string) ([]string, error)
+	ClaimUserMfaTimeStep(userID string, timeStep int64) (bool, error)
 	GetUsersByTeam(teamID string, asGuestID string, showEmail, showName bool) ([]*model.User, error)
 	SearchUsersByTeam(teamID string, searchQuery string, asGuestID string, excludeBots bool, showEmail, showName bool) ([]*model.User, error)
 	PatchUserPreferences(userID string, patch model.UserPreferencesPatch) (mmModel.Preferences, error)
@@ -71,21 +90,71 @@ type Store interface {
 
 	GetActiveUserCount(updatedSecondsAgo int64) (int, error)
 	GetSession(token string, expireTime int64) (*model.Session, error)
+	GetSessionsForUser(userID string, expireTime int64) ([]*model.Session, error)
 	CreateSession(session *model.Session) error
 	RefreshSession(session *model.Session) error
 	UpdateSession(session *model.Session) error
 	DeleteSession(sessionID string) error
+	DeleteSessionsForUser(userID string) error
 	CleanUpSessions(expireTime int64) error
 
+	GetLoginAttempts(key string) (*model.LoginAttempts, error)
+	// @withTransaction
+	IncrementLoginAttempts(key string, windowStart int64) (*model.LoginAttempts, error)
+	DeleteLoginAttempts(key string) error
+
+	CreatePasswordResetToken(token *model.PasswordResetToken) (*model.PasswordResetToken, error)
+	// @withTransaction
+	ConsumePasswordResetToken(tokenHash string) (*model.PasswordResetToken, error)
+	DeletePasswordResetTokensForUser(userID string) error
+
+	CreateEmailVerificationToken(token *model.EmailVerificationToken) (*model.EmailVerificationToken, error)
+	// @withTransaction
+	ConsumeEmailVerificationToken(tokenHash string) (*model.EmailVerificationToken, error)
+	DeleteEmailVerificationTokensForUser(userID string) error
+
 	UpsertSharing(sharing model.Sharing) error
 	GetSharing(rootID string) (*model.Sharing, error)
 
+	CreateShareLink(link *model.ShareLink) (*model.ShareLink, error)
+	GetShareLink(linkID string) (*model.ShareLink, error)
+	GetShareLinkByToken(boardID, token string) (*model.ShareLink, error)
+	GetShareLinksForBoard(boardID string) ([]*model.ShareLink, error)
+	RecordShareLinkAccess(linkID string, accessAt int64) error
+	ClaimShareLinkView(linkID, viewID string) (bool, error)
+	DeleteShareLink(linkID string) error
+
+	CreateAccessToken(token *model.AccessToken) (*model.AccessToken, error)
+	GetAccessToken(tokenID string) (*model.AccessToken, error)
+	GetAccessTokenByHash(tokenHash string) (*model.AccessToken, error)
+	GetAccessTokensForUser(userID string) ([]*model.AccessToken, error)
+	UpdateAccessTokenLastUsed(tokenID string, usedAt int64) error
+	DeleteAccessToken(tokenID string) error
+	DeleteAccessTokensForUser(userID string) error
+
 	UpsertTeamSignupToken(team model.Team) error
 	UpsertTeamSettings(team model.Team) error
 	GetTeam(ID string) (*model.Team, error)
 	GetTeamsForUser(userID string) ([]*model.Team, error)
 	GetAllTeams() ([]*model.Team, error)
 	GetTeamCount() (int64, error)
+	GetTeamBySignupToken(signupToken string) (*model.Team, error)
+	// @withTransaction
+	CreateTeam(team *model.Team, userID string) (*model.Team, error)
+	PatchTeam(teamID string, patch *model.TeamPatch, userID string) (*model.Team, error)
+	ArchiveTeam(teamID string, userID string) error
+
+	SaveTeamMember(member *model.TeamMember) (*model.TeamMember, error)
+	GetTeamMember(teamID, userID string) (*model.TeamMember, error)
+	GetTeamMembers(teamID string) ([]*model.TeamMember, error)
+	DeleteTeamMember(teamID, userID string) error
+
+	CreateTeamInvite(invite *model.TeamInvite) (*model.TeamInvite, error)
+	GetTeamInvite(inviteID string) (*model.TeamInvite, error)
+	GetTeamInvites(teamID string) ([]*model.TeamInvite, error)
+	// @withTransaction
+	AcceptTeamInvite(inviteID string, userID string) (*model.TeamMember, error)
+	DeleteTeamInvite(inviteID string) error
 
 	InsertBoard(board *model.Board, userID string) (*model.Board, error)
 	// @withTransaction
@@ -95,6 +164,7 @@ type Store interface {
 	GetBoard(id string) (*model.Board, error)
 	GetBoardsForUserAndTeam(userID, teamID string, includePublicBoards bool) ([]*model.Board, error)
 	GetBoardsInTeamByIds(boardIDs []string, teamID string) ([]*model.Board, error)
+	GetBoardsWithAutomations() ([]*model.Board, error)
 	// @withTransaction
 	DeleteBoard(boardID, userID string) error
 
@@ -102,6 +172,9 @@ type Store interface {
 	DeleteMember(boardID, userID string) error
 	GetMemberForBoard(boardID, userID string) (*model.BoardMember, error)
 	GetBoardMemberHistory(boardID, userID string, limit uint64) ([]*model.BoardMemberHistoryEntry, error)
+	GetBoardsAdministeredByUser(userID string) ([]*model.Board, error)
+	// @withTransaction
+	TransferBoardOwnership(boardID, fromUserID, toUserID string) (*model.BoardMember, error)
 	GetMembersForBoard(boardID string) ([]*model.BoardMember, error)
 	GetMembersForUser(userID string) ([]*model.BoardMember, error)
 	CanSeeUser(seerID string, seenID string) (bool, error)
@@ -149,6 +222,26 @@ type Store interface {
 	GetNotificationHint(blockID string) (*model.NotificationHint, error)
 	GetNextNotificationHint(remove bool) (*model.NotificationHint, error)
 
+	UpsertCardRecurrence(recurrence *model.CardRecurrence) (*model.CardRecurrence, error)
+	GetCardRecurrence(cardID string) (*model.CardRecurrence, error)
+	GetDueCardRecurrences(now int64, limit uint64) ([]*model.CardRecurrence, error)
+	ClaimCardRecurrenceRun(cardID string, prevNextRunAt int64, nextRunAt int64) (bool, error)
+	DeleteCardRecurrence(cardID string) error
+
+	CreateBoardSnapshot(snapshot *model.BoardSnapshot) (*model.BoardSnapshot, error)
+	GetBoardSnapshot(snapshotID string) (*model.BoardSnapshot, error)
+	GetBoardSnapshots(boardID string) ([]*model.BoardSnapshot, error)
+	DeleteBoardSnapshot(snapshotID string) error
+	// @withTransaction
+	RestoreBoard(board *model.Board, blocks []*model.Block, deletedBlockIDs []string, userID string) error
+
+	CreateCustomBoardRole(role *model.CustomBoardRole) (*model.CustomBoardRole, error)
+	UpdateCustomBoardRole(role *model.CustomBoardRole) (*model.CustomBoardRole, error)
+	GetCustomBoardRole(roleID string) (*model.CustomBoardRole, error)
+	GetCustomBoardRolesForTeam(teamID string) ([]*model.CustomBoardRole, error)
+	// @withTransaction
+	DeleteCustomBoardRole(roleID string) error
+
 	RemoveDefaultTemplates(boards []*model.Board) error
 	GetTemplateBoards(teamID, userID string) ([]*model.Board, error)
 
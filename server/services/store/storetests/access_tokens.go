@@ -0,0 +1,155 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestAccessTokensStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateAndGetAccessTokens", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateAndGetAccessTokens(t, store)
+	})
+	t.Run("UpdateAccessTokenLastUsed", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testUpdateAccessTokenLastUsed(t, store)
+	})
+	t.Run("DeleteAccessToken", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteAccessToken(t, store)
+	})
+	t.Run("DeleteAccessTokensForUser", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteAccessTokensForUser(t, store)
+	})
+}
+
+func createTestAccessToken(t *testing.T, store store.Store, userID, rawToken string) *model.AccessToken {
+	token, err := store.CreateAccessToken(&model.AccessToken{
+		UserID:    userID,
+		Name:      "token " + rawToken,
+		TokenHash: model.HashToken(rawToken),
+		Scopes:    []string{model.AccessTokenScopeRead},
+	})
+	require.NoError(t, err)
+	return token
+}
+
+func testCreateAndGetAccessTokens(t *testing.T, store store.Store) {
+	userID := utils.NewID(utils.IDTypeUser)
+
+	t.Run("invalid token", func(t *testing.T) {
+		token, err := store.CreateAccessToken(&model.AccessToken{})
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, token)
+
+		token, err = store.CreateAccessToken(&model.AccessToken{
+			UserID: userID,
+			Name:   "no hash",
+			Scopes: []string{model.AccessTokenScopeRead},
+		})
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, token)
+	})
+
+	token1 := createTestAccessToken(t, store, userID, "fbpat_token1")
+	require.NotEmpty(t, token1.ID)
+	require.NotZero(t, token1.CreateAt)
+	require.Zero(t, token1.LastUsedAt)
+
+	token2, err := store.CreateAccessToken(&model.AccessToken{
+		UserID:    userID,
+		Name:      "token 2",
+		TokenHash: model.HashToken("fbpat_token2"),
+		Scopes:    []string{model.AccessTokenScopeBoardsWrite, model.AccessTokenScopeAdmin},
+		ExpireAt:  utils.GetMillis() + 1000,
+	})
+	require.NoError(t, err)
+
+	createTestAccessToken(t, store, utils.NewID(utils.IDTypeUser), "fbpat_token3")
+
+	t.Run("GetAccessToken", func(t *testing.T) {
+		got, err := store.GetAccessToken(token2.ID)
+		require.NoError(t, err)
+		require.Equal(t, token2, got)
+
+		_, err = store.GetAccessToken("nonexistent")
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("GetAccessTokenByHash", func(t *testing.T) {
+		got, err := store.GetAccessTokenByHash(model.HashToken("fbpat_token1"))
+		require.NoError(t, err)
+		require.Equal(t, token1.ID, got.ID)
+
+		_, err = store.GetAccessTokenByHash(model.HashToken("fbpat_unknown"))
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("GetAccessTokensForUser", func(t *testing.T) {
+		tokens, err := store.GetAccessTokensForUser(userID)
+		require.NoError(t, err)
+		require.Len(t, tokens, 2)
+
+		tokens, err = store.GetAccessTokensForUser("nonexistent")
+		require.NoError(t, err)
+		require.Empty(t, tokens)
+	})
+}
+
+func testUpdateAccessTokenLastUsed(t *testing.T, store store.Store) {
+	token := createTestAccessToken(t, store, utils.NewID(utils.IDTypeUser), "fbpat_token")
+
+	usedAt := utils.GetMillis()
+	require.NoError(t, store.UpdateAccessTokenLastUsed(token.ID, usedAt))
+
+	got, err := store.GetAccessToken(token.ID)
+	require.NoError(t, err)
+	require.Equal(t, usedAt, got.LastUsedAt)
+
+	err = store.UpdateAccessTokenLastUsed("nonexistent", usedAt)
+	require.True(t, model.IsErrNotFound(err))
+}
+
+func testDeleteAccessToken(t *testing.T, store store.Store) {
+	token := createTestAccessToken(t, store, utils.NewID(utils.IDTypeUser), "fbpat_token")
+
+	require.NoError(t, store.DeleteAccessToken(token.ID))
+
+	_, err := store.GetAccessToken(token.ID)
+	require.True(t, model.IsErrNotFound(err))
+
+	err = store.DeleteAccessToken(token.ID)
+	require.True(t, model.IsErrNotFound(err))
+}
+
+func testDeleteAccessTokensForUser(t *testing.T, store store.Store) {
+	userID := utils.NewID(utils.IDTypeUser)
+	createTestAccessToken(t, store, userID, "fbpat_token1")
+	createTestAccessToken(t, store, userID, "fbpat_token2")
+	other := createTestAccessToken(t, store, utils.NewID(utils.IDTypeUser), "fbpat_token3")
+
+	require.NoError(t, store.DeleteAccessTokensForUser(userID))
+
+	tokens, err := store.GetAccessTokensForUser(userID)
+	require.NoError(t, err)
+	require.Empty(t, tokens)
+
+	_, err = store.GetAccessToken(other.ID)
+	require.NoError(t, err)
+
+	// deleting the tokens of a user without tokens isn't an error
+	require.NoError(t, store.DeleteAccessTokensForUser(userID))
+}
@@ -47,6 +47,11 @@ func StoreTestBlocksStore(t *testing.T, setup func(t *testing.T) (store.Store, f
 		defer tearDown()
 		testDeleteBlock(t, store)
 	})
+	t.Run("DeleteBlocks", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteBlocks(t, store)
+	})
 	t.Run("UndeleteBlock", func(t *testing.T) {
 		store, tearDown := setup(t)
 		defer tearDown()
@@ -62,6 +67,11 @@ func StoreTestBlocksStore(t *testing.T, setup func(t *testing.T) (store.Store, f
 		defer tearDown()
 		testGetBlocks(t, store)
 	})
+	t.Run("GetBlocksExcludeArchived", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBlocksExcludeArchived(t, store)
+	})
 	t.Run("GetBlock", func(t *testing.T) {
 		store, tearDown := setup(t)
 		defer tearDown()
@@ -87,6 +97,16 @@ func StoreTestBlocksStore(t *testing.T, setup func(t *testing.T) (store.Store, f
 		defer tearDown()
 		testGetBlockHistoryNewestChildren(t, store)
 	})
+	t.Run("GetBlockHistoryWithChildren", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBlockHistoryWithChildren(t, store)
+	})
+	t.Run("RevertBlocks", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testRevertBlocks(t, store)
+	})
 }
 
 func testInsertBlock(t *testing.T, store store.Store) {
@@ -1347,3 +1367,175 @@ func testGetBlockHistoryNewestChildren(t *testing.T, store store.Store) {
 		}
 	})
 }
+
+func testGetBlocksExcludeArchived(t *testing.T, store store.Store) {
+	boardID := testBoardID
+
+	blocksToInsert := []*model.Block{
+		{
+			ID:         "card1",
+			BoardID:    boardID,
+			ParentID:   boardID,
+			ModifiedBy: testUserID,
+			Type:       model.TypeCard,
+			Fields:     map[string]any{"icon": "x"},
+		},
+		{
+			ID:         "card2",
+			BoardID:    boardID,
+			ParentID:   boardID,
+			ModifiedBy: testUserID,
+			Type:       model.TypeCard,
+			Fields:     map[string]any{"archivedAt": utils.GetMillis()},
+		},
+		{
+			ID:         "card3",
+			BoardID:    boardID,
+			ParentID:   boardID,
+			ModifiedBy: testUserID,
+			Type:       model.TypeCard,
+		},
+	}
+	InsertBlocks(t, store, blocksToInsert, testUserID)
+	defer DeleteBlocks(t, store, blocksToInsert, "test")
+
+	opts := model.QueryBlocksOptions{
+		BoardID:   boardID,
+		BlockType: model.TypeCard,
+	}
+
+	t.Run("archived cards included by default", func(t *testing.T) {
+		blocks, err := store.GetBlocks(opts)
+		require.NoError(t, err)
+		require.Len(t, blocks, 3)
+	})
+
+	t.Run("archived cards excluded", func(t *testing.T) {
+		opts.ExcludeArchived = true
+		blocks, err := store.GetBlocks(opts)
+		require.NoError(t, err)
+		require.Len(t, blocks, 2)
+		require.ElementsMatch(t, []string{"card1", "card3"}, []string{blocks[0].ID, blocks[1].ID})
+	})
+
+	t.Run("unarchived card is returned", func(t *testing.T) {
+		err := store.PatchBlock("card2", &model.BlockPatch{DeletedFields: []string{"archivedAt"}}, testUserID)
+		require.NoError(t, err)
+
+		opts.ExcludeArchived = true
+		blocks, err := store.GetBlocks(opts)
+		require.NoError(t, err)
+		require.Len(t, blocks, 3)
+	})
+}
+
+func testDeleteBlocks(t *testing.T, store store.Store) {
+	boardID := testBoardID
+
+	blocksToInsert := []*model.Block{
+		{ID: "card1", BoardID: boardID, ParentID: boardID, ModifiedBy: testUserID, Type: model.TypeCard},
+		{ID: "card2", BoardID: boardID, ParentID: boardID, ModifiedBy: testUserID, Type: model.TypeCard},
+		{ID: "card3", BoardID: boardID, ParentID: boardID, ModifiedBy: testUserID, Type: model.TypeCard},
+		{ID: "text1", BoardID: boardID, ParentID: "card1", ModifiedBy: testUserID, Type: model.TypeText},
+	}
+	InsertBlocks(t, store, blocksToInsert, testUserID)
+	defer DeleteBlocks(t, store, blocksToInsert, "test")
+
+	t.Run("delete blocks with their children", func(t *testing.T) {
+		err := store.DeleteBlocks([]string{"card1", "card2"}, testUserID)
+		require.NoError(t, err)
+
+		blocks, err := store.GetBlocksForBoard(boardID)
+		require.NoError(t, err)
+		require.Len(t, blocks, 1)
+		require.Equal(t, "card3", blocks[0].ID)
+	})
+
+	t.Run("not existing block", func(t *testing.T) {
+		err := store.DeleteBlocks([]string{"card3", "not-exists"}, testUserID)
+		require.NoError(t, err)
+	})
+}
+
+func testGetBlockHistoryWithChildren(t *testing.T, store store.Store) {
+	board := createTestBoards(t, store, testTeamID, testUserID, 1)[0]
+	cards := createTestCards(t, store, testUserID, board.ID, 2)
+	card := cards[0]
+	content := createTestBlocksForCard(t, store, card.ID, 3)
+	createTestBlocksForCard(t, store, cards[1].ID, 2)
+
+	time.Sleep(10 * time.Millisecond)
+	title := "changed"
+	err := store.PatchBlock(content[0].ID, &model.BlockPatch{Title: &title}, testUserID)
+	require.NoError(t, err)
+
+	t.Run("invalid card", func(t *testing.T) {
+		blocks, err := store.GetBlockHistoryWithChildren(utils.NewID(utils.IDTypeCard), model.QueryBlockHistoryOptions{})
+		require.NoError(t, err)
+		require.Empty(t, blocks)
+	})
+
+	t.Run("card with children", func(t *testing.T) {
+		blocks, err := store.GetBlockHistoryWithChildren(card.ID, model.QueryBlockHistoryOptions{})
+		require.NoError(t, err)
+		// one record for the card, one for each content block and one for the patch
+		require.Len(t, blocks, 5)
+		for _, block := range blocks {
+			require.True(t, block.ID == card.ID || block.ParentID == card.ID)
+		}
+	})
+
+	t.Run("descending with limit", func(t *testing.T) {
+		opts := model.QueryBlockHistoryOptions{Descending: true, Limit: 1}
+		blocks, err := store.GetBlockHistoryWithChildren(card.ID, opts)
+		require.NoError(t, err)
+		require.Len(t, blocks, 1)
+		require.Equal(t, content[0].ID, blocks[0].ID)
+		require.Equal(t, title, blocks[0].Title)
+	})
+}
+
+func testRevertBlocks(t *testing.T, store store.Store) {
+	boardID := testBoardID
+
+	blocksToInsert := []*model.Block{
+		{ID: "card1", BoardID: boardID, ParentID: boardID, ModifiedBy: testUserID, Type: model.TypeCard, Title: "old"},
+		{ID: "text1", BoardID: boardID, ParentID: "card1", ModifiedBy: testUserID, Type: model.TypeText},
+		{ID: "text2", BoardID: boardID, ParentID: "card1", ModifiedBy: testUserID, Type: model.TypeText},
+	}
+	InsertBlocks(t, store, blocksToInsert, testUserID)
+	defer DeleteBlocks(t, store, blocksToInsert, "test")
+
+	t.Run("restore and delete blocks", func(t *testing.T) {
+		err := store.DeleteBlock("text1", testUserID)
+		require.NoError(t, err)
+
+		restored := []*model.Block{
+			{ID: "card1", BoardID: boardID, ParentID: boardID, Type: model.TypeCard, Title: "reverted"},
+			{ID: "text1", BoardID: boardID, ParentID: "card1", Type: model.TypeText, Title: "restored"},
+		}
+		err = store.RevertBlocks(restored, []string{"text2"}, "other-user")
+		require.NoError(t, err)
+
+		card, err := store.GetBlock("card1")
+		require.NoError(t, err)
+		require.Equal(t, "reverted", card.Title)
+		require.Equal(t, "other-user", card.ModifiedBy)
+
+		text, err := store.GetBlock("text1")
+		require.NoError(t, err)
+		require.Equal(t, "restored", text.Title)
+
+		_, err = store.GetBlock("text2")
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("invalid block", func(t *testing.T) {
+		err := store.RevertBlocks([]*model.Block{{ID: "card1", BoardID: ""}}, nil, testUserID)
+		require.Error(t, err)
+
+		card, err := store.GetBlock("card1")
+		require.NoError(t, err)
+		require.Equal(t, "reverted", card.Title)
+	})
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func StoreTestBoardSnapshotsStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateBoardSnapshot", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateBoardSnapshot(t, store)
+	})
+
+	t.Run("GetBoardSnapshots", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBoardSnapshots(t, store)
+	})
+
+	t.Run("DeleteBoardSnapshot", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteBoardSnapshot(t, store)
+	})
+
+	t.Run("RestoreBoard", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testRestoreBoard(t, store)
+	})
+}
+
+func newTestBoardSnapshot(boardID, name string) *model.BoardSnapshot {
+	return &model.BoardSnapshot{
+		BoardID:   boardID,
+		Name:      name,
+		Type:      model.BoardSnapshotManual,
+		CreatedBy: testUserID,
+	}
+}
+
+func testCreateBoardSnapshot(t *testing.T, store store.Store) {
+	t.Run("valid snapshot", func(t *testing.T) {
+		before := utils.GetMillis()
+		snapshot, err := store.CreateBoardSnapshot(newTestBoardSnapshot(testBoardID, "snapshot"))
+		require.NoError(t, err)
+		require.NotEmpty(t, snapshot.ID)
+		require.GreaterOrEqual(t, snapshot.CreateAt, before)
+
+		fetched, err := store.GetBoardSnapshot(snapshot.ID)
+		require.NoError(t, err)
+		require.Equal(t, snapshot, fetched)
+	})
+
+	t.Run("invalid snapshot", func(t *testing.T) {
+		snapshot, err := store.CreateBoardSnapshot(newTestBoardSnapshot(testBoardID, ""))
+		require.Error(t, err)
+		require.Nil(t, snapshot)
+	})
+
+	t.Run("not existing snapshot", func(t *testing.T) {
+		snapshot, err := store.GetBoardSnapshot(utils.NewID(utils.IDTypeNone))
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, snapshot)
+	})
+}
+
+func testGetBoardSnapshots(t *testing.T, store store.Store) {
+	first, err := store.CreateBoardSnapshot(newTestBoardSnapshot(testBoardID, "first"))
+	require.NoError(t, err)
+	time.Sleep(5 * time.Millisecond)
+	second, err := store.CreateBoardSnapshot(newTestBoardSnapshot(testBoardID, "second"))
+	require.NoError(t, err)
+	_, err = store.CreateBoardSnapshot(newTestBoardSnapshot("other-board", "other"))
+	require.NoError(t, err)
+
+	snapshots, err := store.GetBoardSnapshots(testBoardID)
+	require.NoError(t, err)
+	require.Equal(t, []*model.BoardSnapshot{second, first}, snapshots)
+
+	snapshots, err = store.GetBoardSnapshots("no-board")
+	require.NoError(t, err)
+	require.Empty(t, snapshots)
+}
+
+func testDeleteBoardSnapshot(t *testing.T, store store.Store) {
+	snapshot, err := store.CreateBoardSnapshot(newTestBoardSnapshot(testBoardID, "snapshot"))
+	require.NoError(t, err)
+
+	err = store.DeleteBoardSnapshot(snapshot.ID)
+	require.NoError(t, err)
+
+	_, err = store.GetBoardSnapshot(snapshot.ID)
+	require.True(t, model.IsErrNotFound(err))
+
+	err = store.DeleteBoardSnapshot(snapshot.ID)
+	require.True(t, model.IsErrNotFound(err))
+}
+
+func testRestoreBoard(t *testing.T, store store.Store) {
+	board := createTestBoards(t, store, testTeamID, testUserID, 1)[0]
+	cards := createTestCards(t, store, testUserID, board.ID, 2)
+
+	restoredBoard := *board
+	restoredBoard.Title = "restored title"
+	restoredCard := *cards[0]
+	restoredCard.Title = "restored card"
+
+	err := store.RestoreBoard(&restoredBoard, []*model.Block{&restoredCard}, []string{cards[1].ID}, "other-user")
+	require.NoError(t, err)
+
+	fetchedBoard, err := store.GetBoard(board.ID)
+	require.NoError(t, err)
+	require.Equal(t, "restored title", fetchedBoard.Title)
+	require.Equal(t, "other-user", fetchedBoard.ModifiedBy)
+
+	blocks, err := store.GetBlocksForBoard(board.ID)
+	require.NoError(t, err)
+	require.Len(t, blocks, 1)
+	require.Equal(t, "restored card", blocks[0].Title)
+}
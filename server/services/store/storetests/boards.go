@@ -27,6 +27,11 @@ func StoreTestBoardStore(t *testing.T, setup func(t *testing.T) (store.Store, fu
 		defer tearDown()
 		testGetBoardsInTeamByIds(t, store)
 	})
+	t.Run("GetBoardsWithAutomations", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBoardsWithAutomations(t, store)
+	})
 	t.Run("InsertBoard", func(t *testing.T) {
 		store, tearDown := setup(t)
 		defer tearDown()
@@ -72,6 +77,16 @@ func StoreTestBoardStore(t *testing.T, setup func(t *testing.T) (store.Store, fu
 		defer tearDown()
 		testGetMembersForUser(t, store)
 	})
+	t.Run("GetBoardsAdministeredByUser", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBoardsAdministeredByUser(t, store)
+	})
+	t.Run("TransferBoardOwnership", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testTransferBoardOwnership(t, store)
+	})
 	t.Run("DeleteMember", func(t *testing.T) {
 		store, tearDown := setup(t)
 		defer tearDown()
@@ -223,6 +238,27 @@ func testGetBoardsForUserAndTeam(t *testing.T, store store.Store) {
 	})
 }
 
+func testGetBoardsWithAutomations(t *testing.T, store store.Store) {
+	rules := []*model.AutomationRule{{ID: "rule-id", Name: "rule"}}
+	boards := []*model.Board{
+		{ID: "board-with-rules", TeamID: testTeamID, Type: model.BoardTypeOpen,
+			Properties: map[string]interface{}{model.BoardPropertyAutomations: rules}},
+		{ID: "template-with-rules", TeamID: testTeamID, Type: model.BoardTypeOpen, IsTemplate: true,
+			Properties: map[string]interface{}{model.BoardPropertyAutomations: rules}},
+		{ID: "board-without-rules", TeamID: testTeamID, Type: model.BoardTypeOpen,
+			Properties: map[string]interface{}{"other": "value"}},
+	}
+	for _, board := range boards {
+		_, err := store.InsertBoard(board, testUserID)
+		require.NoError(t, err)
+	}
+
+	found, err := store.GetBoardsWithAutomations()
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, "board-with-rules", found[0].ID)
+}
+
 func testGetBoardsInTeamByIds(t *testing.T, store store.Store) {
 	t.Run("should return err not all found if one or more of the ids are not found", func(t *testing.T) {
 		for _, boardID := range []string{"board-id-1", "board-id-2"} {
@@ -1199,3 +1235,104 @@ func testGetBoardCount(t *testing.T, store store.Store) {
 		require.Equal(t, originalCount+1, newCount)
 	})
 }
+
+func testGetBoardsAdministeredByUser(t *testing.T, store store.Store) {
+	userID := testUserID
+	otherUserID := "other-user-id"
+
+	created, err := store.InsertBoard(&model.Board{ID: "created-board", TeamID: testTeamID, Type: model.BoardTypeOpen}, userID)
+	require.NoError(t, err)
+
+	administered, _, err := store.InsertBoardWithAdmin(&model.Board{ID: "administered-template", TeamID: testTeamID, Type: model.BoardTypeOpen, IsTemplate: true}, otherUserID)
+	require.NoError(t, err)
+	_, err = store.SaveMember(&model.BoardMember{BoardID: administered.ID, UserID: userID, SchemeAdmin: true})
+	require.NoError(t, err)
+
+	edited, _, err := store.InsertBoardWithAdmin(&model.Board{ID: "edited-board", TeamID: testTeamID, Type: model.BoardTypeOpen}, otherUserID)
+	require.NoError(t, err)
+	_, err = store.SaveMember(&model.BoardMember{BoardID: edited.ID, UserID: userID, SchemeEditor: true})
+	require.NoError(t, err)
+
+	t.Run("should return created and administered boards and templates", func(t *testing.T) {
+		boards, err := store.GetBoardsAdministeredByUser(userID)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{created.ID, administered.ID}, boardIDs(boards))
+	})
+
+	t.Run("should return an empty list for a user without boards", func(t *testing.T) {
+		boards, err := store.GetBoardsAdministeredByUser("nonexistent-user")
+		require.NoError(t, err)
+		require.Empty(t, boards)
+	})
+}
+
+func testTransferBoardOwnership(t *testing.T, store store.Store) {
+	fromUserID := testUserID
+	toUserID := "to-user-id"
+
+	historyActions := func(t *testing.T, boardID, userID string) []string {
+		history, err := store.GetBoardMemberHistory(boardID, userID, 0)
+		require.NoError(t, err)
+		actions := make([]string, 0, len(history))
+		for _, entry := range history {
+			actions = append(actions, entry.Action)
+		}
+		return actions
+	}
+
+	t.Run("should transfer a board to a new member", func(t *testing.T) {
+		board, _, err := store.InsertBoardWithAdmin(&model.Board{ID: utils.NewID(utils.IDTypeBoard), TeamID: testTeamID, Type: model.BoardTypeOpen}, fromUserID)
+		require.NoError(t, err)
+
+		member, err := store.TransferBoardOwnership(board.ID, fromUserID, toUserID)
+		require.NoError(t, err)
+		require.Equal(t, toUserID, member.UserID)
+		require.True(t, member.SchemeAdmin)
+
+		rBoard, err := store.GetBoard(board.ID)
+		require.NoError(t, err)
+		require.Equal(t, toUserID, rBoard.CreatedBy)
+
+		fromMember, err := store.GetMemberForBoard(board.ID, fromUserID)
+		require.NoError(t, err)
+		require.False(t, fromMember.SchemeAdmin)
+		require.True(t, fromMember.SchemeEditor)
+
+		require.Contains(t, historyActions(t, board.ID, fromUserID), model.BoardMemberHistoryDemoted)
+		require.Equal(t, []string{model.BoardMemberHistoryCreated}, historyActions(t, board.ID, toUserID))
+	})
+
+	t.Run("should promote an existing member and keep the creator", func(t *testing.T) {
+		board, _, err := store.InsertBoardWithAdmin(&model.Board{ID: utils.NewID(utils.IDTypeBoard), TeamID: testTeamID, Type: model.BoardTypeOpen}, "creator-id")
+		require.NoError(t, err)
+		_, err = store.SaveMember(&model.BoardMember{BoardID: board.ID, UserID: fromUserID, SchemeAdmin: true})
+		require.NoError(t, err)
+		_, err = store.SaveMember(&model.BoardMember{BoardID: board.ID, UserID: toUserID, SchemeViewer: true})
+		require.NoError(t, err)
+
+		member, err := store.TransferBoardOwnership(board.ID, fromUserID, toUserID)
+		require.NoError(t, err)
+		require.True(t, member.SchemeAdmin)
+		require.True(t, member.SchemeEditor)
+
+		rBoard, err := store.GetBoard(board.ID)
+		require.NoError(t, err)
+		require.Equal(t, "creator-id", rBoard.CreatedBy)
+
+		require.Contains(t, historyActions(t, board.ID, toUserID), model.BoardMemberHistoryPromoted)
+	})
+
+	t.Run("should fail for a nonexistent board", func(t *testing.T) {
+		member, err := store.TransferBoardOwnership("nonexistent-board", fromUserID, toUserID)
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, member)
+	})
+}
+
+func boardIDs(boards []*model.Board) []string {
+	ids := make([]string, 0, len(boards))
+	for _, board := range boards {
+		ids = append(ids, board.ID)
+	}
+	return ids
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func StoreTestCustomBoardRolesStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateCustomBoardRole", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateCustomBoardRole(t, store)
+	})
+
+	t.Run("UpdateCustomBoardRole", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testUpdateCustomBoardRole(t, store)
+	})
+
+	t.Run("GetCustomBoardRolesForTeam", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetCustomBoardRolesForTeam(t, store)
+	})
+
+	t.Run("DeleteCustomBoardRole", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteCustomBoardRole(t, store)
+	})
+}
+
+func newTestCustomBoardRole(teamID, name string) *model.CustomBoardRole {
+	return &model.CustomBoardRole{
+		TeamID:      teamID,
+		Name:        name,
+		Description: "a custom role",
+		Permissions: []string{model.PermissionViewBoard.Id, model.PermissionManageBoardCards.Id},
+		CreatedBy:   testUserID,
+	}
+}
+
+func testCreateCustomBoardRole(t *testing.T, store store.Store) {
+	t.Run("valid role", func(t *testing.T) {
+		before := utils.GetMillis()
+		role, err := store.CreateCustomBoardRole(newTestCustomBoardRole(testTeamID, "card editor"))
+		require.NoError(t, err)
+		require.NotEmpty(t, role.ID)
+		require.GreaterOrEqual(t, role.CreateAt, before)
+		require.Equal(t, role.CreateAt, role.UpdateAt)
+
+		fetched, err := store.GetCustomBoardRole(role.ID)
+		require.NoError(t, err)
+		require.Equal(t, role, fetched)
+	})
+
+	t.Run("invalid role", func(t *testing.T) {
+		role := newTestCustomBoardRole(testTeamID, "invalid")
+		role.Permissions = []string{"not_a_permission"}
+
+		created, err := store.CreateCustomBoardRole(role)
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, created)
+	})
+
+	t.Run("not existing role", func(t *testing.T) {
+		role, err := store.GetCustomBoardRole(utils.NewID(utils.IDTypeNone))
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, role)
+	})
+}
+
+func testUpdateCustomBoardRole(t *testing.T, store store.Store) {
+	role, err := store.CreateCustomBoardRole(newTestCustomBoardRole(testTeamID, "card editor"))
+	require.NoError(t, err)
+
+	role.Name = "commenter plus"
+	role.Permissions = []string{model.PermissionCommentBoardCards.Id}
+	updated, err := store.UpdateCustomBoardRole(role)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, updated.UpdateAt, role.CreateAt)
+
+	fetched, err := store.GetCustomBoardRole(role.ID)
+	require.NoError(t, err)
+	require.Equal(t, "commenter plus", fetched.Name)
+	require.Equal(t, []string{model.PermissionCommentBoardCards.Id}, fetched.Permissions)
+
+	missing := newTestCustomBoardRole(testTeamID, "missing")
+	missing.ID = utils.NewID(utils.IDTypeNone)
+	_, err = store.UpdateCustomBoardRole(missing)
+	require.True(t, model.IsErrNotFound(err))
+}
+
+func testGetCustomBoardRolesForTeam(t *testing.T, store store.Store) {
+	second, err := store.CreateCustomBoardRole(newTestCustomBoardRole(testTeamID, "b role"))
+	require.NoError(t, err)
+	first, err := store.CreateCustomBoardRole(newTestCustomBoardRole(testTeamID, "a role"))
+	require.NoError(t, err)
+	_, err = store.CreateCustomBoardRole(newTestCustomBoardRole("other-team", "other role"))
+	require.NoError(t, err)
+
+	roles, err := store.GetCustomBoardRolesForTeam(testTeamID)
+	require.NoError(t, err)
+	require.Equal(t, []*model.CustomBoardRole{first, second}, roles)
+
+	roles, err = store.GetCustomBoardRolesForTeam("no-team")
+	require.NoError(t, err)
+	require.Empty(t, roles)
+}
+
+func testDeleteCustomBoardRole(t *testing.T, store store.Store) {
+	role, err := store.CreateCustomBoardRole(newTestCustomBoardRole(testTeamID, "card editor"))
+	require.NoError(t, err)
+
+	member, err := store.SaveMember(&model.BoardMember{
+		BoardID:         testBoardID,
+		UserID:          testUserID,
+		SchemeCommenter: true,
+		CustomRoleID:    role.ID,
+	})
+	require.NoError(t, err)
+
+	fetchedMember, err := store.GetMemberForBoard(member.BoardID, member.UserID)
+	require.NoError(t, err)
+	require.Equal(t, role.ID, fetchedMember.CustomRoleID)
+
+	err = store.DeleteCustomBoardRole(role.ID)
+	require.NoError(t, err)
+
+	_, err = store.GetCustomBoardRole(role.ID)
+	require.True(t, model.IsErrNotFound(err))
+
+	// the members that had the role are left with their scheme role
+	fetchedMember, err = store.GetMemberForBoard(member.BoardID, member.UserID)
+	require.NoError(t, err)
+	require.Empty(t, fetchedMember.CustomRoleID)
+	require.True(t, fetchedMember.SchemeCommenter)
+
+	err = store.DeleteCustomBoardRole(role.ID)
+	require.True(t, model.IsErrNotFound(err))
+}
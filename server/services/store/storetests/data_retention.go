@@ -92,6 +92,12 @@ func LoadData(t *testing.T, store store.Store) {
 	err = store.UpsertSharing(sharing)
 	require.NoError(t, err)
 
+	_, err = store.CreateShareLink(&model.ShareLink{
+		BoardID:   boardID,
+		CreatedBy: testUserID,
+	})
+	require.NoError(t, err)
+
 	err = store.AddUpdateCategoryBoard(testUserID, categoryID, []string{boardID})
 	require.NoError(t, err)
 }
@@ -132,6 +138,10 @@ func testRunDataRetention(t *testing.T, store store.Store, batchSize int) {
 		require.True(t, model.IsErrNotFound(err), err)
 		require.Nil(t, sharing)
 
+		links, err := store.GetShareLinksForBoard(boardID)
+		require.NoError(t, err)
+		require.Empty(t, links)
+
 		category, err := store.GetUserCategoryBoards(boardID, testTeamID)
 		require.NoError(t, err)
 		require.Empty(t, category)
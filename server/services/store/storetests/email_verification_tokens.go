@@ -0,0 +1,79 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestEmailVerificationTokensStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateAndConsumeEmailVerificationToken", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateAndConsumeEmailVerificationToken(t, store)
+	})
+	t.Run("DeleteEmailVerificationTokensForUser", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteEmailVerificationTokensForUser(t, store)
+	})
+}
+
+func createTestEmailVerificationToken(t *testing.T, store store.Store, userID, rawToken string) *model.EmailVerificationToken {
+	token, err := store.CreateEmailVerificationToken(&model.EmailVerificationToken{
+		UserID:    userID,
+		Email:     "Someone@Example.com",
+		TokenHash: model.HashToken(rawToken),
+		ExpireAt:  utils.GetMillis() + 60*1000,
+	})
+	require.NoError(t, err)
+	return token
+}
+
+func testCreateAndConsumeEmailVerificationToken(t *testing.T, store store.Store) {
+	t.Run("invalid token", func(t *testing.T) {
+		token, err := store.CreateEmailVerificationToken(&model.EmailVerificationToken{UserID: "user-id"})
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, token)
+	})
+
+	token := createTestEmailVerificationToken(t, store, "user-id", "raw-token")
+	require.NotEmpty(t, token.ID)
+	require.NotZero(t, token.CreateAt)
+	require.Equal(t, "someone@example.com", token.Email)
+
+	got, err := store.ConsumeEmailVerificationToken(model.HashToken("raw-token"))
+	require.NoError(t, err)
+	require.Equal(t, token, got)
+
+	// tokens can only be used once
+	_, err = store.ConsumeEmailVerificationToken(model.HashToken("raw-token"))
+	require.True(t, model.IsErrNotFound(err))
+
+	_, err = store.ConsumeEmailVerificationToken(model.HashToken("unknown"))
+	require.True(t, model.IsErrNotFound(err))
+}
+
+func testDeleteEmailVerificationTokensForUser(t *testing.T, store store.Store) {
+	createTestEmailVerificationToken(t, store, "user-1", "token-1")
+	createTestEmailVerificationToken(t, store, "user-1", "token-2")
+	createTestEmailVerificationToken(t, store, "user-2", "token-3")
+
+	require.NoError(t, store.DeleteEmailVerificationTokensForUser("user-1"))
+
+	_, err := store.ConsumeEmailVerificationToken(model.HashToken("token-1"))
+	require.True(t, model.IsErrNotFound(err))
+	_, err = store.ConsumeEmailVerificationToken(model.HashToken("token-2"))
+	require.True(t, model.IsErrNotFound(err))
+
+	got, err := store.ConsumeEmailVerificationToken(model.HashToken("token-3"))
+	require.NoError(t, err)
+	require.Equal(t, "user-2", got.UserID)
+}
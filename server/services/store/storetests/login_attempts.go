@@ -0,0 +1,94 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestLoginAttemptsStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("IncrementAndGetLoginAttempts", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testIncrementAndGetLoginAttempts(t, store)
+	})
+	t.Run("DeleteLoginAttempts", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteLoginAttempts(t, store)
+	})
+}
+
+func testIncrementAndGetLoginAttempts(t *testing.T, store store.Store) {
+	key := model.LoginAttemptsAccountKey("User1")
+	require.Equal(t, "account:user1", key)
+
+	_, err := store.GetLoginAttempts(key)
+	require.True(t, model.IsErrNotFound(err))
+
+	windowStart := utils.GetMillis() - 1000
+	attempts, err := store.IncrementLoginAttempts(key, windowStart)
+	require.NoError(t, err)
+	require.Equal(t, 1, attempts.FailedCount)
+	require.NotZero(t, attempts.LastFailureAt)
+
+	attempts, err = store.IncrementLoginAttempts(key, windowStart)
+	require.NoError(t, err)
+	require.Equal(t, 2, attempts.FailedCount)
+
+	got, err := store.GetLoginAttempts(key)
+	require.NoError(t, err)
+	require.Equal(t, attempts, got)
+
+	t.Run("the counters of other keys are separate", func(t *testing.T) {
+		attempts, err := store.IncrementLoginAttempts(model.LoginAttemptsIPKey("10.0.0.1"), windowStart)
+		require.NoError(t, err)
+		require.Equal(t, 1, attempts.FailedCount)
+	})
+
+	t.Run("counters start over after the window", func(t *testing.T) {
+		attempts, err := store.IncrementLoginAttempts(key, utils.GetMillis()+1000)
+		require.NoError(t, err)
+		require.Equal(t, 1, attempts.FailedCount)
+	})
+
+	t.Run("concurrent failures are all counted", func(t *testing.T) {
+		key := model.LoginAttemptsIPKey("10.0.0.2")
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := store.IncrementLoginAttempts(key, windowStart)
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		attempts, err := store.GetLoginAttempts(key)
+		require.NoError(t, err)
+		require.Equal(t, 10, attempts.FailedCount)
+	})
+}
+
+func testDeleteLoginAttempts(t *testing.T, store store.Store) {
+	key := model.LoginAttemptsIPKey("10.0.0.1")
+	_, err := store.IncrementLoginAttempts(key, 0)
+	require.NoError(t, err)
+
+	require.NoError(t, store.DeleteLoginAttempts(key))
+	_, err = store.GetLoginAttempts(key)
+	require.True(t, model.IsErrNotFound(err))
+
+	// deleting a missing counter isn't an error
+	require.NoError(t, store.DeleteLoginAttempts(key))
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestPasswordResetTokensStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateAndConsumePasswordResetToken", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateAndConsumePasswordResetToken(t, store)
+	})
+	t.Run("DeletePasswordResetTokensForUser", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeletePasswordResetTokensForUser(t, store)
+	})
+}
+
+func createTestPasswordResetToken(t *testing.T, store store.Store, userID, rawToken string) *model.PasswordResetToken {
+	token, err := store.CreatePasswordResetToken(&model.PasswordResetToken{
+		UserID:    userID,
+		TokenHash: model.HashToken(rawToken),
+		ExpireAt:  utils.GetMillis() + 60*1000,
+	})
+	require.NoError(t, err)
+	return token
+}
+
+func testCreateAndConsumePasswordResetToken(t *testing.T, store store.Store) {
+	t.Run("invalid token", func(t *testing.T) {
+		token, err := store.CreatePasswordResetToken(&model.PasswordResetToken{UserID: "user-id"})
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, token)
+	})
+
+	token := createTestPasswordResetToken(t, store, "user-id", "raw-token")
+	require.NotEmpty(t, token.ID)
+	require.NotZero(t, token.CreateAt)
+
+	got, err := store.ConsumePasswordResetToken(model.HashToken("raw-token"))
+	require.NoError(t, err)
+	require.Equal(t, token, got)
+
+	// tokens can only be used once
+	_, err = store.ConsumePasswordResetToken(model.HashToken("raw-token"))
+	require.True(t, model.IsErrNotFound(err))
+
+	_, err = store.ConsumePasswordResetToken(model.HashToken("unknown"))
+	require.True(t, model.IsErrNotFound(err))
+}
+
+func testDeletePasswordResetTokensForUser(t *testing.T, store store.Store) {
+	createTestPasswordResetToken(t, store, "user-1", "token-1")
+	createTestPasswordResetToken(t, store, "user-1", "token-2")
+	createTestPasswordResetToken(t, store, "user-2", "token-3")
+
+	require.NoError(t, store.DeletePasswordResetTokensForUser("user-1"))
+
+	_, err := store.ConsumePasswordResetToken(model.HashToken("token-1"))
+	require.True(t, model.IsErrNotFound(err))
+	_, err = store.ConsumePasswordResetToken(model.HashToken("token-2"))
+	require.True(t, model.IsErrNotFound(err))
+
+	got, err := store.ConsumePasswordResetToken(model.HashToken("token-3"))
+	require.NoError(t, err)
+	require.Equal(t, "user-2", got.UserID)
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func StoreTestCardRecurrencesStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("UpsertCardRecurrence", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testUpsertCardRecurrence(t, store)
+	})
+
+	t.Run("GetDueCardRecurrences", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetDueCardRecurrences(t, store)
+	})
+
+	t.Run("ClaimCardRecurrenceRun", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testClaimCardRecurrenceRun(t, store)
+	})
+
+	t.Run("DeleteCardRecurrence", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteCardRecurrence(t, store)
+	})
+}
+
+func newTestCardRecurrence(nextRunAt int64) *model.CardRecurrence {
+	return &model.CardRecurrence{
+		CardID:    utils.NewID(utils.IDTypeCard),
+		BoardID:   utils.NewID(utils.IDTypeBoard),
+		Rule:      "FREQ=DAILY",
+		NextRunAt: nextRunAt,
+		CreatedBy: testUserID,
+	}
+}
+
+func testUpsertCardRecurrence(t *testing.T, store store.Store) {
+	t.Run("create and get card recurrence", func(t *testing.T) {
+		recurrence := newTestCardRecurrence(utils.GetMillis())
+
+		recurrenceNew, err := store.UpsertCardRecurrence(recurrence)
+		require.NoError(t, err)
+		assert.Equal(t, recurrence.CardID, recurrenceNew.CardID)
+		assert.Equal(t, recurrence.Rule, recurrenceNew.Rule)
+		assert.NotZero(t, recurrenceNew.CreateAt)
+
+		recurrenceGet, err := store.GetCardRecurrence(recurrence.CardID)
+		require.NoError(t, err)
+		assert.Equal(t, recurrenceNew, recurrenceGet)
+	})
+
+	t.Run("replace card recurrence", func(t *testing.T) {
+		recurrence := newTestCardRecurrence(utils.GetMillis())
+		_, err := store.UpsertCardRecurrence(recurrence)
+		require.NoError(t, err)
+
+		recurrence.Rule = "FREQ=MONTHLY;BYMONTHDAY=1"
+		recurrence.DueDatePropertyID = "due-date"
+		recurrenceNew, err := store.UpsertCardRecurrence(recurrence)
+		require.NoError(t, err)
+		assert.Equal(t, "FREQ=MONTHLY;BYMONTHDAY=1", recurrenceNew.Rule)
+		assert.Equal(t, "due-date", recurrenceNew.DueDatePropertyID)
+	})
+
+	t.Run("invalid card recurrence", func(t *testing.T) {
+		recurrence := newTestCardRecurrence(utils.GetMillis())
+		recurrence.Rule = "FREQ=HOURLY"
+		_, err := store.UpsertCardRecurrence(recurrence)
+		require.Error(t, err)
+	})
+
+	t.Run("get missing card recurrence", func(t *testing.T) {
+		_, err := store.GetCardRecurrence(utils.NewID(utils.IDTypeCard))
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testGetDueCardRecurrences(t *testing.T, store store.Store) {
+	now := utils.GetMillis()
+
+	due1 := newTestCardRecurrence(now - 2000)
+	due2 := newTestCardRecurrence(now - 1000)
+	notDue := newTestCardRecurrence(now + 60000)
+	for _, recurrence := range []*model.CardRecurrence{due2, notDue, due1} {
+		_, err := store.UpsertCardRecurrence(recurrence)
+		require.NoError(t, err)
+	}
+
+	recurrences, err := store.GetDueCardRecurrences(now, 10)
+	require.NoError(t, err)
+	require.Len(t, recurrences, 2)
+	assert.Equal(t, due1.CardID, recurrences[0].CardID)
+	assert.Equal(t, due2.CardID, recurrences[1].CardID)
+
+	recurrences, err = store.GetDueCardRecurrences(now, 1)
+	require.NoError(t, err)
+	require.Len(t, recurrences, 1)
+}
+
+func testClaimCardRecurrenceRun(t *testing.T, store store.Store) {
+	now := utils.GetMillis()
+	recurrence := newTestCardRecurrence(now - 1000)
+	_, err := store.UpsertCardRecurrence(recurrence)
+	require.NoError(t, err)
+
+	claimed, err := store.ClaimCardRecurrenceRun(recurrence.CardID, recurrence.NextRunAt, now+60000)
+	require.NoError(t, err)
+	require.True(t, claimed)
+
+	// a second node racing for the same occurrence loses
+	claimed, err = store.ClaimCardRecurrenceRun(recurrence.CardID, recurrence.NextRunAt, now+60000)
+	require.NoError(t, err)
+	require.False(t, claimed)
+
+	recurrenceGet, err := store.GetCardRecurrence(recurrence.CardID)
+	require.NoError(t, err)
+	assert.Equal(t, now+60000, recurrenceGet.NextRunAt)
+	assert.NotZero(t, recurrenceGet.LastRunAt)
+}
+
+func testDeleteCardRecurrence(t *testing.T, store store.Store) {
+	recurrence := newTestCardRecurrence(utils.GetMillis())
+	_, err := store.UpsertCardRecurrence(recurrence)
+	require.NoError(t, err)
+
+	err = store.DeleteCardRecurrence(recurrence.CardID)
+	require.NoError(t, err)
+
+	_, err = store.GetCardRecurrence(recurrence.CardID)
+	require.True(t, model.IsErrNotFound(err))
+
+	err = store.DeleteCardRecurrence(recurrence.CardID)
+	require.True(t, model.IsErrNotFound(err))
+}
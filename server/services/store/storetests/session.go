@@ -28,6 +28,18 @@ func StoreTestSessionStore(t *testing.T, setup func(t *testing.T) (store.Store,
 		defer tearDown()
 		testUpdateSession(t, store)
 	})
+
+	t.Run("GetSessionsForUser", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetSessionsForUser(t, store)
+	})
+
+	t.Run("DeleteSessionsForUser", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteSessionsForUser(t, store)
+	})
 }
 
 func testCreateAndGetAndDeleteSession(t *testing.T, store store.Store) {
@@ -105,3 +117,53 @@ func testUpdateSession(t *testing.T, store store.Store) {
 	require.NoError(t, err)
 	require.Equal(t, session, got)
 }
+
+func testDeleteSessionsForUser(t *testing.T, store store.Store) {
+	sessions := []*model.Session{
+		{ID: "session-1", Token: "token-1", UserID: "user-1"},
+		{ID: "session-2", Token: "token-2", UserID: "user-1"},
+		{ID: "session-3", Token: "token-3", UserID: "user-2"},
+	}
+	for _, session := range sessions {
+		require.NoError(t, store.CreateSession(session))
+	}
+
+	require.NoError(t, store.DeleteSessionsForUser("user-1"))
+
+	_, err := store.GetSession("token-1", 60*60)
+	require.Error(t, err)
+	_, err = store.GetSession("token-2", 60*60)
+	require.Error(t, err)
+
+	got, err := store.GetSession("token-3", 60*60)
+	require.NoError(t, err)
+	require.Equal(t, "user-2", got.UserID)
+}
+
+func testGetSessionsForUser(t *testing.T, store store.Store) {
+	sessions := []*model.Session{
+		{ID: "session-1", Token: "token-1", UserID: "user-1", Props: map[string]interface{}{model.SessionPropUserAgent: "agent"}},
+		{ID: "session-2", Token: "token-2", UserID: "user-1", Props: map[string]interface{}{}},
+		{ID: "session-3", Token: "token-3", UserID: "user-2", Props: map[string]interface{}{}},
+	}
+	for _, session := range sessions {
+		require.NoError(t, store.CreateSession(session))
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, store.RefreshSession(sessions[1]))
+
+	got, err := store.GetSessionsForUser("user-1", 60*60)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	// the most recently active first
+	require.Equal(t, "session-2", got[0].ID)
+	require.Equal(t, "session-1", got[1].ID)
+	require.Equal(t, "agent", got[1].Props[model.SessionPropUserAgent])
+	require.NotZero(t, got[1].CreateAt)
+	require.Greater(t, got[0].UpdateAt, got[1].UpdateAt)
+
+	got, err = store.GetSessionsForUser("nonexistent", 60*60)
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestShareLinksStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateAndGetShareLinks", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateAndGetShareLinks(t, store)
+	})
+	t.Run("RecordShareLinkAccess", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testRecordShareLinkAccess(t, store)
+	})
+	t.Run("ClaimShareLinkView", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testClaimShareLinkView(t, store)
+	})
+	t.Run("DeleteShareLink", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteShareLink(t, store)
+	})
+}
+
+func testCreateAndGetShareLinks(t *testing.T, store store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+
+	t.Run("invalid link", func(t *testing.T) {
+		link, err := store.CreateShareLink(&model.ShareLink{})
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, link)
+	})
+
+	link1, err := store.CreateShareLink(&model.ShareLink{
+		BoardID:   boardID,
+		Name:      "link 1",
+		CreatedBy: "user-id",
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, link1.ID)
+	require.NotEmpty(t, link1.Token)
+	require.NotZero(t, link1.CreateAt)
+	require.False(t, link1.HasPassword)
+
+	link2, err := store.CreateShareLink(&model.ShareLink{
+		BoardID:    boardID,
+		Name:       "link 2",
+		Password:   "hashed-password",
+		SingleView: true,
+		ExpireAt:   utils.GetMillis() + 1000,
+		CreatedBy:  "user-id",
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, link1.Token, link2.Token)
+	require.True(t, link2.HasPassword)
+
+	_, err = store.CreateShareLink(&model.ShareLink{
+		BoardID:   utils.NewID(utils.IDTypeBoard),
+		CreatedBy: "user-id",
+	})
+	require.NoError(t, err)
+
+	t.Run("GetShareLink", func(t *testing.T) {
+		got, err := store.GetShareLink(link2.ID)
+		require.NoError(t, err)
+		require.Equal(t, link2, got)
+
+		_, err = store.GetShareLink("nonexistent")
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("GetShareLinkByToken", func(t *testing.T) {
+		got, err := store.GetShareLinkByToken(boardID, link1.Token)
+		require.NoError(t, err)
+		require.Equal(t, link1, got)
+
+		_, err = store.GetShareLinkByToken(utils.NewID(utils.IDTypeBoard), link1.Token)
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("GetShareLinksForBoard", func(t *testing.T) {
+		links, err := store.GetShareLinksForBoard(boardID)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []*model.ShareLink{link1, link2}, links)
+
+		links, err = store.GetShareLinksForBoard("nonexistent")
+		require.NoError(t, err)
+		require.Empty(t, links)
+	})
+}
+
+func testRecordShareLinkAccess(t *testing.T, store store.Store) {
+	link, err := store.CreateShareLink(&model.ShareLink{
+		BoardID:   utils.NewID(utils.IDTypeBoard),
+		CreatedBy: "user-id",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, store.RecordShareLinkAccess(link.ID, 100))
+	require.NoError(t, store.RecordShareLinkAccess(link.ID, 200))
+
+	got, err := store.GetShareLink(link.ID)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, got.UseCount)
+	require.EqualValues(t, 100, got.FirstAccessAt)
+	require.EqualValues(t, 200, got.LastAccessAt)
+
+	err = store.RecordShareLinkAccess("nonexistent", 100)
+	require.True(t, model.IsErrNotFound(err))
+}
+
+func testClaimShareLinkView(t *testing.T, store store.Store) {
+	link, err := store.CreateShareLink(&model.ShareLink{
+		BoardID:    utils.NewID(utils.IDTypeBoard),
+		SingleView: true,
+		CreatedBy:  "user-id",
+	})
+	require.NoError(t, err)
+
+	claimed, err := store.ClaimShareLinkView(link.ID, "view-1")
+	require.NoError(t, err)
+	require.True(t, claimed)
+
+	got, err := store.GetShareLink(link.ID)
+	require.NoError(t, err)
+	require.Equal(t, "view-1", got.ViewID)
+
+	t.Run("the same view can claim the link again", func(t *testing.T) {
+		claimed, err := store.ClaimShareLinkView(link.ID, "view-1")
+		require.NoError(t, err)
+		require.True(t, claimed)
+	})
+
+	t.Run("another view can't claim the link", func(t *testing.T) {
+		claimed, err := store.ClaimShareLinkView(link.ID, "view-2")
+		require.NoError(t, err)
+		require.False(t, claimed)
+
+		got, err := store.GetShareLink(link.ID)
+		require.NoError(t, err)
+		require.Equal(t, "view-1", got.ViewID)
+	})
+
+	t.Run("nonexistent link", func(t *testing.T) {
+		_, err := store.ClaimShareLinkView("nonexistent", "view-1")
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testDeleteShareLink(t *testing.T, store store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+	link1, err := store.CreateShareLink(&model.ShareLink{BoardID: boardID, CreatedBy: "user-id"})
+	require.NoError(t, err)
+	link2, err := store.CreateShareLink(&model.ShareLink{BoardID: boardID, CreatedBy: "user-id"})
+	require.NoError(t, err)
+
+	require.NoError(t, store.DeleteShareLink(link1.ID))
+
+	_, err = store.GetShareLink(link1.ID)
+	require.True(t, model.IsErrNotFound(err))
+
+	links, err := store.GetShareLinksForBoard(boardID)
+	require.NoError(t, err)
+	require.Equal(t, []*model.ShareLink{link2}, links)
+
+	err = store.DeleteShareLink(link1.ID)
+	require.True(t, model.IsErrNotFound(err))
+}
@@ -32,6 +32,12 @@ func StoreTestSystemStore(t *testing.T, setup func(t *testing.T) (store.Store, f
 		defer tearDown()
 		testSetGetSystemSettings(t, store)
 	})
+
+	t.Run("ClaimSystemSetting", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testClaimSystemSetting(t, store)
+	})
 }
 
 func testSetGetSystemSettings(t *testing.T, store store.Store) {
@@ -63,3 +69,35 @@ func testSetGetSystemSettings(t *testing.T, store store.Store) {
 		require.Equal(t, "test-value-1", value)
 	})
 }
+
+func testClaimSystemSetting(t *testing.T, store store.Store) {
+	t.Run("Claim a new setting", func(t *testing.T) {
+		claimed, err := store.ClaimSystemSetting("claim-1", "", "value-1")
+		require.NoError(t, err)
+		require.True(t, claimed)
+
+		// the setting exists now
+		claimed, err = store.ClaimSystemSetting("claim-1", "", "value-other")
+		require.NoError(t, err)
+		require.False(t, claimed)
+
+		value, err := store.GetSystemSetting("claim-1")
+		require.NoError(t, err)
+		require.Equal(t, "value-1", value)
+	})
+
+	t.Run("Claim an existing setting", func(t *testing.T) {
+		claimed, err := store.ClaimSystemSetting("claim-1", "value-1", "value-2")
+		require.NoError(t, err)
+		require.True(t, claimed)
+
+		// the previous value is outdated
+		claimed, err = store.ClaimSystemSetting("claim-1", "value-1", "value-other")
+		require.NoError(t, err)
+		require.False(t, claimed)
+
+		value, err := store.GetSystemSetting("claim-1")
+		require.NoError(t, err)
+		require.Equal(t, "value-2", value)
+	})
+}
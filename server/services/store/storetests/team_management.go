@@ -0,0 +1,282 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func StoreTestTeamManagementStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateTeam", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateTeam(t, store)
+	})
+
+	t.Run("PatchAndArchiveTeam", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testPatchAndArchiveTeam(t, store)
+	})
+
+	t.Run("GetTeamsForUser", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetTeamsForUser(t, store)
+	})
+
+	t.Run("TeamMembers", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testTeamMembers(t, store)
+	})
+
+	t.Run("TeamInvites", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testTeamInvites(t, store)
+	})
+
+	t.Run("GetUsersByManagedTeam", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetUsersByManagedTeam(t, store)
+	})
+}
+
+func testCreateTeam(t *testing.T, store store.Store) {
+	t.Run("invalid team", func(t *testing.T) {
+		team, err := store.CreateTeam(&model.Team{Title: "  "}, testUserID)
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, team)
+	})
+
+	t.Run("valid team", func(t *testing.T) {
+		before := utils.GetMillis()
+		team, err := store.CreateTeam(&model.Team{Title: " Marketing "}, testUserID)
+		require.NoError(t, err)
+		require.NotEmpty(t, team.ID)
+		require.NotEmpty(t, team.SignupToken)
+		require.Equal(t, "Marketing", team.Title)
+		require.GreaterOrEqual(t, team.CreateAt, before)
+		require.True(t, team.IsManaged())
+
+		got, err := store.GetTeam(team.ID)
+		require.NoError(t, err)
+		require.Equal(t, team.Title, got.Title)
+		require.Equal(t, team.SignupToken, got.SignupToken)
+
+		// the creator becomes the admin of the team
+		member, err := store.GetTeamMember(team.ID, testUserID)
+		require.NoError(t, err)
+		require.True(t, member.SchemeAdmin)
+
+		got, err = store.GetTeamBySignupToken(team.SignupToken)
+		require.NoError(t, err)
+		require.Equal(t, team.ID, got.ID)
+	})
+
+	t.Run("team created by the system", func(t *testing.T) {
+		team, err := store.CreateTeam(&model.Team{Title: "Provisioned"}, model.SystemUserID)
+		require.NoError(t, err)
+
+		members, err := store.GetTeamMembers(team.ID)
+		require.NoError(t, err)
+		require.Empty(t, members)
+	})
+}
+
+func testPatchAndArchiveTeam(t *testing.T, store store.Store) {
+	team, err := store.CreateTeam(&model.Team{Title: "Sales"}, testUserID)
+	require.NoError(t, err)
+
+	t.Run("rename", func(t *testing.T) {
+		title := "Sales EMEA"
+		patched, err := store.PatchTeam(team.ID, &model.TeamPatch{Title: &title}, "other-user")
+		require.NoError(t, err)
+		require.Equal(t, title, patched.Title)
+		require.Equal(t, "other-user", patched.ModifiedBy)
+
+		empty := ""
+		_, err = store.PatchTeam(team.ID, &model.TeamPatch{Title: &empty}, testUserID)
+		require.True(t, model.IsErrBadRequest(err))
+
+		_, err = store.PatchTeam("nonexistent-id", &model.TeamPatch{Title: &title}, testUserID)
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("archive", func(t *testing.T) {
+		require.NoError(t, store.ArchiveTeam(team.ID, testUserID))
+
+		got, err := store.GetTeam(team.ID)
+		require.NoError(t, err)
+		require.NotZero(t, got.DeleteAt)
+
+		// archived teams can't be archived again or joined with their token
+		require.True(t, model.IsErrNotFound(store.ArchiveTeam(team.ID, testUserID)))
+		_, err = store.GetTeamBySignupToken(team.SignupToken)
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testGetTeamsForUser(t *testing.T, store store.Store) {
+	rootTeam := &model.Team{ID: model.GlobalTeamID, SignupToken: utils.NewID(utils.IDTypeToken)}
+	require.NoError(t, store.UpsertTeamSignupToken(*rootTeam))
+
+	team1, err := store.CreateTeam(&model.Team{Title: "Team 1"}, "user-1")
+	require.NoError(t, err)
+	team2, err := store.CreateTeam(&model.Team{Title: "Team 2"}, "user-2")
+	require.NoError(t, err)
+	archived, err := store.CreateTeam(&model.Team{Title: "Archived"}, "user-1")
+	require.NoError(t, err)
+	require.NoError(t, store.ArchiveTeam(archived.ID, "user-1"))
+
+	teams, err := store.GetTeamsForUser("user-1")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{model.GlobalTeamID, team1.ID}, extractIDs(t, teams))
+
+	teams, err = store.GetTeamsForUser("user-2")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{model.GlobalTeamID, team2.ID}, extractIDs(t, teams))
+
+	teams, err = store.GetTeamsForUser("user-3")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{model.GlobalTeamID}, extractIDs(t, teams))
+}
+
+func testTeamMembers(t *testing.T, store store.Store) {
+	team, err := store.CreateTeam(&model.Team{Title: "Support"}, testUserID)
+	require.NoError(t, err)
+
+	t.Run("add and update a member", func(t *testing.T) {
+		member, err := store.SaveTeamMember(&model.TeamMember{TeamID: team.ID, UserID: "user-1"})
+		require.NoError(t, err)
+		require.False(t, member.SchemeAdmin)
+		require.NotZero(t, member.CreateAt)
+
+		updated, err := store.SaveTeamMember(&model.TeamMember{TeamID: team.ID, UserID: "user-1", SchemeAdmin: true})
+		require.NoError(t, err)
+		require.True(t, updated.SchemeAdmin)
+		require.Equal(t, member.CreateAt, updated.CreateAt)
+
+		members, err := store.GetTeamMembers(team.ID)
+		require.NoError(t, err)
+		require.Len(t, members, 2)
+	})
+
+	t.Run("delete a member", func(t *testing.T) {
+		require.NoError(t, store.DeleteTeamMember(team.ID, "user-1"))
+
+		_, err := store.GetTeamMember(team.ID, "user-1")
+		require.True(t, model.IsErrNotFound(err))
+		require.True(t, model.IsErrNotFound(store.DeleteTeamMember(team.ID, "user-1")))
+	})
+}
+
+func testTeamInvites(t *testing.T, store store.Store) {
+	team, err := store.CreateTeam(&model.Team{Title: "Finance"}, testUserID)
+	require.NoError(t, err)
+
+	t.Run("invalid invite", func(t *testing.T) {
+		_, err := store.CreateTeamInvite(&model.TeamInvite{TeamID: team.ID, Email: "not an email"})
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("create, accept and delete invites", func(t *testing.T) {
+		invite, err := store.CreateTeamInvite(&model.TeamInvite{
+			TeamID:      team.ID,
+			Email:       "Someone@Example.com",
+			SchemeAdmin: true,
+			CreatedBy:   testUserID,
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, invite.ID)
+		require.Equal(t, "someone@example.com", invite.Email)
+		require.Equal(t, invite.CreateAt+model.TeamInviteExpiry.Milliseconds(), invite.ExpireAt)
+
+		other, err := store.CreateTeamInvite(&model.TeamInvite{TeamID: team.ID, CreatedBy: testUserID})
+		require.NoError(t, err)
+
+		invites, err := store.GetTeamInvites(team.ID)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{invite.ID, other.ID}, extractIDs(t, invites))
+
+		member, err := store.AcceptTeamInvite(invite.ID, "user-1")
+		require.NoError(t, err)
+		require.Equal(t, team.ID, member.TeamID)
+		require.True(t, member.SchemeAdmin)
+
+		// accepted invites can't be accepted again and are not pending
+		_, err = store.AcceptTeamInvite(invite.ID, "user-2")
+		require.True(t, model.IsErrBadRequest(err))
+
+		got, err := store.GetTeamInvite(invite.ID)
+		require.NoError(t, err)
+		require.Equal(t, "user-1", got.AcceptedBy)
+		require.NotZero(t, got.AcceptAt)
+
+		invites, err = store.GetTeamInvites(team.ID)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{other.ID}, extractIDs(t, invites))
+
+		require.NoError(t, store.DeleteTeamInvite(other.ID))
+		_, err = store.GetTeamInvite(other.ID)
+		require.True(t, model.IsErrNotFound(err))
+		require.True(t, model.IsErrNotFound(store.DeleteTeamInvite(other.ID)))
+	})
+
+	t.Run("invites with boards and expiry", func(t *testing.T) {
+		expireAt := utils.GetMillis() + 1000*60*60
+		invite, err := store.CreateTeamInvite(&model.TeamInvite{
+			TeamID:    team.ID,
+			Email:     "boards@example.com",
+			BoardIDs:  []string{"board-1", "board-2"},
+			BoardRole: model.BoardRoleViewer,
+			ExpireAt:  expireAt,
+			CreatedBy: testUserID,
+		})
+		require.NoError(t, err)
+
+		got, err := store.GetTeamInvite(invite.ID)
+		require.NoError(t, err)
+		require.Equal(t, []string{"board-1", "board-2"}, got.BoardIDs)
+		require.Equal(t, model.BoardRoleViewer, got.BoardRole)
+		require.Equal(t, expireAt, got.ExpireAt)
+
+		other, err := store.CreateTeamInvite(&model.TeamInvite{TeamID: team.ID, CreatedBy: testUserID})
+		require.NoError(t, err)
+		got, err = store.GetTeamInvite(other.ID)
+		require.NoError(t, err)
+		require.Empty(t, got.BoardIDs)
+		require.Equal(t, model.BoardRoleNone, got.BoardRole)
+	})
+}
+
+func testGetUsersByManagedTeam(t *testing.T, store store.Store) {
+	users := createTestUsers(t, store, 3)
+
+	team, err := store.CreateTeam(&model.Team{Title: "Legal"}, users[0].ID)
+	require.NoError(t, err)
+	_, err = store.SaveTeamMember(&model.TeamMember{TeamID: team.ID, UserID: users[1].ID})
+	require.NoError(t, err)
+
+	got, err := store.GetUsersByTeam(team.ID, "", true, true)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{users[0].ID, users[1].ID}, extractIDs(t, got))
+
+	got, err = store.SearchUsersByTeam(team.ID, "mooncake", "", false, true, true)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{users[0].ID, users[1].ID}, extractIDs(t, got))
+
+	// teams that are not in the store include every user
+	got, err = store.GetUsersByTeam(testTeamID, "", true, true)
+	require.NoError(t, err)
+	require.ElementsMatch(t, extractIDs(t, users), extractIDs(t, got))
+}
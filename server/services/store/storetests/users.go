@@ -52,6 +52,36 @@ func StoreTestUserStore(t *testing.T, setup func(t *testing.T) (store.Store, fun
 		defer tearDown()
 		testPatchUserProps(t, store)
 	})
+
+	t.Run("GuestUsers", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGuestUsers(t, store)
+	})
+
+	t.Run("DirectoryUsers", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDirectoryUsers(t, store)
+	})
+
+	t.Run("EmailVerified", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testUserEmailVerified(t, store)
+	})
+
+	t.Run("GetUsers", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetUsers(t, store)
+	})
+
+	t.Run("UpdateUserRoles", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testUpdateUserRoles(t, store)
+	})
 }
 
 func testGetUsersByTeam(t *testing.T, store store.Store) {
@@ -142,6 +172,27 @@ func testCreateAndGetUser(t *testing.T, store store.Store) {
 		require.ErrorAs(t, err, &nf)
 		require.Nil(t, got)
 	})
+
+	t.Run("GetUserByAuthData", func(t *testing.T) {
+		linked := &model.User{
+			ID:          utils.NewID(utils.IDTypeUser),
+			Username:    "linked",
+			Email:       "linked@email.com",
+			AuthService: model.OidcAuthService,
+			AuthData:    "subject",
+		}
+		_, err := store.CreateUser(linked)
+		require.NoError(t, err)
+
+		got, err := store.GetUserByAuthData(model.OidcAuthService, "subject")
+		require.NoError(t, err)
+		require.Equal(t, linked.ID, got.ID)
+
+		got, err = store.GetUserByAuthData("other", "subject")
+		var nf *model.ErrNotFound
+		require.ErrorAs(t, err, &nf)
+		require.Nil(t, got)
+	})
 }
 
 func testGetUsersList(t *testing.T, store store.Store) {
@@ -246,6 +297,63 @@ func testCreateAndUpdateUser(t *testing.T, store store.Store) {
 		require.Equal(t, user.ID, got.ID)
 		require.Equal(t, newPassword, got.Password)
 	})
+
+	t.Run("UpdateUserMfa", func(t *testing.T) {
+		codes, err := store.GetUserMfaRecoveryCodes(user.ID)
+		require.NoError(t, err)
+		require.Empty(t, codes)
+
+		err = store.UpdateUserMfa(user.ID, "secret", true, []string{"hash1", "hash2"})
+		require.NoError(t, err)
+
+		got, err := store.GetUserByID(user.ID)
+		require.NoError(t, err)
+		require.Equal(t, "secret", got.MfaSecret)
+		require.True(t, got.MfaActive)
+
+		codes, err = store.GetUserMfaRecoveryCodes(user.ID)
+		require.NoError(t, err)
+		require.Equal(t, []string{"hash1", "hash2"}, codes)
+
+		err = store.UpdateUserMfa(user.ID, "", false, nil)
+		require.NoError(t, err)
+
+		got, err = store.GetUserByID(user.ID)
+		require.NoError(t, err)
+		require.Empty(t, got.MfaSecret)
+		require.False(t, got.MfaActive)
+
+		codes, err = store.GetUserMfaRecoveryCodes(user.ID)
+		require.NoError(t, err)
+		require.Empty(t, codes)
+	})
+
+	t.Run("ClaimUserMfaTimeStep", func(t *testing.T) {
+		claimed, err := store.ClaimUserMfaTimeStep(user.ID, 100)
+		require.NoError(t, err)
+		require.True(t, claimed)
+
+		// the same and the previous time steps can't be claimed again
+		claimed, err = store.ClaimUserMfaTimeStep(user.ID, 100)
+		require.NoError(t, err)
+		require.False(t, claimed)
+
+		claimed, err = store.ClaimUserMfaTimeStep(user.ID, 99)
+		require.NoError(t, err)
+		require.False(t, claimed)
+
+		claimed, err = store.ClaimUserMfaTimeStep(user.ID, 101)
+		require.NoError(t, err)
+		require.True(t, claimed)
+	})
+
+	t.Run("UpdateUserMfa nonexistent", func(t *testing.T) {
+		err := store.UpdateUserMfa("nonexistent", "secret", true, nil)
+		require.Error(t, err)
+
+		_, err = store.GetUserMfaRecoveryCodes("nonexistent")
+		require.True(t, model.IsErrNotFound(err))
+	})
 }
 
 func testCreateAndGetRegisteredUserCount(t *testing.T, store store.Store) {
@@ -343,3 +451,292 @@ func testPatchUserProps(t *testing.T, store store.Store) {
 		}
 	}
 }
+
+func testGuestUsers(t *testing.T, store store.Store) {
+	teamID := "team_1"
+	createUser := func(username string) *model.User {
+		user, err := store.CreateUser(&model.User{
+			ID:       utils.NewID(utils.IDTypeUser),
+			Username: username,
+		})
+		require.NoError(t, err)
+		return user
+	}
+
+	guest := createUser("guest")
+	member := createUser("member")
+	stranger := createUser("stranger")
+
+	board, err := store.InsertBoard(&model.Board{
+		ID:     utils.NewID(utils.IDTypeBoard),
+		TeamID: teamID,
+		Type:   model.BoardTypeOpen,
+	}, member.ID)
+	require.NoError(t, err)
+
+	for _, userID := range []string{guest.ID, member.ID} {
+		_, err = store.SaveMember(&model.BoardMember{
+			BoardID:         board.ID,
+			UserID:          userID,
+			SchemeViewer:    true,
+			SchemeCommenter: true,
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("UpdateUserGuest", func(t *testing.T) {
+		expireAt := utils.GetMillis() + 1000*60*60
+		require.NoError(t, store.UpdateUserGuest(guest.ID, true, expireAt))
+
+		got, err := store.GetUserByID(guest.ID)
+		require.NoError(t, err)
+		require.True(t, got.IsGuest)
+		require.Equal(t, expireAt, got.GuestExpireAt)
+	})
+
+	t.Run("UpdateUserGuest nonexistent", func(t *testing.T) {
+		err := store.UpdateUserGuest("nonexistent", true, 0)
+		require.Error(t, err)
+	})
+
+	t.Run("guests only see users sharing a board", func(t *testing.T) {
+		users, err := store.GetUsersByTeam(teamID, guest.ID, false, false)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{guest.ID, member.ID}, extractIDs(t, users))
+
+		users, err = store.GetUsersByTeam(teamID, "", false, false)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{guest.ID, member.ID, stranger.ID}, extractIDs(t, users))
+
+		users, err = store.SearchUsersByTeam(teamID, "s", guest.ID, false, false, false)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{guest.ID}, extractIDs(t, users))
+	})
+
+	t.Run("CanSeeUser", func(t *testing.T) {
+		canSee, err := store.CanSeeUser(guest.ID, member.ID)
+		require.NoError(t, err)
+		require.True(t, canSee)
+
+		canSee, err = store.CanSeeUser(guest.ID, stranger.ID)
+		require.NoError(t, err)
+		require.False(t, canSee)
+
+		canSee, err = store.CanSeeUser(stranger.ID, guest.ID)
+		require.NoError(t, err)
+		require.True(t, canSee)
+	})
+
+	t.Run("converting back to a regular user clears the expiry", func(t *testing.T) {
+		require.NoError(t, store.UpdateUserGuest(guest.ID, false, utils.GetMillis()))
+
+		got, err := store.GetUserByID(guest.ID)
+		require.NoError(t, err)
+		require.False(t, got.IsGuest)
+		require.Zero(t, got.GuestExpireAt)
+
+		canSee, err := store.CanSeeUser(guest.ID, stranger.ID)
+		require.NoError(t, err)
+		require.True(t, canSee)
+	})
+}
+
+func testDirectoryUsers(t *testing.T, store store.Store) {
+	user := &model.User{
+		ID:          utils.NewID(utils.IDTypeUser),
+		Username:    "jdoe",
+		Email:       "jdoe@example.com",
+		Nickname:    "JD",
+		FirstName:   "John",
+		LastName:    "Doe",
+		AuthService: model.LdapAuthService,
+		AuthData:    "jdoe",
+	}
+	_, err := store.CreateUser(user)
+	require.NoError(t, err)
+
+	_, err = store.CreateUser(&model.User{
+		ID:       utils.NewID(utils.IDTypeUser),
+		Username: "native",
+		Email:    "native@example.com",
+	})
+	require.NoError(t, err)
+
+	t.Run("names are stored", func(t *testing.T) {
+		got, err := store.GetUserByID(user.ID)
+		require.NoError(t, err)
+		require.Equal(t, "JD", got.Nickname)
+		require.Equal(t, "John", got.FirstName)
+		require.Equal(t, "Doe", got.LastName)
+
+		got.FirstName = "Johnny"
+		_, err = store.UpdateUser(got)
+		require.NoError(t, err)
+
+		got, err = store.GetUserByID(user.ID)
+		require.NoError(t, err)
+		require.Equal(t, "Johnny", got.FirstName)
+	})
+
+	t.Run("GetUsersByAuthService", func(t *testing.T) {
+		users, err := store.GetUsersByAuthService(model.LdapAuthService)
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		require.Equal(t, user.ID, users[0].ID)
+	})
+
+	t.Run("UpdateUserDeleteAt", func(t *testing.T) {
+		deleteAt := utils.GetMillis()
+		require.NoError(t, store.UpdateUserDeleteAt(user.ID, deleteAt))
+
+		// deactivated users are only found by their auth data
+		_, err := store.GetUserByID(user.ID)
+		require.True(t, model.IsErrNotFound(err))
+
+		got, err := store.GetUserByAuthData(model.LdapAuthService, "jdoe")
+		require.NoError(t, err)
+		require.Equal(t, deleteAt, got.DeleteAt)
+
+		users, err := store.GetUsersByAuthService(model.LdapAuthService)
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		require.Equal(t, deleteAt, users[0].DeleteAt)
+
+		require.NoError(t, store.UpdateUserDeleteAt(user.ID, 0))
+		got, err = store.GetUserByID(user.ID)
+		require.NoError(t, err)
+		require.Zero(t, got.DeleteAt)
+	})
+
+	t.Run("UpdateUserScimDeleted", func(t *testing.T) {
+		require.NoError(t, store.UpdateUserScimDeleted(user.ID, true))
+		require.NoError(t, store.UpdateUserDeleteAt(user.ID, utils.GetMillis()))
+
+		got, err := store.GetUserByAuthData(model.LdapAuthService, "jdoe")
+		require.NoError(t, err)
+		require.True(t, got.ScimDeleted)
+
+		// reactivating the user clears its SCIM deletion
+		require.NoError(t, store.UpdateUserDeleteAt(user.ID, 0))
+		got, err = store.GetUserByID(user.ID)
+		require.NoError(t, err)
+		require.False(t, got.ScimDeleted)
+
+		require.Error(t, store.UpdateUserScimDeleted("nonexistent-id", true))
+	})
+
+	t.Run("UpdateUserDeleteAt nonexistent", func(t *testing.T) {
+		err := store.UpdateUserDeleteAt("nonexistent-id", utils.GetMillis())
+		require.Error(t, err)
+	})
+}
+
+func testUserEmailVerified(t *testing.T, store store.Store) {
+	user, err := store.CreateUser(&model.User{
+		ID:       utils.NewID(utils.IDTypeUser),
+		Username: "unverified",
+		Email:    "unverified@example.com",
+	})
+	require.NoError(t, err)
+
+	got, err := store.GetUserByID(user.ID)
+	require.NoError(t, err)
+	require.False(t, got.EmailVerified)
+
+	require.NoError(t, store.UpdateUserEmailVerified(user.ID, true))
+
+	got, err = store.GetUserByID(user.ID)
+	require.NoError(t, err)
+	require.True(t, got.EmailVerified)
+
+	require.Error(t, store.UpdateUserEmailVerified("nonexistent", true))
+}
+
+func testGetUsers(t *testing.T, store store.Store) {
+	for _, user := range []*model.User{
+		{ID: utils.NewID(utils.IDTypeUser), Username: "carol", Email: "carol@example.com", FirstName: "Carol"},
+		{ID: utils.NewID(utils.IDTypeUser), Username: "alice", Email: "alice@example.com", LastName: "Smith"},
+		{ID: utils.NewID(utils.IDTypeUser), Username: "bob", Email: "bob@sample.com"},
+	} {
+		_, err := store.CreateUser(user)
+		require.NoError(t, err)
+	}
+	bob, err := store.GetUserByUsername("bob")
+	require.NoError(t, err)
+	require.NoError(t, store.UpdateUserDeleteAt(bob.ID, utils.GetMillis()))
+
+	usernames := func(users []*model.User) []string {
+		names := make([]string, 0, len(users))
+		for _, user := range users {
+			names = append(names, user.Username)
+		}
+		return names
+	}
+
+	t.Run("active users sorted by username", func(t *testing.T) {
+		users, err := store.GetUsers(model.QueryUsersOptions{})
+		require.NoError(t, err)
+		require.Equal(t, []string{"alice", "carol"}, usernames(users))
+	})
+
+	t.Run("deactivated users included", func(t *testing.T) {
+		users, err := store.GetUsers(model.QueryUsersOptions{IncludeDeleted: true})
+		require.NoError(t, err)
+		require.Equal(t, []string{"alice", "bob", "carol"}, usernames(users))
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		users, err := store.GetUsers(model.QueryUsersOptions{Username: "carol"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"carol"}, usernames(users))
+
+		users, err = store.GetUsers(model.QueryUsersOptions{UserID: bob.ID, IncludeDeleted: true})
+		require.NoError(t, err)
+		require.Equal(t, []string{"bob"}, usernames(users))
+
+		users, err = store.GetUsers(model.QueryUsersOptions{Email: "bob@sample.com", IncludeDeleted: true})
+		require.NoError(t, err)
+		require.Equal(t, []string{"bob"}, usernames(users))
+
+		users, err = store.GetUsers(model.QueryUsersOptions{Term: "Smi"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"alice"}, usernames(users))
+
+		users, err = store.GetUsers(model.QueryUsersOptions{Term: "example.com"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"alice", "carol"}, usernames(users))
+
+		users, err = store.GetUsers(model.QueryUsersOptions{Username: "nobody"})
+		require.NoError(t, err)
+		require.Empty(t, users)
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		users, err := store.GetUsers(model.QueryUsersOptions{IncludeDeleted: true, Page: 1, PerPage: 2})
+		require.NoError(t, err)
+		require.Equal(t, []string{"carol"}, usernames(users))
+	})
+}
+
+func testUpdateUserRoles(t *testing.T, store store.Store) {
+	user, err := store.CreateUser(&model.User{
+		ID:       utils.NewID(utils.IDTypeUser),
+		Username: "roles",
+		Email:    "roles@example.com",
+	})
+	require.NoError(t, err)
+
+	got, err := store.GetUserByID(user.ID)
+	require.NoError(t, err)
+	require.Empty(t, got.Roles)
+	require.False(t, got.IsSystemAdmin())
+
+	require.NoError(t, store.UpdateUserRoles(user.ID, model.SystemAdminRole))
+
+	got, err = store.GetUserByID(user.ID)
+	require.NoError(t, err)
+	require.Equal(t, model.SystemAdminRole, got.Roles)
+	require.True(t, got.IsSystemAdmin())
+
+	require.Error(t, store.UpdateUserRoles("nonexistent", ""))
+}
@@ -148,6 +148,18 @@ func extractIDs(t *testing.T, arr ...any) []string {
 			for _, bh := range tarr {
 				ids = append(ids, bh.ID)
 			}
+		case []*model.Team:
+			for _, team := range tarr {
+				ids = append(ids, team.ID)
+			}
+		case []*model.TeamInvite:
+			for _, invite := range tarr {
+				ids = append(ids, invite.ID)
+			}
+		case []*model.User:
+			for _, user := range tarr {
+				ids = append(ids, user.ID)
+			}
 		default:
 			t.Errorf("unsupported type %T extracting board ID", item)
 		}
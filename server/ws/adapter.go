@@ -15,6 +15,7 @@ const (
 	websocketActionUpdateMember             = "UPDATE_MEMBER"
 	websocketActionDeleteMember             = "DELETE_MEMBER"
 	websocketActionUpdateBlock              = "UPDATE_BLOCK"
+	websocketActionUpdateBlocks             = "UPDATE_BLOCKS"
 	websocketActionUpdateConfig             = "UPDATE_CLIENT_CONFIG"
 	websocketActionUpdateCategory           = "UPDATE_CATEGORY"
 	websocketActionUpdateCategoryBoard      = "UPDATE_BOARD_CATEGORY"
@@ -26,11 +27,13 @@ const (
 
 type Store interface {
 	GetBlock(blockID string) (*model.Block, error)
+	GetBoard(boardID string) (*model.Board, error)
 	GetMembersForBoard(boardID string) ([]*model.BoardMember, error)
 }
 
 type Adapter interface {
 	BroadcastBlockChange(teamID string, block *model.Block)
+	BroadcastBlockChanges(teamID string, blocks []*model.Block)
 	BroadcastBlockDelete(teamID, blockID, boardID string)
 	BroadcastBoardChange(teamID string, board *model.Board)
 	BroadcastBoardDelete(teamID, boardID string)
@@ -43,4 +46,47 @@ type Adapter interface {
 	BroadcastSubscriptionChange(teamID string, subscription *model.Subscription)
 	BroadcastCategoryReorder(teamID, userID string, categoryOrder []string)
 	BroadcastCategoryBoardsReorder(teamID, userID, categoryID string, boardsOrder []string)
+	CloseSessionConnections(sessionIDs []string)
+}
+
+// getCardAudience returns the IDs of the users that can see a change of a
+// block that belongs to a restricted card: the board admins and the users the
+// card is visible to. It returns nil when every board member can see the
+// change.
+func getCardAudience(store Store, block *model.Block) (map[string]bool, error) {
+	card := block
+	if block.Type != model.TypeCard {
+		if block.ParentID == "" || block.ParentID == block.BoardID {
+			return nil, nil
+		}
+		parent, err := store.GetBlock(block.ParentID)
+		if model.IsErrNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		card = parent
+	}
+
+	if !model.BlockIsRestricted(card) {
+		return nil, nil
+	}
+
+	board, err := store.GetBoard(card.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	members, err := store.GetMembersForBoard(card.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	audience := map[string]bool{}
+	for _, member := range members {
+		if member.SchemeAdmin || model.CardIsVisibleTo(card, board, member.UserID) {
+			audience[member.UserID] = true
+		}
+	}
+	return audience, nil
 }
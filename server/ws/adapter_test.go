@@ -0,0 +1,51 @@
+package ws
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCardAudience(t *testing.T) {
+	th := SetupTestHelper(t)
+
+	board := &model.Board{ID: "board-id"}
+	restrictedCard := &model.Block{
+		ID:        "card-id",
+		BoardID:   board.ID,
+		ParentID:  board.ID,
+		Type:      model.TypeCard,
+		CreatedBy: "creator-id",
+		Fields:    map[string]interface{}{model.CardRestrictedField: true},
+	}
+
+	t.Run("Should return nil for blocks of unrestricted cards", func(t *testing.T) {
+		card := &model.Block{ID: "other-card-id", BoardID: board.ID, ParentID: board.ID, Type: model.TypeCard}
+		text := &model.Block{ID: "text-id", BoardID: board.ID, ParentID: card.ID, Type: model.TypeText}
+		th.store.EXPECT().GetBlock(card.ID).Return(card, nil)
+
+		audience, err := getCardAudience(th.store, card)
+		require.NoError(t, err)
+		require.Nil(t, audience)
+
+		audience, err = getCardAudience(th.store, text)
+		require.NoError(t, err)
+		require.Nil(t, audience)
+	})
+
+	t.Run("Should return the admins and the users that can see a restricted card", func(t *testing.T) {
+		text := &model.Block{ID: "text-id", BoardID: board.ID, ParentID: restrictedCard.ID, Type: model.TypeText}
+		th.store.EXPECT().GetBlock(restrictedCard.ID).Return(restrictedCard, nil)
+		th.store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.store.EXPECT().GetMembersForBoard(board.ID).Return([]*model.BoardMember{
+			{BoardID: board.ID, UserID: "creator-id", SchemeEditor: true},
+			{BoardID: board.ID, UserID: "admin-id", SchemeAdmin: true},
+			{BoardID: board.ID, UserID: "editor-id", SchemeEditor: true},
+		}, nil)
+
+		audience, err := getCardAudience(th.store, text)
+		require.NoError(t, err)
+		require.Equal(t, map[string]bool{"creator-id": true, "admin-id": true}, audience)
+	})
+}
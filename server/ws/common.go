@@ -19,6 +19,13 @@ type UpdateBlockMsg struct {
 	Block  *model.Block `json:"block"`
 }
 
+// UpdateBlocksMsg is sent on batch block updates.
+type UpdateBlocksMsg struct {
+	Action string         `json:"action"`
+	TeamID string         `json:"teamId"`
+	Blocks []*model.Block `json:"blocks"`
+}
+
 // UpdateBoardMsg is sent on block updates.
 type UpdateBoardMsg struct {
 	Action string       `json:"action"`
@@ -53,11 +60,13 @@ type UpdateCardLimitTimestamp struct {
 
 // WebsocketCommand is an incoming command from the client.
 type WebsocketCommand struct {
-	Action    string   `json:"action"`
-	TeamID    string   `json:"teamId"`
-	Token     string   `json:"token"`
-	ReadToken string   `json:"readToken"`
-	BlockIDs  []string `json:"blockIds"`
+	Action            string   `json:"action"`
+	TeamID            string   `json:"teamId"`
+	Token             string   `json:"token"`
+	ReadToken         string   `json:"readToken"`
+	ReadTokenPassword string   `json:"readTokenPassword"`
+	ReadTokenViewID   string   `json:"readTokenViewId"`
+	BlockIDs          []string `json:"blockIds"`
 }
 
 type CategoryReorderMessage struct {
@@ -318,6 +318,14 @@ func commandFromRequest(req *mmModel.WebSocketRequest) (*WebsocketCommand, error
 		c.ReadToken = readToken.(string)
 	}
 
+	if readTokenPassword, ok := req.Data["readTokenPassword"]; ok {
+		c.ReadTokenPassword = readTokenPassword.(string)
+	}
+
+	if readTokenViewID, ok := req.Data["readTokenViewId"]; ok {
+		c.ReadTokenViewID = readTokenViewID.(string)
+	}
+
 	if blockIDs, ok := req.Data["blockIds"]; ok {
 		c.BlockIDs = blockIDs.([]string)
 	}
@@ -453,6 +461,41 @@ func (pa *PluginAdapter) sendBoardMessage(teamID, boardID string, payload map[st
 	pa.sendBoardMessageSkipCluster(teamID, boardID, payload, ensureUserIDs...)
 }
 
+// sendRestrictedBoardMessageSkipCluster sends a message to the users that
+// are subscribed to the board's team, are members of it and are in the
+// allowed users.
+func (pa *PluginAdapter) sendRestrictedBoardMessageSkipCluster(teamID, boardID string, payload map[string]interface{}, allowedUserIDs []string) {
+	allowed := make(map[string]bool, len(allowedUserIDs))
+	for _, userID := range allowedUserIDs {
+		allowed[userID] = true
+	}
+
+	userIDs := []string{}
+	for _, userID := range pa.getUserIDsForTeamAndBoard(teamID, boardID) {
+		if allowed[userID] {
+			userIDs = append(userIDs, userID)
+		}
+	}
+	pa.sendUserMessageSkipCluster(websocketActionUpdateBoard, payload, userIDs...)
+}
+
+// sendRestrictedBoardMessage sends and propagates a board message that
+// only the allowed users can receive.
+func (pa *PluginAdapter) sendRestrictedBoardMessage(teamID, boardID string, payload map[string]interface{}, allowedUserIDs []string) {
+	go func() {
+		clusterMessage := &ClusterMessage{
+			TeamID:          teamID,
+			BoardID:         boardID,
+			Payload:         payload,
+			RestrictToUsers: allowedUserIDs,
+		}
+
+		pa.sendMessageToCluster(clusterMessage)
+	}()
+
+	pa.sendRestrictedBoardMessageSkipCluster(teamID, boardID, payload, allowedUserIDs)
+}
+
 func (pa *PluginAdapter) BroadcastBlockChange(teamID string, block *model.Block) {
 	pa.logger.Trace("BroadcastingBlockChange",
 		mlog.String("teamID", teamID),
@@ -466,9 +509,70 @@ func (pa *PluginAdapter) BroadcastBlockChange(teamID string, block *model.Block)
 		Block:  block,
 	}
 
+	audience, err := getCardAudience(pa.store, block)
+	if err != nil {
+		pa.logger.Error("error getting the audience of a block change",
+			mlog.String("teamID", teamID),
+			mlog.String("blockID", block.ID),
+			mlog.Err(err),
+		)
+		return
+	}
+
+	if audience != nil {
+		userIDs := make([]string, 0, len(audience))
+		for userID := range audience {
+			userIDs = append(userIDs, userID)
+		}
+		pa.sendRestrictedBoardMessage(teamID, block.BoardID, utils.StructToMap(message), userIDs)
+		return
+	}
+
 	pa.sendBoardMessage(teamID, block.BoardID, utils.StructToMap(message))
 }
 
+// BroadcastBlockChanges sends one message per board with the changed blocks
+// every member can see. The blocks of restricted cards are sent on their own
+// to their audience.
+func (pa *PluginAdapter) BroadcastBlockChanges(teamID string, blocks []*model.Block) {
+	pa.logger.Trace("BroadcastingBlockChanges",
+		mlog.String("teamID", teamID),
+		mlog.Int("blockCount", len(blocks)),
+	)
+
+	boardIDs := []string{}
+	blocksByBoard := map[string][]*model.Block{}
+	for _, block := range blocks {
+		audience, err := getCardAudience(pa.store, block)
+		if err != nil {
+			pa.logger.Error("error getting the audience of a block change",
+				mlog.String("teamID", teamID),
+				mlog.String("blockID", block.ID),
+				mlog.Err(err),
+			)
+			continue
+		}
+		if audience != nil {
+			pa.BroadcastBlockChange(teamID, block)
+			continue
+		}
+
+		if _, ok := blocksByBoard[block.BoardID]; !ok {
+			boardIDs = append(boardIDs, block.BoardID)
+		}
+		blocksByBoard[block.BoardID] = append(blocksByBoard[block.BoardID], block)
+	}
+
+	for _, boardID := range boardIDs {
+		message := UpdateBlocksMsg{
+			Action: websocketActionUpdateBlocks,
+			TeamID: teamID,
+			Blocks: blocksByBoard[boardID],
+		}
+		pa.sendBoardMessage(teamID, boardID, utils.StructToMap(message))
+	}
+}
+
 func (pa *PluginAdapter) BroadcastCategoryChange(category model.Category) {
 	pa.logger.Debug("BroadcastCategoryChange",
 		mlog.String("userID", category.UserID),
@@ -673,3 +777,8 @@ func (pa *PluginAdapter) BroadcastCardLimitTimestampChange(cardLimitTimestamp in
 
 	pa.sendMessageToAll(websocketActionUpdateCardLimitTimestamp, utils.StructToMap(message))
 }
+
+// CloseSessionConnections does nothing, the sessions and their websocket
+// connections are managed by the Mattermost server.
+func (pa *PluginAdapter) CloseSessionConnections(sessionIDs []string) {
+}
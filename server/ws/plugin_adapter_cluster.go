@@ -13,6 +13,9 @@ type ClusterMessage struct {
 	UserID      string
 	Payload     map[string]interface{}
 	EnsureUsers []string
+
+	// RestrictToUsers limits a board message to these users when set.
+	RestrictToUsers []string
 }
 
 func (pa *PluginAdapter) sendMessageToCluster(clusterMessage *ClusterMessage) {
@@ -51,6 +54,11 @@ func (pa *PluginAdapter) HandleClusterEvent(ev mmModel.PluginClusterEvent) {
 		return
 	}
 
+	if clusterMessage.BoardID != "" && clusterMessage.RestrictToUsers != nil {
+		pa.sendRestrictedBoardMessageSkipCluster(clusterMessage.TeamID, clusterMessage.BoardID, clusterMessage.Payload, clusterMessage.RestrictToUsers)
+		return
+	}
+
 	if clusterMessage.BoardID != "" {
 		pa.sendBoardMessageSkipCluster(clusterMessage.TeamID, clusterMessage.BoardID, clusterMessage.Payload, clusterMessage.EnsureUsers...)
 		return
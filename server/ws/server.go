@@ -3,12 +3,14 @@ package ws
 import (
 	"encoding/json"
 	"net/http"
+	"strings"
 	"sync"
 
 	"github.com/gorilla/mux"
 	"github.com/gorilla/websocket"
 	"github.com/mattermost/focalboard/server/auth"
 	"github.com/mattermost/focalboard/server/model"
+	serviceauth "github.com/mattermost/focalboard/server/services/auth"
 	"github.com/mattermost/focalboard/server/utils"
 
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
@@ -56,11 +58,12 @@ type Server struct {
 }
 
 type websocketSession struct {
-	conn   *websocket.Conn
-	userID string
-	mu     sync.Mutex
-	teams  []string
-	blocks []string
+	conn      *websocket.Conn
+	userID    string
+	sessionID string
+	mu        sync.Mutex
+	teams     []string
+	blocks    []string
 }
 
 func (wss *websocketSession) isAuthenticated() bool {
@@ -110,6 +113,11 @@ func (ws *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 
 	if ws.isMattermostAuth {
 		wsSession.userID = r.Header.Get("Mattermost-User-Id")
+	} else if token, _ := serviceauth.ParseAuthTokenFromRequest(r); strings.HasPrefix(token, model.AccessTokenPrefix) {
+		// scripts can authenticate the upgrade request with a personal
+		// access token. Session cookies aren't used here, as browsers
+		// send them with cross-site websocket requests too.
+		wsSession.userID, wsSession.sessionID = ws.getSessionForToken(token)
 	}
 
 	ws.addListener(wsSession)
@@ -270,7 +278,10 @@ func (ws *Server) isCommandReadTokenValid(command WebsocketCommand) bool {
 	}
 
 	// the read token must be valid for the board
-	isValid, err := ws.auth.IsValidReadToken(boardID, command.ReadToken)
+	isValid, err := ws.auth.IsValidReadToken(boardID, command.ReadToken, model.ShareLinkCredentials{
+		Password: command.ReadTokenPassword,
+		ViewID:   command.ReadTokenViewID,
+	})
 	if err != nil {
 		ws.logger.Error(`ERROR when checking token validity`,
 			mlog.String("teamID", command.TeamID),
@@ -415,21 +426,23 @@ func (ws *Server) removeListenerFromBlock(listener *websocketSession, blockID st
 	listener.blocks = newListenerBlocks
 }
 
-func (ws *Server) getUserIDForToken(token string) string {
+// getSessionForToken returns the IDs of the user and of the session the
+// token authenticates, or empty IDs if the token isn't valid.
+func (ws *Server) getSessionForToken(token string) (string, string) {
 	if len(ws.singleUserToken) > 0 {
 		if token == ws.singleUserToken {
-			return model.SingleUser
+			return model.SingleUser, ""
 		} else {
-			return ""
+			return "", ""
 		}
 	}
 
 	session, err := ws.auth.GetSession(token)
 	if session == nil || err != nil {
-		return ""
+		return "", ""
 	}
 
-	return session.UserID
+	return session.UserID, session.ID
 }
 
 func (ws *Server) authenticateListener(wsSession *websocketSession, token string) {
@@ -448,7 +461,7 @@ func (ws *Server) authenticateListener(wsSession *websocketSession, token string
 	}
 
 	// Authenticate session
-	userID := ws.getUserIDForToken(token)
+	userID, sessionID := ws.getSessionForToken(token)
 	if userID == "" {
 		wsSession.conn.Close()
 		return
@@ -456,9 +469,38 @@ func (ws *Server) authenticateListener(wsSession *websocketSession, token string
 
 	// Authenticated
 	wsSession.userID = userID
+	wsSession.sessionID = sessionID
 	ws.logger.Debug("authenticateListener: Authenticated", mlog.String("userID", userID), mlog.Stringer("client", wsSession.conn.RemoteAddr()))
 }
 
+// CloseSessionConnections closes the websocket connections authenticated
+// with the sessions, so revoked sessions stop receiving updates.
+func (ws *Server) CloseSessionConnections(sessionIDs []string) {
+	revoked := make(map[string]bool, len(sessionIDs))
+	for _, sessionID := range sessionIDs {
+		revoked[sessionID] = true
+	}
+
+	ws.mu.RLock()
+	listeners := []*websocketSession{}
+	for listener := range ws.listeners {
+		if listener.sessionID != "" && revoked[listener.sessionID] {
+			listeners = append(listeners, listener)
+		}
+	}
+	ws.mu.RUnlock()
+
+	for _, listener := range listeners {
+		ws.logger.Debug("Closing the websocket connection of a revoked session",
+			mlog.String("userID", listener.userID),
+			mlog.Stringer("client", listener.conn.RemoteAddr()),
+		)
+		listener.mu.Lock()
+		listener.conn.Close()
+		listener.mu.Unlock()
+	}
+}
+
 // getListenersForBlock returns the listeners subscribed to a
 // block changes.
 func (ws *Server) getListenersForBlock(blockID string) []*websocketSession {
@@ -535,6 +577,16 @@ func (ws *Server) BroadcastBlockChange(teamID string, block *model.Block) {
 		Block:  block,
 	}
 
+	audience, err := getCardAudience(ws.store, block)
+	if err != nil {
+		ws.logger.Error("error getting the audience of a block change",
+			mlog.String("teamID", teamID),
+			mlog.String("blockID", block.ID),
+			mlog.Err(err),
+		)
+		return
+	}
+
 	listeners := ws.getListenersForTeamAndBoard(teamID, block.BoardID)
 	ws.logger.Trace("listener(s) for teamID",
 		mlog.Int("listener_count", len(listeners)),
@@ -551,6 +603,10 @@ func (ws *Server) BroadcastBlockChange(teamID string, block *model.Block) {
 	}
 
 	for _, listener := range listeners {
+		if audience != nil && !audience[listener.userID] {
+			continue
+		}
+
 		ws.logger.Debug("Broadcast block change",
 			mlog.String("teamID", teamID),
 			mlog.String("blockID", block.ID),
@@ -565,6 +621,67 @@ func (ws *Server) BroadcastBlockChange(teamID string, block *model.Block) {
 	}
 }
 
+// BroadcastBlockChanges broadcasts the changes of several blocks to clients,
+// sending a single message with the blocks each client can see.
+func (ws *Server) BroadcastBlockChanges(teamID string, blocks []*model.Block) {
+	listenersByBoard := map[string][]*websocketSession{}
+	blocksByListener := map[*websocketSession][]*model.Block{}
+	listeners := []*websocketSession{}
+
+	for _, block := range blocks {
+		audience, err := getCardAudience(ws.store, block)
+		if err != nil {
+			ws.logger.Error("error getting the audience of a block change",
+				mlog.String("teamID", teamID),
+				mlog.String("blockID", block.ID),
+				mlog.Err(err),
+			)
+			continue
+		}
+
+		boardListeners, ok := listenersByBoard[block.BoardID]
+		if !ok {
+			boardListeners = ws.getListenersForTeamAndBoard(teamID, block.BoardID)
+			listenersByBoard[block.BoardID] = boardListeners
+		}
+
+		blockListeners := append([]*websocketSession{}, boardListeners...)
+		blockListeners = append(blockListeners, ws.getListenersForBlock(block.ID)...)
+		blockListeners = append(blockListeners, ws.getListenersForBlock(block.ParentID)...)
+
+		notified := map[*websocketSession]bool{}
+		for _, listener := range blockListeners {
+			if notified[listener] || (audience != nil && !audience[listener.userID]) {
+				continue
+			}
+			notified[listener] = true
+
+			if _, ok := blocksByListener[listener]; !ok {
+				listeners = append(listeners, listener)
+			}
+			blocksByListener[listener] = append(blocksByListener[listener], block)
+		}
+	}
+
+	for _, listener := range listeners {
+		ws.logger.Debug("Broadcast block changes",
+			mlog.String("teamID", teamID),
+			mlog.Int("blockCount", len(blocksByListener[listener])),
+			mlog.Stringer("remoteAddr", listener.conn.RemoteAddr()),
+		)
+
+		message := UpdateBlocksMsg{
+			Action: websocketActionUpdateBlocks,
+			TeamID: teamID,
+			Blocks: blocksByListener[listener],
+		}
+		if err := listener.WriteJSON(message); err != nil {
+			ws.logger.Error("broadcast error", mlog.Err(err))
+			listener.conn.Close()
+		}
+	}
+}
+
 func (ws *Server) BroadcastCategoryChange(category model.Category) {
 	message := UpdateCategoryMessage{
 		Action:   websocketActionUpdateCategory,
@@ -264,20 +264,23 @@ func TestBlocksSubscription(t *testing.T) {
 	})
 }
 
-func TestGetUserIDForTokenInSingleUserMode(t *testing.T) {
+func TestGetSessionForTokenInSingleUserMode(t *testing.T) {
 	singleUserToken := "single-user-token"
 	server := NewServer(&auth.Auth{}, "token", false, &mlog.Logger{}, nil)
 	server.singleUserToken = singleUserToken
 
 	t.Run("Should return nothing if the token is empty", func(t *testing.T) {
-		require.Empty(t, server.getUserIDForToken(""))
+		userID, _ := server.getSessionForToken("")
+		require.Empty(t, userID)
 	})
 
 	t.Run("Should return nothing if the token is invalid", func(t *testing.T) {
-		require.Empty(t, server.getUserIDForToken("invalid-token"))
+		userID, _ := server.getSessionForToken("invalid-token")
+		require.Empty(t, userID)
 	})
 
 	t.Run("Should return the single user ID if the token is correct", func(t *testing.T) {
-		require.Equal(t, model.SingleUser, server.getUserIDForToken(singleUserToken))
+		userID, _ := server.getSessionForToken(singleUserToken)
+		require.Equal(t, model.SingleUser, userID)
 	})
 }
@@ -6,6 +6,7 @@ import {Block, createBlock} from './block'
 type CardFields = {
     icon?: string
     isTemplate?: boolean
+    archivedAt?: number
     properties: Record<string, string | string[]>
     contentOrder: Array<string | string[]>
 }
@@ -35,6 +36,7 @@ function createCard(block?: Block): Card {
             properties: {...(block?.fields.properties || {})},
             contentOrder,
             isTemplate: block?.fields.isTemplate || false,
+            ...(block?.fields.archivedAt ? {archivedAt: block.fields.archivedAt} : {}),
         },
     }
 }
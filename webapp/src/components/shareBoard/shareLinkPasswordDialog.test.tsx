@@ -0,0 +1,69 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+import React from 'react'
+
+import {render, waitFor} from '@testing-library/react'
+
+import userEvent from '@testing-library/user-event'
+
+import {mocked} from 'jest-mock'
+
+import {wrapIntl} from '../../testUtils'
+import {TestBlockFactory} from '../../test/testBlockFactory'
+
+import octoClient from '../../octoClient'
+import {Utils} from '../../utils'
+
+import ShareLinkPasswordDialog from './shareLinkPasswordDialog'
+
+jest.mock('../../octoClient')
+const mockedOctoClient = mocked(octoClient, true)
+
+describe('components/shareBoard/ShareLinkPasswordDialog', () => {
+    beforeEach(() => {
+        jest.clearAllMocks()
+        Utils.setReadTokenPassword('')
+    })
+
+    it('should grant access with the right password', async () => {
+        mockedOctoClient.getBoard.mockResolvedValue(TestBlockFactory.createBoard())
+        const onAccessGranted = jest.fn()
+
+        const {container} = render(wrapIntl(
+            <ShareLinkPasswordDialog
+                boardId='board-id'
+                onAccessGranted={onAccessGranted}
+                onClose={jest.fn()}
+            />,
+        ))
+
+        const input = container.querySelector('.ShareLinkPasswordDialog input')
+        expect(input).toBeTruthy()
+        userEvent.type(input as Element, 'secret{enter}')
+
+        await waitFor(() => expect(onAccessGranted).toBeCalledTimes(1))
+        expect(mockedOctoClient.getBoard).toBeCalledWith('board-id')
+        expect(Utils.getReadTokenPassword()).toBe('secret')
+    })
+
+    it('should show an error with a wrong password', async () => {
+        mockedOctoClient.getBoard.mockResolvedValue(undefined)
+        const onAccessGranted = jest.fn()
+
+        const {container} = render(wrapIntl(
+            <ShareLinkPasswordDialog
+                boardId='board-id'
+                onAccessGranted={onAccessGranted}
+                onClose={jest.fn()}
+            />,
+        ))
+
+        const input = container.querySelector('.ShareLinkPasswordDialog input')
+        userEvent.type(input as Element, 'wrong{enter}')
+
+        await waitFor(() => expect(container.querySelector('.ShareLinkPasswordDialog .error')).toBeTruthy())
+        expect(onAccessGranted).not.toBeCalled()
+        expect(Utils.getReadTokenPassword()).toBe('')
+    })
+})
@@ -0,0 +1,116 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+import React, {useState, KeyboardEvent} from 'react'
+import {FormattedMessage, useIntl} from 'react-intl'
+
+import octoClient from '../../octoClient'
+import {Utils} from '../../utils'
+
+import Dialog from '../dialog'
+import Button from '../../widgets/buttons/button'
+
+import './shareLinkPasswordDialog.scss'
+
+type Props = {
+    boardId: string
+    onAccessGranted: () => void
+    onClose: () => void
+}
+
+// ShareLinkPasswordDialog asks for the password of a protected share link,
+// and checks it by fetching the shared board.
+const ShareLinkPasswordDialog = (props: Props): JSX.Element => {
+    const intl = useIntl()
+    const [password, setPassword] = useState('')
+    const [checking, setChecking] = useState(false)
+    const [invalidPassword, setInvalidPassword] = useState(false)
+
+    const onSubmit = async () => {
+        if (!password || checking) {
+            return
+        }
+
+        setChecking(true)
+        Utils.setReadTokenPassword(password)
+        const board = await octoClient.getBoard(props.boardId)
+        setChecking(false)
+
+        if (!board) {
+            Utils.setReadTokenPassword('')
+            setInvalidPassword(true)
+            return
+        }
+        props.onAccessGranted()
+    }
+
+    const handleKeypress = (e: KeyboardEvent) => {
+        if (e.key === 'Enter') {
+            onSubmit()
+        }
+    }
+
+    return (
+        <Dialog
+            title={
+                <FormattedMessage
+                    id='ShareLinkPasswordDialog.title'
+                    defaultMessage='Password required'
+                />
+            }
+            className='ShareLinkPasswordDialogModal'
+            onClose={props.onClose}
+        >
+            <div className='ShareLinkPasswordDialog'>
+                <div className='text'>
+                    <FormattedMessage
+                        id='ShareLinkPasswordDialog.text'
+                        defaultMessage='This board is protected by a password, or its link is no longer valid. Enter the password to view the board.'
+                    />
+                </div>
+                <input
+                    type='password'
+                    placeholder={intl.formatMessage({id: 'ShareLinkPasswordDialog.placeholder', defaultMessage: 'Password'})}
+                    value={password}
+                    onChange={(e) => {
+                        setPassword(e.target.value)
+                        setInvalidPassword(false)
+                    }}
+                    autoFocus={true}
+                    onKeyUp={handleKeypress}
+                />
+                {invalidPassword &&
+                    <div className='error'>
+                        <FormattedMessage
+                            id='ShareLinkPasswordDialog.invalid-password'
+                            defaultMessage='The password is incorrect, or too many attempts were made. Try again later.'
+                        />
+                    </div>}
+                <div className='actions'>
+                    <Button
+                        size={'medium'}
+                        onClick={props.onClose}
+                    >
+                        <FormattedMessage
+                            id='error.go-login'
+                            defaultMessage='Log in'
+                        />
+                    </Button>
+                    <Button
+                        size={'medium'}
+                        filled={Boolean(password)}
+                        onClick={onSubmit}
+                        disabled={!password || checking}
+                    >
+                        <FormattedMessage
+                            id='ShareLinkPasswordDialog.submit'
+                            defaultMessage='View board'
+                        />
+                    </Button>
+                </div>
+            </div>
+        </Dialog>
+    )
+}
+
+export default ShareLinkPasswordDialog
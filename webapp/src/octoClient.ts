@@ -166,6 +166,8 @@ class OctoClient {
             'Content-Type': 'application/json',
             Authorization: this.token ? 'Bearer ' + this.token : '',
             'X-Requested-With': 'XMLHttpRequest',
+            ...(Utils.getReadToken() ? {'X-Read-Token-View-Id': Utils.getReadTokenViewId()} : {}),
+            ...(Utils.getReadToken() && Utils.getReadTokenPassword() ? {'X-Read-Token-Password': Utils.getReadTokenPassword()} : {}),
         }
     }
 
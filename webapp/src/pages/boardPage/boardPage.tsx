@@ -29,6 +29,7 @@ import {
 } from '../../store/boards'
 import {getCurrentViewId, setCurrent as setCurrentView, updateViews} from '../../store/views'
 import ConfirmationDialog from '../../components/confirmationDialogBox'
+import ShareLinkPasswordDialog from '../../components/shareBoard/shareLinkPasswordDialog'
 import {initialLoad, initialReadOnlyLoad, loadBoardData} from '../../store/initialLoad'
 import {useAppSelector, useAppDispatch} from '../../store/hooks'
 import {setTeam} from '../../store/teams'
@@ -79,6 +80,7 @@ const BoardPage = (props: Props): JSX.Element => {
     const hiddenBoardIDs = useAppSelector(getHiddenBoardIDs)
     const category = useAppSelector(getCategoryOfBoard(activeBoardId))
     const [showJoinBoardDialog, setShowJoinBoardDialog] = useState<boolean>(false)
+    const [showShareLinkPasswordDialog, setShowShareLinkPasswordDialog] = useState<boolean>(false)
     const history = useHistory()
 
     // if we're in a legacy route and not showing a shared board,
@@ -209,8 +211,21 @@ const BoardPage = (props: Props): JSX.Element => {
         }))
     }, [])
 
+    // a shared board that can't be fetched may be protected by a password,
+    // which is asked for before loading the board
+    const loadBoard = async (boardId: string) => {
+        if (props.readonly && boardId && !Utils.getReadTokenPassword()) {
+            const board = await octoClient.getBoard(boardId)
+            if (!board) {
+                setShowShareLinkPasswordDialog(true)
+                return
+            }
+        }
+        dispatch(loadAction(boardId))
+    }
+
     useEffect(() => {
-        dispatch(loadAction(match.params.boardId))
+        loadBoard(match.params.boardId)
 
         if (match.params.boardId) {
             // set the active board
@@ -280,6 +295,18 @@ const BoardPage = (props: Props): JSX.Element => {
                     }}
                 />}
 
+            {showShareLinkPasswordDialog &&
+                <ShareLinkPasswordDialog
+                    boardId={match.params.boardId}
+                    onAccessGranted={() => {
+                        setShowShareLinkPasswordDialog(false)
+                        dispatch(loadAction(match.params.boardId))
+                    }}
+                    onClose={() => {
+                        window.location.href = window.location.origin
+                    }}
+                />}
+
             {!showJoinBoardDialog &&
                 <div className='BoardPage'>
                     {!props.new && <TeamToBoardAndViewRedirect/>}
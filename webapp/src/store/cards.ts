@@ -96,7 +96,8 @@ const cardsSlice = createSlice({
         },
         updateCards: (state: CardsState, action: PayloadAction<Card[]>) => {
             for (const card of action.payload) {
-                if (card.deleteAt !== 0) {
+                // archived cards are hidden from the board like deleted ones
+                if (card.deleteAt !== 0 || card.fields.archivedAt) {
                     delete state.cards[card.id]
                     delete state.templates[card.id]
                 } else if (card.fields.isTemplate) {
@@ -694,6 +694,29 @@ class Utils {
         return readToken
     }
 
+    // the view id is sent along with the read token, so that single view
+    // share links can be used by every request of the current page view
+    private static readTokenViewId = ''
+
+    static getReadTokenViewId(): string {
+        if (!Utils.readTokenViewId) {
+            Utils.readTokenViewId = Utils.createGuid(IDType.View)
+        }
+        return Utils.readTokenViewId
+    }
+
+    // the password of a protected share link is kept in memory only, and
+    // sent along with the read token
+    private static readTokenPassword = ''
+
+    static getReadTokenPassword(): string {
+        return Utils.readTokenPassword
+    }
+
+    static setReadTokenPassword(password: string): void {
+        Utils.readTokenPassword = password
+    }
+
     static generateClassName(conditions: Record<string, boolean>): string {
         return Object.entries(conditions).map(([className, condition]) => (condition ? className : '')).filter((className) => className !== '').join(' ')
     }
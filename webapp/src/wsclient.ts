@@ -14,6 +14,8 @@ type WSCommand = {
     action: string
     teamId?: string
     readToken?: string
+    readTokenViewId?: string
+    readTokenPassword?: string
     blockIds?: string[]
 }
 
@@ -21,6 +23,7 @@ type WSCommand = {
 export type WSMessage = {
     action?: string
     block?: Block
+    blocks?: Block[]
     board?: Board
     category?: Category
     blockCategories?: BoardCategoryWebsocketData[]
@@ -35,6 +38,7 @@ export const ACTION_UPDATE_BOARD = 'UPDATE_BOARD'
 export const ACTION_UPDATE_MEMBER = 'UPDATE_MEMBER'
 export const ACTION_DELETE_MEMBER = 'DELETE_MEMBER'
 export const ACTION_UPDATE_BLOCK = 'UPDATE_BLOCK'
+export const ACTION_UPDATE_BLOCKS = 'UPDATE_BLOCKS'
 export const ACTION_AUTH = 'AUTH'
 export const ACTION_SUBSCRIBE_BLOCKS = 'SUBSCRIBE_BLOCKS'
 export const ACTION_SUBSCRIBE_TEAM = 'SUBSCRIBE_TEAM'
@@ -477,6 +481,11 @@ class WSClient {
                 case ACTION_UPDATE_BLOCK:
                     this.updateHandler(message)
                     break
+                case ACTION_UPDATE_BLOCKS:
+                    for (const block of message.blocks || []) {
+                        this.updateHandler({action: ACTION_UPDATE_BLOCK, teamId: message.teamId, block})
+                    }
+                    break
                 case ACTION_UPDATE_CATEGORY:
                     this.updateHandler(message)
                     break
@@ -644,6 +653,8 @@ class WSClient {
             blockIds,
             teamId,
             readToken,
+            readTokenViewId: readToken ? Utils.getReadTokenViewId() : undefined,
+            readTokenPassword: readToken ? Utils.getReadTokenPassword() || undefined : undefined,
         }
 
         this.sendCommand(command)
@@ -660,6 +671,8 @@ class WSClient {
             blockIds,
             teamId,
             readToken,
+            readTokenViewId: readToken ? Utils.getReadTokenViewId() : undefined,
+            readTokenPassword: readToken ? Utils.getReadTokenPassword() || undefined : undefined,
         }
 
         this.sendCommand(command)